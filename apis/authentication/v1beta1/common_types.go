@@ -21,5 +21,9 @@ type AuthParams struct {
 	APIServer string  `json:"apiServer,omitempty"`
 	ProxyURL  *string `json:"proxyURL,omitempty"`
 
-	AwsConfig *AwsConfig `json:"awsConfig,omitempty"`
+	Signer   *SignerInfo         `json:"signer,omitempty"`
+	Rotation *CredentialRotation `json:"rotation,omitempty"`
+
+	AwsConfig  *AwsConfig  `json:"awsConfig,omitempty"`
+	OIDCConfig *OIDCConfig `json:"oidcConfig,omitempty"`
 }
@@ -0,0 +1,52 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// CredentialPolicy defines the lifecycle of the credentials issued to a consumer cluster.
+type CredentialPolicy struct {
+	// Validity is the requested validity of the credentials issued to the consumer cluster.
+	// If not set, the default of the signer backend applies. The validity of the OIDC tokens is decided by the OIDC issuer.
+	// +optional
+	Validity *metav1.Duration `json:"validity,omitempty"`
+	// RenewBefore is how long before their expiration the credentials are renewed by the consumer cluster.
+	// If not set, the credentials are renewed once they reach 2/3 of their lifetime.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// CredentialRotation contains the rotation parameters of the credentials, as defined by the provider cluster.
+type CredentialRotation struct {
+	// Generation is the generation of the credentials. It is increased each time the credentials are revoked,
+	// and it is part of the subject the credentials are bound to.
+	Generation int64 `json:"generation,omitempty"`
+	// RenewBefore is how long before their expiration the credentials must be renewed.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// CredentialsStatus contains the status of the credentials issued to a consumer cluster.
+type CredentialsStatus struct {
+	// Generation is the generation of the credentials, increased each time they are revoked.
+	Generation int64 `json:"generation,omitempty"`
+	// Subject is the user the control plane permissions of the consumer cluster are bound to.
+	Subject string `json:"subject,omitempty"`
+	// IssuedAt is the time the current control plane credentials were issued.
+	IssuedAt *metav1.Time `json:"issuedAt,omitempty"`
+	// ExpiresAt is the time the current control plane credentials expire.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// RevokedAt is the last time the credentials were revoked.
+	RevokedAt *metav1.Time `json:"revokedAt,omitempty"`
+}
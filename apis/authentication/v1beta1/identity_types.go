@@ -65,6 +65,8 @@ type IdentitySpec struct {
 type IdentityStatus struct {
 	// KubeconfigSecretRef contains the reference to the secret containing the kubeconfig to access the provider cluster.
 	KubeconfigSecretRef *corev1.LocalObjectReference `json:"kubeconfigSecretRef,omitempty"`
+	// Signer contains the information about the signer which issued the certificate of the identity.
+	Signer *SignerInfo `json:"signer,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -72,6 +74,7 @@ type IdentityStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
 // +kubebuilder:printcolumn:name="KubeconfigSecret",type=string,JSONPath=`.status.kubeconfigSecretRef.name`,priority=1
+// +kubebuilder:printcolumn:name="Signer",type=string,JSONPath=`.status.signer.backend`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Identity contains the information to operate in a remote cluster.
@@ -0,0 +1,30 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// OIDCConfig contains the short-lived OIDC token issued to the consumer cluster, and the information
+// required to refresh it before it expires.
+type OIDCConfig struct {
+	// Issuer is the URL of the OIDC issuer trusted by the provider API server.
+	Issuer string `json:"issuer"`
+	// Token is the signed ID token used as bearer token to authenticate with the provider API server.
+	Token string `json:"token"`
+	// IssuedAt is the time at which the token has been issued.
+	IssuedAt metav1.Time `json:"issuedAt"`
+	// ExpirationTimestamp is the time after which the token is no longer valid.
+	ExpirationTimestamp metav1.Time `json:"expirationTimestamp"`
+}
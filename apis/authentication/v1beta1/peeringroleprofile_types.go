@@ -0,0 +1,91 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// PeeringRoleProfileResource is the name of the peeringroleprofile resources.
+var PeeringRoleProfileResource = "peeringroleprofiles"
+
+// PeeringRoleProfileKind specifies the kind of the peeringroleprofile.
+var PeeringRoleProfileKind = "PeeringRoleProfile"
+
+// PeeringRoleProfileGroupResource is group resource used to register these objects.
+var PeeringRoleProfileGroupResource = schema.GroupResource{Group: GroupVersion.Group, Resource: PeeringRoleProfileResource}
+
+// PeeringRoleProfileGroupVersionResource is groupResourceVersion used to register these objects.
+var PeeringRoleProfileGroupVersionResource = GroupVersion.WithResource(PeeringRoleProfileResource)
+
+// PeeringRoleResource is a resource whose permissions granted to the consumer clusters can be restricted.
+// +kubebuilder:validation:Enum=Services;EndpointSlices;Ingresses;ConfigMaps;Secrets;PersistentVolumeClaims;Events
+type PeeringRoleResource string
+
+const (
+	// PeeringRoleResourceServices refers to the services.
+	PeeringRoleResourceServices PeeringRoleResource = "Services"
+	// PeeringRoleResourceEndpointSlices refers to the (shadow) endpointslices.
+	PeeringRoleResourceEndpointSlices PeeringRoleResource = "EndpointSlices"
+	// PeeringRoleResourceIngresses refers to the ingresses.
+	PeeringRoleResourceIngresses PeeringRoleResource = "Ingresses"
+	// PeeringRoleResourceConfigMaps refers to the configmaps.
+	PeeringRoleResourceConfigMaps PeeringRoleResource = "ConfigMaps"
+	// PeeringRoleResourceSecrets refers to the secrets (including the ones holding the service account tokens).
+	PeeringRoleResourceSecrets PeeringRoleResource = "Secrets"
+	// PeeringRoleResourcePersistentVolumeClaims refers to the persistentvolumeclaims.
+	PeeringRoleResourcePersistentVolumeClaims PeeringRoleResource = "PersistentVolumeClaims"
+	// PeeringRoleResourceEvents refers to the events.
+	PeeringRoleResourceEvents PeeringRoleResource = "Events"
+)
+
+// PeeringRoleProfileSpec defines the permissions granted to the consumer clusters in the offloaded namespaces.
+// The resources not listed are granted the default permissions.
+type PeeringRoleProfileSpec struct {
+	// DisabledResources is the list of resources the consumer clusters cannot operate on.
+	// The corresponding reflectors are disabled in the virtual nodes targeting the local cluster.
+	DisabledResources []PeeringRoleResource `json:"disabledResources,omitempty"`
+	// ReadOnlyResources is the list of resources the consumer clusters can only read.
+	// The reflectors which need to write the corresponding resources are disabled in the virtual nodes targeting the local cluster.
+	ReadOnlyResources []PeeringRoleResource `json:"readOnlyResources,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories=liqo,shortName=prp;prprofile
+// +kubebuilder:printcolumn:name="Disabled",type=string,JSONPath=`.spec.disabledResources`
+// +kubebuilder:printcolumn:name="Read Only",type=string,JSONPath=`.spec.readOnlyResources`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PeeringRoleProfile restricts the permissions granted by the provider cluster to the tenants referencing it.
+type PeeringRoleProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PeeringRoleProfileSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PeeringRoleProfileList contains a list of PeeringRoleProfile.
+type PeeringRoleProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PeeringRoleProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PeeringRoleProfile{}, &PeeringRoleProfileList{})
+}
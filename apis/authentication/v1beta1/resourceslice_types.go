@@ -42,6 +42,9 @@ const (
 	ResourceSliceClassUnknown ResourceSliceClass = ""
 	// ResourceSliceClassDefault is the default class of the ResourceSlice.
 	ResourceSliceClassDefault ResourceSliceClass = "default"
+	// ResourceSliceClassCapacity is the class of the ResourceSlice whose resources are granted according to the
+	// capacity actually available in the provider cluster.
+	ResourceSliceClassCapacity ResourceSliceClass = "capacity"
 )
 
 // ResourceSliceSpec defines the desired state of ResourceSlice.
@@ -56,6 +59,24 @@ type ResourceSliceSpec struct {
 	Class ResourceSliceClass `json:"class,omitempty"`
 	// CSR is the Certificate Signing Request of the consumer cluster.
 	CSR []byte `json:"csr,omitempty"`
+	// Autoscaling, if set, enables the consumer cluster to automatically adjust the requested resources
+	// according to the pressure of the pods offloaded through the associated virtual node.
+	Autoscaling *ResourceSliceAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// ResourceSliceAutoscaling defines the policy used to automatically resize the resources requested by a ResourceSlice.
+type ResourceSliceAutoscaling struct {
+	// MinResources contains the lower bound of the resources requested by the ResourceSlice.
+	MinResources corev1.ResourceList `json:"minResources,omitempty"`
+	// MaxResources contains the upper bound of the resources requested by the ResourceSlice.
+	// Only the resources listed here are automatically resized, while the other ones are left untouched.
+	MaxResources corev1.ResourceList `json:"maxResources"`
+	// TargetUtilizationPercentage is the target ratio between the resources demanded by the offloaded pods
+	// and the ones requested by the ResourceSlice.
+	// +kubebuilder:default=70
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	TargetUtilizationPercentage int32 `json:"targetUtilizationPercentage,omitempty"`
 }
 
 // ResourceSliceConditionType represents different types of conditions that a ResourceSlice could assume.
@@ -113,6 +134,8 @@ type ResourceSliceStatus struct {
 	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
 	// NodeSelector contains the selector to be applied to offloaded pods.
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// DisabledReflectors contains the list of reflectors to be disabled, as not permitted by the provider cluster.
+	DisabledReflectors []string `json:"disabledReflectors,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,36 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// SignerBackend is the backend used by the provider cluster to sign the certificates of the consumer clusters.
+type SignerBackend string
+
+const (
+	// KubernetesSignerBackend indicates that certificates are signed through the Kubernetes CertificateSigningRequest API.
+	KubernetesSignerBackend SignerBackend = "Kubernetes"
+	// CertManagerSignerBackend indicates that certificates are signed through cert-manager CertificateRequests.
+	CertManagerSignerBackend SignerBackend = "CertManager"
+	// ExternalSignerBackend indicates that certificates are signed by an external signer, reached over HTTPS or gRPC.
+	ExternalSignerBackend SignerBackend = "External"
+)
+
+// SignerInfo contains the information about the signer which issued a certificate.
+type SignerInfo struct {
+	// Backend is the backend used to sign the certificate.
+	// +kubebuilder:validation:Enum=Kubernetes;CertManager;External
+	Backend SignerBackend `json:"backend"`
+	// Issuer identifies the issuer within the backend (e.g., the cert-manager issuer, or the external signer endpoint).
+	Issuer string `json:"issuer,omitempty"`
+}
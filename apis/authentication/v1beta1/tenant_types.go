@@ -15,6 +15,7 @@
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -76,6 +77,18 @@ type TenantSpec struct {
 	// +kubebuilder:validation:Enum=Active;Cordoned;Drained
 	// +kubebuilder:default=Active
 	TenantCondition TenantCondition `json:"tenantCondition,omitempty"`
+	// TenantPolicyRef is the reference to the (cluster-scoped) TenantPolicy enforced on the pods offloaded by the tenant.
+	// If not set, no additional constraint is enforced.
+	// +optional
+	TenantPolicyRef *corev1.LocalObjectReference `json:"tenantPolicyRef,omitempty"`
+	// PeeringRoleProfileRef is the reference to the (cluster-scoped) PeeringRoleProfile restricting the permissions
+	// granted to the tenant, both in the offloaded namespaces and in the tenant namespace. If not set, the default permissions are granted.
+	// +optional
+	PeeringRoleProfileRef *corev1.LocalObjectReference `json:"peeringRoleProfileRef,omitempty"`
+	// CredentialPolicy defines the lifecycle of the credentials issued to the tenant.
+	// If not set, the defaults of the identity provider apply.
+	// +optional
+	CredentialPolicy *CredentialPolicy `json:"credentialPolicy,omitempty"`
 }
 
 // TenantCondition contains the conditions of the tenant.
@@ -97,12 +110,16 @@ type TenantStatus struct {
 	TenantNamespace string `json:"tenantNamespace,omitempty"`
 	// AuthParams contains the authentication parameters for the consumer cluster.
 	AuthParams *AuthParams `json:"authParams,omitempty"`
+	// Credentials contains the status of the credentials issued to the tenant.
+	Credentials *CredentialsStatus `json:"credentials,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories=liqo,shortName=tn
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Condition",type=string,JSONPath=`.spec.tenantCondition`
+// +kubebuilder:printcolumn:name="Credentials Generation",type=integer,JSONPath=`.status.credentials.generation`,priority=1
+// +kubebuilder:printcolumn:name="Credentials Expiration",type=date,JSONPath=`.status.credentials.expiresAt`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Tenant represents a consumer cluster.
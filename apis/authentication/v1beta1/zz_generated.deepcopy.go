@@ -20,7 +20,8 @@ package v1beta1
 
 import (
 	corev1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -42,11 +43,26 @@ func (in *AuthParams) DeepCopyInto(out *AuthParams) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Signer != nil {
+		in, out := &in.Signer, &out.Signer
+		*out = new(SignerInfo)
+		**out = **in
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(CredentialRotation)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AwsConfig != nil {
 		in, out := &in.AwsConfig, &out.AwsConfig
 		*out = new(AwsConfig)
 		**out = **in
 	}
+	if in.OIDCConfig != nil {
+		in, out := &in.OIDCConfig, &out.OIDCConfig
+		*out = new(OIDCConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthParams.
@@ -74,6 +90,78 @@ func (in *AwsConfig) DeepCopy() *AwsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialPolicy) DeepCopyInto(out *CredentialPolicy) {
+	*out = *in
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialPolicy.
+func (in *CredentialPolicy) DeepCopy() *CredentialPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotation) DeepCopyInto(out *CredentialRotation) {
+	*out = *in
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotation.
+func (in *CredentialRotation) DeepCopy() *CredentialRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsStatus) DeepCopyInto(out *CredentialsStatus) {
+	*out = *in
+	if in.IssuedAt != nil {
+		in, out := &in.IssuedAt, &out.IssuedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RevokedAt != nil {
+		in, out := &in.RevokedAt, &out.RevokedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsStatus.
+func (in *CredentialsStatus) DeepCopy() *CredentialsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Identity) DeepCopyInto(out *Identity) {
 	*out = *in
@@ -159,7 +247,12 @@ func (in *IdentityStatus) DeepCopyInto(out *IdentityStatus) {
 	*out = *in
 	if in.KubeconfigSecretRef != nil {
 		in, out := &in.KubeconfigSecretRef, &out.KubeconfigSecretRef
-		*out = new(v1.LocalObjectReference)
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Signer != nil {
+		in, out := &in.Signer, &out.Signer
+		*out = new(SignerInfo)
 		**out = **in
 	}
 }
@@ -174,6 +267,106 @@ func (in *IdentityStatus) DeepCopy() *IdentityStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
+	*out = *in
+	in.IssuedAt.DeepCopyInto(&out.IssuedAt)
+	in.ExpirationTimestamp.DeepCopyInto(&out.ExpirationTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfig.
+func (in *OIDCConfig) DeepCopy() *OIDCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRoleProfile) DeepCopyInto(out *PeeringRoleProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRoleProfile.
+func (in *PeeringRoleProfile) DeepCopy() *PeeringRoleProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRoleProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringRoleProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRoleProfileList) DeepCopyInto(out *PeeringRoleProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PeeringRoleProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRoleProfileList.
+func (in *PeeringRoleProfileList) DeepCopy() *PeeringRoleProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRoleProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringRoleProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRoleProfileSpec) DeepCopyInto(out *PeeringRoleProfileSpec) {
+	*out = *in
+	if in.DisabledResources != nil {
+		in, out := &in.DisabledResources, &out.DisabledResources
+		*out = make([]PeeringRoleResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadOnlyResources != nil {
+		in, out := &in.ReadOnlyResources, &out.ReadOnlyResources
+		*out = make([]PeeringRoleResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRoleProfileSpec.
+func (in *PeeringRoleProfileSpec) DeepCopy() *PeeringRoleProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRoleProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Renew) DeepCopyInto(out *Renew) {
 	*out = *in
@@ -248,7 +441,7 @@ func (in *RenewSpec) DeepCopyInto(out *RenewSpec) {
 	}
 	if in.ResourceSliceRef != nil {
 		in, out := &in.ResourceSliceRef, &out.ResourceSliceRef
-		*out = new(v1.LocalObjectReference)
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 }
@@ -310,6 +503,35 @@ func (in *ResourceSlice) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSliceAutoscaling) DeepCopyInto(out *ResourceSliceAutoscaling) {
+	*out = *in
+	if in.MinResources != nil {
+		in, out := &in.MinResources, &out.MinResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxResources != nil {
+		in, out := &in.MaxResources, &out.MaxResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSliceAutoscaling.
+func (in *ResourceSliceAutoscaling) DeepCopy() *ResourceSliceAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSliceAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSliceCondition) DeepCopyInto(out *ResourceSliceCondition) {
 	*out = *in
@@ -373,7 +595,7 @@ func (in *ResourceSliceSpec) DeepCopyInto(out *ResourceSliceSpec) {
 	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = make(v1.ResourceList, len(*in))
+		*out = make(corev1.ResourceList, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val.DeepCopy()
 		}
@@ -383,6 +605,11 @@ func (in *ResourceSliceSpec) DeepCopyInto(out *ResourceSliceSpec) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ResourceSliceAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSliceSpec.
@@ -407,7 +634,7 @@ func (in *ResourceSliceStatus) DeepCopyInto(out *ResourceSliceStatus) {
 	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = make(v1.ResourceList, len(*in))
+		*out = make(corev1.ResourceList, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val.DeepCopy()
 		}
@@ -425,12 +652,16 @@ func (in *ResourceSliceStatus) DeepCopyInto(out *ResourceSliceStatus) {
 	if in.IngressClasses != nil {
 		in, out := &in.IngressClasses, &out.IngressClasses
 		*out = make([]corev1beta1.IngressType, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.LoadBalancerClasses != nil {
 		in, out := &in.LoadBalancerClasses, &out.LoadBalancerClasses
 		*out = make([]corev1beta1.LoadBalancerType, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.NodeLabels != nil {
 		in, out := &in.NodeLabels, &out.NodeLabels
@@ -446,6 +677,11 @@ func (in *ResourceSliceStatus) DeepCopyInto(out *ResourceSliceStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.DisabledReflectors != nil {
+		in, out := &in.DisabledReflectors, &out.DisabledReflectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSliceStatus.
@@ -458,6 +694,21 @@ func (in *ResourceSliceStatus) DeepCopy() *ResourceSliceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignerInfo) DeepCopyInto(out *SignerInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignerInfo.
+func (in *SignerInfo) DeepCopy() *SignerInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(SignerInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tenant) DeepCopyInto(out *Tenant) {
 	*out = *in
@@ -545,6 +796,21 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TenantPolicyRef != nil {
+		in, out := &in.TenantPolicyRef, &out.TenantPolicyRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PeeringRoleProfileRef != nil {
+		in, out := &in.PeeringRoleProfileRef, &out.PeeringRoleProfileRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CredentialPolicy != nil {
+		in, out := &in.CredentialPolicy, &out.CredentialPolicy
+		*out = new(CredentialPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantSpec.
@@ -565,6 +831,11 @@ func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 		*out = new(AuthParams)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(CredentialsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantStatus.
@@ -28,6 +28,10 @@ type IngressType struct {
 	IngressClassName string `json:"ingressClassName"`
 	// Default indicates whether this ingress class is the default ingress class for Liqo.
 	Default bool `json:"default,omitempty"`
+	// ReflectStatus indicates whether the status of the ingresses exposed through this class
+	// (i.e., the assigned hostnames and IPs) shall be reflected back to the local objects.
+	// When unset, the setting configured on the VirtualNode is preserved, and reflection is disabled by default.
+	ReflectStatus *bool `json:"reflectStatus,omitempty"`
 }
 
 // LoadBalancerType defines the type of load balancer offered by a resource offer.
@@ -36,4 +40,8 @@ type LoadBalancerType struct {
 	LoadBalancerClassName string `json:"loadBalancerClassName"`
 	// Default indicates whether this load balancer class is the default load balancer class for Liqo.
 	Default bool `json:"default,omitempty"`
+	// ReflectStatus indicates whether the status of the services exposed through this class
+	// (i.e., the assigned external IPs and hostnames) shall be reflected back to the local objects.
+	// When unset, the setting configured on the VirtualNode is preserved, and reflection is disabled by default.
+	ReflectStatus *bool `json:"reflectStatus,omitempty"`
 }
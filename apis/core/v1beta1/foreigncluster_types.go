@@ -105,6 +105,8 @@ const (
 	NetworkGatewayServerStatusCondition ConditionType = "NetworkGatewayServerStatus"
 	// NetworkGatewayClientStatusCondition shows the network gateway client status.
 	NetworkGatewayClientStatusCondition ConditionType = "NetworkGatewayClientStatus"
+	// NetworkConnectionQualityCondition shows whether the quality of the network connection is within the configured thresholds.
+	NetworkConnectionQualityCondition ConditionType = "NetworkConnectionQuality"
 
 	// AUTHENTICATION
 	// AuthIdentityControlPlaneStatusCondition shows the status of the ControlPlane Identity.
@@ -137,17 +139,19 @@ const (
 	ConditionStatusNotReady ConditionStatusType = "NotReady"
 	// ConditionStatusSomeNotReady indicates that not all components of the conditions are ready.
 	ConditionStatusSomeNotReady ConditionStatusType = "SomeNotReady"
+	// ConditionStatusDegraded indicates that the condition is satisfied, but with a degraded quality.
+	ConditionStatusDegraded ConditionStatusType = "Degraded"
 )
 
 // Condition contains details about state of a.
 type Condition struct {
 	// Type of the condition.
-	// +kubebuilder:validation:Enum="APIServerStatus";"NetworkConnectionStatus";"NetworkGatewayServerStatus";"NetworkGatewayClientStatus";"NetworkGatewayPresence";"NetworkConfigurationStatus";"AuthIdentityControlPlaneStatus";"AuthTenantStatus";"OffloadingVirtualNodeStatus";"OffloadingNodeStatus"
+	// +kubebuilder:validation:Enum="APIServerStatus";"NetworkConnectionStatus";"NetworkConnectionQuality";"NetworkGatewayServerStatus";"NetworkGatewayClientStatus";"NetworkGatewayPresence";"NetworkConfigurationStatus";"AuthIdentityControlPlaneStatus";"AuthTenantStatus";"OffloadingVirtualNodeStatus";"OffloadingNodeStatus"
 	//
 	//nolint:lll // ignore long lines given by Kubebuilder marker annotations
 	Type ConditionType `json:"type"`
 	// Status of the condition.
-	// +kubebuilder:validation:Enum="None";"Pending";"Established";"Error";"Ready";"NotReady";"SomeNotReady"
+	// +kubebuilder:validation:Enum="None";"Pending";"Established";"Error";"Ready";"NotReady";"SomeNotReady";"Degraded"
 	// +kubebuilder:default="None"
 	Status ConditionStatusType `json:"status"`
 	// LastTransitionTime -> timestamp for when the condition last transitioned from one status to another.
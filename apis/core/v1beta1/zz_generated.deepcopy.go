@@ -139,6 +139,11 @@ func (in *ForeignClusterStatus) DeepCopy() *ForeignClusterStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressType) DeepCopyInto(out *IngressType) {
 	*out = *in
+	if in.ReflectStatus != nil {
+		in, out := &in.ReflectStatus, &out.ReflectStatus
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressType.
@@ -154,6 +159,11 @@ func (in *IngressType) DeepCopy() *IngressType {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerType) DeepCopyInto(out *LoadBalancerType) {
 	*out = *in
+	if in.ReflectStatus != nil {
+		in, out := &in.ReflectStatus, &out.ReflectStatus
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerType.
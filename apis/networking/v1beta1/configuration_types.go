@@ -17,6 +17,8 @@ package v1beta1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -42,10 +44,20 @@ type ClusterConfigCIDR struct {
 	External []CIDR `json:"external,omitempty"`
 }
 
+// TransitConfig defines the CIDRs of a cluster reachable in transit through another cluster (see TransitPolicy).
+type TransitConfig struct {
+	// ClusterID of the cluster reachable in transit.
+	ClusterID liqov1beta1.ClusterID `json:"clusterID"`
+	// CIDR of the cluster reachable in transit, as seen by the cluster forwarding the traffic.
+	CIDR ClusterConfigCIDR `json:"cidr"`
+}
+
 // ClusterConfig defines the configuration of a cluster.
 type ClusterConfig struct {
 	// CIDR of the cluster.
 	CIDR ClusterConfigCIDR `json:"cidr,omitempty"`
+	// Transit contains the CIDRs of the clusters reachable in transit through the cluster.
+	Transit []TransitConfig `json:"transit,omitempty"`
 }
 
 // ConfigurationSpec defines the desired state of Configuration.
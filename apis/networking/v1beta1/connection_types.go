@@ -53,6 +53,10 @@ const (
 	Connecting ConnectionStatusValue = "Connecting"
 	// ConnectionError used to se the status in case of errors.
 	ConnectionError ConnectionStatusValue = "Error"
+
+	// ConnectionDegradedCondition is the type of the condition reporting whether the quality of the connection
+	// exceeds the configured thresholds.
+	ConnectionDegradedCondition = "Degraded"
 )
 
 // ConnectionSpec defines the desired state of Connection.
@@ -62,6 +66,20 @@ type ConnectionSpec struct {
 	Type ConnectionType `json:"type"`
 	// GatewayRef specifies the reference to the gateway.
 	GatewayRef corev1.ObjectReference `json:"gatewayRef"`
+	// QualityThresholds defines the thresholds on the quality of the connection, beyond which it is considered degraded.
+	QualityThresholds *ConnectionQualityThresholds `json:"qualityThresholds,omitempty"`
+}
+
+// ConnectionQualityThresholds defines the thresholds on the quality of a connection.
+type ConnectionQualityThresholds struct {
+	// MaxLatency is the maximum round-trip latency.
+	MaxLatency *metav1.Duration `json:"maxLatency,omitempty"`
+	// MaxJitter is the maximum jitter of the round-trip latency.
+	MaxJitter *metav1.Duration `json:"maxJitter,omitempty"`
+	// MaxPacketLossPercentage is the maximum percentage of lost packets.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxPacketLossPercentage *int32 `json:"maxPacketLossPercentage,omitempty"`
 }
 
 // ConnectionLatency represents the latency between two clusters.
@@ -72,12 +90,39 @@ type ConnectionLatency struct {
 	Timestamp metav1.Time `json:"timestamp,omitempty"`
 }
 
+// ConnectionQuality represents the quality of a connection, measured over a sliding window of pings.
+type ConnectionQuality struct {
+	// PacketLoss is the percentage of lost packets.
+	PacketLoss string `json:"packetLoss,omitempty"`
+	// Jitter is the mean variation of the round-trip latency between consecutive packets.
+	Jitter string `json:"jitter,omitempty"`
+	// Timestamp of the measurement.
+	Timestamp metav1.Time `json:"timestamp,omitempty"`
+}
+
+// ConnectionPathMTU represents the MTU discovered by probing the path through the tunnel.
+type ConnectionPathMTU struct {
+	// Value is the size of the largest packet crossing the tunnel, within the configured bounds.
+	Value int `json:"value,omitempty"`
+	// Timestamp of the discovery of the current value.
+	Timestamp metav1.Time `json:"timestamp,omitempty"`
+}
+
 // ConnectionStatus defines the observed state of Connection.
 type ConnectionStatus struct {
 	// Value of the connection.
 	Value ConnectionStatusValue `json:"value,omitempty"`
 	// Latency of the connection.
 	Latency ConnectionLatency `json:"latency,omitempty"`
+	// Quality of the connection.
+	Quality ConnectionQuality `json:"quality,omitempty"`
+	// PathMTU is the MTU of the tunnel, discovered by probing the path towards the remote gateway.
+	// It is set only if the MTU discovery is enabled.
+	PathMTU *ConnectionPathMTU `json:"pathMTU,omitempty"`
+	// Conditions contains the conditions of the connection.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -87,6 +132,10 @@ type ConnectionStatus struct {
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.value`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:printcolumn:name="Latency",type=string,JSONPath=`.status.latency.value`,priority=1
+// +kubebuilder:printcolumn:name="Loss",type=string,JSONPath=`.status.quality.packetLoss`,priority=1
+// +kubebuilder:printcolumn:name="Jitter",type=string,JSONPath=`.status.quality.jitter`,priority=1
+// +kubebuilder:printcolumn:name="MTU",type=integer,JSONPath=`.status.pathMTU.value`,priority=1
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,priority=1
 
 // Connection contains the status of a connection between two clusters (a client and a server).
 type Connection struct {
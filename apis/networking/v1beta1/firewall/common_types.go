@@ -26,6 +26,8 @@ const (
 	IPValueTypeVoid IPValueType = "void"
 	// IPValueTypeRange is a string representing a range of IPs (eg. 10.0.0.1-10.0.0.20).
 	IPValueTypeRange IPValueType = "range"
+	// IPValueTypeSet is a string referencing a named set of IPs (eg. @allowed).
+	IPValueTypeSet IPValueType = "set"
 )
 
 // PortValueType is the type of the match value.
@@ -36,6 +38,8 @@ const (
 	PortValueTypePort PortValueType = "port"
 	// PortValueTypeRange is a string representing a range of ports (eg. 3000-4000).
 	PortValueTypeRange PortValueType = "range"
+	// PortValueTypeSet is a string referencing a named set of ports (eg. @services).
+	PortValueTypeSet PortValueType = "set"
 	// PortValueTypeVoid is a void match value.
 	PortValueTypeVoid PortValueType = "void"
 )
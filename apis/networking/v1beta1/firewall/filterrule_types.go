@@ -36,6 +36,9 @@ const (
 	// ActionReject is the action to be applied to the rule.
 	// ActionReject reject the packet with response.
 	ActionReject FilterAction = "reject"
+	// ActionVerdictMap is the action to be applied to the rule.
+	// ActionVerdictMap applies the verdict found looking up the packet in a verdict map.
+	ActionVerdictMap FilterAction = "vmap"
 )
 
 // FilterRule is a rule to be applied to a filter chain.
@@ -50,8 +53,10 @@ type FilterRule struct {
 	// They can be multiple and they are applied with an AND operator.
 	Match []Match `json:"match"`
 	// Action is the action to be applied to the rule.
-	// +kubebuilder:validation:Enum=ctmark;metamarkfromctmark;tcpmssclamp;accept;drop;reject
+	// +kubebuilder:validation:Enum=ctmark;metamarkfromctmark;tcpmssclamp;accept;drop;reject;vmap
 	Action FilterAction `json:"action"`
 	// Value is the value to be used for the action.
 	Value *string `json:"value,omitempty"`
+	// VerdictMap is the verdict map to be looked up, used by the vmap action.
+	VerdictMap *MapLookup `json:"verdictMap,omitempty"`
 }
@@ -54,10 +54,26 @@ const (
 	L4ProtoUDP L4Proto = "udp"
 )
 
+// CtState is the state of a connection tracked by conntrack.
+// +kubebuilder:validation:Enum=new;established;related;invalid
+type CtState string
+
+const (
+	// CtStateNew is the state of a connection that has not yet seen packets in both directions.
+	CtStateNew CtState = "new"
+	// CtStateEstablished is the state of a connection that has seen packets in both directions.
+	CtStateEstablished CtState = "established"
+	// CtStateRelated is the state of a new connection associated with an existing one (e.g., ICMP errors).
+	CtStateRelated CtState = "related"
+	// CtStateInvalid is the state of a packet that cannot be associated with any known connection.
+	CtStateInvalid CtState = "invalid"
+)
+
 // MatchIP is an IP to be matched.
 // +kubebuilder:object:generate=true
 type MatchIP struct {
 	// Value is the IP or a Subnet to be matched.
+	// It can also reference a named set of the table (eg. @allowed).
 	Value string `json:"value"`
 	// Position is the position of the IP in the packet.
 	// +kubebuilder:validation:Enum=src;dst
@@ -68,6 +84,7 @@ type MatchIP struct {
 // +kubebuilder:object:generate=true
 type MatchPort struct {
 	// Value is the port or a range (eg. 3000-4000) to be matched.
+	// It can also reference a named set of the table (eg. @services).
 	Value string `json:"value"`
 	// Position is the position of the port in the packet.
 	// +kubebuilder:validation:Enum=src;dst
@@ -92,6 +109,14 @@ type MatchProto struct {
 	Value L4Proto `json:"value"`
 }
 
+// MatchCtState is a set of conntrack states to be matched.
+// +kubebuilder:object:generate=true
+type MatchCtState struct {
+	// Value is the list of states to be matched. The packet matches if its connection is in any of them.
+	// +kubebuilder:validation:MinItems=1
+	Value []CtState `json:"value"`
+}
+
 // Match is a match to be applied to a rule.
 // +kubebuilder:object:generate=true
 type Match struct {
@@ -106,4 +131,6 @@ type Match struct {
 	Proto *MatchProto `json:"proto,omitempty"`
 	// Dev contains the options to match a device.
 	Dev *MatchDev `json:"dev,omitempty"`
+	// CtState contains the options to match the conntrack state of the connection.
+	CtState *MatchCtState `json:"ctState,omitempty"`
 }
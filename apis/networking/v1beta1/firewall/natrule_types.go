@@ -41,6 +41,9 @@ type NatRule struct {
 	NatType NatType `json:"natType"`
 	// To is the IP to be used for the NAT translation.
 	To *string `json:"to,omitempty"`
+	// ToMap is the address map to be looked up to get the IP used for the NAT translation.
+	// It is mutually exclusive with To.
+	ToMap *MapLookup `json:"toMap,omitempty"`
 	// TargetRef is the reference to the target object of the rule.
 	// It is optional and it can be used for custom purposes.
 	TargetRef *corev1.ObjectReference `json:"targetRef,omitempty"`
@@ -0,0 +1,81 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firewall
+
+// SetDataType is the type of the elements of a set, or of the values of a map.
+type SetDataType string
+
+const (
+	// SetDataTypeIPv4Addr is an IPv4 address.
+	SetDataTypeIPv4Addr SetDataType = "ipv4_addr"
+	// SetDataTypeIPv6Addr is an IPv6 address.
+	SetDataTypeIPv6Addr SetDataType = "ipv6_addr"
+	// SetDataTypeInetService is a transport layer port.
+	SetDataTypeInetService SetDataType = "inet_service"
+	// SetDataTypeVerdict is a verdict (e.g., accept or drop), and it is valid only as value of a map.
+	SetDataTypeVerdict SetDataType = "verdict"
+)
+
+// SetElement is an element of a set, or an entry of a map.
+// +kubebuilder:object:generate=true
+type SetElement struct {
+	// Key is the element of the set, or the key of the map entry.
+	// Depending on the key type of the set, it can be an IP, a subnet or a range of IPs (eg. 10.0.0.1-10.0.0.20),
+	// or a port or a range of ports (eg. 3000-4000).
+	Key string `json:"key"`
+	// Data is the value associated with the key, and it must be set only for maps.
+	// It is an IP for address maps, and "accept" or "drop" for verdict maps.
+	Data *string `json:"data,omitempty"`
+}
+
+// Set is a named set of elements (or a map, if the data type is set) defined in a table.
+// Rules reference it by name, and its elements can be updated without modifying the rules.
+// +kubebuilder:object:generate=true
+type Set struct {
+	// Name is the name of the set. Matches reference it with the "@<name>" value.
+	Name string `json:"name"`
+	// KeyType is the type of the elements of the set, or of the keys of the map.
+	// +kubebuilder:validation:Enum=ipv4_addr;ipv6_addr;inet_service
+	KeyType SetDataType `json:"keyType"`
+	// DataType is the type of the values of the map. If set, the set is a map.
+	// +kubebuilder:validation:Enum=ipv4_addr;ipv6_addr;verdict
+	DataType *SetDataType `json:"dataType,omitempty"`
+	// Elements is the list of elements of the set.
+	// +kubebuilder:validation:Optional
+	Elements []SetElement `json:"elements,omitempty"`
+}
+
+// MapKey is the field of the packet used as key to look up a map.
+type MapKey string
+
+const (
+	// MapKeyIP uses the IP address of the packet as key.
+	MapKeyIP MapKey = "ip"
+	// MapKeyPort uses the transport layer port of the packet as key.
+	MapKeyPort MapKey = "port"
+)
+
+// MapLookup is the lookup of a field of the packet in a map defined in the same table.
+// +kubebuilder:object:generate=true
+type MapLookup struct {
+	// Name is the name of the map.
+	Name string `json:"name"`
+	// Key is the field of the packet used as key.
+	// +kubebuilder:validation:Enum=ip;port
+	Key MapKey `json:"key"`
+	// Position is the position of the field in the packet.
+	// +kubebuilder:validation:Enum=src;dst
+	Position MatchPosition `json:"position"`
+}
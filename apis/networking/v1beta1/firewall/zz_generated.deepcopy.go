@@ -78,6 +78,11 @@ func (in *FilterRule) DeepCopyInto(out *FilterRule) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.VerdictMap != nil {
+		in, out := &in.VerdictMap, &out.VerdictMap
+		*out = new(MapLookup)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterRule.
@@ -90,6 +95,21 @@ func (in *FilterRule) DeepCopy() *FilterRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MapLookup) DeepCopyInto(out *MapLookup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapLookup.
+func (in *MapLookup) DeepCopy() *MapLookup {
+	if in == nil {
+		return nil
+	}
+	out := new(MapLookup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Match) DeepCopyInto(out *Match) {
 	*out = *in
@@ -113,6 +133,11 @@ func (in *Match) DeepCopyInto(out *Match) {
 		*out = new(MatchDev)
 		**out = **in
 	}
+	if in.CtState != nil {
+		in, out := &in.CtState, &out.CtState
+		*out = new(MatchCtState)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Match.
@@ -125,6 +150,26 @@ func (in *Match) DeepCopy() *Match {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchCtState) DeepCopyInto(out *MatchCtState) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = make([]CtState, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchCtState.
+func (in *MatchCtState) DeepCopy() *MatchCtState {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchCtState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchDev) DeepCopyInto(out *MatchDev) {
 	*out = *in
@@ -205,6 +250,11 @@ func (in *NatRule) DeepCopyInto(out *NatRule) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ToMap != nil {
+		in, out := &in.ToMap, &out.ToMap
+		*out = new(MapLookup)
+		**out = **in
+	}
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
 		*out = new(v1.ObjectReference)
@@ -278,6 +328,53 @@ func (in *RulesSet) DeepCopy() *RulesSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Set) DeepCopyInto(out *Set) {
+	*out = *in
+	if in.DataType != nil {
+		in, out := &in.DataType, &out.DataType
+		*out = new(SetDataType)
+		**out = **in
+	}
+	if in.Elements != nil {
+		in, out := &in.Elements, &out.Elements
+		*out = make([]SetElement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Set.
+func (in *Set) DeepCopy() *Set {
+	if in == nil {
+		return nil
+	}
+	out := new(Set)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SetElement) DeepCopyInto(out *SetElement) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SetElement.
+func (in *SetElement) DeepCopy() *SetElement {
+	if in == nil {
+		return nil
+	}
+	out := new(SetElement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Table) DeepCopyInto(out *Table) {
 	*out = *in
@@ -298,6 +395,13 @@ func (in *Table) DeepCopyInto(out *Table) {
 		*out = new(TableFamily)
 		**out = **in
 	}
+	if in.Sets != nil {
+		in, out := &in.Sets, &out.Sets
+		*out = make([]Set, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Table.
@@ -37,6 +37,11 @@ const (
 	FirewallConfigurationStatusConditionTypeApplied FirewallConfigurationStatusConditionType = "Applied"
 	// FirewallConfigurationStatusConditionTypeError is true if the configuration has not been applied to the firewall.
 	FirewallConfigurationStatusConditionTypeError FirewallConfigurationStatusConditionType = "Error"
+	// FirewallConfigurationStatusConditionTypeSynced is true if the rules applied to the firewall match the configuration.
+	FirewallConfigurationStatusConditionTypeSynced FirewallConfigurationStatusConditionType = "Synced"
+	// FirewallConfigurationStatusConditionTypeDropping is true if some filter rules dropping or rejecting the traffic have been hit.
+	// The message contains the names of those rules, for the rules with the counter enabled.
+	FirewallConfigurationStatusConditionTypeDropping FirewallConfigurationStatusConditionType = "Dropping"
 )
 
 // FirewallConfigurationStatusCondition defines the observed state of FirewallConfiguration.
@@ -49,6 +54,9 @@ type FirewallConfigurationStatusCondition struct {
 	Status metav1.ConditionStatus `json:"status"`
 	// Last time the condition transitioned from one status to another.
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Message is a human readable message indicating details about the condition,
+	// such as the rules which do not match the configuration.
+	Message string `json:"message,omitempty"`
 }
 
 // FirewallConfigurationStatus defines the observed state of FirewallConfiguration.
@@ -46,6 +46,10 @@ type GatewayClientSpec struct {
 	// SecretRef specifies the reference to the secret containing configurations.
 	// Leave it empty to let the operator create a new secret.
 	SecretRef corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// QualityThresholds defines the thresholds on the quality of the connection, beyond which it is considered degraded.
+	// They are propagated to the Connection resource created by the gateway.
+	// +optional
+	QualityThresholds *ConnectionQualityThresholds `json:"qualityThresholds,omitempty"`
 }
 
 // GatewayClientStatus defines the observed state of GatewayClient.
@@ -56,6 +60,9 @@ type GatewayClientStatus struct {
 	SecretRef *corev1.ObjectReference `json:"secretRef,omitempty"`
 	// InternalEndpoint specifies the endpoint for the internal network.
 	InternalEndpoint *InternalGatewayEndpoint `json:"internalEndpoint,omitempty"`
+	// InternalEndpoints specifies the endpoints for the internal network of all the connected replicas,
+	// when the gateway runs in active-active mode.
+	InternalEndpoints []InternalGatewayEndpoint `json:"internalEndpoints,omitempty"`
 }
 
 // +kubebuilder:object:root=true
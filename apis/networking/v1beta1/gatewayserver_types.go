@@ -63,6 +63,10 @@ type GatewayServerSpec struct {
 	// SecretRef specifies the reference to the secret containing configurations.
 	// Leave it empty to let the operator create a new secret.
 	SecretRef corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// QualityThresholds defines the thresholds on the quality of the connection, beyond which it is considered degraded.
+	// They are propagated to the Connection resource created by the gateway.
+	// +optional
+	QualityThresholds *ConnectionQualityThresholds `json:"qualityThresholds,omitempty"`
 }
 
 // EndpointStatus defines the observed state of the endpoint.
@@ -74,6 +78,19 @@ type EndpointStatus struct {
 	// Protocol specifies the protocol of the endpoint.
 	// +kubebuilder:validation:Enum=TCP;UDP
 	Protocol *corev1.Protocol `json:"protocol,omitempty"`
+	// Replicas specifies the endpoints of the single gateway replicas, when the gateway runs in active-active mode.
+	// Each client replica connects to the server replica with the same index.
+	Replicas []ReplicaEndpointStatus `json:"replicas,omitempty"`
+}
+
+// ReplicaEndpointStatus defines the endpoint of a single gateway replica.
+type ReplicaEndpointStatus struct {
+	// Index is the index of the gateway replica.
+	Index int32 `json:"index"`
+	// Addresses specifies the addresses of the endpoint.
+	Addresses []string `json:"addresses,omitempty"`
+	// Port specifies the port of the endpoint.
+	Port int32 `json:"port,omitempty"`
 }
 
 // InternalGatewayEndpoint defines the endpoint for the internal network.
@@ -94,6 +111,9 @@ type GatewayServerStatus struct {
 	SecretRef *corev1.ObjectReference `json:"secretRef,omitempty"`
 	// InternalEndpoint specifies the endpoint for the internal network.
 	InternalEndpoint *InternalGatewayEndpoint `json:"internalEndpoint,omitempty"`
+	// InternalEndpoints specifies the endpoints for the internal network of all the connected replicas,
+	// when the gateway runs in active-active mode.
+	InternalEndpoints []InternalGatewayEndpoint `json:"internalEndpoints,omitempty"`
 }
 
 // +kubebuilder:object:root=true
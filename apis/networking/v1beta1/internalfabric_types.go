@@ -55,6 +55,26 @@ type InternalFabricSpecInterface struct {
 	Gateway InternalFabricSpecInterfaceGateway `json:"gateway"`
 }
 
+// InternalFabricSpecReplica contains the information about an additional gateway replica.
+type InternalFabricSpecReplica struct {
+	// GatewayIP is the IP of the gateway replica pod.
+	GatewayIP IP `json:"gatewayIP"`
+	// NodeInterfaceName is the name of the interface added to the nodes to reach the gateway replica.
+	NodeInterfaceName string `json:"nodeInterfaceName"`
+}
+
+// InternalFabricEncryptionMode is the mode used to encrypt the traffic between the nodes and the gateway.
+// +kubebuilder:validation:Enum=None;WireGuard
+type InternalFabricEncryptionMode string
+
+const (
+	// InternalFabricEncryptionModeNone is the mode where the geneve tunnels between the nodes and the gateway are not encrypted.
+	InternalFabricEncryptionModeNone InternalFabricEncryptionMode = "None"
+	// InternalFabricEncryptionModeWireGuard is the mode where the geneve tunnels between the nodes and the gateway
+	// are carried by WireGuard peerings established between each node and the gateway.
+	InternalFabricEncryptionModeWireGuard InternalFabricEncryptionMode = "WireGuard"
+)
+
 // InternalFabricSpec defines the desired state of InternalFabric.
 type InternalFabricSpec struct {
 	// MTU is the MTU of the internal fabric.
@@ -65,6 +85,12 @@ type InternalFabricSpec struct {
 	Interface InternalFabricSpecInterface `json:"interface"`
 	// GatewayIP is the IP of the gateway pod.
 	GatewayIP IP `json:"gatewayIP"`
+	// Replicas contains the additional gateway replicas the traffic is load balanced across,
+	// when the gateway runs in active-active mode.
+	Replicas []InternalFabricSpecReplica `json:"replicas,omitempty"`
+	// Encryption is the mode used to encrypt the geneve tunnels between the nodes and the gateway.
+	// An empty value is equivalent to None.
+	Encryption InternalFabricEncryptionMode `json:"encryption,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,126 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// PeeringNetworkPolicyResource the name of the peeringnetworkpolicy resources.
+var PeeringNetworkPolicyResource = "peeringnetworkpolicies"
+
+// PeeringNetworkPolicyKind is the kind name used to register the PeeringNetworkPolicy CRD.
+var PeeringNetworkPolicyKind = "PeeringNetworkPolicy"
+
+// PeeringNetworkPolicyGroupResource is group resource used to register these objects.
+var PeeringNetworkPolicyGroupResource = schema.GroupResource{Group: GroupVersion.Group, Resource: PeeringNetworkPolicyResource}
+
+// PeeringNetworkPolicyGroupVersionResource is groupResourceVersion used to register these objects.
+var PeeringNetworkPolicyGroupVersionResource = GroupVersion.WithResource(PeeringNetworkPolicyResource)
+
+// PeeringNetworkPolicyType is the direction of the traffic isolated by a PeeringNetworkPolicy.
+// +kubebuilder:validation:Enum=Ingress;Egress
+type PeeringNetworkPolicyType string
+
+const (
+	// PeeringNetworkPolicyTypeIngress isolates the traffic coming from the remote clusters.
+	PeeringNetworkPolicyTypeIngress PeeringNetworkPolicyType = "Ingress"
+	// PeeringNetworkPolicyTypeEgress isolates the traffic directed to the remote clusters.
+	PeeringNetworkPolicyTypeEgress PeeringNetworkPolicyType = "Egress"
+)
+
+// PeeringNetworkPolicyPort defines a port (or a range of ports) allowed by a PeeringNetworkPolicy.
+type PeeringNetworkPolicyPort struct {
+	// Protocol is the L4 protocol of the traffic.
+	// +kubebuilder:validation:Enum=TCP;UDP
+	// +kubebuilder:default=TCP
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
+	// Port is the destination port of the traffic.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// EndPort, if set, turns the port into the range of ports between Port and EndPort (inclusive).
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	EndPort *int32 `json:"endPort,omitempty"`
+}
+
+// PeeringNetworkPolicyPeer selects the workloads of a remote cluster.
+type PeeringNetworkPolicyPeer struct {
+	// ClusterID is the ID of the remote cluster.
+	ClusterID liqov1beta1.ClusterID `json:"clusterID"`
+	// Namespaces restricts the peer to the pods offloaded by the local cluster to the remote one from the given namespaces,
+	// as they are the only remote workloads whose addresses are known locally.
+	// If empty, the whole pod and external CIDRs of the remote cluster are selected.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// PeeringNetworkPolicyRule defines the traffic allowed between the selected local pods and a set of remote clusters.
+type PeeringNetworkPolicyRule struct {
+	// Clusters is the list of remote clusters the rule applies to.
+	// +kubebuilder:validation:MinItems=1
+	Clusters []PeeringNetworkPolicyPeer `json:"clusters"`
+	// Ports is the list of destination ports allowed by the rule. If empty, any port and protocol is allowed.
+	Ports []PeeringNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// PeeringNetworkPolicySpec defines the desired state of PeeringNetworkPolicy.
+type PeeringNetworkPolicySpec struct {
+	// NamespaceSelector selects the local namespaces the policy applies to. An empty selector selects all namespaces.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// PodSelector selects the pods the policy applies to, within the selected namespaces.
+	// An empty selector selects all pods.
+	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+	// PolicyTypes is the list of directions in which the selected pods are isolated from the remote clusters.
+	// If empty, the pods are isolated in ingress, and also in egress if any egress rule is specified.
+	PolicyTypes []PeeringNetworkPolicyType `json:"policyTypes,omitempty"`
+	// Ingress is the list of rules defining the traffic the remote clusters are allowed to send to the selected pods.
+	Ingress []PeeringNetworkPolicyRule `json:"ingress,omitempty"`
+	// Egress is the list of rules defining the traffic the selected pods are allowed to send to the remote clusters.
+	Egress []PeeringNetworkPolicyRule `json:"egress,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories=liqo,shortName=pnp;pnpol
+// +kubebuilder:printcolumn:name="Policy Types",type=string,JSONPath=`.spec.policyTypes`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PeeringNetworkPolicy defines the traffic allowed between the local pods and the remote clusters,
+// enforced by the gateways through a FirewallConfiguration for each peer.
+// Similarly to the Kubernetes NetworkPolicies, the selected pods are isolated in the given directions,
+// and the allowed traffic is the union of the rules of all the policies selecting them.
+type PeeringNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PeeringNetworkPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PeeringNetworkPolicyList contains a list of PeeringNetworkPolicy.
+type PeeringNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PeeringNetworkPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PeeringNetworkPolicy{}, &PeeringNetworkPolicyList{})
+}
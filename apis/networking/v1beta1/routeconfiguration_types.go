@@ -51,6 +51,20 @@ const (
 	NowhereScope Scope = "nowhere"
 )
 
+// NextHop is a next hop of a multipath route.
+type NextHop struct {
+	// Gw is the gateway of the next hop.
+	Gw *IP `json:"gw,omitempty"`
+	// Dev is the device of the next hop.
+	Dev *string `json:"dev,omitempty"`
+	// Onlink enables the onlink flag for the next hop.
+	Onlink *bool `json:"onlink,omitempty"`
+	// Weight is the relative weight of the next hop.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=256
+	Weight *int `json:"weight,omitempty"`
+}
+
 // Route is the route of the RouteConfiguration.
 type Route struct {
 	// Dst is the destination of the RouteConfiguration.
@@ -66,6 +80,9 @@ type Route struct {
 	// Scope is the scope of the RouteConfiguration.
 	// +kubebuilder:validation:Enum=global;link;host;site;nowhere
 	Scope *Scope `json:"scope,omitempty"`
+	// NextHops is the list of next hops of a multipath (ECMP) route.
+	// If set, the Gw, Dev and Onlink fields of the route are ignored.
+	NextHops []NextHop `json:"nextHops,omitempty"`
 	// TargetRef is the reference to the target object of the route.
 	// It is optional and it can be used for custom purposes.
 	TargetRef *corev1.ObjectReference `json:"targetRef,omitempty"`
@@ -0,0 +1,65 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// TransitAdvertisementResource the name of the transitadvertisement resources.
+var TransitAdvertisementResource = "transitadvertisements"
+
+// TransitAdvertisementKind is the kind name used to register the TransitAdvertisement CRD.
+var TransitAdvertisementKind = "TransitAdvertisement"
+
+// TransitAdvertisementGroupResource is group resource used to register these objects.
+var TransitAdvertisementGroupResource = schema.GroupResource{Group: GroupVersion.Group, Resource: TransitAdvertisementResource}
+
+// TransitAdvertisementGroupVersionResource is groupResourceVersion used to register these objects.
+var TransitAdvertisementGroupVersionResource = GroupVersion.WithResource(TransitAdvertisementResource)
+
+// TransitAdvertisementSpec defines the desired state of TransitAdvertisement.
+type TransitAdvertisementSpec struct {
+	// Transit contains the CIDRs of the clusters reachable in transit through the origin cluster.
+	Transit []TransitConfig `json:"transit,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=liqo,shortName=ta
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// TransitAdvertisement contains the CIDRs of the clusters a remote cluster is allowed to reach in transit
+// through the local cluster, according to the TransitPolicies. It is created in the tenant namespace of the
+// remote cluster and replicated to it, where it is applied to the spec.remote.transit field of the Configuration.
+type TransitAdvertisement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TransitAdvertisementSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TransitAdvertisementList contains a list of TransitAdvertisement.
+type TransitAdvertisementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TransitAdvertisement `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TransitAdvertisement{}, &TransitAdvertisementList{})
+}
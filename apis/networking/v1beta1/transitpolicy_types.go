@@ -0,0 +1,72 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// TransitPolicyResource the name of the transitpolicy resources.
+var TransitPolicyResource = "transitpolicies"
+
+// TransitPolicyKind is the kind name used to register the TransitPolicy CRD.
+var TransitPolicyKind = "TransitPolicy"
+
+// TransitPolicyGroupResource is group resource used to register these objects.
+var TransitPolicyGroupResource = schema.GroupResource{Group: GroupVersion.Group, Resource: TransitPolicyResource}
+
+// TransitPolicyGroupVersionResource is groupResourceVersion used to register these objects.
+var TransitPolicyGroupVersionResource = GroupVersion.WithResource(TransitPolicyResource)
+
+// TransitPolicySpec defines the desired state of TransitPolicy.
+type TransitPolicySpec struct {
+	// Peers is the pair of remote clusters allowed to reach each other through the local cluster.
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:XValidation:rule="self[0] != self[1]",message="peers must be different clusters"
+	Peers []liqov1beta1.ClusterID `json:"peers"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories=liqo,shortName=tp;transit
+// +kubebuilder:printcolumn:name="Peers",type=string,JSONPath=`.spec.peers`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// TransitPolicy turns the local cluster into a transit gateway between two of its peers,
+// which can reach each other through their tunnels towards the local cluster, without a direct one.
+// The CIDRs of each peer, as remapped by the local cluster, are advertised to the other one
+// through the Configuration of the peering (spec.local.transit) and the TransitAdvertisement replicated to it.
+type TransitPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TransitPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TransitPolicyList contains a list of TransitPolicy.
+type TransitPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TransitPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TransitPolicy{}, &TransitPolicyList{})
+}
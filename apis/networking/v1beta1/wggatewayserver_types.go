@@ -92,6 +92,9 @@ type WgGatewayServerStatus struct {
 	Endpoint *EndpointStatus `json:"endpoint,omitempty"`
 	// InternalEndpoint specifies the endpoint for the internal network.
 	InternalEndpoint *InternalGatewayEndpoint `json:"internalEndpoint,omitempty"`
+	// InternalEndpoints specifies the endpoints for the internal network of all the connected replicas,
+	// when the gateway runs in active-active mode.
+	InternalEndpoints []InternalGatewayEndpoint `json:"internalEndpoints,omitempty"`
 }
 
 // +kubebuilder:object:root=true
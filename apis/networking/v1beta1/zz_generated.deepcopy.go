@@ -19,7 +19,9 @@
 package v1beta1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -27,6 +29,13 @@ import (
 func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	*out = *in
 	in.CIDR.DeepCopyInto(&out.CIDR)
+	if in.Transit != nil {
+		in, out := &in.Transit, &out.Transit
+		*out = make([]TransitConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfig.
@@ -169,7 +178,7 @@ func (in *Connection) DeepCopyInto(out *Connection) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -239,10 +248,77 @@ func (in *ConnectionList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionPathMTU) DeepCopyInto(out *ConnectionPathMTU) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionPathMTU.
+func (in *ConnectionPathMTU) DeepCopy() *ConnectionPathMTU {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionPathMTU)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionQuality) DeepCopyInto(out *ConnectionQuality) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionQuality.
+func (in *ConnectionQuality) DeepCopy() *ConnectionQuality {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionQuality)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionQualityThresholds) DeepCopyInto(out *ConnectionQualityThresholds) {
+	*out = *in
+	if in.MaxLatency != nil {
+		in, out := &in.MaxLatency, &out.MaxLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxJitter != nil {
+		in, out := &in.MaxJitter, &out.MaxJitter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxPacketLossPercentage != nil {
+		in, out := &in.MaxPacketLossPercentage, &out.MaxPacketLossPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionQualityThresholds.
+func (in *ConnectionQualityThresholds) DeepCopy() *ConnectionQualityThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionQualityThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionSpec) DeepCopyInto(out *ConnectionSpec) {
 	*out = *in
 	out.GatewayRef = in.GatewayRef
+	if in.QualityThresholds != nil {
+		in, out := &in.QualityThresholds, &out.QualityThresholds
+		*out = new(ConnectionQualityThresholds)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionSpec.
@@ -259,6 +335,19 @@ func (in *ConnectionSpec) DeepCopy() *ConnectionSpec {
 func (in *ConnectionStatus) DeepCopyInto(out *ConnectionStatus) {
 	*out = *in
 	in.Latency.DeepCopyInto(&out.Latency)
+	in.Quality.DeepCopyInto(&out.Quality)
+	if in.PathMTU != nil {
+		in, out := &in.PathMTU, &out.PathMTU
+		*out = new(ConnectionPathMTU)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionStatus.
@@ -323,9 +412,16 @@ func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
 	}
 	if in.Protocol != nil {
 		in, out := &in.Protocol, &out.Protocol
-		*out = new(v1.Protocol)
+		*out = new(corev1.Protocol)
 		**out = **in
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]ReplicaEndpointStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointStatus.
@@ -516,6 +612,11 @@ func (in *GatewayClientSpec) DeepCopyInto(out *GatewayClientSpec) {
 	out.ClientTemplateRef = in.ClientTemplateRef
 	in.Endpoint.DeepCopyInto(&out.Endpoint)
 	out.SecretRef = in.SecretRef
+	if in.QualityThresholds != nil {
+		in, out := &in.QualityThresholds, &out.QualityThresholds
+		*out = new(ConnectionQualityThresholds)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayClientSpec.
@@ -533,12 +634,12 @@ func (in *GatewayClientStatus) DeepCopyInto(out *GatewayClientStatus) {
 	*out = *in
 	if in.ClientRef != nil {
 		in, out := &in.ClientRef, &out.ClientRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.InternalEndpoint != nil {
@@ -546,6 +647,13 @@ func (in *GatewayClientStatus) DeepCopyInto(out *GatewayClientStatus) {
 		*out = new(InternalGatewayEndpoint)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InternalEndpoints != nil {
+		in, out := &in.InternalEndpoints, &out.InternalEndpoints
+		*out = make([]InternalGatewayEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayClientStatus.
@@ -623,6 +731,11 @@ func (in *GatewayServerSpec) DeepCopyInto(out *GatewayServerSpec) {
 	out.ServerTemplateRef = in.ServerTemplateRef
 	in.Endpoint.DeepCopyInto(&out.Endpoint)
 	out.SecretRef = in.SecretRef
+	if in.QualityThresholds != nil {
+		in, out := &in.QualityThresholds, &out.QualityThresholds
+		*out = new(ConnectionQualityThresholds)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayServerSpec.
@@ -640,7 +753,7 @@ func (in *GatewayServerStatus) DeepCopyInto(out *GatewayServerStatus) {
 	*out = *in
 	if in.ServerRef != nil {
 		in, out := &in.ServerRef, &out.ServerRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.Endpoint != nil {
@@ -650,7 +763,7 @@ func (in *GatewayServerStatus) DeepCopyInto(out *GatewayServerStatus) {
 	}
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.InternalEndpoint != nil {
@@ -658,6 +771,13 @@ func (in *GatewayServerStatus) DeepCopyInto(out *GatewayServerStatus) {
 		*out = new(InternalGatewayEndpoint)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InternalEndpoints != nil {
+		in, out := &in.InternalEndpoints, &out.InternalEndpoints
+		*out = make([]InternalGatewayEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayServerStatus.
@@ -733,12 +853,12 @@ func (in *GeneveTunnelSpec) DeepCopyInto(out *GeneveTunnelSpec) {
 	*out = *in
 	if in.InternalNodeRef != nil {
 		in, out := &in.InternalNodeRef, &out.InternalNodeRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.InternalFabricRef != nil {
 		in, out := &in.InternalFabricRef, &out.InternalFabricRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 }
@@ -754,25 +874,26 @@ func (in *GeneveTunnelSpec) DeepCopy() *GeneveTunnelSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalFabric) DeepCopyInto(out *InternalFabric) {
+func (in *IPsecGatewayClient) DeepCopyInto(out *IPsecGatewayClient) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabric.
-func (in *InternalFabric) DeepCopy() *InternalFabric {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayClient.
+func (in *IPsecGatewayClient) DeepCopy() *IPsecGatewayClient {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalFabric)
+	out := new(IPsecGatewayClient)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InternalFabric) DeepCopyObject() runtime.Object {
+func (in *IPsecGatewayClient) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -780,31 +901,31 @@ func (in *InternalFabric) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalFabricList) DeepCopyInto(out *InternalFabricList) {
+func (in *IPsecGatewayClientList) DeepCopyInto(out *IPsecGatewayClientList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]InternalFabric, len(*in))
+		*out = make([]IPsecGatewayClient, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricList.
-func (in *InternalFabricList) DeepCopy() *InternalFabricList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayClientList.
+func (in *IPsecGatewayClientList) DeepCopy() *IPsecGatewayClientList {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalFabricList)
+	out := new(IPsecGatewayClientList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InternalFabricList) DeepCopyObject() runtime.Object {
+func (in *IPsecGatewayClientList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -812,119 +933,148 @@ func (in *InternalFabricList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalFabricSpec) DeepCopyInto(out *InternalFabricSpec) {
+func (in *IPsecGatewayClientSpec) DeepCopyInto(out *IPsecGatewayClientSpec) {
 	*out = *in
-	if in.RemoteCIDRs != nil {
-		in, out := &in.RemoteCIDRs, &out.RemoteCIDRs
-		*out = make([]CIDR, len(*in))
-		copy(*out, *in)
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(Metrics)
+		(*in).DeepCopyInto(*out)
 	}
-	out.Interface = in.Interface
+	out.SecretRef = in.SecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricSpec.
-func (in *InternalFabricSpec) DeepCopy() *InternalFabricSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayClientSpec.
+func (in *IPsecGatewayClientSpec) DeepCopy() *IPsecGatewayClientSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalFabricSpec)
+	out := new(IPsecGatewayClientSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalFabricSpecInterface) DeepCopyInto(out *InternalFabricSpecInterface) {
+func (in *IPsecGatewayClientStatus) DeepCopyInto(out *IPsecGatewayClientStatus) {
 	*out = *in
-	out.Node = in.Node
-	out.Gateway = in.Gateway
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.InternalEndpoint != nil {
+		in, out := &in.InternalEndpoint, &out.InternalEndpoint
+		*out = new(InternalGatewayEndpoint)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricSpecInterface.
-func (in *InternalFabricSpecInterface) DeepCopy() *InternalFabricSpecInterface {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayClientStatus.
+func (in *IPsecGatewayClientStatus) DeepCopy() *IPsecGatewayClientStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalFabricSpecInterface)
+	out := new(IPsecGatewayClientStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalFabricSpecInterfaceGateway) DeepCopyInto(out *InternalFabricSpecInterfaceGateway) {
+func (in *IPsecGatewayClientTemplate) DeepCopyInto(out *IPsecGatewayClientTemplate) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricSpecInterfaceGateway.
-func (in *InternalFabricSpecInterfaceGateway) DeepCopy() *InternalFabricSpecInterfaceGateway {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayClientTemplate.
+func (in *IPsecGatewayClientTemplate) DeepCopy() *IPsecGatewayClientTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalFabricSpecInterfaceGateway)
+	out := new(IPsecGatewayClientTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPsecGatewayClientTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalFabricSpecInterfaceNode) DeepCopyInto(out *InternalFabricSpecInterfaceNode) {
+func (in *IPsecGatewayClientTemplateList) DeepCopyInto(out *IPsecGatewayClientTemplateList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IPsecGatewayClientTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricSpecInterfaceNode.
-func (in *InternalFabricSpecInterfaceNode) DeepCopy() *InternalFabricSpecInterfaceNode {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayClientTemplateList.
+func (in *IPsecGatewayClientTemplateList) DeepCopy() *IPsecGatewayClientTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalFabricSpecInterfaceNode)
+	out := new(IPsecGatewayClientTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPsecGatewayClientTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalGatewayEndpoint) DeepCopyInto(out *InternalGatewayEndpoint) {
+func (in *IPsecGatewayClientTemplateSpec) DeepCopyInto(out *IPsecGatewayClientTemplateSpec) {
 	*out = *in
-	if in.IP != nil {
-		in, out := &in.IP, &out.IP
-		*out = new(IP)
-		**out = **in
-	}
-	if in.Node != nil {
-		in, out := &in.Node, &out.Node
-		*out = new(string)
-		**out = **in
-	}
+	out.ObjectKind = in.ObjectKind
+	in.Template.DeepCopyInto(&out.Template)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalGatewayEndpoint.
-func (in *InternalGatewayEndpoint) DeepCopy() *InternalGatewayEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayClientTemplateSpec.
+func (in *IPsecGatewayClientTemplateSpec) DeepCopy() *IPsecGatewayClientTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalGatewayEndpoint)
+	out := new(IPsecGatewayClientTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalNode) DeepCopyInto(out *InternalNode) {
+func (in *IPsecGatewayServer) DeepCopyInto(out *IPsecGatewayServer) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNode.
-func (in *InternalNode) DeepCopy() *InternalNode {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayServer.
+func (in *IPsecGatewayServer) DeepCopy() *IPsecGatewayServer {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalNode)
+	out := new(IPsecGatewayServer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InternalNode) DeepCopyObject() runtime.Object {
+func (in *IPsecGatewayServer) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -932,31 +1082,31 @@ func (in *InternalNode) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalNodeList) DeepCopyInto(out *InternalNodeList) {
+func (in *IPsecGatewayServerList) DeepCopyInto(out *IPsecGatewayServerList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]InternalNode, len(*in))
+		*out = make([]IPsecGatewayServer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeList.
-func (in *InternalNodeList) DeepCopy() *InternalNodeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayServerList.
+func (in *IPsecGatewayServerList) DeepCopy() *IPsecGatewayServerList {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalNodeList)
+	out := new(IPsecGatewayServerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InternalNodeList) DeepCopyObject() runtime.Object {
+func (in *IPsecGatewayServerList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -964,130 +1114,686 @@ func (in *InternalNodeList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalNodeSpec) DeepCopyInto(out *InternalNodeSpec) {
+func (in *IPsecGatewayServerSpec) DeepCopyInto(out *IPsecGatewayServerSpec) {
 	*out = *in
-	out.Interface = in.Interface
+	in.Service.DeepCopyInto(&out.Service)
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(Metrics)
+		(*in).DeepCopyInto(*out)
+	}
+	out.SecretRef = in.SecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeSpec.
-func (in *InternalNodeSpec) DeepCopy() *InternalNodeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayServerSpec.
+func (in *IPsecGatewayServerSpec) DeepCopy() *IPsecGatewayServerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalNodeSpec)
+	out := new(IPsecGatewayServerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalNodeSpecInterface) DeepCopyInto(out *InternalNodeSpecInterface) {
+func (in *IPsecGatewayServerStatus) DeepCopyInto(out *IPsecGatewayServerStatus) {
 	*out = *in
-	out.Gateway = in.Gateway
-	out.Node = in.Node
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(EndpointStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InternalEndpoint != nil {
+		in, out := &in.InternalEndpoint, &out.InternalEndpoint
+		*out = new(InternalGatewayEndpoint)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeSpecInterface.
-func (in *InternalNodeSpecInterface) DeepCopy() *InternalNodeSpecInterface {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayServerStatus.
+func (in *IPsecGatewayServerStatus) DeepCopy() *IPsecGatewayServerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalNodeSpecInterface)
+	out := new(IPsecGatewayServerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalNodeSpecInterfaceGateway) DeepCopyInto(out *InternalNodeSpecInterfaceGateway) {
+func (in *IPsecGatewayServerTemplate) DeepCopyInto(out *IPsecGatewayServerTemplate) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeSpecInterfaceGateway.
-func (in *InternalNodeSpecInterfaceGateway) DeepCopy() *InternalNodeSpecInterfaceGateway {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayServerTemplate.
+func (in *IPsecGatewayServerTemplate) DeepCopy() *IPsecGatewayServerTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalNodeSpecInterfaceGateway)
+	out := new(IPsecGatewayServerTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPsecGatewayServerTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalNodeSpecInterfaceNode) DeepCopyInto(out *InternalNodeSpecInterfaceNode) {
+func (in *IPsecGatewayServerTemplateList) DeepCopyInto(out *IPsecGatewayServerTemplateList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IPsecGatewayServerTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeSpecInterfaceNode.
-func (in *InternalNodeSpecInterfaceNode) DeepCopy() *InternalNodeSpecInterfaceNode {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayServerTemplateList.
+func (in *IPsecGatewayServerTemplateList) DeepCopy() *IPsecGatewayServerTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalNodeSpecInterfaceNode)
+	out := new(IPsecGatewayServerTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPsecGatewayServerTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalNodeStatus) DeepCopyInto(out *InternalNodeStatus) {
+func (in *IPsecGatewayServerTemplateSpec) DeepCopyInto(out *IPsecGatewayServerTemplateSpec) {
 	*out = *in
-	in.NodeIP.DeepCopyInto(&out.NodeIP)
+	out.ObjectKind = in.ObjectKind
+	in.Template.DeepCopyInto(&out.Template)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeStatus.
-func (in *InternalNodeStatus) DeepCopy() *InternalNodeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecGatewayServerTemplateSpec.
+func (in *IPsecGatewayServerTemplateSpec) DeepCopy() *IPsecGatewayServerTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalNodeStatus)
+	out := new(IPsecGatewayServerTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalNodeStatusNodeIP) DeepCopyInto(out *InternalNodeStatusNodeIP) {
+func (in *InternalFabric) DeepCopyInto(out *InternalFabric) {
 	*out = *in
-	if in.Local != nil {
-		in, out := &in.Local, &out.Local
-		*out = new(IP)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabric.
+func (in *InternalFabric) DeepCopy() *InternalFabric {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalFabric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InternalFabric) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalFabricList) DeepCopyInto(out *InternalFabricList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InternalFabric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricList.
+func (in *InternalFabricList) DeepCopy() *InternalFabricList {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalFabricList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InternalFabricList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalFabricSpec) DeepCopyInto(out *InternalFabricSpec) {
+	*out = *in
+	if in.RemoteCIDRs != nil {
+		in, out := &in.RemoteCIDRs, &out.RemoteCIDRs
+		*out = make([]CIDR, len(*in))
+		copy(*out, *in)
+	}
+	out.Interface = in.Interface
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]InternalFabricSpecReplica, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricSpec.
+func (in *InternalFabricSpec) DeepCopy() *InternalFabricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalFabricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalFabricSpecInterface) DeepCopyInto(out *InternalFabricSpecInterface) {
+	*out = *in
+	out.Node = in.Node
+	out.Gateway = in.Gateway
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricSpecInterface.
+func (in *InternalFabricSpecInterface) DeepCopy() *InternalFabricSpecInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalFabricSpecInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalFabricSpecInterfaceGateway) DeepCopyInto(out *InternalFabricSpecInterfaceGateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricSpecInterfaceGateway.
+func (in *InternalFabricSpecInterfaceGateway) DeepCopy() *InternalFabricSpecInterfaceGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalFabricSpecInterfaceGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalFabricSpecInterfaceNode) DeepCopyInto(out *InternalFabricSpecInterfaceNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricSpecInterfaceNode.
+func (in *InternalFabricSpecInterfaceNode) DeepCopy() *InternalFabricSpecInterfaceNode {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalFabricSpecInterfaceNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalFabricSpecReplica) DeepCopyInto(out *InternalFabricSpecReplica) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFabricSpecReplica.
+func (in *InternalFabricSpecReplica) DeepCopy() *InternalFabricSpecReplica {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalFabricSpecReplica)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalGatewayEndpoint) DeepCopyInto(out *InternalGatewayEndpoint) {
+	*out = *in
+	if in.IP != nil {
+		in, out := &in.IP, &out.IP
+		*out = new(IP)
+		**out = **in
+	}
+	if in.Node != nil {
+		in, out := &in.Node, &out.Node
+		*out = new(string)
 		**out = **in
 	}
-	if in.Remote != nil {
-		in, out := &in.Remote, &out.Remote
-		*out = new(IP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalGatewayEndpoint.
+func (in *InternalGatewayEndpoint) DeepCopy() *InternalGatewayEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalGatewayEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalNode) DeepCopyInto(out *InternalNode) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNode.
+func (in *InternalNode) DeepCopy() *InternalNode {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InternalNode) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalNodeList) DeepCopyInto(out *InternalNodeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InternalNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeList.
+func (in *InternalNodeList) DeepCopy() *InternalNodeList {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalNodeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InternalNodeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalNodeSpec) DeepCopyInto(out *InternalNodeSpec) {
+	*out = *in
+	out.Interface = in.Interface
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeSpec.
+func (in *InternalNodeSpec) DeepCopy() *InternalNodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalNodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalNodeSpecInterface) DeepCopyInto(out *InternalNodeSpecInterface) {
+	*out = *in
+	out.Gateway = in.Gateway
+	out.Node = in.Node
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeSpecInterface.
+func (in *InternalNodeSpecInterface) DeepCopy() *InternalNodeSpecInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalNodeSpecInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalNodeSpecInterfaceGateway) DeepCopyInto(out *InternalNodeSpecInterfaceGateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeSpecInterfaceGateway.
+func (in *InternalNodeSpecInterfaceGateway) DeepCopy() *InternalNodeSpecInterfaceGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalNodeSpecInterfaceGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalNodeSpecInterfaceNode) DeepCopyInto(out *InternalNodeSpecInterfaceNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeSpecInterfaceNode.
+func (in *InternalNodeSpecInterfaceNode) DeepCopy() *InternalNodeSpecInterfaceNode {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalNodeSpecInterfaceNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalNodeStatus) DeepCopyInto(out *InternalNodeStatus) {
+	*out = *in
+	in.NodeIP.DeepCopyInto(&out.NodeIP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeStatus.
+func (in *InternalNodeStatus) DeepCopy() *InternalNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalNodeStatusNodeIP) DeepCopyInto(out *InternalNodeStatusNodeIP) {
+	*out = *in
+	if in.Local != nil {
+		in, out := &in.Local, &out.Local
+		*out = new(IP)
+		**out = **in
+	}
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(IP)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeStatusNodeIP.
+func (in *InternalNodeStatusNodeIP) DeepCopy() *InternalNodeStatusNodeIP {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalNodeStatusNodeIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Metrics) DeepCopyInto(out *Metrics) {
+	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMonitor != nil {
+		in, out := &in.ServiceMonitor, &out.ServiceMonitor
+		*out = new(ServiceMonitorTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metrics.
+func (in *Metrics) DeepCopy() *Metrics {
+	if in == nil {
+		return nil
+	}
+	out := new(Metrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NextHop) DeepCopyInto(out *NextHop) {
+	*out = *in
+	if in.Gw != nil {
+		in, out := &in.Gw, &out.Gw
+		*out = new(IP)
+		**out = **in
+	}
+	if in.Dev != nil {
+		in, out := &in.Dev, &out.Dev
+		*out = new(string)
+		**out = **in
+	}
+	if in.Onlink != nil {
+		in, out := &in.Onlink, &out.Onlink
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NextHop.
+func (in *NextHop) DeepCopy() *NextHop {
+	if in == nil {
+		return nil
+	}
+	out := new(NextHop)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringNetworkPolicy) DeepCopyInto(out *PeeringNetworkPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringNetworkPolicy.
+func (in *PeeringNetworkPolicy) DeepCopy() *PeeringNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringNetworkPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringNetworkPolicyList) DeepCopyInto(out *PeeringNetworkPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PeeringNetworkPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringNetworkPolicyList.
+func (in *PeeringNetworkPolicyList) DeepCopy() *PeeringNetworkPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringNetworkPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PeeringNetworkPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringNetworkPolicyPeer) DeepCopyInto(out *PeeringNetworkPolicyPeer) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringNetworkPolicyPeer.
+func (in *PeeringNetworkPolicyPeer) DeepCopy() *PeeringNetworkPolicyPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringNetworkPolicyPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringNetworkPolicyPort) DeepCopyInto(out *PeeringNetworkPolicyPort) {
+	*out = *in
+	if in.EndPort != nil {
+		in, out := &in.EndPort, &out.EndPort
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalNodeStatusNodeIP.
-func (in *InternalNodeStatusNodeIP) DeepCopy() *InternalNodeStatusNodeIP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringNetworkPolicyPort.
+func (in *PeeringNetworkPolicyPort) DeepCopy() *PeeringNetworkPolicyPort {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalNodeStatusNodeIP)
+	out := new(PeeringNetworkPolicyPort)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Metrics) DeepCopyInto(out *Metrics) {
+func (in *PeeringNetworkPolicyRule) DeepCopyInto(out *PeeringNetworkPolicyRule) {
 	*out = *in
-	if in.Service != nil {
-		in, out := &in.Service, &out.Service
-		*out = new(ServiceTemplate)
-		(*in).DeepCopyInto(*out)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]PeeringNetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.ServiceMonitor != nil {
-		in, out := &in.ServiceMonitor, &out.ServiceMonitor
-		*out = new(ServiceMonitorTemplate)
-		(*in).DeepCopyInto(*out)
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]PeeringNetworkPolicyPort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metrics.
-func (in *Metrics) DeepCopy() *Metrics {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringNetworkPolicyRule.
+func (in *PeeringNetworkPolicyRule) DeepCopy() *PeeringNetworkPolicyRule {
 	if in == nil {
 		return nil
 	}
-	out := new(Metrics)
+	out := new(PeeringNetworkPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringNetworkPolicySpec) DeepCopyInto(out *PeeringNetworkPolicySpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.PolicyTypes != nil {
+		in, out := &in.PolicyTypes, &out.PolicyTypes
+		*out = make([]PeeringNetworkPolicyType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]PeeringNetworkPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]PeeringNetworkPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringNetworkPolicySpec.
+func (in *PeeringNetworkPolicySpec) DeepCopy() *PeeringNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringNetworkPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1170,6 +1876,26 @@ func (in *PublicKeySpec) DeepCopy() *PublicKeySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaEndpointStatus) DeepCopyInto(out *ReplicaEndpointStatus) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaEndpointStatus.
+func (in *ReplicaEndpointStatus) DeepCopy() *ReplicaEndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -1203,9 +1929,16 @@ func (in *Route) DeepCopyInto(out *Route) {
 		*out = new(Scope)
 		**out = **in
 	}
+	if in.NextHops != nil {
+		in, out := &in.NextHops, &out.NextHops
+		*out = make([]NextHop, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 }
@@ -1370,7 +2103,7 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 	}
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 }
@@ -1431,7 +2164,7 @@ func (in *Table) DeepCopyInto(out *Table) {
 	}
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 }
@@ -1446,6 +2179,180 @@ func (in *Table) DeepCopy() *Table {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitAdvertisement) DeepCopyInto(out *TransitAdvertisement) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitAdvertisement.
+func (in *TransitAdvertisement) DeepCopy() *TransitAdvertisement {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitAdvertisement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TransitAdvertisement) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitAdvertisementList) DeepCopyInto(out *TransitAdvertisementList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TransitAdvertisement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitAdvertisementList.
+func (in *TransitAdvertisementList) DeepCopy() *TransitAdvertisementList {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitAdvertisementList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TransitAdvertisementList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitAdvertisementSpec) DeepCopyInto(out *TransitAdvertisementSpec) {
+	*out = *in
+	if in.Transit != nil {
+		in, out := &in.Transit, &out.Transit
+		*out = make([]TransitConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitAdvertisementSpec.
+func (in *TransitAdvertisementSpec) DeepCopy() *TransitAdvertisementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitAdvertisementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitConfig) DeepCopyInto(out *TransitConfig) {
+	*out = *in
+	in.CIDR.DeepCopyInto(&out.CIDR)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitConfig.
+func (in *TransitConfig) DeepCopy() *TransitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitPolicy) DeepCopyInto(out *TransitPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitPolicy.
+func (in *TransitPolicy) DeepCopy() *TransitPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TransitPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitPolicyList) DeepCopyInto(out *TransitPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TransitPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitPolicyList.
+func (in *TransitPolicyList) DeepCopy() *TransitPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TransitPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitPolicySpec) DeepCopyInto(out *TransitPolicySpec) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]corev1beta1.ClusterID, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitPolicySpec.
+func (in *TransitPolicySpec) DeepCopy() *TransitPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WgGatewayClient) DeepCopyInto(out *WgGatewayClient) {
 	*out = *in
@@ -1532,7 +2439,7 @@ func (in *WgGatewayClientStatus) DeepCopyInto(out *WgGatewayClientStatus) {
 	*out = *in
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.InternalEndpoint != nil {
@@ -1540,6 +2447,13 @@ func (in *WgGatewayClientStatus) DeepCopyInto(out *WgGatewayClientStatus) {
 		*out = new(InternalGatewayEndpoint)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InternalEndpoints != nil {
+		in, out := &in.InternalEndpoints, &out.InternalEndpoints
+		*out = make([]InternalGatewayEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WgGatewayClientStatus.
@@ -1714,7 +2628,7 @@ func (in *WgGatewayServerStatus) DeepCopyInto(out *WgGatewayServerStatus) {
 	*out = *in
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.Endpoint != nil {
@@ -1727,6 +2641,13 @@ func (in *WgGatewayServerStatus) DeepCopyInto(out *WgGatewayServerStatus) {
 		*out = new(InternalGatewayEndpoint)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InternalEndpoints != nil {
+		in, out := &in.InternalEndpoints, &out.InternalEndpoints
+		*out = make([]InternalGatewayEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WgGatewayServerStatus.
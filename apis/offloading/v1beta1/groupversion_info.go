@@ -83,6 +83,33 @@ var (
 	// ShadowEndpointSliceGroupVersionResource is groupResourceVersion used to register these objects.
 	ShadowEndpointSliceGroupVersionResource = SchemeGroupVersion.WithResource(ShadowEndpointSliceResource)
 
+	// MigrationResource is the resource name used to register the Migration CRD.
+	MigrationResource = "migrations"
+
+	// MigrationGroupResource is group resource used to register these objects.
+	MigrationGroupResource = schema.GroupResource{Group: SchemeGroupVersion.Group, Resource: MigrationResource}
+
+	// MigrationGroupVersionResource is groupResourceVersion used to register these objects.
+	MigrationGroupVersionResource = SchemeGroupVersion.WithResource(MigrationResource)
+
+	// OffloadedNamespaceQuotaResource is the resource name used to register the OffloadedNamespaceQuota CRD.
+	OffloadedNamespaceQuotaResource = "offloadednamespacequotas"
+
+	// OffloadedNamespaceQuotaGroupResource is group resource used to register these objects.
+	OffloadedNamespaceQuotaGroupResource = schema.GroupResource{Group: SchemeGroupVersion.Group, Resource: OffloadedNamespaceQuotaResource}
+
+	// OffloadedNamespaceQuotaGroupVersionResource is groupResourceVersion used to register these objects.
+	OffloadedNamespaceQuotaGroupVersionResource = SchemeGroupVersion.WithResource(OffloadedNamespaceQuotaResource)
+
+	// TenantPolicyResource is the resource name used to register the TenantPolicy CRD.
+	TenantPolicyResource = "tenantpolicies"
+
+	// TenantPolicyGroupResource is group resource used to register these objects.
+	TenantPolicyGroupResource = schema.GroupResource{Group: SchemeGroupVersion.Group, Resource: TenantPolicyResource}
+
+	// TenantPolicyGroupVersionResource is groupResourceVersion used to register these objects.
+	TenantPolicyGroupVersionResource = SchemeGroupVersion.WithResource(TenantPolicyResource)
+
 	// VkOptionsTemplateResource is the resource name used to register the VkOptionsTemplate CRD.
 	VkOptionsTemplateResource = "vkoptionstemplates"
 
@@ -0,0 +1,111 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MigrationPhaseType represents the different phases of a workload migration.
+type MigrationPhaseType string
+
+const (
+	// PendingMigrationPhaseType -> the migration has not been validated yet.
+	PendingMigrationPhaseType MigrationPhaseType = "Pending"
+	// EvictingMigrationPhaseType -> the selected pods are being evicted from the source node.
+	EvictingMigrationPhaseType MigrationPhaseType = "Evicting"
+	// MovingVolumesMigrationPhaseType -> the volumes of the selected pods are being moved to the target node.
+	MovingVolumesMigrationPhaseType MigrationPhaseType = "MovingVolumes"
+	// SucceededMigrationPhaseType -> the selected pods have been successfully moved to the target node.
+	SucceededMigrationPhaseType MigrationPhaseType = "Succeeded"
+	// RollingBackMigrationPhaseType -> the migration failed, and the moved volumes and the selected pods are being restored.
+	RollingBackMigrationPhaseType MigrationPhaseType = "RollingBack"
+	// FailedMigrationPhaseType -> the migration failed, and it has been rolled back.
+	FailedMigrationPhaseType MigrationPhaseType = "Failed"
+)
+
+// MigrationSpec defines the desired state of Migration.
+type MigrationSpec struct {
+	// PodSelector selects the pods (in the same namespace of the Migration) to be moved.
+	// An empty selector matches all the pods hosted by the source node.
+	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+	// SourceNode is the name of the virtual node the selected pods are moved away from.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="SourceNode field is immutable"
+	SourceNode string `json:"sourceNode"`
+	// TargetNode is the name of the virtual node the selected pods are moved to. It must satisfy the ClusterSelector
+	// of the NamespaceOffloading of the namespace. If empty, a suitable virtual node is automatically selected.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="TargetNode field is immutable"
+	TargetNode string `json:"targetNode,omitempty"`
+}
+
+// MigrationStatus defines the observed state of Migration.
+type MigrationStatus struct {
+	// Phase -> informs users about the progress of the migration:
+	// "Pending" (i.e. the migration has not been validated yet.)
+	// "Evicting" (i.e. the selected pods are being evicted from the source node.)
+	// "MovingVolumes" (i.e. the volumes of the selected pods are being moved to the target node.)
+	// "Succeeded" (i.e. the selected pods have been successfully moved to the target node.)
+	// "RollingBack" (i.e. the migration failed, and the moved volumes and the selected pods are being restored.)
+	// "Failed" (i.e. the migration failed, and it has been rolled back.)
+	Phase MigrationPhaseType `json:"phase,omitempty"`
+	// TargetNode is the name of the virtual node the selected pods are moved to.
+	TargetNode string `json:"targetNode,omitempty"`
+	// Pods is the list of pods evicted from the source node.
+	Pods []string `json:"pods,omitempty"`
+	// Volumes is the list of persistent volume claims already moved to the target node (and not yet restored
+	// to the source node, in case of rollback).
+	Volumes []string `json:"volumes,omitempty"`
+	// CurrentVolume is the name of the persistent volume claim currently being moved by the volume mover job.
+	CurrentVolume string `json:"currentVolume,omitempty"`
+	// Message is a human-readable message indicating details about the current phase.
+	Message string `json:"message,omitempty"`
+	// StartTime is the time the migration started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the migration completed (either successfully or not).
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=liqo,shortName=mig
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.sourceNode`
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.status.targetNode`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+
+// Migration is the Schema for the migrations API, describing the move of a set of pods (and their volumes)
+// from a virtual node to another.
+type Migration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MigrationSpec   `json:"spec"`
+	Status MigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MigrationList contains a list of Migration.
+type MigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Migration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Migration{}, &MigrationList{})
+}
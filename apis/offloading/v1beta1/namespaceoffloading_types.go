@@ -17,6 +17,8 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 )
 
 // OffloadingPhaseType represents different namespaces offloading status.
@@ -67,6 +69,18 @@ const (
 	LocalAndRemotePodOffloadingStrategyType PodOffloadingStrategyType = "LocalAndRemote"
 )
 
+// SpillOverStrategyType represents different strategies to extend the offloading to lower-ranked clusters.
+type SpillOverStrategyType string
+
+const (
+	// DisabledSpillOverStrategyType -> the namespace is offloaded to all the clusters matching the ClusterSelector,
+	// up to MaxClusters, starting from the highest-ranked ones.
+	DisabledSpillOverStrategyType SpillOverStrategyType = "Disabled"
+	// OnLowCapacitySpillOverStrategyType -> the namespace is offloaded to the highest-ranked cluster, and to the
+	// lower-ranked ones (up to MaxClusters) only when all the preceding ones report low free capacity.
+	OnLowCapacitySpillOverStrategyType SpillOverStrategyType = "OnLowCapacity"
+)
+
 // RemoteNamespaceConditionType represents different conditions that a remote namespace could assume.
 type RemoteNamespaceConditionType string
 
@@ -123,6 +137,48 @@ type NamespaceOffloadingSpec struct {
 	// (https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#node-affinity).
 	// A cluster selector with no NodeSelectorTerms matches all clusters.
 	ClusterSelector corev1.NodeSelector `json:"clusterSelector,omitempty"`
+
+	// ClusterPreferences allows users to rank the remote clusters matching the ClusterSelector through weighted
+	// preference terms, following the standard Kubernetes preferred node affinity approach
+	// (https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#node-affinity-weight).
+	// The score of a cluster is the sum of the weights of the terms it matches, and higher scores are ranked first.
+	ClusterPreferences []corev1.PreferredSchedulingTerm `json:"clusterPreferences,omitempty"`
+
+	// MaxClusters is the maximum number of remote clusters the namespace is offloaded to, starting from the
+	// highest-ranked ones. Zero means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	MaxClusters int32 `json:"maxClusters,omitempty"`
+
+	// SpillOverStrategy allows users to configure whether lower-ranked clusters are selected only when needed, according
+	// to two different strategies: "Disabled" (i.e. all matching clusters are selected, up to MaxClusters), and
+	// "OnLowCapacity" (i.e. a lower-ranked cluster is selected only when all the higher-ranked ones report a free
+	// capacity below the SpillOverThreshold on their VirtualNodes).
+	// +kubebuilder:validation:Enum="Disabled";"OnLowCapacity"
+	// +kubebuilder:default="Disabled"
+	// +kubebuilder:validation:Optional
+	SpillOverStrategy SpillOverStrategyType `json:"spillOverStrategy,omitempty"`
+
+	// SpillOverThreshold is the percentage of free capacity below which a cluster is considered low on resources,
+	// for any of the resources assigned to its VirtualNodes. It is ignored if the SpillOverStrategy is "Disabled".
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Optional
+	SpillOverThreshold int32 `json:"spillOverThreshold,omitempty"`
+}
+
+// RankedCluster contains the ranking information about a remote cluster matching the ClusterSelector.
+type RankedCluster struct {
+	// ClusterID is the ID of the remote cluster.
+	ClusterID liqov1beta1.ClusterID `json:"clusterID"`
+	// Score is the sum of the weights of the ClusterPreferences matched by the remote cluster.
+	Score int64 `json:"score"`
+	// LowCapacity -> whether the remote cluster reports a free capacity below the SpillOverThreshold.
+	// It is evaluated only if the SpillOverStrategy is "OnLowCapacity".
+	LowCapacity bool `json:"lowCapacity,omitempty"`
+	// Selected -> whether the namespace is offloaded to the remote cluster.
+	Selected bool `json:"selected"`
 }
 
 // NamespaceOffloadingStatus defines the observed state of NamespaceOffloading.
@@ -140,6 +196,8 @@ type NamespaceOffloadingStatus struct {
 	// RemoteNamespacesConditions -> allows user to verify remote Namespaces' presence and status on all remote
 	// clusters through RemoteNamespaceCondition.
 	RemoteNamespacesConditions map[string]RemoteNamespaceConditions `json:"remoteNamespacesConditions,omitempty"`
+	// ClusterRanking -> lists the remote clusters matching the ClusterSelector, from the highest-ranked to the lowest-ranked one.
+	ClusterRanking []RankedCluster `json:"clusterRanking,omitempty"`
 	// The generation observed by the NamespaceOffloading controller.
 	// This field allows external tools (e.g., liqoctl) to detect whether a spec modification has already been processed
 	// or not (i.e., whether the status should be expected to be up-to-date or not), and thus act accordingly.
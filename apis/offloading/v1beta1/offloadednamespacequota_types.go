@@ -0,0 +1,89 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// OffloadedNamespaceQuotaSpec defines the desired state of OffloadedNamespaceQuota.
+type OffloadedNamespaceQuotaSpec struct {
+	// Hard is the set of resources (e.g., cpu, memory and pods) the namespace is allowed to consume,
+	// as a whole, across the local cluster and all the remote clusters it is offloaded to.
+	Hard corev1.ResourceList `json:"hard"`
+}
+
+// ClusterQuotaUsage defines the resources consumed by the namespace in a given cluster.
+type ClusterQuotaUsage struct {
+	// ClusterID is the identifier of the cluster.
+	ClusterID liqov1beta1.ClusterID `json:"clusterID"`
+	// Used is the set of resources consumed by the namespace in the cluster.
+	Used corev1.ResourceList `json:"used,omitempty"`
+}
+
+// OffloadedNamespaceQuotaStatus defines the observed state of OffloadedNamespaceQuota.
+type OffloadedNamespaceQuotaStatus struct {
+	// Used is the set of resources consumed by the namespace, aggregated across all clusters.
+	Used corev1.ResourceList `json:"used,omitempty"`
+	// Clusters contains the resources consumed by the namespace in each cluster.
+	Clusters []ClusterQuotaUsage `json:"clusters,omitempty"`
+	// Exceeded is true if the resources consumed by the namespace exceed the hard limits.
+	Exceeded bool `json:"exceeded,omitempty"`
+	// LastUpdateTime is the last time the usage has been aggregated.
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// ClusterUsage returns the resources consumed by the namespace in the given cluster.
+func (s *OffloadedNamespaceQuotaStatus) ClusterUsage(clusterID liqov1beta1.ClusterID) corev1.ResourceList {
+	for i := range s.Clusters {
+		if s.Clusters[i].ClusterID == clusterID {
+			return s.Clusters[i].Used
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=liqo,shortName=onq
+// +kubebuilder:subresource:status
+// +genclient
+// +kubebuilder:printcolumn:name="Exceeded",type=boolean,JSONPath=`.status.exceeded`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// OffloadedNamespaceQuota defines a resource budget shared by an offloaded namespace
+// across the local cluster and all the remote clusters it is offloaded to.
+type OffloadedNamespaceQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OffloadedNamespaceQuotaSpec   `json:"spec"`
+	Status OffloadedNamespaceQuotaStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OffloadedNamespaceQuotaList contains a list of OffloadedNamespaceQuota.
+type OffloadedNamespaceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OffloadedNamespaceQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OffloadedNamespaceQuota{}, &OffloadedNamespaceQuotaList{})
+}
@@ -0,0 +1,74 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantPolicySpec defines the constraints enforced on the pods offloaded by the tenants referencing the policy.
+type TenantPolicySpec struct {
+	// AllowedImageRegistries is the list of registries the container images can be pulled from, possibly including
+	// a repository path prefix (e.g., "ghcr.io/liqotech"). Images not specifying any registry are pulled from "docker.io".
+	// An empty list allows any registry.
+	AllowedImageRegistries []string `json:"allowedImageRegistries,omitempty"`
+	// AllowPrivileged allows the offloaded containers to run in privileged mode, to allow privilege escalation
+	// (which must be otherwise explicitly disabled), to add capabilities beyond the ones allowed by the baseline
+	// Pod Security Standard, and to relax the sandboxing (sysctls, proc mount, SELinux, seccomp, AppArmor and
+	// Windows host processes) configured through the pod and container security contexts.
+	AllowPrivileged bool `json:"allowPrivileged,omitempty"`
+	// AllowHostPathVolumes allows the offloaded pods to mount hostPath volumes.
+	AllowHostPathVolumes bool `json:"allowHostPathVolumes,omitempty"`
+	// AllowHostNamespaces allows the offloaded pods to share the host network, PID and IPC namespaces,
+	// and to bind the ports of the host.
+	AllowHostNamespaces bool `json:"allowHostNamespaces,omitempty"`
+	// AllowedRuntimeClasses is the list of runtime classes the offloaded pods can request.
+	// An empty list allows any runtime class.
+	AllowedRuntimeClasses []string `json:"allowedRuntimeClasses,omitempty"`
+	// DefaultRuntimeClass is the runtime class assigned to the offloaded pods not requesting any.
+	DefaultRuntimeClass *string `json:"defaultRuntimeClass,omitempty"`
+}
+
+// TenantPolicyDeniedReason is the reason of the admission responses denying the ShadowPods violating a TenantPolicy,
+// which allows the consumer cluster to distinguish them from any other forbidden request.
+const TenantPolicyDeniedReason metav1.StatusReason = "TenantPolicyDenied"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories=liqo,shortName=tp;tpol
+// +kubebuilder:printcolumn:name="Privileged",type=boolean,JSONPath=`.spec.allowPrivileged`
+// +kubebuilder:printcolumn:name="HostPath",type=boolean,JSONPath=`.spec.allowHostPathVolumes`
+// +kubebuilder:printcolumn:name="HostNamespaces",type=boolean,JSONPath=`.spec.allowHostNamespaces`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// TenantPolicy defines the admission policy enforced by the provider cluster on the pods offloaded by a given tenant.
+type TenantPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TenantPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantPolicyList contains a list of TenantPolicy.
+type TenantPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantPolicy{}, &TenantPolicyList{})
+}
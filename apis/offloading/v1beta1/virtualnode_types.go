@@ -83,6 +83,9 @@ type VirtualNodeSpec struct {
 	IngressClasses []liqov1beta1.IngressType `json:"ingressClasses,omitempty"`
 	// LoadBalancerClasses contains the list of the load balancer classes offered by the cluster.
 	LoadBalancerClasses []liqov1beta1.LoadBalancerType `json:"loadBalancerClasses,omitempty"`
+	// DisabledReflectors contains the list of reflectors to be disabled, overriding the configuration of the VkOptionsTemplate
+	// (e.g., as the corresponding permissions are not granted by the provider cluster).
+	DisabledReflectors []string `json:"disabledReflectors,omitempty"`
 	// VkOptionsTemplateRef contains the namespaced reference to the VkOptionsTemplate.
 	// If not set, the default template installed with Liqo will be used.
 	// +optional
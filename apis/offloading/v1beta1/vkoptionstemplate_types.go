@@ -21,24 +21,26 @@ import (
 
 // VkOptionsTemplateSpec defines the desired state of VkOptionsTemplate.
 type VkOptionsTemplateSpec struct {
-	CreateNode              bool                          `json:"createNode"`
-	DisableNetworkCheck     bool                          `json:"disableNetworkCheck"`
-	ContainerImage          string                        `json:"containerImage"`
-	MetricsEnabled          bool                          `json:"metricsEnabled"`
-	MetricsAddress          string                        `json:"metricsAddress,omitempty"`
-	LabelsNotReflected      []string                      `json:"labelsNotReflected,omitempty"`
-	AnnotationsNotReflected []string                      `json:"annotationsNotReflected,omitempty"`
-	ReflectorsConfig        map[string]ReflectorConfig    `json:"reflectorsConfig,omitempty"`
-	Resources               corev1.ResourceRequirements   `json:"resources,omitempty"`
-	ExtraArgs               []string                      `json:"extraArgs,omitempty"`
-	ExtraAnnotations        map[string]string             `json:"extraAnnotations,omitempty"`
-	ExtraLabels             map[string]string             `json:"extraLabels,omitempty"`
-	NodeExtraAnnotations    map[string]string             `json:"nodeExtraAnnotations,omitempty"`
-	NodeExtraLabels         map[string]string             `json:"nodeExtraLabels,omitempty"`
-	Replicas                *int32                        `json:"replicas,omitempty"`
-	ImagePullSecrets        []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
-	PullPolicy              corev1.PullPolicy             `json:"pullPolicy,omitempty"`
-	Tolerations             []corev1.Toleration           `json:"tolerations,omitempty"`
+	CreateNode              bool                       `json:"createNode"`
+	DisableNetworkCheck     bool                       `json:"disableNetworkCheck"`
+	ContainerImage          string                     `json:"containerImage"`
+	MetricsEnabled          bool                       `json:"metricsEnabled"`
+	MetricsAddress          string                     `json:"metricsAddress,omitempty"`
+	LabelsNotReflected      []string                   `json:"labelsNotReflected,omitempty"`
+	AnnotationsNotReflected []string                   `json:"annotationsNotReflected,omitempty"`
+	ReflectorsConfig        map[string]ReflectorConfig `json:"reflectorsConfig,omitempty"`
+	// CustomResourceReflectors configures the reflection of arbitrary custom resources in the offloaded namespaces.
+	CustomResourceReflectors []CustomResourceReflectorConfig `json:"customResourceReflectors,omitempty"`
+	Resources                corev1.ResourceRequirements     `json:"resources,omitempty"`
+	ExtraArgs                []string                        `json:"extraArgs,omitempty"`
+	ExtraAnnotations         map[string]string               `json:"extraAnnotations,omitempty"`
+	ExtraLabels              map[string]string               `json:"extraLabels,omitempty"`
+	NodeExtraAnnotations     map[string]string               `json:"nodeExtraAnnotations,omitempty"`
+	NodeExtraLabels          map[string]string               `json:"nodeExtraLabels,omitempty"`
+	Replicas                 *int32                          `json:"replicas,omitempty"`
+	ImagePullSecrets         []corev1.LocalObjectReference   `json:"imagePullSecrets,omitempty"`
+	PullPolicy               corev1.PullPolicy               `json:"pullPolicy,omitempty"`
+	Tolerations              []corev1.Toleration             `json:"tolerations,omitempty"`
 }
 
 // ReflectorConfig contains configuration parameters of the reflector.
@@ -47,8 +49,61 @@ type ReflectorConfig struct {
 	NumWorkers uint `json:"workers"`
 	// Type of reflection.
 	Type ReflectionType `json:"type,omitempty"`
+	// Policy further restricts the objects to be reflected, and the fields reflected for each of them.
+	// It is currently supported by the configmap, secret and service reflectors (the latter applying it
+	// also to the corresponding endpointslices).
+	Policy *ReflectionPolicy `json:"policy,omitempty"`
 }
 
+// ReflectionPolicy defines the criteria to select the objects to be reflected, and the fields to be filtered out.
+// An object is reflected only if it matches all the specified criteria, in addition to the ones of the reflection type.
+type ReflectionPolicy struct {
+	// NamespaceSelector selects the local namespaces whose objects are reflected, according to their labels.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ObjectSelector selects the objects to be reflected, according to their labels.
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+	// NameRegex selects the objects to be reflected, according to their name.
+	NameRegex string `json:"nameRegex,omitempty"`
+	// ExcludedKeys is the list of the data keys which are not reflected (only for configmaps and secrets).
+	ExcludedKeys []string `json:"excludedKeys,omitempty"`
+	// ExcludedPorts is the list of the ports (either name or number) which are not reflected (only for services).
+	ExcludedPorts []string `json:"excludedPorts,omitempty"`
+}
+
+// CustomResourceReflectorConfig contains the configuration parameters of the reflector of a custom resource.
+type CustomResourceReflectorConfig struct {
+	// Group is the API group of the custom resource.
+	Group string `json:"group"`
+	// Version is the API version of the custom resource.
+	Version string `json:"version"`
+	// Resource is the plural name of the custom resource.
+	Resource string `json:"resource"`
+	// Direction of the reflection.
+	// +kubebuilder:validation:Enum=LocalToRemote;RemoteToLocalStatus
+	// +kubebuilder:default=LocalToRemote
+	Direction CustomResourceReflectionDirection `json:"direction,omitempty"`
+	// Number of workers for the reflector.
+	NumWorkers uint `json:"workers"`
+	// Type of reflection.
+	// +kubebuilder:validation:Enum=DenyList;AllowList
+	// +kubebuilder:default=DenyList
+	Type ReflectionType `json:"type,omitempty"`
+	// PrunedFields is the list of the fields (dot-separated paths, e.g., spec.secretName) which are not reflected.
+	// The metadata (except for labels and annotations) and the status are never reflected to the remote cluster.
+	PrunedFields []string `json:"prunedFields,omitempty"`
+}
+
+// CustomResourceReflectionDirection is the direction of the reflection of a custom resource.
+type CustomResourceReflectionDirection string
+
+const (
+	// LocalToRemote reflects the local objects to the remote cluster.
+	LocalToRemote CustomResourceReflectionDirection = "LocalToRemote"
+	// RemoteToLocalStatus reflects the local objects to the remote cluster, and the status of the remote objects
+	// back to the local ones.
+	RemoteToLocalStatus CustomResourceReflectionDirection = "RemoteToLocalStatus"
+)
+
 // ReflectionType is the type of reflection.
 type ReflectionType string
 
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
@@ -45,6 +46,7 @@ import (
 	"github.com/liqotech/liqo/pkg/route"
 	argsutils "github.com/liqotech/liqo/pkg/utils/args"
 	flagsutils "github.com/liqotech/liqo/pkg/utils/flags"
+	"github.com/liqotech/liqo/pkg/utils/kernel"
 	kernelversion "github.com/liqotech/liqo/pkg/utils/kernel/version"
 	"github.com/liqotech/liqo/pkg/utils/mapper"
 	"github.com/liqotech/liqo/pkg/utils/resource"
@@ -101,6 +103,12 @@ func run(cmd *cobra.Command, _ []string) error {
 	// Set controller-runtime logger.
 	log.SetLogger(klog.NewKlogr())
 
+	if options.EnableMultipathL4Hashing {
+		if err := kernel.EnableMultipathL4Hashing(); err != nil {
+			return fmt.Errorf("unable to enable the multipath layer 4 hashing: %w", err)
+		}
+	}
+
 	// Initialize global labels from flag
 	resource.SetGlobalLabels(globalLabels.StringMap)
 	resource.SetGlobalAnnotations(globalAnnotations.StringMap)
@@ -182,10 +190,15 @@ func run(cmd *cobra.Command, _ []string) error {
 	}
 
 	if err := fwcr.SetupWithManager(cmd.Context(), mgr,
-		options.EnableNftMonitor, options.ReconcileTimeout); err != nil {
+		options.EnableNftMonitor, options.ReconcileTimeout, options.FirewallCountersPeriod); err != nil {
 		return fmt.Errorf("unable to setup firewall configuration reconciler: %w", err)
 	}
 
+	// Register the firewall rule counters metrics inside the controller-runtime metrics server.
+	if err := metrics.Registry.Register(fwcr); err != nil {
+		return fmt.Errorf("unable to register firewall metrics: %w", err)
+	}
+
 	// Setup the route configuration controller.
 	rcr, err := route.NewRouteConfigurationReconcilerWithFinalizer(
 		mgr.GetClient(),
@@ -217,6 +230,20 @@ func run(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("unable to setup internal fabric reconciler: %w", err)
 	}
 
+	enr, err := fabric.NewEncryptionReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		mgr.GetEventRecorderFor("fabric-encryption-controller"),
+		options,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create fabric encryption reconciler: %w", err)
+	}
+
+	if err := enr.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to setup fabric encryption reconciler: %w", err)
+	}
+
 	// Start the manager.
 	return mgr.Start(cmd.Context())
 }
@@ -119,7 +119,21 @@ func run(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("unable to setup internalnode reconciler: %w", err)
 	}
 
-	if options.GwOptions.LeaderElection {
+	enr, err := geneve.NewEncryptionReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		mgr.GetEventRecorderFor("fabric-encryption-controller"),
+		options,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create fabric encryption reconciler: %w", err)
+	}
+
+	if err := enr.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to setup fabric encryption reconciler: %w", err)
+	}
+
+	if options.GwOptions.LeaderElection || options.GwOptions.ActiveActive {
 		runnableGuest, err := concurrent.NewRunnableGuest(options.GwOptions.ContainerName)
 		if err != nil {
 			return fmt.Errorf("unable to create runnable guest: %w", err)
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
@@ -101,6 +102,10 @@ func main() {
 func run(cmd *cobra.Command, _ []string) error {
 	var err error
 
+	if connoptions.GwOptions.ActiveActive && connoptions.GwOptions.LeaderElection {
+		return fmt.Errorf("flags --%s and --%s are mutually exclusive", gateway.FlagNameActiveActive, gateway.FlagNameLeaderElection)
+	}
+
 	// Check if the minimum kernel version is satisfied.
 	if !connoptions.GwOptions.DisableKernelVersionCheck {
 		if err := kernelversion.CheckKernelVersion(&connoptions.GwOptions.MinimumKernelVersion); err != nil {
@@ -185,6 +190,11 @@ func run(cmd *cobra.Command, _ []string) error {
 		if err = connr.SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup connections reconciler: %w", err)
 		}
+
+		// Register the connection quality metrics inside the controller-runtime metrics server.
+		if err := metrics.Registry.Register(connr.ConnChecker); err != nil {
+			return fmt.Errorf("unable to register connection quality metrics: %w", err)
+		}
 	}
 
 	rcr, err := route.NewRouteConfigurationReconcilerWithoutFinalizer(
@@ -225,17 +235,23 @@ func run(cmd *cobra.Command, _ []string) error {
 	}
 
 	if err := fwcr.SetupWithManager(cmd.Context(), mgr,
-		connoptions.GwOptions.EnableNftMonitor, connoptions.GwOptions.ReconcileTimeout); err != nil {
+		connoptions.GwOptions.EnableNftMonitor, connoptions.GwOptions.ReconcileTimeout, connoptions.GwOptions.FirewallCountersPeriod); err != nil {
 		return fmt.Errorf("unable to setup firewall configuration reconciler: %w", err)
 	}
 
-	if connoptions.GwOptions.LeaderElection {
+	// Register the firewall rule counters metrics inside the controller-runtime metrics server.
+	if err := metrics.Registry.Register(fwcr); err != nil {
+		return fmt.Errorf("unable to register firewall metrics: %w", err)
+	}
+
+	if connoptions.GwOptions.LeaderElection || connoptions.GwOptions.ActiveActive {
 		runnable, err := concurrent.NewRunnableGatewayStartup(
 			cl,
 			connoptions.GwOptions.PodName,
 			connoptions.GwOptions.Name,
 			connoptions.GwOptions.Namespace,
 			connoptions.GwOptions.ConcurrentContainersNames,
+			connoptions.GwOptions.ActiveActive,
 		)
 		if err != nil {
 			return fmt.Errorf("unable to create concurrent runnable: %w", err)
@@ -38,6 +38,7 @@ import (
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	"github.com/liqotech/liqo/pkg/gateway"
 	"github.com/liqotech/liqo/pkg/gateway/concurrent"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
 	"github.com/liqotech/liqo/pkg/gateway/tunnel/wireguard"
 	flagsutils "github.com/liqotech/liqo/pkg/utils/flags"
 	"github.com/liqotech/liqo/pkg/utils/mapper"
@@ -128,15 +129,6 @@ func run(cmd *cobra.Command, _ []string) error {
 	}
 
 	dnsChan := make(chan event.GenericEvent)
-	if options.GwOptions.Mode == gateway.ModeClient {
-		if wireguard.IsDNSRoutineRequired(options) {
-			go wireguard.StartDNSRoutine(cmd.Context(), dnsChan, options)
-			klog.Infof("Starting DNS routine: resolving the endpoint address every %s", options.DNSCheckInterval.String())
-		} else {
-			options.EndpointIP = net.ParseIP(options.EndpointAddress)
-			klog.Infof("Setting static endpoint IP: %s", options.EndpointIP.String())
-		}
-	}
 
 	// Setup the controller.
 	if err = pkr.SetupWithManager(mgr, dnsChan); err != nil {
@@ -166,7 +158,7 @@ func run(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("unable to register prometheus collector: %w", err)
 	}
 
-	if options.GwOptions.LeaderElection {
+	if options.GwOptions.LeaderElection || options.GwOptions.ActiveActive {
 		runnable, err := concurrent.NewRunnableGuest(options.GwOptions.ContainerName)
 		if err != nil {
 			return fmt.Errorf("unable to create runnable guest: %w", err)
@@ -177,6 +169,23 @@ func run(cmd *cobra.Command, _ []string) error {
 		defer runnable.Close()
 	}
 
+	if options.GwOptions.Mode == gateway.ModeClient {
+		// In active-active mode, the replica index is assigned by the gateway container before starting the sidecars.
+		if options.GwOptions.ActiveActive {
+			if err := wireguard.SetReplicaEndpoint(cmd.Context(), mgr.GetAPIReader(), options); err != nil {
+				return fmt.Errorf("unable to set the replica endpoint: %w", err)
+			}
+		}
+
+		if tunnel.IsDNSRoutineRequired(&options.EndpointOptions) {
+			go tunnel.StartDNSRoutine(cmd.Context(), dnsChan, &options.EndpointOptions)
+			klog.Infof("Starting DNS routine: resolving the endpoint address every %s", options.DNSCheckInterval.String())
+		} else {
+			options.SetEndpointIP(net.ParseIP(options.EndpointAddress))
+			klog.Infof("Setting static endpoint IP: %s", options.EndpointIP.String())
+		}
+	}
+
 	// Start the manager.
 	return mgr.Start(cmd.Context())
 }
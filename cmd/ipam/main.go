@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -77,8 +78,8 @@ func main() {
 	cmd.Flags().DurationVar(&options.ServerOpts.SyncGracePeriod, "sync-graceperiod", consts.SyncGracePeriod,
 		"The grace period the sync routine wait before releasing an ip or a network.")
 	cmd.Flags().BoolVar(&options.ServerOpts.GraphvizEnabled, "enable-graphviz", false, "Enable the graphviz output for the IPAM.")
-	cmd.Flags().StringSliceVar(&options.ServerOpts.Pools, "pools", consts.PrivateAddressSpace,
-		"The pools used by the IPAM to acquire Networks and IPs from. Default: private addesses space.",
+	cmd.Flags().StringSliceVar(&options.ServerOpts.Pools, "pools", slices.Concat(consts.PrivateAddressSpace, consts.PrivateAddressSpaceIPv6),
+		"The pools used by the IPAM to acquire Networks and IPs from, of either IP family. Default: private addesses space.",
 	)
 
 	// Leader election flags.
@@ -33,6 +33,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/klog/v2"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -46,13 +47,18 @@ import (
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/cmd/liqo-controller-manager/modules"
+	"github.com/liqotech/liqo/pkg/audit"
+	"github.com/liqotech/liqo/pkg/auth"
 	identitymanager "github.com/liqotech/liqo/pkg/identityManager"
+	"github.com/liqotech/liqo/pkg/identityManager/signer"
 	"github.com/liqotech/liqo/pkg/ipam"
 	liqocontrollermanager "github.com/liqotech/liqo/pkg/liqo-controller-manager"
 	foreignclustercontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/core/foreigncluster-controller"
 	ipmapping "github.com/liqotech/liqo/pkg/liqo-controller-manager/ipmapping"
 	quotacreatorcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/quotacreator-controller"
+	resourcesliceautoscalercontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/resourcesliceautoscaler-controller"
 	virtualnodecreatorcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/virtualnodecreator-controller"
+	"github.com/liqotech/liqo/pkg/resourceoffer"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 	dynamicutils "github.com/liqotech/liqo/pkg/utils/dynamic"
 	liqoerrors "github.com/liqotech/liqo/pkg/utils/errors"
@@ -125,6 +131,10 @@ func run(cmd *cobra.Command, _ []string) error {
 	resource.SetGlobalLabels(opts.GlobalLabels.StringMap)
 	resource.SetGlobalAnnotations(opts.GlobalAnnotations.StringMap)
 
+	if err := audit.Setup(cmd.Context(), "liqo-controller-manager", opts.AuditConfig); err != nil {
+		return fmt.Errorf("unable to setup the audit stream: %w", err)
+	}
+
 	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		MapperProvider: mapper.LiqoMapperProvider(scheme),
 		Scheme:         scheme,
@@ -188,7 +198,7 @@ func run(cmd *cobra.Command, _ []string) error {
 			ipamClient = ipam.NewIPAMClient(conn)
 		}
 
-		netOpts := modules.NewNetworkingOption(factory, dynClient, ipamClient, opts)
+		netOpts := modules.NewNetworkingOption(factory, dynClient, ipamClient, clusterID, opts)
 
 		if err := modules.SetupNetworkingModule(cmd.Context(), mgr, uncachedClient, netOpts); err != nil {
 			return fmt.Errorf("unable to setup the networking module: %w", err)
@@ -198,16 +208,51 @@ func run(cmd *cobra.Command, _ []string) error {
 	// AUTHENTICATION MODULE
 	if opts.AuthenticationEnabled {
 		var idProvider identitymanager.IdentityProvider
-		if opts.AWSConfig.IsEmpty() {
-			idProvider = identitymanager.NewCertificateIdentityProvider(cmd.Context(),
-				mgr.GetClient(), clientset, config, clusterID, namespaceManager)
-		} else {
+		switch {
+		case !opts.AWSConfig.IsEmpty():
 			idProvider = identitymanager.NewIAMIdentityProvider(cmd.Context(),
 				mgr.GetClient(), clientset, clusterID, opts.AWSConfig, namespaceManager)
+		case !opts.OIDCConfig.IsEmpty():
+			if err := opts.OIDCConfig.Validate(); err != nil {
+				return fmt.Errorf("invalid OIDC configuration: %w", err)
+			}
+			// The remote identities are bound to the local permissions with the prefixes added by the API server.
+			auth.SetIdentityPrefixes(opts.OIDCConfig.UsernamePrefix, opts.OIDCConfig.GroupsPrefix)
+			idProvider = identitymanager.NewOIDCIdentityProvider(cmd.Context(),
+				mgr.GetClient(), clientset, config, clusterID, opts.OIDCConfig, namespaceManager)
+		default:
+			opts.SignerConfig.Backend = authv1beta1.SignerBackend(opts.CertificateSigner.Value)
+			if opts.SignerConfig.CertManager.Namespace == "" {
+				opts.SignerConfig.CertManager.Namespace = opts.LiqoNamespace
+			}
+			certSigner, err := signer.New(cmd.Context(), opts.SignerConfig, clientset, mgr.GetClient())
+			if err != nil {
+				return fmt.Errorf("unable to setup the certificate signer: %w", err)
+			}
+			idProvider = identitymanager.NewCertificateIdentityProviderWithSigner(cmd.Context(),
+				mgr.GetClient(), clientset, config, clusterID, namespaceManager, certSigner)
 		}
 
 		authOpts := modules.NewAuthOption(idProvider, namespaceManager, clusterID, opts)
 
+		// Connect to the ResourceOffer plugins in charge of the custom ResourceSlice classes, if any.
+		for class, address := range opts.ResourceSlicePlugins.StringMap {
+			switch authv1beta1.ResourceSliceClass(class) {
+			case authv1beta1.ResourceSliceClassDefault, authv1beta1.ResourceSliceClassUnknown, authv1beta1.ResourceSliceClassCapacity:
+				return fmt.Errorf("the ResourceSlice class %q is built-in and cannot be managed by a plugin", class)
+			}
+
+			klog.Infof("connecting to the ResourceOffer plugin %q for the ResourceSlice class %q", address, class)
+			conn, err := resourceoffer.NewClientConn(address, opts.ResourceSlicePluginsTLS)
+			if err != nil {
+				return fmt.Errorf("failed to establish a connection to the ResourceOffer plugin %q: %w", address, err)
+			}
+
+			defer conn.Close()
+
+			authOpts.ResourceOfferPlugins[authv1beta1.ResourceSliceClass(class)] = resourceoffer.NewResourceOfferClient(conn)
+		}
+
 		if err := modules.SetupAuthenticationModule(cmd.Context(), mgr, uncachedClient, authOpts); err != nil {
 			return fmt.Errorf("unable to setup the authentication module: %w", err)
 		}
@@ -240,6 +285,18 @@ func run(cmd *cobra.Command, _ []string) error {
 		if err := quotaCreatorReconciler.SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup the quotacreator reconciler: %w", err)
 		}
+
+		// Configure controller that resizes the autoscaled resourceslices.
+		metricsClient, err := metrics.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			return fmt.Errorf("unable to create the metrics client: %w", err)
+		}
+		autoscalerReconciler := resourcesliceautoscalercontroller.NewResourceSliceAutoscalerReconciler(
+			mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("resourcesliceautoscaler-controller"),
+			metricsClient.MetricsV1beta1(), opts.AutoscalingSyncPeriod, opts.AutoscalingScaleDownDelay)
+		if err := autoscalerReconciler.SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup the resourcesliceautoscaler reconciler: %w", err)
+		}
 	}
 
 	// OFFLOADING MODULE & NETWORKING MODULE
@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	identitymanager "github.com/liqotech/liqo/pkg/identityManager"
 	liqocontrollermanager "github.com/liqotech/liqo/pkg/liqo-controller-manager"
@@ -32,9 +33,11 @@ import (
 	localresourceslicecontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/localresourceslice-controller"
 	noncecreatorcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/noncecreator-controller"
 	noncesigner "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/noncesigner-controller"
+	peeringroleprofilecontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/peeringroleprofile-controller"
 	remoterenwercontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/remoterenwer-controller"
 	remoteresourceslicecontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/remoteresourceslice-controller"
 	tenantcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/tenant-controller"
+	"github.com/liqotech/liqo/pkg/resourceoffer"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 )
 
@@ -49,6 +52,7 @@ type AuthOption struct {
 	TrustedCA                bool
 	TLSCompatibilityMode     bool
 	SliceStatusOptions       *remoteresourceslicecontroller.SliceStatusOptions
+	ResourceOfferPlugins     map[authv1beta1.ResourceSliceClass]resourceoffer.ResourceOfferClient
 }
 
 // NewAuthOption creates a new AuthOption with the given parameters.
@@ -70,7 +74,9 @@ func NewAuthOption(identityProvider identitymanager.IdentityProvider, namespaceM
 			LoadBalancerClasses:       opts.LoadBalancerClasses,
 			ClusterLabels:             opts.ClusterLabels.StringMap,
 			DefaultResourceQuantity:   opts.DefaultNodeResources.ToResourceList(),
+			CapacityResyncPeriod:      opts.CapacityResyncPeriod,
 		},
+		ResourceOfferPlugins: map[authv1beta1.ResourceSliceClass]resourceoffer.ResourceOfferClient{},
 	}
 }
 
@@ -122,6 +128,14 @@ func SetupAuthenticationModule(ctx context.Context, mgr manager.Manager, uncache
 		return err
 	}
 
+	// Configure controller that enforces the ClusterRoles derived from the PeeringRoleProfiles.
+	peeringRoleProfileReconciler := peeringroleprofilecontroller.NewPeeringRoleProfileReconciler(mgr.GetClient(), mgr.GetScheme(),
+		mgr.GetEventRecorderFor("peeringroleprofile-controller"))
+	if err := peeringRoleProfileReconciler.SetupWithManager(mgr); err != nil {
+		klog.Errorf("Unable to setup the peering role profile reconciler: %v", err)
+		return err
+	}
+
 	// Configure controller that creates Kubeconfig secrets for each identities.
 	identityReconciler := identitycontroller.NewIdentityReconciler(mgr.GetClient(), mgr.GetScheme(),
 		mgr.GetEventRecorderFor("identity-controller"), opts.LiqoNamespace)
@@ -144,7 +158,7 @@ func SetupAuthenticationModule(ctx context.Context, mgr manager.Manager, uncache
 		mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("remoteresourceslice-controller"),
 		opts.IdentityProvider, opts.NamespaceManager,
 		opts.APIServerAddressOverride, caOverride, opts.TrustedCA,
-		opts.SliceStatusOptions)
+		opts.SliceStatusOptions, opts.ResourceOfferPlugins)
 	if err := remoteResourceSliceReconciler.SetupWithManager(mgr); err != nil {
 		klog.Errorf("Unable to setup the remote resource slice reconciler: %v", err)
 		return err
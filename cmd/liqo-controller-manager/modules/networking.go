@@ -24,14 +24,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	ipamv1alpha1 "github.com/liqotech/liqo/apis/ipam/v1alpha1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/ipam"
 	liqocontrollermanager "github.com/liqotech/liqo/pkg/liqo-controller-manager"
 	clientoperator "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/client-operator"
 	configuration "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/configuration"
+	ipsecgatewaycontrollers "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/ipsec"
+	mssclamp "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/mss-clamp"
+	networkpolicy "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/network-policy"
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/remapping"
 	externalnetworkroute "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/route"
 	serveroperator "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/server-operator"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/transit"
 	wggatewaycontrollers "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/wireguard"
 	internalclientcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/internal-network/client-controller"
 	internalconfigurationcontroller "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/internal-network/configuration-controller"
@@ -54,21 +61,33 @@ type NetworkingOption struct {
 	LiqoNamespace string
 	IpamClient    ipam.IPAMClient
 
-	GatewayServerResources         []string
-	GatewayClientResources         []string
-	WgGatewayServerClusterRoleName string
-	WgGatewayClientClusterRoleName string
-	NetworkWorkers                 int
-	IPWorkers                      int
-	FabricFullMasquerade           bool
-	GwmasqbypassEnabled            bool
+	GatewayServerResources            []string
+	GatewayClientResources            []string
+	WgGatewayServerClusterRoleName    string
+	WgGatewayClientClusterRoleName    string
+	IPsecGatewayServerClusterRoleName string
+	IPsecGatewayClientClusterRoleName string
+	NetworkWorkers                    int
+	IPWorkers                         int
+	FabricFullMasquerade              bool
+	GwmasqbypassEnabled               bool
+	FabricEncryption                  networkingv1beta1.InternalFabricEncryptionMode
 
 	GenevePort uint16
+
+	LocalClusterID               liqov1beta1.ClusterID
+	TransitAcceptedClusters      []liqov1beta1.ClusterID
+	TransitMaxAdvertisedClusters int
 }
 
 // NewNetworkingOption creates a new NetworkingOption with the provided parameters.
 func NewNetworkingOption(factory *dynamicutils.RunnableFactory, dynClient dynamic.Interface,
-	ipamClient ipam.IPAMClient, opts *liqocontrollermanager.Options) *NetworkingOption {
+	ipamClient ipam.IPAMClient, clusterID liqov1beta1.ClusterID, opts *liqocontrollermanager.Options) *NetworkingOption {
+	acceptedClusters := make([]liqov1beta1.ClusterID, len(opts.TransitAcceptedClusters.StringList))
+	for i, cluster := range opts.TransitAcceptedClusters.StringList {
+		acceptedClusters[i] = liqov1beta1.ClusterID(cluster)
+	}
+
 	return &NetworkingOption{
 		DynClient: dynClient,
 		Factory:   factory,
@@ -76,16 +95,23 @@ func NewNetworkingOption(factory *dynamicutils.RunnableFactory, dynClient dynami
 		LiqoNamespace: opts.LiqoNamespace,
 		IpamClient:    ipamClient,
 
-		GatewayServerResources:         opts.GatewayServerResources.StringList,
-		GatewayClientResources:         opts.GatewayClientResources.StringList,
-		WgGatewayServerClusterRoleName: opts.WgGatewayServerClusterRoleName,
-		WgGatewayClientClusterRoleName: opts.WgGatewayClientClusterRoleName,
-		NetworkWorkers:                 opts.NetworkWorkers,
-		IPWorkers:                      opts.IPWorkers,
-		FabricFullMasquerade:           opts.FabricFullMasqueradeEnabled,
-		GwmasqbypassEnabled:            opts.GwmasqbypassEnabled,
+		GatewayServerResources:            opts.GatewayServerResources.StringList,
+		GatewayClientResources:            opts.GatewayClientResources.StringList,
+		WgGatewayServerClusterRoleName:    opts.WgGatewayServerClusterRoleName,
+		WgGatewayClientClusterRoleName:    opts.WgGatewayClientClusterRoleName,
+		IPsecGatewayServerClusterRoleName: opts.IPsecGatewayServerClusterRoleName,
+		IPsecGatewayClientClusterRoleName: opts.IPsecGatewayClientClusterRoleName,
+		NetworkWorkers:                    opts.NetworkWorkers,
+		IPWorkers:                         opts.IPWorkers,
+		FabricFullMasquerade:              opts.FabricFullMasqueradeEnabled,
+		GwmasqbypassEnabled:               opts.GwmasqbypassEnabled,
+		FabricEncryption:                  networkingv1beta1.InternalFabricEncryptionMode(opts.FabricEncryption.Value),
 
 		GenevePort: opts.GenevePort,
+
+		LocalClusterID:               clusterID,
+		TransitAcceptedClusters:      acceptedClusters,
+		TransitMaxAdvertisedClusters: opts.TransitMaxAdvertisedClusters,
 	}
 }
 
@@ -146,6 +172,22 @@ func SetupNetworkingModule(ctx context.Context, mgr manager.Manager, uncachedCli
 		return err
 	}
 
+	ipsecServerRec := ipsecgatewaycontrollers.NewIPsecGatewayServerReconciler(mgr.GetClient(), mgr.GetScheme(),
+		mgr.GetEventRecorderFor("ipsec-gateway-server-controller"),
+		opts.IPsecGatewayServerClusterRoleName)
+	if err := ipsecServerRec.SetupWithManager(mgr); err != nil {
+		klog.Errorf("Unable to start the ipsecGatewayServerReconciler: %v", err)
+		return err
+	}
+
+	ipsecClientRec := ipsecgatewaycontrollers.NewIPsecGatewayClientReconciler(mgr.GetClient(), mgr.GetScheme(),
+		mgr.GetEventRecorderFor("ipsec-gateway-client-controller"),
+		opts.IPsecGatewayClientClusterRoleName)
+	if err := ipsecClientRec.SetupWithManager(mgr); err != nil {
+		klog.Errorf("Unable to start the ipsecGatewayClientReconciler: %v", err)
+		return err
+	}
+
 	serverReconciler := serveroperator.NewServerReconciler(mgr.GetClient(),
 		opts.DynClient, opts.Factory, mgr.GetScheme(),
 		mgr.GetEventRecorderFor("server-controller"),
@@ -164,13 +206,15 @@ func SetupNetworkingModule(ctx context.Context, mgr manager.Manager, uncachedCli
 		return err
 	}
 
-	internalServerReconciler := internalservercontroller.NewServerReconciler(mgr.GetClient(), mgr.GetScheme())
+	internalServerReconciler := internalservercontroller.NewServerReconciler(mgr.GetClient(), mgr.GetScheme(),
+		opts.FabricEncryption)
 	if err := internalServerReconciler.SetupWithManager(mgr); err != nil {
 		klog.Errorf("Unable to start the internalServerReconciler: %v", err)
 		return err
 	}
 
-	internalClientReconciler := internalclientcontroller.NewClientReconciler(mgr.GetClient(), mgr.GetScheme())
+	internalClientReconciler := internalclientcontroller.NewClientReconciler(mgr.GetClient(), mgr.GetScheme(),
+		opts.FabricEncryption)
 	if err := internalClientReconciler.SetupWithManager(mgr); err != nil {
 		klog.Errorf("Unable to start the internalClientReconciler: %v", err)
 		return err
@@ -234,6 +278,35 @@ func SetupNetworkingModule(ctx context.Context, mgr manager.Manager, uncachedCli
 		return err
 	}
 
+	peeringNetworkPolicyReconciler := networkpolicy.NewPeeringNetworkPolicyReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		mgr.GetEventRecorderFor("peering-network-policy-controller"),
+	)
+	if err := peeringNetworkPolicyReconciler.SetupWithManager(mgr); err != nil {
+		klog.Errorf("Unable to start the peeringNetworkPolicyReconciler: %v", err)
+		return err
+	}
+
+	transitReconciler := transit.NewTransitReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		mgr.GetEventRecorderFor("transit-controller"),
+		opts.LocalClusterID,
+		opts.TransitAcceptedClusters,
+		opts.TransitMaxAdvertisedClusters,
+	)
+	if err := transitReconciler.SetupWithManager(mgr); err != nil {
+		klog.Errorf("Unable to start the transitReconciler: %v", err)
+		return err
+	}
+
+	mssClampReconciler := mssclamp.NewMSSClampReconciler(mgr.GetClient(), mgr.GetScheme())
+	if err := mssClampReconciler.SetupWithManager(mgr); err != nil {
+		klog.Errorf("Unable to start the mssClampReconciler: %v", err)
+		return err
+	}
+
 	if opts.GwmasqbypassEnabled {
 		gwmasqbypassReconciler := gwmasqbypass.NewPodReconciler(
 			mgr.GetClient(),
@@ -270,6 +343,15 @@ func initializeReservedNetworks(ctx context.Context, cl client.Client, ipamClien
 	}
 	networksToReserve = append(networksToReserve, *serviceCidr)
 
+	// The secondary PodCIDR and ServiceCIDR (dual-stack clusters) are reserved as well, if present.
+	for _, nwType := range []consts.NetworkType{consts.NetworkTypePodCIDRSecondary, consts.NetworkTypeServiceCIDRSecondary} {
+		secondaryNetworks, err := ipamutils.GetNetworksByType(ctx, cl, nwType, corev1.NamespaceAll)
+		if err != nil {
+			return err
+		}
+		networksToReserve = append(networksToReserve, secondaryNetworks...)
+	}
+
 	// Get the reserved networks
 	reservedNetworks, err := ipamutils.GetReservedSubnetNetworks(ctx, cl)
 	if err != nil {
@@ -31,9 +31,11 @@ import (
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 	liqocontrollermanager "github.com/liqotech/liqo/pkg/liqo-controller-manager"
+	migrationctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/migration-controller"
 	mapsctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/namespacemap-controller"
 	nsoffctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/namespaceoffloading-controller"
 	nodefailurectrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/nodefailure-controller"
+	onqctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/offloadednamespacequota-controller"
 	podstatusctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/podstatus-controller"
 	shadowepsctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/shadowendpointslice-controller"
 	shadowpodctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/shadowpod-controller"
@@ -56,6 +58,9 @@ type OffloadingOption struct {
 	ShadowPodWorkers            int
 	ShadowEndpointSliceWorkers  int
 	ResyncPeriod                time.Duration
+	LiqoNamespace               string
+	VolumeMoverImage            string
+	VolumeMoverServiceAccount   string
 }
 
 // NewOffloadingOption creates a new OffloadingOption with the given parameters.
@@ -73,6 +78,9 @@ func NewOffloadingOption(clientset *kubernetes.Clientset, localClusterID liqov1b
 		ShadowPodWorkers:            opts.ShadowPodWorkers,
 		ShadowEndpointSliceWorkers:  opts.ShadowEndpointSliceWorkers,
 		ResyncPeriod:                opts.ResyncPeriod,
+		LiqoNamespace:               opts.LiqoNamespace,
+		VolumeMoverImage:            opts.VolumeMoverImage,
+		VolumeMoverServiceAccount:   opts.VolumeMoverServiceAccount,
 	}
 }
 
@@ -132,6 +140,23 @@ func SetupOffloadingModule(ctx context.Context, mgr manager.Manager, opts *Offlo
 		return err
 	}
 
+	migrationReconciler := migrationctrl.NewMigrationReconciler(mgr.GetClient(), mgr.GetScheme(),
+		mgr.GetEventRecorderFor("migration-controller"), opts.LiqoNamespace, opts.VolumeMoverImage, opts.VolumeMoverServiceAccount)
+	if err = migrationReconciler.SetupWithManager(mgr); err != nil {
+		klog.Errorf("Unable to setup the migration reconciler: %v", err)
+		return err
+	}
+
+	offloadedNamespaceQuotaReconciler := &onqctrl.OffloadedNamespaceQuotaReconciler{
+		Client:       mgr.GetClient(),
+		Recorder:     mgr.GetEventRecorderFor("offloadednamespacequota-controller"),
+		LocalCluster: opts.LocalClusterID,
+	}
+	if err = offloadedNamespaceQuotaReconciler.SetupWithManager(mgr); err != nil {
+		klog.Errorf("Unable to setup the offloadednamespacequota reconciler: %v", err)
+		return err
+	}
+
 	if opts.EnableStorage {
 		liqoProvisioner, err := liqostorageprovisioner.NewLiqoLocalStorageProvisioner(ctx, mgr.GetClient(),
 			opts.VirtualStorageClassName, opts.StorageNamespace, opts.RealStorageClassName)
@@ -0,0 +1,100 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/liqotech/liqo/pkg/liqoctl/audit"
+	"github.com/liqotech/liqo/pkg/liqoctl/completion"
+	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/utils/args"
+)
+
+const liqoctlAuditLongHelp = `Show the audit events of the operations performed by the consumer clusters.
+
+This command retrieves the audit stream emitted by the Liqo controller manager and webhook
+of the local (provider) cluster, concerning the lifecycle of the tenants, the signature of
+the nonces, the acceptance and denial of the ResourceSlices, and the creation and deletion
+of the ShadowPods. Events can be filtered by the cluster ID of the consumer, by action and
+by age.
+
+By default, the events are read from the files written to the audit volume
+("audit.persistence.enabled=true"), which retain the complete history, through one of the
+Liqo pods mounting it. If the audit volume is not enabled (or --logs is set), the events
+are read from the logs of the Liqo components, which requires the audit stream to be written
+to the standard output (i.e., "audit.enabled=true" with the default "audit.path"). Container
+logs only cover the current and the previous instance of each component, hence older events
+are lost. The events collected through the audit webhook sink, as well as copies of the audit
+files, can be read through the --file flag (or from the standard input, if set to "-").
+
+Examples:
+  $ {{ .Executable }} audit --remote-cluster-id remote-cluster-id
+or, considering the events of the last week only
+  $ {{ .Executable }} audit --remote-cluster-id remote-cluster-id --since 168h
+or, considering only the creation and deletion of the ShadowPods, in JSON format
+  $ {{ .Executable }} audit --action ShadowPodCreate --action ShadowPodDelete -o json
+or, reading the events from the logs of the Liqo components
+  $ {{ .Executable }} audit --remote-cluster-id remote-cluster-id --logs
+or, reading the events from a file
+  $ {{ .Executable }} audit --remote-cluster-id remote-cluster-id --file audit.log
+`
+
+func newAuditCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
+	outputFormat := args.NewEnum([]string{string(audit.JSON)}, "")
+
+	options := audit.NewOptions(f)
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the audit events of the operations performed by the consumer clusters",
+		Long:  liqoctlAuditLongHelp,
+		Args:  cobra.NoArgs,
+
+		PreRun: func(_ *cobra.Command, _ []string) {
+			// Errors are printed to the standard error, to allow to pipe the events to other commands.
+			options.Printer.Error.Writer = os.Stderr
+			options.Printer.Info.Writer = os.Stderr
+			options.Printer.Warning.Writer = os.Stderr
+			options.Format = audit.OutputFormat(outputFormat.Value)
+		},
+
+		Run: func(_ *cobra.Command, _ []string) {
+			output.ExitOnErr(options.Run(ctx))
+		},
+	}
+
+	f.AddLiqoNamespaceFlag(cmd.Flags())
+
+	cmd.Flags().Var(&options.ClusterID, "remote-cluster-id", "The cluster ID of the consumer cluster to show the events of (default all)")
+	cmd.Flags().StringArrayVar(&options.Actions, "action", nil,
+		"The actions to show the events of, e.g., TenantConditionChange, NonceSign, ResourceSliceResources, ShadowPodCreate (default all)")
+	cmd.Flags().DurationVar(&options.Since, "since", 0, "Show only the events newer than the given duration, e.g., 168h (default all)")
+	cmd.Flags().StringVar(&options.File, "file", "",
+		"Read the events from the given file ('-' for the standard input), rather than from the audit volume or the logs of the Liqo components")
+	cmd.Flags().BoolVar(&options.Logs, "logs", false,
+		"Read the events from the logs of the Liqo components, rather than from the audit volume (which retains the complete history)")
+	cmd.MarkFlagsMutuallyExclusive("file", "logs")
+	cmd.Flags().VarP(outputFormat, "output", "o", "Output format. Supported formats: json (default table)")
+
+	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc(factory.FlagNamespace, completion.Namespaces(ctx, f, completion.NoLimit)))
+	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("remote-cluster-id", completion.ClusterIDs(ctx, f, completion.NoLimit)))
+	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("output", completion.Enumeration(outputFormat.Allowed)))
+
+	return cmd
+}
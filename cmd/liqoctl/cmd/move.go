@@ -16,8 +16,10 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/liqotech/liqo/pkg/liqoctl/completion"
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
@@ -26,6 +28,7 @@ import (
 	liqoctlutils "github.com/liqotech/liqo/pkg/liqoctl/utils"
 	"github.com/liqotech/liqo/pkg/utils"
 	"github.com/liqotech/liqo/pkg/utils/args"
+	"github.com/liqotech/liqo/pkg/volumemover"
 )
 
 const liqoctlMoveVolumeLongHelp = `Move a Liqo-managed PVC to a different node (i.e., cluster).
@@ -52,6 +55,27 @@ or
       --containers-cpu-limits 1000m --containers-ram-limits 2Gi
 `
 
+const liqoctlMoveWorkloadLongHelp = `Move the pods matching a selector from a virtual node to another.
+
+This command creates a Migration resource, which instructs Liqo to evict the
+selected pods from the source virtual node, move the Liqo-managed volumes they
+mount to the target node, and eventually let the pods (recreated by their
+controllers) be scheduled on the target node. The target node must be a virtual
+node matching the cluster selector of the namespace. If not specified, the first
+suitable virtual node is selected.
+
+Only pods managed by a controller (e.g., a Deployment or a StatefulSet) can be moved,
+as they are recreated after being evicted from the source node. In case a volume
+cannot be moved, the migration is rolled back, and the pods are released to be
+scheduled again without constraints.
+
+Examples:
+  $ {{ .Executable }} move workload --namespace foo --selector app=database --source-node liqo-neutral-colt
+or
+  $ {{ .Executable }} move workload --namespace foo --selector app=database --source-node liqo-neutral-colt
+      --target-node liqo-wise-bee --timeout 30m
+`
+
 // moveCmd represents the move command.
 func newMoveCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
@@ -61,7 +85,11 @@ func newMoveCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 		Args:  cobra.NoArgs,
 	}
 
+	f.AddNamespaceFlag(cmd.PersistentFlags())
+	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc(factory.FlagNamespace, completion.Namespaces(ctx, f, completion.NoLimit)))
+
 	liqoctlutils.AddCommand(cmd, newMoveVolumeCommand(ctx, f))
+	liqoctlutils.AddCommand(cmd, newMoveWorkloadCommand(ctx, f))
 	return cmd
 }
 
@@ -92,9 +120,6 @@ func newMoveVolumeCommand(ctx context.Context, f *factory.Factory) *cobra.Comman
 		},
 	}
 
-	f.AddNamespaceFlag(cmd.Flags())
-	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc(factory.FlagNamespace, completion.Namespaces(ctx, f, completion.NoLimit)))
-
 	cmd.Flags().StringVar(&options.TargetNode, "target-node", "",
 		"The target node (either physical or virtual) the PVC will be moved to")
 
@@ -102,9 +127,9 @@ func newMoveVolumeCommand(ctx context.Context, f *factory.Factory) *cobra.Comman
 	cmd.Flags().Var(&containersCPULimits, "containers-cpu-limits", "The CPU limits for the Restic containers")
 	cmd.Flags().Var(&containersRAMRequests, "containers-ram-requests", "The RAM requests for the Restic containers")
 	cmd.Flags().Var(&containersRAMLimits, "containers-ram-limits", "The RAM limits for the Restic containers")
-	cmd.Flags().StringVar(&options.ResticServerImage, "restic-server-image", move.DefaultResticServerImage,
+	cmd.Flags().StringVar(&options.ResticServerImage, "restic-server-image", volumemover.DefaultResticServerImage,
 		"The Restic server image to use")
-	cmd.Flags().StringVar(&options.ResticImage, "restic-image", move.DefaultResticImage,
+	cmd.Flags().StringVar(&options.ResticImage, "restic-image", volumemover.DefaultResticImage,
 		"The Restic image to use")
 
 	f.Printer.CheckErr(cmd.MarkFlagRequired("target-node"))
@@ -112,3 +137,36 @@ func newMoveVolumeCommand(ctx context.Context, f *factory.Factory) *cobra.Comman
 
 	return cmd
 }
+
+func newMoveWorkloadCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
+	options := &move.WorkloadOptions{Factory: f}
+	var selector string
+
+	var cmd = &cobra.Command{
+		Use:     "workload",
+		Aliases: []string{"pods"},
+		Short:   "Move the pods matching a selector from a virtual node to another",
+		Long:    liqoctlMoveWorkloadLongHelp,
+		Args:    cobra.NoArgs,
+
+		Run: func(_ *cobra.Command, _ []string) {
+			labelSelector, err := metav1.ParseToLabelSelector(selector)
+			output.ExitOnErr(err)
+			options.Selector = labelSelector
+			output.ExitOnErr(options.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "The label selector identifying the pods to be moved")
+	cmd.Flags().StringVar(&options.SourceNode, "source-node", "", "The virtual node the pods are currently running on")
+	cmd.Flags().StringVar(&options.TargetNode, "target-node", "",
+		"The virtual node the pods will be moved to (default: the first virtual node matching the namespace cluster selector)")
+	cmd.Flags().BoolVar(&options.Wait, "wait", true, "Wait for the migration to complete")
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", 10*time.Minute, "The timeout for the migration process")
+
+	f.Printer.CheckErr(cmd.MarkFlagRequired("source-node"))
+	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("source-node", completion.Nodes(ctx, f, completion.NoLimit)))
+	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("target-node", completion.Nodes(ctx, f, completion.NoLimit)))
+
+	return cmd
+}
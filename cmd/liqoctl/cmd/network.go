@@ -159,6 +159,12 @@ func newNetworkConnectCommand(ctx context.Context, options *network.Options) *co
 	cmd.Flags().IntVar(&options.MTU, "mtu", forge.DefaultMTU,
 		fmt.Sprintf("MTU of the Gateway server and client. Default: %d", forge.DefaultMTU))
 	cmd.Flags().BoolVar(&options.DisableSharingKeys, "disable-sharing-keys", false, "Disable the sharing of public keys between the two clusters")
+	cmd.Flags().DurationVar(&options.MaxLatency, "max-latency", 0,
+		"Maximum round-trip latency of the connection, beyond which it is considered degraded. Leave empty to disable the threshold")
+	cmd.Flags().DurationVar(&options.MaxJitter, "max-jitter", 0,
+		"Maximum jitter of the connection, beyond which it is considered degraded. Leave empty to disable the threshold")
+	cmd.Flags().Int32Var(&options.MaxPacketLoss, "max-packet-loss", 0,
+		"Maximum percentage of lost packets, beyond which the connection is considered degraded. Leave empty to disable the threshold")
 
 	runtime.Must(cmd.RegisterFlagCompletionFunc("gw-server-service-type", completion.Enumeration(options.ServerServiceType.Allowed)))
 
@@ -39,7 +39,10 @@ offloaded in the corresponding cluster, as well as the additional resources
 propagated by the resource reflection process.
 
 Namespace offloading can be tuned in terms of:
-* Clusters: select the target clusters through virtual node labels.
+* Clusters: select the target clusters through virtual node labels, possibly
+  ranking them through weighted preferences, and limiting their number. Lower
+  ranked clusters can be selected only when the preceding ones are low on
+  resources (spill-over).
 * Pod offloading: whether pods should be scheduled on physical nodes only,
   virtual nodes only, or both. Forcing all pods to be scheduled locally enables
   the consumption of services from remote clusters.
@@ -64,6 +67,9 @@ or (cluster labels in logical AND)
 or (cluster labels in logical OR)
   $ {{ .Executable }} offload namespace foo --namespace-mapping-strategy EnforceSameName \
       --selector 'region in (europe,us-west)' --selector '!staging'
+or (prefer clusters in europe, offloading to at most two clusters, and spilling over only when needed)
+  $ {{ .Executable }} offload namespace foo --preference '50:region=europe' \
+      --max-clusters 2 --spill-over-strategy OnLowCapacity
 or (output the NamespaceOffloading resource as a yaml manifest, without applying it)
   $ {{ .Executable }} offload namespace foo --output yaml
 `
@@ -81,7 +87,7 @@ func newOffloadCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 }
 
 func newOffloadNamespaceCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
-	var selectors []string
+	var selectors, preferences []string
 	var labelSelector string
 
 	podOffloadingStrategy := args.NewEnum([]string{
@@ -96,6 +102,11 @@ func newOffloadNamespaceCommand(ctx context.Context, f *factory.Factory) *cobra.
 		string(offloadingv1beta1.SelectedNameMappingStrategyType)},
 		string(offloadingv1beta1.DefaultNameMappingStrategyType))
 
+	spillOverStrategy := args.NewEnum([]string{
+		string(offloadingv1beta1.DisabledSpillOverStrategyType),
+		string(offloadingv1beta1.OnLowCapacitySpillOverStrategyType)},
+		string(offloadingv1beta1.DisabledSpillOverStrategyType))
+
 	var remoteNamespaceName = ""
 
 	outputFormat := args.NewEnum([]string{"json", "yaml"}, "")
@@ -115,7 +126,9 @@ func newOffloadNamespaceCommand(ctx context.Context, f *factory.Factory) *cobra.
 			options.RemoteNamespaceName = remoteNamespaceName
 			options.OutputFormat = outputFormat.Value
 			options.LabelSelector = labelSelector
+			options.SpillOverStrategy = offloadingv1beta1.SpillOverStrategyType(spillOverStrategy.Value)
 			options.Printer.CheckErr(options.ParseClusterSelectors(selectors))
+			options.Printer.CheckErr(options.ParseClusterPreferences(preferences))
 		},
 		Run: func(_ *cobra.Command, args []string) {
 			if len(args) == 0 && labelSelector == "" {
@@ -143,6 +156,15 @@ func newOffloadNamespaceCommand(ctx context.Context, f *factory.Factory) *cobra.
 
 	cmd.Flags().StringArrayVarP(&selectors, "selector", "l", []string{},
 		"The selector to filter the target clusters. Can be specified multiple times, defining alternative requirements (i.e., in logical OR)")
+	cmd.Flags().StringArrayVar(&preferences, "preference", []string{},
+		"A weighted preference to rank the target clusters, in the form <weight>:<selector>, with weight in the range 1-100. "+
+			"Can be specified multiple times, and the weights of the preferences matched by each cluster are summed up")
+	cmd.Flags().Int32Var(&options.MaxClusters, "max-clusters", 0,
+		"The maximum number of clusters the namespace is offloaded to, starting from the highest ranked ones (0 means no limit)")
+	cmd.Flags().Var(spillOverStrategy, "spill-over-strategy",
+		"Whether lower ranked clusters are selected only when the preceding ones are low on resources, among Disabled and OnLowCapacity")
+	cmd.Flags().Int32Var(&options.SpillOverThreshold, "spill-over-threshold", 20,
+		"The percentage of free resources below which a cluster is considered low on resources, when spilling over")
 	cmd.Flags().StringVar(&labelSelector, "ns-selector", "",
 		"Selector (label query) to filter namespaces, supports '=', '==', and '!=' (e.g., -l key1=value1,key2=value2).")
 
@@ -151,6 +173,7 @@ func newOffloadNamespaceCommand(ctx context.Context, f *factory.Factory) *cobra.
 
 	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("pod-offloading-strategy", completion.Enumeration(podOffloadingStrategy.Allowed)))
 	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("namespace-mapping-strategy", completion.Enumeration(namespaceMappingStrategy.Allowed)))
+	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("spill-over-strategy", completion.Enumeration(spillOverStrategy.Allowed)))
 	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("selector", completion.LabelsSelector(ctx, f, completion.NoLimit)))
 	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("ns-selector", completion.NamespacesSelector(ctx, f, completion.NoLimit)))
 	f.Printer.CheckErr(cmd.RegisterFlagCompletionFunc("output", completion.Enumeration(outputFormat.Allowed)))
@@ -51,6 +51,7 @@ the following operations:
 Examples:
   $ {{ .Executable }} peer --remote-kubeconfig <provider>
   $ {{ .Executable }} peer --remote-kubeconfig <provider> --gw-server-service-type NodePort
+  $ {{ .Executable }} peer --remote-kubeconfig <provider> --gw-type ipsec
   $ {{ .Executable }} peer --remote-kubeconfig <provider> --cpu 2 --memory 4Gi --pods 10
   $ {{ .Executable }} peer --remote-kubeconfig <provider> --cpu 2 --memory 4Gi --pods 10 --resource nvidia.com/gpu=2
   $ {{ .Executable }} peer --remote-kubeconfig <provider> --create-resource-slice false
@@ -92,6 +93,9 @@ func newPeerCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 
 	// Networking flags
 	cmd.Flags().BoolVar(&options.NetworkingDisabled, "networking-disabled", false, "Disable networking between the two clusters")
+	cmd.Flags().Var(options.GatewayType, "gw-type",
+		fmt.Sprintf("Tunnel technology of the Gateways (%q or %q). Default: %q",
+			nwforge.GatewayTypeWireGuard, nwforge.GatewayTypeIPsec, nwforge.DefaultGatewayType))
 	cmd.Flags().Var(options.ServerServiceLocation, "gw-server-service-location",
 		fmt.Sprintf("Location of the service to expose the Gateway Server (%q or %q). Default: %q",
 			liqov1beta1.ConsumerRole, liqov1beta1.ProviderRole, nwforge.DefaultGwServerLocation))
@@ -115,7 +119,14 @@ func newPeerCommand(ctx context.Context, f *factory.Factory) *cobra.Command {
 			"not directly reachable (e.g. the server is behind a NAT)")
 	cmd.Flags().IntVar(&options.MTU, "mtu", nwforge.DefaultMTU,
 		fmt.Sprintf("MTU of the Gateway server and client. Default: %d", nwforge.DefaultMTU))
-
+	cmd.Flags().DurationVar(&options.MaxLatency, "max-latency", 0,
+		"Maximum round-trip latency of the connection, beyond which it is considered degraded. Leave empty to disable the threshold")
+	cmd.Flags().DurationVar(&options.MaxJitter, "max-jitter", 0,
+		"Maximum jitter of the connection, beyond which it is considered degraded. Leave empty to disable the threshold")
+	cmd.Flags().Int32Var(&options.MaxPacketLoss, "max-packet-loss", 0,
+		"Maximum percentage of lost packets, beyond which the connection is considered degraded. Leave empty to disable the threshold")
+
+	runtime.Must(cmd.RegisterFlagCompletionFunc("gw-type", completion.Enumeration(options.GatewayType.Allowed)))
 	runtime.Must(cmd.RegisterFlagCompletionFunc("gw-server-service-location", completion.Enumeration(options.ServerServiceLocation.Allowed)))
 	runtime.Must(cmd.RegisterFlagCompletionFunc("gw-server-service-type", completion.Enumeration(options.ServerServiceType.Allowed)))
 
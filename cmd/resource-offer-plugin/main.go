@@ -0,0 +1,96 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains a sample ResourceOffer plugin, which can be used as a starting point
+// to implement custom ResourceSlice classes.
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"k8s.io/klog/v2"
+
+	"github.com/liqotech/liqo/pkg/resourceoffer"
+	"github.com/liqotech/liqo/pkg/resourceoffer/sample"
+	flagsutils "github.com/liqotech/liqo/pkg/utils/flags"
+)
+
+var (
+	port      int
+	maxShare  float64
+	tlsConfig resourceoffer.TLSConfig
+)
+
+func main() {
+	var cmd = cobra.Command{
+		Use:  "liqo-resource-offer-plugin",
+		RunE: run,
+	}
+
+	flagsutils.InitKlogFlags(cmd.Flags())
+
+	cmd.Flags().IntVar(&port, "port", 6000, "The port on which to listen for incoming gRPC requests.")
+	cmd.Flags().Float64Var(&maxShare, "max-share", 0.5,
+		"The maximum fraction of the available resources granted to a single ResourceSlice.")
+	cmd.Flags().StringVar(&tlsConfig.CertFile, "tls-cert-file", "", "The certificate used to serve over TLS.")
+	cmd.Flags().StringVar(&tlsConfig.KeyFile, "tls-key-file", "", "The key used to serve over TLS.")
+	cmd.Flags().StringVar(&tlsConfig.CAFile, "tls-client-ca-file", "",
+		"The CA bundle used to verify the certificates of the clients (client authentication is disabled if not set).")
+	cmd.Flags().BoolVar(&tlsConfig.Insecure, "insecure", false,
+		"Serve without TLS, hence without encrypting the connections (not recommended).")
+
+	if err := cmd.Execute(); err != nil {
+		klog.Error(err)
+		os.Exit(1)
+	}
+}
+
+func run(_ *cobra.Command, _ []string) error {
+	plugin, err := sample.NewServer(maxShare)
+	if err != nil {
+		return err
+	}
+
+	creds, err := resourceoffer.NewServerCredentials(&tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(grpc.Creds(creds))
+
+	// Register health service
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+
+	// Register ResourceOffer service
+	resourceoffer.RegisterResourceOfferServer(server, plugin)
+
+	klog.Infof("Serving the ResourceOffer plugin on port %d", port)
+	if err := server.Serve(lis); err != nil { // we do not need to close the listener as Serve will close it when returning
+		klog.Errorf("failed to serve: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -49,6 +49,9 @@ func InstallFlags(flags *pflag.FlagSet, o *Opts) {
 
 	setReflectorsWorkers(flags, o)
 	setReflectorsType(flags, o)
+	setReflectorsPolicy(flags, o)
+	flags.StringVar(&o.CustomResourceReflectors, "custom-resource-reflectors", o.CustomResourceReflectors,
+		"The JSON-encoded list of the configurations of the reflectors for arbitrary custom resources")
 
 	flags.DurationVar(&o.NodeLeaseDuration, "node-lease-duration", o.NodeLeaseDuration, "The duration of the node leases")
 	flags.DurationVar(&o.NodePingInterval, "node-ping-interval", o.NodePingInterval,
@@ -68,9 +71,15 @@ func InstallFlags(flags *pflag.FlagSet, o *Opts) {
 	flags.StringVar(&o.RemoteRealStorageClassName, "remote-real-storage-class-name", "", "Name of the real storage class to use for the actual volumes")
 	flags.BoolVar(&o.EnableIngress, "enable-ingress", false, "Enable the Liqo ingress reflection")
 	flags.StringVar(&o.RemoteRealIngressClassName, "remote-real-ingress-class-name", "", "Name of the real ingress class to use for the actual ingress")
+	flags.Var(&o.IngressClasses, "ingress-classes", "Names of the ingress classes offered by the remote cluster")
+	flags.Var(&o.ReflectIngressStatusClasses, "reflect-ingress-status-classes",
+		"Names of the ingress classes whose status (i.e., the assigned hostnames and IPs) is reflected back to the local ingresses")
 	flags.BoolVar(&o.EnableLoadBalancer, "enable-load-balancer", false, "Enable the Liqo load balancer reflection")
 	flags.StringVar(&o.RemoteRealLoadBalancerClassName, "remote-real-load-balancer-class-name", "",
 		"Name of the real load balancer class to use for the actual load balancer")
+	flags.Var(&o.LoadBalancerClasses, "load-balancer-classes", "Names of the load balancer classes offered by the remote cluster")
+	flags.Var(&o.ReflectLoadBalancerStatusClasses, "reflect-load-balancer-status-classes",
+		"Names of the load balancer classes whose status (i.e., the assigned external IPs and hostnames) is reflected back to the local services")
 	flags.BoolVar(&o.EnableMetrics, "metrics-enabled", false, "Enable the metrics server")
 	flags.StringVar(&o.MetricsAddress, "metrics-address", ":8082", "The address to listen to for metrics requests")
 	flags.StringVar(&o.HomeAPIServerHost, "home-api-server-host", "",
@@ -121,3 +130,14 @@ func setReflectorsType(flags *pflag.FlagSet, o *Opts) {
 		flags.StringVar(o.ReflectorsType[string(*resource)], stringFlag, defaultValue, usage)
 	}
 }
+
+// setReflectorsPolicy sets the flags for the policy of reflection used by the reflectors.
+func setReflectorsPolicy(flags *pflag.FlagSet, o *Opts) {
+	for i := range resources.ReflectorsCustomizablePolicy {
+		resource := &resources.ReflectorsCustomizablePolicy[i]
+		stringFlag := fmt.Sprintf("%s-reflection-policy", *resource)
+		defaultValue := *o.ReflectorsPolicy[string(*resource)]
+		usage := fmt.Sprintf("The JSON-encoded reflection policy used for the %s reflector", *resource)
+		flags.StringVar(o.ReflectorsPolicy[string(*resource)], stringFlag, defaultValue, usage)
+	}
+}
@@ -57,6 +57,7 @@ var DefaultReflectorsWorkers = map[resources.ResourceReflected]uint{
 	resources.ServiceAccount:        3,
 	resources.PersistentVolumeClaim: 3,
 	resources.Event:                 3,
+	resources.NamespaceQuota:        1,
 }
 
 // DefaultReflectorsTypes contains the default type of reflection for each reflected resource.
@@ -69,6 +70,7 @@ var DefaultReflectorsTypes = map[resources.ResourceReflected]offloadingv1beta1.R
 	resources.ServiceAccount:        offloadingv1beta1.CustomLiqo,
 	resources.PersistentVolumeClaim: offloadingv1beta1.CustomLiqo,
 	resources.Event:                 offloadingv1beta1.DenyList,
+	resources.NamespaceQuota:        offloadingv1beta1.CustomLiqo,
 }
 
 // Opts stores all the options for configuring the root virtual-kubelet command.
@@ -102,6 +104,12 @@ type Opts struct {
 	// Type of reflection to use for each reflected resource
 	ReflectorsType map[string]*string
 
+	// JSON-encoded reflection policy to use for each reflected resource
+	ReflectorsPolicy map[string]*string
+
+	// JSON-encoded list of the configurations of the custom resource reflectors
+	CustomResourceReflectors string
+
 	NodeLeaseDuration time.Duration
 	NodePingInterval  time.Duration
 	NodePingTimeout   time.Duration
@@ -110,16 +118,20 @@ type Opts struct {
 	NodeExtraAnnotations argsutils.StringMap
 	NodeExtraLabels      argsutils.StringMap
 
-	EnableAPIServerSupport          bool
-	EnableStorage                   bool
-	VirtualStorageClassName         string
-	RemoteRealStorageClassName      string
-	EnableIngress                   bool
-	RemoteRealIngressClassName      string
-	EnableLoadBalancer              bool
-	RemoteRealLoadBalancerClassName string
-	EnableMetrics                   bool
-	MetricsAddress                  string
+	EnableAPIServerSupport           bool
+	EnableStorage                    bool
+	VirtualStorageClassName          string
+	RemoteRealStorageClassName       string
+	EnableIngress                    bool
+	RemoteRealIngressClassName       string
+	IngressClasses                   argsutils.StringList
+	ReflectIngressStatusClasses      argsutils.StringList
+	EnableLoadBalancer               bool
+	RemoteRealLoadBalancerClassName  string
+	LoadBalancerClasses              argsutils.StringList
+	ReflectLoadBalancerStatusClasses argsutils.StringList
+	EnableMetrics                    bool
+	MetricsAddress                   string
 
 	HomeAPIServerHost string
 	HomeAPIServerPort string
@@ -150,6 +162,7 @@ func NewOpts() *Opts {
 
 		ReflectorsWorkers: initReflectionWorkers(),
 		ReflectorsType:    initReflectionType(),
+		ReflectorsPolicy:  initReflectionPolicy(),
 
 		NodeLeaseDuration: node.DefaultLeaseDuration * time.Second,
 		NodePingInterval:  node.DefaultPingInterval,
@@ -180,3 +193,12 @@ func initReflectionType() map[string]*string {
 	}
 	return reflectionType
 }
+
+func initReflectionPolicy() map[string]*string {
+	reflectionPolicy := make(map[string]*string, len(resources.ReflectorsCustomizablePolicy))
+	for i := range resources.ReflectorsCustomizablePolicy {
+		resource := &resources.ReflectorsCustomizablePolicy[i]
+		reflectionPolicy[string(*resource)] = ptr.To("")
+	}
+	return reflectionPolicy
+}
@@ -17,6 +17,7 @@ package root
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -49,6 +50,8 @@ import (
 	nodeprovider "github.com/liqotech/liqo/pkg/virtualKubelet/liqoNodeProvider"
 	metrics "github.com/liqotech/liqo/pkg/virtualKubelet/metrics"
 	podprovider "github.com/liqotech/liqo/pkg/virtualKubelet/provider"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/customresource"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/policy"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/resources"
 )
 
@@ -151,6 +154,10 @@ func runRootCommand(ctx context.Context, c *Opts) error {
 	if err != nil {
 		return err
 	}
+	customResourceReflectorsConfigs, err := getCustomResourceReflectorsConfigs(c)
+	if err != nil {
+		return err
+	}
 
 	// Get virtual node
 	vnName := os.Getenv("VIRTUALNODE_NAME")
@@ -192,17 +199,22 @@ func runRootCommand(ctx context.Context, c *Opts) error {
 		LocalPodCIDR:         c.LocalPodCIDR,
 		InformerResyncPeriod: c.InformerResyncPeriod,
 
-		ReflectorsConfigs: reflectorsConfigs,
-
-		EnableAPIServerSupport:          c.EnableAPIServerSupport,
-		EnableStorage:                   c.EnableStorage,
-		VirtualStorageClassName:         c.VirtualStorageClassName,
-		RemoteRealStorageClassName:      c.RemoteRealStorageClassName,
-		EnableIngress:                   c.EnableIngress,
-		RemoteRealIngressClassName:      c.RemoteRealIngressClassName,
-		EnableLoadBalancer:              c.EnableLoadBalancer,
-		RemoteRealLoadBalancerClassName: c.RemoteRealLoadBalancerClassName,
-		EnableMetrics:                   c.EnableMetrics,
+		ReflectorsConfigs:               reflectorsConfigs,
+		CustomResourceReflectorsConfigs: customResourceReflectorsConfigs,
+
+		EnableAPIServerSupport:           c.EnableAPIServerSupport,
+		EnableStorage:                    c.EnableStorage,
+		VirtualStorageClassName:          c.VirtualStorageClassName,
+		RemoteRealStorageClassName:       c.RemoteRealStorageClassName,
+		EnableIngress:                    c.EnableIngress,
+		RemoteRealIngressClassName:       c.RemoteRealIngressClassName,
+		IngressClasses:                   c.IngressClasses.StringList,
+		ReflectIngressStatusClasses:      c.ReflectIngressStatusClasses.StringList,
+		EnableLoadBalancer:               c.EnableLoadBalancer,
+		RemoteRealLoadBalancerClassName:  c.RemoteRealLoadBalancerClassName,
+		LoadBalancerClasses:              c.LoadBalancerClasses.StringList,
+		ReflectLoadBalancerStatusClasses: c.ReflectLoadBalancerStatusClasses.StringList,
+		EnableMetrics:                    c.EnableMetrics,
 
 		HomeAPIServerHost: c.HomeAPIServerHost,
 		HomeAPIServerPort: c.HomeAPIServerPort,
@@ -360,7 +372,8 @@ func getVersion(config *rest.Config) string {
 }
 
 func isReflectionTypeNotCustomizable(resource resources.ResourceReflected) bool {
-	return resource == resources.Pod || resource == resources.ServiceAccount || resource == resources.PersistentVolumeClaim
+	return resource == resources.Pod || resource == resources.ServiceAccount || resource == resources.PersistentVolumeClaim ||
+		resource == resources.NamespaceQuota
 }
 
 func getReflectorsConfigs(c *Opts) (map[resources.ResourceReflected]offloadingv1beta1.ReflectorConfig, error) {
@@ -383,7 +396,65 @@ func getReflectorsConfigs(c *Opts) (map[resources.ResourceReflected]offloadingv1
 					reflectionType, *resource, offloadingv1beta1.DenyList, offloadingv1beta1.AllowList)
 			}
 		}
-		reflectorsConfigs[*resource] = offloadingv1beta1.ReflectorConfig{NumWorkers: numWorkers, Type: reflectionType}
+		reflectionPolicy, err := getReflectionPolicy(c, *resource)
+		if err != nil {
+			return nil, err
+		}
+		reflectorsConfigs[*resource] = offloadingv1beta1.ReflectorConfig{NumWorkers: numWorkers, Type: reflectionType, Policy: reflectionPolicy}
+	}
+	return reflectorsConfigs, nil
+}
+
+func getReflectionPolicy(c *Opts, resource resources.ResourceReflected) (*offloadingv1beta1.ReflectionPolicy, error) {
+	if resource == resources.EndpointSlice {
+		// the endpointslice reflector inherits the reflection policy from the service reflector.
+		resource = resources.Service
+	}
+
+	encoded, ok := c.ReflectorsPolicy[string(resource)]
+	if !ok || *encoded == "" {
+		return nil, nil
+	}
+
+	var reflectionPolicy offloadingv1beta1.ReflectionPolicy
+	if err := json.Unmarshal([]byte(*encoded), &reflectionPolicy); err != nil {
+		return nil, fmt.Errorf("reflection policy is not valid for resource %s: %w", resource, err)
+	}
+	// Make sure the policy can be correctly compiled, to fail early in case of errors.
+	if _, err := policy.New(&reflectionPolicy); err != nil {
+		return nil, fmt.Errorf("reflection policy is not valid for resource %s: %w", resource, err)
+	}
+	return &reflectionPolicy, nil
+}
+
+func getCustomResourceReflectorsConfigs(c *Opts) ([]offloadingv1beta1.CustomResourceReflectorConfig, error) {
+	if c.CustomResourceReflectors == "" {
+		return nil, nil
+	}
+
+	var reflectorsConfigs []offloadingv1beta1.CustomResourceReflectorConfig
+	if err := json.Unmarshal([]byte(c.CustomResourceReflectors), &reflectorsConfigs); err != nil {
+		return nil, fmt.Errorf("custom resource reflectors configuration is not valid: %w", err)
+	}
+
+	configured := make(map[string]struct{}, len(reflectorsConfigs))
+	for i := range reflectorsConfigs {
+		reflectorConfig := &reflectorsConfigs[i]
+		if reflectorConfig.Direction == "" {
+			reflectorConfig.Direction = offloadingv1beta1.LocalToRemote
+		}
+		if reflectorConfig.Type == "" {
+			reflectorConfig.Type = offloadingv1beta1.DenyList
+		}
+		if err := customresource.Validate(reflectorConfig); err != nil {
+			return nil, fmt.Errorf("custom resource reflector configuration is not valid: %w", err)
+		}
+
+		gvr := customresource.GroupVersionResource(reflectorConfig)
+		if _, found := configured[gvr.GroupResource().String()]; found {
+			return nil, fmt.Errorf("custom resource reflector for resource %s configured multiple times", gvr.GroupResource())
+		}
+		configured[gvr.GroupResource().String()] = struct{}{}
 	}
 	return reflectorsConfigs, nil
 }
@@ -0,0 +1,109 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	flagsutils "github.com/liqotech/liqo/pkg/utils/flags"
+	"github.com/liqotech/liqo/pkg/utils/restcfg"
+	"github.com/liqotech/liqo/pkg/volumemover"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = offloadingv1beta1.AddToScheme(scheme)
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=core,resources=nodes;persistentvolumes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespaceoffloadings,verbs=get;list;watch;create;update;delete
+
+func main() {
+	namespace := pflag.String("namespace", "", "the namespace of the PVC to be moved")
+	pvcName := pflag.String("pvc-name", "", "the name of the PVC to be moved")
+	targetNode := pflag.String("target-node", "", "the node the PVC is moved to")
+	id := pflag.String("id", "", "the identifier of the move, to isolate its resources from the ones of concurrent moves")
+	resticServerImage := pflag.String("restic-server-image", volumemover.DefaultResticServerImage, "the image used for the restic server")
+	resticImage := pflag.String("restic-image", volumemover.DefaultResticImage, "the image used for the restic client")
+
+	flagsutils.InitKlogFlags(nil)
+
+	pflag.Parse()
+
+	log.SetLogger(klog.NewKlogr())
+
+	if *namespace == "" || *pvcName == "" || *targetNode == "" {
+		klog.Error("the --namespace, --pvc-name and --target-node flags are mandatory")
+		os.Exit(1)
+	}
+
+	resticPassword, ok := os.LookupEnv(volumemover.ResticPasswordEnv)
+	if !ok || resticPassword == "" {
+		klog.Errorf("The %s environment variable is not set", volumemover.ResticPasswordEnv)
+		os.Exit(1)
+	}
+
+	ctx, _ := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+
+	config := restcfg.SetRateLimiter(ctrl.GetConfigOrDie())
+	cl, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		klog.Errorf("Failed to create client: %v", err)
+		os.Exit(1)
+	}
+
+	var pvc corev1.PersistentVolumeClaim
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: *namespace, Name: *pvcName}, &pvc); err != nil {
+		klog.Errorf("Failed to retrieve PVC %s/%s: %v", *namespace, *pvcName, err)
+		os.Exit(1)
+	}
+
+	opts := volumemover.Options{
+		Client:            cl,
+		TargetNode:        *targetNode,
+		ID:                *id,
+		ResticPassword:    resticPassword,
+		ResticServerImage: *resticServerImage,
+		ResticImage:       *resticImage,
+		Progress:          func(step string) { klog.Info(step) },
+	}
+
+	if _, err := opts.Move(ctx, &pvc); err != nil {
+		klog.Errorf("Failed to move PVC %q to node %q: %v", klog.KObj(&pvc), *targetNode, err)
+		os.Exit(1)
+	}
+	klog.Infof("PVC %q correctly moved to node %q", klog.KObj(&pvc), *targetNode)
+}
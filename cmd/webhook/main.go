@@ -40,6 +40,8 @@ import (
 	ipamv1alpha1 "github.com/liqotech/liqo/apis/ipam/v1alpha1"
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/audit"
+	"github.com/liqotech/liqo/pkg/auth"
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/leaderelection"
 	argsutils "github.com/liqotech/liqo/pkg/utils/args"
@@ -93,6 +95,10 @@ func main() {
 		5*time.Minute, "The interval at which the resource validator cache is refreshed")
 	liqoRuntimeClassName := pflag.String("liqo-runtime-class", consts.LiqoRuntimeClassName,
 		"Define the Liqo runtime class forcing the pods to be scheduled on virtual nodes")
+	oidcGroupsPrefix := pflag.String("oidc-groups-prefix", "",
+		"Prefix the local API server adds to the groups of the OIDC tokens issued to remote clusters, if any")
+	var auditConfig audit.Config
+	audit.InitFlags(pflag.CommandLine, &auditConfig)
 
 	flagsutils.InitKlogFlags(pflag.CommandLine)
 	restcfg.InitFlags(pflag.CommandLine)
@@ -101,12 +107,20 @@ func main() {
 
 	log.SetLogger(klog.NewKlogr())
 
+	// The remote control planes authenticated through OIDC tokens are recognized by their prefixed group.
+	auth.SetIdentityPrefixes("", *oidcGroupsPrefix)
+
 	clusterID := clusterIDFlags.ReadOrDie()
 
 	ctx := ctrl.SetupSignalHandler()
 
 	config := restcfg.SetRateLimiter(ctrl.GetConfigOrDie())
 
+	if err := audit.Setup(ctx, "liqo-webhook", &auditConfig); err != nil {
+		klog.Errorf("Unable to setup the audit stream: %v", err)
+		os.Exit(1)
+	}
+
 	// create a client used for configuration
 	cl, err := client.New(config, client.Options{Scheme: scheme})
 	if err != nil {
@@ -95,9 +95,12 @@ Common Labels for Gateway Templates
 {{ include "liqo.selectorTemplate" . }}
 helm.sh/chart: {{ quote (include "liqo.chart" .) }}
 app.kubernetes.io/version: {{ quote (include "liqo.version" .) }}
-{{- if and .isGwTemplate (eq (int .Values.networking.gatewayTemplates.replicas) 1) }}
+{{- if and .isGwTemplate (or (eq (int .Values.networking.gatewayTemplates.replicas) 1) .Values.networking.gatewayTemplates.activeActive) }}
 networking.liqo.io/active: "true"
 {{- end }}
+{{- if and .isGwTemplate .Values.networking.gatewayTemplates.activeActive }}
+networking.liqo.io/active-active: "true"
+{{- end }}
 {{- end }}
 
 {{/*
@@ -253,3 +256,90 @@ imagePullSecrets:
 {{- toYaml .Values.imagePullSecrets | nindent 0 }}
 {{- end -}}
 {{- end -}}
+
+{{/*
+Get the arguments configuring the audit stream
+*/}}
+{{- define "liqo.auditArgs" -}}
+{{- if .Values.audit.enabled }}
+- --audit-log-path={{ .Values.audit.path }}
+{{- if .Values.audit.persistence.enabled }}
+- --audit-log-path=/var/log/liqo/audit/$(POD_NAME).jsonl
+{{- end }}
+{{- with .Values.audit.webhook }}
+{{- if .url }}
+- --audit-webhook-url={{ .url }}
+{{- if .caSecretName }}
+- --audit-webhook-ca-file=/etc/liqo/audit-webhook/ca/ca.crt
+{{- end }}
+{{- if .clientCertSecretName }}
+- --audit-webhook-cert-file=/etc/liqo/audit-webhook/client/tls.crt
+- --audit-webhook-key-file=/etc/liqo/audit-webhook/client/tls.key
+{{- end }}
+{{- if .tokenSecretName }}
+- --audit-webhook-token-file=/etc/liqo/audit-webhook/token/token
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end -}}
+
+{{/*
+Get the volume mounts required by the audit stream
+*/}}
+{{- define "liqo.auditVolumeMounts" -}}
+{{- if .Values.audit.enabled }}
+{{- if .Values.audit.persistence.enabled }}
+- name: audit-log
+  mountPath: /var/log/liqo/audit
+{{- end }}
+{{- with .Values.audit.webhook }}
+{{- if and .url .caSecretName }}
+- name: audit-webhook-ca
+  mountPath: /etc/liqo/audit-webhook/ca
+  readOnly: true
+{{- end }}
+{{- if and .url .clientCertSecretName }}
+- name: audit-webhook-client
+  mountPath: /etc/liqo/audit-webhook/client
+  readOnly: true
+{{- end }}
+{{- if and .url .tokenSecretName }}
+- name: audit-webhook-token
+  mountPath: /etc/liqo/audit-webhook/token
+  readOnly: true
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end -}}
+
+{{/*
+Get the volumes required by the audit stream
+*/}}
+{{- define "liqo.auditVolumes" -}}
+{{- if .Values.audit.enabled }}
+{{- if .Values.audit.persistence.enabled }}
+{{- $auditConfig := (merge (dict "name" "audit" "module" "audit") .) }}
+- name: audit-log
+  persistentVolumeClaim:
+    claimName: {{ .Values.audit.persistence.existingClaim | default (include "liqo.prefixedName" $auditConfig) }}
+{{- end }}
+{{- with .Values.audit.webhook }}
+{{- if and .url .caSecretName }}
+- name: audit-webhook-ca
+  secret:
+    secretName: {{ .caSecretName }}
+{{- end }}
+{{- if and .url .clientCertSecretName }}
+- name: audit-webhook-client
+  secret:
+    secretName: {{ .clientCertSecretName }}
+{{- end }}
+{{- if and .url .tokenSecretName }}
+- name: audit-webhook-token
+  secret:
+    secretName: {{ .tokenSecretName }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end -}}
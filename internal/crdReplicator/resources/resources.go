@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 )
@@ -46,5 +47,9 @@ func GetResourcesToReplicate() []Resource {
 			GroupVersionResource: authv1beta1.RenewGroupVersionResource,
 			Ownership:            consts.OwnershipShared,
 		},
+		{
+			GroupVersionResource: networkingv1beta1.TransitAdvertisementGroupVersionResource,
+			Ownership:            consts.OwnershipShared,
+		},
 	}
 }
@@ -0,0 +1,129 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+)
+
+var (
+	mutex     sync.RWMutex
+	component string
+	sinks     []Sink
+)
+
+// Config contains the configuration of the audit stream.
+type Config struct {
+	// LogPaths are the paths of the files the audit events are appended to (StdoutPath for the standard output).
+	LogPaths []string
+	// Webhook is the configuration of the HTTPS endpoint the audit events are sent to.
+	Webhook WebhookConfig
+}
+
+// IsEmpty returns whether no destination of the audit events is configured.
+func (c *Config) IsEmpty() bool {
+	return len(c.LogPaths) == 0 && c.Webhook.URL == ""
+}
+
+// InitFlags adds the flags configuring the audit stream to the given flagset.
+func InitFlags(flagset *pflag.FlagSet, cfg *Config) {
+	flagset.StringArrayVar(&cfg.LogPaths, "audit-log-path", nil,
+		"The file the audit events of the operations performed by the consumer clusters are written to ('-' for the standard output). "+
+			"Can be repeated to write the events to multiple files")
+	flagset.StringVar(&cfg.Webhook.URL, "audit-webhook-url", "",
+		"The URL of the HTTPS endpoint the audit events of the operations performed by the consumer clusters are sent to")
+	flagset.StringVar(&cfg.Webhook.CAFile, "audit-webhook-ca-file", "",
+		"The CA bundle used to verify the certificate of the audit webhook (defaults to the system roots)")
+	flagset.StringVar(&cfg.Webhook.CertFile, "audit-webhook-cert-file", "",
+		"The client certificate used to authenticate with the audit webhook")
+	flagset.StringVar(&cfg.Webhook.KeyFile, "audit-webhook-key-file", "",
+		"The key of the client certificate used to authenticate with the audit webhook")
+	flagset.StringVar(&cfg.Webhook.TokenFile, "audit-webhook-token-file", "",
+		"The file containing the bearer token used to authenticate with the audit webhook")
+}
+
+// Setup configures the sinks of the audit events emitted by the given component, according to the configuration.
+// The audit stream is disabled in case no destination is configured.
+func Setup(ctx context.Context, componentName string, cfg *Config) error {
+	var configured []Sink
+	for _, path := range cfg.LogPaths {
+		if path == "" {
+			continue
+		}
+		sink, err := NewFileSink(ctx, path)
+		if err != nil {
+			return err
+		}
+		configured = append(configured, sink)
+	}
+	if cfg.Webhook.URL != "" {
+		sink, err := NewWebhookSink(ctx, &cfg.Webhook)
+		if err != nil {
+			return err
+		}
+		configured = append(configured, sink)
+	}
+
+	Init(componentName, configured...)
+	if len(configured) > 0 {
+		klog.Infof("Audit stream enabled (log paths: %q, webhook URL: %q)", cfg.LogPaths, cfg.Webhook.URL)
+	}
+	return nil
+}
+
+// Init sets the component name and the sinks of the audit events emitted by the current process.
+func Init(componentName string, s ...Sink) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	component = componentName
+	sinks = s
+}
+
+// Enabled returns whether the audit stream is enabled.
+func Enabled() bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	return len(sinks) > 0
+}
+
+// Record emits the given event to the configured sinks, completing the kind, the time and the component.
+// Failures are logged, as they shall not prevent the operations from being carried out.
+func Record(event *Event) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	if len(sinks) == 0 {
+		return
+	}
+
+	event.Kind = EventKind
+	event.Component = component
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	for _, sink := range sinks {
+		if err := sink.Write(event); err != nil {
+			klog.Warningf("Failed to record the %s audit event of cluster %q: %v", event.Action, event.ClusterID, err)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/liqotech/liqo/pkg/audit"
+)
+
+var _ = Describe("Audit stream", func() {
+	var event *audit.Event
+
+	BeforeEach(func() {
+		event = &audit.Event{
+			ClusterID: "consumer",
+			Action:    audit.ActionShadowPodCreate,
+			Outcome:   audit.OutcomeAllowed,
+			Resource:  "shadowpods",
+			Namespace: "foo",
+			Name:      "bar",
+		}
+	})
+
+	AfterEach(func() { audit.Init("") })
+
+	Describe("the Record function", func() {
+		var buffer *bytes.Buffer
+
+		BeforeEach(func() { buffer = &bytes.Buffer{} })
+
+		When("the audit stream is disabled", func() {
+			It("should not emit the event", func() {
+				audit.Record(event)
+				Expect(audit.Enabled()).To(BeFalse())
+				Expect(event.Kind).To(BeEmpty())
+			})
+		})
+
+		When("the audit stream is enabled", func() {
+			BeforeEach(func() {
+				audit.Init("liqo-webhook", audit.NewWriterSink(buffer))
+				audit.Record(event)
+				audit.Record(event)
+			})
+
+			It("should write one JSON line per event", func() {
+				lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+				Expect(lines).To(HaveLen(2))
+
+				parsed, ok := audit.ParseEvent([]byte(lines[0]))
+				Expect(ok).To(BeTrue())
+				Expect(parsed.Kind).To(Equal(audit.EventKind))
+				Expect(parsed.Component).To(Equal("liqo-webhook"))
+				Expect(parsed.ClusterID).To(BeEquivalentTo("consumer"))
+				Expect(parsed.Action).To(Equal(audit.ActionShadowPodCreate))
+				Expect(parsed.Outcome).To(Equal(audit.OutcomeAllowed))
+				Expect(parsed.Namespace).To(Equal("foo"))
+				Expect(parsed.Name).To(Equal("bar"))
+				Expect(parsed.Time).ToNot(BeZero())
+			})
+		})
+	})
+
+	DescribeTable("the ParseEvent function",
+		func(line string, expected bool) {
+			_, ok := audit.ParseEvent([]byte(line))
+			Expect(ok).To(Equal(expected))
+		},
+		Entry("an audit event", `{"kind":"LiqoAuditEvent","clusterID":"consumer","action":"NonceSign"}`, true),
+		Entry("a JSON object of a different kind", `{"kind":"Event","clusterID":"consumer"}`, false),
+		Entry("a log line", `I1018 10:00:00.000000       1 controller.go:42] Reconciled`, false),
+		Entry("an empty line", ``, false),
+	)
+
+	Describe("the file sink", func() {
+		readLines := func(path string) func() []string {
+			return func() []string {
+				data, err := os.ReadFile(path)
+				Expect(err).ToNot(HaveOccurred())
+				return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+			}
+		}
+
+		It("should append the events to the given file", func(ctx context.Context) {
+			path := filepath.Join(GinkgoT().TempDir(), "audit.log")
+			Expect(os.WriteFile(path, []byte("existing\n"), 0o600)).To(Succeed())
+
+			sink, err := audit.NewFileSink(ctx, path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sink.Write(event)).To(Succeed())
+
+			Eventually(readLines(path)).Should(HaveLen(2))
+			lines := readLines(path)()
+			Expect(lines[0]).To(Equal("existing"))
+			_, ok := audit.ParseEvent([]byte(lines[1]))
+			Expect(ok).To(BeFalse(), "the kind is set by the Record function")
+			Expect(lines[1]).To(ContainSubstring(`"action":"ShadowPodCreate"`))
+		})
+
+		It("should write all the events written concurrently, one per line", func(ctx context.Context) {
+			path := filepath.Join(GinkgoT().TempDir(), "audit.log")
+			sink, err := audit.NewFileSink(ctx, path)
+			Expect(err).ToNot(HaveOccurred())
+
+			var wg sync.WaitGroup
+			for range 100 {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					Expect(sink.Write(event)).To(Succeed())
+				}()
+			}
+			wg.Wait()
+
+			Eventually(readLines(path)).Should(And(HaveLen(100), HaveEach(ContainSubstring(`"action":"ShadowPodCreate"`))))
+		})
+
+		It("should fail if the file cannot be opened", func(ctx context.Context) {
+			_, err := audit.NewFileSink(ctx, filepath.Join(GinkgoT().TempDir(), "missing", "audit.log"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("the Setup function", func() {
+		It("should write the events to each of the given files", func(ctx context.Context) {
+			dir := GinkgoT().TempDir()
+			paths := []string{filepath.Join(dir, "first.jsonl"), filepath.Join(dir, "second.jsonl")}
+			Expect(audit.Setup(ctx, "liqo-webhook", &audit.Config{LogPaths: paths})).To(Succeed())
+			audit.Record(event)
+
+			for _, path := range paths {
+				Eventually(func() bool {
+					data, err := os.ReadFile(path)
+					Expect(err).ToNot(HaveOccurred())
+					_, ok := audit.ParseEvent(bytes.TrimSuffix(data, []byte("\n")))
+					return ok
+				}).Should(BeTrue())
+			}
+		})
+	})
+
+	Describe("the webhook sink", func() {
+		var (
+			server    *httptest.Server
+			received  chan *http.Request
+			failures  atomic.Int32
+			cfg       audit.WebhookConfig
+			tokenFile string
+		)
+
+		BeforeEach(func() {
+			received = make(chan *http.Request, 10)
+			failures.Store(0)
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+				if failures.Add(-1) >= 0 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				body, err := io.ReadAll(r.Body)
+				Expect(err).ToNot(HaveOccurred())
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				received <- r
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			dir := GinkgoT().TempDir()
+			caFile := filepath.Join(dir, "ca.crt")
+			Expect(os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600)).To(Succeed())
+			tokenFile = filepath.Join(dir, "token")
+			Expect(os.WriteFile(tokenFile, []byte("first\n"), 0o600)).To(Succeed())
+			cfg = audit.WebhookConfig{URL: server.URL, CAFile: caFile, TokenFile: tokenFile}
+		})
+
+		AfterEach(func() { server.Close() })
+
+		receive := func() (*audit.Event, string) {
+			var req *http.Request
+			Eventually(received).WithTimeout(5 * time.Second).Should(Receive(&req))
+			body, err := io.ReadAll(req.Body)
+			Expect(err).ToNot(HaveOccurred())
+			parsed, ok := audit.ParseEvent(body)
+			Expect(ok).To(BeTrue())
+			return parsed, req.Header.Get("Authorization")
+		}
+
+		It("should post the events to the given URL, authenticating with the current token", func(ctx context.Context) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			sink, err := audit.NewWebhookSink(ctx, &cfg)
+			Expect(err).ToNot(HaveOccurred())
+			audit.Init("liqo-controller-manager", sink)
+
+			audit.Record(event)
+			parsed, authorization := receive()
+			Expect(parsed.Component).To(Equal("liqo-controller-manager"))
+			Expect(parsed.Action).To(Equal(audit.ActionShadowPodCreate))
+			Expect(authorization).To(Equal("Bearer first"))
+
+			Expect(os.WriteFile(tokenFile, []byte("second"), 0o600)).To(Succeed())
+			audit.Record(event)
+			_, authorization = receive()
+			Expect(authorization).To(Equal("Bearer second"))
+		})
+
+		It("should retry the delivery of the events the endpoint failed to receive", func(ctx context.Context) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			failures.Store(1)
+			sink, err := audit.NewWebhookSink(ctx, &cfg)
+			Expect(err).ToNot(HaveOccurred())
+			audit.Init("liqo-controller-manager", sink)
+
+			audit.Record(event)
+			parsed, _ := receive()
+			Expect(parsed.Action).To(Equal(audit.ActionShadowPodCreate))
+		})
+
+		It("should not trust the certificate of the endpoint if the CA is not configured", func(ctx context.Context) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			cfg.CAFile = ""
+			sink, err := audit.NewWebhookSink(ctx, &cfg)
+			Expect(err).ToNot(HaveOccurred())
+			audit.Init("liqo-controller-manager", sink)
+
+			audit.Record(event)
+			Consistently(received).WithTimeout(2 * time.Second).ShouldNot(Receive())
+		})
+
+		It("should refuse plain HTTP endpoints", func(ctx context.Context) {
+			cfg.URL = strings.Replace(server.URL, "https://", "http://", 1)
+			_, err := audit.NewWebhookSink(ctx, &cfg)
+			Expect(err).To(MatchError(ContainSubstring("unsupported scheme")))
+		})
+	})
+})
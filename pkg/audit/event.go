@@ -0,0 +1,98 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// EventKind is the kind identifying the audit events within a stream possibly containing other log lines.
+const EventKind = "LiqoAuditEvent"
+
+// Action is the operation tracked by an audit event.
+type Action string
+
+const (
+	// ActionTenantCreate tracks the acceptance of a new Tenant, and the creation of the corresponding tenant namespace.
+	ActionTenantCreate Action = "TenantCreate"
+	// ActionTenantConditionChange tracks the transitions of the condition (i.e., active, cordoned, drained) of a Tenant.
+	ActionTenantConditionChange Action = "TenantConditionChange"
+	// ActionTenantDelete tracks the deletion of a Tenant.
+	ActionTenantDelete Action = "TenantDelete"
+	// ActionNonceSign tracks the signature of the nonce requested by a consumer cluster.
+	ActionNonceSign Action = "NonceSign"
+	// ActionResourceSliceAuthentication tracks the acceptance and denial of the authentication of a ResourceSlice.
+	ActionResourceSliceAuthentication Action = "ResourceSliceAuthentication"
+	// ActionResourceSliceResources tracks the acceptance and denial of the resources requested by a ResourceSlice.
+	ActionResourceSliceResources Action = "ResourceSliceResources"
+	// ActionShadowPodCreate tracks the admission of the creation of a ShadowPod.
+	ActionShadowPodCreate Action = "ShadowPodCreate"
+	// ActionShadowPodDelete tracks the admission of the deletion of a ShadowPod.
+	ActionShadowPodDelete Action = "ShadowPodDelete"
+)
+
+// Outcome is the result of the operation tracked by an audit event.
+type Outcome string
+
+const (
+	// OutcomeSucceeded is the outcome of the operations carried out by the local cluster.
+	OutcomeSucceeded Outcome = "Succeeded"
+	// OutcomeAllowed is the outcome of the requests of the consumer cluster which have been allowed.
+	OutcomeAllowed Outcome = "Allowed"
+	// OutcomeDenied is the outcome of the requests of the consumer cluster which have been denied.
+	OutcomeDenied Outcome = "Denied"
+)
+
+// Event is a record of the audit stream.
+type Event struct {
+	// Kind identifies the audit events, and it is always set to EventKind.
+	Kind string `json:"kind"`
+	// Time is the instant the operation has been performed.
+	Time time.Time `json:"time"`
+	// Component is the liqo component which emitted the event.
+	Component string `json:"component"`
+	// ClusterID is the ID of the consumer cluster the operation refers to.
+	ClusterID liqov1beta1.ClusterID `json:"clusterID"`
+	// Action is the operation tracked by the event.
+	Action Action `json:"action"`
+	// Outcome is the result of the operation.
+	Outcome Outcome `json:"outcome"`
+	// Resource is the type of the object the operation refers to.
+	Resource string `json:"resource"`
+	// Namespace is the namespace of the object the operation refers to, if any.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the object the operation refers to.
+	Name string `json:"name,omitempty"`
+	// Reason is a machine-readable explanation of the outcome of the operation.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable description of the operation.
+	Message string `json:"message,omitempty"`
+	// User is the authenticated user who requested the operation, if performed on behalf of a consumer.
+	User string `json:"user,omitempty"`
+	// Groups are the groups the authenticated user who requested the operation belongs to.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ParseEvent parses a line of an audit stream, returning false in case it does not contain an audit event.
+func ParseEvent(line []byte) (*Event, bool) {
+	var event Event
+	if err := json.Unmarshal(line, &event); err != nil || event.Kind != EventKind {
+		return nil, false
+	}
+	return &event, true
+}
@@ -0,0 +1,302 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// StdoutPath is the path selecting the standard output as destination of the audit events.
+	StdoutPath = "-"
+
+	fileBufferSize    = 1024
+	webhookBufferSize = 1024
+	webhookTimeout    = 10 * time.Second
+)
+
+// webhookBackoff is the backoff applied to retry the delivery of the events the webhook endpoint failed to receive.
+var webhookBackoff = wait.Backoff{Duration: time.Second, Factor: 2, Jitter: 0.1, Steps: 5}
+
+// Sink is the destination of the audit events.
+type Sink interface {
+	// Write emits the given event.
+	Write(event *Event) error
+}
+
+// writerSink is a sink writing the audit events as JSON lines to an io.Writer.
+type writerSink struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+// NewWriterSink returns a sink writing the audit events as JSON lines to the given writer.
+func NewWriterSink(writer io.Writer) Sink {
+	return &writerSink{writer: writer}
+}
+
+// Write implements the Sink interface.
+func (s *writerSink) Write(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, err = s.writer.Write(append(data, '\n'))
+	return err
+}
+
+// fileSink is a sink appending the audit events as JSON lines to a file.
+// Events are written asynchronously, not to slow down the operations they refer to.
+type fileSink struct {
+	file *os.File
+	// sync is whether the file is synced after each batch of events, so that they are persisted even if the process crashes.
+	sync   bool
+	events chan []byte
+}
+
+// NewFileSink returns a sink appending the audit events as JSON lines to the file at the given path.
+// The events are buffered and written in background until the context is canceled, and dropped if the buffer is full.
+// The file is synced after each batch of events, hence, if stored on a persistent volume, the events survive the restarts
+// of the process. The standard output is used in case the path is StdoutPath.
+func NewFileSink(ctx context.Context, path string) (Sink, error) {
+	sink := &fileSink{file: os.Stdout, events: make(chan []byte, fileBufferSize)}
+
+	if path != StdoutPath {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the audit log file %q: %w", path, err)
+		}
+		sink.file, sink.sync = file, true
+	}
+
+	go sink.run(ctx)
+	return sink, nil
+}
+
+// Write implements the Sink interface.
+func (s *fileSink) Write(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case s.events <- append(data, '\n'):
+		return nil
+	default:
+		return errors.New("audit log buffer full, event dropped")
+	}
+}
+
+func (s *fileSink) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			// Flush the events still queued before terminating.
+			s.writeBatch(s.next())
+			if s.sync {
+				if err := s.file.Close(); err != nil {
+					klog.Errorf("Failed to close the audit log file %q: %v", s.file.Name(), err)
+				}
+			}
+			return
+		case data := <-s.events:
+			s.writeBatch(data)
+		}
+	}
+}
+
+// writeBatch writes the given event, along with all the ones already queued, and then syncs the file once.
+func (s *fileSink) writeBatch(data []byte) {
+	for ; data != nil; data = s.next() {
+		if _, err := s.file.Write(data); err != nil {
+			klog.Errorf("Failed to write audit event to %q, event dropped: %v", s.file.Name(), err)
+		}
+	}
+
+	if s.sync {
+		if err := s.file.Sync(); err != nil {
+			klog.Errorf("Failed to sync the audit log file %q: %v", s.file.Name(), err)
+		}
+	}
+}
+
+// next returns the next queued event, if any, without blocking.
+func (s *fileSink) next() []byte {
+	select {
+	case data := <-s.events:
+		return data
+	default:
+		return nil
+	}
+}
+
+// WebhookConfig contains the configuration of the HTTPS endpoint the audit events are sent to.
+type WebhookConfig struct {
+	// URL is the HTTPS endpoint the audit events are sent to.
+	URL string
+	// CAFile is the path of the CA bundle used to verify the certificate of the endpoint. If empty, the system roots are used.
+	CAFile string
+	// CertFile and KeyFile are the paths of the client certificate and key used to authenticate with the endpoint.
+	CertFile string
+	KeyFile  string
+	// TokenFile is the path of the file containing the bearer token used to authenticate with the endpoint.
+	// It is read before each request, so that the token can be rotated without restarting the process.
+	TokenFile string
+}
+
+// webhookSink is a sink sending the audit events to an HTTPS endpoint.
+// Events are sent asynchronously, not to slow down the operations they refer to.
+type webhookSink struct {
+	url       string
+	tokenFile string
+	client    *http.Client
+	events    chan []byte
+}
+
+// NewWebhookSink returns a sink sending each audit event as the JSON body of a POST request to the configured HTTPS endpoint,
+// optionally authenticating through a client certificate or a bearer token. The events are buffered and sent in background
+// until the context is canceled, and dropped if the buffer is full. Failed deliveries are retried with exponential backoff.
+func NewWebhookSink(ctx context.Context, cfg *WebhookConfig) (Sink, error) {
+	endpoint, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit webhook URL %q: %w", cfg.URL, err)
+	}
+	if endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q for the audit webhook URL (supported: https)", endpoint.Scheme)
+	}
+
+	tlsConfig, err := forgeTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.CertFile == "" && cfg.TokenFile == "" {
+		klog.Warningf("No credentials configured to authenticate with the audit webhook %q", cfg.URL)
+	}
+
+	sink := &webhookSink{
+		url:       cfg.URL,
+		tokenFile: cfg.TokenFile,
+		client:    &http.Client{Timeout: webhookTimeout, Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+		events:    make(chan []byte, webhookBufferSize),
+	}
+
+	go sink.run(ctx)
+	return sink, nil
+}
+
+func forgeTLSConfig(cfg *WebhookConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the audit webhook CA: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no valid certificate found in the audit webhook CA %q", cfg.CAFile)
+		}
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate for the audit webhook: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// Write implements the Sink interface.
+func (s *webhookSink) Write(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case s.events <- data:
+		return nil
+	default:
+		return errors.New("webhook buffer full, event dropped")
+	}
+}
+
+func (s *webhookSink) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data := <-s.events:
+			var err error
+			if retryErr := wait.ExponentialBackoffWithContext(ctx, webhookBackoff, func(ctx context.Context) (bool, error) {
+				err = s.send(ctx, data)
+				return err == nil, nil
+			}); retryErr != nil {
+				klog.Errorf("Failed to send audit event to %q, event dropped: %v", s.url, err)
+			}
+		}
+	}
+}
+
+func (s *webhookSink) send(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if s.tokenFile != "" {
+		token, err := os.ReadFile(s.tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the audit webhook token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+var (
+	usernamePrefix string
+	groupsPrefix   string
+)
+
+// SetIdentityPrefixes sets the prefixes the local API server adds to the username and the groups of the remote clusters
+// (i.e., the values of its --oidc-username-prefix and --oidc-groups-prefix flags, when authenticated through OIDC tokens).
+func SetIdentityPrefixes(username, groups string) {
+	usernamePrefix = username
+	groupsPrefix = groups
+}
+
+// UserName returns the username the local API server authenticates the given remote identity with.
+func UserName(name string) string {
+	return usernamePrefix + name
+}
+
+// GroupName returns the group the local API server authenticates the given remote identity group with.
+func GroupName(name string) string {
+	return groupsPrefix + name
+}
@@ -31,6 +31,10 @@ func (c *FakeOffloadingV1beta1) NamespaceMaps(namespace string) v1beta1.Namespac
 	return newFakeNamespaceMaps(c, namespace)
 }
 
+func (c *FakeOffloadingV1beta1) OffloadedNamespaceQuotas(namespace string) v1beta1.OffloadedNamespaceQuotaInterface {
+	return newFakeOffloadedNamespaceQuotas(c, namespace)
+}
+
 func (c *FakeOffloadingV1beta1) ShadowEndpointSlices(namespace string) v1beta1.ShadowEndpointSliceInterface {
 	return newFakeShadowEndpointSlices(c, namespace)
 }
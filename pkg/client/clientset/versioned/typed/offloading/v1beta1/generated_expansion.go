@@ -18,6 +18,8 @@ package v1beta1
 
 type NamespaceMapExpansion interface{}
 
+type OffloadedNamespaceQuotaExpansion interface{}
+
 type ShadowEndpointSliceExpansion interface{}
 
 type ShadowPodExpansion interface{}
@@ -28,6 +28,7 @@ import (
 type OffloadingV1beta1Interface interface {
 	RESTClient() rest.Interface
 	NamespaceMapsGetter
+	OffloadedNamespaceQuotasGetter
 	ShadowEndpointSlicesGetter
 	ShadowPodsGetter
 	VirtualNodesGetter
@@ -43,6 +44,10 @@ func (c *OffloadingV1beta1Client) NamespaceMaps(namespace string) NamespaceMapIn
 	return newNamespaceMaps(c, namespace)
 }
 
+func (c *OffloadingV1beta1Client) OffloadedNamespaceQuotas(namespace string) OffloadedNamespaceQuotaInterface {
+	return newOffloadedNamespaceQuotas(c, namespace)
+}
+
 func (c *OffloadingV1beta1Client) ShadowEndpointSlices(namespace string) ShadowEndpointSliceInterface {
 	return newShadowEndpointSlices(c, namespace)
 }
@@ -59,6 +59,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=offloading, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("namespacemaps"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Offloading().V1beta1().NamespaceMaps().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("offloadednamespacequotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Offloading().V1beta1().OffloadedNamespaceQuotas().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("shadowendpointslices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Offloading().V1beta1().ShadowEndpointSlices().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("shadowpods"):
@@ -24,6 +24,8 @@ import (
 type Interface interface {
 	// NamespaceMaps returns a NamespaceMapInformer.
 	NamespaceMaps() NamespaceMapInformer
+	// OffloadedNamespaceQuotas returns a OffloadedNamespaceQuotaInformer.
+	OffloadedNamespaceQuotas() OffloadedNamespaceQuotaInformer
 	// ShadowEndpointSlices returns a ShadowEndpointSliceInformer.
 	ShadowEndpointSlices() ShadowEndpointSliceInformer
 	// ShadowPods returns a ShadowPodInformer.
@@ -50,6 +52,11 @@ func (v *version) NamespaceMaps() NamespaceMapInformer {
 	return &namespaceMapInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// OffloadedNamespaceQuotas returns a OffloadedNamespaceQuotaInformer.
+func (v *version) OffloadedNamespaceQuotas() OffloadedNamespaceQuotaInformer {
+	return &offloadedNamespaceQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ShadowEndpointSlices returns a ShadowEndpointSliceInformer.
 func (v *version) ShadowEndpointSlices() ShadowEndpointSliceInformer {
 	return &shadowEndpointSliceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
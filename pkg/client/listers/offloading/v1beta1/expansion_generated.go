@@ -24,6 +24,14 @@ type NamespaceMapListerExpansion interface{}
 // NamespaceMapNamespaceLister.
 type NamespaceMapNamespaceListerExpansion interface{}
 
+// OffloadedNamespaceQuotaListerExpansion allows custom methods to be added to
+// OffloadedNamespaceQuotaLister.
+type OffloadedNamespaceQuotaListerExpansion interface{}
+
+// OffloadedNamespaceQuotaNamespaceListerExpansion allows custom methods to be added to
+// OffloadedNamespaceQuotaNamespaceLister.
+type OffloadedNamespaceQuotaNamespaceListerExpansion interface{}
+
 // ShadowEndpointSliceListerExpansion allows custom methods to be added to
 // ShadowEndpointSliceLister.
 type ShadowEndpointSliceListerExpansion interface{}
@@ -55,6 +55,32 @@ const (
 	// RenewAnnotation is the value of the annotation that enables the renewal of a resource.
 	RenewAnnotation = "liqo.io/renew"
 
+	// RevokeCredentialsAnnotation is the value of the annotation that triggers the revocation of the credentials of a tenant.
+	RevokeCredentialsAnnotation = "liqo.io/revoke-credentials"
+
+	// LastAuditedTenantConditionAnnotation is the annotation storing the last condition of a tenant recorded in the audit stream.
+	LastAuditedTenantConditionAnnotation = "liqo.io/last-audited-tenant-condition"
+
+	// PeeringRoleProfileLabelKey is the key of the label identifying the PeeringRoleProfile a ClusterRole is derived from.
+	PeeringRoleProfileLabelKey = "liqo.io/peering-role-profile"
+
+	// LastScaleTimeAnnotation is the annotation storing the last time the resources of an autoscaled ResourceSlice were changed.
+	LastScaleTimeAnnotation = "liqo.io/last-scale-time"
+
 	// PeeringUserNameLabelKey labels all the resources created to grant peering permissions to the user doing a pering toward this cluster.
 	PeeringUserNameLabelKey = "liqo.io/peering-user-name"
 )
+
+var (
+	// TenantClusterRolesAppNames are the "app.kubernetes.io/name" label values assigned to the
+	// ClusterRoles to be bound to the control-plane Identity in the tenant namespace.
+	TenantClusterRolesAppNames = []string{
+		"remote-controlplane",
+	}
+
+	// TenantClusterRolesClusterWideAppNames are the "app.kubernetes.io/name" label values assigned to the
+	// ClusterRoles to be bound to the control-plane Identity with cluster-wide scope.
+	TenantClusterRolesClusterWideAppNames = []string{
+		"virtual-kubelet-remote-clusterwide",
+	}
+)
@@ -32,9 +32,14 @@ const (
 	// Networking.
 	CtrlConfigurationExternal  = "configuration_external"
 	CtrlConfigurationInternal  = "configuration_internal"
+	CtrlConfigurationNetPolicy = "configuration_netpolicy"
 	CtrlConfigurationRemapping = "configuration_remapping"
 	CtrlConfigurationRoute     = "configuration_route"
+	CtrlConfigurationTransit   = "configuration_transit"
 	CtrlConnection             = "connection"
+	CtrlConnectionMSSClamp     = "connection_mssclamp"
+	CtrlFabricEncryptionGw     = "fabricencryption_geneve"
+	CtrlFabricEncryptionNode   = "fabricencryption_fabric"
 	CtrlFirewallConfiguration  = "firewallconfiguration"
 	CtrlGatewayClientExternal  = "gatewayclient_external"
 	CtrlGatewayClientInternal  = "gatewayclient_internal"
@@ -46,6 +51,8 @@ const (
 	CtrlInternalNodeRoute      = "internalnode_route"
 	CtrlIP                     = "ip"
 	CtrlIPRemapping            = "ip_remapping"
+	CtrlIPsecGatewayClient     = "ipsecgatewayclient"
+	CtrlIPsecGatewayServer     = "ipsecgatewayserver"
 	CtrlNetwork                = "network"
 	CtrlNode                   = "node"
 	CtrlPodGateway             = "pod_gateway"
@@ -59,6 +66,7 @@ const (
 	// Authentication.
 	CtrlIdentity            = "identity"
 	CtrlIdentityCreator     = "identity_creator"
+	CtrlPeeringRoleProfile  = "peeringroleprofile"
 	CtrlRenewLocal          = "renew_local"
 	CtrlRenewRemote         = "renew_remote"
 	CtrlSecretNonceCreator  = "secret_noncecreator"
@@ -68,8 +76,10 @@ const (
 	CtrlTenant              = "tenant"
 
 	// Offloading.
+	CtrlMigration           = "migration"
 	CtrlNamespaceMap        = "namespacemap"
 	CtrlNamespaceOffloading = "namespaceoffloading"
+	CtrlNamespaceQuota      = "offloadednamespacequota"
 	CtrlNodeFailure         = "node_failure"
 	CtrlPodStatus           = "pod_status"
 	CtrlShadowEndpointSlice = "shadowendpointslice"
@@ -79,6 +89,7 @@ const (
 	// Cross modules.
 	CtrlResourceSliceQuotaCreator = "resourceslice_quotacreator"
 	CtrlResourceSliceVNCreator    = "resourceslice_vncreator"
+	CtrlResourceSliceAutoscaler   = "resourceslice_autoscaler"
 	CtrlPodIPMapping              = "pod_ipmapping"
 	CtrlConfigurationIPMapping    = "configuration_ipmapping"
 )
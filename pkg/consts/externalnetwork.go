@@ -36,6 +36,8 @@ const (
 	PrivateKeyField = "privateKey"
 	// PublicKeyField is the data field of the secrets containing public keys.
 	PublicKeyField = "publicKey"
+	// PresharedKeyField is the optional data field of the secrets containing the IPsec pre-shared key.
+	PresharedKeyField = "presharedKey"
 
 	// ClusterRoleBindingFinalizer is the finalizer added ti the owner when a ClusterRoleBinding is created.
 	ClusterRoleBindingFinalizer = "networking.liqo.io/clusterrolebinding"
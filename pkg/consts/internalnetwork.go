@@ -30,4 +30,23 @@ const (
 	// InternalFabricGeneveTunnelFinalizer is the finalizer used to ensure that the geneve tunnel is deleted and the
 	// id is freed.
 	InternalFabricGeneveTunnelFinalizer = "networking.liqo.io/internal-fabric-geneve-tunnel-finalizer"
+
+	// DefaultFabricEncryptionPort is the default port used by the WireGuard peerings encrypting the internal fabric.
+	DefaultFabricEncryptionPort = 51840
+	// FabricEncryptionOverhead is the overhead added by the WireGuard peerings encrypting the internal fabric to the geneve packets,
+	// considering the outer IPv6 header (40 bytes), the UDP header (8 bytes) and the WireGuard header and tag (32 bytes).
+	FabricEncryptionOverhead = 80
+	// FabricEncryptionInterfaceName is the name of the WireGuard interface used to encrypt the internal fabric.
+	FabricEncryptionInterfaceName = "liqo-fabric-wg"
+	// FabricEncryptionRouteTable is the name of the table containing the routes towards the encrypted peers of the internal fabric.
+	FabricEncryptionRouteTable = "liqo-fabric-encryption"
+	// FabricPublicKeyRoleLabel is the label used to identify the PublicKeys of the internal fabric, and whether they belong
+	// to a gateway or to a node.
+	FabricPublicKeyRoleLabel = "networking.liqo.io/fabric-publickey-role"
+	// FabricPublicKeyRoleGateway is the value of the FabricPublicKeyRoleLabel for the PublicKeys of the gateways.
+	FabricPublicKeyRoleGateway = "gateway"
+	// FabricPublicKeyRoleNode is the value of the FabricPublicKeyRoleLabel for the PublicKeys of the nodes.
+	FabricPublicKeyRoleNode = "node"
+	// FabricPublicKeyEndpointAnnotation is the annotation containing the IP used to reach the gateway owning a PublicKey.
+	FabricPublicKeyEndpointAnnotation = "networking.liqo.io/fabric-endpoint"
 )
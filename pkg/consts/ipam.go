@@ -41,6 +41,12 @@ const (
 	NetworkTypeExternalCIDR NetworkType = "external-cidr"
 	// NetworkTypeInternalCIDR is the constant representing a network of type internalCIDR.
 	NetworkTypeInternalCIDR NetworkType = "internal-cidr"
+	// NetworkTypePodCIDRSecondary is the constant representing a network of type podCIDR of the secondary IP family (dual-stack clusters).
+	NetworkTypePodCIDRSecondary NetworkType = "pod-cidr-secondary"
+	// NetworkTypeServiceCIDRSecondary is the constant representing a network of type serviceCIDR of the secondary IP family (dual-stack clusters).
+	NetworkTypeServiceCIDRSecondary NetworkType = "service-cidr-secondary"
+	// NetworkTypeExternalCIDRSecondary is the constant representing a network of type externalCIDR of the secondary IP family (dual-stack clusters).
+	NetworkTypeExternalCIDRSecondary NetworkType = "external-cidr-secondary"
 	// NetworkTypeReserved is the constant representing a network of type reserved subnet.
 	NetworkTypeReserved NetworkType = "reserved"
 
@@ -51,6 +57,11 @@ const (
 	// IPTypeAPIServerProxy is the constant representing an IP of type APIServerProxy.
 	IPTypeAPIServerProxy = "api-server-proxy"
 
+	// OffloadedPodNameLabelKey is the label key used to indicate the name of the offloaded pod an IP refers to.
+	OffloadedPodNameLabelKey = "offloading.liqo.io/pod-name"
+	// OffloadedPodNamespaceLabelKey is the label key used to indicate the namespace of the offloaded pod an IP refers to.
+	OffloadedPodNamespaceLabelKey = "offloading.liqo.io/pod-namespace"
+
 	// NetworkNamespaceLabelKey is the label key used to indicate the namespace of a Network.
 	NetworkNamespaceLabelKey = "ipam.liqo.io/network-namespace"
 	// NetworkNameLabelKey is the label key used to indicate the name of a Network.
@@ -63,4 +74,6 @@ const (
 var (
 	// PrivateAddressSpace contains all the ranges for private addresses as defined in RFC1918.
 	PrivateAddressSpace = []string{"10.0.0.0/8", "192.168.0.0/16", "172.16.0.0/12"}
+	// PrivateAddressSpaceIPv6 contains the range for IPv6 unique local addresses as defined in RFC4193.
+	PrivateAddressSpaceIPv6 = []string{"fc00::/7"}
 )
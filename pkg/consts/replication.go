@@ -58,6 +58,10 @@ const (
 	// use the same node port on both clusters.
 	ForceRemoteNodePortAnnotationKey = "liqo.io/force-remote-node-port"
 
+	// StatusReflectedFromAnnotationKey is the annotation key set on local services and ingresses to track the ID of the
+	// remote cluster their status is currently reflected from, to prevent different virtual nodes from overwriting each other.
+	StatusReflectedFromAnnotationKey = "liqo.io/status-reflected-from"
+
 	// SkipReflectionAnnotationKey is the annotation key used to indicate that a given object should not be reflected into a remote cluster.
 	SkipReflectionAnnotationKey = "liqo.io/skip-reflection"
 
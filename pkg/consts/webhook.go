@@ -19,6 +19,14 @@ const (
 	// to Liqo taint.
 	VirtualNodeTolerationKey = "virtual-node.liqo.io/not-allowed"
 
+	// MigrationAnnotationKey is the annotation set on the pods created while a Migration is in progress,
+	// to track the name of the Migration they are subject to.
+	MigrationAnnotationKey = "liqo.io/migration"
+
+	// MigrationSchedulingGate is the scheduling gate preventing the pods subject to a Migration from being
+	// scheduled before their volumes have been moved to the target node.
+	MigrationSchedulingGate = "liqo.io/migration"
+
 	// WebHookLabel used to mark the resouces related to the Liqo webhooks.
 	WebHookLabel = "liqo.io/webhook"
 
@@ -0,0 +1,232 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/route"
+	"github.com/liqotech/liqo/pkg/utils/network/wireguard"
+)
+
+// EncryptionReconciler manages the WireGuard peerings encrypting the geneve tunnels between the node and the gateways,
+// for the InternalFabrics where it is enabled.
+type EncryptionReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	EventsRecorder record.EventRecorder
+	Options        *Options
+
+	privateKey wgtypes.Key
+	tableID    uint32
+}
+
+// NewEncryptionReconciler returns a new EncryptionReconciler.
+// The private key of the node is generated at startup, and the corresponding public key is published through a PublicKey
+// resource in the namespace of each encrypted InternalFabric.
+func NewEncryptionReconciler(cl client.Client, s *runtime.Scheme,
+	er record.EventRecorder, opts *Options) (*EncryptionReconciler, error) {
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate the fabric encryption key: %w", err)
+	}
+	tableID, err := route.GetTableID(consts.FabricEncryptionRouteTable)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptionReconciler{
+		Client:         cl,
+		Scheme:         s,
+		EventsRecorder: er,
+		Options:        opts,
+		privateKey:     privateKey,
+		tableID:        tableID,
+	}, nil
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=internalfabrics,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=publickeies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// Reconcile publishes the key of the node for the given InternalFabric, and configures the peerings towards all the gateways.
+func (r *EncryptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	internalFabric := &networkingv1beta1.InternalFabric{}
+	err := r.Get(ctx, req.NamespacedName, internalFabric)
+	switch {
+	case apierrors.IsNotFound(err):
+		// The PublicKey of the node is garbage collected together with the InternalFabric.
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("unable to get the internalfabric %q: %w", req.NamespacedName, err)
+	case wireguard.IsEncrypted(internalFabric):
+		lbls := wireguard.ForgePublicKeyLabels(internalFabric.Name, consts.FabricPublicKeyRoleNode)
+		lbls[consts.InternalNodeName] = r.Options.NodeName
+		if err := wireguard.EnsurePublicKeyPresence(ctx, r.Client, r.Scheme, internalFabric, r.publicKeyName(),
+			lbls, nil, r.privateKey.PublicKey()); err != nil {
+			return ctrl.Result{}, err
+		}
+	default:
+		if err := wireguard.EnsurePublicKeyAbsence(ctx, r.Client, r.publicKeyName(), internalFabric.Namespace); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// The WireGuard interface is shared by all the InternalFabrics, hence it is configured considering all of them.
+	var internalFabrics networkingv1beta1.InternalFabricList
+	if err := r.List(ctx, &internalFabrics); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to list the internalfabrics: %w", err)
+	}
+
+	var peers []wireguard.Peer
+	for i := range internalFabrics.Items {
+		if !wireguard.IsEncrypted(&internalFabrics.Items[i]) {
+			continue
+		}
+		fabricPeers, err := r.forgePeers(ctx, &internalFabrics.Items[i])
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		peers = append(peers, fabricPeers...)
+	}
+
+	if len(peers) == 0 {
+		if err := wireguard.EnsureRoutesAbsence(r.tableID); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, wireguard.EnsureWireGuardInterfaceAbsence(consts.FabricEncryptionInterfaceName)
+	}
+
+	if err := wireguard.EnsureWireGuardInterfacePresence(consts.FabricEncryptionInterfaceName,
+		r.privateKey, r.Options.EncryptionPort, peers); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	endpoints := make([]net.IP, len(peers))
+	for i := range peers {
+		endpoints[i] = peers[i].Endpoint
+	}
+	if err := wireguard.EnsureRoutesPresence(consts.FabricEncryptionInterfaceName, r.tableID,
+		r.Options.GenevePort, endpoints); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	klog.Infof("Enforced fabric encryption towards %d gateways", len(peers))
+	return ctrl.Result{}, nil
+}
+
+// forgePeers returns the WireGuard peers corresponding to the gateway replicas of the given InternalFabric.
+func (r *EncryptionReconciler) forgePeers(ctx context.Context, internalFabric *networkingv1beta1.InternalFabric) ([]wireguard.Peer, error) {
+	publicKeys, err := wireguard.ListPublicKeys(ctx, r.Client, internalFabric, consts.FabricPublicKeyRoleGateway)
+	if err != nil {
+		return nil, err
+	}
+
+	localGateways, err := r.listLocalGatewayIPs(ctx, internalFabric.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []networkingv1beta1.IP{internalFabric.Spec.GatewayIP}
+	for i := range internalFabric.Spec.Replicas {
+		endpoints = append(endpoints, internalFabric.Spec.Replicas[i].GatewayIP)
+	}
+
+	var peers []wireguard.Peer
+	for _, endpoint := range endpoints {
+		if slices.Contains(localGateways, endpoint.String()) {
+			// The traffic towards the gateways hosted by the node does not leave the node, hence it is not encrypted.
+			continue
+		}
+
+		index := slices.IndexFunc(publicKeys, func(pk networkingv1beta1.PublicKey) bool {
+			return pk.Annotations[consts.FabricPublicKeyEndpointAnnotation] == endpoint.String()
+		})
+		if index < 0 {
+			klog.Infof("waiting for the gateway %s of internalfabric %s to publish its key...", endpoint, internalFabric.Name)
+			continue
+		}
+
+		key, err := wireguard.ParsePublicKey(&publicKeys[index])
+		if err != nil {
+			klog.Warning(err)
+			continue
+		}
+
+		endpointIP := net.ParseIP(endpoint.String())
+		peers = append(peers, wireguard.Peer{PublicKey: key, Endpoint: endpointIP, AllowedIPs: []net.IP{endpointIP}})
+	}
+	return peers, nil
+}
+
+// listLocalGatewayIPs returns the IPs of the gateway pods in the given namespace which are hosted by the node.
+func (r *EncryptionReconciler) listLocalGatewayIPs(ctx context.Context, namespace string) ([]string, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(namespace),
+		client.MatchingLabels(gateway.ForgeActiveGatewayPodLabels())); err != nil {
+		return nil, fmt.Errorf("unable to list the gateway pods in namespace %s: %w", namespace, err)
+	}
+
+	var ips []string
+	for i := range pods.Items {
+		if pods.Items[i].Spec.NodeName == r.Options.NodeName && pods.Items[i].Status.PodIP != "" {
+			ips = append(ips, pods.Items[i].Status.PodIP)
+		}
+	}
+	return ips, nil
+}
+
+func (r *EncryptionReconciler) publicKeyName() string {
+	return fmt.Sprintf("fabric-node-%s", r.Options.NodeName)
+}
+
+// SetupWithManager register the EncryptionReconciler to the manager.
+func (r *EncryptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	gatewayKeys := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetLabels()[consts.FabricPublicKeyRoleLabel] == consts.FabricPublicKeyRoleGateway
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlFabricEncryptionNode).
+		For(&networkingv1beta1.InternalFabric{}).
+		Watches(&networkingv1beta1.PublicKey{}, handler.EnqueueRequestsFromMapFunc(publicKeyToInternalFabricEnqueuer),
+			builder.WithPredicates(gatewayKeys)).
+		Complete(r)
+}
+
+func publicKeyToInternalFabricEnqueuer(_ context.Context, obj client.Object) []reconcile.Request {
+	v, ok := obj.GetLabels()[consts.InternalFabricName]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: v, Namespace: obj.GetNamespace()}}}
+}
@@ -0,0 +1,207 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package fabric
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/utils/network/wireguard"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+)
+
+const (
+	testNamespace = "liqo-tenant-remote"
+	testNodeName  = "worker-1"
+	testTableID   = 1234
+)
+
+// forgeGatewayPublicKey returns the PublicKey published by the gateway reachable through the given endpoint.
+func forgeGatewayPublicKey(name, internalFabricName, endpoint string, key wgtypes.Key) *networkingv1beta1.PublicKey {
+	return &networkingv1beta1.PublicKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   testNamespace,
+			Labels:      wireguard.ForgePublicKeyLabels(internalFabricName, consts.FabricPublicKeyRoleGateway),
+			Annotations: map[string]string{consts.FabricPublicKeyEndpointAnnotation: endpoint},
+		},
+		Spec: networkingv1beta1.PublicKeySpec{PublicKey: key[:]},
+	}
+}
+
+// forgeGatewayPod returns an active gateway pod with the given IP, scheduled on the given node.
+func forgeGatewayPod(name, nodeName, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: gateway.ForgeActiveGatewayPodLabels()},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status:     corev1.PodStatus{PodIP: ip},
+	}
+}
+
+func generateKey() wgtypes.Key {
+	key, err := wgtypes.GeneratePrivateKey()
+	Expect(err).NotTo(HaveOccurred())
+	return key.PublicKey()
+}
+
+var _ = Describe("Fabric encryption controller", func() {
+	var (
+		ctx            context.Context
+		internalFabric *networkingv1beta1.InternalFabric
+		key1, key2     wgtypes.Key
+	)
+
+	newReconciler := func(objs ...client.Object) *EncryptionReconciler {
+		privateKey, err := wgtypes.GeneratePrivateKey()
+		Expect(err).NotTo(HaveOccurred())
+		return &EncryptionReconciler{
+			Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Scheme:         scheme,
+			EventsRecorder: record.NewFakeRecorder(10),
+			Options:        &Options{NodeName: testNodeName, GenevePort: consts.DefaultGenevePort},
+			privateKey:     privateKey,
+			tableID:        testTableID,
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		key1, key2 = generateKey(), generateKey()
+		internalFabric = &networkingv1beta1.InternalFabric{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: testNamespace},
+			Spec: networkingv1beta1.InternalFabricSpec{
+				GatewayIP:  "10.0.0.10",
+				Replicas:   []networkingv1beta1.InternalFabricSpecReplica{{GatewayIP: "10.0.0.11"}},
+				Encryption: networkingv1beta1.InternalFabricEncryptionModeWireGuard,
+			},
+		}
+	})
+
+	Describe("forgePeers function", func() {
+		It("should return a peer for each gateway replica which published its key", func() {
+			r := newReconciler(internalFabric,
+				forgeGatewayPublicKey("gw-0", internalFabric.Name, "10.0.0.10", key1),
+				forgeGatewayPublicKey("gw-1", internalFabric.Name, "10.0.0.11", key2))
+
+			peers, err := r.forgePeers(ctx, internalFabric)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peers).To(ConsistOf(
+				wireguard.Peer{PublicKey: key1, Endpoint: net.ParseIP("10.0.0.10"), AllowedIPs: []net.IP{net.ParseIP("10.0.0.10")}},
+				wireguard.Peer{PublicKey: key2, Endpoint: net.ParseIP("10.0.0.11"), AllowedIPs: []net.IP{net.ParseIP("10.0.0.11")}},
+			))
+		})
+
+		It("should skip the gateways which did not publish their key yet", func() {
+			r := newReconciler(internalFabric, forgeGatewayPublicKey("gw-1", internalFabric.Name, "10.0.0.11", key2))
+
+			peers, err := r.forgePeers(ctx, internalFabric)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peers).To(HaveLen(1))
+			Expect(peers[0].Endpoint.String()).To(Equal("10.0.0.11"))
+		})
+
+		It("should skip the gateways hosted by the node", func() {
+			r := newReconciler(internalFabric,
+				forgeGatewayPublicKey("gw-0", internalFabric.Name, "10.0.0.10", key1),
+				forgeGatewayPublicKey("gw-1", internalFabric.Name, "10.0.0.11", key2),
+				forgeGatewayPod("gw-0", testNodeName, "10.0.0.10"),
+				forgeGatewayPod("gw-1", "worker-2", "10.0.0.11"))
+
+			peers, err := r.forgePeers(ctx, internalFabric)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peers).To(HaveLen(1))
+			Expect(peers[0].Endpoint.String()).To(Equal("10.0.0.11"))
+		})
+
+		It("should skip the keys published for other internal fabrics or with invalid data", func() {
+			invalid := forgeGatewayPublicKey("gw-1", internalFabric.Name, "10.0.0.11", key2)
+			invalid.Spec.PublicKey = []byte("invalid")
+			r := newReconciler(internalFabric, invalid,
+				forgeGatewayPublicKey("gw-0", "other", "10.0.0.10", key1))
+
+			peers, err := r.forgePeers(ctx, internalFabric)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peers).To(BeEmpty())
+		})
+	})
+
+	Describe("Reconcile function", func() {
+		It("should publish the key of the node and remove the encryption when no gateway is reachable", func() {
+			ns := testutil.NewNetworkNamespace()
+			r := newReconciler(internalFabric)
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(internalFabric)}
+
+			testutil.InNetworkNamespace(ns, func() {
+				// A stale interface and the corresponding routes are left by a previous configuration.
+				link := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: consts.FabricEncryptionInterfaceName}, PeerName: "liqo-test-peer"}
+				Expect(netlink.LinkAdd(link)).To(Succeed())
+				Expect(netlink.AddrAdd(link, &netlink.Addr{IPNet: &net.IPNet{IP: net.ParseIP("10.0.0.1"), Mask: net.CIDRMask(24, 32)}})).To(Succeed())
+				Expect(netlink.LinkSetUp(link)).To(Succeed())
+				Expect(wireguard.EnsureRoutesPresence(consts.FabricEncryptionInterfaceName, testTableID,
+					consts.DefaultGenevePort, []net.IP{net.ParseIP("10.0.0.10")})).To(Succeed())
+
+				Expect(r.Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+
+				_, err := netlink.LinkByName(consts.FabricEncryptionInterfaceName)
+				Expect(err).To(BeAssignableToTypeOf(netlink.LinkNotFoundError{}))
+				routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: testTableID}, netlink.RT_FILTER_TABLE)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routes).To(BeEmpty())
+				rules, err := netlink.RuleListFiltered(netlink.FAMILY_ALL, &netlink.Rule{Table: testTableID}, netlink.RT_FILTER_TABLE)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rules).To(BeEmpty())
+			})
+
+			publicKey := &networkingv1beta1.PublicKey{}
+			Expect(r.Get(ctx, client.ObjectKey{Name: r.publicKeyName(), Namespace: testNamespace}, publicKey)).To(Succeed())
+			Expect(publicKey.Labels).To(HaveKeyWithValue(consts.FabricPublicKeyRoleLabel, consts.FabricPublicKeyRoleNode))
+			Expect(publicKey.Labels).To(HaveKeyWithValue(consts.InternalNodeName, testNodeName))
+			nodeKey := r.privateKey.PublicKey()
+			Expect(publicKey.Spec.PublicKey).To(Equal(nodeKey[:]))
+		})
+
+		It("should remove the key of the node when the encryption is disabled", func() {
+			ns := testutil.NewNetworkNamespace()
+			internalFabric.Spec.Encryption = networkingv1beta1.InternalFabricEncryptionModeNone
+			r := newReconciler(internalFabric)
+			Expect(wireguard.EnsurePublicKeyPresence(ctx, r.Client, scheme, internalFabric, r.publicKeyName(),
+				wireguard.ForgePublicKeyLabels(internalFabric.Name, consts.FabricPublicKeyRoleNode), nil, key1)).To(Succeed())
+
+			testutil.InNetworkNamespace(ns, func() {
+				Expect(r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(internalFabric)})).To(Equal(ctrl.Result{}))
+			})
+
+			err := r.Get(ctx, client.ObjectKey{Name: r.publicKeyName(), Namespace: testNamespace}, &networkingv1beta1.PublicKey{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
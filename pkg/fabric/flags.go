@@ -49,6 +49,8 @@ const (
 	FlagNameEnableNftMonitor FlagName = "enable-nft-monitor"
 	// FlagNameEnableRouteMonitor is the flag to enable the route monitor.
 	FlagNameEnableRouteMonitor FlagName = "enable-route-monitor"
+	// FlagNameFirewallCountersPeriod is the period of the inspection of the counters and of the drift of the firewall rules.
+	FlagNameFirewallCountersPeriod FlagName = "firewall-counters-period"
 
 	// FlagNameDisableKernelVersionCheck is the flag to enable the kernel version check.
 	FlagNameDisableKernelVersionCheck FlagName = "disable-kernel-version-check"
@@ -57,6 +59,11 @@ const (
 
 	// FlagNameGenevePort is the flag to set the Geneve port.
 	FlagNameGenevePort FlagName = "geneve-port"
+	// FlagNameEncryptionPort is the flag to set the port of the WireGuard peerings encrypting the internal fabric.
+	FlagNameEncryptionPort FlagName = "fabric-encryption-port"
+
+	// FlagNameEnableMultipathL4Hashing is the flag to balance the multipath routes based on the layer 4 hash.
+	FlagNameEnableMultipathL4Hashing FlagName = "enable-multipath-l4-hashing"
 )
 
 // RequiredFlags contains the list of the mandatory flags.
@@ -77,11 +84,18 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.BoolVar(&opts.DisableARP, FlagNameDisableARP.String(), false, "Disable ARP")
 	flagset.BoolVar(&opts.EnableNftMonitor, FlagNameEnableNftMonitor.String(), true, "Enable nftables monitor")
 	flagset.BoolVar(&opts.EnableRouteMonitor, FlagNameEnableRouteMonitor.String(), true, "Enable route monitor")
+	flagset.DurationVar(&opts.FirewallCountersPeriod, FlagNameFirewallCountersPeriod.String(), 30*time.Second,
+		"Period of the inspection of the counters and of the drift of the firewall rules (0 to disable)")
 
 	flagset.BoolVar(&opts.DisableKernelVersionCheck, FlagNameDisableKernelVersionCheck.String(), false, "Disable the kernel version check")
 	flagset.Var(&opts.MinimumKernelVersion, string(FlagNameMinimumKernelVersion), "Minimum kernel version required to run the wireguard interface")
 
 	flagset.Uint16Var(&opts.GenevePort, FlagNameGenevePort.String(), consts.DefaultGenevePort, "Geneve port")
+	flagset.IntVar(&opts.EncryptionPort, FlagNameEncryptionPort.String(), consts.DefaultFabricEncryptionPort,
+		"Port of the WireGuard peerings encrypting the internal fabric, when enabled")
+
+	flagset.BoolVar(&opts.EnableMultipathL4Hashing, FlagNameEnableMultipathL4Hashing.String(), false,
+		"Balance the multipath routes towards active-active gateways based on the layer 4 hash of the packets")
 }
 
 // MarkFlagsRequired marks the flags as required.
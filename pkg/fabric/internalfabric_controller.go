@@ -92,6 +92,11 @@ func (r *InternalFabricReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		if err := geneve.EnsureGeneveInterfaceAbsence(internalfabric.Spec.Interface.Node.Name); err != nil {
 			return ctrl.Result{}, fmt.Errorf("unable to ensure the geneve interface absence: %w", err)
 		}
+		for i := range internalfabric.Spec.Replicas {
+			if err := geneve.EnsureGeneveInterfaceAbsence(internalfabric.Spec.Replicas[i].NodeInterfaceName); err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to ensure the geneve interface absence: %w", err)
+			}
+		}
 
 		if err = r.ensureinternalfabricFinalizerAbsence(ctx, internalfabric); err != nil {
 			return ctrl.Result{}, err
@@ -128,6 +133,29 @@ func (r *InternalFabricReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	klog.Infof("Enforced interface %s for internalfabric %s", internalfabric.Spec.Interface.Node.Name, internalfabric.Name)
 
+	// In active-active mode, an additional interface sharing the same tunnel ID is created towards each gateway replica.
+	desired := []string{internalfabric.Spec.Interface.Node.Name}
+	for i := range internalfabric.Spec.Replicas {
+		replica := &internalfabric.Spec.Replicas[i]
+		if err := geneve.EnsureGeneveInterfacePresence(
+			replica.NodeInterfaceName,
+			internalnode.Spec.Interface.Node.IP.String(),
+			replica.GatewayIP.String(),
+			id,
+			r.Options.DisableARP,
+			internalfabric.Spec.MTU,
+			r.Options.GenevePort,
+		); err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to ensure the geneve interface presence for replica %s: %w", replica.GatewayIP, err)
+		}
+		desired = append(desired, replica.NodeInterfaceName)
+		klog.Infof("Enforced interface %s for replica %s of internalfabric %s", replica.NodeInterfaceName, replica.GatewayIP, internalfabric.Name)
+	}
+
+	if err := geneve.EnsureStaleGeneveInterfacesAbsence(id, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to remove the stale geneve interfaces: %w", err)
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -34,10 +34,15 @@ type Options struct {
 	EnableNftMonitor   bool
 	EnableRouteMonitor bool
 
+	FirewallCountersPeriod time.Duration
+
 	DisableKernelVersionCheck bool
 	MinimumKernelVersion      kernelversion.KernelVersion
 
-	GenevePort uint16
+	GenevePort     uint16
+	EncryptionPort int
+
+	EnableMultipathL4Hashing bool
 }
 
 // NewOptions returns a new Options struct.
@@ -0,0 +1,317 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/expr"
+	"github.com/google/nftables/userdata"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+)
+
+var (
+	// MetricsRulePackets is the metric that exposes the number of packets which hit a firewall rule.
+	MetricsRulePackets = prometheus.NewDesc(
+		"liqo_firewall_rule_packets_total",
+		"Number of packets which hit a rule of a FirewallConfiguration, for the rules with the counter enabled.",
+		[]string{"namespace", "name", "chain", "rule"},
+		nil,
+	)
+
+	// MetricsRuleBytes is the metric that exposes the number of bytes which hit a firewall rule.
+	MetricsRuleBytes = prometheus.NewDesc(
+		"liqo_firewall_rule_bytes_total",
+		"Number of bytes which hit a rule of a FirewallConfiguration, for the rules with the counter enabled.",
+		[]string{"namespace", "name", "chain", "rule"},
+		nil,
+	)
+
+	// MetricsDroppedPackets is the metric that exposes the number of packets dropped or rejected by the rules of a FirewallConfiguration.
+	MetricsDroppedPackets = prometheus.NewDesc(
+		"liqo_firewall_dropped_packets_total",
+		"Number of packets dropped or rejected by the filter rules of a FirewallConfiguration, for the rules with the counter enabled.",
+		[]string{"namespace", "name", "chain", "rule"},
+		nil,
+	)
+
+	// MetricsConfigurationMismatches is the metric that exposes the number of mismatches between a FirewallConfiguration and nftables.
+	MetricsConfigurationMismatches = prometheus.NewDesc(
+		"liqo_firewall_configuration_mismatches",
+		"Number of chains, rules and sets of a FirewallConfiguration which do not match the ones applied to nftables.",
+		[]string{"namespace", "name"},
+		nil,
+	)
+)
+
+// ruleCounter contains the counters of a rule applied to nftables.
+type ruleCounter struct {
+	chain   string
+	rule    string
+	packets uint64
+	bytes   uint64
+}
+
+// appliedTable contains the observed state of a table applied to nftables.
+type appliedTable struct {
+	table    *firewallapi.Table
+	diff     []string
+	counters []ruleCounter
+	// dropped contains the sorted names of the filter rules which dropped or rejected the traffic since the
+	// previous inspection, as chain/rule.
+	dropped []string
+	// stale is set when the diff could not be reported in the status, to retry at the next inspection.
+	stale bool
+}
+
+// appliedTables keeps track of the tables applied to nftables by the reconciler, to periodically inspect them.
+type appliedTables struct {
+	mutex  sync.RWMutex
+	tables map[types.NamespacedName]*appliedTable
+}
+
+func newAppliedTables() *appliedTables {
+	return &appliedTables{tables: map[types.NamespacedName]*appliedTable{}}
+}
+
+func (at *appliedTables) track(key types.NamespacedName, table *firewallapi.Table, diff []string) {
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+	if current, found := at.tables[key]; found {
+		current.table, current.diff, current.stale = table.DeepCopy(), diff, false
+		return
+	}
+	at.tables[key] = &appliedTable{table: table.DeepCopy(), diff: diff}
+}
+
+func (at *appliedTables) untrack(key types.NamespacedName) {
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+	delete(at.tables, key)
+}
+
+// list returns the keys of the tracked tables, together with a copy of the desired tables.
+func (at *appliedTables) list() map[types.NamespacedName]*firewallapi.Table {
+	at.mutex.RLock()
+	defer at.mutex.RUnlock()
+	tables := make(map[types.NamespacedName]*firewallapi.Table, len(at.tables))
+	for key, applied := range at.tables {
+		tables[key] = applied.table.DeepCopy()
+	}
+	return tables
+}
+
+// update stores the observed diff and counters of the given table, returning the names of the rules which
+// dropped the traffic since the previous inspection and whether either the diff or those names changed.
+func (at *appliedTables) update(key types.NamespacedName, diff []string, counters []ruleCounter) (dropped []string, changed bool) {
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+	current, found := at.tables[key]
+	if !found {
+		return nil, false
+	}
+	dropped = getDroppedRuleNames(current.table, current.counters, counters)
+	changed = current.stale || !slices.Equal(current.diff, diff) || !slices.Equal(current.dropped, dropped)
+	current.diff, current.counters, current.dropped, current.stale = diff, counters, dropped, false
+	return dropped, changed
+}
+
+// markStale forces the next update of the given table to report the diff as changed.
+func (at *appliedTables) markStale(key types.NamespacedName) {
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+	if current, found := at.tables[key]; found {
+		current.stale = true
+	}
+}
+
+var _ prometheus.Collector = &FirewallConfigurationReconciler{}
+
+// Describe implements prometheus.Collector.
+func (r *FirewallConfigurationReconciler) Describe(ch chan<- *prometheus.Desc) {
+	ch <- MetricsRulePackets
+	ch <- MetricsRuleBytes
+	ch <- MetricsDroppedPackets
+	ch <- MetricsConfigurationMismatches
+}
+
+// Collect implements prometheus.Collector.
+func (r *FirewallConfigurationReconciler) Collect(ch chan<- prometheus.Metric) {
+	r.appliedTables.mutex.RLock()
+	defer r.appliedTables.mutex.RUnlock()
+
+	for key, applied := range r.appliedTables.tables {
+		ch <- prometheus.MustNewConstMetric(MetricsConfigurationMismatches, prometheus.GaugeValue,
+			float64(len(applied.diff)), key.Namespace, key.Name)
+		for i := range applied.counters {
+			counter := &applied.counters[i]
+			ch <- prometheus.MustNewConstMetric(MetricsRulePackets, prometheus.CounterValue,
+				float64(counter.packets), key.Namespace, key.Name, counter.chain, counter.rule)
+			ch <- prometheus.MustNewConstMetric(MetricsRuleBytes, prometheus.CounterValue,
+				float64(counter.bytes), key.Namespace, key.Name, counter.chain, counter.rule)
+		}
+		dropped := getDroppedCounters(applied.table, applied.counters)
+		for i := range dropped {
+			ch <- prometheus.MustNewConstMetric(MetricsDroppedPackets, prometheus.CounterValue,
+				float64(dropped[i].packets), key.Namespace, key.Name, dropped[i].chain, dropped[i].rule)
+		}
+	}
+}
+
+// inspectTables periodically reads the counters of the applied rules, and compares the applied tables with the desired ones.
+// The counters are only exposed as metrics, while the Synced and Dropping conditions of the FirewallConfigurations
+// are updated when the mismatches, or the set of rules which dropped the traffic, change.
+func (r *FirewallConfigurationReconciler) inspectTables(ctx context.Context, period time.Duration) error {
+	// A dedicated connection is used, since the one of the reconciler batches the pending changes.
+	nftconn, err := nftables.New()
+	if err != nil {
+		return fmt.Errorf("unable to create nftables connection: %w", err)
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		for key, table := range r.appliedTables.list() {
+			if err := r.inspectTable(ctx, nftconn, key, table); err != nil {
+				klog.Warningf("Unable to inspect the table of firewallconfiguration %s: %v", key, err)
+			}
+		}
+	}, period)
+	return nil
+}
+
+func (r *FirewallConfigurationReconciler) inspectTable(ctx context.Context, nftconn *nftables.Conn,
+	key types.NamespacedName, table *firewallapi.Table) error {
+	diff, err := diffTable(nftconn, table)
+	if err != nil {
+		return err
+	}
+	counters, err := readRuleCounters(nftconn, table)
+	if err != nil {
+		return err
+	}
+	dropped, changed := r.appliedTables.update(key, diff, counters)
+	if !changed {
+		return nil
+	}
+
+	fwcfg := &networkingv1beta1.FirewallConfiguration{}
+	if err := r.Get(ctx, key, fwcfg); err != nil {
+		r.appliedTables.markStale(key)
+		return client.IgnoreNotFound(err)
+	}
+	syncedChanged := setSyncedCondition(fwcfg, r.PodName, diff)
+	if droppingChanged := setDroppingCondition(fwcfg, r.PodName, dropped); !syncedChanged && !droppingChanged {
+		return nil
+	}
+	if err := r.Client.Status().Update(ctx, fwcfg); err != nil {
+		r.appliedTables.markStale(key)
+		return err
+	}
+	return nil
+}
+
+// readRuleCounters returns the counters of the named rules of the given table.
+func readRuleCounters(nftconn *nftables.Conn, table *firewallapi.Table) ([]ruleCounter, error) {
+	nftTable := &nftables.Table{}
+	setTableName(nftTable, *table.Name)
+	setTableFamily(nftTable, *table.Family)
+
+	exists, err := existTable(nftconn, nftTable)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	nftChains, err := nftconn.ListChainsOfTableFamily(nftTable.Family)
+	if err != nil {
+		return nil, err
+	}
+
+	var counters []ruleCounter
+	for i := range nftChains {
+		if nftChains[i].Table.Name != nftTable.Name {
+			continue
+		}
+		nftRules, err := nftconn.GetRules(nftTable, nftChains[i])
+		if err != nil {
+			return nil, err
+		}
+		for j := range nftRules {
+			name, ok := userdata.GetString(nftRules[j].UserData, userdata.TypeComment)
+			if !ok {
+				continue
+			}
+			for k := range nftRules[j].Exprs {
+				if counter, ok := nftRules[j].Exprs[k].(*expr.Counter); ok {
+					counters = append(counters, ruleCounter{
+						chain: nftChains[i].Name, rule: name, packets: counter.Packets, bytes: counter.Bytes,
+					})
+					break
+				}
+			}
+		}
+	}
+	return counters, nil
+}
+
+// getDroppedCounters returns the counters of the filter rules dropping or rejecting the traffic which have been hit.
+func getDroppedCounters(table *firewallapi.Table, counters []ruleCounter) []ruleCounter {
+	var dropped []ruleCounter
+	for i := range counters {
+		if counters[i].packets == 0 {
+			continue
+		}
+		chainIndex := slices.IndexFunc(table.Chains, func(c firewallapi.Chain) bool {
+			return c.Name != nil && *c.Name == counters[i].chain
+		})
+		if chainIndex < 0 {
+			continue
+		}
+		if slices.ContainsFunc(table.Chains[chainIndex].Rules.FilterRules, func(fr firewallapi.FilterRule) bool {
+			return fr.Name != nil && *fr.Name == counters[i].rule &&
+				(fr.Action == firewallapi.ActionDrop || fr.Action == firewallapi.ActionReject)
+		}) {
+			dropped = append(dropped, counters[i])
+		}
+	}
+	return dropped
+}
+
+// getDroppedRuleNames returns the sorted names of the filter rules dropping or rejecting the traffic which have been hit
+// since the previous sample of the counters, as chain/rule. The rules without a previous sample are ignored, as their
+// counters only provide the baseline for the next inspection, while a counter lower than the previous sample means that
+// the rule has been replaced, hence all the packets it counted have been dropped since the previous inspection.
+func getDroppedRuleNames(table *firewallapi.Table, previous, counters []ruleCounter) []string {
+	var names []string
+	for _, counter := range getDroppedCounters(table, counters) {
+		idx := slices.IndexFunc(previous, func(c ruleCounter) bool { return c.chain == counter.chain && c.rule == counter.rule })
+		if idx < 0 || previous[idx].packets == counter.packets {
+			continue
+		}
+		names = append(names, counter.chain+"/"+counter.rule)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,170 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package firewall
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+)
+
+var _ = Describe("Firewall counters", func() {
+	table := &firewallapi.Table{
+		Name: ptr.To("policies"),
+		Chains: []firewallapi.Chain{{
+			Name: ptr.To("forward"),
+			Rules: firewallapi.RulesSet{FilterRules: []firewallapi.FilterRule{
+				{Name: ptr.To("allow-dns"), Action: firewallapi.ActionAccept},
+				{Name: ptr.To("isolate-ingress"), Action: firewallapi.ActionDrop},
+				{Name: ptr.To("isolate-egress"), Action: firewallapi.ActionReject},
+			}},
+		}, {
+			Name: ptr.To("postrouting"),
+			Rules: firewallapi.RulesSet{NatRules: []firewallapi.NatRule{
+				{Name: ptr.To("isolate-ingress"), NatType: firewallapi.NatTypeSource},
+			}},
+		}},
+	}
+
+	DescribeTable("getDroppedCounters function",
+		func(counters, expected []ruleCounter) {
+			Expect(getDroppedCounters(table, counters)).To(Equal(expected))
+		},
+		Entry("No counters", nil, nil),
+		Entry("Accept rule hit",
+			[]ruleCounter{{chain: "forward", rule: "allow-dns", packets: 10, bytes: 800}},
+			nil,
+		),
+		Entry("Drop and reject rules hit",
+			[]ruleCounter{
+				{chain: "forward", rule: "isolate-ingress", packets: 12, bytes: 1024},
+				{chain: "forward", rule: "isolate-egress", packets: 1, bytes: 60},
+			},
+			[]ruleCounter{
+				{chain: "forward", rule: "isolate-ingress", packets: 12, bytes: 1024},
+				{chain: "forward", rule: "isolate-egress", packets: 1, bytes: 60},
+			},
+		),
+		Entry("Drop rule not hit",
+			[]ruleCounter{{chain: "forward", rule: "isolate-ingress"}},
+			nil,
+		),
+		Entry("Rule with the same name in another chain",
+			[]ruleCounter{{chain: "postrouting", rule: "isolate-ingress", packets: 5, bytes: 300}},
+			nil,
+		),
+		Entry("Rule of an unknown chain",
+			[]ruleCounter{{chain: "input", rule: "isolate-ingress", packets: 5, bytes: 300}},
+			nil,
+		),
+	)
+
+	Describe("appliedTables update", func() {
+		var (
+			tables *appliedTables
+			key    types.NamespacedName
+		)
+
+		hasChanged := func(_ []string, changed bool) bool { return changed }
+
+		BeforeEach(func() {
+			tables = newAppliedTables()
+			key = types.NamespacedName{Namespace: "liqo-tenant", Name: "policies"}
+			tables.track(key, table, nil)
+		})
+
+		It("should report a change only when the diff changes", func() {
+			counters := []ruleCounter{{chain: "forward", rule: "allow-dns", packets: 1, bytes: 60}}
+			Expect(hasChanged(tables.update(key, nil, counters))).To(BeFalse())
+			Expect(hasChanged(tables.update(key, []string{"rule forward/allow-dns is missing"}, counters))).To(BeTrue())
+			Expect(hasChanged(tables.update(key, []string{"rule forward/allow-dns is missing"}, nil))).To(BeFalse())
+			Expect(hasChanged(tables.update(key, nil, nil))).To(BeTrue())
+		})
+
+		It("should report a change only when the set of rules which dropped the traffic changes", func() {
+			// The first sample only provides the baseline of the counters.
+			dropped, changed := tables.update(key, nil, []ruleCounter{{chain: "forward", rule: "isolate-ingress", packets: 1, bytes: 60}})
+			Expect(changed).To(BeFalse())
+			Expect(dropped).To(BeEmpty())
+
+			dropped, changed = tables.update(key, nil, []ruleCounter{{chain: "forward", rule: "isolate-ingress", packets: 10, bytes: 600}})
+			Expect(changed).To(BeTrue())
+			Expect(dropped).To(Equal([]string{"forward/isolate-ingress"}))
+
+			dropped, changed = tables.update(key, nil, []ruleCounter{
+				{chain: "forward", rule: "isolate-ingress", packets: 20, bytes: 1200},
+				{chain: "forward", rule: "isolate-egress", packets: 0, bytes: 0},
+			})
+			Expect(changed).To(BeFalse())
+			Expect(dropped).To(Equal([]string{"forward/isolate-ingress"}))
+
+			dropped, changed = tables.update(key, nil, []ruleCounter{
+				{chain: "forward", rule: "isolate-ingress", packets: 30, bytes: 1800},
+				{chain: "forward", rule: "isolate-egress", packets: 1, bytes: 60},
+			})
+			Expect(changed).To(BeTrue())
+			Expect(dropped).To(Equal([]string{"forward/isolate-egress", "forward/isolate-ingress"}))
+		})
+
+		It("should clear the dropped rules once their counters stop increasing", func() {
+			counters := []ruleCounter{{chain: "forward", rule: "isolate-ingress", packets: 1, bytes: 60}}
+			Expect(hasChanged(tables.update(key, nil, nil))).To(BeFalse())
+			Expect(hasChanged(tables.update(key, nil, []ruleCounter{{chain: "forward", rule: "isolate-ingress"}}))).To(BeFalse())
+
+			dropped, changed := tables.update(key, nil, counters)
+			Expect(changed).To(BeTrue())
+			Expect(dropped).To(Equal([]string{"forward/isolate-ingress"}))
+
+			fwcfg := &networkingv1beta1.FirewallConfiguration{}
+			Expect(setDroppingCondition(fwcfg, "gateway", dropped)).To(BeTrue())
+			Expect(fwcfg.Status.Conditions).To(ConsistOf(HaveField("Status", metav1.ConditionTrue)))
+
+			dropped, changed = tables.update(key, nil, counters)
+			Expect(changed).To(BeTrue())
+			Expect(dropped).To(BeEmpty())
+			Expect(setDroppingCondition(fwcfg, "gateway", dropped)).To(BeTrue())
+			Expect(fwcfg.Status.Conditions).To(ConsistOf(HaveField("Status", metav1.ConditionFalse)))
+
+			dropped, changed = tables.update(key, nil, counters)
+			Expect(changed).To(BeFalse())
+			Expect(dropped).To(BeEmpty())
+		})
+
+		It("should consider the rules whose counters have been reset", func() {
+			Expect(hasChanged(tables.update(key, nil, []ruleCounter{{chain: "forward", rule: "isolate-ingress", packets: 10}}))).To(BeFalse())
+			dropped, changed := tables.update(key, nil, []ruleCounter{{chain: "forward", rule: "isolate-ingress", packets: 2}})
+			Expect(changed).To(BeTrue())
+			Expect(dropped).To(Equal([]string{"forward/isolate-ingress"}))
+		})
+
+		It("should report a change after the table has been marked as stale", func() {
+			tables.markStale(key)
+			Expect(hasChanged(tables.update(key, nil, nil))).To(BeTrue())
+			Expect(hasChanged(tables.update(key, nil, nil))).To(BeFalse())
+		})
+
+		It("should ignore the untracked tables", func() {
+			tables.untrack(key)
+			Expect(hasChanged(tables.update(key, []string{"table policies is missing"}, nil))).To(BeFalse())
+		})
+	})
+})
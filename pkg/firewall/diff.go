@@ -0,0 +1,168 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firewall
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/userdata"
+
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	firewallutils "github.com/liqotech/liqo/pkg/firewall/utils"
+)
+
+// diffTable compares the table applied on nftables with the desired one, without modifying it.
+// It returns the list of the mismatches, which is empty if the applied table matches the desired one.
+func diffTable(nftconn *nftables.Conn, table *firewallapi.Table) ([]string, error) {
+	nftTable := &nftables.Table{}
+	setTableName(nftTable, *table.Name)
+	setTableFamily(nftTable, *table.Family)
+
+	exists, err := existTable(nftconn, nftTable)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []string{fmt.Sprintf("table %s is missing", *table.Name)}, nil
+	}
+
+	diff, err := diffSets(nftconn, nftTable, table.Sets)
+	if err != nil {
+		return nil, err
+	}
+
+	nftChains, err := nftconn.ListChainsOfTableFamily(nftTable.Family)
+	if err != nil {
+		return nil, err
+	}
+	nftChains = slices.DeleteFunc(nftChains, func(c *nftables.Chain) bool {
+		return c.Table.Name != nftTable.Name
+	})
+
+	for i := range table.Chains {
+		chain := &table.Chains[i]
+		index := slices.IndexFunc(nftChains, func(c *nftables.Chain) bool {
+			return chain.Name != nil && c.Name == *chain.Name
+		})
+		switch {
+		case index < 0:
+			diff = append(diff, fmt.Sprintf("chain %s is missing", *chain.Name))
+			continue
+		case isChainModified(nftChains[index], chain):
+			diff = append(diff, fmt.Sprintf("chain %s is modified", *chain.Name))
+			continue
+		}
+
+		nftRules, err := nftconn.GetRules(nftTable, nftChains[index])
+		if err != nil {
+			return nil, err
+		}
+		diff = append(diff, diffRules(*chain.Name, nftRules, FromChainToRulesArray(chain))...)
+	}
+
+	for i := range nftChains {
+		if !slices.ContainsFunc(table.Chains, func(c firewallapi.Chain) bool {
+			return c.Name != nil && *c.Name == nftChains[i].Name
+		}) {
+			diff = append(diff, fmt.Sprintf("chain %s is unexpected", nftChains[i].Name))
+		}
+	}
+	return diff, nil
+}
+
+// diffRules compares the rules applied in a chain with the desired ones.
+func diffRules(chainName string, nftRules []*nftables.Rule, rules []firewallutils.Rule) []string {
+	var diff []string
+	for i := range rules {
+		index := slices.IndexFunc(nftRules, func(r *nftables.Rule) bool {
+			name, ok := userdata.GetString(r.UserData, userdata.TypeComment)
+			return ok && name == *rules[i].GetName()
+		})
+		switch {
+		case index < 0:
+			diff = append(diff, fmt.Sprintf("rule %s/%s is missing", chainName, *rules[i].GetName()))
+		case !rules[i].Equal(nftRules[index]):
+			diff = append(diff, fmt.Sprintf("rule %s/%s is modified", chainName, *rules[i].GetName()))
+		}
+	}
+
+	for i := range nftRules {
+		if outdated, name := isRuleOutdated(nftRules[i], rules); outdated && !existRuleName(rules, name) {
+			diff = append(diff, fmt.Sprintf("rule %s/%s is unexpected", chainName, name))
+		}
+	}
+
+	if len(diff) == 0 && !isRulesOrderPreserved(nftRules, rules) {
+		diff = append(diff, fmt.Sprintf("rules of chain %s are out of order", chainName))
+	}
+	return diff
+}
+
+// diffSets compares the sets applied in a table with the desired ones, including their elements.
+func diffSets(nftconn *nftables.Conn, table *nftables.Table, sets []firewallapi.Set) ([]string, error) {
+	var diff []string
+	nftSets, err := getSets(nftconn, table)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sets {
+		current := findSet(nftSets, sets[i].Name)
+		if current == nil {
+			diff = append(diff, fmt.Sprintf("set %s is missing", sets[i].Name))
+			continue
+		}
+		desired, elements, err := firewallutils.ForgeSet(&sets[i], table)
+		if err != nil {
+			return nil, err
+		}
+		currentElements, err := nftconn.GetSetElements(current)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get elements of set %s: %w", current.Name, err)
+		}
+		if firewallutils.IsSetModified(current, currentElements, desired) {
+			diff = append(diff, fmt.Sprintf("set %s is modified", sets[i].Name))
+			continue
+		}
+		if toAdd, toDel := firewallutils.DiffSetElements(currentElements, elements); len(toAdd) > 0 || len(toDel) > 0 {
+			diff = append(diff, fmt.Sprintf("set %s has %d missing and %d unexpected elements", sets[i].Name, len(toAdd), len(toDel)))
+		}
+	}
+
+	for i := range nftSets {
+		if findSetSpec(sets, nftSets[i].Name) == nil {
+			diff = append(diff, fmt.Sprintf("set %s is unexpected", nftSets[i].Name))
+		}
+	}
+	return diff, nil
+}
+
+func existTable(nftconn *nftables.Conn, table *nftables.Table) (bool, error) {
+	nftTables, err := nftconn.ListTablesOfFamily(table.Family)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(nftTables, func(t *nftables.Table) bool {
+		return t.Name == table.Name
+	}), nil
+}
+
+func existRuleName(rules []firewallutils.Rule, name string) bool {
+	return slices.ContainsFunc(rules, func(r firewallutils.Rule) bool {
+		return r.GetName() != nil && *r.GetName() == name
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package firewall
+
+import (
+	"github.com/google/nftables"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+)
+
+var _ = Describe("Firewall diff", func() {
+	var (
+		nftconn *nftables.Conn
+		table   *firewallapi.Table
+	)
+
+	matchIP := func(value string, position firewallapi.MatchPosition) []firewallapi.Match {
+		return []firewallapi.Match{{Op: firewallapi.MatchOperationEq, IP: &firewallapi.MatchIP{Value: value, Position: position}}}
+	}
+
+	forgeTable := func() *firewallapi.Table {
+		return &firewallapi.Table{
+			Name:   ptr.To("policies"),
+			Family: ptr.To(firewallapi.TableFamilyIPv4),
+			Sets: []firewallapi.Set{{
+				Name:     "isolated",
+				KeyType:  firewallapi.SetDataTypeIPv4Addr,
+				Elements: []firewallapi.SetElement{{Key: "10.0.0.1"}, {Key: "10.0.0.2"}},
+			}},
+			Chains: []firewallapi.Chain{{
+				Name:     ptr.To("forward"),
+				Type:     firewallapi.ChainTypeFilter,
+				Policy:   ptr.To(firewallapi.ChainPolicyAccept),
+				Hook:     ptr.To(firewallapi.ChainHookForward),
+				Priority: ptr.To(firewallapi.ChainPriorityFilter),
+				Rules: firewallapi.RulesSet{FilterRules: []firewallapi.FilterRule{{
+					Name:    ptr.To("allow-dns"),
+					Counter: true,
+					Match:   matchIP("10.96.0.10", firewallapi.MatchPositionDst),
+					Action:  firewallapi.ActionAccept,
+				}, {
+					Name:    ptr.To("isolate-ingress"),
+					Counter: true,
+					Match:   matchIP("@isolated", firewallapi.MatchPositionDst),
+					Action:  firewallapi.ActionDrop,
+				}}},
+			}},
+		}
+	}
+
+	apply := func(table *firewallapi.Table) {
+		nftTable := addTable(nftconn, table)
+		Expect(addSets(nftconn, table.Sets, nftTable)).To(Succeed())
+		Expect(addChains(nftconn, table.Chains, nftTable)).To(Succeed())
+		Expect(nftconn.Flush()).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		// The tables are applied in a dedicated network namespace, to leave the ones of the host untouched.
+		ns := testutil.NewNetworkNamespace()
+
+		var err error
+		nftconn, err = nftables.New(nftables.WithNetNSFd(int(ns)))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(nftconn.CloseLasting)
+
+		table = forgeTable()
+	})
+
+	It("should report a missing table", func() {
+		Expect(diffTable(nftconn, table)).To(ConsistOf("table policies is missing"))
+	})
+
+	It("should report no mismatches when the table is applied", func() {
+		apply(table)
+		Expect(diffTable(nftconn, table)).To(BeEmpty())
+	})
+
+	It("should report the missing chains, rules and set elements", func() {
+		applied := forgeTable()
+		applied.Sets[0].Elements = applied.Sets[0].Elements[:1]
+		applied.Chains[0].Rules.FilterRules = applied.Chains[0].Rules.FilterRules[1:]
+		apply(applied)
+
+		table.Chains = append(table.Chains, firewallapi.Chain{
+			Name:     ptr.To("input"),
+			Type:     firewallapi.ChainTypeFilter,
+			Policy:   ptr.To(firewallapi.ChainPolicyAccept),
+			Hook:     ptr.To(firewallapi.ChainHookInput),
+			Priority: ptr.To(firewallapi.ChainPriorityFilter),
+		})
+		Expect(diffTable(nftconn, table)).To(ConsistOf(
+			"set isolated has 1 missing and 0 unexpected elements",
+			"rule forward/allow-dns is missing",
+			"chain input is missing",
+		))
+	})
+
+	It("should report the unexpected chains, rules and sets", func() {
+		applied := forgeTable()
+		applied.Sets = append(applied.Sets, firewallapi.Set{Name: "allowed", KeyType: firewallapi.SetDataTypeIPv4Addr})
+		applied.Chains[0].Rules.FilterRules = append(applied.Chains[0].Rules.FilterRules, firewallapi.FilterRule{
+			Name:    ptr.To("allow-all"),
+			Counter: true,
+			Match:   matchIP("0.0.0.0/0", firewallapi.MatchPositionSrc),
+			Action:  firewallapi.ActionAccept,
+		})
+		applied.Chains = append(applied.Chains, firewallapi.Chain{
+			Name:     ptr.To("input"),
+			Type:     firewallapi.ChainTypeFilter,
+			Policy:   ptr.To(firewallapi.ChainPolicyAccept),
+			Hook:     ptr.To(firewallapi.ChainHookInput),
+			Priority: ptr.To(firewallapi.ChainPriorityFilter),
+		})
+		apply(applied)
+
+		Expect(diffTable(nftconn, table)).To(ConsistOf(
+			"set allowed is unexpected",
+			"rule forward/allow-all is unexpected",
+			"chain input is unexpected",
+		))
+	})
+
+	It("should report the modified chains", func() {
+		applied := forgeTable()
+		applied.Chains[0].Policy = ptr.To(firewallapi.ChainPolicyDrop)
+		apply(applied)
+
+		Expect(diffTable(nftconn, table)).To(ConsistOf("chain forward is modified"))
+	})
+})
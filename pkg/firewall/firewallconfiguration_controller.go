@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/nftables"
@@ -34,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
@@ -54,6 +56,9 @@ type FirewallConfigurationReconciler struct {
 	LabelsSets []labels.Set
 	// EnableFinalizer is used to enable the finalizer on the reconciled resources.
 	EnableFinalizer bool
+
+	// appliedTables keeps track of the tables applied by the reconciler, to inspect their counters and drift.
+	appliedTables *appliedTables
 }
 
 // newFirewallConfigurationReconciler returns a new FirewallConfigurationReconciler.
@@ -71,6 +76,7 @@ func newFirewallConfigurationReconciler(cl client.Client, s *runtime.Scheme, pod
 		EventsRecorder:  er,
 		LabelsSets:      labelsSets,
 		EnableFinalizer: enableFinalizer,
+		appliedTables:   newAppliedTables(),
 	}, nil
 }
 
@@ -95,10 +101,12 @@ func NewFirewallConfigurationReconcilerWithoutFinalizer(cl client.Client, s *run
 // Reconcile manage FirewallConfigurations, applying nftables configuration.
 func (r *FirewallConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var err error
+	var diff []string
 	fwcfg := &networkingv1beta1.FirewallConfiguration{}
 	if err = r.Get(ctx, req.NamespacedName, fwcfg); err != nil {
 		if apierrors.IsNotFound(err) {
 			klog.V(6).Infof("There is no firewallconfiguration %s", req.String())
+			r.appliedTables.untrack(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, fmt.Errorf("unable to get the firewallconfiguration %q: %w", req.NamespacedName, err)
@@ -107,7 +115,7 @@ func (r *FirewallConfigurationReconciler) Reconcile(ctx context.Context, req ctr
 	klog.V(4).Infof("Reconciling firewallconfiguration %s", req.String())
 
 	defer func() {
-		err = r.UpdateStatus(ctx, r.EventsRecorder, fwcfg, r.PodName, err)
+		err = r.UpdateStatus(ctx, r.EventsRecorder, fwcfg, r.PodName, diff, err)
 	}()
 
 	// Manage Finalizers and Table deletion.
@@ -115,10 +123,10 @@ func (r *FirewallConfigurationReconciler) Reconcile(ctx context.Context, req ctr
 
 	if fwcfg.DeletionTimestamp.IsZero() && r.EnableFinalizer {
 		if !ctrlutil.ContainsFinalizer(fwcfg, firewallConfigurationsControllerFinalizer) {
+			// The configuration is applied right away, since the update of the finalizer does not trigger a new reconciliation.
 			if err = r.ensureFirewallConfigurationFinalizerPresence(ctx, fwcfg); err != nil {
 				return ctrl.Result{}, err
 			}
-			return ctrl.Result{}, nil
 		}
 	} else if r.EnableFinalizer {
 		if ctrlutil.ContainsFinalizer(fwcfg, firewallConfigurationsControllerFinalizer) {
@@ -126,6 +134,7 @@ func (r *FirewallConfigurationReconciler) Reconcile(ctx context.Context, req ctr
 			if err = r.NftConnection.Flush(); err != nil {
 				return ctrl.Result{}, err
 			}
+			r.appliedTables.untrack(req.NamespacedName)
 			if err = r.ensureFirewallConfigurationFinalizerAbsence(ctx, fwcfg); err != nil {
 				return ctrl.Result{}, err
 			}
@@ -150,6 +159,11 @@ func (r *FirewallConfigurationReconciler) Reconcile(ctx context.Context, req ctr
 	// Enforce table existence.
 	table := addTable(r.NftConnection, &fwcfg.Spec.Table)
 
+	// Sets are added before the chains, as they must exist when the rules referencing them are added.
+	if err = addSets(r.NftConnection, fwcfg.Spec.Table.Sets, table); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err = addChains(r.NftConnection, fwcfg.Spec.Table.Chains, table); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -160,12 +174,20 @@ func (r *FirewallConfigurationReconciler) Reconcile(ctx context.Context, req ctr
 
 	klog.Infof("Applied firewallconfiguration %s", req.String())
 
+	// Compare the applied table with the desired one, to detect the changes not performed by this reconciler.
+	if diff, err = diffTable(r.NftConnection, &fwcfg.Spec.Table); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to compare the firewallconfiguration %q with nftables: %w", req.NamespacedName, err)
+	}
+	r.appliedTables.track(req.NamespacedName, &fwcfg.Spec.Table, diff)
+
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager register the FirewallConfigurationReconciler to the manager.
+// If countersPeriod is greater than zero, the applied tables are periodically inspected to read the counters of the rules
+// and to detect the mismatches with the desired configuration.
 func (r *FirewallConfigurationReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager,
-	enableNftMonitor bool, reconcileTimeout time.Duration) error {
+	enableNftMonitor bool, reconcileTimeout, countersPeriod time.Duration) error {
 	klog.Infof("Starting FirewallConfiguration controller with labels %v", r.LabelsSets)
 	filterByLabelsPredicate, err := forgeLabelsPredicate(r.LabelsSets)
 	if err != nil {
@@ -180,8 +202,19 @@ func (r *FirewallConfigurationReconciler) SetupWithManager(ctx context.Context,
 		}()
 	}
 
+	if countersPeriod > 0 {
+		klog.Infof("Inspecting the applied firewall tables every %s", countersPeriod)
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			return r.inspectTables(ctx, countersPeriod)
+		})); err != nil {
+			return err
+		}
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlFirewallConfiguration).
-		For(&networkingv1beta1.FirewallConfiguration{}, builder.WithPredicates(filterByLabelsPredicate)).
+		// The status updates, including the ones performed by the periodic inspection, do not trigger a reconciliation.
+		For(&networkingv1beta1.FirewallConfiguration{}, builder.WithPredicates(filterByLabelsPredicate,
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
 		WatchesRawSource(NewFirewallWatchSource(src, NewFirewallWatchEventHandler(r.Client, r.LabelsSets))).
 		WithOptions(controller.Options{
 			ReconciliationTimeout: reconcileTimeout,
@@ -201,29 +234,66 @@ func forgeLabelsPredicate(labelsSets []labels.Set) (predicate.Predicate, error)
 	return predicate.Or(labelPredicates...), nil
 }
 
-func getConditionRef(fwcfg *networkingv1beta1.FirewallConfiguration, podname string) *networkingv1beta1.FirewallConfigurationStatusCondition {
-	var conditionRef *networkingv1beta1.FirewallConfigurationStatusCondition
+// getConditionRef returns a reference to the condition of the given type and host, adding it if not present.
+func getConditionRef(fwcfg *networkingv1beta1.FirewallConfiguration, podname string,
+	conditionType networkingv1beta1.FirewallConfigurationStatusConditionType) *networkingv1beta1.FirewallConfigurationStatusCondition {
 	for i := range fwcfg.Status.Conditions {
-		if fwcfg.Status.Conditions[i].Host == podname {
-			conditionRef = &fwcfg.Status.Conditions[i]
-			break
+		if fwcfg.Status.Conditions[i].Host == podname && fwcfg.Status.Conditions[i].Type == conditionType {
+			return &fwcfg.Status.Conditions[i]
 		}
 	}
-	if conditionRef == nil {
-		conditionRef = &networkingv1beta1.FirewallConfigurationStatusCondition{
-			Host: podname,
-		}
-		fwcfg.Status.Conditions = append(fwcfg.Status.Conditions, *conditionRef)
+	fwcfg.Status.Conditions = append(fwcfg.Status.Conditions, networkingv1beta1.FirewallConfigurationStatusCondition{
+		Host: podname,
+		Type: conditionType,
+	})
+	return &fwcfg.Status.Conditions[len(fwcfg.Status.Conditions)-1]
+}
+
+// setSyncedCondition sets the Synced condition of the given host according to the mismatches with nftables,
+// returning whether it changed.
+func setSyncedCondition(fwcfg *networkingv1beta1.FirewallConfiguration, podname string, diff []string) bool {
+	conditionRef := getConditionRef(fwcfg, podname, networkingv1beta1.FirewallConfigurationStatusConditionTypeSynced)
+
+	status, message := metav1.ConditionTrue, ""
+	if len(diff) > 0 {
+		status, message = metav1.ConditionFalse, strings.Join(diff, "; ")
+	}
+
+	if conditionRef.Status == status && conditionRef.Message == message {
+		return false
+	}
+	if conditionRef.Status != status {
+		conditionRef.LastTransitionTime = metav1.Now()
 	}
-	return conditionRef
+	conditionRef.Status, conditionRef.Message = status, message
+	return true
+}
+
+// setDroppingCondition sets the Dropping condition of the given host according to the names of the filter rules
+// which dropped or rejected the traffic, returning whether it changed.
+func setDroppingCondition(fwcfg *networkingv1beta1.FirewallConfiguration, podname string, dropped []string) bool {
+	conditionRef := getConditionRef(fwcfg, podname, networkingv1beta1.FirewallConfigurationStatusConditionTypeDropping)
+
+	status, message := metav1.ConditionFalse, ""
+	if len(dropped) > 0 {
+		status, message = metav1.ConditionTrue, strings.Join(dropped, ", ")
+	}
+
+	if conditionRef.Status == status && conditionRef.Message == message {
+		return false
+	}
+	if conditionRef.Status != status {
+		conditionRef.LastTransitionTime = metav1.Now()
+	}
+	conditionRef.Status, conditionRef.Message = status, message
+	return true
 }
 
 // UpdateStatus updates the status of the given FirewallConfiguration.
+// The diff contains the mismatches between the desired configuration and nftables, and it is ignored in case of error.
 func (r *FirewallConfigurationReconciler) UpdateStatus(ctx context.Context, er record.EventRecorder,
-	fwcfg *networkingv1beta1.FirewallConfiguration, podname string, err error) error {
-	conditionRef := getConditionRef(fwcfg, podname)
-	conditionRef.Host = podname
-	conditionRef.Type = networkingv1beta1.FirewallConfigurationStatusConditionTypeApplied
+	fwcfg *networkingv1beta1.FirewallConfiguration, podname string, diff []string, err error) error {
+	conditionRef := getConditionRef(fwcfg, podname, networkingv1beta1.FirewallConfigurationStatusConditionTypeApplied)
 
 	oldStatus := conditionRef.Status
 	if err == nil {
@@ -232,13 +302,23 @@ func (r *FirewallConfigurationReconciler) UpdateStatus(ctx context.Context, er r
 		conditionRef.Status = metav1.ConditionFalse
 	}
 
-	if oldStatus == conditionRef.Status {
-		return nil
+	changed := oldStatus != conditionRef.Status
+	if changed {
+		conditionRef.LastTransitionTime = metav1.Now()
+		er.Eventf(fwcfg, "Normal", "FirewallConfigurationUpdate", "FirewallConfiguration %s: %s", conditionRef.Type, conditionRef.Status)
 	}
 
-	conditionRef.LastTransitionTime = metav1.Now()
+	if err == nil && fwcfg.DeletionTimestamp.IsZero() && setSyncedCondition(fwcfg, podname, diff) {
+		changed = true
+		if len(diff) > 0 {
+			er.Eventf(fwcfg, "Warning", "FirewallConfigurationDrift", "FirewallConfiguration does not match nftables: %s", strings.Join(diff, "; "))
+		}
+	}
+
+	if !changed {
+		return nil
+	}
 
-	er.Eventf(fwcfg, "Normal", "FirewallConfigurationUpdate", "FirewallConfiguration %s: %s", conditionRef.Type, conditionRef.Status)
 	if clerr := r.Client.Status().Update(ctx, fwcfg); clerr != nil {
 		err = errors.Join(err, clerr)
 	}
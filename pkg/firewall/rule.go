@@ -15,19 +15,36 @@
 package firewall
 
 import (
+	"slices"
+
 	"github.com/google/nftables"
 	"github.com/google/nftables/userdata"
+	"k8s.io/klog/v2"
 
 	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
 	firewallutils "github.com/liqotech/liqo/pkg/firewall/utils"
 )
 
+// addRules enforces the presence of the rules of the chain, preserving the order in which they are listed,
+// as nftables evaluates them sequentially. Missing rules are appended if they follow all the existing ones,
+// otherwise all the rules of the chain are recreated within the same transaction.
 func addRules(nftconn *nftables.Conn, chain *firewallapi.Chain, nftchain *nftables.Chain) error {
 	apirules := FromChainToRulesArray(chain)
 	nftrules, err := nftconn.GetRules(nftchain.Table, nftchain)
 	if err != nil {
 		return err
 	}
+
+	if !isRulesOrderPreserved(nftrules, apirules) {
+		klog.V(2).Infof("recreating rules of chain %s to preserve their order", nftchain.Name)
+		for i := range nftrules {
+			if err := nftconn.DelRule(nftrules[i]); err != nil {
+				return err
+			}
+		}
+		nftrules = nil
+	}
+
 	for i := range apirules {
 		if exist := existRule(nftrules, apirules[i]); !exist {
 			if err := apirules[i].Add(nftconn, nftchain); err != nil {
@@ -38,6 +55,32 @@ func addRules(nftconn *nftables.Conn, chain *firewallapi.Chain, nftchain *nftabl
 	return nil
 }
 
+// isRulesOrderPreserved checks whether appending the missing rules to the existing ones
+// results in the same order defined in the FirewallConfiguration.
+func isRulesOrderPreserved(nftrules []*nftables.Rule, rules []firewallutils.Rule) bool {
+	last := -1
+	for i := range nftrules {
+		name, ok := userdata.GetString(nftrules[i].UserData, userdata.TypeComment)
+		if !ok {
+			continue
+		}
+		index := slices.IndexFunc(rules, func(r firewallutils.Rule) bool {
+			return r.GetName() != nil && *r.GetName() == name
+		})
+		if index < 0 {
+			continue
+		}
+		// Existing rules must be in order, and they must not follow any missing one.
+		if index < last || slices.ContainsFunc(rules[last+1:index], func(r firewallutils.Rule) bool {
+			return !existRule(nftrules, r)
+		}) {
+			return false
+		}
+		last = index
+	}
+	return true
+}
+
 func existRule(nftrules []*nftables.Rule, rule firewallutils.Rule) bool {
 	for i := range nftrules {
 		name, ok := userdata.GetString(nftrules[i].UserData, userdata.TypeComment)
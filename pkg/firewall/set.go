@@ -0,0 +1,164 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firewall
+
+import (
+	"fmt"
+
+	"github.com/google/nftables"
+	"k8s.io/klog/v2"
+
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	firewallutils "github.com/liqotech/liqo/pkg/firewall/utils"
+)
+
+// addSets adds the sets to the table, or updates the elements of the existing ones.
+// Elements are updated in place, without modifying the rules referencing the sets.
+func addSets(nftconn *nftables.Conn, sets []firewallapi.Set, table *nftables.Table) error {
+	nftSets, err := getSets(nftconn, table)
+	if err != nil {
+		return err
+	}
+
+	for i := range sets {
+		nftSet, elements, err := firewallutils.ForgeSet(&sets[i], table)
+		if err != nil {
+			return err
+		}
+
+		current := findSet(nftSets, sets[i].Name)
+		if current == nil {
+			klog.V(2).Infof("adding set %s", nftSet.Name)
+			if err := nftconn.AddSet(nftSet, elements); err != nil {
+				return fmt.Errorf("cannot add set %s: %w", nftSet.Name, err)
+			}
+			continue
+		}
+
+		if err := updateSetElements(nftconn, current, nftSet, elements); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateSetElements aligns the elements of the given set with the desired ones.
+func updateSetElements(nftconn *nftables.Conn, current, desired *nftables.Set, elements []nftables.SetElement) error {
+	currentElements, err := nftconn.GetSetElements(current)
+	if err != nil {
+		return fmt.Errorf("cannot get elements of set %s: %w", current.Name, err)
+	}
+
+	toAdd, toDel := firewallutils.DiffSetElements(currentElements, elements)
+	if len(toAdd) == 0 && len(toDel) == 0 {
+		return nil
+	}
+	klog.V(2).Infof("updating set %s: adding %d elements, removing %d elements", current.Name, len(toAdd), len(toDel))
+
+	// The elements of interval sets come in pairs, which may be merged differently by the kernel,
+	// hence the set is flushed and refilled within the same transaction.
+	if desired.Interval {
+		nftconn.FlushSet(current)
+		if len(elements) == 0 {
+			return nil
+		}
+		return nftconn.SetAddElements(current, elements)
+	}
+
+	if len(toDel) > 0 {
+		if err := nftconn.SetDeleteElements(current, toDel); err != nil {
+			return fmt.Errorf("cannot delete elements of set %s: %w", current.Name, err)
+		}
+	}
+	if len(toAdd) > 0 {
+		if err := nftconn.SetAddElements(current, toAdd); err != nil {
+			return fmt.Errorf("cannot add elements to set %s: %w", current.Name, err)
+		}
+	}
+	return nil
+}
+
+// areSetsModified checks whether any set applied on nftables has been modified in the firewall configuration.
+// In that case the whole table must be recreated, since a set cannot be deleted while rules reference it.
+func areSetsModified(nftconn *nftables.Conn, table *nftables.Table, sets []firewallapi.Set) (bool, error) {
+	nftSets, err := getSets(nftconn, table)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range nftSets {
+		set := findSetSpec(sets, nftSets[i].Name)
+		if set == nil {
+			continue
+		}
+		desired, _, err := firewallutils.ForgeSet(set, table)
+		if err != nil {
+			return false, err
+		}
+		currentElements, err := nftconn.GetSetElements(nftSets[i])
+		if err != nil {
+			return false, fmt.Errorf("cannot get elements of set %s: %w", nftSets[i].Name, err)
+		}
+		if firewallutils.IsSetModified(nftSets[i], currentElements, desired) {
+			klog.V(2).Infof("set %s has been modified", nftSets[i].Name)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cleanSets removes the sets that are not present in the firewall configuration.
+// It must be called after the rules referencing them have been deleted.
+func cleanSets(nftconn *nftables.Conn, table *nftables.Table, sets []firewallapi.Set) error {
+	nftSets, err := getSets(nftconn, table)
+	if err != nil {
+		return err
+	}
+
+	for i := range nftSets {
+		if findSetSpec(sets, nftSets[i].Name) == nil {
+			klog.V(2).Infof("deleting set %s", nftSets[i].Name)
+			nftconn.DelSet(nftSets[i])
+		}
+	}
+	return nil
+}
+
+// getSets returns the sets of the given table, or none if the table does not exist yet.
+func getSets(nftconn *nftables.Conn, table *nftables.Table) ([]*nftables.Set, error) {
+	exists, err := existTable(nftconn, table)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return nftconn.GetSets(table)
+}
+
+func findSet(nftSets []*nftables.Set, name string) *nftables.Set {
+	for i := range nftSets {
+		if nftSets[i].Name == name {
+			return nftSets[i]
+		}
+	}
+	return nil
+}
+
+func findSetSpec(sets []firewallapi.Set, name string) *firewallapi.Set {
+	for i := range sets {
+		if sets[i].Name == name {
+			return &sets[i]
+		}
+	}
+	return nil
+}
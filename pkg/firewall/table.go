@@ -32,6 +32,9 @@ func addTable(nftconn *nftables.Conn, table *firewallapi.Table) *nftables.Table
 func delTable(nftconn *nftables.Conn, table *firewallapi.Table) {
 	nftTable := &nftables.Table{}
 	setTableName(nftTable, *table.Name)
+	if table.Family != nil {
+		setTableFamily(nftTable, *table.Family)
+	}
 	nftconn.DelTable(nftTable)
 }
 
@@ -54,8 +57,23 @@ func getTableFamily(family firewallapi.TableFamily) nftables.TableFamily {
 	}
 }
 
-// cleanTable removes all the chains and rules that are not present in the firewall configuration or that have been modified.
+// cleanTable removes all the chains, rules and sets that are not present in the firewall configuration or that have been modified.
 func cleanTable(nftconn *nftables.Conn, table *firewallapi.Table) error {
+	nftTable := &nftables.Table{}
+	setTableName(nftTable, *table.Name)
+	setTableFamily(nftTable, *table.Family)
+
+	// If a set has been modified, the whole table is deleted to be recreated from scratch.
+	modified, err := areSetsModified(nftconn, nftTable, table.Sets)
+	if err != nil {
+		return err
+	}
+	if modified {
+		klog.V(2).Infof("deleting table %s", *table.Name)
+		nftconn.DelTable(nftTable)
+		return nil
+	}
+
 	nftChains, err := nftconn.ListChainsOfTableFamily(getTableFamily(*table.Family))
 	if err != nil {
 		return err
@@ -78,7 +96,8 @@ func cleanTable(nftconn *nftables.Conn, table *firewallapi.Table) error {
 			return err
 		}
 	}
-	return nil
+	// Sets are deleted after the rules, as they cannot be deleted while referenced.
+	return cleanSets(nftconn, nftTable, table.Sets)
 }
 
 func setTableName(table *nftables.Table, name string) {
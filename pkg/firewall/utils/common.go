@@ -35,6 +35,11 @@ func GetIPValueType(value *string) (firewallv1beta1.IPValueType, error) {
 		return firewallv1beta1.IPValueTypeVoid, nil
 	}
 
+	// Check if the value references a named set.
+	if IsSetReference(*value) {
+		return firewallv1beta1.IPValueTypeSet, nil
+	}
+
 	// Check if the value is a pool subnet.
 	if _, _, err := net.ParseCIDR(*value); err == nil {
 		return firewallv1beta1.IPValueTypeSubnet, nil
@@ -92,6 +97,11 @@ func GetPortValueType(value *string) (firewallv1beta1.PortValueType, error) {
 		return firewallv1beta1.PortValueTypeVoid, nil
 	}
 
+	// Check if the value references a named set.
+	if IsSetReference(*value) {
+		return firewallv1beta1.PortValueTypeSet, nil
+	}
+
 	// Check if the value is a port range.
 	if _, _, err := port.ParsePortRange(*value); err == nil {
 		return firewallv1beta1.PortValueTypeRange, nil
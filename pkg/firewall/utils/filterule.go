@@ -110,6 +110,10 @@ func forgeFilterRule(fr *firewallv1beta1.FilterRule, chain *nftables.Chain) (*nf
 		applyDropAction(rule)
 	case firewallv1beta1.ActionReject:
 		applyRejectAction(rule)
+	case firewallv1beta1.ActionVerdictMap:
+		if err := applyVerdictMapAction(fr.VerdictMap, rule); err != nil {
+			return nil, fmt.Errorf("cannot apply vmap action: %w", err)
+		}
 	default:
 	}
 
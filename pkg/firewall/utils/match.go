@@ -58,6 +58,12 @@ func applyMatch(m *firewallv1beta1.Match, rule *nftables.Rule) error {
 			return err
 		}
 	}
+	if m.CtState != nil {
+		err = applyMatchCtState(m, rule, op)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -74,62 +80,73 @@ func applyMatchIP(m *firewallv1beta1.Match, rule *nftables.Rule, op expr.CmpOp)
 		return applyMatchIPPoolSubnet(m, rule, op)
 	case firewallv1beta1.IPValueTypeRange:
 		return applyMatchIPRange(m, rule, op)
+	case firewallv1beta1.IPValueTypeSet:
+		return applyMatchSet(firewallv1beta1.MapKeyIP, m.IP.Position, m.IP.Value, rule, op)
 	default:
 		return fmt.Errorf("invalid match value type %s", matchIPValueType)
 	}
 }
 
 func applyMatchIPSingleIP(m *firewallv1beta1.Match, rule *nftables.Rule, op expr.CmpOp) error {
-	posOffset, err := getMatchIPPositionOffset(m)
+	ip := net.ParseIP(m.IP.Value)
+	if ip == nil {
+		return fmt.Errorf("invalid ip %s", m.IP.Value)
+	}
+	ipBytes := getIPBytes(ip)
+
+	posOffset, err := getMatchIPPositionOffset(m, ip)
 	if err != nil {
 		return err
 	}
 
+	applyMatchIPFamily(rule, ip)
 	rule.Exprs = append(rule.Exprs,
 		&expr.Payload{
 			DestRegister: 1,
 			Base:         expr.PayloadBaseNetworkHeader,
 			Offset:       posOffset,
-			Len:          4,
+			Len:          uint32(len(ipBytes)),
 		},
 		&expr.Cmp{
 			Op:       op,
 			Register: 1,
-			Data:     net.ParseIP(m.IP.Value).To4(),
+			Data:     ipBytes,
 		},
 	)
 	return nil
 }
 
 func applyMatchIPPoolSubnet(m *firewallv1beta1.Match, rule *nftables.Rule, op expr.CmpOp) error {
-	posOffset, err := getMatchIPPositionOffset(m)
+	ip, subnet, err := net.ParseCIDR(m.IP.Value)
 	if err != nil {
 		return err
 	}
+	ipBytes := getIPBytes(ip)
 
-	ip, subnet, err := net.ParseCIDR(m.IP.Value)
+	posOffset, err := getMatchIPPositionOffset(m, ip)
 	if err != nil {
 		return err
 	}
 
+	applyMatchIPFamily(rule, ip)
 	rule.Exprs = append(rule.Exprs,
 		&expr.Payload{
 			DestRegister: 1,
 			Base:         expr.PayloadBaseNetworkHeader,
 			Offset:       posOffset,
-			Len:          4,
+			Len:          uint32(len(ipBytes)),
 		},
 		&expr.Bitwise{
 			SourceRegister: 1,
 			DestRegister:   1,
-			Len:            4,
-			Xor:            []byte{0x0, 0x0, 0x0, 0x0},
+			Len:            uint32(len(ipBytes)),
+			Xor:            make([]byte, len(ipBytes)),
 			Mask:           subnet.Mask,
 		},
 		&expr.Cmp{
 			Op:       op,
 			Register: 1,
-			Data:     ip.To4(),
+			Data:     ipBytes,
 		},
 	)
 	return nil
@@ -245,35 +262,38 @@ func applyMatchPort(m *firewallv1beta1.Match, rule *nftables.Rule, op expr.CmpOp
 		return applyMatchPortSinglePort(m, rule, op)
 	case firewallv1beta1.PortValueTypeRange:
 		return applyMatchPortRange(m, rule)
+	case firewallv1beta1.PortValueTypeSet:
+		return applyMatchSet(firewallv1beta1.MapKeyPort, m.Port.Position, m.Port.Value, rule, op)
 	default:
 		return fmt.Errorf("invalid match value type %s", matchPortValueType)
 	}
 }
 
 func applyMatchIPRange(m *firewallv1beta1.Match, rule *nftables.Rule, op expr.CmpOp) error {
-	posOffset, err := getMatchIPPositionOffset(m)
-	if err != nil {
-		return err
-	}
-
 	startIP, endIP, err := GetIPValueRange(m.IP.Value)
 	if err != nil || startIP == nil || endIP == nil {
 		return err
 	}
 
-	startIPBytes := startIP.To4()
-	endIPBytes := endIP.To4()
+	startIPBytes := getIPBytes(startIP)
+	endIPBytes := getIPBytes(endIP)
 
-	if startIPBytes == nil || endIPBytes == nil {
-		return fmt.Errorf("invalid IPv4 addresses: startIP=%v, endIP=%v, err=%w", startIP, endIP, err)
+	if len(startIPBytes) != len(endIPBytes) {
+		return fmt.Errorf("invalid IP range, addresses belong to different families: startIP=%v, endIP=%v", startIP, endIP)
 	}
 
+	posOffset, err := getMatchIPPositionOffset(m, startIP)
+	if err != nil {
+		return err
+	}
+
+	applyMatchIPFamily(rule, startIP)
 	rule.Exprs = append(rule.Exprs,
 		&expr.Payload{
 			DestRegister: 1,
 			Base:         expr.PayloadBaseNetworkHeader,
 			Offset:       posOffset,
-			Len:          4,
+			Len:          uint32(len(startIPBytes)),
 		},
 		&expr.Range{
 			Op:       op,
@@ -286,6 +306,74 @@ func applyMatchIPRange(m *firewallv1beta1.Match, rule *nftables.Rule, op expr.Cm
 	return nil
 }
 
+func applyMatchCtState(m *firewallv1beta1.Match, rule *nftables.Rule, op expr.CmpOp) error {
+	var mask uint32
+	for _, state := range m.CtState.Value {
+		bit, err := getMatchCtStateBit(state)
+		if err != nil {
+			return err
+		}
+		mask |= bit
+	}
+
+	// The packet matches if the state bit of its connection is among the ones in the mask,
+	// hence the comparison operator is inverted with respect to the zero value.
+	cmpOp := expr.CmpOpNeq
+	if op == expr.CmpOpNeq {
+		cmpOp = expr.CmpOpEq
+	}
+
+	rule.Exprs = append(rule.Exprs,
+		// [ ct load state => reg 1 ]
+		&expr.Ct{Register: 1, Key: expr.CtKeySTATE},
+		// [ bitwise reg 1 = ( reg 1 & mask ) ^ 0x00000000 ]
+		&expr.Bitwise{
+			SourceRegister: 1,
+			DestRegister:   1,
+			Len:            4,
+			Mask:           binaryutil.NativeEndian.PutUint32(mask),
+			Xor:            binaryutil.NativeEndian.PutUint32(0),
+		},
+		// [ cmp neq reg 1 0x00000000 ]
+		&expr.Cmp{
+			Op:       cmpOp,
+			Register: 1,
+			Data:     binaryutil.NativeEndian.PutUint32(0),
+		},
+	)
+	return nil
+}
+
+// applyMatchIPFamily adds a match on the family of the packet if the rule belongs to an inet table,
+// as the offsets of the addresses in the network header differ between IPv4 and IPv6.
+func applyMatchIPFamily(rule *nftables.Rule, ip net.IP) {
+	if rule.Table == nil || rule.Table.Family != nftables.TableFamilyINet {
+		return
+	}
+
+	nfproto := byte(unix.NFPROTO_IPV4)
+	if ip.To4() == nil {
+		nfproto = unix.NFPROTO_IPV6
+	}
+
+	rule.Exprs = append(rule.Exprs,
+		&expr.Meta{Key: expr.MetaKeyNFPROTO, Register: 1},
+		&expr.Cmp{
+			Op:       expr.CmpOpEq,
+			Register: 1,
+			Data:     []byte{nfproto},
+		},
+	)
+}
+
+// getIPBytes returns the representation of the given IP address, using 4 bytes for IPv4 and 16 bytes for IPv6 addresses.
+func getIPBytes(ip net.IP) []byte {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip.To16()
+}
+
 func getMatchCmpOp(m *firewallv1beta1.Match) (expr.CmpOp, error) {
 	switch m.Op {
 	case firewallv1beta1.MatchOperationEq:
@@ -296,14 +384,21 @@ func getMatchCmpOp(m *firewallv1beta1.Match) (expr.CmpOp, error) {
 	return expr.CmpOp(0), fmt.Errorf("invalid match operation %s", m.Op)
 }
 
-func getMatchIPPositionOffset(m *firewallv1beta1.Match) (uint32, error) {
+func getMatchIPPositionOffset(m *firewallv1beta1.Match, ip net.IP) (uint32, error) {
+	ipv6 := ip.To4() == nil
 	switch m.IP.Position {
 	case firewallv1beta1.MatchPositionSrc:
+		if ipv6 {
+			return 8, nil
+		}
 		return 12, nil
 	case firewallv1beta1.MatchPositionDst:
+		if ipv6 {
+			return 24, nil
+		}
 		return 16, nil
 	}
-	return 0, fmt.Errorf("invalid match IP position %s", m.Dev.Position)
+	return 0, fmt.Errorf("invalid match IP position %s", m.IP.Position)
 }
 
 func getMatchPortPositionOffset(m *firewallv1beta1.Match) (uint32, error) {
@@ -326,6 +421,20 @@ func getMatchProtoValue(m *firewallv1beta1.Match) (uint8, error) {
 	return 0, fmt.Errorf("invalid match IP position %s", m.Dev.Position)
 }
 
+func getMatchCtStateBit(state firewallv1beta1.CtState) (uint32, error) {
+	switch state {
+	case firewallv1beta1.CtStateNew:
+		return expr.CtStateBitNEW, nil
+	case firewallv1beta1.CtStateEstablished:
+		return expr.CtStateBitESTABLISHED, nil
+	case firewallv1beta1.CtStateRelated:
+		return expr.CtStateBitRELATED, nil
+	case firewallv1beta1.CtStateInvalid:
+		return expr.CtStateBitINVALID, nil
+	}
+	return 0, fmt.Errorf("invalid match ct state %s", state)
+}
+
 func getMatchDevMetaKey(m *firewallv1beta1.Match) (expr.MetaKey, error) {
 	switch m.Dev.Position {
 	case firewallv1beta1.MatchDevPositionIn:
@@ -18,6 +18,8 @@ package utils
 
 import (
 	"github.com/google/nftables"
+	"github.com/google/nftables/binaryutil"
+	"github.com/google/nftables/expr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -138,6 +140,77 @@ var _ = Describe("Match Functions", func() {
 			Expect(rule.Exprs).NotTo(BeEmpty())
 		})
 
+		It("should apply IPv6 single IP match (src)", func() {
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationEq,
+				IP: &firewallv1beta1.MatchIP{
+					Value:    "fd00::1",
+					Position: firewallv1beta1.MatchPositionSrc,
+				},
+			}
+			err := applyMatch(match, rule)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.Exprs).To(HaveLen(2))
+			Expect(rule.Exprs[0]).To(Equal(&expr.Payload{
+				DestRegister: 1, Base: expr.PayloadBaseNetworkHeader, Offset: 8, Len: 16,
+			}))
+		})
+
+		It("should apply IPv6 subnet match (dst)", func() {
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationEq,
+				IP: &firewallv1beta1.MatchIP{
+					Value:    "fd00:10::/64",
+					Position: firewallv1beta1.MatchPositionDst,
+				},
+			}
+			err := applyMatch(match, rule)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.Exprs).To(HaveLen(3))
+			Expect(rule.Exprs[0]).To(Equal(&expr.Payload{
+				DestRegister: 1, Base: expr.PayloadBaseNetworkHeader, Offset: 24, Len: 16,
+			}))
+		})
+
+		It("should apply IPv6 range match", func() {
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationEq,
+				IP: &firewallv1beta1.MatchIP{
+					Value:    "fd00::1-fd00::ff",
+					Position: firewallv1beta1.MatchPositionSrc,
+				},
+			}
+			err := applyMatch(match, rule)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.Exprs).To(HaveLen(2))
+		})
+
+		It("should error on IP range mixing families", func() {
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationEq,
+				IP: &firewallv1beta1.MatchIP{
+					Value:    "10.0.0.1-fd00::ff",
+					Position: firewallv1beta1.MatchPositionSrc,
+				},
+			}
+			Expect(applyMatch(match, rule)).To(HaveOccurred())
+		})
+
+		It("should match the packet family in inet tables", func() {
+			table.Family = nftables.TableFamilyINet
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationEq,
+				IP: &firewallv1beta1.MatchIP{
+					Value:    "fd00::1",
+					Position: firewallv1beta1.MatchPositionSrc,
+				},
+			}
+			err := applyMatch(match, rule)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.Exprs).To(HaveLen(4))
+			Expect(rule.Exprs[0]).To(Equal(&expr.Meta{Key: expr.MetaKeyNFPROTO, Register: 1}))
+		})
+
 		It("should apply single port match (src)", func() {
 			match := &firewallv1beta1.Match{
 				Op: firewallv1beta1.MatchOperationEq,
@@ -253,6 +326,34 @@ var _ = Describe("Match Functions", func() {
 			Expect(rule.Exprs).NotTo(BeEmpty())
 		})
 
+		It("should apply ct state match", func() {
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationEq,
+				CtState: &firewallv1beta1.MatchCtState{
+					Value: []firewallv1beta1.CtState{firewallv1beta1.CtStateEstablished, firewallv1beta1.CtStateRelated},
+				},
+			}
+			err := applyMatch(match, rule)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.Exprs).To(HaveLen(3))
+			Expect(rule.Exprs[0]).To(Equal(&expr.Ct{Register: 1, Key: expr.CtKeySTATE}))
+			Expect(rule.Exprs[1].(*expr.Bitwise).Mask).To(Equal(binaryutil.NativeEndian.PutUint32(
+				expr.CtStateBitESTABLISHED | expr.CtStateBitRELATED)))
+			Expect(rule.Exprs[2].(*expr.Cmp).Op).To(Equal(expr.CmpOpNeq))
+		})
+
+		It("should apply ct state match with Neq operation", func() {
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationNeq,
+				CtState: &firewallv1beta1.MatchCtState{
+					Value: []firewallv1beta1.CtState{firewallv1beta1.CtStateInvalid},
+				},
+			}
+			err := applyMatch(match, rule)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.Exprs[2].(*expr.Cmp).Op).To(Equal(expr.CmpOpEq))
+		})
+
 		It("should apply combined match (proto + IP + port + dev)", func() {
 			matches := []firewallv1beta1.Match{
 				{
@@ -327,6 +428,17 @@ var _ = Describe("Match Functions", func() {
 			err := applyMatch(match, rule)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("should error on invalid ct state value", func() {
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationEq,
+				CtState: &firewallv1beta1.MatchCtState{
+					Value: []firewallv1beta1.CtState{"invalid-state"},
+				},
+			}
+			err := applyMatch(match, rule)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Context("ifname function", func() {
@@ -15,13 +15,13 @@
 package utils
 
 import (
-	"encoding/binary"
 	"fmt"
 	"net"
 
 	"github.com/google/nftables"
 	"github.com/google/nftables/expr"
 	"github.com/google/nftables/userdata"
+	"golang.org/x/sys/unix"
 
 	firewallv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
 )
@@ -86,12 +86,16 @@ func forgeNatRule(nr *firewallv1beta1.NatRule, chain *nftables.Chain) (*nftables
 }
 
 func applyNatRule(nr *firewallv1beta1.NatRule, rule *nftables.Rule) error {
-	ipType, err := GetIPValueType(nr.To)
+	natType, err := getNatRuleType(nr)
 	if err != nil {
 		return err
 	}
 
-	natType, err := getNatRuleType(nr)
+	if nr.ToMap != nil {
+		return applyNatMap(nr.ToMap, natType, rule)
+	}
+
+	ipType, err := GetIPValueType(nr.To)
 	if err != nil {
 		return err
 	}
@@ -119,13 +123,13 @@ func applyNatIP(ip *string, natType expr.NATType, rule *nftables.Rule) error {
 	rule.Exprs = append(rule.Exprs,
 		&expr.Immediate{
 			Register: 1,
-			Data:     ipNet.To4(),
+			Data:     getIPBytes(ipNet),
 		},
 		&expr.NAT{
 			Type:       natType,
 			RegAddrMin: 1,
 			RegAddrMax: 1,
-			Family:     uint32(rule.Table.Family),
+			Family:     getNatFamily(ipNet),
 		})
 	return nil
 }
@@ -144,17 +148,18 @@ func applyNatSubnet(ip *string, natType expr.NATType, rule *nftables.Rule) error
 		return err
 	}
 
-	mask := binary.BigEndian.Uint32(subnet.Mask)
-	start := binary.BigEndian.Uint32(subnet.IP)
+	firstIP := getIPBytes(subnet.IP)
 
 	// find the final address
-	lastIP := make(net.IP, 4)
-	binary.BigEndian.PutUint32(lastIP, (start&mask)|(mask^0xffffffff))
+	lastIP := make(net.IP, len(firstIP))
+	for i := range firstIP {
+		lastIP[i] = firstIP[i] | ^subnet.Mask[i]
+	}
 
 	rule.Exprs = append(rule.Exprs,
 		&expr.Immediate{
 			Register: 1,
-			Data:     subnet.IP,
+			Data:     firstIP,
 		},
 		&expr.Immediate{
 			Register: 2,
@@ -165,12 +170,20 @@ func applyNatSubnet(ip *string, natType expr.NATType, rule *nftables.Rule) error
 			RegAddrMin: 1,
 			RegAddrMax: 2,
 			Prefix:     true,
-			Family:     uint32(rule.Table.Family),
+			Family:     getNatFamily(subnet.IP),
 		},
 	)
 	return nil
 }
 
+// getNatFamily returns the netfilter family of the given address, to be used in NAT expressions.
+func getNatFamily(ip net.IP) uint32 {
+	if ip.To4() != nil {
+		return unix.NFPROTO_IPV4
+	}
+	return unix.NFPROTO_IPV6
+}
+
 func getNatRuleType(natrule *firewallv1beta1.NatRule) (expr.NATType, error) {
 	switch natrule.NatType {
 	case firewallv1beta1.NatTypeDestination:
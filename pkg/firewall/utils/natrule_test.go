@@ -17,10 +17,13 @@
 package utils
 
 import (
+	"net"
+
 	"github.com/google/nftables"
 	"github.com/google/nftables/expr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/sys/unix"
 	"k8s.io/utils/ptr"
 
 	firewallv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
@@ -266,6 +269,45 @@ var _ = Describe("NatRuleWrapper", func() {
 		Expect(wrapper.Equal(expectedRule)).To(BeTrue())
 	})
 
+	It("Equal should return true for IPv6 SNAT with subnet", func() {
+		table.Family = nftables.TableFamilyIPv6
+		nr := &firewallv1beta1.NatRule{
+			Name:    ptr.To("snat-subnet-v6-rule"),
+			NatType: firewallv1beta1.NatTypeSource,
+			To:      ptr.To("fd00:10::/64"),
+		}
+		wrapper := &NatRuleWrapper{NatRule: nr}
+
+		expectedRule, err := forgeNatRule(nr, chain)
+		Expect(err).NotTo(HaveOccurred())
+		expectedRule.Table = table
+
+		Expect(expectedRule.Exprs).To(ContainElement(&expr.Immediate{
+			Register: 2,
+			Data:     net.ParseIP("fd00:10::ffff:ffff:ffff:ffff").To16(),
+		}))
+		Expect(wrapper.Equal(expectedRule)).To(BeTrue())
+	})
+
+	It("Equal should return true for IPv6 DNAT rule", func() {
+		table.Family = nftables.TableFamilyIPv6
+		nr := &firewallv1beta1.NatRule{
+			Name:    ptr.To("dnat-v6-rule"),
+			NatType: firewallv1beta1.NatTypeDestination,
+			To:      ptr.To("fd00::10"),
+		}
+		wrapper := &NatRuleWrapper{NatRule: nr}
+
+		expectedRule, err := forgeNatRule(nr, chain)
+		Expect(err).NotTo(HaveOccurred())
+		expectedRule.Table = table
+
+		Expect(expectedRule.Exprs).To(ContainElement(&expr.NAT{
+			Type: expr.NATTypeDestNAT, RegAddrMin: 1, RegAddrMax: 1, Family: unix.NFPROTO_IPV6,
+		}))
+		Expect(wrapper.Equal(expectedRule)).To(BeTrue())
+	})
+
 	Context("Error handling", func() {
 		It("should error when 'to' is empty string for SNAT (IP type)", func() {
 			nr := &firewallv1beta1.NatRule{
@@ -0,0 +1,507 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/binaryutil"
+	"github.com/google/nftables/expr"
+	"golang.org/x/sys/unix"
+
+	firewallv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	"github.com/liqotech/liqo/pkg/utils/network/port"
+)
+
+// SetReferencePrefix is the prefix of the values referencing a named set.
+const SetReferencePrefix = "@"
+
+// IsSetReference returns whether the given value references a named set.
+func IsSetReference(value string) bool {
+	return strings.HasPrefix(value, SetReferencePrefix) && len(value) > len(SetReferencePrefix)
+}
+
+// GetSetReferenceName returns the name of the set referenced by the given value.
+func GetSetReferenceName(value string) string {
+	return strings.TrimPrefix(value, SetReferencePrefix)
+}
+
+// setInterval is an interval of keys, with both ends included, and its associated element.
+type setInterval struct {
+	start   []byte
+	end     []byte
+	val     []byte
+	verdict *expr.Verdict
+}
+
+// ForgeSet forges a nftables set, together with its elements, from a Set.
+func ForgeSet(set *firewallv1beta1.Set, table *nftables.Table) (*nftables.Set, []nftables.SetElement, error) {
+	keyType, err := getSetDatatype(set.KeyType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nftSet := &nftables.Set{
+		Table:   table,
+		Name:    set.Name,
+		KeyType: keyType,
+	}
+	if set.DataType != nil {
+		dataType, err := getSetDatatype(*set.DataType)
+		if err != nil {
+			return nil, nil, err
+		}
+		nftSet.IsMap = true
+		nftSet.DataType = dataType
+	}
+
+	intervals := make([]setInterval, 0, len(set.Elements))
+	for i := range set.Elements {
+		interval, err := parseSetElement(set, &set.Elements[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid element %q of set %s: %w", set.Elements[i].Key, set.Name, err)
+		}
+		if !bytes.Equal(interval.start, interval.end) {
+			nftSet.Interval = true
+		}
+		intervals = append(intervals, interval)
+	}
+
+	slices.SortFunc(intervals, func(a, b setInterval) int {
+		return bytes.Compare(a.start, b.start)
+	})
+
+	if nftSet.Interval {
+		// Overlapping intervals are rejected by the kernel, hence they are merged (or refused, for maps).
+		if intervals, err = mergeSetIntervals(intervals, nftSet.IsMap); err != nil {
+			return nil, nil, fmt.Errorf("invalid elements of set %s: %w", set.Name, err)
+		}
+		return nftSet, forgeIntervalSetElements(intervals), nil
+	}
+
+	elements := make([]nftables.SetElement, 0, len(intervals))
+	for i := range intervals {
+		if i > 0 && bytes.Equal(intervals[i].start, intervals[i-1].start) {
+			if nftSet.IsMap && !isSameSetIntervalData(&intervals[i], &intervals[i-1]) {
+				return nil, nil, fmt.Errorf("invalid elements of set %s: duplicated key with different data", set.Name)
+			}
+			continue
+		}
+		elements = append(elements, nftables.SetElement{
+			Key:         intervals[i].start,
+			Val:         intervals[i].val,
+			VerdictData: intervals[i].verdict,
+		})
+	}
+	return nftSet, elements, nil
+}
+
+// IsSetModified checks whether the set applied on nftables differs from the desired one,
+// so that it needs to be recreated. Elements are not considered, since they can be updated in place.
+func IsSetModified(current *nftables.Set, currentElements []nftables.SetElement, desired *nftables.Set) bool {
+	if current.IsMap != desired.IsMap || current.Interval != desired.Interval {
+		return true
+	}
+
+	// The nftables library overwrites the key type with the data type of verdict maps when retrieving them,
+	// hence the key type is checked through the length of the keys of the current elements.
+	if desired.IsMap && desired.DataType.Name == nftables.TypeVerdict.Name {
+		if current.KeyType.Name != nftables.TypeVerdict.Name {
+			return true
+		}
+	} else if current.KeyType.Name != desired.KeyType.Name ||
+		(desired.IsMap && current.DataType.Name != desired.DataType.Name) {
+		return true
+	}
+
+	for i := range currentElements {
+		if uint32(len(currentElements[i].Key)) != desired.KeyType.Bytes {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffSetElements returns the elements that have to be added to, and removed from, the current ones to obtain the desired ones.
+func DiffSetElements(current, desired []nftables.SetElement) (toAdd, toDel []nftables.SetElement) {
+	currentKeys := make(map[string]struct{}, len(current))
+	for i := range current {
+		currentKeys[getSetElementID(&current[i])] = struct{}{}
+	}
+	desiredKeys := make(map[string]struct{}, len(desired))
+	for i := range desired {
+		id := getSetElementID(&desired[i])
+		desiredKeys[id] = struct{}{}
+		if _, found := currentKeys[id]; !found {
+			toAdd = append(toAdd, desired[i])
+		}
+	}
+	for i := range current {
+		if _, found := desiredKeys[getSetElementID(&current[i])]; !found {
+			toDel = append(toDel, current[i])
+		}
+	}
+	return toAdd, toDel
+}
+
+// getSetElementID returns a string uniquely identifying the given element, including its data.
+func getSetElementID(e *nftables.SetElement) string {
+	var verdict string
+	if e.VerdictData != nil {
+		verdict = strconv.Itoa(int(e.VerdictData.Kind))
+	}
+	return fmt.Sprintf("%x/%t/%x/%s", e.Key, e.IntervalEnd, e.Val, verdict)
+}
+
+func getSetDatatype(dt firewallv1beta1.SetDataType) (nftables.SetDatatype, error) {
+	switch dt {
+	case firewallv1beta1.SetDataTypeIPv4Addr:
+		return nftables.TypeIPAddr, nil
+	case firewallv1beta1.SetDataTypeIPv6Addr:
+		return nftables.TypeIP6Addr, nil
+	case firewallv1beta1.SetDataTypeInetService:
+		return nftables.TypeInetService, nil
+	case firewallv1beta1.SetDataTypeVerdict:
+		return nftables.TypeVerdict, nil
+	default:
+		return nftables.TypeInvalid, fmt.Errorf("invalid set data type %s", dt)
+	}
+}
+
+// parseSetElement parses the key of the given element as an interval, together with its data.
+func parseSetElement(set *firewallv1beta1.Set, element *firewallv1beta1.SetElement) (setInterval, error) {
+	var (
+		interval setInterval
+		err      error
+	)
+
+	switch set.KeyType {
+	case firewallv1beta1.SetDataTypeIPv4Addr, firewallv1beta1.SetDataTypeIPv6Addr:
+		interval.start, interval.end, err = parseSetIPKey(element.Key, set.KeyType == firewallv1beta1.SetDataTypeIPv6Addr)
+	case firewallv1beta1.SetDataTypeInetService:
+		interval.start, interval.end, err = parseSetPortKey(element.Key)
+	default:
+		err = fmt.Errorf("invalid set key type %s", set.KeyType)
+	}
+	if err != nil {
+		return interval, err
+	}
+
+	if set.DataType == nil {
+		if element.Data != nil {
+			return interval, fmt.Errorf("data can be set only for maps")
+		}
+		return interval, nil
+	}
+	if element.Data == nil {
+		return interval, fmt.Errorf("data must be set for maps")
+	}
+
+	switch *set.DataType {
+	case firewallv1beta1.SetDataTypeIPv4Addr, firewallv1beta1.SetDataTypeIPv6Addr:
+		ip := net.ParseIP(*element.Data)
+		if ip == nil || (ip.To4() == nil) != (*set.DataType == firewallv1beta1.SetDataTypeIPv6Addr) {
+			return interval, fmt.Errorf("invalid data %s for type %s", *element.Data, *set.DataType)
+		}
+		interval.val = getIPBytes(ip)
+	case firewallv1beta1.SetDataTypeVerdict:
+		switch *element.Data {
+		case string(firewallv1beta1.ActionAccept):
+			interval.verdict = &expr.Verdict{Kind: expr.VerdictAccept}
+		case string(firewallv1beta1.ActionDrop):
+			interval.verdict = &expr.Verdict{Kind: expr.VerdictDrop}
+		default:
+			return interval, fmt.Errorf("invalid verdict %s", *element.Data)
+		}
+	default:
+		return interval, fmt.Errorf("invalid set data type %s", *set.DataType)
+	}
+	return interval, nil
+}
+
+// parseSetIPKey parses an IP, a subnet or a range of IPs, returning the first and the last address.
+func parseSetIPKey(key string, ipv6 bool) (start, end []byte, err error) {
+	var startIP, endIP net.IP
+
+	valueType, err := GetIPValueType(&key)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch valueType {
+	case firewallv1beta1.IPValueTypeIP:
+		startIP = net.ParseIP(key)
+		endIP = startIP
+	case firewallv1beta1.IPValueTypeSubnet:
+		_, subnet, err := net.ParseCIDR(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		startIP = getIPBytes(subnet.IP)
+		endIP = make(net.IP, len(startIP))
+		for i := range startIP {
+			endIP[i] = startIP[i] | ^subnet.Mask[i]
+		}
+	case firewallv1beta1.IPValueTypeRange:
+		if startIP, endIP, err = GetIPValueRange(key); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("invalid IP value %s", key)
+	}
+
+	start, end = getIPBytes(startIP), getIPBytes(endIP)
+	if (len(start) == net.IPv6len) != ipv6 || len(start) != len(end) {
+		return nil, nil, fmt.Errorf("IP value %s does not belong to the family of the set", key)
+	}
+	if bytes.Compare(start, end) > 0 {
+		return nil, nil, fmt.Errorf("invalid IP range %s", key)
+	}
+	return start, end, nil
+}
+
+// parseSetPortKey parses a port or a range of ports, returning the first and the last port.
+func parseSetPortKey(key string) (start, end []byte, err error) {
+	valueType, err := GetPortValueType(&key)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch valueType {
+	case firewallv1beta1.PortValueTypePort:
+		p, err := strconv.ParseUint(key, 10, 16)
+		if err != nil {
+			return nil, nil, err
+		}
+		start = binaryutil.BigEndian.PutUint16(uint16(p))
+		return start, start, nil
+	case firewallv1beta1.PortValueTypeRange:
+		startPort, endPort, err := port.ParsePortRange(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		return binaryutil.BigEndian.PutUint16(startPort), binaryutil.BigEndian.PutUint16(endPort), nil
+	default:
+		return nil, nil, fmt.Errorf("invalid port value %s", key)
+	}
+}
+
+// mergeSetIntervals merges the overlapping and adjacent intervals, which must be sorted by their start.
+// Overlapping intervals of maps are refused, since they would be ambiguous.
+func mergeSetIntervals(intervals []setInterval, isMap bool) ([]setInterval, error) {
+	merged := make([]setInterval, 0, len(intervals))
+	for i := range intervals {
+		if len(merged) == 0 {
+			merged = append(merged, intervals[i])
+			continue
+		}
+		last := &merged[len(merged)-1]
+		if bytes.Compare(intervals[i].start, last.end) <= 0 {
+			if isMap {
+				return nil, fmt.Errorf("overlapping keys are not allowed in maps")
+			}
+			if bytes.Compare(intervals[i].end, last.end) > 0 {
+				last.end = intervals[i].end
+			}
+			continue
+		}
+		if next, overflow := incrementBytes(last.end); !isMap && !overflow && bytes.Equal(next, intervals[i].start) {
+			last.end = intervals[i].end
+			continue
+		}
+		merged = append(merged, intervals[i])
+	}
+	return merged, nil
+}
+
+// forgeIntervalSetElements converts the given intervals, which must be sorted and not overlapping, into set elements.
+// Each interval is represented by its first key and by the key following the last one, flagged as interval end.
+func forgeIntervalSetElements(intervals []setInterval) []nftables.SetElement {
+	elements := make([]nftables.SetElement, 0, 2*len(intervals))
+	for i := range intervals {
+		elements = append(elements, nftables.SetElement{
+			Key:         intervals[i].start,
+			Val:         intervals[i].val,
+			VerdictData: intervals[i].verdict,
+		})
+		next, overflow := incrementBytes(intervals[i].end)
+		// The interval end is omitted when the interval extends up to the last key,
+		// or when it would coincide with the start of the following interval.
+		if overflow || (i+1 < len(intervals) && bytes.Equal(next, intervals[i+1].start)) {
+			continue
+		}
+		elements = append(elements, nftables.SetElement{Key: next, IntervalEnd: true})
+	}
+	return elements
+}
+
+func isSameSetIntervalData(a, b *setInterval) bool {
+	if (a.verdict == nil) != (b.verdict == nil) {
+		return false
+	}
+	if a.verdict != nil && a.verdict.Kind != b.verdict.Kind {
+		return false
+	}
+	return bytes.Equal(a.val, b.val)
+}
+
+// incrementBytes returns the big endian number following the given one, and whether it overflowed.
+func incrementBytes(b []byte) (next []byte, overflow bool) {
+	next = slices.Clone(b)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next, false
+		}
+	}
+	return next, true
+}
+
+// getLookupKeyPayload returns the expression loading the given field of the packet into the register 1.
+// IP addresses are supported only in ipv4 and ipv6 tables, since the family of the set must be known.
+func getLookupKeyPayload(key firewallv1beta1.MapKey, position firewallv1beta1.MatchPosition, table *nftables.Table) (*expr.Payload, error) {
+	switch key {
+	case firewallv1beta1.MapKeyIP:
+		var offset, length uint32
+		switch getTableFamily(table) {
+		case nftables.TableFamilyIPv4:
+			offset, length = 12, net.IPv4len
+		case nftables.TableFamilyIPv6:
+			offset, length = 8, net.IPv6len
+		default:
+			return nil, fmt.Errorf("sets of IP addresses can be looked up only in ipv4 and ipv6 tables")
+		}
+		switch position {
+		case firewallv1beta1.MatchPositionSrc:
+		case firewallv1beta1.MatchPositionDst:
+			offset += length
+		default:
+			return nil, fmt.Errorf("invalid lookup position %s", position)
+		}
+		return &expr.Payload{
+			DestRegister: 1,
+			Base:         expr.PayloadBaseNetworkHeader,
+			Offset:       offset,
+			Len:          length,
+		}, nil
+	case firewallv1beta1.MapKeyPort:
+		var offset uint32
+		switch position {
+		case firewallv1beta1.MatchPositionSrc:
+			offset = 0
+		case firewallv1beta1.MatchPositionDst:
+			offset = 2
+		default:
+			return nil, fmt.Errorf("invalid lookup position %s", position)
+		}
+		return &expr.Payload{
+			DestRegister: 1,
+			Base:         expr.PayloadBaseTransportHeader,
+			Offset:       offset,
+			Len:          2,
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid lookup key %s", key)
+	}
+}
+
+func getTableFamily(table *nftables.Table) nftables.TableFamily {
+	if table == nil {
+		return nftables.TableFamilyUnspecified
+	}
+	return table.Family
+}
+
+// applyMatchSet adds the lookup of the given field of the packet in a named set.
+func applyMatchSet(key firewallv1beta1.MapKey, position firewallv1beta1.MatchPosition, value string,
+	rule *nftables.Rule, op expr.CmpOp) error {
+	payload, err := getLookupKeyPayload(key, position, rule.Table)
+	if err != nil {
+		return err
+	}
+
+	rule.Exprs = append(rule.Exprs,
+		payload,
+		&expr.Lookup{
+			SourceRegister: 1,
+			SetName:        GetSetReferenceName(value),
+			Invert:         op == expr.CmpOpNeq,
+		},
+	)
+	return nil
+}
+
+// applyVerdictMapAction adds the lookup of the packet in a verdict map, whose result is the verdict of the rule.
+func applyVerdictMapAction(lookup *firewallv1beta1.MapLookup, rule *nftables.Rule) error {
+	if lookup == nil {
+		return fmt.Errorf("verdict map must be set for the vmap action")
+	}
+	payload, err := getLookupKeyPayload(lookup.Key, lookup.Position, rule.Table)
+	if err != nil {
+		return err
+	}
+
+	rule.Exprs = append(rule.Exprs,
+		payload,
+		// [ lookup reg 1 set <name> dreg 0 ]
+		&expr.Lookup{
+			SourceRegister: 1,
+			DestRegister:   0,
+			IsDestRegSet:   true,
+			SetName:        lookup.Name,
+		},
+	)
+	return nil
+}
+
+// applyNatMap adds the NAT translation to the address found looking up the packet in an address map.
+func applyNatMap(lookup *firewallv1beta1.MapLookup, natType expr.NATType, rule *nftables.Rule) error {
+	payload, err := getLookupKeyPayload(lookup.Key, lookup.Position, rule.Table)
+	if err != nil {
+		return err
+	}
+
+	var family uint32
+	switch getTableFamily(rule.Table) {
+	case nftables.TableFamilyIPv4:
+		family = unix.NFPROTO_IPV4
+	case nftables.TableFamilyIPv6:
+		family = unix.NFPROTO_IPV6
+	default:
+		return fmt.Errorf("address maps can be used only in ipv4 and ipv6 tables")
+	}
+
+	rule.Exprs = append(rule.Exprs,
+		payload,
+		// [ lookup reg 1 set <name> dreg 1 ]
+		&expr.Lookup{
+			SourceRegister: 1,
+			DestRegister:   1,
+			IsDestRegSet:   true,
+			SetName:        lookup.Name,
+		},
+		&expr.NAT{
+			Type:       natType,
+			RegAddrMin: 1,
+			RegAddrMax: 1,
+			Family:     family,
+		},
+	)
+	return nil
+}
@@ -0,0 +1,268 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package utils
+
+import (
+	"github.com/google/nftables"
+	"github.com/google/nftables/expr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	firewallv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+)
+
+var _ = Describe("Set Functions", func() {
+	var table *nftables.Table
+
+	BeforeEach(func() {
+		table = &nftables.Table{
+			Name:   "filter",
+			Family: nftables.TableFamilyIPv4,
+		}
+	})
+
+	Context("ForgeSet", func() {
+		It("should forge a plain set of IPs", func() {
+			set := &firewallv1beta1.Set{
+				Name:    "allowed",
+				KeyType: firewallv1beta1.SetDataTypeIPv4Addr,
+				Elements: []firewallv1beta1.SetElement{
+					{Key: "10.0.0.2"}, {Key: "10.0.0.1"}, {Key: "10.0.0.2"},
+				},
+			}
+			nftSet, elements, err := ForgeSet(set, table)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nftSet.Interval).To(BeFalse())
+			Expect(nftSet.IsMap).To(BeFalse())
+			Expect(nftSet.KeyType).To(Equal(nftables.TypeIPAddr))
+			Expect(elements).To(Equal([]nftables.SetElement{
+				{Key: []byte{10, 0, 0, 1}}, {Key: []byte{10, 0, 0, 2}},
+			}))
+		})
+
+		It("should forge an interval set merging overlapping elements", func() {
+			set := &firewallv1beta1.Set{
+				Name:    "allowed",
+				KeyType: firewallv1beta1.SetDataTypeIPv4Addr,
+				Elements: []firewallv1beta1.SetElement{
+					{Key: "10.0.0.0/24"}, {Key: "10.0.0.10"}, {Key: "10.0.1.0-10.0.1.9"}, {Key: "10.0.2.1"},
+				},
+			}
+			nftSet, elements, err := ForgeSet(set, table)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nftSet.Interval).To(BeTrue())
+			Expect(elements).To(Equal([]nftables.SetElement{
+				{Key: []byte{10, 0, 0, 0}},
+				{Key: []byte{10, 0, 1, 10}, IntervalEnd: true},
+				{Key: []byte{10, 0, 2, 1}},
+				{Key: []byte{10, 0, 2, 2}, IntervalEnd: true},
+			}))
+		})
+
+		It("should forge an interval set of ports", func() {
+			set := &firewallv1beta1.Set{
+				Name:     "services",
+				KeyType:  firewallv1beta1.SetDataTypeInetService,
+				Elements: []firewallv1beta1.SetElement{{Key: "80"}, {Key: "8000-8080"}, {Key: "65535"}},
+			}
+			nftSet, elements, err := ForgeSet(set, table)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nftSet.Interval).To(BeTrue())
+			Expect(elements).To(Equal([]nftables.SetElement{
+				{Key: []byte{0, 80}},
+				{Key: []byte{0, 81}, IntervalEnd: true},
+				{Key: []byte{0x1f, 0x40}},
+				{Key: []byte{0x1f, 0x91}, IntervalEnd: true},
+				{Key: []byte{0xff, 0xff}},
+			}))
+		})
+
+		It("should forge a verdict map", func() {
+			set := &firewallv1beta1.Set{
+				Name:     "verdicts",
+				KeyType:  firewallv1beta1.SetDataTypeIPv4Addr,
+				DataType: ptr.To(firewallv1beta1.SetDataTypeVerdict),
+				Elements: []firewallv1beta1.SetElement{
+					{Key: "10.0.0.1", Data: ptr.To("accept")},
+					{Key: "10.0.0.2", Data: ptr.To("drop")},
+				},
+			}
+			nftSet, elements, err := ForgeSet(set, table)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nftSet.IsMap).To(BeTrue())
+			Expect(nftSet.DataType).To(Equal(nftables.TypeVerdict))
+			Expect(elements).To(Equal([]nftables.SetElement{
+				{Key: []byte{10, 0, 0, 1}, VerdictData: &expr.Verdict{Kind: expr.VerdictAccept}},
+				{Key: []byte{10, 0, 0, 2}, VerdictData: &expr.Verdict{Kind: expr.VerdictDrop}},
+			}))
+		})
+
+		It("should forge an address map", func() {
+			set := &firewallv1beta1.Set{
+				Name:     "mapping",
+				KeyType:  firewallv1beta1.SetDataTypeIPv4Addr,
+				DataType: ptr.To(firewallv1beta1.SetDataTypeIPv4Addr),
+				Elements: []firewallv1beta1.SetElement{{Key: "10.0.0.1", Data: ptr.To("192.168.0.1")}},
+			}
+			_, elements, err := ForgeSet(set, table)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements).To(Equal([]nftables.SetElement{
+				{Key: []byte{10, 0, 0, 1}, Val: []byte{192, 168, 0, 1}},
+			}))
+		})
+
+		DescribeTable("should refuse invalid sets",
+			func(set *firewallv1beta1.Set) {
+				_, _, err := ForgeSet(set, table)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("IPv6 element in an IPv4 set", &firewallv1beta1.Set{
+				Name: "s", KeyType: firewallv1beta1.SetDataTypeIPv4Addr,
+				Elements: []firewallv1beta1.SetElement{{Key: "fd00::1"}},
+			}),
+			Entry("invalid port", &firewallv1beta1.Set{
+				Name: "s", KeyType: firewallv1beta1.SetDataTypeInetService,
+				Elements: []firewallv1beta1.SetElement{{Key: "70000"}},
+			}),
+			Entry("data in a set", &firewallv1beta1.Set{
+				Name: "s", KeyType: firewallv1beta1.SetDataTypeIPv4Addr,
+				Elements: []firewallv1beta1.SetElement{{Key: "10.0.0.1", Data: ptr.To("accept")}},
+			}),
+			Entry("missing data in a map", &firewallv1beta1.Set{
+				Name: "s", KeyType: firewallv1beta1.SetDataTypeIPv4Addr, DataType: ptr.To(firewallv1beta1.SetDataTypeVerdict),
+				Elements: []firewallv1beta1.SetElement{{Key: "10.0.0.1"}},
+			}),
+			Entry("invalid verdict", &firewallv1beta1.Set{
+				Name: "s", KeyType: firewallv1beta1.SetDataTypeIPv4Addr, DataType: ptr.To(firewallv1beta1.SetDataTypeVerdict),
+				Elements: []firewallv1beta1.SetElement{{Key: "10.0.0.1", Data: ptr.To("jump")}},
+			}),
+			Entry("overlapping keys in a map", &firewallv1beta1.Set{
+				Name: "s", KeyType: firewallv1beta1.SetDataTypeIPv4Addr, DataType: ptr.To(firewallv1beta1.SetDataTypeVerdict),
+				Elements: []firewallv1beta1.SetElement{
+					{Key: "10.0.0.0/24", Data: ptr.To("accept")}, {Key: "10.0.0.1", Data: ptr.To("drop")},
+				},
+			}),
+		)
+	})
+
+	Context("DiffSetElements", func() {
+		It("should return the elements to be added and removed", func() {
+			current := []nftables.SetElement{{Key: []byte{10, 0, 0, 1}}, {Key: []byte{10, 0, 0, 2}}}
+			desired := []nftables.SetElement{{Key: []byte{10, 0, 0, 2}}, {Key: []byte{10, 0, 0, 3}}}
+			toAdd, toDel := DiffSetElements(current, desired)
+			Expect(toAdd).To(Equal([]nftables.SetElement{{Key: []byte{10, 0, 0, 3}}}))
+			Expect(toDel).To(Equal([]nftables.SetElement{{Key: []byte{10, 0, 0, 1}}}))
+		})
+
+		It("should consider the data of map entries", func() {
+			current := []nftables.SetElement{{Key: []byte{10, 0, 0, 1}, VerdictData: &expr.Verdict{Kind: expr.VerdictAccept}}}
+			desired := []nftables.SetElement{{Key: []byte{10, 0, 0, 1}, VerdictData: &expr.Verdict{Kind: expr.VerdictDrop}}}
+			toAdd, toDel := DiffSetElements(current, desired)
+			Expect(toAdd).To(Equal(desired))
+			Expect(toDel).To(Equal(current))
+		})
+	})
+
+	Context("IsSetModified", func() {
+		It("should detect a change of the key type", func() {
+			current := &nftables.Set{KeyType: nftables.TypeIPAddr}
+			desired := &nftables.Set{KeyType: nftables.TypeInetService}
+			Expect(IsSetModified(current, nil, desired)).To(BeTrue())
+		})
+
+		It("should detect a change of the interval flag", func() {
+			current := &nftables.Set{KeyType: nftables.TypeIPAddr}
+			desired := &nftables.Set{KeyType: nftables.TypeIPAddr, Interval: true}
+			Expect(IsSetModified(current, nil, desired)).To(BeTrue())
+		})
+
+		It("should not consider the elements", func() {
+			current := &nftables.Set{KeyType: nftables.TypeIPAddr}
+			desired := &nftables.Set{KeyType: nftables.TypeIPAddr}
+			Expect(IsSetModified(current, []nftables.SetElement{{Key: []byte{10, 0, 0, 1}}}, desired)).To(BeFalse())
+		})
+	})
+
+	Context("set references", func() {
+		var rule *nftables.Rule
+
+		BeforeEach(func() {
+			rule = &nftables.Rule{Table: table, Chain: &nftables.Chain{Name: "forward", Table: table}}
+		})
+
+		It("should look up the destination IP in a set", func() {
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationNeq,
+				IP: &firewallv1beta1.MatchIP{Value: "@allowed", Position: firewallv1beta1.MatchPositionDst},
+			}
+			Expect(applyMatch(match, rule)).To(Succeed())
+			Expect(rule.Exprs).To(Equal([]expr.Any{
+				&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseNetworkHeader, Offset: 16, Len: 4},
+				&expr.Lookup{SourceRegister: 1, SetName: "allowed", Invert: true},
+			}))
+		})
+
+		It("should look up the source port in a set", func() {
+			match := &firewallv1beta1.Match{
+				Op:   firewallv1beta1.MatchOperationEq,
+				Port: &firewallv1beta1.MatchPort{Value: "@services", Position: firewallv1beta1.MatchPositionSrc},
+			}
+			Expect(applyMatch(match, rule)).To(Succeed())
+			Expect(rule.Exprs).To(Equal([]expr.Any{
+				&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseTransportHeader, Offset: 0, Len: 2},
+				&expr.Lookup{SourceRegister: 1, SetName: "services"},
+			}))
+		})
+
+		It("should refuse IP set references in inet tables", func() {
+			rule.Table = &nftables.Table{Name: "filter", Family: nftables.TableFamilyINet}
+			match := &firewallv1beta1.Match{
+				Op: firewallv1beta1.MatchOperationEq,
+				IP: &firewallv1beta1.MatchIP{Value: "@allowed", Position: firewallv1beta1.MatchPositionDst},
+			}
+			Expect(applyMatch(match, rule)).NotTo(Succeed())
+		})
+
+		It("should look up the verdict in a verdict map", func() {
+			fr := &firewallv1beta1.FilterRule{
+				Name:       ptr.To("vmap"),
+				Action:     firewallv1beta1.ActionVerdictMap,
+				VerdictMap: &firewallv1beta1.MapLookup{Name: "verdicts", Key: firewallv1beta1.MapKeyIP, Position: firewallv1beta1.MatchPositionSrc},
+			}
+			nftRule, err := forgeFilterRule(fr, rule.Chain)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nftRule.Exprs).To(Equal([]expr.Any{
+				&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseNetworkHeader, Offset: 12, Len: 4},
+				&expr.Lookup{SourceRegister: 1, DestRegister: 0, IsDestRegSet: true, SetName: "verdicts"},
+			}))
+		})
+
+		It("should translate the address found in an address map", func() {
+			nr := &firewallv1beta1.NatRule{
+				Name:    ptr.To("dnat"),
+				NatType: firewallv1beta1.NatTypeDestination,
+				ToMap:   &firewallv1beta1.MapLookup{Name: "mapping", Key: firewallv1beta1.MapKeyIP, Position: firewallv1beta1.MatchPositionDst},
+			}
+			nftRule, err := forgeNatRule(nr, rule.Chain)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nftRule.Exprs).To(HaveLen(3))
+			Expect(nftRule.Exprs[1]).To(Equal(&expr.Lookup{SourceRegister: 1, DestRegister: 1, IsDestRegSet: true, SetName: "mapping"}))
+			Expect(nftRule.Exprs[2]).To(BeAssignableToTypeOf(&expr.NAT{}))
+		})
+	})
+})
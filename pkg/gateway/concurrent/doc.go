@@ -18,4 +18,6 @@
 // Then, the active gateway is labeled with the ActiveGatewayKey and ActiveGatewayValue, and the passive gateways are unlabeled.
 // The gateway service target the active gateway using the ActiveGatewayKey and ActiveGatewayValue labels.
 // In order to cohordinate the sidecar containers, the gateway uses a unix socket to manage the IPC, and to start the sidecars when it becomes leader.
+// In active-active mode, all the replicas are active at the same time: each of them reserves a replica index,
+// used to pair it with the remote replica with the same index, and keeps its own tunnel.
 package concurrent
@@ -36,12 +36,16 @@ type RunnableGateway struct {
 	GatewayName string
 	Namespace   string
 
+	// ActiveActive makes every replica active, rather than electing a single leader.
+	ActiveActive bool
+
 	Socket           net.Listener
 	GuestConnections ipc.GuestConnections
 }
 
 // NewRunnableGatewayStartup creates a new Runnable.
-func NewRunnableGatewayStartup(cl client.Client, podName, gatewayName, namespace string, containerNames []string) (*RunnableGateway, error) {
+func NewRunnableGatewayStartup(cl client.Client, podName, gatewayName, namespace string, containerNames []string,
+	activeActive bool) (*RunnableGateway, error) {
 	guestConnections := ipc.NewGuestConnections(containerNames)
 
 	socket, err := ipc.CreateListenSocket(unixSocketPath)
@@ -59,6 +63,7 @@ func NewRunnableGatewayStartup(cl client.Client, podName, gatewayName, namespace
 		PodName:          podName,
 		GatewayName:      gatewayName,
 		Namespace:        namespace,
+		ActiveActive:     activeActive,
 		Socket:           socket,
 		GuestConnections: guestConnections,
 	}, nil
@@ -68,6 +73,10 @@ func NewRunnableGatewayStartup(cl client.Client, podName, gatewayName, namespace
 func (rg *RunnableGateway) Start(ctx context.Context) error {
 	defer rg.Close()
 
+	if rg.ActiveActive {
+		return rg.startActiveActive(ctx)
+	}
+
 	pods, err := ListAllGatewaysReplicas(ctx, rg.Client, rg.Namespace, rg.GatewayName)
 	if err != nil {
 		return err
@@ -100,6 +109,22 @@ func (rg *RunnableGateway) Start(ctx context.Context) error {
 	return nil
 }
 
+// startActiveActive reserves a replica index for the current pod and starts the sidecars,
+// without demoting the other replicas, which are all active at the same time.
+func (rg *RunnableGateway) startActiveActive(ctx context.Context) error {
+	podKey := client.ObjectKey{Namespace: rg.Namespace, Name: rg.PodName}
+
+	if _, err := ClaimReplicaIndex(ctx, rg.Client, podKey, rg.GatewayName); err != nil {
+		return fmt.Errorf("unable to claim the replica index: %w", err)
+	}
+
+	if err := AddActiveGatewayLabel(ctx, rg.Client, podKey); err != nil {
+		return err
+	}
+
+	return ipc.StartAllGuestsConnections(rg.GuestConnections)
+}
+
 // Close closes the Runnable.
 func (rg *RunnableGateway) Close() {
 	ipc.CloseListenSocket(rg.Socket)
@@ -19,4 +19,17 @@ const (
 	ActiveGatewayKey = "networking.liqo.io/active"
 	// ActiveGatewayValue is the value used to label the active pod gateway.
 	ActiveGatewayValue = "true"
+
+	// ActiveActiveGatewayKey is the key used to label the gateway resources running in active-active mode.
+	ActiveActiveGatewayKey = "networking.liqo.io/active-active"
+	// ActiveActiveGatewayValue is the value used to label the gateway resources running in active-active mode.
+	ActiveActiveGatewayValue = "true"
+
+	// ReplicaIndexKey is the key used to label each gateway pod with the index of the replica, in active-active mode.
+	ReplicaIndexKey = "networking.liqo.io/gateway-replica"
+
+	// ConnectedGatewayKey is the key used to label the gateway pods with the status of their tunnel, in active-active mode.
+	ConnectedGatewayKey = "networking.liqo.io/connected"
+	// ConnectedGatewayValue is the value used to label the gateway pods whose tunnel is connected, in active-active mode.
+	ConnectedGatewayValue = "true"
 )
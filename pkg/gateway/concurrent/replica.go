@@ -0,0 +1,188 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsActiveActive returns whether the given gateway resource runs in active-active mode.
+func IsActiveActive(obj metav1.Object) bool {
+	return obj.GetLabels()[ActiveActiveGatewayKey] == ActiveActiveGatewayValue
+}
+
+// GetReplicaIndex returns the index assigned to the given gateway replica, if any.
+func GetReplicaIndex(pod *corev1.Pod) (int, bool) {
+	value, ok := pod.GetLabels()[ReplicaIndexKey]
+	if !ok {
+		return 0, false
+	}
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+// IsConnectedGateway returns whether the tunnel of the given gateway replica is connected.
+func IsConnectedGateway(pod *corev1.Pod) bool {
+	return pod.GetLabels()[ConnectedGatewayKey] == ConnectedGatewayValue
+}
+
+// SortReplicasByIndex sorts the given gateway replicas by their index. Replicas without an index are moved to the end.
+func SortReplicasByIndex(pods []corev1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		ii, iok := GetReplicaIndex(&pods[i])
+		ji, jok := GetReplicaIndex(&pods[j])
+		switch {
+		case iok && jok:
+			return ii < ji
+		default:
+			return iok && !jok
+		}
+	})
+}
+
+// ForgeReplicaLeaseName returns the name of the Lease used to reserve a replica index of a gateway.
+func ForgeReplicaLeaseName(gatewayName string, index int) string {
+	return fmt.Sprintf("%s-replica-%d", gatewayName, index)
+}
+
+// ClaimReplicaIndex reserves the lowest replica index not held by another running replica of the gateway,
+// and labels the given pod with it. Each index is reserved through a Lease owned by the holder pod,
+// so that it is released as soon as the pod is deleted.
+func ClaimReplicaIndex(ctx context.Context, cl client.Client, podKey client.ObjectKey, gatewayName string) (int, error) {
+	pod := &corev1.Pod{}
+	if err := cl.Get(ctx, podKey, pod); err != nil {
+		return 0, err
+	}
+
+	for index := 0; ; index++ {
+		claimed, err := claimReplicaLease(ctx, cl, pod, ForgeReplicaLeaseName(gatewayName, index))
+		if err != nil {
+			return 0, err
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := setPodLabel(ctx, cl, podKey, ReplicaIndexKey, strconv.Itoa(index)); err != nil {
+			return 0, err
+		}
+		klog.Infof("Pod %s is the gateway replica %d", podKey, index)
+		return index, nil
+	}
+}
+
+// claimReplicaLease tries to acquire the given lease for the pod.
+// A lease held by a pod which no longer exists, or which is terminating, is taken over.
+func claimReplicaLease(ctx context.Context, cl client.Client, pod *corev1.Pod, leaseName string) (bool, error) {
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: pod.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       pod.Name,
+				UID:        pod.UID,
+			}},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity: ptr.To(pod.Name),
+		},
+	}
+
+	err := cl.Create(ctx, lease)
+	switch {
+	case err == nil:
+		return true, nil
+	case !apierrors.IsAlreadyExists(err):
+		return false, fmt.Errorf("unable to create lease %s/%s: %w", pod.Namespace, leaseName, err)
+	}
+
+	existing := &coordinationv1.Lease{}
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(lease), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The lease has been garbage collected in the meanwhile: try again to create it.
+			return claimReplicaLease(ctx, cl, pod, leaseName)
+		}
+		return false, fmt.Errorf("unable to get lease %s/%s: %w", pod.Namespace, leaseName, err)
+	}
+
+	holder := ptr.Deref(existing.Spec.HolderIdentity, "")
+	if holder == pod.Name {
+		return true, nil
+	}
+
+	if holder != "" {
+		var holderPod corev1.Pod
+		err := cl.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: holder}, &holderPod)
+		switch {
+		case err == nil && holderPod.DeletionTimestamp.IsZero():
+			// The index is held by another running replica.
+			return false, nil
+		case err != nil && !apierrors.IsNotFound(err):
+			return false, fmt.Errorf("unable to get pod %s/%s: %w", pod.Namespace, holder, err)
+		}
+	}
+
+	existing.Spec.HolderIdentity = lease.Spec.HolderIdentity
+	existing.OwnerReferences = lease.OwnerReferences
+	if err := cl.Update(ctx, existing); err != nil {
+		if apierrors.IsConflict(err) {
+			// Another replica took over the lease in the meanwhile.
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to update lease %s/%s: %w", pod.Namespace, leaseName, err)
+	}
+	return true, nil
+}
+
+// SetConnectedGatewayLabel labels the given gateway replica with the status of its tunnel.
+func SetConnectedGatewayLabel(ctx context.Context, cl client.Client, key client.ObjectKey, connected bool) error {
+	return setPodLabel(ctx, cl, key, ConnectedGatewayKey, strconv.FormatBool(connected))
+}
+
+func setPodLabel(ctx context.Context, cl client.Client, key client.ObjectKey, labelKey, labelValue string) error {
+	pod := &corev1.Pod{}
+	if err := cl.Get(ctx, key, pod); err != nil {
+		return err
+	}
+
+	if pod.GetLabels()[labelKey] == labelValue {
+		return nil
+	}
+
+	labels := pod.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[labelKey] = labelValue
+	pod.SetLabels(labels)
+
+	return cl.Update(ctx, pod)
+}
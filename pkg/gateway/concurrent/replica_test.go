@@ -0,0 +1,198 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrent
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Gateway replica indexes", func() {
+	const (
+		namespace   = "liqo-tenant-consumer"
+		gatewayName = "gateway"
+		podName     = "gateway-new"
+	)
+
+	var (
+		ctx     context.Context
+		cl      client.Client
+		objects []client.Object
+		funcs   interceptor.Funcs
+		podKey  = client.ObjectKey{Namespace: namespace, Name: podName}
+	)
+
+	forgePod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID("uid-" + name)}}
+	}
+
+	forgeLease := func(index int, holder string) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: ForgeReplicaLeaseName(gatewayName, index), Namespace: namespace},
+			Spec:       coordinationv1.LeaseSpec{HolderIdentity: ptr.To(holder)},
+		}
+	}
+
+	getLease := func(index int) *coordinationv1.Lease {
+		lease := &coordinationv1.Lease{}
+		Expect(cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ForgeReplicaLeaseName(gatewayName, index)}, lease)).To(Succeed())
+		return lease
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		objects = []client.Object{forgePod(podName)}
+		funcs = interceptor.Funcs{}
+	})
+
+	JustBeforeEach(func() {
+		cl = fake.NewClientBuilder().WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+	})
+
+	Describe("the ClaimReplicaIndex function", func() {
+		var (
+			index int
+			err   error
+		)
+
+		JustBeforeEach(func() {
+			index, err = ClaimReplicaIndex(ctx, cl, podKey, gatewayName)
+		})
+
+		expectClaimed := func(expected int) {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(index).To(Equal(expected))
+
+			pod := &corev1.Pod{}
+			Expect(cl.Get(ctx, podKey, pod)).To(Succeed())
+			podIndex, ok := GetReplicaIndex(pod)
+			Expect(ok).To(BeTrue())
+			Expect(podIndex).To(Equal(expected))
+
+			lease := getLease(expected)
+			Expect(lease.Spec.HolderIdentity).To(PointTo(Equal(podName)))
+			Expect(lease.OwnerReferences).To(ConsistOf(HaveField("UID", BeEquivalentTo("uid-"+podName))))
+		}
+
+		When("no index is reserved", func() {
+			It("should claim the first index", func() { expectClaimed(0) })
+		})
+
+		When("the first index is held by a running replica", func() {
+			BeforeEach(func() {
+				objects = append(objects, forgePod("gateway-running"), forgeLease(0, "gateway-running"))
+			})
+
+			It("should claim the next index", func() { expectClaimed(1) })
+			It("should not take over the lease of the running replica", func() {
+				Expect(getLease(0).Spec.HolderIdentity).To(PointTo(Equal("gateway-running")))
+			})
+		})
+
+		When("the first index is held by a deleted replica", func() {
+			BeforeEach(func() {
+				objects = append(objects, forgeLease(0, "gateway-deleted"))
+			})
+
+			It("should take over its lease", func() { expectClaimed(0) })
+		})
+
+		When("the first index is held by a terminating replica", func() {
+			BeforeEach(func() {
+				terminating := forgePod("gateway-terminating")
+				terminating.DeletionTimestamp = ptr.To(metav1.Now())
+				terminating.Finalizers = []string{"liqo.io/test"}
+				objects = append(objects, terminating, forgeLease(0, "gateway-terminating"))
+			})
+
+			It("should take over its lease", func() { expectClaimed(0) })
+		})
+
+		When("another replica takes over the lease of a deleted replica in the meanwhile", func() {
+			BeforeEach(func() {
+				objects = append(objects, forgeLease(0, "gateway-deleted"))
+				funcs.Update = func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if _, ok := obj.(*coordinationv1.Lease); ok && obj.GetName() == ForgeReplicaLeaseName(gatewayName, 0) {
+						return apierrors.NewConflict(schema.GroupResource{Group: coordinationv1.GroupName, Resource: "leases"}, obj.GetName(), nil)
+					}
+					return cl.Update(ctx, obj, opts...)
+				}
+			})
+
+			It("should claim the next index", func() { expectClaimed(1) })
+		})
+	})
+
+	Describe("the claimReplicaLease function", func() {
+		var (
+			claimed bool
+			err     error
+			creates int
+		)
+
+		BeforeEach(func() { creates = 0 })
+
+		JustBeforeEach(func() {
+			claimed, err = claimReplicaLease(ctx, cl, forgePod(podName), ForgeReplicaLeaseName(gatewayName, 0))
+		})
+
+		When("the lease is garbage collected after the creation attempt", func() {
+			BeforeEach(func() {
+				// The first creation fails as the lease still exists, while it is no longer found when retrieved.
+				funcs.Create = func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if creates++; creates == 1 {
+						return apierrors.NewAlreadyExists(schema.GroupResource{Group: coordinationv1.GroupName, Resource: "leases"}, obj.GetName())
+					}
+					return cl.Create(ctx, obj, opts...)
+				}
+			})
+
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should try again to create the lease", func() {
+				Expect(claimed).To(BeTrue())
+				Expect(creates).To(Equal(2))
+				Expect(getLease(0).Spec.HolderIdentity).To(PointTo(Equal(podName)))
+			})
+		})
+
+		When("the lease update conflicts", func() {
+			BeforeEach(func() {
+				objects = append(objects, forgeLease(0, "gateway-deleted"))
+				funcs.Update = func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.UpdateOption) error {
+					return apierrors.NewConflict(schema.GroupResource{Group: coordinationv1.GroupName, Resource: "leases"}, obj.GetName(), nil)
+				}
+			})
+
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should not claim the lease", func() {
+				Expect(claimed).To(BeFalse())
+				Expect(getLease(0).Spec.HolderIdentity).To(PointTo(Equal("gateway-deleted")))
+			})
+		})
+	})
+})
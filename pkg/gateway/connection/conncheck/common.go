@@ -24,13 +24,21 @@ type Msg struct {
 	ClusterID string    `json:"clusterID"`
 	MsgType   MsgTypes  `json:"msgType"`
 	TimeStamp time.Time `json:"timeStamp"`
+	// Seq is the sequence number of the PING, echoed back in the PONG. It is used to compute the packet loss.
+	Seq uint64 `json:"seq,omitempty"`
+	// Size is the size of the IP packet carrying an MTU probe, echoed back in the acknowledgment.
+	Size int `json:"size,omitempty"`
+	// Padding fills the MTU probes up to the desired size.
+	Padding string `json:"padding,omitempty"`
 }
 
 func (msg Msg) String() string {
-	return fmt.Sprintf("ClusterID: %s, MsgType: %s, Timestamp: %s",
+	return fmt.Sprintf("ClusterID: %s, MsgType: %s, Timestamp: %s, Seq: %d, Size: %d",
 		msg.ClusterID,
 		msg.MsgType,
-		msg.TimeStamp.Format("00:00:00.000000000"))
+		msg.TimeStamp.Format("00:00:00.000000000"),
+		msg.Seq,
+		msg.Size)
 }
 
 // MsgTypes represents the type of a message.
@@ -41,7 +49,12 @@ const (
 	PING MsgTypes = "PING"
 	// PONG is the type of a pong message.
 	PONG MsgTypes = "PONG"
+	// MTUPROBE is the type of a message probing the MTU of the path.
+	MTUPROBE MsgTypes = "MTUPROBE"
+	// MTUACK is the type of the message acknowledging the reception of an MTU probe.
+	MTUACK MsgTypes = "MTUACK"
 )
 
 // UpdateFunc is a function called when a Receiver gets a PONG or when a connection is declared failed.
-type UpdateFunc func(connected bool, latency time.Duration, time time.Time) error
+// The quality is not available (i.e., it has no samples) when the connection is declared failed.
+type UpdateFunc func(connected bool, latency time.Duration, time time.Time, quality Quality) error
@@ -108,10 +108,12 @@ func (c *ConnChecker) RunSender(clusterID string) {
 	klog.Infof("conncheck sender %q starting against %q", clusterID, sender.raddr.IP.String())
 
 	if err := wait.PollUntilContextCancel(sender.Ctx, c.opts.PingInterval, false, func(_ context.Context) (done bool, err error) {
-		err = c.senders[clusterID].SendPing()
+		msg, err := sender.SendPing()
 		if err != nil {
 			klog.Warningf("failed to send ping: %s", err)
+			return false, nil
 		}
+		c.receiver.RecordPing(msg)
 		return false, nil
 	}); err != nil {
 		klog.Errorf("conncheck sender %s stopped for an error: %s", clusterID, err)
@@ -147,6 +149,16 @@ func (c *ConnChecker) GetLatency(clusterID string) (time.Duration, error) {
 	return 0, fmt.Errorf("sender %s not found", clusterID)
 }
 
+// GetQuality returns the quality of the connection with clusterID.
+func (c *ConnChecker) GetQuality(clusterID string) (Quality, error) {
+	c.receiver.m.RLock()
+	defer c.receiver.m.RUnlock()
+	if peer, ok := c.receiver.peers[clusterID]; ok {
+		return peer.quality(time.Now(), c.opts.PingInterval), nil
+	}
+	return Quality{}, fmt.Errorf("sender %s not found", clusterID)
+}
+
 // GetConnected returns the connection status with clusterID.
 func (c *ConnChecker) GetConnected(clusterID string) (bool, error) {
 	c.receiver.m.RLock()
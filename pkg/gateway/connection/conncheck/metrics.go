@@ -0,0 +1,63 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conncheck
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// MetricsPeerPacketLoss is the metric that exposes the ratio of pings lost towards a given peer.
+	MetricsPeerPacketLoss = prometheus.NewDesc(
+		"liqo_peer_packet_loss_ratio",
+		"Ratio (between 0 and 1) of the pings towards a given peer which did not receive a reply, computed over a sliding window.",
+		[]string{"cluster_id"},
+		nil,
+	)
+
+	// MetricsPeerJitter is the metric that exposes the jitter of the round-trip latency towards a given peer.
+	MetricsPeerJitter = prometheus.NewDesc(
+		"liqo_peer_jitter_us",
+		"Mean variation of the round-trip latency of a given peer in microseconds, computed over a sliding window.",
+		[]string{"cluster_id"},
+		nil,
+	)
+)
+
+var _ prometheus.Collector = &ConnChecker{}
+
+// Describe implements prometheus.Collector.
+func (c *ConnChecker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- MetricsPeerPacketLoss
+	ch <- MetricsPeerJitter
+}
+
+// Collect implements prometheus.Collector.
+func (c *ConnChecker) Collect(ch chan<- prometheus.Metric) {
+	c.receiver.m.RLock()
+	defer c.receiver.m.RUnlock()
+
+	now := time.Now()
+	for clusterID, peer := range c.receiver.peers {
+		quality := peer.quality(now, c.opts.PingInterval)
+		if !quality.Available() {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(MetricsPeerPacketLoss, prometheus.GaugeValue, quality.PacketLoss, clusterID)
+		ch <- prometheus.MustNewConstMetric(MetricsPeerJitter, prometheus.GaugeValue, float64(quality.Jitter.Microseconds()), clusterID)
+	}
+}
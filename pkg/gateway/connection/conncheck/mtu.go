@@ -0,0 +1,242 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conncheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ipv4HeaderSize is the size of the IPv4 header of the MTU probes.
+	ipv4HeaderSize = 20
+	// ipv6HeaderSize is the size of the IPv6 header of the MTU probes.
+	ipv6HeaderSize = 40
+	// udpHeaderSize is the size of the UDP header of the MTU probes.
+	udpHeaderSize = 8
+	// maxMsgSize is the size of the largest message the receiver can handle, including the MTU probes.
+	maxMsgSize = 65535
+)
+
+// ProbeFunc is a function probing whether packets of the given size cross the path.
+type ProbeFunc func(size int) (bool, error)
+
+// SearchPathMTU returns the size of the largest packet crossing the path, between the lower and the upper bounds (included).
+// It returns false if not even packets of the lower bound size cross the path.
+func SearchPathMTU(lower, upper int, probe ProbeFunc) (mtu int, found bool, err error) {
+	if lower > upper {
+		return 0, false, fmt.Errorf("invalid MTU bounds: lower bound %d is greater than upper bound %d", lower, upper)
+	}
+
+	// The upper bound is probed first, as it is expected to be the common case.
+	if ok, err := probe(upper); err != nil || ok {
+		return upper, ok, err
+	}
+	if ok, err := probe(lower); err != nil || !ok {
+		return lower, false, err
+	}
+
+	// Invariant: the lower bound crosses the path, while the upper one does not.
+	for upper-lower > 1 {
+		mid := lower + (upper-lower)/2
+		ok, err := probe(mid)
+		if err != nil {
+			return lower, false, err
+		}
+		if ok {
+			lower = mid
+		} else {
+			upper = mid
+		}
+	}
+	return lower, true, nil
+}
+
+// MTUProber probes the MTU of the path towards a peer from a dedicated socket, so that the DF bit is set only on the probes,
+// and their acknowledgments are not mixed with the messages handled by the receiver.
+type MTUProber struct {
+	ctx       context.Context
+	clusterID string
+	conn      *net.UDPConn
+	opts      *Options
+	buff      []byte
+}
+
+// NewMTUProber returns an MTUProber towards the peer with clusterID, which must be closed once the discovery completes.
+func (c *ConnChecker) NewMTUProber(clusterID string) (*MTUProber, error) {
+	c.sm.RLock()
+	sender, ok := c.senders[clusterID]
+	c.sm.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("sender %s not found", clusterID)
+	}
+
+	conn, err := net.DialUDP("udp", nil, &sender.raddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the MTU probes socket towards %s: %w", sender.raddr.String(), err)
+	}
+	if err := setDontFragment(conn, sender.raddr.IP); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to set the DF bit on the MTU probes socket towards %s: %w", sender.raddr.String(), err)
+	}
+	return &MTUProber{
+		ctx:       sender.Ctx,
+		clusterID: clusterID,
+		conn:      conn,
+		opts:      c.opts,
+		buff:      make([]byte, c.opts.PingBufferSize),
+	}, nil
+}
+
+// RemoteIP returns the IP of the probed peer.
+func (p *MTUProber) RemoteIP() net.IP {
+	return p.conn.RemoteAddr().(*net.UDPAddr).IP
+}
+
+// Close closes the socket of the prober.
+func (p *MTUProber) Close() error {
+	return p.conn.Close()
+}
+
+// Probe sends MTU probes of the given size to the peer, and returns whether they are acknowledged.
+// The probe is retransmitted up to the configured number of retries, to tell apart the loss of a packet from an MTU issue.
+func (p *MTUProber) Probe(size int) (bool, error) {
+	msg, b, err := forgeMTUProbe(p.clusterID, size, p.RemoteIP())
+	if err != nil {
+		return false, err
+	}
+
+	for range p.opts.MTUProbeRetries + 1 {
+		if _, err := p.conn.Write(b); err != nil {
+			// The probe is larger than the MTU of the route towards the peer.
+			if errors.Is(err, syscall.EMSGSIZE) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to write an MTUPROBE to %s: %w", p.conn.RemoteAddr().String(), err)
+		}
+		klog.V(8).Infof("conncheck MTU prober: sent an MTUPROBE -> %s", msg)
+
+		if acked, err := p.waitAck(size); err != nil || acked {
+			return acked, err
+		}
+	}
+	return false, nil
+}
+
+// waitAck waits for the acknowledgment of an MTU probe of the given size, discarding the ones of previous probes.
+func (p *MTUProber) waitAck(size int) (bool, error) {
+	if err := p.conn.SetReadDeadline(time.Now().Add(p.opts.MTUProbeTimeout)); err != nil {
+		return false, err
+	}
+	for {
+		if err := p.ctx.Err(); err != nil {
+			return false, err
+		}
+
+		n, err := p.conn.Read(p.buff)
+		switch {
+		case errors.Is(err, os.ErrDeadlineExceeded), errors.Is(err, syscall.EMSGSIZE):
+			// The probe has been lost, or it has been dropped by a hop notifying a smaller MTU.
+			return false, nil
+		case err != nil:
+			return false, fmt.Errorf("failed to read from %s: %w", p.conn.RemoteAddr().String(), err)
+		}
+
+		msg := &Msg{}
+		if err := json.Unmarshal(p.buff[:n], msg); err != nil {
+			klog.Errorf("conncheck MTU prober: failed to unmarshal msg: %v", err)
+			continue
+		}
+		if msg.MsgType == MTUACK && msg.Size == size {
+			klog.V(8).Infof("conncheck MTU prober: received an MTUACK -> %s", msg)
+			return true, nil
+		}
+	}
+}
+
+// forgeMTUProbe returns an MTU probe, together with its encoding padded so that the IP packet carrying it
+// towards the given destination has the given size.
+func forgeMTUProbe(clusterID string, size int, dst net.IP) (*Msg, []byte, error) {
+	msg := Msg{ClusterID: clusterID, MsgType: MTUPROBE, TimeStamp: time.Now(), Size: size, Padding: "x"}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal msg: %w", err)
+	}
+
+	padding := size - ipHeaderSize(dst) - udpHeaderSize - len(b) + len(msg.Padding)
+	if padding < 1 {
+		return nil, nil, fmt.Errorf("MTU probe size %d is too small", size)
+	}
+	msg.Padding = strings.Repeat("x", padding)
+	if b, err = json.Marshal(msg); err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal msg: %w", err)
+	}
+	return &msg, b, nil
+}
+
+// SendMTUAck acknowledges the reception of an MTU probe.
+func (r *Receiver) SendMTUAck(raddr *net.UDPAddr, msg *Msg) error {
+	msg.MsgType = MTUACK
+	msg.Padding = ""
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal msg: %w", err)
+	}
+	if _, err = r.conn.WriteToUDP(b, raddr); err != nil {
+		return fmt.Errorf("failed to write to %s: %w", raddr.String(), err)
+	}
+	klog.V(8).Infof("conncheck receiver: sent an MTUACK -> %s", msg)
+	return nil
+}
+
+// ipHeaderSize returns the size of the header of the IP packets towards the given destination.
+func ipHeaderSize(dst net.IP) int {
+	if dst.To4() != nil {
+		return ipv4HeaderSize
+	}
+	return ipv6HeaderSize
+}
+
+// setDontFragment configures the socket to set the DF bit on the packets it sends towards the given destination,
+// and to return an error instead of fragmenting the ones larger than the MTU of the route towards it.
+// The MTU of the route is used instead of the one of the interface, so that the probes can be larger than the latter
+// when the route allows it.
+func setDontFragment(conn *net.UDPConn, dst net.IP) error {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	level, option, value := unix.IPPROTO_IP, unix.IP_MTU_DISCOVER, unix.IP_PMTUDISC_DO
+	if dst.To4() == nil {
+		level, option, value = unix.IPPROTO_IPV6, unix.IPV6_MTU_DISCOVER, unix.IPV6_PMTUDISC_DO
+	}
+	var sockErr error
+	if err := raw.Control(func(fd uintptr) {
+		sockErr = unix.SetsockoptInt(int(fd), level, option, value)
+	}); err != nil || sockErr != nil {
+		return errors.Join(err, sockErr)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conncheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Path MTU discovery", func() {
+	// forgePath returns a probe function emulating a path with the given MTU, which records the probed sizes.
+	forgePath := func(pathMTU int, probed *[]int) ProbeFunc {
+		return func(size int) (bool, error) {
+			*probed = append(*probed, size)
+			return size <= pathMTU, nil
+		}
+	}
+
+	Context("Searching the path MTU", func() {
+		var probed []int
+
+		BeforeEach(func() { probed = nil })
+
+		It("should return the upper bound with a single probe if supported", func() {
+			mtu, found, err := SearchPathMTU(1280, 1420, forgePath(1500, &probed))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(mtu).To(Equal(1420))
+			Expect(probed).To(ConsistOf(1420))
+		})
+
+		It("should find the path MTU between the bounds", func() {
+			for _, pathMTU := range []int{1280, 1281, 1337, 1419} {
+				mtu, found, err := SearchPathMTU(1280, 1420, forgePath(pathMTU, &probed))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(mtu).To(Equal(pathMTU))
+			}
+		})
+
+		It("should return the lower bound if not supported", func() {
+			mtu, found, err := SearchPathMTU(1280, 1420, forgePath(1000, &probed))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(mtu).To(Equal(1280))
+		})
+
+		It("should fail with invalid bounds", func() {
+			_, _, err := SearchPathMTU(1420, 1280, forgePath(1500, &probed))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should propagate the errors of the probes", func() {
+			_, _, err := SearchPathMTU(1280, 1420, func(int) (bool, error) { return false, errors.New("probe failed") })
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Forging the MTU probes", func() {
+		DescribeTable("should pad the probes to the requested size",
+			func(dst string, headerSize int) {
+				for _, size := range []int{1280, 1340, 1500, 9000} {
+					msg, b, err := forgeMTUProbe("cluster-id", size, net.ParseIP(dst))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(b) + headerSize + udpHeaderSize).To(Equal(size))
+
+					decoded := &Msg{}
+					Expect(json.Unmarshal(b, decoded)).To(Succeed())
+					Expect(decoded.MsgType).To(Equal(MTUPROBE))
+					Expect(decoded.Size).To(Equal(size))
+					Expect(decoded.Padding).To(Equal(msg.Padding))
+				}
+			},
+			Entry("IPv4 destination", "10.80.0.2", ipv4HeaderSize),
+			Entry("IPv6 destination", "fd00::2", ipv6HeaderSize),
+		)
+
+		It("should fail if the size cannot fit the message", func() {
+			_, _, err := forgeMTUProbe("cluster-id", 64, net.ParseIP("10.80.0.2"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Probing the MTU of a path", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+			cc     *ConnChecker
+		)
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+
+			// The conncheck acknowledges the probes sent to itself through the loopback interface.
+			listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+			Expect(err).ToNot(HaveOccurred())
+			port := listener.LocalAddr().(*net.UDPAddr).Port
+			Expect(listener.Close()).To(Succeed())
+
+			cc, err = NewConnChecker(&Options{
+				PingPort:        port,
+				PingBufferSize:  1024,
+				MTUProbeTimeout: 100 * time.Millisecond,
+				MTUProbeRetries: 1,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(cc.conn.Close)
+			go cc.RunReceiver(ctx)
+
+			Expect(cc.AddSender(ctx, "cluster-id", "127.0.0.1", func(bool, time.Duration, time.Time, Quality) error { return nil })).To(Succeed())
+		})
+
+		It("should report the acknowledged probes as crossing the path", func() {
+			prober, err := cc.NewMTUProber("cluster-id")
+			Expect(err).ToNot(HaveOccurred())
+			defer prober.Close()
+
+			Expect(prober.RemoteIP().String()).To(Equal("127.0.0.1"))
+			Expect(prober.Probe(1280)).To(BeTrue())
+			Expect(prober.Probe(9000)).To(BeTrue())
+		})
+
+		It("should report the probes which cannot be sent as not crossing the path", func() {
+			prober, err := cc.NewMTUProber("cluster-id")
+			Expect(err).ToNot(HaveOccurred())
+			defer prober.Close()
+
+			Expect(prober.Probe(maxMsgSize + 1)).To(BeFalse())
+		})
+
+		It("should fail for an unknown peer", func() {
+			_, err := cc.NewMTUProber("unknown")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
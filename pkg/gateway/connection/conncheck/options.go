@@ -26,6 +26,12 @@ type Options struct {
 	PingLossThreshold uint
 	// PingInterval is the interval at which the ping is sent.
 	PingInterval time.Duration
+	// QualityWindowSize is the number of pings the quality of the connection (packet loss and jitter) is computed on.
+	QualityWindowSize uint
+	// MTUProbeTimeout is the time waited for the acknowledgment of an MTU probe.
+	MTUProbeTimeout time.Duration
+	// MTUProbeRetries is the number of times an MTU probe is retransmitted before considering the size as not supported.
+	MTUProbeRetries uint
 }
 
 // NewOptions returns a new Options struct.
@@ -0,0 +1,111 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conncheck
+
+import (
+	"sort"
+	"time"
+)
+
+// Quality represents the quality of a connection, measured over a sliding window of pings.
+type Quality struct {
+	// PacketLoss is the ratio (between 0 and 1) of pings which did not receive a PONG.
+	PacketLoss float64
+	// Jitter is the mean absolute difference between the round-trip latencies of consecutive pings.
+	Jitter time.Duration
+	// Samples is the number of pings the quality has been computed on. If zero, the quality is not available.
+	Samples int
+}
+
+// Available returns whether the quality has been computed on at least one sample.
+func (q Quality) Available() bool {
+	return q.Samples > 0
+}
+
+type qualitySample struct {
+	seq      uint64
+	sentAt   time.Time
+	rtt      time.Duration
+	received bool
+}
+
+// qualityWindow tracks the outcome of the last pings sent to a peer, indexed by their sequence number.
+type qualityWindow struct {
+	samples []qualitySample
+}
+
+func newQualityWindow(size uint) *qualityWindow {
+	if size == 0 {
+		size = 1
+	}
+	return &qualityWindow{samples: make([]qualitySample, size)}
+}
+
+// recordSent records that the ping with the given sequence number has been sent.
+func (w *qualityWindow) recordSent(seq uint64, sentAt time.Time) {
+	w.samples[seq%uint64(len(w.samples))] = qualitySample{seq: seq, sentAt: sentAt}
+}
+
+// recordReceived records that the PONG for the ping with the given sequence number has been received.
+// PONGs related to pings no longer in the window are ignored.
+func (w *qualityWindow) recordReceived(seq uint64, rtt time.Duration) {
+	sample := &w.samples[seq%uint64(len(w.samples))]
+	if sample.seq != seq || sample.sentAt.IsZero() {
+		return
+	}
+	sample.received = true
+	sample.rtt = rtt
+}
+
+// quality computes the quality over the current window. The pings sent less than pendingTimeout ago,
+// and not answered yet, are considered still in flight and are not accounted.
+func (w *qualityWindow) quality(now time.Time, pendingTimeout time.Duration) Quality {
+	var q Quality
+	var lost int
+	var received []*qualitySample
+	for i := range w.samples {
+		sample := &w.samples[i]
+		switch {
+		case sample.sentAt.IsZero():
+			continue
+		case sample.received:
+			received = append(received, sample)
+		case now.Sub(sample.sentAt) < pendingTimeout:
+			continue
+		default:
+			lost++
+		}
+		q.Samples++
+	}
+
+	if q.Samples == 0 {
+		return q
+	}
+	q.PacketLoss = float64(lost) / float64(q.Samples)
+
+	if len(received) > 1 {
+		sort.Slice(received, func(i, j int) bool { return received[i].seq < received[j].seq })
+		var total time.Duration
+		for i := 1; i < len(received); i++ {
+			diff := received[i].rtt - received[i-1].rtt
+			if diff < 0 {
+				diff = -diff
+			}
+			total += diff
+		}
+		q.Jitter = total / time.Duration(len(received)-1)
+	}
+	return q
+}
@@ -0,0 +1,87 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conncheck
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quality window", func() {
+	var (
+		window *qualityWindow
+		start  time.Time
+	)
+
+	BeforeEach(func() {
+		window = newQualityWindow(4)
+		start = time.Now()
+	})
+
+	It("should not be available without samples", func() {
+		Expect(window.quality(start, time.Second).Available()).To(BeFalse())
+	})
+
+	It("should not account the pings still in flight", func() {
+		window.recordSent(1, start)
+		window.recordSent(2, start.Add(time.Second))
+		window.recordReceived(1, 10*time.Millisecond)
+
+		quality := window.quality(start.Add(1500*time.Millisecond), time.Second)
+		Expect(quality.Samples).To(Equal(1))
+		Expect(quality.PacketLoss).To(BeZero())
+	})
+
+	It("should compute the packet loss and the jitter", func() {
+		rtts := map[uint64]time.Duration{1: 10 * time.Millisecond, 2: 30 * time.Millisecond, 4: 20 * time.Millisecond}
+		for seq := uint64(1); seq <= 4; seq++ {
+			window.recordSent(seq, start.Add(time.Duration(seq)*time.Second))
+			if rtt, ok := rtts[seq]; ok {
+				window.recordReceived(seq, rtt)
+			}
+		}
+
+		quality := window.quality(start.Add(10*time.Second), time.Second)
+		Expect(quality.Samples).To(Equal(4))
+		Expect(quality.PacketLoss).To(BeNumerically("~", 0.25))
+		// |30-10| and |20-30|, averaged.
+		Expect(quality.Jitter).To(Equal(15 * time.Millisecond))
+	})
+
+	It("should only consider the last pings", func() {
+		for seq := uint64(1); seq <= 8; seq++ {
+			window.recordSent(seq, start.Add(time.Duration(seq)*time.Second))
+			if seq > 4 {
+				window.recordReceived(seq, 10*time.Millisecond)
+			}
+		}
+
+		quality := window.quality(start.Add(10*time.Second), time.Second)
+		Expect(quality.Samples).To(Equal(4))
+		Expect(quality.PacketLoss).To(BeZero())
+		Expect(quality.Jitter).To(BeZero())
+	})
+
+	It("should ignore the PONGs of the pings no longer in the window", func() {
+		window.recordSent(5, start)
+		window.recordReceived(1, 10*time.Millisecond)
+
+		quality := window.quality(start.Add(10*time.Second), time.Second)
+		Expect(quality.Samples).To(Equal(1))
+		Expect(quality.PacketLoss).To(Equal(1.0))
+	})
+})
@@ -33,6 +33,18 @@ type Peer struct {
 	// lastReceivedTimestamp is the timestamp when the last received PING has been sent.
 	lastReceivedTimestamp time.Time
 	updateCallback        UpdateFunc
+	// window tracks the outcome of the last pings, to compute the quality of the connection.
+	window *qualityWindow
+	// legacy is set when the peer does not echo the sequence number of the pings, hence the quality cannot be computed.
+	legacy bool
+}
+
+// quality returns the quality of the connection with the peer.
+func (p *Peer) quality(now time.Time, pendingTimeout time.Duration) Quality {
+	if p.legacy {
+		return Quality{}
+	}
+	return p.window.quality(now, pendingTimeout)
 }
 
 // Receiver is a receiver for conncheck messages.
@@ -48,7 +60,7 @@ type Receiver struct {
 func NewReceiver(conn *net.UDPConn, opts *Options) *Receiver {
 	return &Receiver{
 		peers: make(map[string]*Peer),
-		buff:  make([]byte, opts.PingBufferSize),
+		buff:  make([]byte, max(opts.PingBufferSize, maxMsgSize)), // The buffer must also fit the MTU probes.
 		conn:  conn,
 		opts:  opts,
 	}
@@ -82,8 +94,13 @@ func (r *Receiver) ReceivePong(msg *Msg) error {
 		peer.lastReceivedTimestamp = msg.TimeStamp
 		peer.latency = now.Sub(msg.TimeStamp)
 		peer.connected = true
+		if msg.Seq == 0 {
+			peer.legacy = true
+		} else {
+			peer.window.recordReceived(msg.Seq, peer.latency)
+		}
 
-		err := peer.updateCallback(true, peer.latency, now)
+		err := peer.updateCallback(true, peer.latency, now, peer.quality(now, r.opts.PingInterval))
 		if err != nil {
 			return fmt.Errorf("failed to update peer %s: %w", msg.ClusterID, err)
 		}
@@ -101,10 +118,20 @@ func (r *Receiver) InitPeer(clusterID string, updateCallback UpdateFunc) error {
 		latency:               0,
 		lastReceivedTimestamp: time.Now(),
 		updateCallback:        updateCallback,
+		window:                newQualityWindow(r.opts.QualityWindowSize),
 	}
 	return nil
 }
 
+// RecordPing records that a PING has been sent to the given peer.
+func (r *Receiver) RecordPing(msg *Msg) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if peer, ok := r.peers[msg.ClusterID]; ok {
+		peer.window.recordSent(msg.Seq, msg.TimeStamp)
+	}
+}
+
 // Run starts the receiver.
 func (r *Receiver) Run(ctx context.Context) {
 	klog.Infof("conncheck receiver: started")
@@ -128,6 +155,9 @@ func (r *Receiver) Run(ctx context.Context) {
 		case PONG:
 			klog.V(8).Infof("conncheck receiver: received a PONG from %s  -> %s", raddr, msgr)
 			err = r.ReceivePong(msgr)
+		case MTUPROBE:
+			klog.V(8).Infof("conncheck receiver: received an MTUPROBE from %s -> %s", raddr, msgr)
+			err = r.SendMTUAck(raddr, msgr)
 		}
 		if err != nil {
 			klog.Errorf("conncheck receiver: %v", err)
@@ -154,7 +184,7 @@ func (r *Receiver) RunDisconnectObserver(ctx context.Context) {
 				klog.V(8).Infof("conncheck receiver: %s unreachable", id)
 				peer.connected = false
 				peer.latency = 0
-				err := peer.updateCallback(false, 0, time.Time{})
+				err := peer.updateCallback(false, 0, time.Time{}, Quality{})
 				if err != nil {
 					klog.Errorf("conncheck receiver: failed to update peer %s: %s", peer.lastReceivedTimestamp, err)
 				}
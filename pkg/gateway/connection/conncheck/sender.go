@@ -31,6 +31,7 @@ type Sender struct {
 	cancel    func()
 	conn      *net.UDPConn
 	raddr     net.UDPAddr
+	seq       uint64
 }
 
 // NewSender creates a new conncheck sender.
@@ -48,17 +49,18 @@ func NewSender(ctx context.Context, opts *Options, clusterID string, cancel func
 	}, nil
 }
 
-// SendPing sends a PING message to the given address.
-func (s *Sender) SendPing() error {
-	msgOut := Msg{ClusterID: s.clusterID, MsgType: PING, TimeStamp: time.Now()}
+// SendPing sends a PING message to the given address, and returns the message sent.
+func (s *Sender) SendPing() (*Msg, error) {
+	s.seq++
+	msgOut := Msg{ClusterID: s.clusterID, MsgType: PING, TimeStamp: time.Now(), Seq: s.seq}
 	b, err := json.Marshal(msgOut)
 	if err != nil {
-		return fmt.Errorf("conncheck sender: failed to marshal msg: %w", err)
+		return nil, fmt.Errorf("conncheck sender: failed to marshal msg: %w", err)
 	}
 	_, err = s.conn.WriteToUDP(b, &s.raddr)
 	if err != nil {
-		return fmt.Errorf("conncheck sender: failed to write to %s: %w", s.raddr.String(), err)
+		return nil, fmt.Errorf("conncheck sender: failed to write to %s: %w", s.raddr.String(), err)
 	}
 	klog.V(8).Infof("conncheck sender: sent a PING -> %s", msgOut)
-	return nil
+	return &msgOut, nil
 }
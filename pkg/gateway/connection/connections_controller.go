@@ -31,6 +31,7 @@ import (
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway/concurrent"
 	"github.com/liqotech/liqo/pkg/gateway/connection/conncheck"
 	"github.com/liqotech/liqo/pkg/gateway/tunnel"
 )
@@ -98,8 +99,12 @@ func (r *ConnectionsReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 
 		go r.ConnChecker.RunSender(r.Options.GwOptions.RemoteClusterID)
+
+		if r.Options.MTUDiscoveryEnabled {
+			go RunMTUDiscovery(ctx, r.Client, r.Options, r.ConnChecker, req.NamespacedName)
+		}
 	case false:
-		if err := updateConnection(true, 0, time.Time{}); err != nil {
+		if err := updateConnection(true, 0, time.Time{}, conncheck.Quality{}); err != nil {
 			return ctrl.Result{}, fmt.Errorf("unable to update the connection status: %w", err)
 		}
 	}
@@ -124,11 +129,17 @@ func (r *ConnectionsReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // ForgeUpdateConnectionCallback forges the UpdateConnectionStatus function.
 func ForgeUpdateConnectionCallback(ctx context.Context, cl client.Client, opts *Options, req ctrl.Request) conncheck.UpdateFunc {
-	return func(connected bool, latency time.Duration, timestamp time.Time) error {
+	return func(connected bool, latency time.Duration, timestamp time.Time, quality conncheck.Quality) error {
 		connection := &networkingv1beta1.Connection{}
 		if err := cl.Get(ctx, req.NamespacedName, connection); err != nil {
 			return err
 		}
+		if opts.GwOptions.ActiveActive {
+			skip, err := handleActiveActiveReplicaStatus(ctx, cl, opts, connected)
+			if err != nil || skip {
+				return err
+			}
+		}
 		var connStatusValue networkingv1beta1.ConnectionStatusValue
 		switch connected {
 		case true:
@@ -136,6 +147,31 @@ func ForgeUpdateConnectionCallback(ctx context.Context, cl client.Client, opts *
 		case false:
 			connStatusValue = networkingv1beta1.ConnectionError
 		}
-		return UpdateConnectionStatus(ctx, cl, opts, connection, connStatusValue, latency, timestamp)
+		return UpdateConnectionStatus(ctx, cl, opts, connection, connStatusValue, latency, timestamp, quality)
+	}
+}
+
+// handleActiveActiveReplicaStatus labels the current replica with the status of its tunnel, so that it is included in
+// (or removed from) the multipath routes towards the gateway. It returns whether the update of the Connection resource
+// must be skipped, since a disconnected replica must not mark the whole connection as failed while other replicas are connected.
+func handleActiveActiveReplicaStatus(ctx context.Context, cl client.Client, opts *Options, connected bool) (skip bool, err error) {
+	podKey := client.ObjectKey{Namespace: opts.GwOptions.Namespace, Name: opts.GwOptions.PodName}
+	if err := concurrent.SetConnectedGatewayLabel(ctx, cl, podKey, connected); err != nil {
+		return false, fmt.Errorf("unable to update the connected label of pod %q: %w", podKey, err)
+	}
+
+	if connected {
+		return false, nil
+	}
+
+	pods, err := concurrent.ListAllGatewaysReplicas(ctx, cl, opts.GwOptions.Namespace, opts.GwOptions.Name)
+	if err != nil {
+		return false, fmt.Errorf("unable to list the gateway replicas: %w", err)
+	}
+	for i := range pods {
+		if pods[i].Name != opts.GwOptions.PodName && concurrent.IsConnectedGateway(&pods[i]) {
+			return true, nil
+		}
 	}
+	return false, nil
 }
@@ -18,6 +18,8 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
 )
 
 // FlagName is the type for the name of the flags.
@@ -42,6 +44,20 @@ const (
 	PingIntervalFlag FlagName = "ping-interval"
 	// PingUpdateStatusIntervalFlag is the name of the flag used to set the ping update status interval.
 	PingUpdateStatusIntervalFlag FlagName = "ping-update-status-interval"
+	// PingQualityWindowFlag is the name of the flag used to set the number of pings the connection quality is computed on.
+	PingQualityWindowFlag FlagName = "ping-quality-window"
+	// MTUDiscoveryEnabledFlag is the name of the flag used to enable the discovery of the path MTU.
+	MTUDiscoveryEnabledFlag FlagName = "mtu-discovery-enabled"
+	// MTUDiscoveryMinFlag is the name of the flag used to set the lower bound of the discovered MTU.
+	MTUDiscoveryMinFlag FlagName = "mtu-discovery-min"
+	// MTUDiscoveryMaxFlag is the name of the flag used to set the upper bound of the discovered MTU.
+	MTUDiscoveryMaxFlag FlagName = "mtu-discovery-max"
+	// MTUDiscoveryIntervalFlag is the name of the flag used to set the interval between two discoveries of the MTU.
+	MTUDiscoveryIntervalFlag FlagName = "mtu-discovery-interval"
+	// MTUProbeTimeoutFlag is the name of the flag used to set the time waited for the acknowledgment of an MTU probe.
+	MTUProbeTimeoutFlag FlagName = "mtu-probe-timeout"
+	// MTUProbeRetriesFlag is the name of the flag used to set the number of retransmissions of an MTU probe.
+	MTUProbeRetriesFlag FlagName = "mtu-probe-retries"
 )
 
 // InitFlags initializes the flags for the wireguard tunnel.
@@ -60,4 +76,18 @@ func InitFlags(flagset *pflag.FlagSet, options *Options) {
 		"ping-interval is the interval between two connection checks")
 	flagset.DurationVar(&options.PingUpdateStatusInterval, PingUpdateStatusIntervalFlag.String(), 10*time.Second,
 		"ping-update-status-interval is the interval at which the status is updated")
+	flagset.UintVar(&options.ConnCheckOptions.QualityWindowSize, PingQualityWindowFlag.String(), 60,
+		"ping-quality-window is the number of pings the quality of the connection (packet loss and jitter) is computed on")
+	flagset.BoolVar(&options.MTUDiscoveryEnabled, MTUDiscoveryEnabledFlag.String(), false,
+		"mtu-discovery-enabled enables the discovery of the MTU of the path through the tunnel, which is adjusted accordingly. Requires the ping check.")
+	flagset.IntVar(&options.MTUDiscoveryMin, MTUDiscoveryMinFlag.String(), 1280,
+		"mtu-discovery-min is the lower bound of the discovered MTU")
+	flagset.IntVar(&options.MTUDiscoveryMax, MTUDiscoveryMaxFlag.String(), forge.DefaultMTU,
+		"mtu-discovery-max is the upper bound of the discovered MTU")
+	flagset.DurationVar(&options.MTUDiscoveryInterval, MTUDiscoveryIntervalFlag.String(), 5*time.Minute,
+		"mtu-discovery-interval is the interval between two discoveries of the MTU")
+	flagset.DurationVar(&options.ConnCheckOptions.MTUProbeTimeout, MTUProbeTimeoutFlag.String(), time.Second,
+		"mtu-probe-timeout is the time waited for the acknowledgment of an MTU probe")
+	flagset.UintVar(&options.ConnCheckOptions.MTUProbeRetries, MTUProbeRetriesFlag.String(), 2,
+		"mtu-probe-retries is the number of retransmissions of an MTU probe before considering its size as not supported")
 }
@@ -17,20 +17,32 @@ package connection
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/gateway/connection/conncheck"
 	timeutils "github.com/liqotech/liqo/pkg/utils/time"
 )
 
+const (
+	qualityThresholdsExceededReason = "QualityThresholdsExceeded"
+	qualityWithinThresholdsReason   = "QualityWithinThresholds"
+	qualityWithinThresholdsMessage  = "The quality of the connection is within the configured thresholds"
+	connectionDownReason            = "ConnectionDown"
+	connectionDownMessage           = "The connection is down"
+)
+
 // UpdateConnectionStatus updates the status of a connection.
 func UpdateConnectionStatus(ctx context.Context, cl client.Client, opts *Options, connection *networkingv1beta1.Connection,
-	value networkingv1beta1.ConnectionStatusValue, latency time.Duration, timestamp time.Time) error {
-	if connection.Status.Value != value ||
+	value networkingv1beta1.ConnectionStatusValue, latency time.Duration, timestamp time.Time, quality conncheck.Quality) error {
+	conditionChanged := ensureDegradedCondition(connection, value, latency, quality)
+	if connection.Status.Value != value || conditionChanged ||
 		timestamp.Sub(connection.Status.Latency.Timestamp.Time) > opts.PingUpdateStatusInterval {
 		if connection.Status.Value != value {
 			klog.Infof("changing connection %q status to %q",
@@ -40,6 +52,14 @@ func UpdateConnectionStatus(ctx context.Context, cl client.Client, opts *Options
 			Value:     timeutils.FormatLatency(latency),
 			Timestamp: metav1.NewTime(timestamp),
 		}
+		connection.Status.Quality = networkingv1beta1.ConnectionQuality{}
+		if quality.Available() {
+			connection.Status.Quality = networkingv1beta1.ConnectionQuality{
+				PacketLoss: FormatPacketLoss(quality.PacketLoss),
+				Jitter:     timeutils.FormatLatency(quality.Jitter),
+				Timestamp:  metav1.NewTime(timestamp),
+			}
+		}
 		connection.Status.Value = value
 		if err := cl.Status().Update(ctx, connection); err != nil {
 			return fmt.Errorf("unable to update connection %q: %w",
@@ -48,3 +68,57 @@ func UpdateConnectionStatus(ctx context.Context, cl client.Client, opts *Options
 	}
 	return nil
 }
+
+// FormatPacketLoss formats a packet loss ratio as a percentage.
+func FormatPacketLoss(ratio float64) string {
+	return fmt.Sprintf("%.1f%%", ratio*100)
+}
+
+// ensureDegradedCondition sets the Degraded condition of the connection, comparing the measured quality
+// with the configured thresholds. It returns whether the condition has changed.
+func ensureDegradedCondition(connection *networkingv1beta1.Connection, value networkingv1beta1.ConnectionStatusValue,
+	latency time.Duration, quality conncheck.Quality) bool {
+	thresholds := connection.Spec.QualityThresholds
+	if thresholds == nil {
+		return meta.RemoveStatusCondition(&connection.Status.Conditions, networkingv1beta1.ConnectionDegradedCondition)
+	}
+
+	condition := metav1.Condition{
+		Type:               networkingv1beta1.ConnectionDegradedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             qualityWithinThresholdsReason,
+		Message:            qualityWithinThresholdsMessage,
+		ObservedGeneration: connection.Generation,
+	}
+	if value != networkingv1beta1.Connected {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = connectionDownReason
+		condition.Message = connectionDownMessage
+	} else if violations := CheckQualityThresholds(thresholds, latency, quality); len(violations) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = qualityThresholdsExceededReason
+		condition.Message = strings.Join(violations, ", ")
+	}
+	return meta.SetStatusCondition(&connection.Status.Conditions, condition)
+}
+
+// CheckQualityThresholds returns the list of the thresholds exceeded by the given latency and quality.
+// The messages do not include the measured values, which are reported in the status, to avoid continuous updates
+// of the condition. The packet loss and jitter thresholds are ignored if the quality is not available.
+func CheckQualityThresholds(thresholds *networkingv1beta1.ConnectionQualityThresholds,
+	latency time.Duration, quality conncheck.Quality) []string {
+	var violations []string
+	if thresholds.MaxLatency != nil && latency > thresholds.MaxLatency.Duration {
+		violations = append(violations, fmt.Sprintf("latency exceeds %s", thresholds.MaxLatency.Duration))
+	}
+	if !quality.Available() {
+		return violations
+	}
+	if thresholds.MaxPacketLossPercentage != nil && quality.PacketLoss*100 > float64(*thresholds.MaxPacketLossPercentage) {
+		violations = append(violations, fmt.Sprintf("packet loss exceeds %d%%", *thresholds.MaxPacketLossPercentage))
+	}
+	if thresholds.MaxJitter != nil && quality.Jitter > thresholds.MaxJitter.Duration {
+		violations = append(violations, fmt.Sprintf("jitter exceeds %s", thresholds.MaxJitter.Duration))
+	}
+	return violations
+}
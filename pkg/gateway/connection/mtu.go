@@ -0,0 +1,154 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/gateway/connection/conncheck"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+)
+
+// RunMTUDiscovery periodically discovers the MTU of the path through the tunnel towards the remote cluster,
+// setting it on the tunnel interface and reporting it in the status of the given connection.
+func RunMTUDiscovery(ctx context.Context, cl client.Client, opts *Options, cc *conncheck.ConnChecker, key client.ObjectKey) {
+	clusterID := opts.GwOptions.RemoteClusterID
+	klog.Infof("MTU discovery towards %q started (bounds: %d-%d)", clusterID, opts.MTUDiscoveryMin, opts.MTUDiscoveryMax)
+
+	for {
+		// While the connection is not established, the discovery is retried at the pace of the pings.
+		delay := opts.ConnCheckOptions.PingInterval
+		connected, err := cc.GetConnected(clusterID)
+		switch {
+		case err != nil:
+			// The sender has been removed, hence the discovery must stop as well.
+			klog.Infof("MTU discovery towards %q stopped: %v", clusterID, err)
+			return
+		case connected:
+			delay = opts.MTUDiscoveryInterval
+			mtu, err := discoverMTU(opts, cc)
+			if err != nil {
+				klog.Errorf("Unable to discover the MTU towards %q: %v", clusterID, err)
+				break
+			}
+			if err := updateConnectionPathMTU(ctx, cl, key, mtu); err != nil {
+				klog.Errorf("Unable to report the MTU towards %q: %v", clusterID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// discoverMTU probes the path through the tunnel and sets the discovered MTU on the tunnel interface.
+// The probes are routed through a dedicated host route towards the peer, whose MTU is the upper bound: this way, they can
+// be larger than the MTU of the tunnel interface, which keeps carrying the traffic with the previous MTU while probing.
+func discoverMTU(opts *Options, cc *conncheck.ConnChecker) (int, error) {
+	link, err := tunnel.GetLink(tunnel.TunnelInterfaceName)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get the tunnel interface: %w", err)
+	}
+
+	prober, err := cc.NewMTUProber(opts.GwOptions.RemoteClusterID)
+	if err != nil {
+		return 0, err
+	}
+	defer prober.Close()
+
+	if err := ensureProbeRoute(link, prober.RemoteIP(), opts.MTUDiscoveryMax); err != nil {
+		return 0, err
+	}
+
+	mtu, found, err := conncheck.SearchPathMTU(opts.MTUDiscoveryMin, opts.MTUDiscoveryMax, prober.Probe)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		klog.Warningf("The packets of the lower bound size (%d) do not cross the tunnel towards %q, using it anyway",
+			opts.MTUDiscoveryMin, opts.GwOptions.RemoteClusterID)
+	}
+
+	current := link.Attrs().MTU
+	if err := setLinkMTU(link, mtu); err != nil {
+		return 0, err
+	}
+	if mtu != current {
+		klog.Infof("MTU of the tunnel towards %q changed from %d to %d", opts.GwOptions.RemoteClusterID, current, mtu)
+	}
+	return mtu, nil
+}
+
+// ensureProbeRoute ensures that the traffic towards the given IP is routed through the given link, with the given MTU.
+// The route only matches the peer address, hence it does not affect the MTU of the traffic towards the remote cluster.
+func ensureProbeRoute(link netlink.Link, ip net.IP, mtu int) error {
+	bits := net.IPv6len * 8
+	if ip.To4() != nil {
+		ip, bits = ip.To4(), net.IPv4len*8
+	}
+	route := &netlink.Route{
+		LinkIndex: link.Attrs().Index,
+		Dst:       &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)},
+		Scope:     netlink.SCOPE_LINK,
+		MTU:       mtu,
+	}
+	if err := netlink.RouteReplace(route); err != nil {
+		return fmt.Errorf("unable to set the route towards %s for the MTU probes: %w", ip.String(), err)
+	}
+	return nil
+}
+
+// setLinkMTU sets the MTU of the given link, if different from the current one.
+func setLinkMTU(link netlink.Link, mtu int) error {
+	if link.Attrs().MTU == mtu {
+		return nil
+	}
+	if err := netlink.LinkSetMTU(link, mtu); err != nil {
+		return fmt.Errorf("unable to set the MTU of interface %q to %d: %w", link.Attrs().Name, mtu, err)
+	}
+	link.Attrs().MTU = mtu
+	return nil
+}
+
+// updateConnectionPathMTU reports the discovered MTU in the status of the connection, if changed.
+func updateConnectionPathMTU(ctx context.Context, cl client.Client, key client.ObjectKey, mtu int) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		connection := &networkingv1beta1.Connection{}
+		if err := cl.Get(ctx, key, connection); err != nil {
+			return err
+		}
+		if connection.Status.PathMTU != nil && connection.Status.PathMTU.Value == mtu {
+			return nil
+		}
+		connection.Status.PathMTU = &networkingv1beta1.ConnectionPathMTU{
+			Value:     mtu,
+			Timestamp: metav1.NewTime(time.Now()),
+		}
+		return cl.Status().Update(ctx, connection)
+	})
+}
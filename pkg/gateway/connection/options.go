@@ -33,6 +33,14 @@ type Options struct {
 	PingEnabled bool
 	// PingUpdateStatusInterval is the interval at which the status is updated.
 	PingUpdateStatusInterval time.Duration
+	// MTUDiscoveryEnabled enables the discovery of the MTU of the path through the tunnel.
+	MTUDiscoveryEnabled bool
+	// MTUDiscoveryMin is the lower bound of the discovered MTU.
+	MTUDiscoveryMin int
+	// MTUDiscoveryMax is the upper bound of the discovered MTU.
+	MTUDiscoveryMax int
+	// MTUDiscoveryInterval is the interval between two discoveries of the MTU.
+	MTUDiscoveryInterval time.Duration
 }
 
 // NewOptions returns a new Options struct.
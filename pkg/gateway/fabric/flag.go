@@ -34,6 +34,8 @@ const (
 	FlagNameGenevePort FlagName = "geneve-port"
 	// FlagNameGeneveCleanupInterval is the flag to set the Geneve cleanup interval.
 	FlagNameGeneveCleanupInterval FlagName = "geneve-cleanup-interval"
+	// FlagNameEncryptionPort is the flag to set the port of the WireGuard peerings encrypting the internal fabric.
+	FlagNameEncryptionPort FlagName = "fabric-encryption-port"
 )
 
 // InitFlags initializes the flags for the gateway.
@@ -42,4 +44,6 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.Uint16Var(&opts.GenevePort, FlagNameGenevePort.String(), consts.DefaultGenevePort, "Geneve port")
 	flagset.DurationVar(&opts.GeneveCleanupInterval, FlagNameGeneveCleanupInterval.String(),
 		consts.DefaultGeneveCleanupInterval, "Geneve cleanup interval")
+	flagset.IntVar(&opts.EncryptionPort, FlagNameEncryptionPort.String(), consts.DefaultFabricEncryptionPort,
+		"Port of the WireGuard peerings encrypting the internal fabric, when enabled")
 }
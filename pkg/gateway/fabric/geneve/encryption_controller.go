@@ -0,0 +1,238 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geneve
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway/fabric"
+	"github.com/liqotech/liqo/pkg/route"
+	"github.com/liqotech/liqo/pkg/utils/network/wireguard"
+)
+
+// EncryptionReconciler manages the WireGuard peerings encrypting the geneve tunnels between the gateway and the nodes,
+// when enabled in the InternalFabric.
+type EncryptionReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	EventsRecorder record.EventRecorder
+	Options        *fabric.Options
+
+	privateKey wgtypes.Key
+	tableID    uint32
+}
+
+// NewEncryptionReconciler returns a new EncryptionReconciler.
+// The private key of the gateway is generated at startup, and the corresponding public key is published through a PublicKey resource.
+func NewEncryptionReconciler(cl client.Client, s *runtime.Scheme,
+	er record.EventRecorder, opts *fabric.Options) (*EncryptionReconciler, error) {
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate the fabric encryption key: %w", err)
+	}
+	tableID, err := route.GetTableID(consts.FabricEncryptionRouteTable)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptionReconciler{
+		Client:         cl,
+		Scheme:         s,
+		EventsRecorder: er,
+		Options:        opts,
+		privateKey:     privateKey,
+		tableID:        tableID,
+	}, nil
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=internalnodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=internalfabrics,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=publickeies,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile manages the WireGuard peerings of the gateway towards the nodes.
+func (r *EncryptionReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	internalFabric, err := getInternalFabric(ctx, r.Client, r.Options.GwOptions.Name, r.Options.GwOptions.RemoteClusterID, r.Options.GwOptions.Namespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		return ctrl.Result{}, r.ensureEncryptionAbsence()
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("unable to get the internal fabric: %w", err)
+	}
+
+	if !wireguard.IsEncrypted(internalFabric) {
+		if err := wireguard.EnsurePublicKeyAbsence(ctx, r.Client, r.publicKeyName(), internalFabric.Namespace); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.ensureEncryptionAbsence()
+	}
+
+	// The nodes reach each replica of the gateway through its own IP, hence it is published together with the key.
+	endpoints := []string{internalFabric.Spec.GatewayIP.String()}
+	for i := range internalFabric.Spec.Replicas {
+		endpoints = append(endpoints, internalFabric.Spec.Replicas[i].GatewayIP.String())
+	}
+	localIP, err := wireguard.FindLocalIP(endpoints)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if localIP == nil {
+		klog.Infof("waiting for the IP of the gateway to be set in internalfabric %s...", internalFabric.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if err := wireguard.EnsurePublicKeyPresence(ctx, r.Client, r.Scheme, internalFabric, r.publicKeyName(),
+		wireguard.ForgePublicKeyLabels(internalFabric.Name, consts.FabricPublicKeyRoleGateway),
+		map[string]string{consts.FabricPublicKeyEndpointAnnotation: localIP.String()},
+		r.privateKey.PublicKey()); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ensureStalePublicKeysAbsence(ctx, internalFabric, endpoints); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	peers, err := r.forgePeers(ctx, internalFabric)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := wireguard.EnsureWireGuardInterfacePresence(consts.FabricEncryptionInterfaceName,
+		r.privateKey, r.Options.EncryptionPort, peers); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	peerEndpoints := make([]net.IP, len(peers))
+	for i := range peers {
+		peerEndpoints[i] = peers[i].Endpoint
+	}
+	if err := wireguard.EnsureRoutesPresence(consts.FabricEncryptionInterfaceName, r.tableID,
+		r.Options.GenevePort, peerEndpoints); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	klog.Infof("Enforced encryption of internalfabric %s towards %d nodes", internalFabric.Name, len(peers))
+	return ctrl.Result{}, nil
+}
+
+// forgePeers returns the WireGuard peers corresponding to the nodes which published their key.
+func (r *EncryptionReconciler) forgePeers(ctx context.Context, internalFabric *networkingv1beta1.InternalFabric) ([]wireguard.Peer, error) {
+	publicKeys, err := wireguard.ListPublicKeys(ctx, r.Client, internalFabric, consts.FabricPublicKeyRoleNode)
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []wireguard.Peer
+	for i := range publicKeys {
+		nodeName := publicKeys[i].Labels[consts.InternalNodeName]
+		if nodeName == r.Options.GwOptions.NodeName {
+			// The traffic towards the node hosting the gateway does not leave the node, hence it is not encrypted.
+			continue
+		}
+		internalNode := &networkingv1beta1.InternalNode{}
+		if err := r.Get(ctx, types.NamespacedName{Name: nodeName}, internalNode); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to get the internalnode %q: %w", nodeName, err)
+		}
+
+		key, err := wireguard.ParsePublicKey(&publicKeys[i])
+		if err != nil {
+			klog.Warning(err)
+			continue
+		}
+
+		// The endpoint is the same IP used as remote of the geneve tunnel towards the node.
+		endpoint := internalNode.Status.NodeIP.Remote
+		if endpoint == nil {
+			klog.Infof("waiting for the remote IP of internalnode %s to be set...", internalNode.Name)
+			continue
+		}
+
+		endpointIP := net.ParseIP(endpoint.String())
+		peers = append(peers, wireguard.Peer{PublicKey: key, Endpoint: endpointIP, AllowedIPs: []net.IP{endpointIP}})
+	}
+	return peers, nil
+}
+
+// ensureStalePublicKeysAbsence deletes the keys published by the gateway replicas no longer part of the internal fabric.
+func (r *EncryptionReconciler) ensureStalePublicKeysAbsence(ctx context.Context,
+	internalFabric *networkingv1beta1.InternalFabric, endpoints []string) error {
+	publicKeys, err := wireguard.ListPublicKeys(ctx, r.Client, internalFabric, consts.FabricPublicKeyRoleGateway)
+	if err != nil {
+		return err
+	}
+	for i := range publicKeys {
+		if slices.Contains(endpoints, publicKeys[i].Annotations[consts.FabricPublicKeyEndpointAnnotation]) {
+			continue
+		}
+		klog.Infof("Removing the stale fabric publickey %s/%s", publicKeys[i].Namespace, publicKeys[i].Name)
+		if err := wireguard.EnsurePublicKeyAbsence(ctx, r.Client, publicKeys[i].Name, publicKeys[i].Namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *EncryptionReconciler) ensureEncryptionAbsence() error {
+	if err := wireguard.EnsureRoutesAbsence(r.tableID); err != nil {
+		return err
+	}
+	return wireguard.EnsureWireGuardInterfaceAbsence(consts.FabricEncryptionInterfaceName)
+}
+
+func (r *EncryptionReconciler) publicKeyName() string {
+	return fmt.Sprintf("fabric-gateway-%s", r.Options.GwOptions.PodName)
+}
+
+// SetupWithManager register the EncryptionReconciler to the manager.
+func (r *EncryptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// All the events trigger the reconciliation of the whole configuration of the gateway.
+	enqueuer := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{
+			Name: r.Options.GwOptions.RemoteClusterID, Namespace: r.Options.GwOptions.Namespace,
+		}}}
+	})
+	inNamespace := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == r.Options.GwOptions.Namespace
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlFabricEncryptionGw).
+		Watches(&networkingv1beta1.InternalFabric{}, enqueuer, builder.WithPredicates(inNamespace)).
+		Watches(&networkingv1beta1.InternalNode{}, enqueuer).
+		Watches(&networkingv1beta1.PublicKey{}, enqueuer, builder.WithPredicates(inNamespace,
+			predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetLabels()[consts.FabricPublicKeyRoleLabel] == consts.FabricPublicKeyRoleNode
+			}))).
+		Complete(r)
+}
@@ -0,0 +1,244 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package geneve
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/gateway/fabric"
+	"github.com/liqotech/liqo/pkg/utils/network/wireguard"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+)
+
+const (
+	testNamespace = "liqo-tenant-remote"
+	testClusterID = "remote"
+	testNodeName  = "worker-1"
+	testTableID   = 1234
+)
+
+// forgePublicKey returns a PublicKey published for the given internal fabric.
+func forgePublicKey(name, role string, lbls, annotations map[string]string, key wgtypes.Key) *networkingv1beta1.PublicKey {
+	publicKey := &networkingv1beta1.PublicKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   testNamespace,
+			Labels:      wireguard.ForgePublicKeyLabels(testClusterID, role),
+			Annotations: annotations,
+		},
+		Spec: networkingv1beta1.PublicKeySpec{PublicKey: key[:]},
+	}
+	for k, v := range lbls {
+		publicKey.Labels[k] = v
+	}
+	return publicKey
+}
+
+// forgeInternalNode returns an InternalNode with the given remote IP.
+func forgeInternalNode(name string, remote *networkingv1beta1.IP) *networkingv1beta1.InternalNode {
+	return &networkingv1beta1.InternalNode{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     networkingv1beta1.InternalNodeStatus{NodeIP: networkingv1beta1.InternalNodeStatusNodeIP{Remote: remote}},
+	}
+}
+
+func generateKey() wgtypes.Key {
+	key, err := wgtypes.GeneratePrivateKey()
+	Expect(err).NotTo(HaveOccurred())
+	return key.PublicKey()
+}
+
+var _ = Describe("Gateway fabric encryption controller", func() {
+	var (
+		ctx            context.Context
+		internalFabric *networkingv1beta1.InternalFabric
+		key1, key2     wgtypes.Key
+	)
+
+	newReconciler := func(objs ...client.Object) *EncryptionReconciler {
+		privateKey, err := wgtypes.GeneratePrivateKey()
+		Expect(err).NotTo(HaveOccurred())
+		return &EncryptionReconciler{
+			Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Scheme:         scheme,
+			EventsRecorder: record.NewFakeRecorder(10),
+			Options: &fabric.Options{
+				GwOptions: &gateway.Options{
+					Name: "gw", Namespace: testNamespace, RemoteClusterID: testClusterID, NodeName: testNodeName, PodName: "gw-0",
+				},
+				GenevePort: consts.DefaultGenevePort,
+			},
+			privateKey: privateKey,
+			tableID:    testTableID,
+		}
+	}
+
+	nodeKeyLabels := func(nodeName string) map[string]string {
+		return map[string]string{consts.InternalNodeName: nodeName}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		key1, key2 = generateKey(), generateKey()
+		internalFabric = &networkingv1beta1.InternalFabric{
+			ObjectMeta: metav1.ObjectMeta{Name: testClusterID, Namespace: testNamespace},
+			Spec: networkingv1beta1.InternalFabricSpec{
+				GatewayIP:  "10.0.0.10",
+				Replicas:   []networkingv1beta1.InternalFabricSpecReplica{{GatewayIP: "10.0.0.11"}},
+				Encryption: networkingv1beta1.InternalFabricEncryptionModeWireGuard,
+			},
+		}
+	})
+
+	Describe("forgePeers function", func() {
+		It("should return a peer for each node which published its key", func() {
+			r := newReconciler(internalFabric,
+				forgePublicKey("node-2", consts.FabricPublicKeyRoleNode, nodeKeyLabels("worker-2"), nil, key1),
+				forgePublicKey("node-3", consts.FabricPublicKeyRoleNode, nodeKeyLabels("worker-3"), nil, key2),
+				forgeInternalNode("worker-2", ptr.To(networkingv1beta1.IP("10.0.1.2"))),
+				forgeInternalNode("worker-3", ptr.To(networkingv1beta1.IP("fd00::3"))))
+
+			peers, err := r.forgePeers(ctx, internalFabric)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peers).To(ConsistOf(
+				wireguard.Peer{PublicKey: key1, Endpoint: net.ParseIP("10.0.1.2"), AllowedIPs: []net.IP{net.ParseIP("10.0.1.2")}},
+				wireguard.Peer{PublicKey: key2, Endpoint: net.ParseIP("fd00::3"), AllowedIPs: []net.IP{net.ParseIP("fd00::3")}},
+			))
+		})
+
+		It("should skip the node hosting the gateway", func() {
+			r := newReconciler(internalFabric,
+				forgePublicKey("node-1", consts.FabricPublicKeyRoleNode, nodeKeyLabels(testNodeName), nil, key1),
+				forgeInternalNode(testNodeName, ptr.To(networkingv1beta1.IP("10.0.1.1"))))
+
+			peers, err := r.forgePeers(ctx, internalFabric)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peers).To(BeEmpty())
+		})
+
+		It("should skip the nodes without an internalnode or without a remote IP", func() {
+			r := newReconciler(internalFabric,
+				forgePublicKey("node-2", consts.FabricPublicKeyRoleNode, nodeKeyLabels("worker-2"), nil, key1),
+				forgePublicKey("node-3", consts.FabricPublicKeyRoleNode, nodeKeyLabels("worker-3"), nil, key2),
+				forgeInternalNode("worker-3", nil))
+
+			peers, err := r.forgePeers(ctx, internalFabric)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peers).To(BeEmpty())
+		})
+
+		It("should ignore the keys published by the gateways", func() {
+			r := newReconciler(internalFabric,
+				forgePublicKey("gw-1", consts.FabricPublicKeyRoleGateway, nodeKeyLabels("worker-2"), nil, key1),
+				forgeInternalNode("worker-2", ptr.To(networkingv1beta1.IP("10.0.1.2"))))
+
+			peers, err := r.forgePeers(ctx, internalFabric)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(peers).To(BeEmpty())
+		})
+	})
+
+	Describe("ensureStalePublicKeysAbsence function", func() {
+		It("should remove the keys of the replicas no longer part of the internal fabric", func() {
+			endpointAnnotation := func(ip string) map[string]string {
+				return map[string]string{consts.FabricPublicKeyEndpointAnnotation: ip}
+			}
+			r := newReconciler(internalFabric,
+				forgePublicKey("gw-0", consts.FabricPublicKeyRoleGateway, nil, endpointAnnotation("10.0.0.10"), key1),
+				forgePublicKey("gw-old", consts.FabricPublicKeyRoleGateway, nil, endpointAnnotation("10.0.0.99"), key2),
+				forgePublicKey("node-2", consts.FabricPublicKeyRoleNode, nodeKeyLabels("worker-2"), nil, key2))
+
+			Expect(r.ensureStalePublicKeysAbsence(ctx, internalFabric, []string{"10.0.0.10", "10.0.0.11"})).To(Succeed())
+
+			var publicKeys networkingv1beta1.PublicKeyList
+			Expect(r.List(ctx, &publicKeys)).To(Succeed())
+			names := make([]string, len(publicKeys.Items))
+			for i := range publicKeys.Items {
+				names[i] = publicKeys.Items[i].Name
+			}
+			Expect(names).To(ConsistOf("gw-0", "node-2"))
+		})
+	})
+
+	Describe("Reconcile function", func() {
+		var req ctrl.Request
+
+		BeforeEach(func() {
+			req = ctrl.Request{NamespacedName: client.ObjectKey{Name: testClusterID, Namespace: testNamespace}}
+		})
+
+		It("should wait for the IP of the gateway to be assigned before publishing the key", func() {
+			ns := testutil.NewNetworkNamespace()
+			r := newReconciler(internalFabric)
+
+			testutil.InNetworkNamespace(ns, func() {
+				Expect(r.Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+			})
+
+			err := r.Get(ctx, client.ObjectKey{Name: r.publicKeyName(), Namespace: testNamespace}, &networkingv1beta1.PublicKey{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should remove the key, the routes and the interface when the encryption is disabled", func() {
+			ns := testutil.NewNetworkNamespace()
+			internalFabric.Spec.Encryption = networkingv1beta1.InternalFabricEncryptionModeNone
+			r := newReconciler(internalFabric)
+			Expect(wireguard.EnsurePublicKeyPresence(ctx, r.Client, scheme, internalFabric, r.publicKeyName(),
+				wireguard.ForgePublicKeyLabels(internalFabric.Name, consts.FabricPublicKeyRoleGateway), nil, key1)).To(Succeed())
+
+			testutil.InNetworkNamespace(ns, func() {
+				// A stale interface and the corresponding routes are left by a previous configuration.
+				link := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: consts.FabricEncryptionInterfaceName}, PeerName: "liqo-test-peer"}
+				Expect(netlink.LinkAdd(link)).To(Succeed())
+				Expect(netlink.AddrAdd(link, &netlink.Addr{IPNet: &net.IPNet{IP: net.ParseIP("10.0.1.1"), Mask: net.CIDRMask(24, 32)}})).To(Succeed())
+				Expect(netlink.LinkSetUp(link)).To(Succeed())
+				Expect(wireguard.EnsureRoutesPresence(consts.FabricEncryptionInterfaceName, testTableID,
+					consts.DefaultGenevePort, []net.IP{net.ParseIP("10.0.1.2")})).To(Succeed())
+
+				Expect(r.Reconcile(ctx, req)).To(Equal(ctrl.Result{}))
+
+				_, err := netlink.LinkByName(consts.FabricEncryptionInterfaceName)
+				Expect(err).To(BeAssignableToTypeOf(netlink.LinkNotFoundError{}))
+				routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: testTableID}, netlink.RT_FILTER_TABLE)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routes).To(BeEmpty())
+				rules, err := netlink.RuleListFiltered(netlink.FAMILY_ALL, &netlink.Rule{Table: testTableID}, netlink.RT_FILTER_TABLE)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rules).To(BeEmpty())
+			})
+
+			err := r.Get(ctx, client.ObjectKey{Name: r.publicKeyName(), Namespace: testNamespace}, &networkingv1beta1.PublicKey{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
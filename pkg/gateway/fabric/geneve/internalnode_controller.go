@@ -24,8 +24,10 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
@@ -122,9 +124,27 @@ func (r *InternalNodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlInternalNodeGeneve).
 		For(&networkingv1beta1.InternalNode{}).
 		Watches(&networkingv1beta1.GeneveTunnel{}, handler.EnqueueRequestsFromMapFunc(geneveToInternalNodeEnqueuer)).
+		// The changes of the InternalFabric (e.g., of the MTU) must be applied to the interfaces towards all the nodes.
+		Watches(&networkingv1beta1.InternalFabric{}, handler.EnqueueRequestsFromMapFunc(r.internalFabricToInternalNodesEnqueuer),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetNamespace() == r.Options.GwOptions.Namespace
+			}))).
 		Complete(r)
 }
 
+func (r *InternalNodeReconciler) internalFabricToInternalNodesEnqueuer(ctx context.Context, _ client.Object) []reconcile.Request {
+	var internalNodes networkingv1beta1.InternalNodeList
+	if err := r.List(ctx, &internalNodes); err != nil {
+		klog.Errorf("unable to list the internalnodes: %v", err)
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(internalNodes.Items))
+	for i := range internalNodes.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: internalNodes.Items[i].Name}})
+	}
+	return requests
+}
+
 func geneveToInternalNodeEnqueuer(_ context.Context, obj client.Object) []reconcile.Request {
 	v, ok := obj.GetLabels()[consts.InternalNodeName]
 	if !ok {
@@ -26,6 +26,7 @@ type Options struct {
 	DisableARP            bool
 	GenevePort            uint16
 	GeneveCleanupInterval time.Duration
+	EncryptionPort        int
 }
 
 // NewOptions returns a new Options struct.
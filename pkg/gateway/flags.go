@@ -54,6 +54,9 @@ const (
 	// FlagNameReconcileTimeout is the reconciliation timeout.
 	FlagNameReconcileTimeout FlagName = "reconcile-timeout"
 
+	// FlagNameActiveActive is the flag to run all the gateway replicas in active-active mode.
+	FlagNameActiveActive FlagName = "active-active"
+
 	// FlagNameLeaderElection is the flag to enable leader election.
 	FlagNameLeaderElection FlagName = "leader-election"
 	// FlagNameLeaderElectionLeaseDuration is the lease duration for the leader election.
@@ -72,6 +75,8 @@ const (
 	FlagNameEnableNftMonitor FlagName = "enable-nft-monitor"
 	// FlagNameEnableRouteMonitor is the flag to enable the route monitor.
 	FlagNameEnableRouteMonitor FlagName = "enable-route-monitor"
+	// FlagNameFirewallCountersPeriod is the period of the inspection of the counters and of the drift of the firewall rules.
+	FlagNameFirewallCountersPeriod FlagName = "firewall-counters-period"
 
 	// FlagNameDisableKernelVersionCheck is the flag to enable the kernel version check.
 	FlagNameDisableKernelVersionCheck FlagName = "disable-kernel-version-check"
@@ -109,6 +114,9 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 
 	flagset.DurationVar(&opts.ReconcileTimeout, FlagNameReconcileTimeout.String(), 10*time.Second, "Reconciliation timeout")
 
+	flagset.BoolVar(&opts.ActiveActive, FlagNameActiveActive.String(), false,
+		"Run all the gateway replicas in active-active mode, rather than electing a leader")
+
 	flagset.BoolVar(&opts.LeaderElection, FlagNameLeaderElection.String(), false, "Enable leader election")
 	flagset.DurationVar(&opts.LeaderElectionLeaseDuration, FlagNameLeaderElectionLeaseDuration.String(), 15*time.Second,
 		"LeaseDuration for the leader election")
@@ -122,6 +130,8 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 
 	flagset.BoolVar(&opts.EnableNftMonitor, FlagNameEnableNftMonitor.String(), true, "Enable nftables monitor")
 	flagset.BoolVar(&opts.EnableRouteMonitor, FlagNameEnableRouteMonitor.String(), true, "Enable route monitor")
+	flagset.DurationVar(&opts.FirewallCountersPeriod, FlagNameFirewallCountersPeriod.String(), 30*time.Second,
+		"Period of the inspection of the counters and of the drift of the firewall rules (0 to disable)")
 
 	flagset.BoolVar(&opts.DisableKernelVersionCheck, FlagNameDisableKernelVersionCheck.String(), false, "Disable the kernel version check")
 	flagset.Var(&opts.MinimumKernelVersion, FlagNameMinimumKernelVersion.String(), "Minimum kernel version required by Liqo")
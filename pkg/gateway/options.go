@@ -38,6 +38,8 @@ type Options struct {
 
 	ReconcileTimeout time.Duration
 
+	ActiveActive bool
+
 	LeaderElection              bool
 	LeaderElectionLeaseDuration time.Duration
 	LeaderElectionRenewDeadline time.Duration
@@ -49,6 +51,8 @@ type Options struct {
 	EnableNftMonitor   bool
 	EnableRouteMonitor bool
 
+	FirewallCountersPeriod time.Duration
+
 	DisableKernelVersionCheck bool
 	MinimumKernelVersion      kernelversion.KernelVersion
 }
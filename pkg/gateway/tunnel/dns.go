@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package wireguard
+package tunnel
 
 import (
 	"context"
@@ -36,13 +36,14 @@ import (
 	"github.com/liqotech/liqo/pkg/utils/getters"
 )
 
-// StartDNSRoutine run a routine which periodically resolves the DNS associated to the wireguard client endpoint.
+// StartDNSRoutine run a routine which periodically resolves the DNS associated to the client endpoint of the tunnel.
 // The DNS is resolved every 5 minutes.
 // If the DNS changed a new publickkeys-controller reconcile is triggered through a generic event.
-func StartDNSRoutine(ctx context.Context, ch chan event.GenericEvent, opts *Options) {
+func StartDNSRoutine(ctx context.Context, ch chan event.GenericEvent, opts *EndpointOptions) {
 	// Try to solve the DNS every 5 seconds until the DNS is resolved for 10 minutes.
 	// In some cases (like AWS LoadBalancer) the DNS is not immediatlly populated or can contain not working IPs.
-	timeout, _ := context.WithTimeoutCause(ctx, time.Minute*10, context.DeadlineExceeded)
+	timeout, cancel := context.WithTimeoutCause(ctx, time.Minute*10, context.DeadlineExceeded)
+	defer cancel()
 	err := wait.PollUntilContextCancel(timeout, time.Second*5, true, forgeResolveCallback(opts, ch))
 	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
 		klog.Error(err)
@@ -55,7 +56,7 @@ func StartDNSRoutine(ctx context.Context, ch chan event.GenericEvent, opts *Opti
 	}
 }
 
-func forgeResolveCallback(opts *Options, ch chan event.GenericEvent) func(_ context.Context) (done bool, err error) {
+func forgeResolveCallback(opts *EndpointOptions, ch chan event.GenericEvent) func(_ context.Context) (done bool, err error) {
 	return func(_ context.Context) (done bool, err error) {
 		ips, err := net.LookupIP(opts.EndpointAddress)
 		if err != nil {
@@ -76,21 +77,20 @@ func forgeResolveCallback(opts *Options, ch chan event.GenericEvent) func(_ cont
 		}
 
 		// Checks if the DNS resolution has changed
+		current := opts.GetEndpointIP()
 		for _, ip := range ips {
-			if opts.EndpointIP.Equal(ip) {
+			if current.Equal(ip) {
 				return false, nil
 			}
 		}
+		if len(ips) == 0 {
+			return false, nil
+		}
 
 		klog.Infof("DNS %q resolved to %q: updating endpoint", opts.EndpointAddress, ips[0])
 
 		// Copies the new IPs to store for the next check
-		opts.EndpointIPMutex.Lock()
-		defer opts.EndpointIPMutex.Unlock()
-		if len(ips) == 0 {
-			return false, nil
-		}
-		opts.EndpointIP = ips[0]
+		opts.SetEndpointIP(ips[0])
 
 		// Triggers a new reconcile
 		ch <- event.GenericEvent{}
@@ -101,7 +101,7 @@ func forgeResolveCallback(opts *Options, ch chan event.GenericEvent) func(_ cont
 
 // IsDNSRoutineRequired checks if the client endpoint is a DNS.
 // If it is a DNS the DNS routine is required.
-func IsDNSRoutineRequired(opts *Options) bool {
+func IsDNSRoutineRequired(opts *EndpointOptions) bool {
 	if opts.GwOptions.Mode != gateway.ModeClient {
 		return false
 	}
@@ -114,13 +114,13 @@ func NewDNSSource(src <-chan event.GenericEvent, eh handler.EventHandler) source
 }
 
 // NewDNSEventHandler creates a new EventHandler.
-func NewDNSEventHandler(cl client.Client, opts *Options) handler.EventHandler {
+func NewDNSEventHandler(cl client.Client, opts *gateway.Options) handler.EventHandler {
 	return handler.EnqueueRequestsFromMapFunc(
 		func(ctx context.Context, _ client.Object) []reconcile.Request {
 			labelSet := labels.Set{
-				string(consts.RemoteClusterID): opts.GwOptions.RemoteClusterID,
+				string(consts.RemoteClusterID): opts.RemoteClusterID,
 			}
-			list, err := getters.ListPublicKeysByLabel(ctx, cl, opts.GwOptions.Namespace, labels.SelectorFromSet(labelSet))
+			list, err := getters.ListPublicKeysByLabel(ctx, cl, opts.Namespace, labels.SelectorFromSet(labelSet))
 			if err != nil {
 				klog.Error(err)
 				return nil
@@ -0,0 +1,58 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnel
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/liqotech/liqo/pkg/gateway"
+)
+
+// EndpointOptions contains the options describing the remote endpoint of the tunnel, shared by the tunnel implementations.
+type EndpointOptions struct {
+	GwOptions *gateway.Options
+
+	EndpointAddress string
+	EndpointPort    int
+
+	EndpointIP      net.IP
+	EndpointIPMutex *sync.Mutex
+
+	DNSCheckInterval time.Duration
+}
+
+// NewEndpointOptions returns a new EndpointOptions struct.
+func NewEndpointOptions(options *gateway.Options) EndpointOptions {
+	return EndpointOptions{
+		GwOptions:       options,
+		EndpointIPMutex: &sync.Mutex{},
+	}
+}
+
+// GetEndpointIP returns the current IP of the remote endpoint (client only).
+func (o *EndpointOptions) GetEndpointIP() net.IP {
+	o.EndpointIPMutex.Lock()
+	defer o.EndpointIPMutex.Unlock()
+	return o.EndpointIP
+}
+
+// SetEndpointIP sets the current IP of the remote endpoint (client only).
+func (o *EndpointOptions) SetEndpointIP(ip net.IP) {
+	o.EndpointIPMutex.Lock()
+	defer o.EndpointIPMutex.Unlock()
+	o.EndpointIP = ip
+}
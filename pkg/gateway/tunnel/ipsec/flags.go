@@ -0,0 +1,101 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
+)
+
+// FlagName is the type for the name of the flags.
+type FlagName string
+
+func (fn FlagName) String() string {
+	return string(fn)
+}
+
+const (
+	// FlagNameMTU is the MTU for the IPsec interface.
+	FlagNameMTU FlagName = "mtu"
+	// FlagNameListenPort is the UDP port used to send and receive the encapsulated ESP traffic.
+	FlagNameListenPort FlagName = "listen-port"
+	// FlagNameLocalAddress is the local address of the tunnel.
+	FlagNameLocalAddress FlagName = "local-address"
+	// FlagNameEndpointAddress is the address of the remote endpoint.
+	FlagNameEndpointAddress FlagName = "endpoint-address"
+	// FlagNameEndpointPort is the port of the remote endpoint.
+	FlagNameEndpointPort FlagName = "endpoint-port"
+	// FlagNameKeysDir is the directory where the keys are stored.
+	FlagNameKeysDir FlagName = "keys-dir"
+
+	// FlagNameDNSCheckInterval is the interval between two DNS checks.
+	FlagNameDNSCheckInterval FlagName = "dns-check-interval"
+	// FlagNameKeepaliveInterval is the interval between two NAT-keepalive packets sent by the client.
+	FlagNameKeepaliveInterval FlagName = "keepalive-interval"
+	// FlagNameRekeyInterval is the interval after which the security associations are rekeyed (i.e., the length of an epoch).
+	FlagNameRekeyInterval FlagName = "rekey-interval"
+	// FlagNameReplayWindow is the size of the anti-replay window of the security associations.
+	FlagNameReplayWindow FlagName = "replay-window"
+)
+
+// ClientRequiredFlags contains the flags required in client mode.
+var ClientRequiredFlags = []FlagName{
+	FlagNameEndpointAddress,
+}
+
+// InitFlags initializes the flags for the IPsec tunnel.
+func InitFlags(flagset *pflag.FlagSet, opts *Options) {
+	flagset.IntVar(&opts.MTU, FlagNameMTU.String(), forge.DefaultMTU, "MTU for the interface")
+	flagset.IntVar(&opts.ListenPort, FlagNameListenPort.String(), forge.DefaultGwServerPort, "Listen port")
+	flagset.StringVar(&opts.LocalAddress, FlagNameLocalAddress.String(), "",
+		"Local address of the tunnel (required in server mode, defaults to the source address towards the endpoint in client mode)")
+	flagset.StringVar(&opts.EndpointAddress, FlagNameEndpointAddress.String(), "", "Endpoint address (client only)")
+	flagset.IntVar(&opts.EndpointPort, FlagNameEndpointPort.String(), forge.DefaultGwServerPort, "Endpoint port (client only)")
+	flagset.StringVar(&opts.KeysDir, FlagNameKeysDir.String(), forge.DefaultIPsecKeysDir, "Directory where the keys are stored")
+
+	flagset.DurationVar(&opts.DNSCheckInterval, FlagNameDNSCheckInterval.String(), 5*time.Minute, "Interval between two DNS checks")
+	flagset.DurationVar(&opts.KeepaliveInterval, FlagNameKeepaliveInterval.String(), 10*time.Second,
+		"Interval between two NAT-keepalive packets sent by the client")
+	flagset.DurationVar(&opts.RekeyInterval, FlagNameRekeyInterval.String(), 10*time.Minute,
+		"Interval after which the security associations are rekeyed, with keys derived for the new epoch")
+	flagset.IntVar(&opts.ReplayWindow, FlagNameReplayWindow.String(), MaxReplayWindow, "Size of the anti-replay window of the security associations")
+}
+
+// ServerRequiredFlags contains the flags required in server mode.
+var ServerRequiredFlags = []FlagName{
+	FlagNameLocalAddress,
+}
+
+// MarkFlagsRequired marks the flags as required.
+func MarkFlagsRequired(cmd *cobra.Command, opts *Options) error {
+	var required []FlagName
+	switch opts.GwOptions.Mode {
+	case gateway.ModeClient:
+		required = ClientRequiredFlags
+	case gateway.ModeServer:
+		required = ServerRequiredFlags
+	}
+	for _, flag := range required {
+		if err := cmd.MarkFlagRequired(flag.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
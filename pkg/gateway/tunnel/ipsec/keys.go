@@ -0,0 +1,169 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway"
+)
+
+const (
+	// MinPresharedKeyLen is the minimum length of the pre-shared key.
+	MinPresharedKeyLen = 32
+
+	// encKeyLen is the length of the cbc(aes) keys (AES-256).
+	encKeyLen = 32
+	// authKeyLen is the length of the hmac(sha256) keys.
+	authKeyLen = 32
+	// spiLen is the length of the material used to derive a SPI.
+	spiLen = 4
+	// minSPI is the lowest SPI value which can be used (values below 256 are reserved by the IANA).
+	minSPI = 0x100
+
+	saKeysInfo = "liqo-ipsec sa"
+)
+
+// saKeys contains the keying material of a security association.
+type saKeys struct {
+	EncKey  []byte
+	AuthKey []byte
+	SPI     uint32
+}
+
+// epochKeys contains the keying material of the outbound and inbound security associations of an epoch.
+type epochKeys struct {
+	Out saKeys
+	In  saKeys
+}
+
+// ParsePublicKey parses an ECDSA P-256 public key in PKIX, ASN.1 DER form.
+func ParsePublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the public key: %w", err)
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("unsupported public key type %T: an ECDSA P-256 key is required", key)
+	}
+	return ecKey, nil
+}
+
+// LoadKeys loads the private and the optional pre-shared keys from the keys directory.
+func LoadKeys(options *Options) error {
+	der, err := readKeyFile(options.KeysDir, consts.PrivateKeyField)
+	if err != nil {
+		return err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return fmt.Errorf("unable to parse the private key: %w", err)
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return fmt.Errorf("unsupported private key type %T: an ECDSA P-256 key is required", key)
+	}
+	options.PrivateKey = ecKey
+
+	psk, err := readKeyFile(options.KeysDir, consts.PresharedKeyField)
+	switch {
+	case os.IsNotExist(err):
+		options.PresharedKey = nil
+	case err != nil:
+		return err
+	case len(psk) < MinPresharedKeyLen:
+		return fmt.Errorf("the pre-shared key is too short: at least %d bytes are required, got %d", MinPresharedKeyLen, len(psk))
+	default:
+		options.PresharedKey = psk
+	}
+
+	return nil
+}
+
+func readKeyFile(dir, name string) ([]byte, error) {
+	f, err := os.Open(filepath.Clean(path.Join(dir, name)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// sharedSecret computes the static ECDH shared secret between the local private key and the public key of the peer.
+func sharedSecret(privateKey *ecdsa.PrivateKey, peerPublicKey *ecdsa.PublicKey) ([]byte, error) {
+	priv, err := privateKey.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert the private key: %w", err)
+	}
+	pub, err := peerPublicKey.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert the public key of the peer: %w", err)
+	}
+	secret, err := priv.ECDH(pub)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute the shared secret: %w", err)
+	}
+	return secret, nil
+}
+
+// deriveEpochKeys derives the keys and the SPIs of the security associations of the given epoch from the shared secret.
+// The optional pre-shared key salts the derivation, while the epoch makes the keys of each rekey interval independent.
+// Both peers derive the same material, which is split in the client-to-server and the server-to-client directions.
+func deriveEpochKeys(mode gateway.Mode, secret, psk []byte, epoch int64) (*epochKeys, error) {
+	info := binary.BigEndian.AppendUint64([]byte(saKeysInfo), uint64(epoch))
+	material, err := hkdf.Key(sha256.New, secret, psk, string(info), 2*(encKeyLen+authKeyLen+spiLen))
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive the security associations keys: %w", err)
+	}
+
+	split := func(b []byte) saKeys {
+		return saKeys{
+			EncKey:  b[:encKeyLen],
+			AuthKey: b[encKeyLen : encKeyLen+authKeyLen],
+			SPI:     toSPI(b[encKeyLen+authKeyLen:]),
+		}
+	}
+	c2s := split(material[:encKeyLen+authKeyLen+spiLen])
+	s2c := split(material[encKeyLen+authKeyLen+spiLen:])
+	if c2s.SPI == s2c.SPI {
+		s2c.SPI = toSPI(binary.BigEndian.AppendUint32(nil, s2c.SPI+1))
+	}
+
+	if mode == gateway.ModeClient {
+		return &epochKeys{Out: c2s, In: s2c}, nil
+	}
+	return &epochKeys{Out: s2c, In: c2s}, nil
+}
+
+// toSPI converts the given material to a SPI, skipping the reserved range.
+func toSPI(b []byte) uint32 {
+	spi := binary.BigEndian.Uint32(b)
+	if spi < minSPI {
+		spi += minSPI
+	}
+	return spi
+}
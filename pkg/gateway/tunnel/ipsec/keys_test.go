@@ -0,0 +1,117 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/liqotech/liqo/pkg/gateway"
+)
+
+var _ = Describe("Keys", func() {
+	It("should parse ECDSA P-256 public keys", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		parsed, err := ParsePublicKey(der)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.Equal(&key.PublicKey)).To(BeTrue())
+	})
+
+	It("should reject public keys on other curves", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ParsePublicKey(der)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject malformed public keys", func() {
+		_, err := ParsePublicKey(bytes.Repeat([]byte{1}, 32))
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("Deriving the keys of the security associations", func() {
+		var clientSecret, serverSecret, psk []byte
+
+		BeforeEach(func() {
+			clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			clientSecret, err = sharedSecret(clientKey, &serverKey.PublicKey)
+			Expect(err).ToNot(HaveOccurred())
+			serverSecret, err = sharedSecret(serverKey, &clientKey.PublicKey)
+			Expect(err).ToNot(HaveOccurred())
+			psk = bytes.Repeat([]byte{7}, MinPresharedKeyLen)
+		})
+
+		It("should derive matching keys on both sides", func() {
+			Expect(clientSecret).To(Equal(serverSecret))
+
+			client, err := deriveEpochKeys(gateway.ModeClient, clientSecret, psk, 42)
+			Expect(err).ToNot(HaveOccurred())
+			server, err := deriveEpochKeys(gateway.ModeServer, serverSecret, psk, 42)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(client.Out).To(Equal(server.In))
+			Expect(client.In).To(Equal(server.Out))
+			Expect(client.Out.EncKey).To(HaveLen(encKeyLen))
+			Expect(client.Out.AuthKey).To(HaveLen(authKeyLen))
+			Expect(client.Out.EncKey).ToNot(Equal(client.In.EncKey))
+			Expect(client.Out.SPI).ToNot(Equal(client.In.SPI))
+			Expect(client.Out.SPI).To(BeNumerically(">=", minSPI))
+		})
+
+		It("should derive different keys for each epoch", func() {
+			current, err := deriveEpochKeys(gateway.ModeClient, clientSecret, psk, 42)
+			Expect(err).ToNot(HaveOccurred())
+			next, err := deriveEpochKeys(gateway.ModeClient, clientSecret, psk, 43)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(next.Out.EncKey).ToNot(Equal(current.Out.EncKey))
+			Expect(next.Out.AuthKey).ToNot(Equal(current.Out.AuthKey))
+			Expect(next.Out.SPI).ToNot(Equal(current.Out.SPI))
+		})
+
+		It("should mix the pre-shared key in the derivation", func() {
+			withPSK, err := deriveEpochKeys(gateway.ModeClient, clientSecret, psk, 42)
+			Expect(err).ToNot(HaveOccurred())
+			withoutPSK, err := deriveEpochKeys(gateway.ModeClient, clientSecret, nil, 42)
+			Expect(err).ToNot(HaveOccurred())
+			otherPSK, err := deriveEpochKeys(gateway.ModeClient, clientSecret, bytes.Repeat([]byte{8}, MinPresharedKeyLen), 42)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(withPSK.Out.EncKey).ToNot(Equal(withoutPSK.Out.EncKey))
+			Expect(withPSK.Out.EncKey).ToNot(Equal(otherPSK.Out.EncKey))
+		})
+
+		It("should skip the reserved SPIs", func() {
+			Expect(toSPI([]byte{0, 0, 0, 1})).To(BeNumerically(">=", minSPI))
+			Expect(toSPI([]byte{0, 0, 0x10, 0})).To(Equal(uint32(0x1000)))
+		})
+	})
+})
@@ -0,0 +1,81 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	"k8s.io/klog/v2"
+	netutils "k8s.io/utils/net"
+
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+)
+
+// xfrmIfID is the identifier binding the XFRM interface to the security associations and policies managed by the gateway.
+const xfrmIfID = 1
+
+// InitIPsecLink inits the XFRM interface.
+func InitIPsecLink(options *Options) error {
+	exists, err := existsLink()
+	if err != nil {
+		return fmt.Errorf("cannot check if IPsec interface exists: %w", err)
+	}
+	if exists {
+		klog.Infof("IPsec interface %q already exists", tunnel.TunnelInterfaceName)
+		return nil
+	}
+
+	if err := netlink.LinkAdd(&netlink.Xfrmi{
+		LinkAttrs: netlink.LinkAttrs{
+			MTU:  options.MTU,
+			Name: tunnel.TunnelInterfaceName,
+		},
+		Ifid: xfrmIfID,
+	}); err != nil {
+		return fmt.Errorf("cannot create IPsec interface: %w", err)
+	}
+
+	link, err := tunnel.GetLink(tunnel.TunnelInterfaceName)
+	if err != nil {
+		return fmt.Errorf("cannot get IPsec interface: %w", err)
+	}
+
+	for _, ip := range tunnel.GetInterfaceIPs(options.GwOptions.Mode) {
+		klog.Infof("Setting up IPsec interface %q with IP %q", tunnel.TunnelInterfaceName, ip)
+		if err := tunnel.AddAddress(link, ip); err != nil {
+			// IPv6 might be disabled in IPv4-only clusters: in that case, the interface is configured with the IPv4 address only.
+			if netutils.IsIPv6CIDRString(ip) {
+				klog.Warningf("Unable to configure the IPv6 address %q on the IPsec interface (is IPv6 disabled?): %v", ip, err)
+				continue
+			}
+			return err
+		}
+	}
+
+	return netlink.LinkSetUp(link)
+}
+
+func existsLink() (bool, error) {
+	_, err := tunnel.GetLink(tunnel.TunnelInterfaceName)
+	if err != nil {
+		if errors.As(err, &netlink.LinkNotFoundError{}) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
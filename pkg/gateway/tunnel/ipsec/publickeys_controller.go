@@ -0,0 +1,38 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+)
+
+// NewPublicKeysReconciler returns a new PublicKeysReconciler configuring the session with the remote PublicKey.
+func NewPublicKeysReconciler(cl client.Client, s *runtime.Scheme, er record.EventRecorder,
+	options *Options, session *Session) *tunnel.PublicKeysReconciler {
+	return tunnel.NewPublicKeysReconciler(cl, s, er, &options.EndpointOptions,
+		networkingv1beta1.IPsecGatewayServerKind, networkingv1beta1.IPsecGatewayClientKind,
+		func(publicKey []byte) error {
+			if err := session.SetPeerPublicKey(publicKey); err != nil {
+				return err
+			}
+			session.Wakeup()
+			return nil
+		})
+}
@@ -0,0 +1,296 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/liqotech/liqo/pkg/gateway"
+)
+
+// MinRekeyInterval is the minimum interval after which the security associations are rekeyed.
+const MinRekeyInterval = time.Minute
+
+// natKeepalive is the NAT-keepalive packet defined by RFC 3948, discarded by the kernel of the receiver.
+var natKeepalive = []byte{0xff}
+
+var _ manager.Runnable = &Session{}
+
+// Session manages the security associations shared with the remote gateway.
+//
+// No key exchange protocol is involved: the security associations are keyed directly from the static ECDH secret
+// between the keys exchanged during the peering, salted with the optional pre-shared key. The time is split in epochs
+// as long as the rekey interval, and each epoch uses its own keys and SPIs: the outbound security association of the
+// current epoch is installed together with the inbound ones of the previous, the current and the next epoch, hence
+// the gateways switch to the new keys at each epoch boundary without any coordination (given synchronized clocks).
+//
+// ESP is encapsulated in UDP, and the server learns the (possibly NATted) endpoint of the client from the mapping
+// events emitted by the kernel, once an ESP packet of the client is authenticated. The client periodically sends
+// NAT-keepalive packets, which keep the mappings alive.
+type Session struct {
+	opts *Options
+
+	mu            sync.Mutex
+	peerPublicKey []byte
+	secret        []byte
+	local         net.IP
+	remote        *net.UDPAddr
+	conns         map[string]*net.UDPConn
+
+	wakeup chan struct{}
+}
+
+// NewSession returns a new Session.
+func NewSession(opts *Options) (*Session, error) {
+	if opts.PrivateKey == nil {
+		return nil, fmt.Errorf("the private key has not been loaded")
+	}
+	if opts.RekeyInterval < MinRekeyInterval || opts.RekeyInterval%time.Second != 0 {
+		return nil, fmt.Errorf("the rekey interval must be a whole number of seconds, and at least %s", MinRekeyInterval)
+	}
+	if opts.ReplayWindow < 0 || opts.ReplayWindow > MaxReplayWindow {
+		return nil, fmt.Errorf("the replay window must be between 0 and %d", MaxReplayWindow)
+	}
+
+	var local net.IP
+	if opts.LocalAddress != "" {
+		if local = net.ParseIP(opts.LocalAddress); local == nil {
+			return nil, fmt.Errorf("invalid local address %q", opts.LocalAddress)
+		}
+	}
+	if opts.GwOptions.Mode == gateway.ModeServer && local == nil {
+		return nil, fmt.Errorf("the local address is required in server mode")
+	}
+
+	return &Session{
+		opts:   opts,
+		local:  local,
+		conns:  map[string]*net.UDPConn{},
+		wakeup: make(chan struct{}, 1),
+	}, nil
+}
+
+// SetPeerPublicKey configures the public key of the remote gateway, from which the keys of the security associations are derived.
+func (s *Session) SetPeerPublicKey(der []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if bytes.Equal(s.peerPublicKey, der) {
+		return nil
+	}
+	key, err := ParsePublicKey(der)
+	if err != nil {
+		return err
+	}
+	secret, err := sharedSecret(s.opts.PrivateKey, key)
+	if err != nil {
+		return err
+	}
+	s.peerPublicKey, s.secret = bytes.Clone(der), secret
+	s.Wakeup()
+	return nil
+}
+
+// Wakeup triggers the reconfiguration of the security associations (e.g., when the endpoint address changed).
+func (s *Session) Wakeup() {
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Start configures the security associations, and keeps them up to date until the context is canceled.
+func (s *Session) Start(ctx context.Context) error {
+	if err := cleanupXfrm(); err != nil {
+		return err
+	}
+
+	for _, network := range []string{"udp4", "udp6"} {
+		conn, err := listenEncap(network, s.opts.ListenPort)
+		if err != nil {
+			// IPv6 might be disabled in IPv4-only clusters.
+			if network == "udp6" {
+				klog.Warningf("Unable to open the IPv6 encapsulation socket (is IPv6 disabled?): %v", err)
+				continue
+			}
+			return err
+		}
+		defer conn.Close()
+		s.conns[network] = conn
+	}
+
+	var mappings chan *mapping
+	var keepalive <-chan time.Time
+	if s.opts.GwOptions.Mode == gateway.ModeServer {
+		mappings = make(chan *mapping)
+		if err := monitorMappings(ctx, mappings); err != nil {
+			return err
+		}
+	} else {
+		ticker := time.NewTicker(s.opts.KeepaliveInterval)
+		defer ticker.Stop()
+		keepalive = ticker.C
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return cleanupXfrm()
+		case <-timer.C:
+			// A new epoch begins: the security associations are rekeyed.
+			now := time.Now()
+			timer.Reset(time.Unix((epochOf(now, s.opts.RekeyInterval)+1)*int64(s.opts.RekeyInterval/time.Second), 0).Sub(now))
+		case <-keepalive:
+			s.sendKeepalive()
+		case m, ok := <-mappings:
+			if !ok {
+				return fmt.Errorf("the XFRM mapping events monitor terminated unexpectedly")
+			}
+			s.setRemote(m)
+		case <-s.wakeup:
+		}
+
+		if err := s.sync(time.Now()); err != nil {
+			klog.Errorf("Unable to configure the IPsec security associations: %v", err)
+		}
+	}
+}
+
+// setRemote configures the endpoint of the client, learned from a mapping event (server only).
+func (s *Session) setRemote(m *mapping) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if addrEqual(s.remote, m.Addr) {
+		return
+	}
+	klog.Infof("IPsec client endpoint detected at %s (SPI 0x%x)", m.Addr, m.SPI)
+	s.remote = m.Addr
+}
+
+// sendKeepalive sends a NAT-keepalive packet to the server (client only).
+func (s *Session) sendKeepalive() {
+	endpoint := s.clientEndpoint()
+	if endpoint == nil {
+		return
+	}
+	conn := s.conns["udp6"]
+	if endpoint.IP.To4() != nil {
+		conn = s.conns["udp4"]
+	}
+	if conn == nil {
+		return
+	}
+	if _, err := conn.WriteToUDP(natKeepalive, endpoint); err != nil {
+		klog.Warningf("Unable to send the NAT-keepalive packet to %s: %v", endpoint, err)
+	}
+}
+
+// sync configures the security associations of the current epoch, and the policies towards the peer endpoint.
+func (s *Session) sync(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.secret == nil {
+		return nil
+	}
+	ep, err := s.endpoints()
+	if err != nil || ep == nil {
+		return err
+	}
+
+	epoch := epochOf(now, s.opts.RekeyInterval)
+	var states []*netlink.XfrmState
+	for e := epoch - 1; e <= epoch+1; e++ {
+		keys, err := deriveEpochKeys(s.opts.GwOptions.Mode, s.secret, s.opts.PresharedKey, e)
+		if err != nil {
+			return err
+		}
+		states = append(states, forgeState(ep.remoteOrAny(), ep.Local, ep.RemotePort, ep.LocalPort, &keys.In, s.opts.ReplayWindow))
+		if e == epoch && ep.Remote != nil {
+			out := forgeState(ep.Local, ep.Remote, ep.LocalPort, ep.RemotePort, &keys.Out, s.opts.ReplayWindow)
+			out.Replay = &netlink.XfrmReplayState{OSeq: initialSeq(now, s.opts.RekeyInterval)}
+			states = append(states, out)
+		}
+	}
+
+	if err := syncStates(states); err != nil {
+		return err
+	}
+	return installPolicies(ep)
+}
+
+// endpoints returns the outer addresses of the tunnel, or nil if the endpoint of the server is not known yet (client only).
+func (s *Session) endpoints() (*tunnelEndpoints, error) {
+	if s.opts.GwOptions.Mode == gateway.ModeServer {
+		ep := &tunnelEndpoints{Local: s.local, LocalPort: s.opts.ListenPort}
+		if s.remote != nil {
+			ep.Remote, ep.RemotePort = s.remote.IP, s.remote.Port
+		}
+		return ep, nil
+	}
+
+	endpoint := s.clientEndpoint()
+	if endpoint == nil {
+		return nil, nil
+	}
+	local := s.local
+	if local == nil {
+		var err error
+		if local, err = localAddressTowards(endpoint.IP); err != nil {
+			return nil, err
+		}
+	}
+	return &tunnelEndpoints{Local: local, LocalPort: s.opts.ListenPort, Remote: endpoint.IP, RemotePort: endpoint.Port}, nil
+}
+
+func (s *Session) clientEndpoint() *net.UDPAddr {
+	ip := s.opts.GetEndpointIP()
+	if ip == nil {
+		return nil
+	}
+	return &net.UDPAddr{IP: ip, Port: s.opts.EndpointPort}
+}
+
+// epochOf returns the epoch the given time belongs to.
+func epochOf(t time.Time, interval time.Duration) int64 {
+	return t.Unix() / int64(interval/time.Second)
+}
+
+// initialSeq returns the initial sequence number of an outbound security association installed at the given time.
+// The sequence numbers grow with the time elapsed since the beginning of the epoch, so that a restarted gateway does not
+// reuse the sequence numbers already sent with the same keys, which would be discarded by the anti-replay check of the peer.
+// Half of the sequence numbers space is left to the packets sent after the installation.
+func initialSeq(t time.Time, interval time.Duration) uint32 {
+	seconds := int64(interval / time.Second)
+	return uint32(t.Unix() % seconds * (1 << 31) / seconds)
+}
+
+func addrEqual(a, b *net.UDPAddr) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.IP.Equal(b.IP) && a.Port == b.Port
+}
@@ -0,0 +1,66 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink/nl"
+)
+
+var _ = Describe("Session", func() {
+	const interval = 10 * time.Minute
+
+	It("should split the time in epochs as long as the rekey interval", func() {
+		start := time.Unix(42*600, 0)
+		Expect(epochOf(start, interval)).To(BeEquivalentTo(42))
+		Expect(epochOf(start.Add(interval-time.Second), interval)).To(BeEquivalentTo(42))
+		Expect(epochOf(start.Add(interval), interval)).To(BeEquivalentTo(43))
+	})
+
+	It("should grow the initial sequence numbers within an epoch", func() {
+		start := time.Unix(42*600, 0)
+		Expect(initialSeq(start, interval)).To(BeZero())
+
+		previous := initialSeq(start.Add(time.Second), interval)
+		Expect(previous).To(BeNumerically(">", 0))
+		for elapsed := 2 * time.Second; elapsed < interval; elapsed += time.Second {
+			seq := initialSeq(start.Add(elapsed), interval)
+			Expect(seq).To(BeNumerically(">", previous))
+			previous = seq
+		}
+		Expect(previous).To(BeNumerically("<", uint32(1<<31)))
+	})
+
+	It("should parse the mapping events", func() {
+		b := make([]byte, sizeofXfrmUserMapping)
+		id := nl.DeserializeXfrmUsersaId(b[:nl.SizeofXfrmUsersaId])
+		id.Family = nl.FAMILY_V4
+		binary.BigEndian.PutUint32(b[16:20], 0x1234)
+		copy(b[28:32], net.ParseIP("10.0.0.1").To4())
+		copy(b[44:48], net.ParseIP("192.168.1.10").To4())
+		binary.BigEndian.PutUint16(b[60:62], 4500)
+		binary.BigEndian.PutUint16(b[62:64], 31000)
+
+		m := parseMapping(b)
+		Expect(m.SPI).To(BeEquivalentTo(0x1234))
+		Expect(m.Addr.IP.Equal(net.ParseIP("192.168.1.10"))).To(BeTrue())
+		Expect(m.Addr.Port).To(Equal(31000))
+	})
+})
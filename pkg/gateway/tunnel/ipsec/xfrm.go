@@ -0,0 +1,326 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// encAlgorithm is the cipher protecting the confidentiality of the traffic (AES-256-CBC).
+	// AES-GCM is not used, since its security breaks down if a nonce is ever reused with manually configured keys (RFC 4106).
+	encAlgorithm = "cbc(aes)"
+	// authAlgorithm is the algorithm protecting the integrity of the traffic (HMAC-SHA-256-128, RFC 4868).
+	authAlgorithm = "hmac(sha256)"
+	// authTruncLen is the length (in bits) of the integrity check value.
+	authTruncLen = 128
+	// reqID is the request identifier linking the policies to the security associations.
+	reqID = 1
+
+	// MaxReplayWindow is the maximum size of the anti-replay window of the security associations,
+	// which use 32 bits sequence numbers, since the extended ones cannot be initialized to a given value.
+	MaxReplayWindow = 32
+
+	// sizeofXfrmUserMapping is the size of the xfrm_user_mapping struct carried by the XFRM_MSG_MAPPING messages:
+	// the xfrm_usersa_id (24 bytes), the reqid, the old and the new source addresses, and the old and the new source ports.
+	sizeofXfrmUserMapping = 64
+)
+
+// tunnelEndpoints describes the outer addresses of the tunnel.
+// The remote address is nil until the server learns the (possibly NATted) endpoint of the client.
+type tunnelEndpoints struct {
+	Local      net.IP
+	LocalPort  int
+	Remote     net.IP
+	RemotePort int
+}
+
+// remoteOrAny returns the remote address, or the unspecified address of the same family of the local one if it is unknown.
+func (ep *tunnelEndpoints) remoteOrAny() net.IP {
+	switch {
+	case ep.Remote != nil:
+		return ep.Remote
+	case ep.Local.To4() != nil:
+		return net.IPv4zero
+	default:
+		return net.IPv6zero
+	}
+}
+
+// mapping is a NAT mapping detected by the kernel, i.e., an authenticated ESP packet received from an unexpected address.
+type mapping struct {
+	SPI  uint32
+	Addr *net.UDPAddr
+}
+
+// anySelectors are the inner selectors of the policies: every packet routed to the XFRM interface is protected.
+var anySelectors = []*net.IPNet{
+	{IP: net.IPv4zero, Mask: net.CIDRMask(0, net.IPv4len*8)},
+	{IP: net.IPv6zero, Mask: net.CIDRMask(0, net.IPv6len*8)},
+}
+
+// forgeState forges a security association protecting the traffic from src to dst with the given keys.
+func forgeState(src, dst net.IP, srcPort, dstPort int, keys *saKeys, replayWindow int) *netlink.XfrmState {
+	return &netlink.XfrmState{
+		Src:          src,
+		Dst:          dst,
+		Proto:        netlink.XFRM_PROTO_ESP,
+		Mode:         netlink.XFRM_MODE_TUNNEL,
+		Spi:          int(keys.SPI),
+		Reqid:        reqID,
+		ReplayWindow: replayWindow,
+		Ifid:         xfrmIfID,
+		Crypt: &netlink.XfrmStateAlgo{
+			Name: encAlgorithm,
+			Key:  keys.EncKey,
+		},
+		Auth: &netlink.XfrmStateAlgo{
+			Name:        authAlgorithm,
+			Key:         keys.AuthKey,
+			TruncateLen: authTruncLen,
+		},
+		Encap: &netlink.XfrmStateEncap{
+			Type:            netlink.XFRM_ENCAP_ESPINUDP,
+			SrcPort:         srcPort,
+			DstPort:         dstPort,
+			OriginalAddress: net.IPv4zero,
+		},
+	}
+}
+
+// forgePolicies forges the policies steering the traffic of the XFRM interface through the security associations.
+// The outbound policies are forged only once the remote endpoint is known.
+func forgePolicies(ep *tunnelEndpoints) []*netlink.XfrmPolicy {
+	dirs := []netlink.Dir{netlink.XFRM_DIR_IN, netlink.XFRM_DIR_FWD}
+	if ep.Remote != nil {
+		dirs = append(dirs, netlink.XFRM_DIR_OUT)
+	}
+
+	var policies []*netlink.XfrmPolicy
+	for _, sel := range anySelectors {
+		for _, dir := range dirs {
+			src, dst := ep.remoteOrAny(), ep.Local
+			if dir == netlink.XFRM_DIR_OUT {
+				src, dst = ep.Local, ep.Remote
+			}
+			policies = append(policies, &netlink.XfrmPolicy{
+				Src:  sel,
+				Dst:  sel,
+				Dir:  dir,
+				Ifid: xfrmIfID,
+				Tmpls: []netlink.XfrmPolicyTmpl{{
+					Src:   src,
+					Dst:   dst,
+					Proto: netlink.XFRM_PROTO_ESP,
+					Mode:  netlink.XFRM_MODE_TUNNEL,
+					Reqid: reqID,
+				}},
+			})
+		}
+	}
+	return policies
+}
+
+// installPolicies configures the policies steering the traffic of the XFRM interface towards the given endpoints.
+func installPolicies(ep *tunnelEndpoints) error {
+	for _, policy := range forgePolicies(ep) {
+		if err := netlink.XfrmPolicyUpdate(policy); err != nil {
+			return fmt.Errorf("unable to configure the %s policy for %s: %w", policy.Dir, policy.Dst, err)
+		}
+	}
+	return nil
+}
+
+// syncStates configures the given security associations, and removes the other ones managed by the gateway.
+// The new security associations are added before the old ones are removed (make-before-break), so that the
+// traffic is never left without a matching security association during a rekey. The security associations
+// whose peer endpoint changed are replaced, preserving the anti-replay status of the inbound ones.
+func syncStates(desired []*netlink.XfrmState) error {
+	current, err := listStates()
+	if err != nil {
+		return err
+	}
+
+	for _, state := range desired {
+		idx := slices.IndexFunc(current, func(c netlink.XfrmState) bool { return sameState(&c, state) })
+		if idx >= 0 {
+			if current[idx].Src.Equal(state.Src) && current[idx].Encap != nil &&
+				current[idx].Encap.SrcPort == state.Encap.SrcPort && current[idx].Encap.DstPort == state.Encap.DstPort {
+				continue
+			}
+			if state.Replay == nil {
+				state.Replay = current[idx].Replay
+			}
+			if err := netlink.XfrmStateDel(&current[idx]); err != nil {
+				return fmt.Errorf("unable to delete the security association with SPI 0x%x: %w", current[idx].Spi, err)
+			}
+		}
+		if err := netlink.XfrmStateAdd(state); err != nil && !errors.Is(err, unix.EEXIST) {
+			return fmt.Errorf("unable to add the security association with SPI 0x%x: %w", state.Spi, err)
+		}
+	}
+
+	for i := range current {
+		if slices.ContainsFunc(desired, func(d *netlink.XfrmState) bool { return sameState(&current[i], d) }) {
+			continue
+		}
+		if err := netlink.XfrmStateDel(&current[i]); err != nil && !errors.Is(err, unix.ESRCH) {
+			return fmt.Errorf("unable to delete the security association with SPI 0x%x: %w", current[i].Spi, err)
+		}
+	}
+	return nil
+}
+
+// sameState returns whether the given security associations are identified by the same SPI and destination.
+func sameState(a, b *netlink.XfrmState) bool {
+	return a.Spi == b.Spi && a.Dst.Equal(b.Dst)
+}
+
+// cleanupXfrm removes the security associations and the policies bound to the XFRM interface.
+func cleanupXfrm() error {
+	if err := syncStates(nil); err != nil {
+		return err
+	}
+
+	policies, err := netlink.XfrmPolicyList(netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("unable to list the IPsec policies: %w", err)
+	}
+	for i := range policies {
+		if policies[i].Ifid != xfrmIfID {
+			continue
+		}
+		if err := netlink.XfrmPolicyDel(&policies[i]); err != nil {
+			return fmt.Errorf("unable to delete the IPsec policy for %s: %w", policies[i].Dst, err)
+		}
+	}
+	return nil
+}
+
+// listStates returns the security associations bound to the XFRM interface.
+func listStates() ([]netlink.XfrmState, error) {
+	states, err := netlink.XfrmStateList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the security associations: %w", err)
+	}
+	var res []netlink.XfrmState
+	for i := range states {
+		if states[i].Ifid == xfrmIfID {
+			res = append(res, states[i])
+		}
+	}
+	return res, nil
+}
+
+// monitorMappings notifies the NAT mappings detected by the kernel, which emits an event when an ESP packet,
+// once authenticated by an inbound security association, is received from an address or port different from
+// the ones configured in the security association. The channel is closed when the monitor terminates.
+func monitorMappings(ctx context.Context, ch chan<- *mapping) error {
+	s, err := nl.Subscribe(unix.NETLINK_XFRM, nl.XFRMNLGRP_MAPPING)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to the XFRM mapping events: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.Close()
+	}()
+
+	go func() {
+		defer close(ch)
+		for {
+			msgs, from, err := s.Receive()
+			switch {
+			case errors.Is(err, unix.ENOBUFS):
+				// Some events have been lost, but the following ones carry the same information.
+				continue
+			case err != nil:
+				if ctx.Err() == nil {
+					klog.Errorf("Unable to receive the XFRM mapping events: %v", err)
+				}
+				return
+			case from.Pid != nl.PidKernel:
+				continue
+			}
+			for i := range msgs {
+				if msgs[i].Header.Type != nl.XFRM_MSG_MAPPING || len(msgs[i].Data) < sizeofXfrmUserMapping {
+					continue
+				}
+				select {
+				case ch <- parseMapping(msgs[i].Data):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// parseMapping parses the xfrm_user_mapping struct carried by a XFRM_MSG_MAPPING message.
+func parseMapping(b []byte) *mapping {
+	id := nl.DeserializeXfrmUsersaId(b[:nl.SizeofXfrmUsersaId])
+	newAddr := nl.DeserializeXfrmAddress(b[44:60]).ToIP()
+	if id.Family == nl.FAMILY_V4 {
+		newAddr = newAddr.To4()
+	}
+	return &mapping{
+		SPI:  binary.BigEndian.Uint32(b[16:20]),
+		Addr: &net.UDPAddr{IP: newAddr, Port: int(binary.BigEndian.Uint16(b[62:64]))},
+	}
+}
+
+// listenEncap opens an UDP socket configured to decapsulate the ESP packets it receives.
+func listenEncap(network string, port int) (*net.UDPConn, error) {
+	conn, err := net.ListenUDP(network, &net.UDPAddr{Port: port})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the %s encapsulation socket: %w", network, err)
+	}
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	var sockErr error
+	if err := raw.Control(func(fd uintptr) {
+		sockErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_UDP, unix.UDP_ENCAP, unix.UDP_ENCAP_ESPINUDP)
+	}); err != nil || sockErr != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to enable the ESP encapsulation on the %s socket: %w", network, errors.Join(err, sockErr))
+	}
+	return conn, nil
+}
+
+// localAddressTowards returns the source address used to reach the given destination.
+func localAddressTowards(dst net.IP) (net.IP, error) {
+	routes, err := netlink.RouteGet(dst)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the route towards %s: %w", dst, err)
+	}
+	if len(routes) == 0 || routes[0].Src == nil {
+		return nil, fmt.Errorf("no source address available to reach %s", dst)
+	}
+	return routes[0].Src, nil
+}
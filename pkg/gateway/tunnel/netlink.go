@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/liqotech/liqo/pkg/gateway"
 )
@@ -27,6 +28,10 @@ const (
 	ServerInterfaceIP = "169.254.18.1/30"
 	// ClientInterfaceIP is the IP address of the Wireguard interface in client mode.
 	ClientInterfaceIP = "169.254.18.2/30"
+	// ServerInterfaceIPv6 is the IPv6 address of the Wireguard interface in server mode, used to route the IPv6 traffic.
+	ServerInterfaceIPv6 = "fd00:a9fe:1201::1/126"
+	// ClientInterfaceIPv6 is the IPv6 address of the Wireguard interface in client mode, used to route the IPv6 traffic.
+	ClientInterfaceIPv6 = "fd00:a9fe:1201::2/126"
 )
 
 // AddAddress adds an IP address to the Wireguard interface.
@@ -55,14 +60,40 @@ func GetInterfaceIP(mode gateway.Mode) string {
 	return ""
 }
 
+// GetInterfaceIPs returns the IP addresses of the Wireguard interface, one for each IP family.
+func GetInterfaceIPs(mode gateway.Mode) []string {
+	switch mode {
+	case gateway.ModeServer:
+		return []string{ServerInterfaceIP, ServerInterfaceIPv6}
+	case gateway.ModeClient:
+		return []string{ClientInterfaceIP, ClientInterfaceIPv6}
+	}
+	return nil
+}
+
 // GetRemoteInterfaceIP returns the IP address of the remote Wireguard interface.
 func GetRemoteInterfaceIP(mode gateway.Mode) (string, error) {
+	return GetRemoteInterfaceIPByFamily(mode, corev1.IPv4Protocol)
+}
+
+// GetRemoteInterfaceIPByFamily returns the IP address of the given family of the remote Wireguard interface.
+func GetRemoteInterfaceIPByFamily(mode gateway.Mode, family corev1.IPFamily) (string, error) {
+	var serverIP, clientIP string
+	switch family {
+	case corev1.IPv4Protocol:
+		serverIP, clientIP = ServerInterfaceIP, ClientInterfaceIP
+	case corev1.IPv6Protocol:
+		serverIP, clientIP = ServerInterfaceIPv6, ClientInterfaceIPv6
+	default:
+		return "", fmt.Errorf("invalid IP family %v", family)
+	}
+
 	switch mode {
 	case gateway.ModeServer:
-		ip, err := netlink.ParseIPNet(ClientInterfaceIP)
+		ip, err := netlink.ParseIPNet(clientIP)
 		return ip.IP.String(), err
 	case gateway.ModeClient:
-		ip, err := netlink.ParseIPNet(ServerInterfaceIP)
+		ip, err := netlink.ParseIPNet(serverIP)
 		return ip.IP.String(), err
 	}
 	return "", fmt.Errorf("invalid mode %v", mode)
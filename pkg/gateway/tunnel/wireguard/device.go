@@ -32,8 +32,11 @@ func configureDevice(wgcl *wgctrl.Client, options *Options, peerPubKey wgtypes.K
 		ListenPort: nil,
 		Peers: []wgtypes.PeerConfig{
 			{
-				PublicKey:  peerPubKey,
-				AllowedIPs: []net.IPNet{{IP: net.IP{0, 0, 0, 0}, Mask: net.CIDRMask(0, 32)}},
+				PublicKey: peerPubKey,
+				AllowedIPs: []net.IPNet{
+					{IP: net.IPv4zero, Mask: net.CIDRMask(0, net.IPv4len*8)},
+					{IP: net.IPv6zero, Mask: net.CIDRMask(0, net.IPv6len*8)},
+				},
 			},
 		},
 		ReplacePeers: true,
@@ -44,7 +47,7 @@ func configureDevice(wgcl *wgctrl.Client, options *Options, peerPubKey wgtypes.K
 		confdev.ListenPort = &options.ListenPort
 	case gateway.ModeClient:
 		confdev.Peers[0].Endpoint = &net.UDPAddr{
-			IP:   options.EndpointIP,
+			IP:   options.GetEndpointIP(),
 			Port: options.EndpointPort,
 		}
 	}
@@ -42,6 +42,8 @@ const (
 	FlagNameEndpointAddress FlagName = "endpoint-address"
 	// FlagNameEndpointPort is the port of the endpoint for the wireguard interface.
 	FlagNameEndpointPort FlagName = "endpoint-port"
+	// FlagNameReplicaEndpoints is the list of endpoints of the server replicas, in active-active mode.
+	FlagNameReplicaEndpoints FlagName = "replica-endpoints"
 	// FlagNameKeysDir is the directory where the keys are stored.
 	FlagNameKeysDir FlagName = "keys-dir"
 
@@ -63,6 +65,8 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.IntVar(&opts.ListenPort, FlagNameListenPort.String(), forge.DefaultGwServerPort, "Listen port (server only)")
 	flagset.StringVar(&opts.EndpointAddress, FlagNameEndpointAddress.String(), "", "Endpoint address (client only)")
 	flagset.IntVar(&opts.EndpointPort, FlagNameEndpointPort.String(), forge.DefaultGwServerPort, "Endpoint port (client only)")
+	flagset.StringSliceVar(&opts.ReplicaEndpoints, FlagNameReplicaEndpoints.String(), []string{},
+		"Endpoints of the server replicas in the <index>=<address>:<port> format (client only, active-active mode)")
 	flagset.StringVar(&opts.KeysDir, FlagNameKeysDir.String(), forge.DefaultKeysDir, "Directory where the keys are stored")
 
 	flagset.DurationVar(&opts.DNSCheckInterval, FlagNameDNSCheckInterval.String(), 5*time.Minute, "Interval between two DNS checks")
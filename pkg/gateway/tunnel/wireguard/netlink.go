@@ -27,6 +27,7 @@ import (
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
+	netutils "k8s.io/utils/net"
 
 	"github.com/liqotech/liqo/pkg/gateway"
 	"github.com/liqotech/liqo/pkg/gateway/tunnel"
@@ -52,9 +53,16 @@ func InitWireguardLink(ctx context.Context, options *Options) error {
 		return fmt.Errorf("cannot get Wireguard interface: %w", err)
 	}
 
-	klog.Infof("Setting up Wireguard interface %q with IP %q", tunnel.TunnelInterfaceName, tunnel.GetInterfaceIP(options.GwOptions.Mode))
-	if err := tunnel.AddAddress(link, tunnel.GetInterfaceIP(options.GwOptions.Mode)); err != nil {
-		return err
+	for _, ip := range tunnel.GetInterfaceIPs(options.GwOptions.Mode) {
+		klog.Infof("Setting up Wireguard interface %q with IP %q", tunnel.TunnelInterfaceName, ip)
+		if err := tunnel.AddAddress(link, ip); err != nil {
+			// IPv6 might be disabled in IPv4-only clusters: in that case, the interface is configured with the IPv4 address only.
+			if netutils.IsIPv6CIDRString(ip) {
+				klog.Warningf("Unable to configure the IPv6 address %q on the Wireguard interface (is IPv6 disabled?): %v", ip, err)
+				continue
+			}
+			return err
+		}
 	}
 
 	return netlink.LinkSetUp(link)
@@ -16,13 +16,11 @@ package wireguard
 
 import (
 	"fmt"
-	"net"
-	"sync"
-	"time"
 
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
 	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
 )
 
 // WgImplementation represents the implementation of the wireguard interface.
@@ -59,20 +57,15 @@ func (wgi WgImplementation) Type() string {
 
 // Options contains the options for the wireguard interface.
 type Options struct {
-	GwOptions *gateway.Options
+	tunnel.EndpointOptions
 
-	MTU             int
-	PrivateKey      wgtypes.Key
-	InterfaceIP     string
-	ListenPort      int
-	EndpointAddress string
-	EndpointPort    int
-	KeysDir         string
+	MTU         int
+	PrivateKey  wgtypes.Key
+	InterfaceIP string
+	ListenPort  int
+	KeysDir     string
 
-	EndpointIP      net.IP
-	EndpointIPMutex *sync.Mutex
-
-	DNSCheckInterval time.Duration
+	ReplicaEndpoints []string
 
 	Implementation WgImplementation
 }
@@ -80,7 +73,6 @@ type Options struct {
 // NewOptions returns a new Options struct.
 func NewOptions(options *gateway.Options) *Options {
 	return &Options{
-		GwOptions:       options,
-		EndpointIPMutex: &sync.Mutex{},
+		EndpointOptions: tunnel.NewEndpointOptions(options),
 	}
 }
@@ -0,0 +1,99 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/liqotech/liqo/pkg/gateway/concurrent"
+)
+
+// SetReplicaEndpoint configures the endpoint of the server replica paired with the current client replica,
+// that is the one with the same replica index. It is a no-op if no replica endpoint has been provided.
+func SetReplicaEndpoint(ctx context.Context, cl client.Reader, options *Options) error {
+	if len(options.ReplicaEndpoints) == 0 {
+		return nil
+	}
+
+	var pod corev1.Pod
+	podKey := client.ObjectKey{Namespace: options.GwOptions.Namespace, Name: options.GwOptions.PodName}
+	if err := cl.Get(ctx, podKey, &pod); err != nil {
+		return fmt.Errorf("unable to get pod %q: %w", podKey, err)
+	}
+	index, ok := concurrent.GetReplicaIndex(&pod)
+	if !ok {
+		return fmt.Errorf("pod %q has no replica index", podKey)
+	}
+
+	endpoints, err := ParseReplicaEndpoints(options.ReplicaEndpoints)
+	if err != nil {
+		return err
+	}
+	endpoint, ok := endpoints[index]
+	if !ok {
+		return fmt.Errorf("no endpoint found for the server replica %d", index)
+	}
+
+	host, port, err := splitHostPort(endpoint)
+	if err != nil {
+		return err
+	}
+	options.EndpointAddress = host
+	options.EndpointPort = port
+	klog.Infof("Connecting to the server replica %d at %s", index, endpoint)
+	return nil
+}
+
+// ParseReplicaEndpoints parses the endpoints of the server replicas, provided in the <index>=<address>:<port> format.
+// Empty entries are ignored.
+func ParseReplicaEndpoints(values []string) (map[int]string, error) {
+	endpoints := make(map[int]string, len(values))
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		rawIndex, endpoint, found := strings.Cut(value, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid replica endpoint %q: expected <index>=<address>:<port>", value)
+		}
+		index, err := strconv.Atoi(rawIndex)
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("invalid index in replica endpoint %q", value)
+		}
+		endpoints[index] = endpoint
+	}
+	return endpoints, nil
+}
+
+// splitHostPort splits an endpoint in the <address>:<port> format. Differently from net.SplitHostPort,
+// IPv6 addresses are accepted also without square brackets, as the port is always the last element.
+func splitHostPort(endpoint string) (host string, port int, err error) {
+	sep := strings.LastIndex(endpoint, ":")
+	if sep <= 0 {
+		return "", 0, fmt.Errorf("invalid endpoint %q: expected <address>:<port>", endpoint)
+	}
+	if port, err = strconv.Atoi(endpoint[sep+1:]); err != nil {
+		return "", 0, fmt.Errorf("invalid port in endpoint %q: %w", endpoint, err)
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(endpoint[:sep], "["), "]")
+	return host, port, nil
+}
@@ -123,3 +123,9 @@ func (certManager *identityManager) isAwsIdentity(secret *v1.Secret) bool {
 	}
 	return true
 }
+
+// isOIDCIdentity returns whether the secret contains an identity backed by an OIDC token.
+func isOIDCIdentity(secret *v1.Secret) bool {
+	_, ok := secret.Data[OIDCTokenSecretKey]
+	return ok
+}
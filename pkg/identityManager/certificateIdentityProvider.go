@@ -19,10 +19,9 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
-	"strconv"
+	"strings"
 	"time"
 
-	certv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,9 +34,9 @@ import (
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 	responsetypes "github.com/liqotech/liqo/pkg/identityManager/responseTypes"
+	"github.com/liqotech/liqo/pkg/identityManager/signer"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 	"github.com/liqotech/liqo/pkg/utils/apiserver"
-	certificateSigningRequest "github.com/liqotech/liqo/pkg/utils/csr"
 	"github.com/liqotech/liqo/pkg/utils/resource"
 )
 
@@ -51,11 +50,13 @@ type certificateIdentityProvider struct {
 	k8sClient        kubernetes.Interface
 	cl               client.Client
 	cnf              *rest.Config
-	csrWatcher       certificateSigningRequest.Watcher
+	signer           signer.Interface
 }
 
 // GetRemoteCertificate retrieves a certificate issued in the past,
 // given the clusterid and the signingRequest.
+// In case of renewals (IsUpdate set), it always returns a NotFound error, regardless of the signer backend and
+// even if the stored certificate matches the signing request, so that a fresh certificate is signed.
 func (identityProvider *certificateIdentityProvider) GetRemoteCertificate(ctx context.Context,
 	options *SigningRequestOptions) (response *responsetypes.SigningRequestResponse, err error) {
 	response = &responsetypes.SigningRequestResponse{
@@ -63,6 +64,13 @@ func (identityProvider *certificateIdentityProvider) GetRemoteCertificate(ctx co
 	}
 
 	secretName := remoteCertificateSecretName(options)
+	if options.IsUpdate {
+		return response, kerrors.NewNotFound(schema.GroupResource{
+			Group:    "v1",
+			Resource: "secrets",
+		}, secretName)
+	}
+
 	secret, err := identityProvider.k8sClient.CoreV1().Secrets(options.TenantNamespace).Get(ctx, secretName, metav1.GetOptions{})
 	if err != nil {
 		if kerrors.IsNotFound(err) {
@@ -84,7 +92,7 @@ func (identityProvider *certificateIdentityProvider) GetRemoteCertificate(ctx co
 	}
 
 	// check that this certificate is related to this signing request
-	if !bytes.Equal(signingRequestSecret, options.SigningRequest) && !options.IsUpdate {
+	if !bytes.Equal(signingRequestSecret, options.SigningRequest) {
 		err = kerrors.NewBadRequest(fmt.Sprintf("the stored and the provided CSR for cluster %s does not match", options.Cluster))
 		klog.Error(err)
 		return response, err
@@ -100,60 +108,42 @@ func (identityProvider *certificateIdentityProvider) GetRemoteCertificate(ctx co
 		return response, err
 	}
 
+	if backend, ok := secret.Annotations[signerBackendAnnotation]; ok {
+		response.Signer = &authv1beta1.SignerInfo{
+			Backend: authv1beta1.SignerBackend(backend),
+			Issuer:  secret.Annotations[signerIssuerAnnotation],
+		}
+	}
+
 	return response, nil
 }
 
-// ApproveSigningRequest approves a remote CertificateSigningRequest.
-// It creates a CertificateSigningRequest CR to be issued by the local cluster, and approves it.
-// This function will wait (with a timeout) for an available certificate before returning.
+// ApproveSigningRequest approves a remote certificate signing request.
+// It forwards the request to the configured signer backend (by default, the Kubernetes CertificateSigningRequest API),
+// and stores the issued certificate, in this way it is possible to retrieve it again in the future.
 func (identityProvider *certificateIdentityProvider) ApproveSigningRequest(ctx context.Context,
 	options *SigningRequestOptions) (response *responsetypes.SigningRequestResponse, err error) {
-	cert := &certv1.CertificateSigningRequest{
-		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: identitySecretRoot + "-",
-			Labels:       map[string]string{remoteTenantCSRLabel: strconv.FormatBool(true)},
-		},
-		Spec: certv1.CertificateSigningRequestSpec{
-			Groups: []string{
-				"system:authenticated",
-			},
-			SignerName: certv1.KubeAPIServerClientSignerName,
-			Request:    options.SigningRequest,
-			Usages: []certv1.KeyUsage{
-				certv1.UsageDigitalSignature,
-				certv1.UsageKeyEncipherment,
-				certv1.UsageClientAuth,
-			},
-		},
-	}
-
-	cert, err = identityProvider.k8sClient.CertificatesV1().CertificateSigningRequests().Create(ctx, cert, metav1.CreateOptions{})
+	certificate, signerInfo, err := identityProvider.signer.Sign(ctx, &signer.Request{
+		ClusterID:       options.Cluster,
+		IdentityType:    options.IdentityType,
+		Name:            options.Name,
+		CSR:             options.SigningRequest,
+		TenantNamespace: options.TenantNamespace,
+		Duration:        options.Validity,
+	})
 	if err != nil {
 		klog.Error(err)
 		return response, err
 	}
 
-	// approve the CertificateSigningRequest
-	if err = certificateSigningRequest.Approve(identityProvider.k8sClient, cert, "IdentityManagerApproval",
-		"This CSR was approved by Liqo Identity Manager"); err != nil {
-		klog.Error(err)
-		return response, err
-	}
-
 	response = &responsetypes.SigningRequestResponse{
 		ResponseType: responsetypes.SigningRequestResponseCertificate,
-	}
-	// retrieve the certificate issued by the Kubernetes issuer in the CSR (with a 30 seconds timeout)
-	ctxC, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-	response.Certificate, err = identityProvider.csrWatcher.RetrieveCertificate(ctxC, cert.Name)
-	if err != nil {
-		klog.Error(err)
-		return response, err
+		Certificate:  certificate,
+		Signer:       signerInfo,
 	}
 
 	// store the certificate in a Secret, in this way is possbile to retrieve it again in the future
-	if _, err = identityProvider.storeRemoteCertificate(ctx, options, response.Certificate); err != nil {
+	if _, err = identityProvider.storeRemoteCertificate(ctx, options, response); err != nil {
 		klog.Error(err)
 		return response, err
 	}
@@ -183,9 +173,31 @@ func (identityProvider *certificateIdentityProvider) ForgeAuthParams(ctx context
 		SignedCRT: resp.Certificate,
 		APIServer: apiServer,
 		ProxyURL:  options.ProxyURL,
+		Signer:    resp.Signer,
 	}, nil
 }
 
+// DeleteRemoteCertificates deletes the certificates (both of the control plane and of the ResourceSlices) stored for the
+// consumer cluster in the given tenant namespace, so that they are no longer served, and new ones are issued at the
+// next authentication of the consumer cluster.
+func DeleteRemoteCertificates(ctx context.Context, cl client.Client, tenantNamespace string) error {
+	var secrets corev1.SecretList
+	if err := cl.List(ctx, &secrets, client.InNamespace(tenantNamespace)); err != nil {
+		return err
+	}
+
+	for i := range secrets.Items {
+		name := secrets.Items[i].Name
+		if name != remoteCertificateSecret && !strings.HasPrefix(name, remoteCertificateSecret+"-") {
+			continue
+		}
+		if err := client.IgnoreNotFound(cl.Delete(ctx, &secrets.Items[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func remoteCertificateSecretName(options *SigningRequestOptions) string {
 	switch options.IdentityType {
 	case authv1beta1.ResourceSliceIdentityType:
@@ -197,7 +209,7 @@ func remoteCertificateSecretName(options *SigningRequestOptions) string {
 
 // storeRemoteCertificate stores the issued certificate in a Secret in the TenantNamespace.
 func (identityProvider *certificateIdentityProvider) storeRemoteCertificate(ctx context.Context,
-	options *SigningRequestOptions, certificate []byte) (*corev1.Secret, error) {
+	options *SigningRequestOptions, response *responsetypes.SigningRequestResponse) (*corev1.Secret, error) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      remoteCertificateSecretName(options),
@@ -215,7 +227,15 @@ func (identityProvider *certificateIdentityProvider) storeRemoteCertificate(ctx
 			secret.Data = map[string][]byte{}
 		}
 		secret.Data[csrSecretKey] = options.SigningRequest
-		secret.Data[certificateSecretKey] = certificate
+		secret.Data[certificateSecretKey] = response.Certificate
+
+		if response.Signer != nil {
+			if secret.Annotations == nil {
+				secret.Annotations = map[string]string{}
+			}
+			secret.Annotations[signerBackendAnnotation] = string(response.Signer.Backend)
+			secret.Annotations[signerIssuerAnnotation] = response.Signer.Issuer
+		}
 
 		return nil
 	})
@@ -68,6 +68,10 @@ func (certManager *identityManager) GetConfigFromSecret(remoteCluster liqov1beta
 		return certManager.mutateIAMConfig(secret, remoteCluster, cnf)
 	}
 
+	if isOIDCIdentity(secret) {
+		return certManager.oidcTokenManager.mutateConfig(secret, remoteCluster, cnf)
+	}
+
 	return cnf, nil
 }
 
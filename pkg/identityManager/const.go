@@ -18,7 +18,6 @@ import "github.com/liqotech/liqo/pkg/consts"
 
 const (
 	localIdentitySecretLabel = "liqo.io/local-identity" //nolint:gosec // not a credential
-	remoteTenantCSRLabel     = "liqo.io/remote-tenant-csr"
 	// CertificateAvailableLabel is the label used to identify the secrets containing a certificate.
 	CertificateAvailableLabel = "liqo.io/certificate-available"
 )
@@ -33,6 +32,8 @@ const (
 
 const (
 	certificateExpireTimeAnnotation = "liqo.io/certificate-expire-time"
+	signerBackendAnnotation         = "liqo.io/signer-backend"
+	signerIssuerAnnotation          = "liqo.io/signer-issuer"
 )
 
 const (
@@ -58,4 +59,9 @@ const (
 	AwsEKSClusterIDSecretKey = "awsEksClusterID" //nolint:gosec // not a credential
 	// AwsIAMUserArnSecretKey is the key used for the AWS IAM user ARN inside the secret.
 	AwsIAMUserArnSecretKey = "awsIamUserArn" //nolint:gosec // not a credential
+
+	// OIDCIssuerSecretKey is the key used for the OIDC issuer URL inside the secret.
+	OIDCIssuerSecretKey = "oidcIssuer"
+	// OIDCTokenSecretKey is the key used for the OIDC ID token inside the secret.
+	OIDCTokenSecretKey = "oidcToken" //nolint:gosec // not a credential
 )
@@ -146,7 +146,7 @@ func (identityProvider *iamIdentityProvider) ApproveSigningRequest(ctx context.C
 
 	switch options.IdentityType {
 	case authv1beta1.ControlPlaneIdentityType:
-		username = authentication.CommonNameControlPlaneCSR(options.Cluster)
+		username = authentication.CommonNameControlPlaneCSR(options.Cluster, options.CredentialsGeneration)
 		organization = authentication.OrganizationControlPlaneCSR()
 	case authv1beta1.ResourceSliceIdentityType:
 		if options.ResourceSlice == nil {
@@ -155,7 +155,7 @@ func (identityProvider *iamIdentityProvider) ApproveSigningRequest(ctx context.C
 		}
 
 		username = authentication.CommonNameResourceSliceCSR(options.ResourceSlice)
-		organization = authentication.OrganizationResourceSliceCSR(options.ResourceSlice)
+		organization = authentication.OrganizationResourceSliceCSR(options.ResourceSlice, options.CredentialsGeneration)
 	default:
 		klog.Errorf("identity type %v not supported", options.IdentityType)
 		return response, fmt.Errorf("identity type %v not supported", options.IdentityType)
@@ -17,9 +17,6 @@ package identitymanager
 import (
 	"context"
 
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -27,8 +24,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/identityManager/signer"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
-	"github.com/liqotech/liqo/pkg/utils/csr"
 )
 
 var _ IdentityManager = &identityManager{}
@@ -41,7 +38,8 @@ type identityManager struct {
 	localCluster     liqov1beta1.ClusterID
 	namespaceManager tenantnamespace.Manager
 
-	iamTokenManager tokenManager
+	iamTokenManager  tokenManager
+	oidcTokenManager tokenManager
 }
 
 // NewCertificateIdentityReader gets a new certificate identity reader.
@@ -64,21 +62,26 @@ func NewCertificateIdentityManager(ctx context.Context,
 	return newIdentityManager(ctx, cl, k8sClient, localCluster, namespaceManager, idProvider)
 }
 
-// NewCertificateIdentityProvider gets a new certificate identity approver.
+// NewCertificateIdentityProvider gets a new certificate identity approver,
+// which signs the certificates through the Kubernetes CertificateSigningRequest API.
 func NewCertificateIdentityProvider(ctx context.Context, cl client.Client, k8sClient kubernetes.Interface,
 	cnf *rest.Config,
 	localCluster liqov1beta1.ClusterID, namespaceManager tenantnamespace.Manager) IdentityProvider {
-	req, err := labels.NewRequirement(remoteTenantCSRLabel, selection.Exists, []string{})
-	utilruntime.Must(err)
+	return NewCertificateIdentityProviderWithSigner(ctx, cl, k8sClient, cnf, localCluster, namespaceManager,
+		signer.NewKubernetesSigner(ctx, k8sClient, 0))
+}
 
-	csrWatcher := csr.NewWatcher(k8sClient, 0, labels.NewSelector().Add(*req), fields.Everything())
-	csrWatcher.Start(ctx)
+// NewCertificateIdentityProviderWithSigner gets a new certificate identity approver,
+// which signs the certificates through the given signer backend.
+func NewCertificateIdentityProviderWithSigner(ctx context.Context, cl client.Client, k8sClient kubernetes.Interface,
+	cnf *rest.Config, localCluster liqov1beta1.ClusterID, namespaceManager tenantnamespace.Manager,
+	certSigner signer.Interface) IdentityProvider {
 	idProvider := &certificateIdentityProvider{
 		namespaceManager: namespaceManager,
 		k8sClient:        k8sClient,
 		cl:               cl,
 		cnf:              cnf,
-		csrWatcher:       csrWatcher,
+		signer:           certSigner,
 	}
 
 	return newIdentityManager(ctx, cl, k8sClient, localCluster, namespaceManager, idProvider)
@@ -99,6 +102,21 @@ func NewIAMIdentityProvider(ctx context.Context, cl client.Client, k8sClient kub
 	return newIdentityManager(ctx, cl, k8sClient, localCluster, namespaceManager, idProvider)
 }
 
+// NewOIDCIdentityProvider gets a new identity approver handing out short-lived tokens obtained from an OIDC issuer.
+func NewOIDCIdentityProvider(ctx context.Context, cl client.Client, k8sClient kubernetes.Interface,
+	cnf *rest.Config, localCluster liqov1beta1.ClusterID, localOIDCConfig *LocalOIDCConfig,
+	namespaceManager tenantnamespace.Manager) IdentityProvider {
+	idProvider := &oidcIdentityProvider{
+		localOIDCConfig: localOIDCConfig,
+		cl:              cl,
+		cnf:             cnf,
+	}
+
+	utilruntime.Must(idProvider.init())
+
+	return newIdentityManager(ctx, cl, k8sClient, localCluster, namespaceManager, idProvider)
+}
+
 func newIdentityManager(ctx context.Context,
 	cl client.Client, k8sClient kubernetes.Interface,
 	localCluster liqov1beta1.ClusterID,
@@ -111,6 +129,13 @@ func newIdentityManager(ctx context.Context,
 	}
 	iamTokenManager.start(ctx)
 
+	oidcTokenManager := &oidcTokenManager{
+		client:           k8sClient,
+		availableSecrets: map[types.NamespacedName]struct{}{},
+		tokenFiles:       map[types.NamespacedName]string{},
+	}
+	oidcTokenManager.start(ctx)
+
 	return &identityManager{
 		client:           cl,
 		k8sClient:        k8sClient,
@@ -119,6 +144,7 @@ func newIdentityManager(ctx context.Context,
 
 		IdentityProvider: idProvider,
 
-		iamTokenManager: iamTokenManager,
+		iamTokenManager:  iamTokenManager,
+		oidcTokenManager: oidcTokenManager,
 	}
 }
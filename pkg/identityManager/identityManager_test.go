@@ -15,12 +15,29 @@
 package identitymanager
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
 	idManTest "github.com/liqotech/liqo/pkg/identityManager/testUtils"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	"github.com/liqotech/liqo/pkg/utils/csr"
+	"github.com/liqotech/liqo/pkg/utils/kubeconfig"
 )
 
 var _ = Describe("IdentityManager", func() {
@@ -70,6 +87,35 @@ var _ = Describe("IdentityManager", func() {
 			Expect(certificate.Certificate).To(Equal([]byte(idManTest.FakeCRT)))
 		})
 
+		It("Renew Remote Certificate", func() {
+			opts := &SigningRequestOptions{
+				Cluster:         remoteCluster,
+				SigningRequest:  csrBytes,
+				IdentityType:    authv1beta1.ControlPlaneIdentityType,
+				TenantNamespace: namespace.Name,
+				IsUpdate:        true,
+			}
+
+			// The stored certificate is never returned in case of renewals, even if it matches the signing request.
+			_, err := identityProvider.GetRemoteCertificate(ctx, opts)
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+
+			// Hence, a new certificate is signed and stored for the new signing request.
+			_, opts.SigningRequest, err = csr.NewKeyAndRequest("foobar")
+			Expect(err).To(BeNil())
+			_, err = EnsureCertificate(ctx, identityProvider, opts)
+			Expect(err).To(BeNil())
+
+			opts.IsUpdate = false
+			certificate, err := identityProvider.GetRemoteCertificate(ctx, opts)
+			Expect(err).To(BeNil())
+			Expect(certificate.Certificate).To(Equal([]byte(idManTest.FakeCRT)))
+
+			opts.SigningRequest = csrBytes
+			_, err = identityProvider.GetRemoteCertificate(ctx, opts)
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+		})
+
 	})
 
 	Context("Identity Provider", func() {
@@ -88,4 +134,193 @@ var _ = Describe("IdentityManager", func() {
 
 	})
 
+	Context("OIDC Identity Provider", Ordered, func() {
+
+		var key *ecdsa.PrivateKey
+		var issuer *httptest.Server
+		var oidcConfig *LocalOIDCConfig
+		// claimsMutator allows to alter the claims of the tokens returned by the fake issuer.
+		var claimsMutator func(claims *oidcClaims)
+
+		BeforeAll(func() {
+			var err error
+			key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode(map[string]string{"token_endpoint": issuer.URL + "/token"})
+			})
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.ParseForm()).To(Succeed())
+				Expect(r.PostForm.Get("grant_type")).To(Equal("urn:ietf:params:oauth:grant-type:token-exchange"))
+				Expect(r.PostForm.Get("subject_token")).To(Equal("workload-identity-token"))
+				Expect(r.PostForm.Get("client_id")).To(Equal("liqo"))
+
+				clientID, secret, ok := r.BasicAuth()
+				if !ok || clientID != "liqo" || secret != "client-secret" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				now := time.Now().Truncate(time.Second)
+				claims := &oidcClaims{
+					RegisteredClaims: jwt.RegisteredClaims{
+						Issuer:    issuer.URL,
+						Subject:   r.PostForm.Get("requested_subject"),
+						Audience:  jwt.ClaimStrings{r.PostForm.Get("audience")},
+						IssuedAt:  jwt.NewNumericDate(now),
+						ExpiresAt: jwt.NewNumericDate(now.Add(10 * time.Minute)),
+					},
+					Groups: []string{authentication.OrganizationControlPlaneCSR()},
+				}
+				if claimsMutator != nil {
+					claimsMutator(claims)
+				}
+
+				token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(key)
+				Expect(err).ToNot(HaveOccurred())
+				_ = json.NewEncoder(w).Encode(map[string]string{
+					"access_token": token, "issued_token_type": "urn:ietf:params:oauth:token-type:id_token", "token_type": "N_A"})
+			})
+			issuer = httptest.NewServer(mux)
+			DeferCleanup(issuer.Close)
+
+			dir := GinkgoT().TempDir()
+			subjectTokenFile := filepath.Join(dir, "token")
+			Expect(os.WriteFile(subjectTokenFile, []byte("workload-identity-token\n"), 0o600)).To(Succeed())
+			clientSecretFile := filepath.Join(dir, "client-secret")
+			Expect(os.WriteFile(clientSecretFile, []byte("client-secret"), 0o600)).To(Succeed())
+
+			oidcConfig = &LocalOIDCConfig{
+				IssuerType:       OIDCIssuerTypeKeycloak,
+				Issuer:           issuer.URL,
+				Audience:         "liqo",
+				ClientSecretFile: clientSecretFile,
+				SubjectTokenFile: subjectTokenFile,
+				UsernamePrefix:   "liqo:",
+				GroupsPrefix:     "liqo:",
+			}
+		})
+
+		AfterEach(func() {
+			claimsMutator = nil
+		})
+
+		It("OIDC Identity Provider", func() {
+			idProvider := NewOIDCIdentityProvider(ctx,
+				mgr.GetClient(), cluster.GetClient(), cluster.GetCfg(),
+				localCluster, oidcConfig, namespaceManager)
+
+			oidcIDManager, ok := idProvider.(*identityManager)
+			Expect(ok).To(BeTrue())
+
+			_, ok = oidcIDManager.IdentityProvider.(*oidcIdentityProvider)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("Obtain the OIDC token from the issuer", func() {
+			idProvider := &oidcIdentityProvider{localOIDCConfig: oidcConfig}
+			Expect(idProvider.init()).To(Succeed())
+
+			resp, err := idProvider.ApproveSigningRequest(ctx, &SigningRequestOptions{
+				Cluster:         remoteCluster,
+				IdentityType:    authv1beta1.ControlPlaneIdentityType,
+				TenantNamespace: namespace.Name,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.OIDCIdentityResponse.Issuer).To(Equal(oidcConfig.Issuer))
+			Expect(resp.OIDCIdentityResponse.ExpiresAt.Sub(resp.OIDCIdentityResponse.IssuedAt)).To(Equal(10 * time.Minute))
+			Expect(idProvider.tokenEndpoint).To(Equal(issuer.URL + "/token"))
+
+			claims := &oidcClaims{}
+			_, err = jwt.ParseWithClaims(resp.OIDCIdentityResponse.Token, claims, func(*jwt.Token) (interface{}, error) {
+				return &key.PublicKey, nil
+			}, jwt.WithIssuer(oidcConfig.Issuer), jwt.WithAudience(oidcConfig.Audience), jwt.WithValidMethods([]string{"ES256"}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims.Subject).To(Equal(authentication.CommonNameControlPlaneCSR(remoteCluster, 0)))
+		})
+
+		DescribeTable("Reject the OIDC tokens not matching the requested identity", func(mutator func(claims *oidcClaims)) {
+			claimsMutator = mutator
+			idProvider := &oidcIdentityProvider{localOIDCConfig: oidcConfig}
+			Expect(idProvider.init()).To(Succeed())
+
+			_, err := idProvider.ApproveSigningRequest(ctx, &SigningRequestOptions{
+				Cluster:         remoteCluster,
+				IdentityType:    authv1beta1.ControlPlaneIdentityType,
+				TenantNamespace: namespace.Name,
+			})
+			Expect(err).To(HaveOccurred())
+		},
+			Entry("different subject", func(claims *oidcClaims) { claims.Subject = "admin" }),
+			Entry("requested subject ignored", func(claims *oidcClaims) {
+				claims.Subject = "system:serviceaccount:liqo:liqo-controller-manager"
+			}),
+			Entry("missing group", func(claims *oidcClaims) { claims.Groups = nil }),
+			Entry("different audience", func(claims *oidcClaims) { claims.Audience = jwt.ClaimStrings{"other"} }),
+			Entry("different issuer", func(claims *oidcClaims) { claims.Issuer = "https://other.example.com" }),
+			Entry("no expiration", func(claims *oidcClaims) { claims.ExpiresAt = nil }),
+		)
+
+		It("Fail the token exchange if the requested subject is not honored", func() {
+			claimsMutator = func(claims *oidcClaims) { claims.Subject = "" }
+			idProvider := &oidcIdentityProvider{localOIDCConfig: oidcConfig}
+			Expect(idProvider.init()).To(Succeed())
+
+			token, err := idProvider.exchangeToken(ctx, authentication.CommonNameControlPlaneCSR(remoteCluster, 0))
+			Expect(err).To(MatchError(ContainSubstring("not honored by the issuer")))
+			Expect(token).To(BeEmpty())
+		})
+
+		DescribeTable("Validate the OIDC prefixes", func(usernamePrefix, groupsPrefix string, expectErr bool) {
+			cfg := *oidcConfig
+			cfg.UsernamePrefix, cfg.GroupsPrefix = usernamePrefix, groupsPrefix
+			if expectErr {
+				Expect(cfg.Validate()).To(HaveOccurred())
+				Expect((&oidcIdentityProvider{localOIDCConfig: &cfg}).init()).To(HaveOccurred())
+			} else {
+				Expect(cfg.Validate()).To(Succeed())
+			}
+		},
+			Entry("valid prefixes", "liqo:", "liqo:", false),
+			Entry("empty username prefix", "", "liqo:", true),
+			Entry("empty groups prefix", "liqo:", "", true),
+			Entry("disabled username prefix", "-", "liqo:", true),
+			Entry("disabled groups prefix", "liqo:", "-", true),
+			Entry("reserved username prefix", "system:liqo:", "liqo:", true),
+			Entry("reserved groups prefix", "liqo:", "system:", true),
+		)
+
+		It("Reject the OIDC issuers not supporting the impersonation", func() {
+			cfg := *oidcConfig
+			cfg.IssuerType = ""
+			Expect(cfg.Validate()).To(HaveOccurred())
+			cfg.IssuerType = "Dex"
+			Expect(cfg.Validate()).To(HaveOccurred())
+		})
+
+		It("Use the OIDC token in the rest config", func() {
+			kc, err := kubeconfig.GenerateKubeconfigWithToken("user", string(remoteCluster), "https://example.com", nil, "token", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubeconfig-oidc", Namespace: namespace.Name},
+				Data: map[string][]byte{
+					consts.KubeconfigSecretField: kc,
+					OIDCIssuerSecretKey:          []byte(oidcConfig.Issuer),
+					OIDCTokenSecretKey:           []byte("token"),
+				},
+			}
+
+			cnf, err := identityMan.GetConfigFromSecret(remoteCluster, secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cnf.BearerToken).To(BeEmpty())
+			Expect(cnf.BearerTokenFile).ToNot(BeEmpty())
+			Expect(os.ReadFile(cnf.BearerTokenFile)).To(Equal([]byte("token")))
+		})
+
+	})
+
 })
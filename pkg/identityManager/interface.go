@@ -16,6 +16,7 @@ package identitymanager
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -57,7 +58,13 @@ type SigningRequestOptions struct {
 	TrustedCA                bool
 	ResourceSlice            *authv1beta1.ResourceSlice
 	ProxyURL                 *string
-	IsUpdate                 bool
+	// IsUpdate marks the request as a renewal: the stored certificate is never returned,
+	// and a new one is always signed through the configured signer backend.
+	IsUpdate bool
+	// CredentialsGeneration is the generation of the credentials of the consumer cluster, part of the control plane subject.
+	CredentialsGeneration int64
+	// Validity is the requested validity of the credentials. If zero, the provider default applies.
+	Validity time.Duration
 }
 
 // IdentityProvider provides the interface to retrieve and approve remote cluster identities.
@@ -71,3 +78,4 @@ type IdentityProvider interface {
 
 var _ IdentityProvider = &certificateIdentityProvider{}
 var _ IdentityProvider = &iamIdentityProvider{}
+var _ IdentityProvider = &oidcIdentityProvider{}
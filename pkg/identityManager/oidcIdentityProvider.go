@@ -0,0 +1,316 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identitymanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	responsetypes "github.com/liqotech/liqo/pkg/identityManager/responseTypes"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/utils/apiserver"
+)
+
+const (
+	// oidcTokenExchangeGrantType is the grant type of the OAuth 2.0 token exchange (RFC 8693).
+	oidcTokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// oidcJWTTokenType identifies a JWT in the token exchange.
+	oidcJWTTokenType = "urn:ietf:params:oauth:token-type:jwt"
+	// oidcIDTokenType identifies an ID token in the token exchange.
+	oidcIDTokenType = "urn:ietf:params:oauth:token-type:id_token"
+
+	// oidcRequestTimeout is the timeout of the requests to the OIDC issuer.
+	oidcRequestTimeout = 30 * time.Second
+)
+
+// oidcIdentityProvider obtains from an OIDC issuer trusted by the local API server the short-lived tokens issued to remote
+// clusters, through the OAuth 2.0 token exchange (RFC 8693) extended with the impersonation of Keycloak. The local cluster
+// presents its own workload identity token, requesting a token on behalf of the subject corresponding to the remote identity
+// (i.e., the common name of the certificates issued by the certificateIdentityProvider) through the "requested_subject"
+// parameter, which is not defined by RFC 8693: hence, only Keycloak issuers are supported (see LocalOIDCConfig.Validate).
+// No signing key is held by Liqo: the issuer is in charge of authorizing the exchange, and of asserting the subject and
+// the groups (i.e., the organization of the certificates) of the tokens.
+// The API server shall be configured with "--oidc-username-claim=sub" and "--oidc-groups-claim=groups", and it prepends
+// the mandatory username and groups prefixes, so that the tokens cannot impersonate any user or group outside them.
+type oidcIdentityProvider struct {
+	localOIDCConfig *LocalOIDCConfig
+	cl              client.Client
+	cnf             *rest.Config
+
+	httpClient    *http.Client
+	tokenEndpoint string
+	mutex         sync.Mutex
+}
+
+// oidcClaims are the claims of the tokens issued by the OIDC issuer, checked before handing them out.
+type oidcClaims struct {
+	jwt.RegisteredClaims
+	Groups []string `json:"groups,omitempty"`
+}
+
+// oidcTokenExchangeResponse is the response of the token endpoint to a token exchange request.
+type oidcTokenExchangeResponse struct {
+	// AccessToken is the issued token, regardless of its type.
+	AccessToken string `json:"access_token"`
+}
+
+func (identityProvider *oidcIdentityProvider) init() error {
+	if err := identityProvider.localOIDCConfig.Validate(); err != nil {
+		return err
+	}
+
+	identityProvider.httpClient = &http.Client{Timeout: oidcRequestTimeout}
+	identityProvider.tokenEndpoint = identityProvider.localOIDCConfig.TokenEndpoint
+	return nil
+}
+
+// GetRemoteCertificate obtains a new token for the remote cluster, as tokens are short-lived and never stored.
+func (identityProvider *oidcIdentityProvider) GetRemoteCertificate(ctx context.Context,
+	options *SigningRequestOptions) (response *responsetypes.SigningRequestResponse, err error) {
+	return identityProvider.ApproveSigningRequest(ctx, options)
+}
+
+// ApproveSigningRequest obtains a new token for the remote cluster from the OIDC issuer.
+func (identityProvider *oidcIdentityProvider) ApproveSigningRequest(ctx context.Context,
+	options *SigningRequestOptions) (response *responsetypes.SigningRequestResponse, err error) {
+	var username string
+	var organization string
+
+	switch options.IdentityType {
+	case authv1beta1.ControlPlaneIdentityType:
+		username = authentication.CommonNameControlPlaneCSR(options.Cluster, options.CredentialsGeneration)
+		organization = authentication.OrganizationControlPlaneCSR()
+	case authv1beta1.ResourceSliceIdentityType:
+		if options.ResourceSlice == nil {
+			klog.Error("resource slice is nil")
+			return response, fmt.Errorf("resource slice is nil")
+		}
+
+		username = authentication.CommonNameResourceSliceCSR(options.ResourceSlice)
+		organization = authentication.OrganizationResourceSliceCSR(options.ResourceSlice, options.CredentialsGeneration)
+	default:
+		klog.Errorf("identity type %v not supported", options.IdentityType)
+		return response, fmt.Errorf("identity type %v not supported", options.IdentityType)
+	}
+
+	token, err := identityProvider.exchangeToken(ctx, username)
+	if err != nil {
+		klog.Errorf("failed to obtain the OIDC token for cluster %q: %v", options.Cluster, err)
+		return response, err
+	}
+
+	issuedAt, expiresAt, err := identityProvider.checkToken(token, organization)
+	if err != nil {
+		klog.Errorf("invalid OIDC token obtained for cluster %q: %v", options.Cluster, err)
+		return response, err
+	}
+
+	klog.V(4).Infof("Obtained OIDC token for user %q (group %q), expiring at %s", username, organization, expiresAt)
+
+	return &responsetypes.SigningRequestResponse{
+		ResponseType: responsetypes.SigningRequestResponseOIDC,
+		OIDCIdentityResponse: responsetypes.OIDCIdentityResponse{
+			Issuer:    identityProvider.localOIDCConfig.Issuer,
+			Token:     token,
+			IssuedAt:  issuedAt,
+			ExpiresAt: expiresAt,
+		},
+	}, nil
+}
+
+// exchangeToken requests the OIDC issuer a token on behalf of the given subject, presenting the workload identity
+// token of the local cluster. The subject is requested through the Keycloak "requested_subject" extension, and the
+// token file is read at each request, as it is periodically rotated. Issuers not supporting the extension may silently
+// ignore it, returning a token for the local cluster itself: hence, the subject of the token is checked to match the
+// requested one, and the exchange fails otherwise.
+func (identityProvider *oidcIdentityProvider) exchangeToken(ctx context.Context, subject string) (string, error) {
+	config := identityProvider.localOIDCConfig
+
+	endpoint, err := identityProvider.getTokenEndpoint(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	subjectToken, err := os.ReadFile(config.SubjectTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the OIDC subject token: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type":           {oidcTokenExchangeGrantType},
+		"client_id":            {config.GetClientID()},
+		"subject_token":        {strings.TrimSpace(string(subjectToken))},
+		"subject_token_type":   {oidcJWTTokenType},
+		"requested_token_type": {oidcIDTokenType},
+		"requested_subject":    {subject},
+		"audience":             {config.Audience},
+		"scope":                {"openid"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if config.ClientSecretFile != "" {
+		secret, err := os.ReadFile(config.ClientSecretFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the OIDC client secret: %w", err)
+		}
+		req.SetBasicAuth(url.QueryEscape(config.GetClientID()), url.QueryEscape(strings.TrimSpace(string(secret))))
+	}
+
+	var resp oidcTokenExchangeResponse
+	if err := identityProvider.do(req, &resp); err != nil {
+		return "", fmt.Errorf("token exchange failed: %w", err)
+	}
+	if resp.AccessToken == "" {
+		return "", fmt.Errorf("token exchange failed: no token returned")
+	}
+
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(resp.AccessToken, &claims); err != nil {
+		return "", fmt.Errorf("token exchange failed: failed to parse the token: %w", err)
+	}
+	if claims.Subject != subject {
+		return "", fmt.Errorf("token exchange failed: requested subject %q not honored by the issuer (got %q)", subject, claims.Subject)
+	}
+	return resp.AccessToken, nil
+}
+
+// checkToken checks that the token obtained from the OIDC issuer matches the expected identity, returning its validity.
+// The subject is already checked by exchangeToken. The signature is not verified, as the token is not trusted by the
+// local cluster, but presented to the API server.
+func (identityProvider *oidcIdentityProvider) checkToken(token, organization string) (issuedAt, expiresAt time.Time, err error) {
+	var claims oidcClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return issuedAt, expiresAt, fmt.Errorf("failed to parse the token: %w", err)
+	}
+
+	switch {
+	case claims.Issuer != identityProvider.localOIDCConfig.Issuer:
+		return issuedAt, expiresAt, fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	case !slices.Contains(claims.Audience, identityProvider.localOIDCConfig.Audience):
+		return issuedAt, expiresAt, fmt.Errorf("audience %q not granted", identityProvider.localOIDCConfig.Audience)
+	case !slices.Contains(claims.Groups, organization):
+		return issuedAt, expiresAt, fmt.Errorf("group %q not asserted by the issuer", organization)
+	case claims.ExpiresAt == nil:
+		return issuedAt, expiresAt, fmt.Errorf("no expiration set")
+	}
+
+	expiresAt = claims.ExpiresAt.Time
+	issuedAt = time.Now().Truncate(time.Second)
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	return issuedAt, expiresAt, nil
+}
+
+// getTokenEndpoint returns the token endpoint of the OIDC issuer, discovering it from the issuer metadata if not configured.
+func (identityProvider *oidcIdentityProvider) getTokenEndpoint(ctx context.Context) (string, error) {
+	identityProvider.mutex.Lock()
+	defer identityProvider.mutex.Unlock()
+
+	if identityProvider.tokenEndpoint != "" {
+		return identityProvider.tokenEndpoint, nil
+	}
+
+	discovery := strings.TrimSuffix(identityProvider.localOIDCConfig.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+
+	var metadata struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := identityProvider.do(req, &metadata); err != nil {
+		return "", fmt.Errorf("failed to discover the OIDC token endpoint: %w", err)
+	}
+	if metadata.TokenEndpoint == "" {
+		return "", fmt.Errorf("no token endpoint advertised by the OIDC issuer %q", identityProvider.localOIDCConfig.Issuer)
+	}
+
+	identityProvider.tokenEndpoint = metadata.TokenEndpoint
+	return identityProvider.tokenEndpoint, nil
+}
+
+// do performs the given request to the OIDC issuer, decoding the JSON response into the given object.
+func (identityProvider *oidcIdentityProvider) do(req *http.Request, into any) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := identityProvider.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %q: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, into)
+}
+
+// ForgeAuthParams forges the AuthParams containing a freshly obtained token for the remote cluster.
+func (identityProvider *oidcIdentityProvider) ForgeAuthParams(ctx context.Context,
+	options *SigningRequestOptions) (*authv1beta1.AuthParams, error) {
+	resp, err := identityProvider.ApproveSigningRequest(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	apiServer, err := apiserver.GetURL(ctx, identityProvider.cl, options.APIServerAddressOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := apiserver.RetrieveAPIServerCA(identityProvider.cnf,
+		options.CAOverride, options.TrustedCA)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authv1beta1.AuthParams{
+		CA:        ca,
+		APIServer: apiServer,
+		ProxyURL:  options.ProxyURL,
+		OIDCConfig: &authv1beta1.OIDCConfig{
+			Issuer:              resp.OIDCIdentityResponse.Issuer,
+			Token:               resp.OIDCIdentityResponse.Token,
+			IssuedAt:            metav1.NewTime(resp.OIDCIdentityResponse.IssuedAt),
+			ExpirationTimestamp: metav1.NewTime(resp.OIDCIdentityResponse.ExpiresAt),
+		},
+	}, nil
+}
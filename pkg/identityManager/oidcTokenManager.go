@@ -0,0 +1,146 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identitymanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// oidcTokenRefreshPeriod is the period after which the token files are refreshed from the identity secrets.
+// It matches the period after which client-go reloads the bearer token files.
+const oidcTokenRefreshPeriod = time.Minute
+
+var _ tokenManager = &oidcTokenManager{}
+
+// oidcTokenManager keeps the OIDC tokens stored in the identity secrets in sync with the token files
+// used by the rest configs. The tokens are renewed through the Renew flow, which updates the identity secrets,
+// hence existing clients pick up the new tokens without being recreated.
+type oidcTokenManager struct {
+	client           kubernetes.Interface
+	availableSecrets map[types.NamespacedName]struct{}
+	tokenFiles       map[types.NamespacedName]string
+	mutex            sync.Mutex
+}
+
+func (tokMan *oidcTokenManager) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(oidcTokenRefreshPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				klog.V(4).Info("Refreshing OIDC tokens...")
+				for _, namespacedName := range tokMan.secrets() {
+					if err := tokMan.refreshToken(ctx, namespacedName); err != nil {
+						klog.Errorf("Failed to refresh the OIDC token from secret %q: %v", namespacedName, err)
+					}
+				}
+				klog.V(4).Info("OIDC tokens refresh completed")
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (tokMan *oidcTokenManager) refreshToken(ctx context.Context, namespacedName types.NamespacedName) error {
+	secret, err := tokMan.client.CoreV1().Secrets(namespacedName.Namespace).Get(ctx, namespacedName.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		// The identity has been removed, hence there is no token to refresh anymore.
+		tokMan.forget(namespacedName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tokMan.storeToken(secret)
+	return err
+}
+
+func (tokMan *oidcTokenManager) mutateConfig(secret *v1.Secret, _ liqov1beta1.ClusterID,
+	cnf *rest.Config) (*rest.Config, error) {
+	filename, err := tokMan.storeToken(secret)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	cnf.BearerTokenFile = filename
+	cnf.BearerToken = ""
+
+	return cnf, nil
+}
+
+func (tokMan *oidcTokenManager) secrets() []types.NamespacedName {
+	tokMan.mutex.Lock()
+	defer tokMan.mutex.Unlock()
+
+	secrets := make([]types.NamespacedName, 0, len(tokMan.availableSecrets))
+	for namespacedName := range tokMan.availableSecrets {
+		secrets = append(secrets, namespacedName)
+	}
+	return secrets
+}
+
+func (tokMan *oidcTokenManager) forget(namespacedName types.NamespacedName) {
+	tokMan.mutex.Lock()
+	defer tokMan.mutex.Unlock()
+
+	if filename, found := tokMan.tokenFiles[namespacedName]; found {
+		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("Failed to remove the OIDC token file %q: %v", filename, err)
+		}
+	}
+	delete(tokMan.tokenFiles, namespacedName)
+	delete(tokMan.availableSecrets, namespacedName)
+}
+
+// storeToken writes the token contained in the given secret to the associated token file,
+// and tracks the secret for subsequent refreshes.
+func (tokMan *oidcTokenManager) storeToken(secret *v1.Secret) (string, error) {
+	namespacedName := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+	tok, ok := secret.Data[OIDCTokenSecretKey]
+	if !ok {
+		return "", fmt.Errorf("key %v not found in secret %q", OIDCTokenSecretKey, namespacedName)
+	}
+
+	tokMan.mutex.Lock()
+	defer tokMan.mutex.Unlock()
+
+	filename, err := storeTokenFile(tokMan.tokenFiles[namespacedName], string(tok))
+	if err != nil {
+		return "", err
+	}
+
+	tokMan.tokenFiles[namespacedName] = filename
+	tokMan.availableSecrets[namespacedName] = struct{}{}
+	return filename, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identitymanager
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OIDCIssuerType is the type of the OIDC issuer, which determines how the tokens issued to remote clusters are requested.
+type OIDCIssuerType string
+
+const (
+	// OIDCIssuerTypeKeycloak identifies a Keycloak issuer. The tokens are requested through the OAuth 2.0 token exchange
+	// (RFC 8693), impersonating the remote identities through the "requested_subject" parameter. The parameter is an
+	// extension of Keycloak, not defined by RFC 8693, hence other issuers ignore or reject it.
+	OIDCIssuerTypeKeycloak OIDCIssuerType = "Keycloak"
+)
+
+// LocalOIDCConfig contains the configuration of the OIDC issuer trusted by the local API server,
+// from which the short-lived tokens issued to remote clusters are obtained.
+type LocalOIDCConfig struct {
+	// IssuerType is the type of the OIDC issuer. Only Keycloak is currently supported.
+	IssuerType OIDCIssuerType
+	// Issuer is the URL of the OIDC issuer (i.e., the value of the --oidc-issuer-url API server flag).
+	Issuer string
+	// Audience is the audience of the issued tokens (i.e., the value of the --oidc-client-id API server flag).
+	Audience string
+	// TokenEndpoint is the token endpoint of the issuer. If not set, it is discovered from the issuer metadata.
+	TokenEndpoint string
+	// ClientID is the client the local cluster authenticates as at the token endpoint. If not set, the audience is used.
+	ClientID string
+	// ClientSecretFile is the path of the file containing the secret of the client, if it is a confidential one.
+	ClientSecretFile string
+	// SubjectTokenFile is the path of the token identifying the local cluster at the issuer (e.g., a projected
+	// service account token, with the issuer federating with the cluster as a workload identity provider).
+	SubjectTokenFile string
+	// UsernamePrefix is the prefix the API server adds to the username of the tokens (i.e., the value of the
+	// --oidc-username-prefix API server flag). It is required, to prevent the tokens from impersonating any other user.
+	UsernamePrefix string
+	// GroupsPrefix is the prefix the API server adds to the groups of the tokens (i.e., the value of the
+	// --oidc-groups-prefix API server flag). It is required, to prevent the tokens from claiming any other group.
+	GroupsPrefix string
+}
+
+// IsEmpty indicates that some of the required values is not set.
+func (oc *LocalOIDCConfig) IsEmpty() bool {
+	return oc == nil || oc.Issuer == "" || oc.Audience == "" || oc.SubjectTokenFile == ""
+}
+
+// GetClientID returns the client the local cluster authenticates as at the token endpoint.
+func (oc *LocalOIDCConfig) GetClientID() string {
+	if oc.ClientID != "" {
+		return oc.ClientID
+	}
+	return oc.Audience
+}
+
+// Validate checks that the issuer type is supported, and that the username and groups prefixes are set,
+// and that they do not overlap with the ones reserved to Kubernetes.
+func (oc *LocalOIDCConfig) Validate() error {
+	if oc.IssuerType != OIDCIssuerTypeKeycloak {
+		return fmt.Errorf("unsupported OIDC issuer type %q: only %q is supported, as the tokens are obtained impersonating "+
+			"the remote identities through its token exchange extension", oc.IssuerType, OIDCIssuerTypeKeycloak)
+	}
+	if err := validateOIDCPrefix("username", oc.UsernamePrefix); err != nil {
+		return err
+	}
+	return validateOIDCPrefix("groups", oc.GroupsPrefix)
+}
+
+func validateOIDCPrefix(name, prefix string) error {
+	switch {
+	case prefix == "":
+		return fmt.Errorf("the OIDC %s prefix is required", name)
+	case prefix == "-":
+		return fmt.Errorf("the OIDC %s prefix cannot be disabled", name)
+	case strings.HasPrefix(prefix, "system:"):
+		return fmt.Errorf("the OIDC %s prefix %q overlaps with the ones reserved to Kubernetes", name, prefix)
+	}
+	return nil
+}
@@ -14,6 +14,12 @@
 
 package responsetypes
 
+import (
+	"time"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+)
+
 // SigningRequestResponseType indicates the type for a signign request response.
 type SigningRequestResponseType string
 
@@ -23,6 +29,8 @@ const (
 	SigningRequestResponseCertificate SigningRequestResponseType = "Certificate"
 	// SigningRequestResponseIAM indicates that the identity has been validated by the Amazon IAM service.
 	SigningRequestResponseIAM SigningRequestResponseType = "IAM"
+	// SigningRequestResponseOIDC indicates that the identity is backed by a short-lived token signed by an OIDC issuer.
+	SigningRequestResponseOIDC SigningRequestResponseType = "OIDC"
 )
 
 // AwsIdentityResponse contains the information about the created IAM user and the EKS cluster.
@@ -36,11 +44,22 @@ type AwsIdentityResponse struct {
 	Region                             string
 }
 
+// OIDCIdentityResponse contains the OIDC token issued for the remote cluster.
+type OIDCIdentityResponse struct {
+	Issuer    string
+	Token     string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
 // SigningRequestResponse contains the response from an Indentity Provider.
 type SigningRequestResponse struct {
 	ResponseType SigningRequestResponseType
 
 	Certificate []byte
+	Signer      *authv1beta1.SignerInfo
 
 	AwsIdentityResponse AwsIdentityResponse
+
+	OIDCIdentityResponse OIDCIdentityResponse
 }
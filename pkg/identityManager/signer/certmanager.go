@@ -0,0 +1,235 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+const (
+	// DefaultCertManagerIssuerKind is the default kind of the cert-manager issuer.
+	DefaultCertManagerIssuerKind = "ClusterIssuer"
+	// DefaultCertManagerIssuerGroup is the default group of the cert-manager issuer.
+	DefaultCertManagerIssuerGroup = "cert-manager.io"
+)
+
+// CertificateRequestGVK is the GroupVersionKind of the cert-manager CertificateRequests.
+var CertificateRequestGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "CertificateRequest"}
+
+// CertManagerConfig contains the configuration of the cert-manager signer backend.
+type CertManagerConfig struct {
+	// IssuerName is the name of the issuer signing the certificates.
+	IssuerName string
+	// IssuerKind is the kind of the issuer signing the certificates (e.g., Issuer or ClusterIssuer).
+	IssuerKind string
+	// IssuerGroup is the API group of the issuer signing the certificates.
+	IssuerGroup string
+	// Namespace is the namespace where the CertificateRequests are created.
+	// In case of namespaced issuers, it must correspond to the namespace of the issuer.
+	Namespace string
+}
+
+var _ Interface = &certManagerSigner{}
+
+// certManagerSigner signs the certificates through cert-manager CertificateRequests.
+// The CertificateRequests are owned by the tenant namespace of the consumer cluster, and deleted once the signing completes,
+// as the issuance is tracked by the signer information stored along with the certificate.
+type certManagerSigner struct {
+	cl       client.Client
+	cfg      CertManagerConfig
+	duration time.Duration
+}
+
+// NewCertManagerSigner returns a signer issuing the certificates through cert-manager CertificateRequests.
+func NewCertManagerSigner(cl client.Client, cfg *CertManagerConfig, duration time.Duration) (Interface, error) {
+	if cfg.IssuerName == "" {
+		return nil, fmt.Errorf("the cert-manager issuer name must be specified")
+	}
+	if cfg.Namespace == "" {
+		return nil, fmt.Errorf("the namespace of the cert-manager CertificateRequests must be specified")
+	}
+
+	s := &certManagerSigner{cl: cl, cfg: *cfg, duration: duration}
+	if s.cfg.IssuerKind == "" {
+		s.cfg.IssuerKind = DefaultCertManagerIssuerKind
+	}
+	if s.cfg.IssuerGroup == "" {
+		s.cfg.IssuerGroup = DefaultCertManagerIssuerGroup
+	}
+	return s, nil
+}
+
+// Sign creates a cert-manager CertificateRequest referencing the configured issuer,
+// and waits (with a timeout) for the certificate to be issued.
+func (s *certManagerSigner) Sign(ctx context.Context, req *Request) ([]byte, *authv1beta1.SignerInfo, error) {
+	cr, err := s.forgeCertificateRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.setOwner(ctx, cr, req.TenantNamespace); err != nil {
+		klog.Errorf("Failed to set the owner of the CertificateRequest for cluster %q: %v", req.ClusterID, err)
+		return nil, nil, err
+	}
+
+	if err := s.cl.Create(ctx, cr); err != nil {
+		klog.Errorf("Failed to create the CertificateRequest for cluster %q: %v", req.ClusterID, err)
+		return nil, nil, err
+	}
+	defer s.deleteCertificateRequest(ctx, cr)
+
+	var certificate []byte
+	err = wait.PollUntilContextTimeout(ctx, time.Second, signingTimeout, true, func(ctx context.Context) (bool, error) {
+		if err := s.cl.Get(ctx, client.ObjectKeyFromObject(cr), cr); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		issued, done, err := certificateRequestOutcome(cr)
+		certificate = issued
+		return done, err
+	})
+	if err != nil {
+		klog.Errorf("Failed to retrieve the certificate of CertificateRequest %q: %v", client.ObjectKeyFromObject(cr), err)
+		return nil, nil, err
+	}
+
+	if err := checkCertificate(req.CSR, certificate); err != nil {
+		klog.Errorf("Invalid certificate issued through CertificateRequest %q: %v", client.ObjectKeyFromObject(cr), err)
+		return nil, nil, err
+	}
+
+	klog.Infof("Certificate for cluster %q issued through CertificateRequest %q", req.ClusterID, client.ObjectKeyFromObject(cr))
+	return certificate, &authv1beta1.SignerInfo{
+		Backend: authv1beta1.CertManagerSignerBackend,
+		Issuer:  fmt.Sprintf("%s.%s/%s", s.cfg.IssuerKind, s.cfg.IssuerGroup, s.cfg.IssuerName),
+	}, nil
+}
+
+// setOwner sets the tenant namespace as owner of the CertificateRequest, so that it is garbage collected
+// along with the tenant even if it is not deleted once the signing completes (e.g., due to a restart).
+// Being cluster-scoped, the namespace can own the CertificateRequest regardless of the namespace it is created in.
+func (s *certManagerSigner) setOwner(ctx context.Context, cr *unstructured.Unstructured, tenantNamespace string) error {
+	if tenantNamespace == "" {
+		return nil
+	}
+
+	var namespace corev1.Namespace
+	if err := s.cl.Get(ctx, client.ObjectKey{Name: tenantNamespace}, &namespace); err != nil {
+		return err
+	}
+
+	cr.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: corev1.SchemeGroupVersion.String(),
+		Kind:       "Namespace",
+		Name:       namespace.Name,
+		UID:        namespace.UID,
+	}})
+	return nil
+}
+
+// deleteCertificateRequest deletes the CertificateRequest once the signing completes, either successfully or not.
+func (s *certManagerSigner) deleteCertificateRequest(ctx context.Context, cr *unstructured.Unstructured) {
+	if err := s.cl.Delete(ctx, cr); client.IgnoreNotFound(err) != nil {
+		klog.Warningf("Failed to delete the CertificateRequest %q: %v", client.ObjectKeyFromObject(cr), err)
+	}
+}
+
+func (s *certManagerSigner) forgeCertificateRequest(req *Request) (*unstructured.Unstructured, error) {
+	spec := map[string]interface{}{
+		"request": base64.StdEncoding.EncodeToString(req.CSR),
+		"issuerRef": map[string]interface{}{
+			"name":  s.cfg.IssuerName,
+			"kind":  s.cfg.IssuerKind,
+			"group": s.cfg.IssuerGroup,
+		},
+		"isCA": false,
+	}
+
+	u := make([]interface{}, len(usages))
+	for i := range usages {
+		u[i] = string(usages[i])
+	}
+	spec["usages"] = u
+
+	if duration := req.duration(s.duration); duration > 0 {
+		spec["duration"] = duration.String()
+	}
+
+	cr := &unstructured.Unstructured{}
+	cr.SetGroupVersionKind(CertificateRequestGVK)
+	cr.SetGenerateName(csrGenerateName)
+	cr.SetNamespace(s.cfg.Namespace)
+	cr.SetLabels(map[string]string{
+		consts.RemoteClusterID:      string(req.ClusterID),
+		consts.IdentityTypeLabelKey: string(req.IdentityType),
+	})
+	if err := unstructured.SetNestedField(cr.Object, spec, "spec"); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// certificateRequestOutcome returns the issued certificate, if any, and whether the CertificateRequest reached a final state.
+func certificateRequestOutcome(cr *unstructured.Unstructured) (certificate []byte, done bool, err error) {
+	conditions, _, err := unstructured.NestedSlice(cr.Object, "status", "conditions")
+	if err != nil {
+		return nil, false, err
+	}
+
+	for i := range conditions {
+		condition, ok := conditions[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		ctype, _, _ := unstructured.NestedString(condition, "type")
+		status, _, _ := unstructured.NestedString(condition, "status")
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		message, _, _ := unstructured.NestedString(condition, "message")
+
+		switch {
+		case (ctype == "Denied" || ctype == "InvalidRequest") && status == string(metav1.ConditionTrue):
+			return nil, true, fmt.Errorf("CertificateRequest %s: %s (%s)", ctype, message, reason)
+		case ctype == "Ready" && status == string(metav1.ConditionFalse) && reason == "Failed":
+			return nil, true, fmt.Errorf("CertificateRequest failed: %s", message)
+		case ctype == "Ready" && status == string(metav1.ConditionTrue):
+			encoded, _, err := unstructured.NestedString(cr.Object, "status", "certificate")
+			if err != nil {
+				return nil, true, err
+			}
+			certificate, err = base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, true, fmt.Errorf("failed to decode the issued certificate: %w", err)
+			}
+			return certificate, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
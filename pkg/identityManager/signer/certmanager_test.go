@@ -0,0 +1,216 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/csr"
+)
+
+var _ = Describe("cert-manager signer", func() {
+	var (
+		cr *unstructured.Unstructured
+
+		field = func(obj *unstructured.Unstructured, path ...string) string {
+			value, _, err := unstructured.NestedString(obj.Object, path...)
+			Expect(err).ToNot(HaveOccurred())
+			return value
+		}
+
+		withConditions = func(conditions ...map[string]interface{}) {
+			list := make([]interface{}, len(conditions))
+			for i := range conditions {
+				list[i] = conditions[i]
+			}
+			Expect(unstructured.SetNestedSlice(cr.Object, list, "status", "conditions")).To(Succeed())
+		}
+	)
+
+	BeforeEach(func() {
+		cr = &unstructured.Unstructured{Object: map[string]interface{}{}}
+		cr.SetGroupVersionKind(CertificateRequestGVK)
+	})
+
+	Context("creating the signer", func() {
+		It("should require the issuer name", func() {
+			_, err := NewCertManagerSigner(nil, &CertManagerConfig{Namespace: "liqo"}, 0)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should default the issuer kind and group", func() {
+			s, err := NewCertManagerSigner(nil, &CertManagerConfig{IssuerName: "issuer", Namespace: "liqo"}, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(*certManagerSigner).cfg.IssuerKind).To(Equal(DefaultCertManagerIssuerKind))
+			Expect(s.(*certManagerSigner).cfg.IssuerGroup).To(Equal(DefaultCertManagerIssuerGroup))
+		})
+	})
+
+	Context("forging the CertificateRequest", func() {
+		It("should reference the configured issuer", func() {
+			s, err := NewCertManagerSigner(nil, &CertManagerConfig{IssuerName: "issuer", IssuerKind: "Issuer", Namespace: "liqo"}, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			cr, err := s.(*certManagerSigner).forgeCertificateRequest(&Request{
+				ClusterID: "remote", IdentityType: authv1beta1.ControlPlaneIdentityType, CSR: []byte("csr"),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cr.GetNamespace()).To(Equal("liqo"))
+			Expect(cr.GetLabels()).To(HaveKeyWithValue(consts.RemoteClusterID, "remote"))
+			Expect(field(cr, "spec", "issuerRef", "name")).To(Equal("issuer"))
+			Expect(field(cr, "spec", "issuerRef", "kind")).To(Equal("Issuer"))
+			Expect(field(cr, "spec", "request")).To(Equal(base64.StdEncoding.EncodeToString([]byte("csr"))))
+			Expect(field(cr, "spec", "duration")).To(Equal("1h0m0s"))
+		})
+	})
+
+	Context("parsing the CertificateRequest outcome", func() {
+		It("should wait if no condition is set", func() {
+			certificate, done, err := certificateRequestOutcome(cr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(done).To(BeFalse())
+			Expect(certificate).To(BeNil())
+		})
+
+		It("should wait while the request is pending", func() {
+			withConditions(map[string]interface{}{"type": "Ready", "status": "False", "reason": "Pending"})
+			_, done, err := certificateRequestOutcome(cr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(done).To(BeFalse())
+		})
+
+		It("should return the certificate once ready", func() {
+			withConditions(
+				map[string]interface{}{"type": "Approved", "status": "True"},
+				map[string]interface{}{"type": "Ready", "status": "True", "reason": "Issued"},
+			)
+			Expect(unstructured.SetNestedField(cr.Object, base64.StdEncoding.EncodeToString([]byte("cert")), "status", "certificate")).To(Succeed())
+
+			certificate, done, err := certificateRequestOutcome(cr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(done).To(BeTrue())
+			Expect(certificate).To(Equal([]byte("cert")))
+		})
+
+		It("should fail if the request is denied", func() {
+			withConditions(map[string]interface{}{"type": "Denied", "status": "True", "reason": "Policy", "message": "denied"})
+			_, done, err := certificateRequestOutcome(cr)
+			Expect(err).To(HaveOccurred())
+			Expect(done).To(BeTrue())
+		})
+
+		It("should fail if the issuance failed", func() {
+			withConditions(map[string]interface{}{"type": "Ready", "status": "False", "reason": "Failed", "message": "failed"})
+			_, done, err := certificateRequestOutcome(cr)
+			Expect(err).To(HaveOccurred())
+			Expect(done).To(BeTrue())
+		})
+	})
+
+	Context("signing the certificates", func() {
+		var (
+			ctx    context.Context
+			csrPEM []byte
+
+			// issued is the certificate set in the status of the CertificateRequests.
+			issued  []byte
+			created *unstructured.Unstructured
+			deleted bool
+
+			s Interface
+		)
+
+		BeforeEach(func() {
+			var err error
+			ctx = context.Background()
+			_, csrPEM, err = csr.NewKeyAndRequest("remote")
+			Expect(err).ToNot(HaveOccurred())
+			issued = forgeCertificateForCSR(csrPEM)
+			created, deleted = nil, false
+
+			tenant := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "liqo-tenant-remote", UID: "tenant-uid"}}
+			// The CertificateRequests are served by the interceptors, as the cert-manager types are not part of the scheme.
+			cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tenant).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+					created = obj.(*unstructured.Unstructured).DeepCopy()
+					created.SetName(created.GetGenerateName() + "abcde")
+					obj.SetName(created.GetName())
+					return nil
+				},
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					u, ok := obj.(*unstructured.Unstructured)
+					if !ok {
+						return c.Get(ctx, key, obj, opts...)
+					}
+					created.DeepCopyInto(u)
+					Expect(unstructured.SetNestedSlice(u.Object, []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "True", "reason": "Issued"},
+					}, "status", "conditions")).To(Succeed())
+					return unstructured.SetNestedField(u.Object, base64.StdEncoding.EncodeToString(issued), "status", "certificate")
+				},
+				Delete: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.DeleteOption) error {
+					Expect(obj.GetName()).To(Equal(created.GetName()))
+					deleted = true
+					return nil
+				},
+			}).Build()
+
+			s, err = NewCertManagerSigner(cl, &CertManagerConfig{IssuerName: "issuer", Namespace: "liqo"}, 0)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should return the certificate, and delete the CertificateRequest owned by the tenant namespace", func() {
+			certificate, info, err := s.Sign(ctx, &Request{ClusterID: "remote", CSR: csrPEM, TenantNamespace: "liqo-tenant-remote"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificate).To(Equal(issued))
+			Expect(info.Backend).To(Equal(authv1beta1.CertManagerSignerBackend))
+
+			Expect(created).ToNot(BeNil())
+			Expect(created.GetOwnerReferences()).To(ConsistOf(HaveField("UID", types.UID("tenant-uid"))))
+			Expect(deleted).To(BeTrue())
+		})
+
+		It("should fail if the tenant namespace does not exist", func() {
+			_, _, err := s.Sign(ctx, &Request{ClusterID: "remote", CSR: csrPEM, TenantNamespace: "liqo-tenant-other"})
+			Expect(err).To(HaveOccurred())
+			Expect(created).To(BeNil())
+		})
+
+		It("should reject, and delete the CertificateRequest, if the certificate does not match the request", func() {
+			_, otherCSR, err := csr.NewKeyAndRequest("other")
+			Expect(err).ToNot(HaveOccurred())
+			issued = forgeCertificateForCSR(otherCSR)
+
+			_, _, err = s.Sign(ctx, &Request{ClusterID: "remote", CSR: csrPEM, TenantNamespace: "liqo-tenant-remote"})
+			Expect(err).To(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+		})
+	})
+})
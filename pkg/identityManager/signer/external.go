@@ -0,0 +1,212 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/klog/v2"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/certificatesigner"
+)
+
+const (
+	// ExternalSignerHTTPSScheme is the URL scheme selecting the HTTPS transport for the external signer.
+	ExternalSignerHTTPSScheme = "https"
+	// ExternalSignerGRPCScheme is the URL scheme selecting the gRPC transport (over TLS) for the external signer.
+	ExternalSignerGRPCScheme = "grpc"
+
+	// maxResponseSize is the maximum size of the responses returned by the external signers over HTTPS.
+	maxResponseSize = 1 << 20
+)
+
+// ExternalConfig contains the configuration of the external signer backend.
+type ExternalConfig struct {
+	// URL is the endpoint of the external signer (e.g., https://signer.example.com/sign or grpc://signer.example.com:443).
+	URL string
+	// CAFile is the path of the CA bundle used to verify the certificate of the external signer.
+	// If empty, the system roots are used.
+	CAFile string
+	// CertFile and KeyFile are the paths of the client certificate and key used to authenticate with the external signer.
+	CertFile string
+	KeyFile  string
+}
+
+var _ Interface = &externalSigner{}
+
+// externalSigner signs the certificates through an external signer, reached over HTTPS or gRPC.
+// In both cases, the exchanged messages are the ones defined by the certificatesigner package,
+// JSON-encoded in case of HTTPS.
+type externalSigner struct {
+	endpoint string
+	duration time.Duration
+
+	httpClient *http.Client
+	grpcClient certificatesigner.CertificateSignerClient
+}
+
+// NewExternalSigner returns a signer issuing the certificates through an external signer.
+func NewExternalSigner(cfg *ExternalConfig, duration time.Duration) (Interface, error) {
+	endpoint, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid external signer URL %q: %w", cfg.URL, err)
+	}
+
+	tlsConfig, err := forgeTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &externalSigner{endpoint: cfg.URL, duration: duration}
+	switch endpoint.Scheme {
+	case ExternalSignerHTTPSScheme:
+		s.httpClient = &http.Client{
+			Timeout:   signingTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		}
+	case ExternalSignerGRPCScheme:
+		conn, err := grpc.NewClient(endpoint.Host, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to the external signer %q: %w", cfg.URL, err)
+		}
+		s.grpcClient = certificatesigner.NewCertificateSignerClient(conn)
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q for the external signer URL (supported: %s, %s)",
+			endpoint.Scheme, ExternalSignerHTTPSScheme, ExternalSignerGRPCScheme)
+	}
+
+	return s, nil
+}
+
+func forgeTLSConfig(cfg *ExternalConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the external signer CA: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no valid certificate found in the external signer CA %q", cfg.CAFile)
+		}
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate for the external signer: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// Sign forwards the request to the external signer, and returns the issued certificate.
+func (s *externalSigner) Sign(ctx context.Context, req *Request) ([]byte, *authv1beta1.SignerInfo, error) {
+	signRequest := &certificatesigner.SignRequest{
+		Csr:             req.CSR,
+		ClusterID:       string(req.ClusterID),
+		IdentityType:    string(req.IdentityType),
+		Name:            req.Name,
+		DurationSeconds: int64(req.duration(s.duration).Seconds()),
+	}
+	for i := range usages {
+		signRequest.Usages = append(signRequest.Usages, string(usages[i]))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, signingTimeout)
+	defer cancel()
+
+	var resp *certificatesigner.SignResponse
+	var err error
+	if s.grpcClient != nil {
+		resp, err = s.grpcClient.Sign(ctx, signRequest)
+	} else {
+		resp, err = s.signHTTPS(ctx, signRequest)
+	}
+	if err != nil {
+		klog.Errorf("The external signer %q failed to sign the certificate for cluster %q: %v", s.endpoint, req.ClusterID, err)
+		return nil, nil, err
+	}
+
+	if len(resp.GetCertificate()) == 0 {
+		return nil, nil, fmt.Errorf("the external signer %q returned an empty certificate", s.endpoint)
+	}
+
+	if err := checkCertificate(req.CSR, resp.GetCertificate()); err != nil {
+		klog.Errorf("Invalid certificate issued by the external signer %q for cluster %q: %v", s.endpoint, req.ClusterID, err)
+		return nil, nil, err
+	}
+
+	issuer := resp.GetIssuer()
+	if issuer == "" {
+		issuer = s.endpoint
+	}
+
+	klog.Infof("Certificate for cluster %q issued by the external signer %q", req.ClusterID, issuer)
+	return resp.GetCertificate(), &authv1beta1.SignerInfo{
+		Backend: authv1beta1.ExternalSignerBackend,
+		Issuer:  issuer,
+	}, nil
+}
+
+func (s *externalSigner) signHTTPS(ctx context.Context, signRequest *certificatesigner.SignRequest) (*certificatesigner.SignResponse, error) {
+	body, err := protojson.Marshal(signRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := s.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q: %s", httpResponse.Status, bytes.TrimSpace(data))
+	}
+
+	var resp certificatesigner.SignResponse
+	if err := protojson.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode the response: %w", err)
+	}
+	return &resp, nil
+}
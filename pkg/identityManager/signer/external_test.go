@@ -0,0 +1,133 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/certificatesigner"
+	"github.com/liqotech/liqo/pkg/utils/csr"
+)
+
+var _ = Describe("External signer", func() {
+	var (
+		ctx    context.Context
+		server *httptest.Server
+		caFile string
+
+		csrPEM      []byte
+		certificate []byte
+
+		received *certificatesigner.SignRequest
+		response *certificatesigner.SignResponse
+		status   int
+	)
+
+	BeforeEach(func() {
+		var err error
+		ctx = context.Background()
+		_, csrPEM, err = csr.NewKeyAndRequest("remote")
+		Expect(err).ToNot(HaveOccurred())
+		certificate = forgeCertificateForCSR(csrPEM)
+
+		received = nil
+		response = &certificatesigner.SignResponse{Certificate: certificate, Issuer: "test-pki"}
+		status = http.StatusOK
+
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			body, err := io.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			received = &certificatesigner.SignRequest{}
+			Expect(protojson.Unmarshal(body, received)).To(Succeed())
+
+			w.WriteHeader(status)
+			data, err := protojson.Marshal(response)
+			Expect(err).ToNot(HaveOccurred())
+			_, _ = w.Write(data)
+		}))
+
+		caFile = filepath.Join(GinkgoT().TempDir(), "ca.crt")
+		ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		Expect(os.WriteFile(caFile, ca, 0o600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should reject unsupported schemes", func() {
+		_, err := NewExternalSigner(&ExternalConfig{URL: "http://signer.example.com"}, 0)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should sign the certificate over HTTPS", func() {
+		s, err := NewExternalSigner(&ExternalConfig{URL: server.URL + "/sign", CAFile: caFile}, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		issued, info, err := s.Sign(ctx, &Request{
+			ClusterID: "remote", IdentityType: authv1beta1.ControlPlaneIdentityType, Name: "name", CSR: csrPEM,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(issued).To(Equal(certificate))
+		Expect(*info).To(Equal(authv1beta1.SignerInfo{Backend: authv1beta1.ExternalSignerBackend, Issuer: "test-pki"}))
+
+		Expect(received).ToNot(BeNil())
+		Expect(received.GetClusterID()).To(Equal("remote"))
+		Expect(received.GetIdentityType()).To(Equal(string(authv1beta1.ControlPlaneIdentityType)))
+		Expect(received.GetCsr()).To(Equal(csrPEM))
+		Expect(received.GetUsages()).To(ContainElement("client auth"))
+	})
+
+	It("should fail if the signer returns an error", func() {
+		status = http.StatusForbidden
+		s, err := NewExternalSigner(&ExternalConfig{URL: server.URL + "/sign", CAFile: caFile}, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = s.Sign(ctx, &Request{ClusterID: "remote", CSR: csrPEM})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail if the certificate does not match the request", func() {
+		_, otherCSR, err := csr.NewKeyAndRequest("other")
+		Expect(err).ToNot(HaveOccurred())
+		response.Certificate = forgeCertificateForCSR(otherCSR)
+
+		s, err := NewExternalSigner(&ExternalConfig{URL: server.URL + "/sign", CAFile: caFile}, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = s.Sign(ctx, &Request{ClusterID: "remote", CSR: csrPEM})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail if the signer certificate is not trusted", func() {
+		s, err := NewExternalSigner(&ExternalConfig{URL: server.URL + "/sign"}, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = s.Sign(ctx, &Request{ClusterID: "remote", CSR: csrPEM})
+		Expect(err).To(HaveOccurred())
+	})
+})
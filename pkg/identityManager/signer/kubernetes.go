@@ -0,0 +1,114 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	certv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/csr"
+)
+
+const (
+	// RemoteTenantCSRLabel is the label added to the CertificateSigningRequests created on behalf of the consumer clusters.
+	RemoteTenantCSRLabel = "liqo.io/remote-tenant-csr"
+
+	csrGenerateName = "liqo-identity-"
+)
+
+var _ Interface = &kubernetesSigner{}
+
+// kubernetesSigner signs the certificates through the Kubernetes CertificateSigningRequest API.
+type kubernetesSigner struct {
+	k8sClient  kubernetes.Interface
+	csrWatcher csr.Watcher
+	duration   time.Duration
+}
+
+// NewKubernetesSigner returns a signer issuing the certificates through the Kubernetes CertificateSigningRequest API.
+func NewKubernetesSigner(ctx context.Context, k8sClient kubernetes.Interface, duration time.Duration) Interface {
+	req, err := labels.NewRequirement(RemoteTenantCSRLabel, selection.Exists, []string{})
+	utilruntime.Must(err)
+
+	csrWatcher := csr.NewWatcher(k8sClient, 0, labels.NewSelector().Add(*req), fields.Everything())
+	csrWatcher.Start(ctx)
+
+	return &kubernetesSigner{
+		k8sClient:  k8sClient,
+		csrWatcher: csrWatcher,
+		duration:   duration,
+	}
+}
+
+// Sign creates a CertificateSigningRequest CR to be issued by the local cluster, and approves it.
+// This function will wait (with a timeout) for an available certificate before returning.
+func (s *kubernetesSigner) Sign(ctx context.Context, req *Request) ([]byte, *authv1beta1.SignerInfo, error) {
+	cert := &certv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: csrGenerateName,
+			Labels:       map[string]string{RemoteTenantCSRLabel: strconv.FormatBool(true)},
+		},
+		Spec: certv1.CertificateSigningRequestSpec{
+			Groups: []string{
+				"system:authenticated",
+			},
+			SignerName: certv1.KubeAPIServerClientSignerName,
+			Request:    req.CSR,
+			Usages:     usages,
+		},
+	}
+	if duration := req.duration(s.duration); duration > 0 {
+		cert.Spec.ExpirationSeconds = ptr.To(int32(duration.Seconds()))
+	}
+
+	cert, err := s.k8sClient.CertificatesV1().CertificateSigningRequests().Create(ctx, cert, metav1.CreateOptions{})
+	if err != nil {
+		klog.Error(err)
+		return nil, nil, err
+	}
+
+	// approve the CertificateSigningRequest
+	if err = csr.Approve(s.k8sClient, cert, "IdentityManagerApproval",
+		"This CSR was approved by Liqo Identity Manager"); err != nil {
+		klog.Error(err)
+		return nil, nil, err
+	}
+
+	// retrieve the certificate issued by the Kubernetes issuer in the CSR (with a timeout)
+	ctxC, cancel := context.WithTimeout(ctx, signingTimeout)
+	defer cancel()
+	certificate, err := s.csrWatcher.RetrieveCertificate(ctxC, cert.Name)
+	if err != nil {
+		klog.Error(err)
+		return nil, nil, err
+	}
+
+	return certificate, &authv1beta1.SignerInfo{
+		Backend: authv1beta1.KubernetesSignerBackend,
+		Issuer:  certv1.KubeAPIServerClientSignerName,
+	}, nil
+}
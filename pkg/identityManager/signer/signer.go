@@ -0,0 +1,127 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	certv1 "k8s.io/api/certificates/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+)
+
+// signingTimeout is the maximum time waited for a certificate to be issued.
+const signingTimeout = 30 * time.Second
+
+// usages are the key usages requested for the certificates issued to the consumer clusters.
+var usages = []certv1.KeyUsage{
+	certv1.UsageDigitalSignature,
+	certv1.UsageKeyEncipherment,
+	certv1.UsageClientAuth,
+}
+
+// Interface is implemented by the backends which sign the certificates of the consumer clusters.
+type Interface interface {
+	// Sign signs the given request, and returns the PEM-encoded certificate along with the information about the signer.
+	Sign(ctx context.Context, req *Request) (certificate []byte, info *authv1beta1.SignerInfo, err error)
+}
+
+// Request contains the information about a certificate signing request of a consumer cluster.
+type Request struct {
+	ClusterID    liqov1beta1.ClusterID
+	IdentityType authv1beta1.IdentityType
+	Name         string
+	CSR          []byte
+	// TenantNamespace is the tenant namespace of the consumer cluster, owning the resources created to sign the certificate.
+	TenantNamespace string
+	// Duration is the requested validity of the certificate. If zero, the duration configured for the signer applies.
+	Duration time.Duration
+}
+
+// duration returns the validity requested for the certificate, falling back to the given default.
+func (req *Request) duration(fallback time.Duration) time.Duration {
+	if req.Duration > 0 {
+		return req.Duration
+	}
+	return fallback
+}
+
+// checkCertificate verifies that the PEM-encoded certificate returned by an issuer carries the subject and the public key
+// of the PEM-encoded CSR, so that an issuer rewriting the request cannot grant a different identity to the consumer cluster.
+func checkCertificate(csrPEM, certificatePEM []byte) error {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil {
+		return fmt.Errorf("invalid PEM-encoded certificate signing request")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse the certificate signing request: %w", err)
+	}
+
+	block, _ = pem.Decode(certificatePEM)
+	if block == nil {
+		return fmt.Errorf("the issued certificate is not PEM-encoded")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse the issued certificate: %w", err)
+	}
+
+	if certificate.Subject.CommonName != csr.Subject.CommonName ||
+		!sets.New(certificate.Subject.Organization...).Equal(sets.New(csr.Subject.Organization...)) {
+		return fmt.Errorf("the subject of the issued certificate (%s) does not match the requested one (%s)",
+			certificate.Subject, csr.Subject)
+	}
+
+	publicKey, ok := certificate.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(csr.PublicKey) {
+		return fmt.Errorf("the public key of the issued certificate does not match the requested one")
+	}
+	return nil
+}
+
+// Config contains the configuration of the signer backend.
+type Config struct {
+	// Backend is the backend used to sign the certificates.
+	Backend authv1beta1.SignerBackend
+	// Duration is the requested validity of the certificates. If zero, the backend default applies.
+	Duration time.Duration
+
+	CertManager CertManagerConfig
+	External    ExternalConfig
+}
+
+// New returns the signer backend corresponding to the given configuration.
+func New(ctx context.Context, cfg *Config, k8sClient kubernetes.Interface, cl client.Client) (Interface, error) {
+	switch cfg.Backend {
+	case authv1beta1.KubernetesSignerBackend, "":
+		return NewKubernetesSigner(ctx, k8sClient, cfg.Duration), nil
+	case authv1beta1.CertManagerSignerBackend:
+		return NewCertManagerSigner(cl, &cfg.CertManager, cfg.Duration)
+	case authv1beta1.ExternalSignerBackend:
+		return NewExternalSigner(&cfg.External, cfg.Duration)
+	default:
+		return nil, fmt.Errorf("unknown signer backend %q", cfg.Backend)
+	}
+}
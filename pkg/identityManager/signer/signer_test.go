@@ -0,0 +1,95 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/liqotech/liqo/pkg/utils/csr"
+)
+
+// forgeCertificate returns a PEM-encoded certificate for the given subject and public key, signed by a throwaway CA.
+func forgeCertificate(subject pkix.Name, publicKey crypto.PublicKey) []byte {
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	issuer := &x509.Certificate{Subject: pkix.Name{CommonName: "test-ca"}}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, publicKey, caKey)
+	Expect(err).ToNot(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// forgeCertificateForCSR returns a PEM-encoded certificate matching the given PEM-encoded CSR.
+func forgeCertificateForCSR(csrPEM []byte) []byte {
+	block, _ := pem.Decode(csrPEM)
+	Expect(block).ToNot(BeNil())
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	Expect(err).ToNot(HaveOccurred())
+	return forgeCertificate(request.Subject, request.PublicKey)
+}
+
+var _ = Describe("Certificate check", func() {
+	var csrPEM []byte
+
+	BeforeEach(func() {
+		var err error
+		_, csrPEM, err = csr.NewKeyAndRequest("remote")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should accept a certificate matching the CSR", func() {
+		Expect(checkCertificate(csrPEM, forgeCertificateForCSR(csrPEM))).To(Succeed())
+	})
+
+	It("should reject a certificate with a different public key", func() {
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		certificate := forgeCertificate(pkix.Name{CommonName: "remote", Organization: []string{"liqo.io"}}, publicKey)
+		Expect(checkCertificate(csrPEM, certificate)).ToNot(Succeed())
+	})
+
+	DescribeTable("should reject a certificate with a different subject", func(subject pkix.Name) {
+		block, _ := pem.Decode(csrPEM)
+		request, err := x509.ParseCertificateRequest(block.Bytes)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(checkCertificate(csrPEM, forgeCertificate(subject, request.PublicKey))).ToNot(Succeed())
+	},
+		Entry("different common name", pkix.Name{CommonName: "other", Organization: []string{"liqo.io"}}),
+		Entry("additional organization", pkix.Name{CommonName: "remote", Organization: []string{"liqo.io", "system:masters"}}),
+		Entry("missing organization", pkix.Name{CommonName: "remote"}),
+	)
+
+	It("should reject invalid certificates", func() {
+		Expect(checkCertificate(csrPEM, []byte("cert"))).ToNot(Succeed())
+	})
+})
@@ -133,13 +133,24 @@ func (tokMan *iamTokenManager) addClusterID(remoteCluster liqov1beta1.ClusterID,
 }
 
 func (tokMan *iamTokenManager) storeToken(remoteCluster liqov1beta1.ClusterID, tok *token.Token) (string, error) {
+	filename, err := storeTokenFile(tokMan.tokenFiles[remoteCluster], tok.Token)
+	if err != nil {
+		return "", err
+	}
+
+	tokMan.tokenFiles[remoteCluster] = filename
+	return filename, nil
+}
+
+// storeTokenFile writes the token to the given file, creating a new temporary file if it does not exist yet.
+// It returns the name of the file where the token has been written.
+func storeTokenFile(filename, tok string) (string, error) {
 	var err error
-	filename, found := tokMan.tokenFiles[remoteCluster]
-	if found {
+	if filename != "" {
 		_, err = os.Stat(filename)
 	}
 
-	if !found || os.IsNotExist(err) {
+	if filename == "" || os.IsNotExist(err) {
 		file, err := os.CreateTemp("", "token")
 		if err != nil {
 			klog.Errorf("Error creating the authentication token tmp file: %v", err)
@@ -152,10 +163,9 @@ func (tokMan *iamTokenManager) storeToken(remoteCluster liqov1beta1.ClusterID, t
 		}
 
 		filename = file.Name()
-		tokMan.tokenFiles[remoteCluster] = filename
 	}
 
-	err = os.WriteFile(filename, []byte(tok.Token), 0o600)
+	err = os.WriteFile(filename, []byte(tok), 0o600)
 	if err != nil {
 		klog.Errorf("Error writing the authentication token tmp file: %v", err)
 		return "", err
@@ -43,10 +43,19 @@ func NewIpam(pools []netip.Prefix) (*Ipam, error) {
 	return ipam, nil
 }
 
-// NetworkAcquire allocates a network of the given size.
+// NetworkAcquire allocates an IPv4 network of the given size.
 // It returns the allocated network or nil if no network is available.
 func (ipam *Ipam) NetworkAcquire(size int) *netip.Prefix {
+	return ipam.NetworkAcquireWithFamily(size, IPv4)
+}
+
+// NetworkAcquireWithFamily allocates a network of the given size, picking it from the pools of the given IP family.
+// It returns the allocated network or nil if no network is available.
+func (ipam *Ipam) NetworkAcquireWithFamily(size int, family IPFamily) *netip.Prefix {
 	for i := range ipam.roots {
+		if FamilyOf(ipam.roots[i].prefix) != family || size > ipam.roots[i].prefix.Addr().BitLen() {
+			continue
+		}
 		if result := allocateNetwork(size, &ipam.roots[i]); result != nil {
 			return result
 		}
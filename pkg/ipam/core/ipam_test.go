@@ -23,6 +23,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Ipam", func() {
@@ -449,6 +450,79 @@ var _ = Describe("Ipam", func() {
 		})
 	})
 
+	Context("Ipam dual-stack pools", func() {
+		var dualStackPools = []netip.Prefix{
+			netip.MustParsePrefix("10.0.0.0/8"),
+			netip.MustParsePrefix("fd00::/8"),
+		}
+
+		BeforeEach(func() {
+			var err error
+			ipam, err = NewIpam(dualStackPools)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("acquiring networks of a given family", func() {
+			It("should pick them from the pools of the same family", func() {
+				network := ipam.NetworkAcquireWithFamily(24, IPv4)
+				Expect(network).ShouldNot(BeNil())
+				Expect(network.Addr().Is4()).To(BeTrue())
+
+				network = ipam.NetworkAcquireWithFamily(24, IPv6)
+				Expect(network).ShouldNot(BeNil())
+				Expect(network.Addr().Is6()).To(BeTrue())
+				Expect(dualStackPools[1].Overlaps(*network)).To(BeTrue())
+
+				network = ipam.NetworkAcquireWithFamily(64, IPv6)
+				Expect(network).ShouldNot(BeNil())
+				Expect(network.Bits()).To(Equal(64))
+			})
+
+			It("should not pick IPv6 networks when acquiring without family", func() {
+				network := ipam.NetworkAcquire(64)
+				Expect(network).Should(BeNil())
+			})
+		})
+
+		When("acquiring IPv6 networks with prefix", func() {
+			It("should succeed, and remap conflicting ones", func() {
+				prefix := netip.MustParsePrefix("fd00:10:244::/56")
+				Expect(ipam.NetworkIsAvailable(prefix)).To(BeTrue())
+				Expect(ipam.NetworkAcquireWithPrefix(prefix)).To(PointTo(Equal(prefix)))
+				Expect(ipam.NetworkIsAvailable(prefix)).To(BeFalse())
+				Expect(ipam.NetworkAcquireWithPrefix(prefix)).To(BeNil())
+
+				remapped := ipam.NetworkAcquireWithFamily(prefix.Bits(), FamilyOf(prefix))
+				Expect(remapped).ShouldNot(BeNil())
+				Expect(remapped.Overlaps(prefix)).To(BeFalse())
+				Expect(remapped.Bits()).To(Equal(56))
+
+				Expect(ipam.NetworkRelease(prefix, 0)).To(PointTo(Equal(prefix)))
+				Expect(ipam.NetworkIsAvailable(prefix)).To(BeTrue())
+			})
+		})
+
+		When("acquiring IPs from an IPv6 network", func() {
+			It("should succeed", func() {
+				prefix := netip.MustParsePrefix("fd00:10:244::/64")
+				Expect(ipam.NetworkAcquireWithPrefix(prefix)).NotTo(BeNil())
+
+				addr, err := ipam.IPAcquire(prefix)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(addr).To(PointTo(Equal(netip.MustParseAddr("fd00:10:244::"))))
+
+				addr, err = ipam.IPAcquire(prefix)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(addr).To(PointTo(Equal(netip.MustParseAddr("fd00:10:244::1"))))
+
+				addr, err = ipam.IPAcquireWithAddr(prefix, netip.MustParseAddr("fd00:10:244::ff"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(addr).NotTo(BeNil())
+				Expect(ipam.IPIsAllocated(prefix, netip.MustParseAddr("fd00:10:244::ff"))).To(BeTrue())
+			})
+		})
+	})
+
 	Context("Ipam IPs", func() {
 		var (
 			// WARNING: availableIPs must be a power of 2
@@ -15,11 +15,31 @@
 package ipamcore
 
 import (
+	"net/netip"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("Ipam low level utilities ", func() {
+	Context("prefix splitting", func() {
+		It("should split IPv4 prefixes", func() {
+			left, right := splitNetworkPrefix(netip.MustParsePrefix("10.0.0.0/8"))
+			Expect(left).To(Equal(netip.MustParsePrefix("10.0.0.0/9")))
+			Expect(right).To(Equal(netip.MustParsePrefix("10.128.0.0/9")))
+		})
+
+		It("should split IPv6 prefixes", func() {
+			left, right := splitNetworkPrefix(netip.MustParsePrefix("fd00::/8"))
+			Expect(left).To(Equal(netip.MustParsePrefix("fd00::/9")))
+			Expect(right).To(Equal(netip.MustParsePrefix("fd80::/9")))
+
+			left, right = splitNetworkPrefix(netip.MustParsePrefix("fd00:10:244::/63"))
+			Expect(left).To(Equal(netip.MustParsePrefix("fd00:10:244::/64")))
+			Expect(right).To(Equal(netip.MustParsePrefix("fd00:10:244:1::/64")))
+		})
+	})
+
 	Context("bit operations", func() {
 		When("setting bit in byte", func() {
 			It("should return 0", func() {
@@ -189,7 +189,11 @@ func (n *node) ipAcquire() *netip.Addr {
 		return nil
 	}
 
-	size := int(math.Pow(2, float64(n.prefix.Addr().BitLen()-n.prefix.Bits())))
+	// The number of addresses of large (e.g., IPv6) prefixes does not fit an int: cap it, as it cannot be exhausted anyway.
+	size := math.MaxInt
+	if hostBits := n.prefix.Addr().BitLen() - n.prefix.Bits(); hostBits < 62 {
+		size = 1 << hostBits
+	}
 
 	// If the lastip is not initialized, set it to the first address of the prefix.
 	if !n.lastip.IsValid() {
@@ -359,7 +363,7 @@ func (n *node) toGraphviz() error {
 		}
 	}
 
-	filePath := filepath.Clean(graphvizFolder + "/" + strings.NewReplacer("/", "_", ".", "_").Replace(n.prefix.String()) + ".dot")
+	filePath := filepath.Clean(graphvizFolder + "/" + strings.NewReplacer("/", "_", ".", "_", ":", "_").Replace(n.prefix.String()) + ".dot")
 	file, err := os.Create(filePath)
 	if err != nil {
 		return err
@@ -23,6 +23,7 @@ import (
 	klog "k8s.io/klog/v2"
 
 	ipamv1alpha1 "github.com/liqotech/liqo/apis/ipam/v1alpha1"
+	ipamcore "github.com/liqotech/liqo/pkg/ipam/core"
 	ipamutils "github.com/liqotech/liqo/pkg/utils/ipam"
 )
 
@@ -30,7 +31,7 @@ import (
 func (lipam *LiqoIPAM) networkAcquire(prefix netip.Prefix) (*netip.Prefix, error) {
 	result := lipam.IpamCore.NetworkAcquireWithPrefix(prefix)
 	if result == nil {
-		result = lipam.IpamCore.NetworkAcquire(prefix.Bits())
+		result = lipam.IpamCore.NetworkAcquireWithFamily(prefix.Bits(), ipamcore.FamilyOf(prefix))
 		if result == nil {
 			return nil, fmt.Errorf("failed to reserve network %q", prefix.String())
 		}
@@ -0,0 +1,113 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authentication
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/auth"
+)
+
+// CredentialValidity returns the validity period of the credentials carried by the given AuthParams.
+// Credentials backed by OIDC tokens are characterized by the validity of the token, while the other ones
+// by the validity of the signed certificate.
+func CredentialValidity(authParams *authv1beta1.AuthParams) (notBefore, notAfter time.Time, err error) {
+	if oidcConfig := authParams.OIDCConfig; oidcConfig != nil {
+		return oidcConfig.IssuedAt.Time, oidcConfig.ExpirationTimestamp.Time, nil
+	}
+
+	if len(authParams.SignedCRT) == 0 {
+		return notBefore, notAfter, fmt.Errorf("no signed certificate found")
+	}
+
+	// Parse the certificate to get its expiration time
+	block, _ := pem.Decode(authParams.SignedCRT)
+	if block == nil {
+		return notBefore, notAfter, fmt.Errorf("failed to decode PEM block containing certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return notBefore, notAfter, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return cert.NotBefore, cert.NotAfter, nil
+}
+
+// RenewalTime returns the time at which the credentials valid in the given period should be renewed.
+// If the rotation parameters define a renew-before window shorter than the lifetime of the credentials,
+// they are renewed that long before their expiration, otherwise once they reach 2/3 of their lifetime.
+func RenewalTime(notBefore, notAfter time.Time, rotation *authv1beta1.CredentialRotation) time.Time {
+	lifetime := notAfter.Sub(notBefore)
+	if rotation != nil && rotation.RenewBefore != nil && rotation.RenewBefore.Duration > 0 && rotation.RenewBefore.Duration < lifetime {
+		return notAfter.Add(-rotation.RenewBefore.Duration)
+	}
+	return notAfter.Add(-lifetime / 3)
+}
+
+// CredentialsGeneration returns the generation of the credentials carried by the given AuthParams.
+func CredentialsGeneration(authParams *authv1beta1.AuthParams) int64 {
+	if authParams == nil || authParams.Rotation == nil {
+		return 0
+	}
+	return authParams.Rotation.Generation
+}
+
+// TenantCredentialsGeneration returns the generation of the credentials issued to the given tenant.
+func TenantCredentialsGeneration(tenant *authv1beta1.Tenant) int64 {
+	if tenant.Status.Credentials == nil {
+		return 0
+	}
+	return tenant.Status.Credentials.Generation
+}
+
+// TenantCredentialsValidity returns the validity of the credentials requested by the policy of the given tenant,
+// or zero if the default of the identity provider applies.
+func TenantCredentialsValidity(tenant *authv1beta1.Tenant) time.Duration {
+	if tenant.Spec.CredentialPolicy == nil || tenant.Spec.CredentialPolicy.Validity == nil {
+		return 0
+	}
+	return tenant.Spec.CredentialPolicy.Validity.Duration
+}
+
+// ForgeCredentialRotation forges the rotation parameters of the credentials issued to the given tenant.
+func ForgeCredentialRotation(tenant *authv1beta1.Tenant) *authv1beta1.CredentialRotation {
+	rotation := &authv1beta1.CredentialRotation{Generation: TenantCredentialsGeneration(tenant)}
+	if tenant.Spec.CredentialPolicy != nil {
+		rotation.RenewBefore = tenant.Spec.CredentialPolicy.RenewBefore
+	}
+	return rotation
+}
+
+// SetTenantCredentialsStatus updates the credentials status of the given tenant, given the AuthParams
+// of the control plane credentials just issued.
+func SetTenantCredentialsStatus(tenant *authv1beta1.Tenant, authParams *authv1beta1.AuthParams) {
+	if tenant.Status.Credentials == nil {
+		tenant.Status.Credentials = &authv1beta1.CredentialsStatus{}
+	}
+
+	credentials := tenant.Status.Credentials
+	credentials.Subject = auth.UserName(CommonNameControlPlaneCSR(tenant.Spec.ClusterID, credentials.Generation))
+	credentials.IssuedAt, credentials.ExpiresAt = nil, nil
+	if notBefore, notAfter, err := CredentialValidity(authParams); err == nil {
+		credentials.IssuedAt, credentials.ExpiresAt = &metav1.Time{Time: notBefore}, &metav1.Time{Time: notAfter}
+	}
+}
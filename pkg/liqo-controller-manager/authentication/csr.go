@@ -31,15 +31,16 @@ import (
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/auth"
 )
 
 // CSRChecker is a function that checks a CSR.
 type CSRChecker func(*x509.CertificateRequest) error
 
-// GenerateCSRForResourceSlice generates a new CSR given a private key and a resource slice.
+// GenerateCSRForResourceSlice generates a new CSR given a private key, a resource slice and the generation of the credentials.
 func GenerateCSRForResourceSlice(key crypto.PrivateKey,
-	resourceSlice *authv1beta1.ResourceSlice) (csrBytes []byte, err error) {
-	return generateCSR(key, CommonNameResourceSliceCSR(resourceSlice), OrganizationResourceSliceCSR(resourceSlice))
+	resourceSlice *authv1beta1.ResourceSlice, generation int64) (csrBytes []byte, err error) {
+	return generateCSR(key, CommonNameResourceSliceCSR(resourceSlice), OrganizationResourceSliceCSR(resourceSlice, generation))
 }
 
 // CommonNameResourceSliceCSR returns the common name for a resource slice CSR.
@@ -62,14 +63,20 @@ func CommonNameResourceSliceCSR(resourceSlice *authv1beta1.ResourceSlice) string
 	return fmt.Sprintf("%s-%x", resourceSlice.Name, h[:6])
 }
 
-// OrganizationResourceSliceCSR returns the organization for a resource slice CSR.
-func OrganizationResourceSliceCSR(resourceSlice *authv1beta1.ResourceSlice) string {
-	return string(*resourceSlice.Spec.ConsumerClusterID)
+// OrganizationResourceSliceCSR returns the organization for a resource slice CSR, given the generation of the credentials.
+func OrganizationResourceSliceCSR(resourceSlice *authv1beta1.ResourceSlice, generation int64) string {
+	return ResourceSliceGroup(*resourceSlice.Spec.ConsumerClusterID, generation)
 }
 
-// GenerateCSRForControlPlane generates a new CSR given a private key and a subject.
-func GenerateCSRForControlPlane(key crypto.PrivateKey, clusterID liqov1beta1.ClusterID) (csrBytes []byte, err error) {
-	return generateCSR(key, CommonNameControlPlaneCSR(clusterID), OrganizationControlPlaneCSR())
+// ResourceSliceGroup returns the group the permissions of the virtual nodes of the given consumer cluster are bound to.
+// As for the control plane subject, the generation is increased each time the credentials are revoked, to rotate the group.
+func ResourceSliceGroup(clusterID liqov1beta1.ClusterID, generation int64) string {
+	return withGeneration(string(clusterID), generation)
+}
+
+// GenerateCSRForControlPlane generates a new CSR given a private key, the cluster ID and the generation of the credentials.
+func GenerateCSRForControlPlane(key crypto.PrivateKey, clusterID liqov1beta1.ClusterID, generation int64) (csrBytes []byte, err error) {
+	return generateCSR(key, CommonNameControlPlaneCSR(clusterID, generation), OrganizationControlPlaneCSR())
 }
 
 // GenerateCSRForPeerUser generates a new CSR given a private key and the clusterID from which the peering will start.
@@ -92,9 +99,18 @@ func commonNamePeerUser(clusterID liqov1beta1.ClusterID) (string, error) {
 	return fmt.Sprintf("liqo-peer-user-%s-%x", clusterID, randSuffix), nil
 }
 
-// CommonNameControlPlaneCSR returns the common name for a control plane CSR.
-func CommonNameControlPlaneCSR(clusterID liqov1beta1.ClusterID) string {
-	return string(clusterID)
+// CommonNameControlPlaneCSR returns the common name for a control plane CSR, which is also the user the control plane
+// permissions are bound to. The generation is increased each time the credentials are revoked, to rotate the subject.
+func CommonNameControlPlaneCSR(clusterID liqov1beta1.ClusterID, generation int64) string {
+	return withGeneration(string(clusterID), generation)
+}
+
+// withGeneration appends the generation of the credentials to the given name, unless they have never been revoked.
+func withGeneration(name string, generation int64) string {
+	if generation == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-gen%d", name, generation)
 }
 
 // OrganizationControlPlaneCSR returns the organization for a control plane CSR.
@@ -105,19 +121,19 @@ func OrganizationControlPlaneCSR() string {
 // IsControlPlaneUser checks if a user is a control plane user.
 func IsControlPlaneUser(groups []string) bool {
 	for _, group := range groups {
-		if group == OrganizationControlPlaneCSR() {
+		if group == auth.GroupName(OrganizationControlPlaneCSR()) {
 			return true
 		}
 	}
 	return false
 }
 
-// CheckCSRForControlPlane checks a CSR for a control plane.
-func CheckCSRForControlPlane(csr, publicKeyDER []byte, remoteClusterID liqov1beta1.ClusterID) error {
+// CheckCSRForControlPlane checks a CSR for a control plane, given the current generation of the credentials.
+func CheckCSRForControlPlane(csr, publicKeyDER []byte, remoteClusterID liqov1beta1.ClusterID, generation int64) error {
 	return checkCSR(csr, publicKeyDER, true,
 		func(x509Csr *x509.CertificateRequest) error {
-			if x509Csr.Subject.CommonName != CommonNameControlPlaneCSR(remoteClusterID) {
-				return fmt.Errorf("invalid common name")
+			if expected := CommonNameControlPlaneCSR(remoteClusterID, generation); x509Csr.Subject.CommonName != expected {
+				return fmt.Errorf("invalid common name %q (expected %q)", x509Csr.Subject.CommonName, expected)
 			}
 			return nil
 		},
@@ -129,8 +145,8 @@ func CheckCSRForControlPlane(csr, publicKeyDER []byte, remoteClusterID liqov1bet
 		})
 }
 
-// CheckCSRForResourceSlice checks a CSR for a resource slice.
-func CheckCSRForResourceSlice(tenantPublicKey []byte, resourceSlice *authv1beta1.ResourceSlice, checkPublicKey bool) error {
+// CheckCSRForResourceSlice checks a CSR for a resource slice, given the current generation of the credentials.
+func CheckCSRForResourceSlice(csr, tenantPublicKey []byte, resourceSlice *authv1beta1.ResourceSlice, generation int64, checkPublicKey bool) error {
 	var parsedPublicKey []byte
 	if checkPublicKey {
 		_, parsedPublicKeyDER, err := ParseTenantPublicKey(tenantPublicKey)
@@ -140,7 +156,7 @@ func CheckCSRForResourceSlice(tenantPublicKey []byte, resourceSlice *authv1beta1
 		parsedPublicKey = parsedPublicKeyDER
 	}
 
-	return checkCSR(resourceSlice.Spec.CSR, parsedPublicKey, checkPublicKey,
+	return checkCSR(csr, parsedPublicKey, checkPublicKey,
 		func(x509Csr *x509.CertificateRequest) error {
 			if x509Csr.Subject.CommonName != CommonNameResourceSliceCSR(resourceSlice) {
 				return fmt.Errorf("invalid common name")
@@ -148,8 +164,8 @@ func CheckCSRForResourceSlice(tenantPublicKey []byte, resourceSlice *authv1beta1
 			return nil
 		},
 		func(x509Csr *x509.CertificateRequest) error {
-			if x509Csr.Subject.Organization[0] != OrganizationResourceSliceCSR(resourceSlice) {
-				return fmt.Errorf("invalid organization")
+			if expected := OrganizationResourceSliceCSR(resourceSlice, generation); x509Csr.Subject.Organization[0] != expected {
+				return fmt.Errorf("invalid organization %q (expected %q)", x509Csr.Subject.Organization[0], expected)
 			}
 			return nil
 		})
@@ -15,6 +15,8 @@
 package forge
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,8 +29,16 @@ import (
 
 // ResourceSliceOptions contains the options to forge a ResourceSlice resource.
 type ResourceSliceOptions struct {
-	Class     authv1beta1.ResourceSliceClass
-	Resources map[corev1.ResourceName]string
+	Class       authv1beta1.ResourceSliceClass
+	Resources   map[corev1.ResourceName]string
+	Autoscaling *ResourceSliceAutoscalingOptions
+}
+
+// ResourceSliceAutoscalingOptions contains the options to enable the autoscaling of a ResourceSlice resource.
+// The initially requested resources are used as lower bounds.
+type ResourceSliceAutoscalingOptions struct {
+	MaxResources                map[corev1.ResourceName]string
+	TargetUtilizationPercentage int32
 }
 
 // ResourceSlice forges a ResourceSlice resource.
@@ -67,14 +77,52 @@ func MutateResourceSlice(resourceSlice *authv1beta1.ResourceSlice, remoteCluster
 		return err
 	}
 
+	autoscaling, err := resourceSliceAutoscaling(opts.Autoscaling, rl)
+	if err != nil {
+		return err
+	}
+
 	resourceSlice.Spec = authv1beta1.ResourceSliceSpec{
 		Class:             opts.Class,
 		ProviderClusterID: ptr.To(remoteClusterID),
 		Resources:         rl,
+		Autoscaling:       autoscaling,
 	}
 	return nil
 }
 
+func resourceSliceAutoscaling(opts *ResourceSliceAutoscalingOptions,
+	initial corev1.ResourceList) (*authv1beta1.ResourceSliceAutoscaling, error) {
+	if opts == nil {
+		return nil, nil
+	}
+
+	maxResources, err := resourceList(opts.MaxResources)
+	if err != nil {
+		return nil, err
+	}
+	if len(maxResources) == 0 {
+		return nil, nil
+	}
+
+	minResources := corev1.ResourceList{}
+	for name, upper := range maxResources {
+		if qnt, ok := initial[name]; ok {
+			if qnt.Cmp(upper) > 0 {
+				return nil, fmt.Errorf("the requested %s (%s) exceeds the autoscaling upper bound (%s)",
+					name, qnt.String(), upper.String())
+			}
+			minResources[name] = qnt.DeepCopy()
+		}
+	}
+
+	return &authv1beta1.ResourceSliceAutoscaling{
+		MinResources:                minResources,
+		MaxResources:                maxResources,
+		TargetUtilizationPercentage: opts.TargetUtilizationPercentage,
+	}, nil
+}
+
 func resourceList(resources map[corev1.ResourceName]string) (corev1.ResourceList, error) {
 	resourceList := corev1.ResourceList{}
 	for name, quantity := range resources {
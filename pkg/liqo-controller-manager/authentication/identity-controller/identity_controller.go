@@ -130,9 +130,11 @@ func (r *IdentityReconciler) ensureKubeconfigSecret(ctx context.Context, identit
 	return kubeconfigSecret, nil
 }
 
-// handleIdentityStatus updates the identity status to reference the kubeconfig secret.
+// handleIdentityStatus updates the identity status to reference the kubeconfig secret,
+// and to report the signer which issued the certificate.
 func (r *IdentityReconciler) handleIdentityStatus(identity *authv1beta1.Identity, secretName string) {
 	identity.Status.KubeconfigSecretRef = &corev1.LocalObjectReference{
 		Name: secretName,
 	}
+	identity.Status.Signer = identity.Spec.AuthParams.Signer
 }
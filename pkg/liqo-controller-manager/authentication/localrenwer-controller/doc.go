@@ -23,7 +23,7 @@
 // * Handling manual renewal requests via the "liqo.io/renew" annotation
 //
 // Certificate renewal is triggered in two ways:
-// 1. Automatically when a certificate reaches 2/3 of its lifetime
+// 1. Automatically when a certificate reaches 2/3 of its lifetime (or the renew-before window set by the provider)
 // 2. Manually when an Identity is annotated with "liqo.io/renew: true"
 //
 // The controller implements an adaptive requeue mechanism that adjusts the check frequency
@@ -16,8 +16,6 @@ package localrenwercontroller
 
 import (
 	"context"
-	"crypto/x509"
-	"encoding/pem"
 	"fmt"
 	"time"
 
@@ -76,7 +74,7 @@ func NewLocalRenewerReconciler(cl client.Client, s *runtime.Scheme,
 // The function first retrieves the Identity object and checks if it should be
 // renewed using the shouldRenew function. Renewal can be triggered either by
 // the presence of a "liqo.io/renew" annotation set to true, or by the certificate
-// approaching its expiration time (2/3 of its lifetime).
+// approaching its expiration time (by default, 2/3 of its lifetime).
 //
 // If the Identity does not need renewal, it removes the current Renew object
 // if present and returns a requeue time calculated by the shouldRenew function.
@@ -138,10 +136,11 @@ func (r *LocalRenewerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // If the annotation is present, it immediately triggers renewal regardless of certificate status.
 //
 // Otherwise, it retrieves the kubeconfig secret referenced by the Identity and checks the
-// signed certificate within. The function calculates the certificate's lifetime
-// and determines if a renewal is required based on the 2/3 life rule.
+// signed certificate (or the OIDC token) within. The function calculates the credential lifetime
+// and determines if a renewal is required based on the renew-before window set by the provider
+// cluster, or on the 2/3 life rule otherwise.
 // If the certificate is not near expiration, it calculates the next check time
-// as the remaining time until the 2/3 point plus a 10% buffer.
+// as the remaining time until the renewal point plus a 10% buffer.
 // If the certificate is near expiration, it checks if a Renew object already exists.
 //
 // Args:
@@ -164,31 +163,18 @@ func (r *LocalRenewerReconciler) shouldRenew(ctx context.Context, identity *auth
 		return false, requeueIn, fmt.Errorf("identity %s/%s has no kubeconfig secret reference", identity.Namespace, identity.Name)
 	}
 
-	// Get the signed certificate from the kubeconfig
-	signedCrt := identity.Spec.AuthParams.SignedCRT
-	if len(signedCrt) == 0 {
-		return false, requeueIn, fmt.Errorf("identity %s/%s has no signed certificate", identity.Namespace, identity.Name)
-	}
-
-	// Parse the certificate to get its expiration time
-	block, _ := pem.Decode(signedCrt)
-	if block == nil {
-		return false, requeueIn, fmt.Errorf("failed to decode PEM block containing certificate")
-	}
-
-	cert, err := x509.ParseCertificate(block.Bytes)
+	notBefore, notAfter, err := credentialValidity(identity)
 	if err != nil {
-		return false, requeueIn, fmt.Errorf("failed to parse certificate: %w", err)
+		return false, requeueIn, err
 	}
 
-	// Calculate if we need to renew based on 2/3 life rule
-	lifetime := cert.NotAfter.Sub(cert.NotBefore)
-	twoThirdsPoint := cert.NotAfter.Add(-lifetime / 3)
+	// Calculate if we need to renew based on the renew-before window set by the provider, or on the 2/3 life rule
+	renewalTime := authentication.RenewalTime(notBefore, notAfter, identity.Spec.AuthParams.Rotation)
 
-	if time.Now().Before(twoThirdsPoint) {
-		// Calculate requeue time as the remaining time until the 2/3 point of the certificate expiration time + 10%
-		timeUntilTwoThirds := time.Until(twoThirdsPoint)
-		requeueIn = timeUntilTwoThirds * 11 / 10
+	if time.Now().Before(renewalTime) {
+		// Calculate requeue time as the remaining time until the renewal time + 10%
+		timeUntilRenewal := time.Until(renewalTime)
+		requeueIn = timeUntilRenewal * 11 / 10
 
 		klog.V(4).Infof("Certificate not ready for renewal, will check again in %v", requeueIn)
 		return false, requeueIn, nil
@@ -208,6 +194,15 @@ func (r *LocalRenewerReconciler) shouldRenew(ctx context.Context, identity *auth
 	return true, requeueIn, nil // No existing Renew, proceed with creation
 }
 
+// credentialValidity returns the validity period of the credentials associated with the given Identity.
+func credentialValidity(identity *authv1beta1.Identity) (notBefore, notAfter time.Time, err error) {
+	notBefore, notAfter, err = authentication.CredentialValidity(&identity.Spec.AuthParams)
+	if err != nil {
+		return notBefore, notAfter, fmt.Errorf("identity %s/%s: %w", identity.Namespace, identity.Name, err)
+	}
+	return notBefore, notAfter, nil
+}
+
 // enforceRenew enforces the creation of a Renew object for the given Identity.
 //
 // The function creates a Renew object with the same name and namespace as the given Identity.
@@ -268,7 +263,8 @@ func (r *LocalRenewerReconciler) enforceRenew(ctx context.Context, identity *aut
 		switch identity.Spec.Type {
 		case authv1beta1.ControlPlaneIdentityType:
 			// Generate a CSR for the remote cluster.
-			CSR, err := authentication.GenerateCSRForControlPlane(privateKey, r.LocalClusterID)
+			CSR, err := authentication.GenerateCSRForControlPlane(privateKey, r.LocalClusterID,
+				authentication.CredentialsGeneration(&identity.Spec.AuthParams))
 			if err != nil {
 				return fmt.Errorf("unable to generate CSR: %w", err)
 			}
@@ -283,7 +279,8 @@ func (r *LocalRenewerReconciler) enforceRenew(ctx context.Context, identity *aut
 			}
 
 			// Generate a CSR for the remote cluster.
-			CSR, err := authentication.GenerateCSRForResourceSlice(privateKey, &resourceSlice)
+			CSR, err := authentication.GenerateCSRForResourceSlice(privateKey, &resourceSlice,
+				authentication.CredentialsGeneration(&identity.Spec.AuthParams))
 			if err != nil {
 				return fmt.Errorf("unable to generate CSR: %w", err)
 			}
@@ -19,14 +19,18 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/record"
 	klog "k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
@@ -34,6 +38,7 @@ import (
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
+	"github.com/liqotech/liqo/pkg/utils/getters"
 )
 
 // NewLocalResourceSliceReconciler returns a new LocalResourceSliceReconciler.
@@ -117,9 +122,19 @@ func (r *LocalResourceSliceReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
-	if len(resourceSlice.Spec.CSR) == 0 {
+	generation, err := r.credentialsGeneration(ctx, *resourceSlice.Spec.ProviderClusterID)
+	if err != nil {
+		klog.Errorf("unable to get the generation of the credentials towards cluster %q: %v", *resourceSlice.Spec.ProviderClusterID, err)
+		r.eventRecorder.Event(&resourceSlice, corev1.EventTypeWarning, "FailedGetCredentialsGeneration", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// The CSR is generated again if the credentials have been revoked in the meanwhile (i.e., the consumer cluster
+	// authenticated again with a new generation), as the provider cluster does not accept the previous subject anymore.
+	if len(resourceSlice.Spec.CSR) == 0 ||
+		authentication.CheckCSRForResourceSlice(resourceSlice.Spec.CSR, nil, &resourceSlice, generation, false) != nil {
 		// Generate a CSR for the remote cluster.
-		CSR, err := authentication.GenerateCSRForResourceSlice(privateKey, &resourceSlice)
+		CSR, err := authentication.GenerateCSRForResourceSlice(privateKey, &resourceSlice, generation)
 		if err != nil {
 			klog.Errorf("unable to generate CSR for ResourceSlice %q: %v", req.NamespacedName, err)
 			r.eventRecorder.Event(&resourceSlice, corev1.EventTypeWarning, "FailedGenerateCSR", err.Error())
@@ -141,6 +156,47 @@ func (r *LocalResourceSliceReconciler) Reconcile(ctx context.Context, req ctrl.R
 	return ctrl.Result{}, nil
 }
 
+// credentialsGeneration returns the generation of the control plane credentials issued by the given provider cluster,
+// which the subject of the ResourceSlices must correspond to.
+func (r *LocalResourceSliceReconciler) credentialsGeneration(ctx context.Context, providerClusterID liqov1beta1.ClusterID) (int64, error) {
+	identity, err := getters.GetControlPlaneIdentityByClusterID(ctx, r.Client, providerClusterID)
+	switch {
+	case errors.IsNotFound(err):
+		return 0, nil
+	case err != nil:
+		return 0, err
+	default:
+		return authentication.CredentialsGeneration(&identity.Spec.AuthParams), nil
+	}
+}
+
+// identityEnqueuer enqueues the local ResourceSlices towards the cluster of the given control plane Identity,
+// so that their CSRs are generated again when the credentials are rotated.
+func (r *LocalResourceSliceReconciler) identityEnqueuer(ctx context.Context, obj client.Object) []reconcile.Request {
+	identity, ok := obj.(*authv1beta1.Identity)
+	if !ok || identity.Spec.Type != authv1beta1.ControlPlaneIdentityType {
+		return nil
+	}
+
+	localResSliceSelector := reflection.LocalResourcesLabelSelector()
+	selector, err := metav1.LabelSelectorAsSelector(&localResSliceSelector)
+	utilruntime.Must(err)
+
+	var resourceSlices authv1beta1.ResourceSliceList
+	if err := r.List(ctx, &resourceSlices, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		klog.Errorf("unable to list the ResourceSlices towards cluster %q: %v", identity.Spec.ClusterID, err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range resourceSlices.Items {
+		if providerID := resourceSlices.Items[i].Spec.ProviderClusterID; providerID != nil && *providerID == identity.Spec.ClusterID {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&resourceSlices.Items[i])})
+		}
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *LocalResourceSliceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// generate the predicate to filter just the ResourceSlices created by the local cluster checking crdReplicator labels
@@ -152,5 +208,6 @@ func (r *LocalResourceSliceReconciler) SetupWithManager(mgr ctrl.Manager) error
 
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlResourceSliceLocal).
 		For(&authv1beta1.ResourceSlice{}, builder.WithPredicates(localResSliceFilter)).
+		Watches(&authv1beta1.Identity{}, handler.EnqueueRequestsFromMapFunc(r.identityEnqueuer)).
 		Complete(r)
 }
@@ -30,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	"github.com/liqotech/liqo/pkg/audit"
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	authgetters "github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication/getters"
@@ -138,6 +139,16 @@ func (r *NonceSignerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	r.eventRecorder.Event(&secret, "Normal", "NonceSigned", "Nonce signed")
 	klog.Infof("secret %q signed", req.NamespacedName)
 
+	audit.Record(&audit.Event{
+		ClusterID: remoteClusterID,
+		Action:    audit.ActionNonceSign,
+		Outcome:   audit.OutcomeSucceeded,
+		Resource:  "secrets",
+		Namespace: secret.Namespace,
+		Name:      secret.Name,
+		Message:   "Nonce signed",
+	})
+
 	return ctrl.Result{}, nil
 }
 
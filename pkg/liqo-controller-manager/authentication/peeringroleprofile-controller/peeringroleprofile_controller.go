@@ -0,0 +1,168 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringroleprofilecontroller
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	peeringroles "github.com/liqotech/liqo/pkg/peering-roles"
+	"github.com/liqotech/liqo/pkg/utils"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// PeeringRoleProfileReconciler enforces the ClusterRoles derived from the PeeringRoleProfiles.
+type PeeringRoleProfileReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	EventRecorder record.EventRecorder
+}
+
+// NewPeeringRoleProfileReconciler returns a new PeeringRoleProfileReconciler.
+func NewPeeringRoleProfileReconciler(cl client.Client, s *runtime.Scheme, recorder record.EventRecorder) *PeeringRoleProfileReconciler {
+	return &PeeringRoleProfileReconciler{
+		Client: cl,
+		Scheme: s,
+
+		EventRecorder: recorder,
+	}
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=peeringroleprofiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile enforces the ClusterRoles granting the consumer clusters the permissions allowed by the given PeeringRoleProfile,
+// both in the offloaded namespaces (to the virtual kubelet) and in the tenant namespace and cluster-wide (to the control plane).
+func (r *PeeringRoleProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var profile authv1beta1.PeeringRoleProfile
+	if err := r.Get(ctx, req.NamespacedName, &profile); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The derived ClusterRoles are garbage collected, as owned by the profile.
+			klog.V(4).Infof("PeeringRoleProfile %q not found", req.Name)
+			return ctrl.Result{}, nil
+		}
+		klog.Errorf("Unable to get the PeeringRoleProfile %q: %s", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
+	bases, err := r.getBaseClusterRoles(ctx)
+	if err != nil {
+		klog.Errorf("Unable to get the ClusterRoles restricted by the PeeringRoleProfiles: %s", err)
+		r.EventRecorder.Event(&profile, corev1.EventTypeWarning, "ClusterRoleNotFound", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	for i := range bases {
+		if err := r.enforceClusterRole(ctx, &profile, &bases[i]); err != nil {
+			klog.Errorf("Unable to enforce the ClusterRole derived from %q for the PeeringRoleProfile %q: %s", bases[i].Name, profile.Name, err)
+			r.EventRecorder.Event(&profile, corev1.EventTypeWarning, "ClusterRoleFailed", err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getBaseClusterRoles returns the ClusterRoles restricted by the PeeringRoleProfiles, i.e., the one granting permissions
+// to the virtual kubelet in the offloaded namespaces, and the ones bound to the tenants by the tenant controller.
+func (r *PeeringRoleProfileReconciler) getBaseClusterRoles(ctx context.Context) ([]rbacv1.ClusterRole, error) {
+	var base rbacv1.ClusterRole
+	if err := r.Get(ctx, types.NamespacedName{Name: consts.RemoteNamespaceClusterRoleName}, &base); err != nil {
+		return nil, err
+	}
+
+	appNames := slices.Concat(consts.TenantClusterRolesAppNames, consts.TenantClusterRolesClusterWideAppNames)
+	req, err := labels.NewRequirement(consts.K8sAppNameKey, selection.In, appNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var tenantRoles rbacv1.ClusterRoleList
+	if err := r.List(ctx, &tenantRoles, client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*req)}); err != nil {
+		return nil, err
+	}
+	return append([]rbacv1.ClusterRole{base}, tenantRoles.Items...), nil
+}
+
+// enforceClusterRole enforces the ClusterRole derived from the given one, restricted according to the given PeeringRoleProfile.
+func (r *PeeringRoleProfileReconciler) enforceClusterRole(ctx context.Context, profile *authv1beta1.PeeringRoleProfile,
+	base *rbacv1.ClusterRole) error {
+	clusterRole := rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: peeringroles.ProfileClusterRoleName(base.Name, profile.Name)}}
+	result, err := resource.CreateOrUpdate(ctx, r.Client, &clusterRole, func() error {
+		clusterRole.Labels = labels.Merge(clusterRole.Labels, map[string]string{
+			consts.K8sAppManagedByKey:         consts.LiqoAppLabelValue,
+			consts.PeeringRoleProfileLabelKey: profile.Name,
+		})
+		clusterRole.Rules = peeringroles.ForgeProfileRules(base.Rules, &profile.Spec)
+		return controllerutil.SetControllerReference(profile, &clusterRole, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+
+	klog.V(utils.FromResult(result)).Infof("ClusterRole %q for the PeeringRoleProfile %q successfully enforced (with %v operation)",
+		clusterRole.Name, profile.Name, result)
+	return nil
+}
+
+// SetupWithManager registers the PeeringRoleProfileReconciler with the manager.
+func (r *PeeringRoleProfileReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Reconcile all the profiles when a base ClusterRole changes (e.g., upon upgrades).
+	baseFilter := predicate.NewPredicateFuncs(peeringroles.IsProfileClusterRoleBase)
+
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlPeeringRoleProfile).
+		For(&authv1beta1.PeeringRoleProfile{}).
+		Owns(&rbacv1.ClusterRole{}).
+		Watches(&rbacv1.ClusterRole{}, handler.EnqueueRequestsFromMapFunc(r.profilesEnqueuer()), builder.WithPredicates(baseFilter)).
+		Complete(r)
+}
+
+func (r *PeeringRoleProfileReconciler) profilesEnqueuer() handler.MapFunc {
+	return func(ctx context.Context, _ client.Object) []reconcile.Request {
+		var profiles authv1beta1.PeeringRoleProfileList
+		if err := r.List(ctx, &profiles); err != nil {
+			klog.Errorf("Unable to list the PeeringRoleProfiles: %s", err)
+			return nil
+		}
+
+		requests := make([]reconcile.Request, len(profiles.Items))
+		for i := range profiles.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: profiles.Items[i].Name}}
+		}
+		return requests
+	}
+}
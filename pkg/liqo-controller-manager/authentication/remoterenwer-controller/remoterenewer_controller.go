@@ -30,6 +30,7 @@ import (
 	"github.com/liqotech/liqo/internal/crdReplicator/reflection"
 	"github.com/liqotech/liqo/pkg/consts"
 	identitymanager "github.com/liqotech/liqo/pkg/identityManager"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 	"github.com/liqotech/liqo/pkg/utils/events"
 	"github.com/liqotech/liqo/pkg/utils/getters"
@@ -121,6 +122,16 @@ func (r *RemoteRenewerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// Make sure that the renewed credentials are bound to the current subject, as rotated by the last revocation.
+	if authv1beta1.GetAuthzPolicyValue(tenant.Spec.AuthzPolicy) != authv1beta1.TolerateNoHandshake {
+		if err := checkCSR(&renew, tenant, resourceSlice); err != nil {
+			klog.Errorf("Invalid CSR for the Renew %q: %s", req.NamespacedName, err)
+			events.EventWithOptions(r.recorder, &renew, fmt.Sprintf("Invalid CSR: %s", err),
+				&events.Option{EventType: events.Error, Reason: "InvalidCSR"})
+			return ctrl.Result{}, nil
+		}
+	}
+
 	if err := r.handleRenew(ctx, &renew, tenant, resourceSlice); err != nil {
 		klog.Errorf("Unable to handle Renew %q: %s", req.NamespacedName, err)
 		events.EventWithOptions(r.recorder, &renew, fmt.Sprintf("Failed to handle renewal: %s", err),
@@ -201,6 +212,7 @@ func (r *RemoteRenewerReconciler) updateTenantStatusOnRenew(ctx context.Context,
 	renew *authv1beta1.Renew,
 	tenant *authv1beta1.Tenant) error {
 	tenant.Status.AuthParams = renew.Status.AuthParams
+	authentication.SetTenantCredentialsStatus(tenant, renew.Status.AuthParams)
 	if err := r.Status().Update(ctx, tenant); err != nil {
 		klog.Errorf("Failed to update Tenant status for %q: %s", tenant.Name, err)
 		return err
@@ -243,12 +255,15 @@ func (r *RemoteRenewerReconciler) handleRenew(ctx context.Context,
 		ResourceSlice:            resourceSlice,
 		ProxyURL:                 tenant.Spec.ProxyURL,
 		IsUpdate:                 true,
+		CredentialsGeneration:    authentication.TenantCredentialsGeneration(tenant),
+		Validity:                 authentication.TenantCredentialsValidity(tenant),
 	})
 	if err != nil {
 		klog.Errorf("Unable to forge the AuthParams for the Renew %q: %s", renew.Name, err)
 		return err
 	}
 
+	authParams.Rotation = authentication.ForgeCredentialRotation(tenant)
 	renew.Status.AuthParams = authParams
 	if err := r.Status().Update(ctx, renew); err != nil {
 		klog.Errorf("Failed to update Renew status for %q: %s", renew.Name, err)
@@ -257,3 +272,25 @@ func (r *RemoteRenewerReconciler) handleRenew(ctx context.Context,
 
 	return nil
 }
+
+// checkCSR checks that the CSR of the given Renew matches the public key of the tenant,
+// and the subject corresponding to the current generation of its credentials.
+func checkCSR(renew *authv1beta1.Renew, tenant *authv1beta1.Tenant, resourceSlice *authv1beta1.ResourceSlice) error {
+	generation := authentication.TenantCredentialsGeneration(tenant)
+
+	switch renew.Spec.IdentityType {
+	case authv1beta1.ControlPlaneIdentityType:
+		_, publicKeyDER, err := authentication.ParseTenantPublicKey(tenant.Spec.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to parse the public key of Tenant %q: %w", tenant.Name, err)
+		}
+		return authentication.CheckCSRForControlPlane(renew.Spec.CSR, publicKeyDER, renew.Spec.ConsumerClusterID, generation)
+	case authv1beta1.ResourceSliceIdentityType:
+		if resourceSlice == nil {
+			return fmt.Errorf("the Renew does not reference any ResourceSlice")
+		}
+		return authentication.CheckCSRForResourceSlice(renew.Spec.CSR, tenant.Spec.PublicKey, resourceSlice, generation, true)
+	default:
+		return fmt.Errorf("identity type %q not supported", renew.Spec.IdentityType)
+	}
+}
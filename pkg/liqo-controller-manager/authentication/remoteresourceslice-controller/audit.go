@@ -0,0 +1,69 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/audit"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+)
+
+// recordConditionAuditEvents records in the audit stream the changes of the authentication and resources conditions
+// of the ResourceSlice, with respect to the given original conditions. It is expected to be called only once the
+// status of the ResourceSlice has been successfully persisted.
+func recordConditionAuditEvents(resourceSlice *authv1beta1.ResourceSlice, original []authv1beta1.ResourceSliceCondition) {
+	previous := &authv1beta1.ResourceSlice{Status: authv1beta1.ResourceSliceStatus{Conditions: original}}
+	for _, conditionType := range []authv1beta1.ResourceSliceConditionType{
+		authv1beta1.ResourceSliceConditionTypeAuthentication, authv1beta1.ResourceSliceConditionTypeResources} {
+		current := authentication.GetCondition(resourceSlice, conditionType)
+		if current == nil || current.Status == "" {
+			continue
+		}
+
+		old := authentication.GetCondition(previous, conditionType)
+		if old != nil && old.Status == current.Status && old.Reason == current.Reason && old.Message == current.Message {
+			continue
+		}
+
+		recordConditionAuditEvent(resourceSlice, conditionType, current.Status, current.Reason, current.Message)
+	}
+}
+
+// recordConditionAuditEvent records the acceptance or denial of the authentication or the resources of a ResourceSlice
+// in the audit stream.
+func recordConditionAuditEvent(resourceSlice *authv1beta1.ResourceSlice, conditionType authv1beta1.ResourceSliceConditionType,
+	status authv1beta1.ResourceSliceConditionStatus, reason, message string) {
+	event := &audit.Event{
+		Action:    audit.ActionResourceSliceResources,
+		Outcome:   audit.OutcomeAllowed,
+		Resource:  authv1beta1.ResourceSliceResource,
+		Namespace: resourceSlice.Namespace,
+		Name:      resourceSlice.Name,
+		Reason:    reason,
+		Message:   message,
+	}
+
+	if conditionType == authv1beta1.ResourceSliceConditionTypeAuthentication {
+		event.Action = audit.ActionResourceSliceAuthentication
+	}
+	if status == authv1beta1.ResourceSliceConditionDenied {
+		event.Outcome = audit.OutcomeDenied
+	}
+	if resourceSlice.Spec.ConsumerClusterID != nil {
+		event.ClusterID = *resourceSlice.Spec.ConsumerClusterID
+	}
+
+	audit.Record(event)
+}
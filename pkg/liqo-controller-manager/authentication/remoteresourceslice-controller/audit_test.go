@@ -0,0 +1,120 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"bufio"
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/audit"
+)
+
+var _ = Describe("Audit of the ResourceSlice conditions", func() {
+	var (
+		buffer        bytes.Buffer
+		resourceSlice *authv1beta1.ResourceSlice
+		original      []authv1beta1.ResourceSliceCondition
+	)
+
+	condition := func(conditionType authv1beta1.ResourceSliceConditionType, status authv1beta1.ResourceSliceConditionStatus,
+		reason string) authv1beta1.ResourceSliceCondition {
+		return authv1beta1.ResourceSliceCondition{Type: conditionType, Status: status, Reason: reason, Message: reason}
+	}
+
+	recordedEvents := func() []*audit.Event {
+		var events []*audit.Event
+		scanner := bufio.NewScanner(&buffer)
+		for scanner.Scan() {
+			event, ok := audit.ParseEvent(scanner.Bytes())
+			Expect(ok).To(BeTrue())
+			events = append(events, event)
+		}
+		return events
+	}
+
+	BeforeEach(func() {
+		buffer.Reset()
+		audit.Init("test", audit.NewWriterSink(&buffer))
+		DeferCleanup(func() { audit.Init("") })
+
+		resourceSlice = &authv1beta1.ResourceSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: "slice", Namespace: "liqo-tenant-consumer"},
+			Spec:       authv1beta1.ResourceSliceSpec{ConsumerClusterID: ptr.To(liqov1beta1.ClusterID("consumer"))},
+		}
+		original = nil
+	})
+
+	JustBeforeEach(func() {
+		recordConditionAuditEvents(resourceSlice, original)
+	})
+
+	When("the conditions are set for the first time", func() {
+		BeforeEach(func() {
+			resourceSlice.Status.Conditions = []authv1beta1.ResourceSliceCondition{
+				condition(authv1beta1.ResourceSliceConditionTypeAuthentication, authv1beta1.ResourceSliceConditionAccepted, "AuthAccepted"),
+				condition(authv1beta1.ResourceSliceConditionTypeResources, authv1beta1.ResourceSliceConditionDenied, "ResDenied"),
+			}
+		})
+
+		It("should record an event for each condition", func() {
+			events := recordedEvents()
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Action).To(Equal(audit.ActionResourceSliceAuthentication))
+			Expect(events[0].Outcome).To(Equal(audit.OutcomeAllowed))
+			Expect(events[0].ClusterID).To(BeEquivalentTo("consumer"))
+			Expect(events[1].Action).To(Equal(audit.ActionResourceSliceResources))
+			Expect(events[1].Outcome).To(Equal(audit.OutcomeDenied))
+			Expect(events[1].Reason).To(Equal("ResDenied"))
+		})
+	})
+
+	When("only one condition changed", func() {
+		BeforeEach(func() {
+			original = []authv1beta1.ResourceSliceCondition{
+				condition(authv1beta1.ResourceSliceConditionTypeAuthentication, authv1beta1.ResourceSliceConditionAccepted, "AuthAccepted"),
+				condition(authv1beta1.ResourceSliceConditionTypeResources, authv1beta1.ResourceSliceConditionAccepted, "ResAccepted"),
+			}
+			resourceSlice.Status.Conditions = []authv1beta1.ResourceSliceCondition{
+				condition(authv1beta1.ResourceSliceConditionTypeAuthentication, authv1beta1.ResourceSliceConditionAccepted, "AuthAccepted"),
+				condition(authv1beta1.ResourceSliceConditionTypeResources, authv1beta1.ResourceSliceConditionDenied, "ResDenied"),
+			}
+		})
+
+		It("should record an event for the changed condition only", func() {
+			events := recordedEvents()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal(audit.ActionResourceSliceResources))
+			Expect(events[0].Outcome).To(Equal(audit.OutcomeDenied))
+		})
+	})
+
+	When("no condition changed", func() {
+		BeforeEach(func() {
+			resourceSlice.Status.Conditions = []authv1beta1.ResourceSliceCondition{
+				condition(authv1beta1.ResourceSliceConditionTypeAuthentication, authv1beta1.ResourceSliceConditionAccepted, "AuthAccepted"),
+			}
+			original = resourceSlice.Status.DeepCopy().Conditions
+		})
+
+		It("should not record any event", func() { Expect(recordedEvents()).To(BeEmpty()) })
+	})
+})
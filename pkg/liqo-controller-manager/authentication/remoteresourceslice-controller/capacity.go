@@ -0,0 +1,194 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
+	"github.com/liqotech/liqo/pkg/utils/resources"
+)
+
+// getAvailableCapacity returns the resources which can still be granted to the given ResourceSlice.
+// It is computed as the allocatable capacity of the physical nodes of the cluster, minus the resources requested
+// by the pods running on them (excluding the offloaded ones), minus the resources already granted to the other
+// ResourceSlices.
+func (r *RemoteResourceSliceReconciler) getAvailableCapacity(ctx context.Context,
+	resourceSlice *authv1beta1.ResourceSlice) (corev1.ResourceList, error) {
+	nodes, localPods, err := r.getPhysicalNodesAndPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := r.getGrantedResources(ctx, resourceSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	available := resources.SubResources(resources.SumNodesAllocatable(nodes), resources.SumPodsRequests(podsOnAllocatableNodes(nodes, localPods)))
+	return resources.SubResources(available, granted), nil
+}
+
+// podsOnAllocatableNodes returns the pods bound to the nodes whose allocatable resources are accounted for,
+// since the ones running on cordoned or not ready nodes do not consume any of the available capacity.
+func podsOnAllocatableNodes(nodes []corev1.Node, pods []corev1.Pod) []corev1.Pod {
+	nodeNames := make(map[string]struct{}, len(nodes))
+	for i := range nodes {
+		if resources.IsAllocatableNode(&nodes[i]) {
+			nodeNames[nodes[i].Name] = struct{}{}
+		}
+	}
+
+	filtered := make([]corev1.Pod, 0, len(pods))
+	for i := range pods {
+		if _, ok := nodeNames[pods[i].Spec.NodeName]; ok {
+			filtered = append(filtered, pods[i])
+		}
+	}
+	return filtered
+}
+
+// getPhysicalNodesAndPods returns the physical nodes of the cluster, along with the pods running on them.
+// Offloaded pods are not returned, since their resources are already accounted for in the grants of the
+// corresponding ResourceSlices.
+func (r *RemoteResourceSliceReconciler) getPhysicalNodesAndPods(ctx context.Context) ([]corev1.Node, []corev1.Pod, error) {
+	nodes, err := getters.ListPhysicalNodes(ctx, r.Client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to list the physical nodes: %w", err)
+	}
+
+	nodeNames := make(map[string]struct{}, len(nodes.Items))
+	for i := range nodes.Items {
+		nodeNames[nodes.Items[i].Name] = struct{}{}
+	}
+
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList); err != nil {
+		return nil, nil, fmt.Errorf("unable to list the pods: %w", err)
+	}
+
+	localPods := make([]corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if _, ok := nodeNames[pod.Spec.NodeName]; !ok || pod.Labels[consts.ManagedByLabelKey] == consts.ManagedByShadowPodValue {
+			continue
+		}
+		localPods = append(localPods, *pod)
+	}
+
+	return nodes.Items, localPods, nil
+}
+
+// getGrantedResources returns the resources granted to all the remote ResourceSlices but the given one.
+func (r *RemoteResourceSliceReconciler) getGrantedResources(ctx context.Context,
+	resourceSlice *authv1beta1.ResourceSlice) (corev1.ResourceList, error) {
+	resSlices, err := getters.ListResourceSlicesByLabel(ctx, r.Client, corev1.NamespaceAll, liqolabels.RemoteLabelSelector())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the ResourceSlices: %w", err)
+	}
+
+	grantedSlices := make([]authv1beta1.ResourceSlice, 0, len(resSlices))
+	for i := range resSlices {
+		if resSlices[i].UID == resourceSlice.UID {
+			continue
+		}
+		cond := authentication.GetCondition(&resSlices[i], authv1beta1.ResourceSliceConditionTypeResources)
+		if cond == nil || cond.Status != authv1beta1.ResourceSliceConditionAccepted {
+			continue
+		}
+		grantedSlices = append(grantedSlices, resSlices[i])
+	}
+
+	return resources.SumResourceSlices(grantedSlices), nil
+}
+
+// grantResources returns the portion of the requested resources which fits in the available ones,
+// along with the list of the resources which could not be fully granted.
+func grantResources(requested, available corev1.ResourceList) (granted corev1.ResourceList, shortages []corev1.ResourceName) {
+	granted = corev1.ResourceList{}
+	for name, req := range requested {
+		avail, ok := available[name]
+		switch {
+		case !ok:
+			// The resource is not provided by the physical nodes (e.g., it is a custom one): nothing can be granted.
+			granted[name] = *resource.NewQuantity(0, req.Format)
+		case req.Cmp(avail) > 0:
+			granted[name] = avail.DeepCopy()
+		default:
+			granted[name] = req.DeepCopy()
+			continue
+		}
+		shortages = append(shortages, name)
+	}
+
+	sort.Slice(shortages, func(i, j int) bool { return shortages[i] < shortages[j] })
+	return granted, shortages
+}
+
+// isEmptyGrant returns whether none of the given resources has been granted.
+func isEmptyGrant(granted corev1.ResourceList) bool {
+	for _, v := range granted {
+		if !v.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// shortagesMessage returns a human-readable message describing the resources which could not be fully granted.
+func shortagesMessage(requested, granted corev1.ResourceList, shortages []corev1.ResourceName) string {
+	details := make([]string, len(shortages))
+	for i, name := range shortages {
+		req, grant := requested[name], granted[name]
+		details[i] = fmt.Sprintf("%s (requested: %s, granted: %s)", name, req.String(), grant.String())
+	}
+	return fmt.Sprintf("Insufficient capacity in the provider cluster for %s", strings.Join(details, ", "))
+}
+
+// handleCapacityStatus sets the resources of a ResourceSlice of the capacity class, granting the requested
+// resources (possibly partially) according to the capacity available in the provider cluster.
+func (r *RemoteResourceSliceReconciler) handleCapacityStatus(ctx context.Context,
+	resourceSlice *authv1beta1.ResourceSlice, requested corev1.ResourceList) error {
+	available, err := r.getAvailableCapacity(ctx, resourceSlice)
+	if err != nil {
+		return err
+	}
+
+	granted, shortages := grantResources(requested, available)
+	resourceSlice.Status.Resources = granted
+
+	switch {
+	case len(shortages) == 0:
+		acceptResources(resourceSlice, r.eventRecorder)
+	case isEmptyGrant(granted):
+		ensureResourcesCondition(resourceSlice, r.eventRecorder, authv1beta1.ResourceSliceConditionDenied,
+			"ResourceSliceResourcesInsufficientCapacity", shortagesMessage(requested, granted, shortages))
+	default:
+		ensureResourcesCondition(resourceSlice, r.eventRecorder, authv1beta1.ResourceSliceConditionAccepted,
+			"ResourceSliceResourcesPartiallyAccepted", shortagesMessage(requested, granted, shortages))
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+)
+
+const gpuResource corev1.ResourceName = "nvidia.com/gpu"
+
+// resourceList returns a ResourceList with the given CPU and memory quantities (empty ones are omitted).
+func resourceList(cpu, memory string) corev1.ResourceList {
+	list := corev1.ResourceList{}
+	if cpu != "" {
+		list[corev1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[corev1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return list
+}
+
+// expectResources asserts that the given ResourceList contains exactly the expected quantities.
+func expectResources(actual, expected corev1.ResourceList) {
+	Expect(actual).To(HaveLen(len(expected)))
+	for name, quantity := range expected {
+		Expect(actual).To(HaveKey(name))
+		got := actual[name]
+		Expect(got.Equal(quantity)).To(BeTrue(), "resource %s: expected %s, got %s", name, quantity.String(), got.String())
+	}
+}
+
+var _ = Describe("Capacity class", func() {
+	DescribeTable("the grantResources function",
+		func(requested, available, expected corev1.ResourceList, expectedShortages []corev1.ResourceName) {
+			granted, shortages := grantResources(requested, available)
+			expectResources(granted, expected)
+			Expect(shortages).To(Equal(expectedShortages))
+		},
+		Entry("full grant", resourceList("2", "4Gi"), resourceList("4", "8Gi"), resourceList("2", "4Gi"), nil),
+		Entry("full grant, with available exactly matching the requested resources",
+			resourceList("4", "8Gi"), resourceList("4", "8Gi"), resourceList("4", "8Gi"), nil),
+		Entry("partial grant with shortages", resourceList("6", "4Gi"), resourceList("4", "2Gi"), resourceList("4", "2Gi"),
+			[]corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}),
+		Entry("partial grant with a single shortage", resourceList("6", "4Gi"), resourceList("4", "8Gi"), resourceList("4", "4Gi"),
+			[]corev1.ResourceName{corev1.ResourceCPU}),
+		Entry("zero available", resourceList("2", "4Gi"), resourceList("0", "0"), resourceList("0", "0"),
+			[]corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}),
+		Entry("missing resource key",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), gpuResource: resource.MustParse("1")},
+			resourceList("4", "8Gi"),
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), gpuResource: resource.MustParse("0")},
+			[]corev1.ResourceName{gpuResource}),
+		Entry("nothing requested", corev1.ResourceList{}, resourceList("4", "8Gi"), corev1.ResourceList{}, nil),
+	)
+
+	Describe("the handleCapacityStatus function", func() {
+		const tenantNamespace = "liqo-tenant-consumer"
+
+		var (
+			ctx           context.Context
+			r             *RemoteResourceSliceReconciler
+			resourceSlice *authv1beta1.ResourceSlice
+			others        corev1.ResourceList
+			requested     corev1.ResourceList
+			objects       []client.Object
+			err           error
+		)
+
+		forgeResourceSlice := func(name string) *authv1beta1.ResourceSlice {
+			return &authv1beta1.ResourceSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: tenantNamespace, UID: types.UID("uid-" + name),
+					Labels: map[string]string{consts.ReplicationStatusLabel: "true"},
+				},
+				Spec: authv1beta1.ResourceSliceSpec{Class: authv1beta1.ResourceSliceClassCapacity},
+			}
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			// The slice was previously granted 6 CPUs, and the corresponding condition is accepted.
+			resourceSlice = forgeResourceSlice("slice")
+			resourceSlice.Status.Resources = resourceList("6", "4Gi")
+			authentication.EnsureCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources,
+				authv1beta1.ResourceSliceConditionAccepted, "ResourceSliceResourcesAccepted", "ResourceSlice resources accepted")
+			requested = resourceList("6", "4Gi")
+			others = resourceList("1", "1Gi")
+			objects = nil
+		})
+
+		JustBeforeEach(func() {
+			other := forgeResourceSlice("other")
+			other.Status.Resources = others
+			authentication.EnsureCondition(other, authv1beta1.ResourceSliceConditionTypeResources,
+				authv1beta1.ResourceSliceConditionAccepted, "ResourceSliceResourcesAccepted", "ResourceSlice resources accepted")
+
+			allocatable := resourceList("8", "16Gi")
+			allocatable[corev1.ResourcePods] = resource.MustParse("110")
+
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "physical"},
+					Status: corev1.NodeStatus{
+						Allocatable: allocatable,
+						Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+					},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "default"},
+					Spec: corev1.PodSpec{NodeName: "physical", Containers: []corev1.Container{{
+						Resources: corev1.ResourceRequirements{Requests: resourceList("1", "")},
+					}}},
+				},
+				other, resourceSlice,
+			).WithObjects(objects...).Build()
+
+			r = &RemoteResourceSliceReconciler{
+				Client:        cl,
+				Scheme:        scheme,
+				eventRecorder: record.NewFakeRecorder(10),
+			}
+
+			err = r.handleCapacityStatus(ctx, resourceSlice, requested)
+		})
+
+		When("the available capacity covers the previous grant", func() {
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should keep granting the requested resources", func() {
+				expectResources(resourceSlice.Status.Resources, resourceList("6", "4Gi"))
+			})
+			It("should keep the resources accepted", func() {
+				cond := authentication.GetCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources)
+				Expect(cond.Status).To(Equal(authv1beta1.ResourceSliceConditionAccepted))
+				Expect(cond.Reason).To(Equal("ResourceSliceResourcesAccepted"))
+			})
+		})
+
+		When("the available capacity shrinks below the previous grant", func() {
+			// 8 allocatable CPUs, minus 1 requested by the local pod, minus 3 granted to the other slice.
+			BeforeEach(func() { others = resourceList("3", "1Gi") })
+
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should reduce the grant to the available capacity", func() {
+				expectResources(resourceSlice.Status.Resources, resourceList("4", "4Gi"))
+			})
+			It("should mark the resources as partially accepted, reporting the shortages", func() {
+				cond := authentication.GetCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources)
+				Expect(cond.Status).To(Equal(authv1beta1.ResourceSliceConditionAccepted))
+				Expect(cond.Reason).To(Equal("ResourceSliceResourcesPartiallyAccepted"))
+				Expect(cond.Message).To(ContainSubstring("cpu (requested: 6, granted: 4)"))
+				Expect(cond.Message).ToNot(ContainSubstring("memory"))
+			})
+		})
+
+		When("pods run on cordoned or not ready nodes", func() {
+			// The full request fits only if neither these nodes nor their pods are accounted for.
+			BeforeEach(func() {
+				requested = resourceList("4", "4Gi")
+				others = resourceList("3", "1Gi")
+				forgeNode := func(name string, unschedulable bool, ready corev1.ConditionStatus) *corev1.Node {
+					return &corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+						Status: corev1.NodeStatus{
+							Allocatable: resourceList("8", "16Gi"),
+							Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+						},
+					}
+				}
+				forgePod := func(name, nodeName string) *corev1.Pod {
+					return &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+						Spec: corev1.PodSpec{NodeName: nodeName, Containers: []corev1.Container{{
+							Resources: corev1.ResourceRequirements{Requests: resourceList("4", "")},
+						}}},
+					}
+				}
+				objects = []client.Object{
+					forgeNode("cordoned", true, corev1.ConditionTrue), forgePod("on-cordoned", "cordoned"),
+					forgeNode("not-ready", false, corev1.ConditionFalse), forgePod("on-not-ready", "not-ready"),
+				}
+			})
+
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should subtract only the pods running on the accounted nodes", func() {
+				expectResources(resourceSlice.Status.Resources, resourceList("4", "4Gi"))
+				cond := authentication.GetCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources)
+				Expect(cond.Reason).To(Equal("ResourceSliceResourcesAccepted"))
+			})
+		})
+
+		When("the pods resource is requested", func() {
+			// 110 allocatable pods, minus the local one, minus 9 granted to the other slice.
+			BeforeEach(func() {
+				requested[corev1.ResourcePods] = resource.MustParse("110")
+				others[corev1.ResourcePods] = resource.MustParse("9")
+			})
+
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should account for each running pod", func() {
+				Expect(resourceSlice.Status.Resources).To(HaveKey(corev1.ResourcePods))
+				pods := resourceSlice.Status.Resources[corev1.ResourcePods]
+				Expect(pods.Equal(resource.MustParse("100"))).To(BeTrue(), "expected 100 pods, got %s", pods.String())
+			})
+		})
+
+		When("no capacity is left", func() {
+			BeforeEach(func() { others = resourceList("7", "16Gi") })
+
+			It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+			It("should grant no resources", func() {
+				expectResources(resourceSlice.Status.Resources, resourceList("0", "0"))
+			})
+			It("should deny the resources", func() {
+				cond := authentication.GetCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources)
+				Expect(cond.Status).To(Equal(authv1beta1.ResourceSliceConditionDenied))
+				Expect(cond.Reason).To(Equal("ResourceSliceResourcesInsufficientCapacity"))
+			})
+		})
+	})
+})
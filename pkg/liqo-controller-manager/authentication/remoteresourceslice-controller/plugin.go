@@ -0,0 +1,127 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/resourceoffer"
+	"github.com/liqotech/liqo/pkg/utils/resources"
+)
+
+// pluginTimeout is the maximum time waited for a ResourceOffer plugin to reply.
+const pluginTimeout = 10 * time.Second
+
+// handlePluginStatus sets the resources of a ResourceSlice of a custom class, according to the
+// offer returned by the ResourceOffer plugin in charge of that class.
+func (r *RemoteResourceSliceReconciler) handlePluginStatus(ctx context.Context, plugin resourceoffer.ResourceOfferClient,
+	resourceSlice *authv1beta1.ResourceSlice, tenant *authv1beta1.Tenant) error {
+	inventory, err := r.forgeInventory(ctx, resourceSlice)
+	if err != nil {
+		return err
+	}
+
+	req, err := resourceoffer.ForgeOfferRequest(resourceSlice, tenant, inventory)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pluginTimeout)
+	defer cancel()
+
+	resp, err := plugin.Offer(ctx, req)
+	if err != nil {
+		return fmt.Errorf("unable to get the offer from the plugin of class %q: %w", resourceSlice.Spec.Class, err)
+	}
+
+	granted, err := resourceoffer.MapToResourceList(resp.GetResources())
+	if err != nil {
+		return fmt.Errorf("invalid offer from the plugin of class %q: %w", resourceSlice.Spec.Class, err)
+	}
+	resourceSlice.Status.Resources = granted
+
+	// Fields not set by the plugin default to the ones offered by the provider cluster.
+	if len(resp.GetStorageClasses()) > 0 {
+		resourceSlice.Status.StorageClasses = resourceoffer.StorageClassesFromProto(resp.GetStorageClasses())
+	}
+	if len(resp.GetIngressClasses()) > 0 {
+		resourceSlice.Status.IngressClasses = resourceoffer.IngressClassesFromProto(resp.GetIngressClasses())
+	}
+	if len(resp.GetLoadBalancerClasses()) > 0 {
+		resourceSlice.Status.LoadBalancerClasses = resourceoffer.LoadBalancerClassesFromProto(resp.GetLoadBalancerClasses())
+	}
+	if len(resp.GetNodeLabels()) > 0 {
+		resourceSlice.Status.NodeLabels = resp.GetNodeLabels()
+	}
+	resourceSlice.Status.NodeSelector = resp.GetNodeSelector()
+
+	status, reason, message := authv1beta1.ResourceSliceConditionAccepted, "ResourceSliceResourcesAccepted", "ResourceSlice resources accepted"
+	if !resp.GetAccepted() {
+		status, reason, message = authv1beta1.ResourceSliceConditionDenied, "ResourceSliceResourcesDenied", "ResourceSlice resources denied"
+	}
+	if resp.GetReason() != "" {
+		reason = resp.GetReason()
+	}
+	if resp.GetMessage() != "" {
+		message = resp.GetMessage()
+	}
+	ensureResourcesCondition(resourceSlice, r.eventRecorder, status, reason, message)
+
+	return nil
+}
+
+// forgeInventory forges the inventory of the provider cluster sent to the ResourceOffer plugins.
+func (r *RemoteResourceSliceReconciler) forgeInventory(ctx context.Context,
+	resourceSlice *authv1beta1.ResourceSlice) (*resourceoffer.Inventory, error) {
+	nodes, localPods, err := r.getPhysicalNodesAndPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	podsByNode := make(map[string][]corev1.Pod, len(nodes))
+	for i := range localPods {
+		podsByNode[localPods[i].Spec.NodeName] = append(podsByNode[localPods[i].Spec.NodeName], localPods[i])
+	}
+
+	granted, err := r.getGrantedResources(ctx, resourceSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &resourceoffer.Inventory{
+		Nodes:               make([]*resourceoffer.Node, len(nodes)),
+		Granted:             resourceoffer.ResourceListToMap(granted),
+		StorageClasses:      resourceoffer.StorageClassesToProto(resourceSlice.Status.StorageClasses),
+		IngressClasses:      resourceoffer.IngressClassesToProto(resourceSlice.Status.IngressClasses),
+		LoadBalancerClasses: resourceoffer.LoadBalancerClassesToProto(resourceSlice.Status.LoadBalancerClasses),
+		ClusterLabels:       getNodeLabels(r.sliceStatusOptions),
+	}
+	for i := range nodes {
+		inventory.Nodes[i] = resourceoffer.ForgeNode(&nodes[i], resources.SumPodsRequests(podsByNode[nodes[i].Name]))
+	}
+
+	return inventory, nil
+}
+
+// getPlugin returns the ResourceOffer plugin in charge of the class of the given ResourceSlice, if any.
+func (r *RemoteResourceSliceReconciler) getPlugin(resourceSlice *authv1beta1.ResourceSlice) (resourceoffer.ResourceOfferClient, bool) {
+	plugin, ok := r.resourceOfferPlugins[resourceSlice.Spec.Class]
+	return plugin, ok
+}
@@ -0,0 +1,201 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteresourceslicecontroller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/resourceoffer"
+)
+
+// fakePlugin is a ResourceOffer plugin returning a predefined response, and recording the received request.
+type fakePlugin struct {
+	resp *resourceoffer.OfferResponse
+	err  error
+	req  *resourceoffer.OfferRequest
+}
+
+func (p *fakePlugin) Offer(_ context.Context, req *resourceoffer.OfferRequest, _ ...grpc.CallOption) (*resourceoffer.OfferResponse, error) {
+	p.req = req
+	return p.resp, p.err
+}
+
+var _ = Describe("ResourceOffer plugins", func() {
+	const tenantNamespace = "liqo-tenant-consumer"
+
+	var (
+		ctx           context.Context
+		r             *RemoteResourceSliceReconciler
+		plugin        *fakePlugin
+		resourceSlice *authv1beta1.ResourceSlice
+		tenant        *authv1beta1.Tenant
+		err           error
+
+		forgeNode = func(name string, labels map[string]string) *corev1.Node {
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+				Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("8"), corev1.ResourceMemory: resource.MustParse("16Gi"),
+				}},
+			}
+		}
+
+		forgeResourceSlice = func(name string, cpu string) *authv1beta1.ResourceSlice {
+			return &authv1beta1.ResourceSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: tenantNamespace, UID: types.UID("uid-" + name),
+					Labels: map[string]string{consts.ReplicationStatusLabel: "true"},
+				},
+				Spec: authv1beta1.ResourceSliceSpec{
+					Class:     "gold",
+					Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		plugin = &fakePlugin{}
+		tenant = &authv1beta1.Tenant{Spec: authv1beta1.TenantSpec{ClusterID: "consumer"}}
+		resourceSlice = forgeResourceSlice("slice", "2")
+		resourceSlice.Status.StorageClasses = []liqov1beta1.StorageType{{StorageClassName: "standard", Default: true}}
+
+		granted := forgeResourceSlice("other", "3")
+		granted.Status.Resources = granted.Spec.Resources
+		authentication.EnsureCondition(granted, authv1beta1.ResourceSliceConditionTypeResources,
+			authv1beta1.ResourceSliceConditionAccepted, "ResourceSliceResourcesAccepted", "")
+
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			forgeNode("physical", nil),
+			forgeNode("virtual", map[string]string{consts.TypeLabel: consts.TypeNode}),
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "default"},
+				Spec: corev1.PodSpec{NodeName: "physical", Containers: []corev1.Container{{
+					Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+				}}},
+			},
+			granted,
+		).Build()
+
+		r = &RemoteResourceSliceReconciler{
+			Client:             cl,
+			Scheme:             scheme,
+			eventRecorder:      record.NewFakeRecorder(10),
+			sliceStatusOptions: &SliceStatusOptions{ClusterLabels: map[string]string{"provider": "true"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		err = r.handlePluginStatus(ctx, plugin, resourceSlice, tenant)
+	})
+
+	When("the plugin accepts the ResourceSlice", func() {
+		BeforeEach(func() {
+			plugin.resp = &resourceoffer.OfferResponse{
+				Accepted:   true,
+				Resources:  map[string]string{"cpu": "1500m"},
+				NodeLabels: map[string]string{"tier": "gold"},
+			}
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should send the inventory of the physical nodes of the provider cluster", func() {
+			inventory := plugin.req.GetInventory()
+			Expect(inventory.GetNodes()).To(HaveLen(1))
+			Expect(inventory.GetNodes()[0].GetName()).To(Equal("physical"))
+			Expect(inventory.GetNodes()[0].GetRequested()).To(HaveKeyWithValue("cpu", "1"))
+			Expect(inventory.GetGranted()).To(HaveKeyWithValue("cpu", "3"))
+			Expect(inventory.GetClusterLabels()).To(HaveKeyWithValue("provider", "true"))
+		})
+
+		It("should send the ResourceSlice and the Tenant", func() {
+			slice, t, err := resourceoffer.ParseOfferRequest(plugin.req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(slice.Name).To(Equal(resourceSlice.Name))
+			Expect(t.Spec.ClusterID).To(Equal(tenant.Spec.ClusterID))
+		})
+
+		It("should set the granted resources and the offered node labels", func() {
+			Expect(resourceSlice.Status.Resources.Cpu().String()).To(Equal("1500m"))
+			Expect(resourceSlice.Status.NodeLabels).To(HaveKeyWithValue("tier", "gold"))
+		})
+
+		It("should keep the classes not set by the plugin", func() {
+			Expect(resourceSlice.Status.StorageClasses).To(ConsistOf(liqov1beta1.StorageType{StorageClassName: "standard", Default: true}))
+		})
+
+		It("should accept the resources", func() {
+			cond := authentication.GetCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(authv1beta1.ResourceSliceConditionAccepted))
+			Expect(cond.Reason).To(Equal("ResourceSliceResourcesAccepted"))
+		})
+	})
+
+	When("the plugin denies the ResourceSlice", func() {
+		BeforeEach(func() {
+			plugin.resp = &resourceoffer.OfferResponse{
+				Accepted: false, Reason: "QuotaExceeded", Message: "the gold quota is exhausted",
+			}
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+
+		It("should deny the resources with the reason returned by the plugin", func() {
+			cond := authentication.GetCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(authv1beta1.ResourceSliceConditionDenied))
+			Expect(cond.Reason).To(Equal("QuotaExceeded"))
+			Expect(cond.Message).To(Equal("the gold quota is exhausted"))
+		})
+	})
+
+	When("the plugin returns invalid resources", func() {
+		BeforeEach(func() {
+			plugin.resp = &resourceoffer.OfferResponse{Accepted: true, Resources: map[string]string{"cpu": "invalid"}}
+		})
+
+		It("should fail without setting the condition", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(authentication.GetCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources)).To(BeNil())
+		})
+	})
+
+	When("the plugin cannot be reached", func() {
+		BeforeEach(func() { plugin.err = fmt.Errorf("connection refused") })
+
+		It("should fail without setting the condition", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(authentication.GetCondition(resourceSlice, authv1beta1.ResourceSliceConditionTypeResources)).To(BeNil())
+		})
+	})
+})
@@ -20,8 +20,8 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -40,6 +40,7 @@ import (
 	"github.com/liqotech/liqo/pkg/consts"
 	identitymanager "github.com/liqotech/liqo/pkg/identityManager"
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	"github.com/liqotech/liqo/pkg/resourceoffer"
 	tenantnamespace "github.com/liqotech/liqo/pkg/tenantNamespace"
 	"github.com/liqotech/liqo/pkg/utils/getters"
 	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
@@ -51,7 +52,17 @@ func NewRemoteResourceSliceReconciler(cl client.Client, s *runtime.Scheme, confi
 	identityProvider identitymanager.IdentityProvider,
 	namespaceManager tenantnamespace.Manager,
 	apiServerAddressOverride string, caOverride []byte, trustedCA bool,
-	sliceStatusOptions *SliceStatusOptions) *RemoteResourceSliceReconciler {
+	sliceStatusOptions *SliceStatusOptions,
+	resourceOfferPlugins map[authv1beta1.ResourceSliceClass]resourceoffer.ResourceOfferClient) *RemoteResourceSliceReconciler {
+	reconciledClasses := []authv1beta1.ResourceSliceClass{
+		authv1beta1.ResourceSliceClassDefault,
+		authv1beta1.ResourceSliceClassUnknown,
+		authv1beta1.ResourceSliceClassCapacity,
+	}
+	for class := range resourceOfferPlugins {
+		reconciledClasses = append(reconciledClasses, class)
+	}
+
 	return &RemoteResourceSliceReconciler{
 		Client: cl,
 		Scheme: s,
@@ -65,12 +76,10 @@ func NewRemoteResourceSliceReconciler(cl client.Client, s *runtime.Scheme, confi
 		caOverride:               caOverride,
 		trustedCA:                trustedCA,
 
-		sliceStatusOptions: sliceStatusOptions,
+		sliceStatusOptions:   sliceStatusOptions,
+		resourceOfferPlugins: resourceOfferPlugins,
 
-		reconciledClasses: []authv1beta1.ResourceSliceClass{
-			authv1beta1.ResourceSliceClassDefault,
-			authv1beta1.ResourceSliceClassUnknown,
-		},
+		reconciledClasses: reconciledClasses,
 	}
 }
 
@@ -88,7 +97,8 @@ type RemoteResourceSliceReconciler struct {
 	caOverride               []byte
 	trustedCA                bool
 
-	sliceStatusOptions *SliceStatusOptions
+	sliceStatusOptions   *SliceStatusOptions
+	resourceOfferPlugins map[authv1beta1.ResourceSliceClass]resourceoffer.ResourceOfferClient
 
 	reconciledClasses []authv1beta1.ResourceSliceClass
 }
@@ -96,8 +106,10 @@ type RemoteResourceSliceReconciler struct {
 // cluster-role
 // +kubebuilder:rbac:groups=authentication.liqo.io,resources=resourceslices;resourceslices/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=authentication.liqo.io,resources=tenants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=peeringroleprofiles,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=storage,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes;pods,verbs=get;list;watch
 
 // Reconcile replicated ResourceSlice resources.
 func (r *RemoteResourceSliceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -138,12 +150,23 @@ func (r *RemoteResourceSliceReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	// Defer the update of the ResourceSlice status.
+	// The changes of the conditions are recorded in the audit stream only once the status has been persisted,
+	// to prevent duplicate records in case the update fails (e.g., because of a conflict) and the reconciliation is retried.
+	originalStatus := resourceSlice.Status.DeepCopy()
 	defer func() {
+		// Skip the update (and the corresponding event) if the status did not change, as in most periodic resyncs.
+		if equality.Semantic.DeepEqual(originalStatus, &resourceSlice.Status) {
+			klog.V(4).Infof("Status of ResourceSlice %q already up-to-date", req.NamespacedName)
+			return
+		}
+
 		errDef := r.Client.Status().Update(ctx, &resourceSlice)
 		if errDef != nil {
 			klog.Errorf("Unable to update the ResourceSlice %q: %s", req.NamespacedName, errDef)
 			r.eventRecorder.Event(&resourceSlice, corev1.EventTypeWarning, "UpdateFailed", errDef.Error())
 			err = errDef
+		} else {
+			recordConditionAuditEvents(&resourceSlice, originalStatus.Conditions)
 		}
 
 		if err == nil {
@@ -174,15 +197,23 @@ func (r *RemoteResourceSliceReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
+	// The resources granted to ResourceSlices of the capacity class are periodically re-evaluated,
+	// to follow the changes of the capacity available in the cluster.
+	if isInResourceClasses(&resourceSlice, authv1beta1.ResourceSliceClassCapacity) {
+		return ctrl.Result{RequeueAfter: r.sliceStatusOptions.CapacityResyncPeriod}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
 func (r *RemoteResourceSliceReconciler) handleAuthenticationStatus(ctx context.Context,
 	resourceSlice *authv1beta1.ResourceSlice, tenant *authv1beta1.Tenant) error {
-	// check that the CSR is valid
+	// check that the CSR is valid, and bound to the subject corresponding to the current generation of the credentials
 	shouldCheckPublicKey := authv1beta1.GetAuthzPolicyValue(tenant.Spec.AuthzPolicy) != authv1beta1.TolerateNoHandshake
+	generation := authentication.TenantCredentialsGeneration(tenant)
 
-	if err := authentication.CheckCSRForResourceSlice(tenant.Spec.PublicKey, resourceSlice, shouldCheckPublicKey); err != nil {
+	if err := authentication.CheckCSRForResourceSlice(resourceSlice.Spec.CSR, tenant.Spec.PublicKey, resourceSlice,
+		generation, shouldCheckPublicKey); err != nil {
 		klog.Errorf("Invalid CSR for the ResourceSlice %q: %s", client.ObjectKeyFromObject(resourceSlice), err)
 		r.eventRecorder.Event(resourceSlice, corev1.EventTypeWarning, "InvalidCSR", err.Error())
 		denyAuthentication(resourceSlice, r.eventRecorder)
@@ -202,6 +233,8 @@ func (r *RemoteResourceSliceReconciler) handleAuthenticationStatus(ctx context.C
 		TrustedCA:                r.trustedCA,
 		ResourceSlice:            resourceSlice,
 		ProxyURL:                 tenant.Spec.ProxyURL,
+		CredentialsGeneration:    generation,
+		Validity:                 authentication.TenantCredentialsValidity(tenant),
 	})
 	if err != nil {
 		klog.Errorf("Unable to forge the AuthParams for the ResourceSlice %q: %s", client.ObjectKeyFromObject(resourceSlice), err)
@@ -210,6 +243,7 @@ func (r *RemoteResourceSliceReconciler) handleAuthenticationStatus(ctx context.C
 		return err
 	}
 
+	authParams.Rotation = authentication.ForgeCredentialRotation(tenant)
 	resourceSlice.Status.AuthParams = authParams
 
 	// accept the authentication
@@ -224,32 +258,21 @@ func (r *RemoteResourceSliceReconciler) handleResourcesStatus(ctx context.Contex
 
 	switch tenant.Spec.TenantCondition {
 	case authv1beta1.TenantConditionActive:
-		// If the ResourceSlice is not of the default class, the resource status is leaved as it is and the update is
-		// demanded to external controllers/plugins.
+		// If the ResourceSlice is neither of a built-in class nor of a class managed by a ResourceOffer plugin,
+		// the resource status is leaved as it is and the update is demanded to external controllers.
 		if !isInResourceClasses(resourceSlice, r.reconciledClasses...) {
-			klog.V(6).Infof("ResourceSlice %q is not of the default class, the resource status is leaved as it is",
+			klog.V(6).Infof("ResourceSlice %q is not of a reconciled class, the resource status is leaved as it is",
 				client.ObjectKeyFromObject(resourceSlice))
 			return nil
 		}
 
-		// Default class: accept requested resources and set the default values for the resources not specified.
-		findOrDefault := func(resource corev1.ResourceName, val resource.Quantity) resource.Quantity {
-			v, ok := resourceSlice.Spec.Resources[resource]
-			if ok {
-				return v
-			}
-			return val
-		}
-
-		if resourceSlice.Status.Resources == nil {
-			resourceSlice.Status.Resources = corev1.ResourceList{}
-		}
-
+		// Compute the requested resources, setting the default values for the resources not specified.
+		requested := corev1.ResourceList{}
 		for k, v := range r.sliceStatusOptions.DefaultResourceQuantity {
-			resourceSlice.Status.Resources[k] = findOrDefault(k, v)
+			requested[k] = v.DeepCopy()
 		}
 		for k, v := range resourceSlice.Spec.Resources {
-			resourceSlice.Status.Resources[k] = v
+			requested[k] = v.DeepCopy()
 		}
 
 		resourceSlice.Status.StorageClasses, err = getStorageClasses(ctx, r.Client, r.sliceStatusOptions)
@@ -263,6 +286,29 @@ func (r *RemoteResourceSliceReconciler) handleResourcesStatus(ctx context.Contex
 		resourceSlice.Status.LoadBalancerClasses = getLoadBalancerClasses(r.sliceStatusOptions)
 		resourceSlice.Status.NodeLabels = getNodeLabels(r.sliceStatusOptions)
 
+		resourceSlice.Status.DisabledReflectors, err = getDisabledReflectors(ctx, r.Client, tenant)
+		if err != nil {
+			klog.Errorf("Unable to get the PeeringRoleProfile for the ResourceSlice %q: %s", client.ObjectKeyFromObject(resourceSlice), err)
+			r.eventRecorder.Event(resourceSlice, corev1.EventTypeWarning, "PeeringRoleProfileFailed", err.Error())
+			return err
+		}
+
+		if plugin, ok := r.getPlugin(resourceSlice); ok {
+			// Custom class: the granted resources are computed by the corresponding ResourceOffer plugin.
+			if err = r.handlePluginStatus(ctx, plugin, resourceSlice, tenant); err != nil {
+				klog.Errorf("Unable to handle the resources of the ResourceSlice %q: %s", client.ObjectKeyFromObject(resourceSlice), err)
+				r.eventRecorder.Event(resourceSlice, corev1.EventTypeWarning, "ResourceOfferFailed", err.Error())
+			}
+			return err
+		}
+
+		if isInResourceClasses(resourceSlice, authv1beta1.ResourceSliceClassCapacity) {
+			// Capacity class: grant the requested resources according to the capacity available in the cluster.
+			return r.handleCapacityStatus(ctx, resourceSlice, requested)
+		}
+
+		// Default class: accept the requested resources.
+		resourceSlice.Status.Resources = requested
 		acceptResources(resourceSlice, r.eventRecorder)
 	case authv1beta1.TenantConditionCordoned:
 		// Only deny if the resources are not already accepted.
@@ -293,9 +339,30 @@ func (r *RemoteResourceSliceReconciler) SetupWithManager(mgr ctrl.Manager) error
 			builder.WithPredicates(predicate.And(remoteResSliceFilter, withCSR(), predicate.GenerationChangedPredicate{})),
 		).
 		Watches(&authv1beta1.Tenant{}, handler.EnqueueRequestsFromMapFunc(r.resourceSlicesEnquer())).
+		Watches(&authv1beta1.PeeringRoleProfile{}, handler.EnqueueRequestsFromMapFunc(r.profileResourceSlicesEnqueuer())).
 		Complete(r)
 }
 
+// profileResourceSlicesEnqueuer enqueues the ResourceSlices of the tenants referencing the given PeeringRoleProfile.
+func (r *RemoteResourceSliceReconciler) profileResourceSlicesEnqueuer() func(ctx context.Context, obj client.Object) []reconcile.Request {
+	enqueueSlices := r.resourceSlicesEnquer()
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var tenants authv1beta1.TenantList
+		if err := r.List(ctx, &tenants); err != nil {
+			klog.Errorf("Failed to retrieve Tenants for PeeringRoleProfile %q: %v", obj.GetName(), err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for i := range tenants.Items {
+			if ref := tenants.Items[i].Spec.PeeringRoleProfileRef; ref != nil && ref.Name == obj.GetName() {
+				reqs = append(reqs, enqueueSlices(ctx, &tenants.Items[i])...)
+			}
+		}
+		return reqs
+	}
+}
+
 func (r *RemoteResourceSliceReconciler) resourceSlicesEnquer() func(ctx context.Context, obj client.Object) []reconcile.Request {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
 		tenant, ok := obj.(*authv1beta1.Tenant)
@@ -415,6 +482,29 @@ func denyResources(resourceSlice *authv1beta1.ResourceSlice, er record.EventReco
 	}
 }
 
+func ensureResourcesCondition(resourceSlice *authv1beta1.ResourceSlice, er record.EventRecorder,
+	status authv1beta1.ResourceSliceConditionStatus, reason, message string) {
+	switch authentication.EnsureCondition(
+		resourceSlice,
+		authv1beta1.ResourceSliceConditionTypeResources,
+		status,
+		reason,
+		message,
+	) {
+	case controllerutil.OperationResultNone:
+		klog.V(4).Infof("ResourceSlice resources %q condition already set (%s: %s)", resourceSlice.Name, status, reason)
+	case controllerutil.OperationResultUpdated, controllerutil.OperationResultCreated:
+		klog.Infof("ResourceSlice resources %q condition set (%s: %s): %s", resourceSlice.Name, status, reason, message)
+		eventType := corev1.EventTypeNormal
+		if status == authv1beta1.ResourceSliceConditionDenied {
+			eventType = corev1.EventTypeWarning
+		}
+		er.Event(resourceSlice, eventType, reason, message)
+	default:
+		return
+	}
+}
+
 func isInResourceClasses(resourceSlice *authv1beta1.ResourceSlice, classes ...authv1beta1.ResourceSliceClass) bool {
 	for _, class := range classes {
 		if resourceSlice.Spec.Class == class {
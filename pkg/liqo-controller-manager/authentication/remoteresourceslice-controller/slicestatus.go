@@ -17,12 +17,17 @@ package remoteresourceslicecontroller
 import (
 	"context"
 	"sort"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	peeringroles "github.com/liqotech/liqo/pkg/peering-roles"
 	argutils "github.com/liqotech/liqo/pkg/utils/args"
 )
 
@@ -34,6 +39,9 @@ type SliceStatusOptions struct {
 	LoadBalancerClasses       argutils.ClassNameList
 	ClusterLabels             map[string]string
 	DefaultResourceQuantity   corev1.ResourceList
+	// CapacityResyncPeriod is the period after which the resources granted to the ResourceSlices
+	// of the capacity class are re-evaluated.
+	CapacityResyncPeriod time.Duration
 }
 
 func getIngressClasses(opts *SliceStatusOptions) []liqov1beta1.IngressType {
@@ -104,3 +112,20 @@ func getNodeLabels(opts *SliceStatusOptions) map[string]string {
 	}
 	return opts.ClusterLabels
 }
+
+// getDisabledReflectors returns the reflectors not permitted by the PeeringRoleProfile referenced by the given tenant.
+// If the referenced profile does not exist, no permissions are granted to the tenant, hence all the reflectors
+// operating on the resources restricted by the profiles are disabled.
+func getDisabledReflectors(ctx context.Context, cl client.Client, tenant *authv1beta1.Tenant) ([]string, error) {
+	profile, err := peeringroles.GetTenantProfile(ctx, cl, tenant)
+	switch {
+	case apierrors.IsNotFound(err):
+		klog.Warningf("PeeringRoleProfile %q referenced by the Tenant %q not found, disabling all the restricted reflectors",
+			tenant.Spec.PeeringRoleProfileRef.Name, tenant.Name)
+		return peeringroles.MissingProfileDisabledReflectors(), nil
+	case err != nil || profile == nil:
+		return nil, err
+	default:
+		return peeringroles.DisabledReflectors(&profile.Spec), nil
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenantcontroller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/audit"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+// tenantCondition returns the condition of the Tenant, defaulting to active if unset.
+func tenantCondition(tenant *authv1beta1.Tenant) authv1beta1.TenantCondition {
+	if tenant.Spec.TenantCondition == "" {
+		return authv1beta1.TenantConditionActive
+	}
+	return tenant.Spec.TenantCondition
+}
+
+// enforceConditionTransitionAudit records the transition of the condition of the Tenant in the audit stream, if any.
+// The last recorded condition is persisted in an annotation of the Tenant, so that the transitions occurred
+// while the controller was not running (e.g., during a restart or a leader election) are recorded as well.
+func (r *TenantReconciler) enforceConditionTransitionAudit(ctx context.Context, tenant *authv1beta1.Tenant) error {
+	current := tenantCondition(tenant)
+	previous, found := tenant.Annotations[consts.LastAuditedTenantConditionAnnotation]
+	if found && previous == string(current) {
+		return nil
+	}
+
+	original := tenant.DeepCopy()
+	if tenant.Annotations == nil {
+		tenant.Annotations = make(map[string]string)
+	}
+	tenant.Annotations[consts.LastAuditedTenantConditionAnnotation] = string(current)
+	if err := r.Client.Patch(ctx, tenant, client.MergeFrom(original)); err != nil {
+		klog.Errorf("Unable to store the last audited condition of the Tenant %q: %s", tenant.Name, err)
+		return err
+	}
+
+	switch {
+	case found:
+		recordTenantAuditEvent(tenant, audit.ActionTenantConditionChange, string(current),
+			fmt.Sprintf("Tenant condition changed from %s to %s", previous, current))
+	case current != authv1beta1.TenantConditionActive:
+		// The Tenant has never been audited before, hence the previous condition is unknown.
+		recordTenantAuditEvent(tenant, audit.ActionTenantConditionChange, string(current),
+			fmt.Sprintf("Tenant condition set to %s", current))
+	}
+	return nil
+}
+
+// recordTenantAuditEvent records an operation concerning the given Tenant in the audit stream.
+func recordTenantAuditEvent(tenant *authv1beta1.Tenant, action audit.Action, reason, message string) {
+	audit.Record(&audit.Event{
+		ClusterID: tenant.Spec.ClusterID,
+		Action:    action,
+		Outcome:   audit.OutcomeSucceeded,
+		Resource:  authv1beta1.TenantResource,
+		Namespace: tenant.Namespace,
+		Name:      tenant.Name,
+		Reason:    reason,
+		Message:   message,
+	})
+}
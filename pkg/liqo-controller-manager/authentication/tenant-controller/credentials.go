@@ -0,0 +1,114 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenantcontroller
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/auth"
+	"github.com/liqotech/liqo/pkg/consts"
+	identitymanager "github.com/liqotech/liqo/pkg/identityManager"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+)
+
+// revocationRequested returns whether the revocation of the credentials of the tenant has been requested.
+func revocationRequested(tenant *authv1beta1.Tenant) bool {
+	return strings.EqualFold(tenant.Annotations[consts.RevokeCredentialsAnnotation], "true")
+}
+
+// handleCredentialsRevocation revokes the credentials issued to the tenant. Since certificates cannot be revoked
+// in Kubernetes, the permissions are bound to new subjects, including the new generation of the credentials:
+// the control plane permissions to a new user (the cluster ID followed by the generation), and the permissions
+// of the virtual nodes (i.e., of the ResourceSlice credentials) to a new group (likewise derived from the cluster ID).
+// The credentials issued so far immediately lose any permission, although they are formally valid until their
+// expiration. The stored certificates are deleted, so that they are no longer served, and the consumer cluster
+// shall authenticate again to obtain credentials for the new subjects, and sign again its ResourceSlices.
+// The role bindings in the namespaces offloaded by the consumer are rotated by the NamespaceMap controller.
+func (r *TenantReconciler) handleCredentialsRevocation(ctx context.Context, tenant *authv1beta1.Tenant) error {
+	generation := authentication.TenantCredentialsGeneration(tenant) + 1
+	subject := authentication.CommonNameControlPlaneCSR(tenant.Spec.ClusterID, generation)
+
+	if tenant.Status.TenantNamespace != "" {
+		// Drained tenants are not bound to any permission, which must not be restored.
+		if tenant.Spec.TenantCondition != authv1beta1.TenantConditionDrained {
+			if err := r.rotateSubjects(ctx, tenant, subject, generation); err != nil {
+				klog.Errorf("Unable to rotate the subjects of the ClusterRoles for the Tenant %q: %s", tenant.Name, err)
+				r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "CredentialsRevocationFailed", err.Error())
+				return err
+			}
+		}
+
+		if err := identitymanager.DeleteRemoteCertificates(ctx, r.Client, tenant.Status.TenantNamespace); err != nil {
+			klog.Errorf("Unable to delete the certificates issued to the Tenant %q: %s", tenant.Name, err)
+			r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "CredentialsRevocationFailed", err.Error())
+			return err
+		}
+	}
+
+	// The status is updated before removing the annotation: in case of failure, the revocation is repeated,
+	// rather than rolling back the subject at the next reconciliation.
+	now := metav1.Now()
+	tenant.Status.AuthParams = nil
+	tenant.Status.Credentials = &authv1beta1.CredentialsStatus{
+		Generation: generation,
+		Subject:    auth.UserName(subject),
+		RevokedAt:  &now,
+	}
+	if err := r.Client.Status().Update(ctx, tenant); err != nil {
+		klog.Errorf("Unable to update the status of the Tenant %q: %s", tenant.Name, err)
+		return err
+	}
+
+	delete(tenant.Annotations, consts.RevokeCredentialsAnnotation)
+	if err := r.Client.Update(ctx, tenant); err != nil {
+		klog.Errorf("Unable to remove the %s annotation from the Tenant %q: %s", consts.RevokeCredentialsAnnotation, tenant.Name, err)
+		return err
+	}
+
+	klog.Infof("Credentials of the Tenant %q revoked (generation %d)", tenant.Name, generation)
+	r.EventRecorder.Eventf(tenant, corev1.EventTypeNormal, "CredentialsRevoked",
+		"Credentials revoked, the consumer cluster must authenticate again to obtain new ones (generation %d)", generation)
+	return nil
+}
+
+// rotateSubjects binds the permissions of the tenant to the subjects corresponding to the given generation of the credentials.
+func (r *TenantReconciler) rotateSubjects(ctx context.Context, tenant *authv1beta1.Tenant, subject string, generation int64) error {
+	profileRef, missingProfile, err := r.getProfileRef(ctx, tenant)
+	if err != nil {
+		return err
+	}
+	if missingProfile {
+		// No permissions are granted to the tenant, hence there are no subjects to rotate.
+		return r.handleMissingProfile(ctx, tenant)
+	}
+
+	// The control plane permissions are managed by the user in case no handshake is performed.
+	if authv1beta1.GetAuthzPolicyValue(tenant.Spec.AuthzPolicy) != authv1beta1.TolerateNoHandshake {
+		if _, err := r.NamespaceManager.BindClusterRolesToUser(ctx, tenant.Spec.ClusterID, subject,
+			profileRef, tenant, r.tenantClusterRoles...); err != nil {
+			return err
+		}
+	}
+
+	_, err = r.NamespaceManager.BindClusterRolesClusterWideToGroup(ctx, tenant.Spec.ClusterID,
+		authentication.ResourceSliceGroup(tenant.Spec.ClusterID, generation), profileRef, nil, r.tenantClusterRolesClusterWide...)
+	return err
+}
@@ -0,0 +1,82 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenantcontroller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	peeringroles "github.com/liqotech/liqo/pkg/peering-roles"
+)
+
+// getProfileRef returns the reference to the PeeringRoleProfile restricting the permissions bound to the tenant (if any),
+// and whether the referenced profile does not exist. In that case, no permissions shall be granted to the tenant,
+// consistently with the bindings in the offloaded namespaces, which refer to a non-existing ClusterRole.
+func (r *TenantReconciler) getProfileRef(ctx context.Context, tenant *authv1beta1.Tenant) (ref *corev1.LocalObjectReference,
+	missing bool, err error) {
+	if _, err := peeringroles.GetTenantProfile(ctx, r.Client, tenant); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, true, nil
+		}
+		return nil, false, err
+	}
+	return tenant.Spec.PeeringRoleProfileRef, false, nil
+}
+
+// handleMissingProfile removes the permissions bound to the tenant, as the PeeringRoleProfile it references does not exist.
+// The permissions are bound again as soon as the profile is created.
+func (r *TenantReconciler) handleMissingProfile(ctx context.Context, tenant *authv1beta1.Tenant) error {
+	klog.Warningf("PeeringRoleProfile %q referenced by the Tenant %q not found, no permissions are granted",
+		tenant.Spec.PeeringRoleProfileRef.Name, tenant.Name)
+	r.EventRecorder.Eventf(tenant, corev1.EventTypeWarning, "PeeringRoleProfileNotFound",
+		"PeeringRoleProfile %q not found, no permissions are granted", tenant.Spec.PeeringRoleProfileRef.Name)
+
+	if err := r.NamespaceManager.UnbindClusterRolesClusterWide(ctx, tenant.Spec.ClusterID, r.tenantClusterRolesClusterWide...); err != nil {
+		r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRolesClusterWideUnbindingFailed", err.Error())
+		return err
+	}
+
+	if err := r.NamespaceManager.UnbindClusterRoles(ctx, tenant.Spec.ClusterID, r.tenantClusterRoles...); err != nil {
+		r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRolesUnbindingFailed", err.Error())
+		return err
+	}
+	return nil
+}
+
+// profileTenantsEnqueuer enqueues the Tenants referencing the given PeeringRoleProfile.
+func (r *TenantReconciler) profileTenantsEnqueuer() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var tenants authv1beta1.TenantList
+		if err := r.List(ctx, &tenants); err != nil {
+			klog.Errorf("Failed to retrieve Tenants for PeeringRoleProfile %q: %v", obj.GetName(), err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for i := range tenants.Items {
+			if ref := tenants.Items[i].Spec.PeeringRoleProfileRef; ref != nil && ref.Name == obj.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&tenants.Items[i])})
+			}
+		}
+		return reqs
+	}
+}
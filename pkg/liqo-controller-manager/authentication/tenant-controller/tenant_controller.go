@@ -28,8 +28,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	controllerutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/audit"
 	"github.com/liqotech/liqo/pkg/consts"
 	identitymanager "github.com/liqotech/liqo/pkg/identityManager"
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
@@ -39,20 +41,6 @@ import (
 	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
 )
 
-var (
-	// tenantClusterRolesLabelValues is the list "app.kubernetes.io/name" label values assigned to the
-	// ClusterRoles to be binded to the control-plane Identity.
-	tenantClusterRolesLabelValues = []string{
-		"remote-controlplane",
-	}
-
-	// tenantClusterRolesClusterWideLabelValues is the list "app.kubernetes.io/name" label values assigned to the
-	// ClusterRoles to be binded to the control-plane Identity with cluster-wide scope.
-	tenantClusterRolesClusterWideLabelValues = []string{
-		"virtual-kubelet-remote-clusterwide",
-	}
-)
-
 // TenantReconciler manages the lifecycle of a Tenant.
 type TenantReconciler struct {
 	client.Client
@@ -94,8 +82,11 @@ func NewTenantReconciler(cl client.Client, scheme *runtime.Scheme, config *rest.
 // cluster-role
 // +kubebuilder:rbac:groups=authentication.liqo.io,resources=tenants;tenants/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=authentication.liqo.io,resources=tenants;tenants/finalizers,verbs=update
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=peeringroleprofiles,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=namespaces/finalizers,verbs=update
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;deletecollection;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings/finalizers,verbs=update
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
@@ -127,7 +118,15 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 			klog.Errorf("Unable to unbind the ClusterRolesClusterWide for the Tenant %q before deletion: %s", req.Name, err)
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, r.enforceTenantFinalizerAbsence(ctx, tenant)
+		if !controllerutil.ContainsFinalizer(tenant, tenantControllerFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if err := r.enforceTenantFinalizerAbsence(ctx, tenant); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		recordTenantAuditEvent(tenant, audit.ActionTenantDelete, "TenantDeleted", "Tenant deleted")
+		return ctrl.Result{}, nil
 	}
 
 	// Check if the tenant has a finalizer and if not, add it.
@@ -135,6 +134,16 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		return ctrl.Result{}, r.enforceTenantFinalizerPresence(ctx, tenant)
 	}
 
+	if err := r.enforceConditionTransitionAudit(ctx, tenant); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// If the revocation of the credentials is requested, we rotate the subject the permissions are bound to,
+	// and we invalidate the credentials issued so far.
+	if revocationRequested(tenant) {
+		return ctrl.Result{}, r.handleCredentialsRevocation(ctx, tenant)
+	}
+
 	// If the Tenant is drained we remove the binding of cluster roles used to replicate resources and
 	// delete all replicated resources.
 	switch tenant.Spec.TenantCondition {
@@ -157,6 +166,7 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 	}
 
 	clusterID := tenant.Spec.ClusterID
+	generation := authentication.TenantCredentialsGeneration(tenant)
 
 	// If no handshake is tolerated, then do not perform the checks on the exchanged keys.
 	if authv1beta1.GetAuthzPolicyValue(tenant.Spec.AuthzPolicy) != authv1beta1.TolerateNoHandshake {
@@ -197,7 +207,7 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 
 		// check that the CSR is created with the same public key
 		if err = authentication.CheckCSRForControlPlane(
-			tenant.Spec.CSR, publicKeyDER, tenant.Spec.ClusterID); err != nil {
+			tenant.Spec.CSR, publicKeyDER, tenant.Spec.ClusterID, generation); err != nil {
 			klog.Errorf("Invalid CSR for the Tenant %q: %s", req.Name, err)
 			r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "InvalidCSR", err.Error())
 			return ctrl.Result{}, nil
@@ -213,6 +223,7 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		return ctrl.Result{}, err
 	}
 
+	accepted := tenant.Status.TenantNamespace == ""
 	tenant.Status.TenantNamespace = tenantNamespace.Name
 
 	defer func() {
@@ -221,6 +232,9 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 			klog.Errorf("Unable to update the Tenant %q: %s", req.Name, errDef)
 			r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "UpdateFailed", errDef.Error())
 			err = errDef
+		} else if accepted {
+			recordTenantAuditEvent(tenant, audit.ActionTenantCreate, "TenantAccepted",
+				fmt.Sprintf("Tenant accepted, with tenant namespace %q", tenant.Status.TenantNamespace))
 		}
 
 		if err == nil {
@@ -228,6 +242,16 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		}
 	}()
 
+	profileRef, missingProfile, err := r.getProfileRef(ctx, tenant)
+	if err != nil {
+		klog.Errorf("Unable to get the PeeringRoleProfile for the Tenant %q: %s", req.Name, err)
+		r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "PeeringRoleProfileFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+	if missingProfile {
+		return ctrl.Result{}, r.handleMissingProfile(ctx, tenant)
+	}
+
 	// If no handshake is performed, then the user is charge of creating the authentication params and bind the right permissions.
 	if authv1beta1.GetAuthzPolicyValue(tenant.Spec.AuthzPolicy) != authv1beta1.TolerateNoHandshake {
 		// create the CSR and forge the AuthParams
@@ -243,6 +267,8 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 			CAOverride:               r.CAOverride,
 			TrustedCA:                r.TrustedCA,
 			ProxyURL:                 tenant.Spec.ProxyURL,
+			CredentialsGeneration:    generation,
+			Validity:                 authentication.TenantCredentialsValidity(tenant),
 		})
 		if err != nil {
 			klog.Errorf("Unable to forge the AuthParams for the Tenant %q: %s", req.Name, err)
@@ -250,11 +276,14 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 			return ctrl.Result{}, err
 		}
 
+		authParams.Rotation = authentication.ForgeCredentialRotation(tenant)
 		tenant.Status.AuthParams = authParams
+		authentication.SetTenantCredentialsStatus(tenant, authParams)
 
-		// bind permissions
+		// bind permissions to the current subject
 
-		_, err = r.NamespaceManager.BindClusterRoles(ctx, tenant.Spec.ClusterID, tenant, r.tenantClusterRoles...)
+		_, err = r.NamespaceManager.BindClusterRolesToUser(ctx, tenant.Spec.ClusterID,
+			authentication.CommonNameControlPlaneCSR(clusterID, generation), profileRef, tenant, r.tenantClusterRoles...)
 		if err != nil {
 			klog.Errorf("Unable to bind the ClusterRoles for the Tenant %q: %s", req.Name, err)
 			r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRolesBindingFailed", err.Error())
@@ -265,7 +294,8 @@ func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 	// Bind cluster roles with cluster-wide scope to the tenant group.
 	// We do this even with TolerateNoHandshake since these clusterrole are tied to the tenant Group and
 	// will be used by the virtual kubelet to access cluster-wide resources (e.g., scraping remote metrics server)
-	_, err = r.NamespaceManager.BindClusterRolesClusterWide(ctx, tenant.Spec.ClusterID, nil, r.tenantClusterRolesClusterWide...)
+	_, err = r.NamespaceManager.BindClusterRolesClusterWideToGroup(ctx, tenant.Spec.ClusterID,
+		authentication.ResourceSliceGroup(clusterID, generation), profileRef, nil, r.tenantClusterRolesClusterWide...)
 	if err != nil {
 		klog.Errorf("Unable to bind the ClusterRolesClusterWide for the Tenant %q: %s", req.Name, err)
 		r.EventRecorder.Event(tenant, corev1.EventTypeWarning, "ClusterRolesClusterWideBindingFailed", err.Error())
@@ -297,7 +327,7 @@ func (r *TenantReconciler) getClusterRoles(ctx context.Context, rolesAppLabel []
 
 func (r *TenantReconciler) ensureSetup(ctx context.Context) error {
 	if len(r.tenantClusterRoles) == 0 {
-		cRoles, err := r.getClusterRoles(ctx, tenantClusterRolesLabelValues)
+		cRoles, err := r.getClusterRoles(ctx, consts.TenantClusterRolesAppNames)
 
 		if err != nil {
 			return fmt.Errorf("unable to get ClusterRoles to bind on tenant namespace: %w", err)
@@ -307,7 +337,7 @@ func (r *TenantReconciler) ensureSetup(ctx context.Context) error {
 	}
 
 	if len(r.tenantClusterRolesClusterWide) == 0 {
-		cRoles, err := r.getClusterRoles(ctx, tenantClusterRolesClusterWideLabelValues)
+		cRoles, err := r.getClusterRoles(ctx, consts.TenantClusterRolesClusterWideAppNames)
 
 		if err != nil {
 			return fmt.Errorf("unable to get ClusterRoles to bind cluster-wide: %w", err)
@@ -324,6 +354,7 @@ func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlTenant).
 		For(&authv1beta1.Tenant{}).
 		Owns(&corev1.Namespace{}).
+		Watches(&authv1beta1.PeeringRoleProfile{}, handler.EnqueueRequestsFromMapFunc(r.profileTenantsEnqueuer())).
 		Complete(r)
 }
 
@@ -31,9 +31,10 @@ import (
 // the provider cluster.
 // It needs the local cluster identity to get the authentication keys and the signature
 // of the nonce given by the provider cluster to complete the authentication challenge.
+// The generation of the credentials (increased by the provider cluster at each revocation) is part of the CSR subject.
 func GenerateTenant(ctx context.Context, cl client.Client,
 	localClusterID liqov1beta1.ClusterID, liqoNamespace, remoteTenantNamespace string,
-	signature []byte, proxyURL *string) (*authv1beta1.Tenant, error) {
+	signature []byte, proxyURL *string, generation int64) (*authv1beta1.Tenant, error) {
 	// Get public and private keys of the local cluster.
 	privateKey, publicKey, err := authentication.GetClusterKeys(ctx, cl, liqoNamespace)
 	if err != nil {
@@ -41,7 +42,7 @@ func GenerateTenant(ctx context.Context, cl client.Client,
 	}
 
 	// Generate a CSR for the remote cluster.
-	CSR, err := authentication.GenerateCSRForControlPlane(privateKey, localClusterID)
+	CSR, err := authentication.GenerateCSRForControlPlane(privateKey, localClusterID, generation)
 	if err != nil {
 		return nil, fmt.Errorf("unable to generate CSR: %w", err)
 	}
@@ -27,6 +27,12 @@ const (
 	connectionMissingReason  = "ConnectionMissing"
 	connectionMissingMessage = "There is no network connection with the foreign cluster"
 
+	connectionQualityReadyReason  = "ConnectionQualityReady"
+	connectionQualityReadyMessage = "The quality of the network connection with the foreign cluster is within the configured thresholds"
+
+	connectionDegradedReason  = "ConnectionDegraded"
+	connectionDegradedMessage = "The network connection with the foreign cluster is degraded"
+
 	gatewaysReadyReason  = "GatewaysReady"
 	gatewaysReadyMessage = "All gateway replicas are ready"
 
@@ -16,10 +16,12 @@ package foreignclustercontroller
 
 import (
 	"context"
+	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -71,6 +73,7 @@ func (r *ForeignClusterReconciler) handleConnectionStatus(ctx context.Context,
 	case errors.IsNotFound(err):
 		klog.V(6).Infof("Connection resource not found for ForeignCluster %q", clusterID)
 		fcutils.DeleteModuleCondition(&fc.Status.Modules.Networking, liqov1beta1.NetworkConnectionStatusCondition)
+		fcutils.DeleteModuleCondition(&fc.Status.Modules.Networking, liqov1beta1.NetworkConnectionQualityCondition)
 		statusExceptions[liqov1beta1.NetworkConnectionStatusCondition] = statusException{
 			ConditionStatusType: liqov1beta1.ConditionStatusNotReady,
 			Reason:              connectionMissingReason,
@@ -95,10 +98,28 @@ func (r *ForeignClusterReconciler) handleConnectionStatus(ctx context.Context,
 				liqov1beta1.NetworkConnectionStatusCondition, liqov1beta1.ConditionStatusError,
 				connectionErrorReason, connectionErrorMessage)
 		}
+		handleConnectionQualityStatus(fc, connection)
 	}
 	return nil
 }
 
+// handleConnectionQualityStatus reflects the Degraded condition of the Connection, if any, in the networking module.
+func handleConnectionQualityStatus(fc *liqov1beta1.ForeignCluster, connection *networkingv1beta1.Connection) {
+	degraded := meta.FindStatusCondition(connection.Status.Conditions, networkingv1beta1.ConnectionDegradedCondition)
+	switch {
+	case degraded == nil || connection.Status.Value != networkingv1beta1.Connected:
+		fcutils.DeleteModuleCondition(&fc.Status.Modules.Networking, liqov1beta1.NetworkConnectionQualityCondition)
+	case degraded.Status == metav1.ConditionTrue:
+		fcutils.EnsureModuleCondition(&fc.Status.Modules.Networking,
+			liqov1beta1.NetworkConnectionQualityCondition, liqov1beta1.ConditionStatusDegraded,
+			connectionDegradedReason, fmt.Sprintf("%s: %s", connectionDegradedMessage, degraded.Message))
+	default:
+		fcutils.EnsureModuleCondition(&fc.Status.Modules.Networking,
+			liqov1beta1.NetworkConnectionQualityCondition, liqov1beta1.ConditionStatusReady,
+			connectionQualityReadyReason, connectionQualityReadyMessage)
+	}
+}
+
 func (r *ForeignClusterReconciler) handleGatewaysStatus(ctx context.Context,
 	fc *liqov1beta1.ForeignCluster, statusExceptions map[liqov1beta1.ConditionType]statusException) error {
 	clusterID := fc.Spec.ClusterID
@@ -19,7 +19,11 @@ import (
 
 	"github.com/spf13/pflag"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/audit"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/identityManager/signer"
 	"github.com/liqotech/liqo/pkg/utils/args"
 )
 
@@ -30,6 +34,9 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	opts.IngressClasses = args.ClassNameList{}
 	opts.LoadBalancerClasses = args.ClassNameList{}
 	opts.DefaultNodeResources = args.ResourceMap{}
+	opts.ResourceSlicePlugins = args.StringMap{}
+	opts.CertificateSigner = args.NewEnum([]string{string(authv1beta1.KubernetesSignerBackend),
+		string(authv1beta1.CertManagerSignerBackend), string(authv1beta1.ExternalSignerBackend)}, string(authv1beta1.KubernetesSignerBackend))
 	opts.GatewayServerResources = args.StringList{}
 	opts.GatewayClientResources = args.StringList{}
 	opts.GlobalLabels = args.StringMap{}
@@ -68,15 +75,27 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 		"The name of the cluster role used by the wireguard gateway servers")
 	flagset.StringVar(&opts.WgGatewayClientClusterRoleName, "wg-gateway-client-cluster-role-name", "liqo-gateway",
 		"The name of the cluster role used by the wireguard gateway clients")
+	flagset.StringVar(&opts.IPsecGatewayServerClusterRoleName, "ipsec-gateway-server-cluster-role-name", "liqo-gateway",
+		"The name of the cluster role used by the IPsec gateway servers")
+	flagset.StringVar(&opts.IPsecGatewayClientClusterRoleName, "ipsec-gateway-client-cluster-role-name", "liqo-gateway",
+		"The name of the cluster role used by the IPsec gateway clients")
 	flagset.BoolVar(&opts.FabricFullMasqueradeEnabled, "fabric-full-masquerade-enabled", false,
 		"Enable the full masquerade on the fabric network")
 	flagset.BoolVar(&opts.GwmasqbypassEnabled, "gateway-masquerade-bypass-enabled", false,
 		"Enable the gateway masquerade bypass")
+	opts.FabricEncryption = *args.NewEnum([]string{string(networkingv1beta1.InternalFabricEncryptionModeNone),
+		string(networkingv1beta1.InternalFabricEncryptionModeWireGuard)}, string(networkingv1beta1.InternalFabricEncryptionModeNone))
+	flagset.Var(&opts.FabricEncryption, "fabric-encryption",
+		"The encryption mode of the geneve tunnels between the nodes and the gateways, configured on the new InternalFabrics")
 	flagset.IntVar(&opts.NetworkWorkers, "network-ctrl-workers", 1,
 		"The number of workers used to reconcile Network resources.")
 	flagset.IntVar(&opts.IPWorkers, "ip-ctrl-workers", 1,
 		"The number of workers used to reconcile IP resources.")
 	flagset.Uint16Var(&opts.GenevePort, "geneve-port", 6081, "The port used by the Geneve tunnel")
+	flagset.Var(&opts.TransitAcceptedClusters, "transit-accepted-clusters",
+		"The list of remote clusters allowed to forward the local traffic in transit, whose TransitAdvertisements are accepted")
+	flagset.IntVar(&opts.TransitMaxAdvertisedClusters, "transit-max-advertised-clusters", 16,
+		"The maximum number of clusters reachable in transit accepted from each remote cluster")
 
 	// Authentication module
 	flagset.StringVar(&opts.APIServerAddressOverride, "api-server-address-override", "",
@@ -89,14 +108,70 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.StringVar(&opts.AWSConfig.AwsSecretAccessKey, "aws-secret-access-key", "", "AWS IAM SecretAccessKey for the Liqo User")
 	flagset.StringVar(&opts.AWSConfig.AwsRegion, "aws-region", "", "AWS region where the local cluster is running")
 	flagset.StringVar(&opts.AWSConfig.AwsClusterName, "aws-cluster-name", "", "Name of the local EKS cluster")
+	flagset.StringVar(&opts.OIDCConfig.Issuer, "oidc-issuer-url", "",
+		"URL of the OIDC issuer trusted by the local API server, used to authenticate remote clusters with short-lived tokens "+
+			"(only Keycloak is supported)")
+	flagset.StringVar((*string)(&opts.OIDCConfig.IssuerType), "oidc-issuer-type", "",
+		"Type of the OIDC issuer, which must support impersonating the remote identities through the token exchange (only Keycloak is supported)")
+	flagset.StringVar(&opts.OIDCConfig.Audience, "oidc-client-id", "", "Audience of the OIDC tokens issued to remote clusters")
+	flagset.StringVar(&opts.OIDCConfig.TokenEndpoint, "oidc-token-endpoint", "",
+		"Token endpoint of the OIDC issuer (discovered from the issuer metadata if not set)")
+	flagset.StringVar(&opts.OIDCConfig.ClientID, "oidc-exchange-client-id", "",
+		"Client the local cluster authenticates as at the OIDC token endpoint (the OIDC client ID if not set)")
+	flagset.StringVar(&opts.OIDCConfig.ClientSecretFile, "oidc-client-secret-file", "",
+		"Path of the file containing the secret of the client, if it is a confidential one")
+	flagset.StringVar(&opts.OIDCConfig.SubjectTokenFile, "oidc-subject-token-file", "",
+		"Path of the workload identity token exchanged with the OIDC issuer for the tokens issued to remote clusters")
+	flagset.StringVar(&opts.OIDCConfig.UsernamePrefix, "oidc-username-prefix", "",
+		"Prefix the local API server adds to the username of the OIDC tokens (required if the OIDC issuer is set)")
+	flagset.StringVar(&opts.OIDCConfig.GroupsPrefix, "oidc-groups-prefix", "",
+		"Prefix the local API server adds to the groups of the OIDC tokens (required if the OIDC issuer is set)")
+	flagset.Var(opts.CertificateSigner, "certificate-signer",
+		"The backend signing the certificates of remote clusters, among Kubernetes, CertManager and External")
+	flagset.DurationVar(&opts.SignerConfig.Duration, "certificate-duration", 0,
+		"The requested validity of the certificates issued to remote clusters (0 to use the signer default)")
+	flagset.StringVar(&opts.SignerConfig.CertManager.IssuerName, "cert-manager-issuer-name", "",
+		"The name of the cert-manager issuer signing the certificates of remote clusters")
+	flagset.StringVar(&opts.SignerConfig.CertManager.IssuerKind, "cert-manager-issuer-kind", signer.DefaultCertManagerIssuerKind,
+		"The kind of the cert-manager issuer signing the certificates of remote clusters")
+	flagset.StringVar(&opts.SignerConfig.CertManager.IssuerGroup, "cert-manager-issuer-group", signer.DefaultCertManagerIssuerGroup,
+		"The API group of the cert-manager issuer signing the certificates of remote clusters")
+	flagset.StringVar(&opts.SignerConfig.CertManager.Namespace, "cert-manager-namespace", "",
+		"The namespace where the cert-manager CertificateRequests are created (defaults to the Liqo namespace)")
+	flagset.StringVar(&opts.SignerConfig.External.URL, "external-signer-url", "",
+		"The URL of the external signer, either https://<host>/<path> or grpc://<host>:<port>")
+	flagset.StringVar(&opts.SignerConfig.External.CAFile, "external-signer-ca-file", "",
+		"The CA bundle used to verify the certificate of the external signer (defaults to the system roots)")
+	flagset.StringVar(&opts.SignerConfig.External.CertFile, "external-signer-cert-file", "",
+		"The client certificate used to authenticate with the external signer")
+	flagset.StringVar(&opts.SignerConfig.External.KeyFile, "external-signer-key-file", "",
+		"The client key used to authenticate with the external signer")
 	flagset.Var(&opts.ClusterLabels, consts.ClusterLabelsParameter,
 		"The set of labels which characterizes the local cluster when exposed remotely as a virtual node")
 	flagset.Var(&opts.IngressClasses, "ingress-classes", "List of ingress classes offered by the cluster. Example: \"nginx;default,traefik\"")
 	flagset.Var(&opts.LoadBalancerClasses, "load-balancer-classes", "List of load balancer classes offered by the cluster. Example:\"metallb;default\"")
 	flagset.Var(&opts.DefaultNodeResources, "default-node-resources", "Default resources assigned to the Virtual Node Pod")
+	flagset.DurationVar(&opts.CapacityResyncPeriod, "resource-slice-capacity-resync-period", 1*time.Minute,
+		"The period after which the resources granted to the ResourceSlices of the capacity class are re-evaluated")
+	flagset.Var(&opts.ResourceSlicePlugins, "resource-slice-class-plugins",
+		"The ResourceOffer plugins in charge of the custom ResourceSlice classes. Example: \"gold=gold-plugin.liqo:6000,silver=silver-plugin.liqo:6000\"")
+	flagset.StringVar(&opts.ResourceSlicePluginsTLS.CAFile, "resource-slice-class-plugins-ca-file", "",
+		"The CA bundle used to verify the certificate of the ResourceOffer plugins (the system roots are used if not set)")
+	flagset.StringVar(&opts.ResourceSlicePluginsTLS.CertFile, "resource-slice-class-plugins-cert-file", "",
+		"The client certificate used to authenticate with the ResourceOffer plugins")
+	flagset.StringVar(&opts.ResourceSlicePluginsTLS.KeyFile, "resource-slice-class-plugins-key-file", "",
+		"The client key used to authenticate with the ResourceOffer plugins")
+	flagset.BoolVar(&opts.ResourceSlicePluginsTLS.Insecure, "resource-slice-class-plugins-insecure", false,
+		"Connect to the ResourceOffer plugins without TLS, hence without encrypting the connections (not recommended)")
 	flagset.Var(&opts.GlobalLabels, "global-labels", "The set of labels that will be added to all resources created by Liqo controllers")
 	flagset.Var(&opts.GlobalAnnotations, "global-annotations", "The set of annotations that will be added to all resources created by Liqo controllers")
 
+	// Authentication & Offloading modules
+	flagset.DurationVar(&opts.AutoscalingSyncPeriod, "resource-slice-autoscaling-sync-period", 30*time.Second,
+		"The period after which the resources requested by the autoscaled ResourceSlices are re-evaluated")
+	flagset.DurationVar(&opts.AutoscalingScaleDownDelay, "resource-slice-autoscaling-scale-down-delay", 5*time.Minute,
+		"The minimum time elapsing between a resize of an autoscaled ResourceSlice and a subsequent scale down")
+
 	// Offloading module
 	flagset.BoolVar(&opts.EnableStorage, "enable-storage", false, "enable the liqo virtual storage class")
 	flagset.StringVar(&opts.VirtualStorageClassName, "virtual-storage-class-name", "liqo", "Name of the virtual storage class")
@@ -106,7 +181,12 @@ func InitFlags(flagset *pflag.FlagSet, opts *Options) {
 	flagset.IntVar(&opts.ShadowPodWorkers, "shadow-pod-ctrl-workers", 10, "The number of workers used to reconcile ShadowPod resources.")
 	flagset.IntVar(&opts.ShadowEndpointSliceWorkers, "shadow-endpointslice-ctrl-workers", 10,
 		"The number of workers used to reconcile ShadowEndpointSlice resources.")
+	flagset.StringVar(&opts.VolumeMoverImage, "volume-mover-image", "ghcr.io/liqotech/volume-mover",
+		"The image of the jobs moving the volumes of the migrated pods")
+	flagset.StringVar(&opts.VolumeMoverServiceAccount, "volume-mover-service-account", "liqo-volume-mover",
+		"The service account of the jobs moving the volumes of the migrated pods")
 
 	// Cross module
 	flagset.BoolVar(&opts.EnableAPIServerIPRemapping, "enable-api-server-ip-remapping", true, "Enable the API server IP remapping")
+	audit.InitFlags(flagset, opts.AuditConfig)
 }
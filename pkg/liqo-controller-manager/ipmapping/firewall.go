@@ -0,0 +1,176 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipmapping
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ipamv1alpha1 "github.com/liqotech/liqo/apis/ipam/v1alpha1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/remapping"
+	cidrutils "github.com/liqotech/liqo/pkg/utils/cidr"
+	ipamutils "github.com/liqotech/liqo/pkg/utils/ipam"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+const (
+	// NatMappingName is the name of the firewall configuration translating the remapped IPs of the offloaded pods of a namespace.
+	NatMappingName = "offloaded-pods-ipmapping"
+	// NatMappingIPv6Suffix is the suffix appended to the name of the firewall configuration managing the IPv6 addresses.
+	NatMappingIPv6Suffix = "-v6"
+	// NatMappingMapName is the name of the address map associating the remapped IPs with the ones of the offloaded pods.
+	NatMappingMapName = "offloaded-pods"
+
+	dnatRuleName = "dnat"
+	snatRuleName = "snat"
+)
+
+// forgeNatMappingName returns the name of the firewall configuration translating the addresses of the given family.
+func forgeNatMappingName(family corev1.IPFamily) string {
+	if family == corev1.IPv6Protocol {
+		return NatMappingName + NatMappingIPv6Suffix
+	}
+	return NatMappingName
+}
+
+// EnforceNatMapping ensures the firewall configurations translating the remapped IPs of the offloaded pods of the given namespace.
+// The translations are stored in an address map looked up by a single NAT rule per direction, so that the gateways
+// update the map elements in place as the pods churn, without modifying the rules.
+func EnforceNatMapping(ctx context.Context, cl client.Client, namespace string) error {
+	var ips ipamv1alpha1.IPList
+	if err := cl.List(ctx, &ips, client.InNamespace(namespace), client.HasLabels{consts.OffloadedPodNameLabelKey}); err != nil {
+		return fmt.Errorf("unable to list the IPs of the offloaded pods in namespace %q: %w", namespace, err)
+	}
+
+	elements := map[corev1.IPFamily][]firewall.SetElement{}
+	for i := range ips.Items {
+		ip := &ips.Items[i]
+		remapped := ipamutils.GetRemappedIP(ip)
+		if !ip.DeletionTimestamp.IsZero() || ip.Spec.IP == "" || remapped == "" {
+			continue
+		}
+		family := cidrutils.GetFamily(cidrutils.HostCIDR(ip.Spec.IP.String()))
+		if family != cidrutils.GetFamily(cidrutils.HostCIDR(remapped.String())) {
+			continue
+		}
+		elements[family] = append(elements[family], firewall.SetElement{Key: remapped.String(), Data: ptr.To(ip.Spec.IP.String())})
+	}
+
+	for _, family := range []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol} {
+		fwcfg := &networkingv1beta1.FirewallConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      forgeNatMappingName(family),
+				Namespace: namespace,
+			},
+		}
+
+		if len(elements[family]) == 0 {
+			if err := ensureFirewallConfigurationAbsence(ctx, cl, fwcfg); err != nil {
+				return err
+			}
+			continue
+		}
+
+		slices.SortFunc(elements[family], func(a, b firewall.SetElement) int {
+			return strings.Compare(a.Key, b.Key)
+		})
+		if _, err := resource.CreateOrUpdate(ctx, cl, fwcfg, func() error {
+			fwcfg.SetLabels(remapping.ForgeFirewallTargetLabelsIPMappingGw())
+			fwcfg.Spec = forgeNatMappingSpec(namespace, family, elements[family])
+			return nil
+		}); err != nil {
+			return fmt.Errorf("unable to create or update the firewall configuration %q: %w", client.ObjectKeyFromObject(fwcfg), err)
+		}
+	}
+	return nil
+}
+
+// ensureFirewallConfigurationAbsence deletes the given firewall configuration, if it exists.
+func ensureFirewallConfigurationAbsence(ctx context.Context, cl client.Client, fwcfg *networkingv1beta1.FirewallConfiguration) error {
+	err := cl.Get(ctx, client.ObjectKeyFromObject(fwcfg), fwcfg)
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("unable to get the firewall configuration %q: %w", client.ObjectKeyFromObject(fwcfg), err)
+	}
+	if err := client.IgnoreNotFound(cl.Delete(ctx, fwcfg)); err != nil {
+		return fmt.Errorf("unable to delete the firewall configuration %q: %w", client.ObjectKeyFromObject(fwcfg), err)
+	}
+	return nil
+}
+
+// forgeNatMappingSpec forges the table translating the remapped IPs (of the given family) into the ones of the offloaded pods.
+// The map is looked up with the destination address of the incoming traffic (DNAT), and with the source address
+// of the outgoing one (SNAT).
+func forgeNatMappingSpec(namespace string, family corev1.IPFamily, elements []firewall.SetElement) networkingv1beta1.FirewallConfigurationSpec {
+	tableFamily, dataType := firewall.TableFamilyIPv4, firewall.SetDataTypeIPv4Addr
+	if family == corev1.IPv6Protocol {
+		tableFamily, dataType = firewall.TableFamilyIPv6, firewall.SetDataTypeIPv6Addr
+	}
+
+	return networkingv1beta1.FirewallConfigurationSpec{
+		Table: firewall.Table{
+			Name:   ptr.To(fmt.Sprintf("%s-%s", forgeNatMappingName(family), namespace)),
+			Family: ptr.To(tableFamily),
+			Sets: []firewall.Set{{
+				Name:     NatMappingMapName,
+				KeyType:  dataType,
+				DataType: ptr.To(dataType),
+				Elements: elements,
+			}},
+			Chains: []firewall.Chain{
+				forgeNatMappingChain(remapping.PreroutingChainName, firewall.ChainHookPrerouting, firewall.ChainPriorityNATDest,
+					dnatRuleName, firewall.NatTypeDestination, firewall.MatchPositionDst),
+				forgeNatMappingChain(remapping.PostroutingChainName, firewall.ChainHookPostrouting, firewall.ChainPriorityNATSource,
+					snatRuleName, firewall.NatTypeSource, firewall.MatchPositionSrc),
+			},
+		},
+	}
+}
+
+func forgeNatMappingChain(name string, hook firewall.ChainHook, priority firewall.ChainPriority,
+	ruleName string, natType firewall.NatType, position firewall.MatchPosition) firewall.Chain {
+	return firewall.Chain{
+		Name:     ptr.To(name),
+		Policy:   ptr.To(firewall.ChainPolicyAccept),
+		Type:     firewall.ChainTypeNAT,
+		Hook:     ptr.To(hook),
+		Priority: ptr.To(priority),
+		Rules: firewall.RulesSet{
+			NatRules: []firewall.NatRule{{
+				Name:    ptr.To(ruleName),
+				NatType: natType,
+				Match:   []firewall.Match{},
+				ToMap: &firewall.MapLookup{
+					Name:     NatMappingMapName,
+					Key:      firewall.MapKeyIP,
+					Position: position,
+				},
+			}},
+		},
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipmapping
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ipamv1alpha1 "github.com/liqotech/liqo/apis/ipam/v1alpha1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+)
+
+var _ = Describe("NAT mapping of the offloaded pods", func() {
+	const namespace = "offloaded"
+
+	var (
+		ctx context.Context
+		cl  client.Client
+
+		newIP = func(name, ip, remapped string) *ipamv1alpha1.IP {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+			return &ipamv1alpha1.IP{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: forgeIPLabels(pod)},
+				Spec:       ipamv1alpha1.IPSpec{IP: networkingv1beta1.IP(ip)},
+				Status:     ipamv1alpha1.IPStatus{IP: networkingv1beta1.IP(remapped)},
+			}
+		}
+
+		getFirewallConfiguration = func(name string) (*networkingv1beta1.FirewallConfiguration, error) {
+			fwcfg := &networkingv1beta1.FirewallConfiguration{}
+			err := cl.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, fwcfg)
+			return fwcfg, err
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newIP("pod-a", "10.70.0.1", "10.71.0.1"),
+			newIP("pod-b", "10.70.0.2", "10.71.0.2"),
+			newIP("pod-pending", "10.70.0.3", ""),
+			&ipamv1alpha1.IP{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: namespace},
+				Spec:       ipamv1alpha1.IPSpec{IP: "10.70.0.4"},
+				Status:     ipamv1alpha1.IPStatus{IP: "10.71.0.4"},
+			},
+		).Build()
+	})
+
+	It("should store the translations of the remapped IPs in a single map", func() {
+		Expect(EnforceNatMapping(ctx, cl, namespace)).To(Succeed())
+
+		fwcfg, err := getFirewallConfiguration(NatMappingName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fwcfg.Spec.Table.Family).To(Equal(ptr.To(firewall.TableFamilyIPv4)))
+		Expect(fwcfg.Spec.Table.Sets).To(ConsistOf(firewall.Set{
+			Name:     NatMappingMapName,
+			KeyType:  firewall.SetDataTypeIPv4Addr,
+			DataType: ptr.To(firewall.SetDataTypeIPv4Addr),
+			Elements: []firewall.SetElement{
+				{Key: "10.71.0.1", Data: ptr.To("10.70.0.1")},
+				{Key: "10.71.0.2", Data: ptr.To("10.70.0.2")},
+			},
+		}))
+
+		Expect(fwcfg.Spec.Table.Chains).To(HaveLen(2))
+		for i := range fwcfg.Spec.Table.Chains {
+			Expect(fwcfg.Spec.Table.Chains[i].Rules.NatRules).To(HaveLen(1))
+			Expect(fwcfg.Spec.Table.Chains[i].Rules.NatRules[0].ToMap).To(PointTo(HaveField("Name", NatMappingMapName)))
+		}
+
+		_, err = getFirewallConfiguration(NatMappingName + NatMappingIPv6Suffix)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should only update the map elements when the pods change", func() {
+		Expect(EnforceNatMapping(ctx, cl, namespace)).To(Succeed())
+		before, err := getFirewallConfiguration(NatMappingName)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cl.Delete(ctx, newIP("pod-a", "", ""))).To(Succeed())
+		Expect(EnforceNatMapping(ctx, cl, namespace)).To(Succeed())
+
+		after, err := getFirewallConfiguration(NatMappingName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(after.Spec.Table.Chains).To(Equal(before.Spec.Table.Chains))
+		Expect(after.Spec.Table.Sets[0].Elements).To(ConsistOf(firewall.SetElement{Key: "10.71.0.2", Data: ptr.To("10.70.0.2")}))
+	})
+
+	It("should delete the firewall configuration once no offloaded pod is left", func() {
+		Expect(EnforceNatMapping(ctx, cl, namespace)).To(Succeed())
+
+		for _, name := range []string{"pod-a", "pod-b", "pod-pending"} {
+			Expect(cl.Delete(ctx, newIP(name, "", ""))).To(Succeed())
+		}
+		Expect(EnforceNatMapping(ctx, cl, namespace)).To(Succeed())
+
+		_, err := getFirewallConfiguration(NatMappingName)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -16,16 +16,13 @@ package ipmapping
 
 import (
 	corev1 "k8s.io/api/core/v1"
-)
 
-const (
-	offloadedPodNameLabelKey      = "offloading.liqo.io/pod-name"
-	offloadedPodNamespaceLabelKey = "offloading.liqo.io/pod-namespace"
+	"github.com/liqotech/liqo/pkg/consts"
 )
 
 func forgeIPLabels(pod *corev1.Pod) map[string]string {
 	return map[string]string{
-		offloadedPodNameLabelKey:      pod.Name,
-		offloadedPodNamespaceLabelKey: pod.Namespace,
+		consts.OffloadedPodNameLabelKey:      pod.Name,
+		consts.OffloadedPodNamespaceLabelKey: pod.Namespace,
 	}
 }
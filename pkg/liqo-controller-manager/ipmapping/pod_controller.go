@@ -23,13 +23,17 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	ipamv1alpha1 "github.com/liqotech/liqo/apis/ipam/v1alpha1"
 	"github.com/liqotech/liqo/pkg/consts"
 )
 
@@ -51,6 +55,7 @@ func NewOffloadedPodReconciler(cl client.Client, s *runtime.Scheme, er record.Ev
 
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=ips,verbs=get;list;watch;update;patch;create;delete
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=firewallconfigurations,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile reconciles on offloaded pods.
 func (r *OffloadedPodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -58,7 +63,8 @@ func (r *OffloadedPodReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err := r.Client.Get(ctx, req.NamespacedName, pod); err != nil {
 		if apierrors.IsNotFound(err) {
 			klog.V(6).Infof("There is no pod %s", req.String())
-			return ctrl.Result{}, nil
+			// The IP of the pod is garbage collected, and the NAT mapping is enforced again once it is deleted.
+			return ctrl.Result{}, r.enforceNatMapping(ctx, req.Namespace)
 		}
 		return ctrl.Result{}, fmt.Errorf("unable to fetch Pod %s: %w", req.String(), err)
 	}
@@ -75,7 +81,14 @@ func (r *OffloadedPodReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	klog.Infof("IP resource created or updated for pod %s", req.String())
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{}, r.enforceNatMapping(ctx, pod.Namespace)
+}
+
+func (r *OffloadedPodReconciler) enforceNatMapping(ctx context.Context, namespace string) error {
+	if err := EnforceNatMapping(ctx, r.Client, namespace); err != nil {
+		return fmt.Errorf("unable to enforce the NAT mapping of the offloaded pods in namespace %q: %w", namespace, err)
+	}
+	return nil
 }
 
 // SetupWithManager monitors updates on nodes.
@@ -90,5 +103,17 @@ func (r *OffloadedPodReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlPodIPMapping).
 		For(&corev1.Pod{}, builder.WithPredicates(p)).
+		Watches(&ipamv1alpha1.IP{}, handler.EnqueueRequestsFromMapFunc(podEnqueuer)).
 		Complete(r)
 }
+
+// podEnqueuer enqueues the offloaded pod an IP refers to, so that the NAT mapping is updated
+// once the remapped IP is allocated, and once the IP is deleted.
+func podEnqueuer(_ context.Context, obj client.Object) []reconcile.Request {
+	name, okName := obj.GetLabels()[consts.OffloadedPodNameLabelKey]
+	namespace, okNamespace := obj.GetLabels()[consts.OffloadedPodNamespaceLabelKey]
+	if !okName || !okNamespace {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}}
+}
@@ -31,6 +31,7 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
@@ -79,10 +80,15 @@ func NewClientReconciler(cl client.Client, dynClient dynamic.Interface,
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayclients,verbs=get;list;watch;delete;create;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayclients/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayclients/finalizers,verbs=update
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=connections,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=wggatewayclients,verbs=get;list;watch;delete;create;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=wggatewayclients/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=wggatewayclients/finalizers,verbs=update
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=wggatewayclienttemplates,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayclients,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayclients/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayclients/finalizers,verbs=update
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayclienttemplates,verbs=get;list;watch;delete;create;update;patch
 
 // Reconcile manage GatewayClient lifecycle.
 func (r *ClientReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -247,6 +253,10 @@ func (r *ClientReconciler) EnsureGatewayClient(ctx context.Context, gwClient *ne
 		return fmt.Errorf("unable to update the client: %w", err)
 	}
 
+	if err := enutils.EnsureConnectionQualityThresholds(ctx, r.Client, gwClient, gwClient.Spec.QualityThresholds); err != nil {
+		return err
+	}
+
 	gwClient.Status.ClientRef = &corev1.ObjectReference{
 		APIVersion: unstructuredObject.GetAPIVersion(),
 		Kind:       unstructuredObject.GetKind(),
@@ -268,6 +278,12 @@ func (r *ClientReconciler) EnsureGatewayClient(ctx context.Context, gwClient *ne
 	if ok && internalEndpoint != nil {
 		gwClient.Status.InternalEndpoint = enutils.ParseInternalEndpoint(*internalEndpoint)
 	}
+	internalEndpoints, ok := enutils.GetIfExists[[]interface{}](status, "internalEndpoints")
+	if ok && internalEndpoints != nil {
+		gwClient.Status.InternalEndpoints = enutils.ParseInternalEndpoints(*internalEndpoints)
+	} else {
+		gwClient.Status.InternalEndpoints = nil
+	}
 
 	return nil
 }
@@ -288,5 +304,7 @@ func (r *ClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlGatewayClientExternal).
 		WatchesRawSource(factorySource.Source(ownerEnqueuer)).
 		For(&networkingv1beta1.GatewayClient{}).
+		Watches(&networkingv1beta1.Connection{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &networkingv1beta1.GatewayClient{})).
 		Complete(r)
 }
@@ -58,7 +58,7 @@ func NewConfigurationReconciler(cl client.Client, s *runtime.Scheme, er record.E
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=configurations,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=configurations/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=configurations/finalizers,verbs=update
-// +kubebuilder:rbac:groups=ipam.liqo.io,resources=networks,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=ipam.liqo.io,resources=networks,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=ipam.liqo.io,resources=networks/status,verbs=get;list;watch
 
 // Reconcile manage Configurations, remapping cidrs with Networks resources.
@@ -84,6 +84,10 @@ func (r *ConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	if err := r.RemapTransit(ctx, configuration, r.EventsRecorder); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := r.UpdateConfigurationStatus(ctx, configuration); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -102,19 +106,19 @@ func (r *ConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 func (r *ConfigurationReconciler) defaultLocalNetwork(ctx context.Context, cfg *networkingv1beta1.Configuration) error {
 	if r.localCIDR == nil {
-		podCIDR, err := ipamutils.GetPodCIDR(ctx, r.Client, corev1.NamespaceAll)
+		podCIDRs, err := ipamutils.GetPodCIDRs(ctx, r.Client, corev1.NamespaceAll)
 		if err != nil {
 			return fmt.Errorf("unable to retrieve the podCIDR: %w", err)
 		}
 
-		externalCIDR, err := ipamutils.GetExternalCIDR(ctx, r.Client, corev1.NamespaceAll)
+		externalCIDRs, err := ipamutils.GetExternalCIDRs(ctx, r.Client, corev1.NamespaceAll)
 		if err != nil {
 			return fmt.Errorf("unable to retrieve the externalCIDR: %w", err)
 		}
 
 		r.localCIDR = &networkingv1beta1.ClusterConfigCIDR{
-			Pod:      cidr.SetPrimary(networkingv1beta1.CIDR(podCIDR)),
-			External: cidr.SetPrimary(networkingv1beta1.CIDR(externalCIDR)),
+			Pod:      cidr.FromStrings(podCIDRs),
+			External: cidr.FromStrings(externalCIDRs),
 		}
 	}
 
@@ -128,15 +132,18 @@ func (r *ConfigurationReconciler) defaultLocalNetwork(ctx context.Context, cfg *
 func (r *ConfigurationReconciler) RemapConfiguration(ctx context.Context, cfg *networkingv1beta1.Configuration,
 	er record.EventRecorder) error {
 	// Checks if the configuration is already remapped.
+	// A separate network is managed for each CIDR type and IP family (dual-stack clusters).
 	for _, cidrType := range LabelCIDRTypeValues {
-		network, err := CreateOrGetNetwork(ctx, r.Client, r.Scheme, er, cfg, cidrType)
-		if err != nil {
-			return fmt.Errorf("unable to create or get the network %q: %w", client.ObjectKeyFromObject(cfg), err)
-		}
-		if network.Status.CIDR == "" {
-			continue
+		for _, family := range cidr.GetFamilies(GetRemoteCIDRs(cfg, cidrType)) {
+			network, err := CreateOrGetNetwork(ctx, r.Client, r.Scheme, er, cfg, nil, cidrType, family)
+			if err != nil {
+				return fmt.Errorf("unable to create or get the network %q: %w", client.ObjectKeyFromObject(cfg), err)
+			}
+			if network.Status.CIDR == "" {
+				continue
+			}
+			ForgeConfigurationStatus(cfg, network, cidrType)
 		}
-		ForgeConfigurationStatus(cfg, network, cidrType)
 	}
 	return nil
 }
@@ -154,15 +161,18 @@ func ForgeConfigurationStatus(cfg *networkingv1beta1.Configuration, net *ipamv1a
 	if cfg.Status.Remote == nil {
 		cfg.Status.Remote = &networkingv1beta1.ClusterConfig{}
 	}
-	var cidrNew, cidrOld networkingv1beta1.CIDR
-	cidrNew = net.Status.CIDR
+	var cidrOld networkingv1beta1.CIDR
+	cidrNew := net.Status.CIDR
+	if old := cidr.GetByFamily(GetRemoteCIDRs(cfg, cidrType), cidr.GetFamily(cidrNew)); old != nil {
+		cidrOld = *old
+	}
+	// The remapped CIDRs are kept in the same order of the original ones, so that the primary CIDR always comes first.
+	families := cidr.GetFamilies(GetRemoteCIDRs(cfg, cidrType))
 	switch cidrType {
 	case LabelCIDRTypePod:
-		cidrOld = *cidr.GetPrimary(cfg.Spec.Remote.CIDR.Pod)
-		cfg.Status.Remote.CIDR.Pod = cidr.SetPrimary(cidrNew)
+		cfg.Status.Remote.CIDR.Pod = cidr.SortByFamilies(cidr.SetByFamily(cfg.Status.Remote.CIDR.Pod, cidrNew), families)
 	case LabelCIDRTypeExternal:
-		cidrOld = *cidr.GetPrimary(cfg.Spec.Remote.CIDR.External)
-		cfg.Status.Remote.CIDR.External = cidr.SetPrimary(cidrNew)
+		cfg.Status.Remote.CIDR.External = cidr.SortByFamilies(cidr.SetByFamily(cfg.Status.Remote.CIDR.External, cidrNew), families)
 	}
 	klog.Infof("Configuration %s %s CIDR: %s -> %s", client.ObjectKeyFromObject(cfg).String(), cidrType, cidrOld, cidrNew)
 }
@@ -171,7 +181,9 @@ func isConfigurationConfigured(cfg *networkingv1beta1.Configuration) bool {
 	if cfg.Status.Remote == nil {
 		return false
 	}
-	return !cidr.IsVoid(cidr.GetPrimary(cfg.Status.Remote.CIDR.Pod)) && !cidr.IsVoid(cidr.GetPrimary(cfg.Status.Remote.CIDR.External))
+	return cidr.HasSameFamilies(cfg.Spec.Remote.CIDR.Pod, cfg.Status.Remote.CIDR.Pod) &&
+		cidr.HasSameFamilies(cfg.Spec.Remote.CIDR.External, cfg.Status.Remote.CIDR.External) &&
+		isTransitConfigured(cfg)
 }
 
 // SetupWithManager register the ConfigurationReconciler to the manager.
@@ -18,7 +18,9 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
@@ -42,28 +44,51 @@ const (
 // LabelCIDRTypeValues is the list of all the possible values of the LabelCIDRType label.
 var LabelCIDRTypeValues = []LabelCIDRTypeValue{LabelCIDRTypePod, LabelCIDRTypeExternal}
 
+// LabelIPFamily is the label used to target a ipamv1alpha1.Network resource that manages a CIDR of a given IP family.
+const LabelIPFamily = "configuration.liqo.io/ip-family"
+
+// LabelTransitClusterID is the label used to target a ipamv1alpha1.Network resource that manages a CIDR
+// of a cluster reachable in transit through the remote one.
+const LabelTransitClusterID = "configuration.liqo.io/transit-cluster-id"
+
 // ForgeNetworkLabel creates a label to target a ipamv1alpha1.Network resource.
-// The label is composed by the remote cluster ID and the CIDR type.
-func ForgeNetworkLabel(cfg *networkingv1beta1.Configuration, cidrType LabelCIDRTypeValue) (netLabels map[string]string, err error) {
+// The label is composed by the remote cluster ID, the CIDR type and the IP family,
+// plus the ID of the cluster reachable in transit if transit is not nil.
+func ForgeNetworkLabel(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig, cidrType LabelCIDRTypeValue,
+	family corev1.IPFamily) (netLabels map[string]string, err error) {
 	remoteClusterID, ok := cfg.Labels[consts.RemoteClusterID]
 	if !ok {
 		return nil, fmt.Errorf("missing label %s", consts.RemoteClusterID)
 	}
-	return map[string]string{
+	netLabels = map[string]string{
 		consts.RemoteClusterID: remoteClusterID,
 		LabelCIDRType:          string(cidrType),
-	}, nil
+		LabelIPFamily:          string(family),
+	}
+	if transit != nil {
+		netLabels[LabelTransitClusterID] = string(transit.ClusterID)
+	}
+	return netLabels, nil
 }
 
 // ForgeNetworkLabelSelector creates a labels.Selector to target a ipamv1alpha1.Network resource.
-// The label is composed by the remote cluster ID and the CIDR type.
-func ForgeNetworkLabelSelector(cfg *networkingv1beta1.Configuration,
-	cidrType LabelCIDRTypeValue) (labelsSelector labels.Selector, err error) {
-	result, err := ForgeNetworkLabel(cfg, cidrType)
+// The label is composed by the remote cluster ID, the CIDR type and the IP family.
+// The Networks of the clusters reachable in transit are selected only if transit is not nil.
+func ForgeNetworkLabelSelector(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig,
+	cidrType LabelCIDRTypeValue, family corev1.IPFamily) (labelsSelector labels.Selector, err error) {
+	result, err := ForgeNetworkLabel(cfg, transit, cidrType, family)
 	if err != nil {
 		return nil, err
 	}
-	return labels.SelectorFromSet(result), nil
+	selector := labels.SelectorFromSet(result)
+	if transit == nil {
+		req, err := labels.NewRequirement(LabelTransitClusterID, selection.DoesNotExist, nil)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*req)
+	}
+	return selector, nil
 }
 
 const (
@@ -17,7 +17,9 @@ package configurationcontroller
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,33 +33,81 @@ import (
 	"github.com/liqotech/liqo/pkg/utils/resource"
 )
 
+// GetRemoteCIDRs returns the remote CIDRs of the given type, as specified in the configuration.
+func GetRemoteCIDRs(cfg *networkingv1beta1.Configuration, cidrType LabelCIDRTypeValue) []networkingv1beta1.CIDR {
+	switch cidrType {
+	case LabelCIDRTypePod:
+		return cfg.Spec.Remote.CIDR.Pod
+	case LabelCIDRTypeExternal:
+		return cfg.Spec.Remote.CIDR.External
+	default:
+		return nil
+	}
+}
+
+// GetTransitCIDRs returns the CIDRs of the given type of a cluster reachable in transit through the remote one.
+func GetTransitCIDRs(transit *networkingv1beta1.TransitConfig, cidrType LabelCIDRTypeValue) []networkingv1beta1.CIDR {
+	switch cidrType {
+	case LabelCIDRTypePod:
+		return transit.CIDR.Pod
+	case LabelCIDRTypeExternal:
+		return transit.CIDR.External
+	default:
+		return nil
+	}
+}
+
+// getCIDRs returns the CIDRs of the given type of the remote cluster or, if transit is not nil,
+// of the given cluster reachable in transit through it.
+func getCIDRs(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig,
+	cidrType LabelCIDRTypeValue) []networkingv1beta1.CIDR {
+	if transit != nil {
+		return GetTransitCIDRs(transit, cidrType)
+	}
+	return GetRemoteCIDRs(cfg, cidrType)
+}
+
+// ForgeNetworkName returns the name of the ipamv1alpha1.Network resource managing the CIDR of the given type and IP family.
+// The Network of the primary CIDR is named after the configuration and the CIDR type only,
+// while the one of the secondary CIDR (dual-stack clusters) is suffixed with the IP family.
+// The Networks of the clusters reachable in transit additionally include the ID of the transit cluster.
+func ForgeNetworkName(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig,
+	cidrType LabelCIDRTypeValue, family corev1.IPFamily) string {
+	name := fmt.Sprintf("%s-%s", cfg.Name, cidrType)
+	if transit != nil {
+		name = fmt.Sprintf("%s-transit-%s-%s", cfg.Name, transit.ClusterID, cidrType)
+	}
+	if primary := cidrutils.GetPrimary(getCIDRs(cfg, transit, cidrType)); primary != nil && cidrutils.GetFamily(*primary) != family {
+		name = fmt.Sprintf("%s-%s", name, strings.ToLower(string(family)))
+	}
+	return name
+}
+
 // ForgeNetworkMetadata creates the metadata of a ipamv1alpha1.Network resource.
-func ForgeNetworkMetadata(net *ipamv1alpha1.Network, cfg *networkingv1beta1.Configuration, cidrType LabelCIDRTypeValue) error {
-	labels, err := ForgeNetworkLabel(cfg, cidrType)
+func ForgeNetworkMetadata(net *ipamv1alpha1.Network, cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig,
+	cidrType LabelCIDRTypeValue, family corev1.IPFamily) error {
+	labels, err := ForgeNetworkLabel(cfg, transit, cidrType, family)
 	if err != nil {
 		return err
 	}
-	net.Name = fmt.Sprintf("%s-%s", cfg.Name, cidrType)
+	net.Name = ForgeNetworkName(cfg, transit, cidrType, family)
 	net.Namespace = cfg.Namespace
 	net.Labels = labels
 	return nil
 }
 
 // ForgeNetwork creates a ipamv1alpha1.Network resource.
-func ForgeNetwork(net *ipamv1alpha1.Network, cfg *networkingv1beta1.Configuration, cidrType LabelCIDRTypeValue,
-	scheme *runtime.Scheme) (err error) {
-	if err := ForgeNetworkMetadata(net, cfg, cidrType); err != nil {
+func ForgeNetwork(net *ipamv1alpha1.Network, cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig,
+	cidrType LabelCIDRTypeValue, family corev1.IPFamily, scheme *runtime.Scheme) (err error) {
+	if err := ForgeNetworkMetadata(net, cfg, transit, cidrType, family); err != nil {
 		return err
 	}
-	var cidr networkingv1beta1.CIDR
-	switch cidrType {
-	case LabelCIDRTypePod:
-		cidr = *cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.Pod)
-	case LabelCIDRTypeExternal:
-		cidr = *cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.External)
+	cidr := cidrutils.GetByFamily(getCIDRs(cfg, transit, cidrType), family)
+	if cidr == nil {
+		return fmt.Errorf("no %s CIDR of family %s found in the configuration", cidrType, family)
 	}
 	net.Spec = ipamv1alpha1.NetworkSpec{
-		CIDR: cidr,
+		CIDR: *cidr,
 	}
 	err = ctrlutil.SetControllerReference(cfg, net, scheme)
 	if err != nil {
@@ -67,9 +117,11 @@ func ForgeNetwork(net *ipamv1alpha1.Network, cfg *networkingv1beta1.Configuratio
 }
 
 // CreateOrGetNetwork creates or gets a ipamv1alpha1.Network resource.
+// If transit is not nil, the Network manages the CIDR of the given cluster reachable in transit through the remote one.
 func CreateOrGetNetwork(ctx context.Context, cl client.Client, scheme *runtime.Scheme, er record.EventRecorder,
-	cfg *networkingv1beta1.Configuration, cidrType LabelCIDRTypeValue) (*ipamv1alpha1.Network, error) {
-	ls, err := ForgeNetworkLabelSelector(cfg, cidrType)
+	cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig,
+	cidrType LabelCIDRTypeValue, family corev1.IPFamily) (*ipamv1alpha1.Network, error) {
+	ls, err := ForgeNetworkLabelSelector(cfg, transit, cidrType, family)
 	if err != nil {
 		return nil, err
 	}
@@ -90,12 +142,12 @@ func CreateOrGetNetwork(ctx context.Context, cl client.Client, scheme *runtime.S
 	events.Event(er, cfg, fmt.Sprintf("Creating network %s/%s", cfg.Name, cfg.Namespace))
 
 	network := &ipamv1alpha1.Network{}
-	if err = ForgeNetworkMetadata(network, cfg, cidrType); err != nil {
+	if err = ForgeNetworkMetadata(network, cfg, transit, cidrType, family); err != nil {
 		return nil, err
 	}
 
 	if _, err := resource.CreateOrUpdate(ctx, cl, network, func() error {
-		return ForgeNetwork(network, cfg, cidrType, scheme)
+		return ForgeNetwork(network, cfg, transit, cidrType, family, scheme)
 	}); err != nil {
 		return nil, err
 	}
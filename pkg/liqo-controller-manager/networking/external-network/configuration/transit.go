@@ -0,0 +1,145 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configurationcontroller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	ipamv1alpha1 "github.com/liqotech/liqo/apis/ipam/v1alpha1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/cidr"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// RemapTransit remaps the CIDRs of the clusters reachable in transit through the remote one, using ipamv1alpha1.Network.
+// The status and the Networks of the clusters no longer advertised by the remote cluster are removed.
+func (r *ConfigurationReconciler) RemapTransit(ctx context.Context, cfg *networkingv1beta1.Configuration,
+	er record.EventRecorder) error {
+	for i := range cfg.Spec.Remote.Transit {
+		transit := &cfg.Spec.Remote.Transit[i]
+		for _, cidrType := range LabelCIDRTypeValues {
+			for _, family := range cidr.GetFamilies(GetTransitCIDRs(transit, cidrType)) {
+				network, err := CreateOrGetNetwork(ctx, r.Client, r.Scheme, er, cfg, transit, cidrType, family)
+				if err != nil {
+					return fmt.Errorf("unable to create or get the transit network %q: %w", client.ObjectKeyFromObject(cfg), err)
+				}
+				if network.Status.CIDR == "" {
+					continue
+				}
+				ForgeTransitConfigurationStatus(cfg, transit, network, cidrType)
+			}
+		}
+	}
+
+	if cfg.Status.Remote != nil {
+		cfg.Status.Remote.Transit = slices.DeleteFunc(cfg.Status.Remote.Transit, func(t networkingv1beta1.TransitConfig) bool {
+			return GetTransit(cfg.Spec.Remote.Transit, t.ClusterID) == nil
+		})
+	}
+
+	return r.deleteStaleTransitNetworks(ctx, cfg)
+}
+
+// deleteStaleTransitNetworks deletes the Networks of the clusters no longer reachable in transit through the remote one.
+func (r *ConfigurationReconciler) deleteStaleTransitNetworks(ctx context.Context, cfg *networkingv1beta1.Configuration) error {
+	remoteClusterID, ok := cfg.Labels[consts.RemoteClusterID]
+	if !ok {
+		return fmt.Errorf("missing label %s", consts.RemoteClusterID)
+	}
+	req, err := labels.NewRequirement(LabelTransitClusterID, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	selector := labels.SelectorFromSet(labels.Set{consts.RemoteClusterID: remoteClusterID}).Add(*req)
+	networks, err := getters.ListNetworksByLabel(ctx, r.Client, cfg.Namespace, selector)
+	if err != nil {
+		return fmt.Errorf("unable to list the transit networks of configuration %q: %w", client.ObjectKeyFromObject(cfg), err)
+	}
+	for i := range networks.Items {
+		net := &networks.Items[i]
+		transitID := liqov1beta1.ClusterID(net.Labels[LabelTransitClusterID])
+		if GetTransit(cfg.Spec.Remote.Transit, transitID) != nil {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Client.Delete(ctx, net)); err != nil {
+			return fmt.Errorf("unable to delete the transit network %q: %w", client.ObjectKeyFromObject(net), err)
+		}
+		klog.Infof("Deleted network %q of cluster %s, no longer reachable in transit", client.ObjectKeyFromObject(net), transitID)
+	}
+	return nil
+}
+
+// ForgeTransitConfigurationStatus sets in the status of the configuration the remapped CIDR of a cluster reachable in transit.
+func ForgeTransitConfigurationStatus(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig,
+	net *ipamv1alpha1.Network, cidrType LabelCIDRTypeValue) {
+	if cfg.Status.Remote == nil {
+		cfg.Status.Remote = &networkingv1beta1.ClusterConfig{}
+	}
+	status := GetTransit(cfg.Status.Remote.Transit, transit.ClusterID)
+	if status == nil {
+		cfg.Status.Remote.Transit = append(cfg.Status.Remote.Transit, networkingv1beta1.TransitConfig{ClusterID: transit.ClusterID})
+		status = &cfg.Status.Remote.Transit[len(cfg.Status.Remote.Transit)-1]
+	}
+
+	cidrNew := net.Status.CIDR
+	families := cidr.GetFamilies(GetTransitCIDRs(transit, cidrType))
+	switch cidrType {
+	case LabelCIDRTypePod:
+		status.CIDR.Pod = cidr.SortByFamilies(cidr.SetByFamily(status.CIDR.Pod, cidrNew), families)
+	case LabelCIDRTypeExternal:
+		status.CIDR.External = cidr.SortByFamilies(cidr.SetByFamily(status.CIDR.External, cidrNew), families)
+	}
+	klog.V(4).Infof("Configuration %s transit %s %s CIDR: %s", client.ObjectKeyFromObject(cfg).String(), transit.ClusterID, cidrType, cidrNew)
+}
+
+// GetTransit returns the transit configuration of the given cluster, or nil if not present.
+func GetTransit(transits []networkingv1beta1.TransitConfig, clusterID liqov1beta1.ClusterID) *networkingv1beta1.TransitConfig {
+	for i := range transits {
+		if transits[i].ClusterID == clusterID {
+			return &transits[i]
+		}
+	}
+	return nil
+}
+
+func isTransitConfigured(cfg *networkingv1beta1.Configuration) bool {
+	for i := range cfg.Spec.Remote.Transit {
+		transit := &cfg.Spec.Remote.Transit[i]
+		if cfg.Status.Remote == nil {
+			return false
+		}
+		status := GetTransit(cfg.Status.Remote.Transit, transit.ClusterID)
+		if status == nil {
+			return false
+		}
+		if len(transit.CIDR.Pod) > 0 && !cidr.HasSameFamilies(transit.CIDR.Pod, status.CIDR.Pod) {
+			return false
+		}
+		if len(transit.CIDR.External) > 0 && !cidr.HasSameFamilies(transit.CIDR.External, status.CIDR.External) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,258 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipsec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/gateway/forge"
+	enutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/utils"
+)
+
+// IPsecGatewayServerReconciler manage IPsecGatewayServer lifecycle.
+type IPsecGatewayServerReconciler struct {
+	client.Client
+	Scheme          *runtime.Scheme
+	clusterRoleName string
+
+	eventRecorder record.EventRecorder
+}
+
+// NewIPsecGatewayServerReconciler returns a new IPsecGatewayServerReconciler.
+func NewIPsecGatewayServerReconciler(cl client.Client, s *runtime.Scheme,
+	recorder record.EventRecorder,
+	clusterRoleName string) *IPsecGatewayServerReconciler {
+	return &IPsecGatewayServerReconciler{
+		Client:          cl,
+		Scheme:          s,
+		clusterRoleName: clusterRoleName,
+
+		eventRecorder: recorder,
+	}
+}
+
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayservers,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;create;delete;update
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;delete;create;update;patch
+// +kubectl:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;delete;create;update;patch
+
+// Reconcile manage IPsecGatewayServer lifecycle.
+func (r *IPsecGatewayServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	ipsecServer := &networkingv1beta1.IPsecGatewayServer{}
+	if err = r.Get(ctx, req.NamespacedName, ipsecServer); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(4).Infof("IPsec gateway server %q not found", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		klog.Errorf("Unable to get the IPsec gateway server %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	if !ipsecServer.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(ipsecServer, consts.ClusterRoleBindingFinalizer) {
+			if err = enutils.DeleteClusterRoleBinding(ctx, r.Client, ipsecServer); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			controllerutil.RemoveFinalizer(ipsecServer, consts.ClusterRoleBindingFinalizer)
+			if err = r.Update(ctx, ipsecServer); err != nil {
+				klog.Errorf("Unable to remove finalizer %q from IPsec gateway server %q: %v",
+					consts.ClusterRoleBindingFinalizer, req.NamespacedName, err)
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Resource is deleting and child resources are deleted as well by garbage collector. Nothing to do.
+		return ctrl.Result{}, nil
+	}
+
+	originalIPsecServer := ipsecServer.DeepCopy()
+
+	// Ensure ServiceAccount and ClusterRoleBinding (create or update)
+	if err = enutils.EnsureServiceAccountAndClusterRoleBinding(ctx, r.Client, r.Scheme, &ipsecServer.Spec.Deployment, ipsecServer,
+		r.clusterRoleName); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// update if the ipsecServer has been updated
+	if !equality.Semantic.DeepEqual(originalIPsecServer, ipsecServer) {
+		if err := r.Update(ctx, ipsecServer); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// we return here to avoid conflicts
+		return ctrl.Result{}, nil
+	}
+
+	deployNsName := types.NamespacedName{Namespace: ipsecServer.Namespace, Name: forge.GatewayResourceName(ipsecServer.Name)}
+	svcNsName := types.NamespacedName{Namespace: ipsecServer.Namespace, Name: forge.GatewayResourceName(ipsecServer.Name)}
+
+	var deploy *appsv1.Deployment
+	var d appsv1.Deployment
+	err = r.Get(ctx, deployNsName, &d)
+	switch {
+	case apierrors.IsNotFound(err):
+		deploy = nil
+	case err != nil:
+		klog.Errorf("Unable to get the deployment %q: %v", deployNsName, err)
+		return ctrl.Result{}, err
+	default:
+		deploy = &d
+	}
+
+	// Handle status
+	defer func() {
+		newErr := r.Status().Update(ctx, ipsecServer)
+		if newErr != nil {
+			if err != nil {
+				klog.Errorf("Error reconciling the IPsec gateway server %q: %s", req.NamespacedName, err)
+			}
+			klog.Errorf("Unable to update the IPsec gateway server status %q: %s", req.NamespacedName, newErr)
+			err = newErr
+			return
+		}
+
+		r.eventRecorder.Event(ipsecServer, corev1.EventTypeNormal, "Reconciled", "IPsec gateway server reconciled")
+	}()
+
+	if err := r.handleEndpointStatus(ctx, ipsecServer, svcNsName, deploy); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.handleInternalEndpointStatus(ctx, ipsecServer, svcNsName, deploy); err != nil {
+		klog.Errorf("Error while handling internal endpoint status: %v", err)
+		r.eventRecorder.Event(ipsecServer, corev1.EventTypeWarning, "InternalEndpointStatusFailed",
+			fmt.Sprintf("Failed to handle internal endpoint status: %s", err))
+		return ctrl.Result{}, err
+	}
+
+	// If a secret has not been provided in the gateway specification, the controller is in charge of generating a secret with the IPsec keys.
+	if err = enutils.EnforceKeysSecret(ctx, r.Client, r.eventRecorder, ipsecServer, ipsecServer.Spec.SecretRef,
+		gateway.ModeServer, enutils.GenerateIPsecKeys); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.handleSecretRefStatus(ctx, ipsecServer); err != nil {
+		klog.Errorf("Error while handling secret ref status: %v", err)
+		r.eventRecorder.Event(ipsecServer, corev1.EventTypeWarning, "SecretRefStatusFailed",
+			fmt.Sprintf("Failed to handle secret ref status: %s", err))
+		return ctrl.Result{}, err
+	}
+
+	// Ensure deployment (create or update)
+	_, err = enutils.EnsureGatewayDeployment(ctx, r.Client, r.Scheme, r.eventRecorder, ipsecServer, &ipsecServer.Spec.Deployment,
+		ipsecServer.Status.SecretRef, ipsecVolumeName, deployNsName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.eventRecorder.Event(ipsecServer, corev1.EventTypeNormal, "DeploymentEnforced", "Enforced deployment")
+
+	// Ensure service (create or update)
+	_, err = enutils.EnsureGatewayService(ctx, r.Client, r.Scheme, ipsecServer, &ipsecServer.Spec.Service, svcNsName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.eventRecorder.Event(ipsecServer, corev1.EventTypeNormal, "ServiceEnforced", "Enforced service")
+
+	// Ensure Metrics (if set)
+	err = enutils.EnsureMetrics(ctx,
+		r.Client, r.Scheme,
+		ipsecServer.Spec.Metrics, ipsecServer)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.eventRecorder.Event(ipsecServer, corev1.EventTypeNormal, "MetricsEnforced", "Enforced metrics")
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager register the IPsecGatewayServerReconciler to the manager.
+func (r *IPsecGatewayServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlIPsecGatewayServer).
+		For(&networkingv1beta1.IPsecGatewayServer{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.ServiceAccount{}).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(enutils.PodEnquerer)).
+		Watches(&rbacv1.ClusterRoleBinding{},
+			handler.EnqueueRequestsFromMapFunc(enutils.ClusterRoleBindingEnquerer)).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(enutils.KeysSecretEnquerer),
+			builder.WithPredicates(enutils.FilterKeysSecretsPredicate())).
+		Complete(r)
+}
+
+func (r *IPsecGatewayServerReconciler) handleEndpointStatus(ctx context.Context, ipsecServer *networkingv1beta1.IPsecGatewayServer,
+	svcNsName types.NamespacedName, dep *appsv1.Deployment) error {
+	// Put service endpoint in IPsec server status
+	endpointStatus, err := enutils.ForgeServerEndpointStatus(ctx, r.Client, svcNsName, dep)
+	if err != nil {
+		if errors.Is(err, enutils.ErrUnsupportedServiceType) {
+			ipsecServer.Status.Endpoint = nil // we empty the endpoint status to avoid misaligned spec and status
+		}
+		return err
+	}
+
+	ipsecServer.Status.Endpoint = endpointStatus
+	return nil
+}
+
+func (r *IPsecGatewayServerReconciler) handleSecretRefStatus(ctx context.Context, ipsecServer *networkingv1beta1.IPsecGatewayServer) error {
+	secretRef, err := enutils.ForgeSecretRefStatus(ctx, r.Client, ipsecServer)
+	if err != nil {
+		return err
+	}
+	ipsecServer.Status.SecretRef = secretRef
+	return nil
+}
+
+func (r *IPsecGatewayServerReconciler) handleInternalEndpointStatus(ctx context.Context, ipsecServer *networkingv1beta1.IPsecGatewayServer,
+	svcNsName types.NamespacedName, dep *appsv1.Deployment) error {
+	ige, err := enutils.ForgeServerInternalEndpointStatus(ctx, r.Client, svcNsName, dep)
+	if err != nil {
+		return err
+	}
+
+	ipsecServer.Status.InternalEndpoint = ige
+	return nil
+}
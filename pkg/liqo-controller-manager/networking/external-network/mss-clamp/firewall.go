@@ -0,0 +1,78 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mssclamp
+
+import (
+	"fmt"
+
+	"k8s.io/utils/ptr"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+)
+
+const (
+	// TableName is the name of the table clamping the TCP MSS in the gateway.
+	TableName = "mss-clamp"
+	// ChainName is the name of the chain clamping the TCP MSS of the traffic forwarded by the gateway.
+	ChainName = "forward"
+	// ruleName is the name of the rule clamping the TCP MSS of the traffic entering the tunnel.
+	ruleName = "clamp-tunnel"
+)
+
+// forgeFirewallConfigurationName returns the name of the firewall configuration clamping the TCP MSS
+// of the traffic crossing the tunnel associated with the given connection.
+func forgeFirewallConfigurationName(connectionName string) string {
+	return fmt.Sprintf("%s-%s", connectionName, TableName)
+}
+
+// forgeFirewallConfigurationSpec returns the table clamping the TCP MSS of the connections entering the tunnel.
+// The MSS is clamped to the MTU of the route (hence, of the tunnel interface), which follows the discovered MTU.
+func forgeFirewallConfigurationSpec() *networkingv1beta1.FirewallConfigurationSpec {
+	return &networkingv1beta1.FirewallConfigurationSpec{
+		Table: firewall.Table{
+			Name:   ptr.To(TableName),
+			Family: ptr.To(firewall.TableFamilyINet),
+			Chains: []firewall.Chain{
+				{
+					Name:     ptr.To(ChainName),
+					Type:     firewall.ChainTypeFilter,
+					Policy:   ptr.To(firewall.ChainPolicyAccept),
+					Hook:     ptr.To(firewall.ChainHookForward),
+					Priority: ptr.To(firewall.ChainPriorityMangle),
+					Rules: firewall.RulesSet{
+						FilterRules: []firewall.FilterRule{
+							{
+								Name: ptr.To(ruleName),
+								Match: []firewall.Match{
+									{
+										Op:    firewall.MatchOperationEq,
+										Proto: &firewall.MatchProto{Value: firewall.L4ProtoTCP},
+									},
+									{
+										Op:  firewall.MatchOperationEq,
+										Dev: &firewall.MatchDev{Value: tunnel.TunnelInterfaceName, Position: firewall.MatchDevPositionOut},
+									},
+								},
+								Action: firewall.ActionTCPMssClamp,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
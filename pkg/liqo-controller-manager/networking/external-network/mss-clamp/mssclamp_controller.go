@@ -0,0 +1,106 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mssclamp
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/remapping"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// cluster-role
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=connections,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=firewallconfigurations,verbs=get;list;create;delete;update;watch
+
+// MSSClampReconciler clamps the TCP MSS of the connections crossing the tunnels whose MTU is discovered by the gateways,
+// so that the TCP segments fit the tunnel even if the path MTU discovery of the endpoints is not effective.
+type MSSClampReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+// NewMSSClampReconciler returns a new MSSClampReconciler.
+func NewMSSClampReconciler(cl client.Client, s *runtime.Scheme) *MSSClampReconciler {
+	return &MSSClampReconciler{
+		Client: cl,
+		Scheme: s,
+	}
+}
+
+// Reconcile manages the Connection resources, enforcing the clamping of the TCP MSS if the MTU of the tunnel is discovered.
+func (r *MSSClampReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	connection := &networkingv1beta1.Connection{}
+	if err := r.Client.Get(ctx, req.NamespacedName, connection); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(6).Infof("There is no connection %s", req.String())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to get the connection %q: %w", req.NamespacedName, err)
+	}
+
+	fwcfg := &networkingv1beta1.FirewallConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      forgeFirewallConfigurationName(connection.Name),
+			Namespace: connection.Namespace,
+		},
+	}
+
+	// The MTU is reported only if its discovery is enabled in the gateway.
+	if connection.Status.PathMTU == nil {
+		if err := client.IgnoreNotFound(r.Client.Delete(ctx, fwcfg)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to delete firewall configuration %q: %w", fwcfg.Name, err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	remoteClusterID, ok := connection.Labels[consts.RemoteClusterID]
+	if !ok {
+		return ctrl.Result{}, fmt.Errorf("connection %q has no remote cluster ID label", req.NamespacedName)
+	}
+
+	op, err := resource.CreateOrUpdate(ctx, r.Client, fwcfg, func() error {
+		fwcfg.SetLabels(remapping.ForgeFirewallTargetLabels(remoteClusterID))
+		fwcfg.Spec = *forgeFirewallConfigurationSpec()
+		return controllerutil.SetControllerReference(connection, fwcfg, r.Scheme)
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to enforce firewall configuration %q: %w", fwcfg.Name, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		klog.Infof("Firewall configuration %q clamping the TCP MSS towards cluster %q %s", fwcfg.Name, remoteClusterID, op)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager register the MSSClampReconciler to the manager.
+func (r *MSSClampReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlConnectionMSSClamp).
+		For(&networkingv1beta1.Connection{}).
+		Owns(&networkingv1beta1.FirewallConfiguration{}).
+		Complete(r)
+}
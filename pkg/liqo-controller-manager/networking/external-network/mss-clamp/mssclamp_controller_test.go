@@ -0,0 +1,109 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mssclamp
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	"github.com/liqotech/liqo/pkg/consts"
+	fwlabels "github.com/liqotech/liqo/pkg/firewall"
+)
+
+var _ = Describe("MSS clamp controller", func() {
+	const (
+		tenantNamespace = "liqo-tenant-cluster-b"
+		remoteClusterID = "cluster-b"
+	)
+
+	var (
+		ctx        context.Context
+		cl         client.Client
+		r          *MSSClampReconciler
+		connection *networkingv1beta1.Connection
+		req        ctrl.Request
+
+		getFirewallConfiguration = func() (*networkingv1beta1.FirewallConfiguration, error) {
+			fwcfg := &networkingv1beta1.FirewallConfiguration{}
+			return fwcfg, cl.Get(ctx, client.ObjectKey{
+				Namespace: tenantNamespace, Name: forgeFirewallConfigurationName(connection.Name),
+			}, fwcfg)
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		connection = &networkingv1beta1.Connection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "gw-cluster-b", Namespace: tenantNamespace, UID: "uid",
+				Labels: map[string]string{consts.RemoteClusterID: remoteClusterID},
+			},
+		}
+		req = ctrl.Request{NamespacedName: client.ObjectKeyFromObject(connection)}
+	})
+
+	JustBeforeEach(func() {
+		cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(connection).
+			WithStatusSubresource(&networkingv1beta1.Connection{}).Build()
+		r = NewMSSClampReconciler(cl, scheme)
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	When("the MTU of the tunnel is not discovered", func() {
+		It("should not clamp the TCP MSS", func() {
+			_, err := getFirewallConfiguration()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("the MTU of the tunnel is discovered", func() {
+		BeforeEach(func() {
+			connection.Status.PathMTU = &networkingv1beta1.ConnectionPathMTU{Value: 1300}
+		})
+
+		It("should clamp the TCP MSS of the traffic entering the tunnel", func() {
+			fwcfg, err := getFirewallConfiguration()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fwcfg.Labels).To(HaveKeyWithValue(fwlabels.FirewallUniqueTargetKey, remoteClusterID))
+			Expect(fwcfg.OwnerReferences).To(HaveLen(1))
+			Expect(fwcfg.Spec.Table.Chains).To(HaveLen(1))
+			rules := fwcfg.Spec.Table.Chains[0].Rules.FilterRules
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0].Action).To(Equal(firewall.ActionTCPMssClamp))
+			Expect(rules[0].Value).To(BeNil())
+		})
+
+		It("should stop clamping the TCP MSS when the discovery is disabled", func() {
+			Expect(cl.Get(ctx, req.NamespacedName, connection)).To(Succeed())
+			connection.Status.PathMTU = nil
+			Expect(cl.Status().Update(ctx, connection)).To(Succeed())
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = getFirewallConfiguration()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
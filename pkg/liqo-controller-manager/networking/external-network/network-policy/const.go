@@ -0,0 +1,35 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+const (
+	// TableName is the name of the table enforcing the PeeringNetworkPolicies in the gateway.
+	TableName = "peering-network-policy"
+	// TableIPv6Suffix is the suffix appended to the name of the table managing IPv6 traffic.
+	TableIPv6Suffix = "-v6"
+	// ChainName is the name of the chain filtering the traffic forwarded by the gateway.
+	ChainName = "forward"
+
+	// PeeringNetworkPolicyLabel is the label used to identify the firewallconfigurations enforcing the PeeringNetworkPolicies.
+	PeeringNetworkPolicyLabel = "networking.liqo.io/peering-network-policy"
+	// PeeringNetworkPolicyLabelValue is the value of the label used to identify the firewallconfigurations
+	// enforcing the PeeringNetworkPolicies.
+	PeeringNetworkPolicyLabelValue = "true"
+
+	// establishedRuleName is the name of the rule accepting the traffic of the already established connections.
+	establishedRuleName = "established"
+	// isolatedSetPrefix is the prefix of the names of the sets storing the addresses of the isolated pods.
+	isolatedSetPrefix = "isolated"
+)
@@ -0,0 +1,291 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	"github.com/liqotech/liqo/pkg/consts"
+	fwutils "github.com/liqotech/liqo/pkg/firewall/utils"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+)
+
+// getTableName returns the name of the table enforcing the policies for the given IP family.
+func getTableName(family corev1.IPFamily) string {
+	if family == corev1.IPv6Protocol {
+		return TableName + TableIPv6Suffix
+	}
+	return TableName
+}
+
+// forgeFirewallConfigurationSpec compiles the given policies into the table enforced
+// by the gateway towards the remote cluster described by the Configuration.
+// The addresses of the pods are stored in named sets, so that pod churn only updates their elements.
+func forgeFirewallConfigurationSpec(ctx context.Context, cl client.Client, cfg *networkingv1beta1.Configuration,
+	policies []networkingv1beta1.PeeringNetworkPolicy, family corev1.IPFamily) (*networkingv1beta1.FirewallConfigurationSpec, error) {
+	tableFamily := firewall.TableFamilyIPv4
+	if family == corev1.IPv6Protocol {
+		tableFamily = firewall.TableFamilyIPv6
+	}
+
+	rules, fwsets, err := forgeFilterRules(ctx, cl, cfg, policies, family)
+	if err != nil {
+		return nil, err
+	}
+
+	return &networkingv1beta1.FirewallConfigurationSpec{
+		Table: firewall.Table{
+			Name:   ptr.To(getTableName(family)),
+			Family: ptr.To(tableFamily),
+			Sets:   fwsets,
+			Chains: []firewall.Chain{
+				{
+					Name:     ptr.To(ChainName),
+					Type:     firewall.ChainTypeFilter,
+					Policy:   ptr.To(firewall.ChainPolicyAccept),
+					Hook:     ptr.To(firewall.ChainHookForward),
+					Priority: ptr.To(firewall.ChainPriorityFilter),
+					Rules:    firewall.RulesSet{FilterRules: rules},
+				},
+			},
+		},
+	}, nil
+}
+
+// forgeFilterRules returns the ordered list of rules enforcing the policies, together with the sets they reference:
+// the traffic of the established connections and the one allowed by the policies is accepted,
+// while the remaining traffic of the isolated pods is dropped.
+// The policies are expected to be sorted by name, to keep the order of the rules stable.
+func forgeFilterRules(ctx context.Context, cl client.Client, cfg *networkingv1beta1.Configuration,
+	policies []networkingv1beta1.PeeringNetworkPolicy, family corev1.IPFamily) ([]firewall.FilterRule, []firewall.Set, error) {
+	rules := []firewall.FilterRule{forgeEstablishedRule()}
+	var fwsets []firewall.Set
+	isolated := map[networkingv1beta1.PeeringNetworkPolicyType]sets.Set[string]{
+		networkingv1beta1.PeeringNetworkPolicyTypeIngress: sets.New[string](),
+		networkingv1beta1.PeeringNetworkPolicyTypeEgress:  sets.New[string](),
+	}
+
+	for i := range policies {
+		policy := &policies[i]
+		localIPs, err := getSelectedPodIPs(ctx, cl, policy, family)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to resolve the pods selected by policy %q: %w", policy.Name, err)
+		}
+
+		for _, direction := range getPolicyTypes(policy) {
+			isolated[direction].Insert(localIPs...)
+
+			policyRules := policy.Spec.Ingress
+			if direction == networkingv1beta1.PeeringNetworkPolicyTypeEgress {
+				policyRules = policy.Spec.Egress
+			}
+			for j := range policyRules {
+				allowRules, allowSets, err := forgeAllowRules(ctx, cl, cfg, policy.Name, direction, j, &policyRules[j], localIPs, family)
+				if err != nil {
+					return nil, nil, fmt.Errorf("unable to compile policy %q: %w", policy.Name, err)
+				}
+				rules = append(rules, allowRules...)
+				fwsets = append(fwsets, allowSets...)
+			}
+		}
+	}
+
+	for _, direction := range []networkingv1beta1.PeeringNetworkPolicyType{
+		networkingv1beta1.PeeringNetworkPolicyTypeIngress, networkingv1beta1.PeeringNetworkPolicyTypeEgress,
+	} {
+		setName := fmt.Sprintf("%s-%s", isolatedSetPrefix, getDirectionName(direction))
+		fwsets = append(fwsets, forgeSet(setName, family, sets.List(isolated[direction])))
+		rules = append(rules, forgeIsolationRule(direction, setName))
+	}
+
+	return rules, fwsets, nil
+}
+
+// forgeAllowRules returns the rules accepting the traffic allowed by a rule of a policy
+// between the given local addresses and the peers matching the remote cluster of the Configuration,
+// together with the sets storing the addresses and the ports they match.
+func forgeAllowRules(ctx context.Context, cl client.Client, cfg *networkingv1beta1.Configuration, policyName string,
+	direction networkingv1beta1.PeeringNetworkPolicyType, index int, policyRule *networkingv1beta1.PeeringNetworkPolicyRule,
+	localIPs []string, family corev1.IPFamily) ([]firewall.FilterRule, []firewall.Set, error) {
+	var remotes []string
+	matched := false
+	for i := range policyRule.Clusters {
+		peer := &policyRule.Clusters[i]
+		if string(peer.ClusterID) != cfg.Labels[string(consts.RemoteClusterID)] {
+			continue
+		}
+
+		addresses, err := getPeerAddresses(ctx, cl, cfg, peer, family)
+		if err != nil {
+			return nil, nil, err
+		}
+		remotes = append(remotes, addresses...)
+		matched = true
+	}
+	if !matched {
+		return nil, nil, nil
+	}
+
+	// Set names are derived from a hash, since the kernel limits their length.
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", policyName, getDirectionName(direction), index)))
+	setPrefix := hex.EncodeToString(hash[:5])
+	localSet := forgeSet(setPrefix+"-local", family, localIPs)
+	remoteSet := forgeSet(setPrefix+"-remote", family, remotes)
+	fwsets := []firewall.Set{localSet, remoteSet}
+
+	ruleName := fmt.Sprintf("%s-%s-%d", policyName, getDirectionName(direction), index)
+	if len(policyRule.Ports) == 0 {
+		return []firewall.FilterRule{forgeAllowRule(ruleName, direction, localSet.Name, remoteSet.Name, "", nil)}, fwsets, nil
+	}
+
+	var rules []firewall.FilterRule
+	ports := getPortsByProtocol(policyRule.Ports)
+	for _, proto := range []firewall.L4Proto{firewall.L4ProtoTCP, firewall.L4ProtoUDP} {
+		if len(ports[proto]) == 0 {
+			continue
+		}
+		portSet := firewall.Set{Name: fmt.Sprintf("%s-%s", setPrefix, proto), KeyType: firewall.SetDataTypeInetService}
+		for _, port := range ports[proto] {
+			portSet.Elements = append(portSet.Elements, firewall.SetElement{Key: port})
+		}
+		fwsets = append(fwsets, portSet)
+		rules = append(rules, forgeAllowRule(fmt.Sprintf("%s-%s", ruleName, proto), direction,
+			localSet.Name, remoteSet.Name, proto, &portSet.Name))
+	}
+	return rules, fwsets, nil
+}
+
+func forgeEstablishedRule() firewall.FilterRule {
+	return firewall.FilterRule{
+		Name:    ptr.To(establishedRuleName),
+		Counter: true,
+		Match: []firewall.Match{
+			{
+				Op: firewall.MatchOperationEq,
+				CtState: &firewall.MatchCtState{
+					Value: []firewall.CtState{firewall.CtStateEstablished, firewall.CtStateRelated},
+				},
+			},
+		},
+		Action: firewall.ActionAccept,
+	}
+}
+
+// forgeAllowRule returns the rule accepting the traffic between the addresses of the local and the remote set,
+// optionally restricted to the destination ports of the given set.
+func forgeAllowRule(name string, direction networkingv1beta1.PeeringNetworkPolicyType,
+	localSet, remoteSet string, proto firewall.L4Proto, portSet *string) firewall.FilterRule {
+	localPosition, remotePosition := getIPPositions(direction)
+	matches := []firewall.Match{
+		forgeTunnelMatch(direction),
+		{Op: firewall.MatchOperationEq, IP: &firewall.MatchIP{Value: fwutils.SetReferencePrefix + localSet, Position: localPosition}},
+		{Op: firewall.MatchOperationEq, IP: &firewall.MatchIP{Value: fwutils.SetReferencePrefix + remoteSet, Position: remotePosition}},
+	}
+
+	if portSet != nil {
+		matches = append(matches, firewall.Match{
+			Op:    firewall.MatchOperationEq,
+			Proto: &firewall.MatchProto{Value: proto},
+			Port:  &firewall.MatchPort{Value: fwutils.SetReferencePrefix + *portSet, Position: firewall.MatchPositionDst},
+		})
+	}
+
+	return firewall.FilterRule{
+		Name:    ptr.To(name),
+		Counter: true,
+		Match:   matches,
+		Action:  firewall.ActionAccept,
+	}
+}
+
+// forgeIsolationRule returns the rule dropping the traffic of the isolated pods not accepted by the previous rules.
+func forgeIsolationRule(direction networkingv1beta1.PeeringNetworkPolicyType, setName string) firewall.FilterRule {
+	localPosition, _ := getIPPositions(direction)
+	return firewall.FilterRule{
+		Name:    ptr.To(fmt.Sprintf("isolate-%s", getDirectionName(direction))),
+		Counter: true,
+		Match: []firewall.Match{
+			forgeTunnelMatch(direction),
+			{Op: firewall.MatchOperationEq, IP: &firewall.MatchIP{Value: fwutils.SetReferencePrefix + setName, Position: localPosition}},
+		},
+		Action: firewall.ActionDrop,
+	}
+}
+
+// forgeSet returns a set containing the given addresses, which can be either IPs or CIDRs.
+func forgeSet(name string, family corev1.IPFamily, addresses []string) firewall.Set {
+	set := firewall.Set{Name: name, KeyType: firewall.SetDataTypeIPv4Addr}
+	if family == corev1.IPv6Protocol {
+		set.KeyType = firewall.SetDataTypeIPv6Addr
+	}
+	for _, address := range addresses {
+		set.Elements = append(set.Elements, firewall.SetElement{Key: address})
+	}
+	return set
+}
+
+// forgeTunnelMatch matches the traffic received from (ingress) or sent to (egress) the remote cluster.
+func forgeTunnelMatch(direction networkingv1beta1.PeeringNetworkPolicyType) firewall.Match {
+	position := firewall.MatchDevPositionIn
+	if direction == networkingv1beta1.PeeringNetworkPolicyTypeEgress {
+		position = firewall.MatchDevPositionOut
+	}
+	return firewall.Match{
+		Op:  firewall.MatchOperationEq,
+		Dev: &firewall.MatchDev{Value: tunnel.TunnelInterfaceName, Position: position},
+	}
+}
+
+// getIPPositions returns the position of the local and of the remote address in the packets of the given direction.
+func getIPPositions(direction networkingv1beta1.PeeringNetworkPolicyType) (local, remote firewall.MatchPosition) {
+	if direction == networkingv1beta1.PeeringNetworkPolicyTypeEgress {
+		return firewall.MatchPositionSrc, firewall.MatchPositionDst
+	}
+	return firewall.MatchPositionDst, firewall.MatchPositionSrc
+}
+
+func getDirectionName(direction networkingv1beta1.PeeringNetworkPolicyType) string {
+	if direction == networkingv1beta1.PeeringNetworkPolicyTypeEgress {
+		return "egress"
+	}
+	return "ingress"
+}
+
+// getPortsByProtocol returns the ports (or ranges of ports) of the given list, grouped by protocol.
+func getPortsByProtocol(ports []networkingv1beta1.PeeringNetworkPolicyPort) map[firewall.L4Proto][]string {
+	result := map[firewall.L4Proto][]string{}
+	for i := range ports {
+		proto := firewall.L4ProtoTCP
+		if ports[i].Protocol == corev1.ProtocolUDP {
+			proto = firewall.L4ProtoUDP
+		}
+		value := fmt.Sprintf("%d", ports[i].Port)
+		if ports[i].EndPort != nil && *ports[i].EndPort > ports[i].Port {
+			value = fmt.Sprintf("%d-%d", ports[i].Port, *ports[i].EndPort)
+		}
+		result[proto] = append(result[proto], value)
+	}
+	return result
+}
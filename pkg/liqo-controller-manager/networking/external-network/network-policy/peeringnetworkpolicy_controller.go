@@ -0,0 +1,271 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	configuration "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/configuration"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/remapping"
+	cidrutils "github.com/liqotech/liqo/pkg/utils/cidr"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// cluster-role
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=peeringnetworkpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=configurations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=firewallconfigurations,verbs=get;list;create;delete;update;watch
+// +kubebuilder:rbac:groups=core,resources=pods;namespaces;nodes,verbs=get;list;watch
+
+// PeeringNetworkPolicyReconciler compiles the PeeringNetworkPolicies into the FirewallConfigurations
+// enforced by the gateway towards each remote cluster.
+type PeeringNetworkPolicyReconciler struct {
+	Client         client.Client
+	Scheme         *runtime.Scheme
+	EventsRecorder record.EventRecorder
+}
+
+// NewPeeringNetworkPolicyReconciler returns a new PeeringNetworkPolicyReconciler.
+func NewPeeringNetworkPolicyReconciler(cl client.Client, s *runtime.Scheme, er record.EventRecorder) *PeeringNetworkPolicyReconciler {
+	return &PeeringNetworkPolicyReconciler{
+		Client:         cl,
+		Scheme:         s,
+		EventsRecorder: er,
+	}
+}
+
+// Reconcile manages the Configuration resources, enforcing the PeeringNetworkPolicies towards the corresponding remote cluster.
+func (r *PeeringNetworkPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cfg := &networkingv1beta1.Configuration{}
+	if err := r.Client.Get(ctx, req.NamespacedName, cfg); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(6).Infof("There is no configuration %s", req.String())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to get the configuration %q: %w", req.NamespacedName, err)
+	}
+
+	remoteClusterID, ok := cfg.Labels[consts.RemoteClusterID]
+	if !ok {
+		return ctrl.Result{}, fmt.Errorf("configuration %q has no remote cluster ID label", req.NamespacedName)
+	}
+
+	var policies networkingv1beta1.PeeringNetworkPolicyList
+	if err := r.Client.List(ctx, &policies); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to list the peering network policies: %w", err)
+	}
+	slices.SortFunc(policies.Items, func(a, b networkingv1beta1.PeeringNetworkPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	desired := sets.New[string]()
+	if len(policies.Items) > 0 {
+		for _, family := range cidrutils.GetFamilies(cfg.Spec.Remote.CIDR.Pod) {
+			spec, err := forgeFirewallConfigurationSpec(ctx, r.Client, cfg, policies.Items, family)
+			if err != nil {
+				r.EventsRecorder.Eventf(cfg, corev1.EventTypeWarning, "PeeringNetworkPolicyFailed",
+					"Unable to compile the peering network policies: %v", err)
+				return ctrl.Result{}, err
+			}
+
+			fwcfg := &networkingv1beta1.FirewallConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-%s", cfg.Name, getTableName(family)),
+					Namespace: cfg.Namespace,
+				},
+			}
+			op, err := resource.CreateOrUpdate(ctx, r.Client, fwcfg, func() error {
+				fwcfg.SetLabels(forgeFirewallConfigurationLabels(remoteClusterID))
+				fwcfg.Spec = *spec
+				return controllerutil.SetOwnerReference(cfg, fwcfg, r.Scheme)
+			})
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to enforce firewall configuration %q: %w", fwcfg.Name, err)
+			}
+			if op != controllerutil.OperationResultNone {
+				klog.Infof("Firewall configuration %q enforcing the peering network policies %s", fwcfg.Name, op)
+			}
+			desired.Insert(fwcfg.Name)
+		}
+	}
+
+	if err := r.ensureStaleFirewallConfigurationsAbsence(ctx, cfg, remoteClusterID, desired); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// ensureStaleFirewallConfigurationsAbsence deletes the firewall configurations enforcing the policies towards
+// the remote cluster which are no longer required (e.g., because all the policies have been deleted).
+func (r *PeeringNetworkPolicyReconciler) ensureStaleFirewallConfigurationsAbsence(ctx context.Context,
+	cfg *networkingv1beta1.Configuration, remoteClusterID string, desired sets.Set[string]) error {
+	fwcfgs, err := getters.ListFirewallConfigurationsInNamespaceByLabel(ctx, r.Client, cfg.Namespace,
+		labels.SelectorFromSet(forgeFirewallConfigurationLabels(remoteClusterID)))
+	if err != nil {
+		return fmt.Errorf("unable to list firewall configurations: %w", err)
+	}
+	for i := range fwcfgs.Items {
+		if desired.Has(fwcfgs.Items[i].Name) {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Client.Delete(ctx, &fwcfgs.Items[i])); err != nil {
+			return fmt.Errorf("unable to delete firewall configuration %q: %w", fwcfgs.Items[i].Name, err)
+		}
+		klog.Infof("Firewall configuration %q enforcing the peering network policies deleted", fwcfgs.Items[i].Name)
+	}
+	return nil
+}
+
+// forgeFirewallConfigurationLabels returns the labels of the firewall configurations enforcing
+// the policies, targeting the gateway towards the given remote cluster.
+func forgeFirewallConfigurationLabels(remoteClusterID string) map[string]string {
+	return labels.Merge(remapping.ForgeFirewallTargetLabels(remoteClusterID), map[string]string{
+		PeeringNetworkPolicyLabel: PeeringNetworkPolicyLabelValue,
+	})
+}
+
+// SetupWithManager register the PeeringNetworkPolicyReconciler to the manager.
+func (r *PeeringNetworkPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	filterByLabelsPredicate, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			configuration.Configured: configuration.ConfiguredValue,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	enqueuer := handler.EnqueueRequestsFromMapFunc(r.configurationsEnqueuer)
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlConfigurationNetPolicy).
+		For(&networkingv1beta1.Configuration{}, builder.WithPredicates(filterByLabelsPredicate)).
+		Owns(&networkingv1beta1.FirewallConfiguration{}).
+		Watches(&networkingv1beta1.PeeringNetworkPolicy{}, enqueuer).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.podEnqueuer), builder.WithPredicates(podPredicate())).
+		Watches(&corev1.Namespace{}, enqueuer, builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Complete(r)
+}
+
+// configurationsEnqueuer enqueues all the configurations, as any change to the policies,
+// or to the namespaces they select, may affect the rules enforced towards every remote cluster.
+func (r *PeeringNetworkPolicyReconciler) configurationsEnqueuer(ctx context.Context, _ client.Object) []reconcile.Request {
+	return r.enqueueConfigurations(ctx, map[string]string{configuration.Configured: configuration.ConfiguredValue})
+}
+
+// podEnqueuer enqueues the configurations affected by a change of the given pod, according to the policies referring to it.
+// A local pod selected by a policy affects the rules enforced towards every remote cluster, while an offloaded pod only
+// affects the ones towards the remote clusters whose peers include its namespace. Pods not referred to by any policy
+// are ignored.
+func (r *PeeringNetworkPolicyReconciler) podEnqueuer(ctx context.Context, obj client.Object) []reconcile.Request {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+
+	var policies networkingv1beta1.PeeringNetworkPolicyList
+	if err := r.Client.List(ctx, &policies); err != nil {
+		klog.Errorf("Unable to list the peering network policies: %v", err)
+		return nil
+	}
+	if len(policies.Items) == 0 {
+		return nil
+	}
+
+	if pod.Labels[consts.LocalPodLabelKey] != consts.LocalPodLabelValue {
+		if pod.Spec.HostNetwork {
+			return nil
+		}
+		var namespace corev1.Namespace
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: pod.Namespace}, &namespace); err != nil {
+			klog.Errorf("Unable to get the namespace %q: %v", pod.Namespace, err)
+			return nil
+		}
+		for i := range policies.Items {
+			selected, err := isPodSelected(&policies.Items[i], pod, &namespace)
+			if err != nil {
+				klog.Errorf("Unable to evaluate the selectors of policy %q: %v", policies.Items[i].Name, err)
+				continue
+			}
+			if selected {
+				return r.configurationsEnqueuer(ctx, obj)
+			}
+		}
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, clusterID := range sets.List(getPeerClusterIDs(policies.Items, pod.Namespace)) {
+		requests = append(requests, r.enqueueConfigurations(ctx, map[string]string{
+			configuration.Configured: configuration.ConfiguredValue,
+			consts.RemoteClusterID:   string(clusterID),
+		})...)
+	}
+	return requests
+}
+
+// enqueueConfigurations enqueues the configurations matching the given labels.
+func (r *PeeringNetworkPolicyReconciler) enqueueConfigurations(ctx context.Context, lbls map[string]string) []reconcile.Request {
+	cfgs, err := getters.ListConfigurationsByLabel(ctx, r.Client, labels.SelectorFromSet(lbls))
+	if err != nil {
+		klog.Errorf("Unable to list configurations: %v", err)
+		return nil
+	}
+	requests := make([]reconcile.Request, len(cfgs.Items))
+	for i := range cfgs.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfgs.Items[i])}
+	}
+	return requests
+}
+
+// podPredicate filters the pod events which may affect the addresses selected by the policies.
+func podPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+			newPod, okNew := e.ObjectNew.(*corev1.Pod)
+			if !okOld || !okNew {
+				return false
+			}
+			return !maps.Equal(oldPod.Labels, newPod.Labels) ||
+				!slices.Equal(oldPod.Status.PodIPs, newPod.Status.PodIPs) ||
+				oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+				isPodTerminated(oldPod) != isPodTerminated(newPod)
+		},
+	}
+}
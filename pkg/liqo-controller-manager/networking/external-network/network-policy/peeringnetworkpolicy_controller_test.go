@@ -0,0 +1,311 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+	configuration "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/configuration"
+)
+
+var _ = Describe("PeeringNetworkPolicy controller", func() {
+	const (
+		tenantNamespace = "liqo-tenant-cluster-b"
+		remoteClusterID = "cluster-b"
+		virtualNodeName = "liqo-cluster-b"
+	)
+
+	var (
+		ctx context.Context
+		cl  client.Client
+		r   *PeeringNetworkPolicyReconciler
+		cfg *networkingv1beta1.Configuration
+		req ctrl.Request
+
+		newNamespace = func(name string, lbls map[string]string) *corev1.Namespace {
+			return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: lbls}}
+		}
+
+		newPod = func(namespace, name, nodeName, ip string, lbls map[string]string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: lbls},
+				Spec:       corev1.PodSpec{NodeName: nodeName},
+				Status: corev1.PodStatus{
+					Phase:  corev1.PodRunning,
+					PodIP:  ip,
+					PodIPs: []corev1.PodIP{{IP: ip}},
+				},
+			}
+		}
+
+		getTable = func() *firewall.Table {
+			fwcfg := &networkingv1beta1.FirewallConfiguration{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: "configuration-" + TableName, Namespace: tenantNamespace}, fwcfg)).To(Succeed())
+			Expect(fwcfg.Labels).To(HaveKeyWithValue(PeeringNetworkPolicyLabel, PeeringNetworkPolicyLabelValue))
+			Expect(fwcfg.Spec.Table.Family).To(PointTo(Equal(firewall.TableFamilyIPv4)))
+			Expect(fwcfg.Spec.Table.Chains).To(HaveLen(1))
+			return &fwcfg.Spec.Table
+		}
+
+		// getRuleSets returns the elements of the sets referenced by the source and destination IP matches of the rule.
+		getRuleSets = func(table *firewall.Table, rule *firewall.FilterRule) (src, dst []string) {
+			for i := range rule.Match {
+				if rule.Match[i].IP == nil {
+					continue
+				}
+				Expect(rule.Match[i].IP.Value).To(HavePrefix("@"))
+				var elements []string
+				for j := range table.Sets {
+					if "@"+table.Sets[j].Name == rule.Match[i].IP.Value {
+						for k := range table.Sets[j].Elements {
+							elements = append(elements, table.Sets[j].Elements[k].Key)
+						}
+					}
+				}
+				if rule.Match[i].IP.Position == firewall.MatchPositionSrc {
+					src = elements
+				} else {
+					dst = elements
+				}
+			}
+			return src, dst
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		cfg = &networkingv1beta1.Configuration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "configuration",
+				Namespace: tenantNamespace,
+				Labels: map[string]string{
+					consts.RemoteClusterID:   remoteClusterID,
+					configuration.Configured: configuration.ConfiguredValue,
+				},
+			},
+			Spec: networkingv1beta1.ConfigurationSpec{
+				Remote: networkingv1beta1.ClusterConfig{CIDR: networkingv1beta1.ClusterConfigCIDR{
+					Pod:      []networkingv1beta1.CIDR{"10.200.0.0/16"},
+					External: []networkingv1beta1.CIDR{"10.201.0.0/16"},
+				}},
+			},
+			Status: networkingv1beta1.ConfigurationStatus{
+				Remote: &networkingv1beta1.ClusterConfig{CIDR: networkingv1beta1.ClusterConfigCIDR{
+					Pod:      []networkingv1beta1.CIDR{"10.70.0.0/16"},
+					External: []networkingv1beta1.CIDR{"10.71.0.0/16"},
+				}},
+			},
+		}
+		req = ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cfg)}
+
+		cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			cfg,
+			newNamespace("ingress", map[string]string{"team": "ingress"}),
+			newNamespace("backend", nil),
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: virtualNodeName, Labels: map[string]string{consts.RemoteClusterID: remoteClusterID},
+			}},
+			newPod("ingress", "web", "node", "10.0.0.5", map[string]string{"app": "web"}),
+			newPod("backend", "db", "node", "10.0.0.6", map[string]string{"app": "db"}),
+			newPod("ingress", "offloaded", virtualNodeName, "10.70.1.2", map[string]string{
+				consts.LocalPodLabelKey: consts.LocalPodLabelValue,
+			}),
+		).Build()
+		r = NewPeeringNetworkPolicyReconciler(cl, scheme, record.NewFakeRecorder(10))
+	})
+
+	When("no policy exists", func() {
+		It("should not create any firewall configuration", func() {
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			var fwcfgs networkingv1beta1.FirewallConfigurationList
+			Expect(cl.List(ctx, &fwcfgs)).To(Succeed())
+			Expect(fwcfgs.Items).To(BeEmpty())
+		})
+	})
+
+	When("a policy allows the ingress traffic from the remote cluster to a namespace", func() {
+		BeforeEach(func() {
+			Expect(cl.Create(ctx, &networkingv1beta1.PeeringNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "allow-ingress"},
+				Spec: networkingv1beta1.PeeringNetworkPolicySpec{
+					NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "ingress"}},
+					Ingress: []networkingv1beta1.PeeringNetworkPolicyRule{{
+						Clusters: []networkingv1beta1.PeeringNetworkPolicyPeer{{ClusterID: remoteClusterID}},
+						Ports:    []networkingv1beta1.PeeringNetworkPolicyPort{{Protocol: corev1.ProtocolTCP, Port: 80}},
+					}},
+				},
+			})).To(Succeed())
+		})
+
+		It("should accept the allowed traffic and isolate the selected pods", func() {
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			table := getTable()
+			rules := table.Chains[0].Rules.FilterRules
+			Expect(rules).To(HaveLen(4))
+			Expect(rules[0].Name).To(PointTo(Equal(establishedRuleName)))
+
+			// The offloaded pod is not selected, as it is executed in the remote cluster.
+			Expect(rules[1].Name).To(PointTo(Equal("allow-ingress-ingress-0-tcp")))
+			Expect(rules[1].Action).To(Equal(firewall.ActionAccept))
+			src, dst := getRuleSets(table, &rules[1])
+			Expect(src).To(ConsistOf("10.200.0.0/16", "10.201.0.0/16"))
+			Expect(dst).To(ConsistOf("10.0.0.5"))
+			Expect(rules[1].Match).To(ContainElement(
+				firewall.Match{Op: firewall.MatchOperationEq, Dev: &firewall.MatchDev{
+					Value: tunnel.TunnelInterfaceName, Position: firewall.MatchDevPositionIn}},
+			))
+
+			Expect(rules[2].Name).To(PointTo(Equal("isolate-ingress")))
+			Expect(rules[2].Action).To(Equal(firewall.ActionDrop))
+			_, dst = getRuleSets(table, &rules[2])
+			Expect(dst).To(ConsistOf("10.0.0.5"))
+
+			Expect(rules[3].Name).To(PointTo(Equal("isolate-egress")))
+			src, _ = getRuleSets(table, &rules[3])
+			Expect(src).To(BeEmpty())
+		})
+
+		It("should only update the sets when the selected pods change", func() {
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			before := getTable().Chains[0].Rules.FilterRules
+
+			Expect(cl.Create(ctx, newPod("ingress", "web-2", "node", "10.0.0.4", nil))).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			table := getTable()
+			Expect(table.Chains[0].Rules.FilterRules).To(Equal(before))
+			_, dst := getRuleSets(table, &before[1])
+			Expect(dst).To(ConsistOf("10.0.0.4", "10.0.0.5"))
+		})
+
+		It("should delete the firewall configuration once the policy is deleted", func() {
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cl.Delete(ctx, &networkingv1beta1.PeeringNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "allow-ingress"},
+			})).To(Succeed())
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			var fwcfgs networkingv1beta1.FirewallConfigurationList
+			Expect(cl.List(ctx, &fwcfgs)).To(Succeed())
+			Expect(fwcfgs.Items).To(BeEmpty())
+		})
+	})
+
+	When("a policy allows the egress traffic towards the pods offloaded to the remote cluster", func() {
+		BeforeEach(func() {
+			Expect(cl.Create(ctx, &networkingv1beta1.PeeringNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "allow-egress"},
+				Spec: networkingv1beta1.PeeringNetworkPolicySpec{
+					PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+					PolicyTypes: []networkingv1beta1.PeeringNetworkPolicyType{networkingv1beta1.PeeringNetworkPolicyTypeEgress},
+					Egress: []networkingv1beta1.PeeringNetworkPolicyRule{{
+						Clusters: []networkingv1beta1.PeeringNetworkPolicyPeer{
+							{ClusterID: remoteClusterID, Namespaces: []string{"ingress"}},
+							{ClusterID: "cluster-c"},
+						},
+						Ports: []networkingv1beta1.PeeringNetworkPolicyPort{
+							{Protocol: corev1.ProtocolUDP, Port: 5000, EndPort: ptr.To[int32](5010)},
+						},
+					}},
+				},
+			})).To(Succeed())
+		})
+
+		It("should translate the remapped addresses of the offloaded pods", func() {
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			table := getTable()
+			rules := table.Chains[0].Rules.FilterRules
+			Expect(rules).To(HaveLen(4))
+
+			Expect(rules[1].Name).To(PointTo(Equal("allow-egress-egress-0-udp")))
+			src, dst := getRuleSets(table, &rules[1])
+			Expect(src).To(ConsistOf("10.0.0.6"))
+			Expect(dst).To(ConsistOf("10.200.1.2"))
+
+			var ports []firewall.SetElement
+			for i := range rules[1].Match {
+				if rules[1].Match[i].Port == nil {
+					continue
+				}
+				Expect(rules[1].Match[i].Proto).To(PointTo(Equal(firewall.MatchProto{Value: firewall.L4ProtoUDP})))
+				for j := range table.Sets {
+					if "@"+table.Sets[j].Name == rules[1].Match[i].Port.Value {
+						Expect(table.Sets[j].KeyType).To(Equal(firewall.SetDataTypeInetService))
+						ports = table.Sets[j].Elements
+					}
+				}
+			}
+			Expect(ports).To(ConsistOf(firewall.SetElement{Key: "5000-5010"}))
+
+			Expect(rules[3].Name).To(PointTo(Equal("isolate-egress")))
+			src, _ = getRuleSets(table, &rules[3])
+			Expect(src).To(ConsistOf("10.0.0.6"))
+		})
+
+		DescribeTable("should enqueue the configuration only for the pods referred to by the policy",
+			func(pod *corev1.Pod, expected []ctrl.Request) {
+				Expect(r.podEnqueuer(ctx, pod)).To(Equal(expected))
+			},
+			Entry("local pod selected by the policy", newPod("backend", "db", "node", "10.0.0.6", map[string]string{"app": "db"}),
+				[]ctrl.Request{{NamespacedName: client.ObjectKey{Name: "configuration", Namespace: tenantNamespace}}}),
+			Entry("local pod not selected by the policy", newPod("ingress", "web", "node", "10.0.0.5", map[string]string{"app": "web"}),
+				nil),
+			Entry("offloaded pod in a namespace of a peer", newPod("ingress", "offloaded", virtualNodeName, "10.70.1.2",
+				map[string]string{consts.LocalPodLabelKey: consts.LocalPodLabelValue}),
+				[]ctrl.Request{{NamespacedName: client.ObjectKey{Name: "configuration", Namespace: tenantNamespace}}}),
+			Entry("offloaded pod in a namespace of no peer", newPod("backend", "offloaded", virtualNodeName, "10.70.1.3",
+				map[string]string{consts.LocalPodLabelKey: consts.LocalPodLabelValue}),
+				nil),
+		)
+	})
+})
+
+var _ = DescribeTable("translateIP",
+	func(ip, from, to, expected string) {
+		translated, err := translateIP(ip, from, to)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(translated).To(Equal(expected))
+	},
+	Entry("IPv4 address", "10.70.1.2", "10.70.0.0/16", "10.200.0.0/16", "10.200.1.2"),
+	Entry("IPv6 address", "fd70::1:2", "fd70::/64", "fd00:200::/64", "fd00:200::1:2"),
+	Entry("address outside the source CIDR", "10.0.0.1", "10.70.0.0/16", "10.200.0.0/16", "10.0.0.1"),
+)
@@ -0,0 +1,226 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	cidrutils "github.com/liqotech/liqo/pkg/utils/cidr"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	"github.com/liqotech/liqo/pkg/utils/ipam/mapping"
+)
+
+// getPolicyTypes returns the directions in which the pods selected by the policy are isolated.
+func getPolicyTypes(policy *networkingv1beta1.PeeringNetworkPolicy) []networkingv1beta1.PeeringNetworkPolicyType {
+	if len(policy.Spec.PolicyTypes) > 0 {
+		return policy.Spec.PolicyTypes
+	}
+	types := []networkingv1beta1.PeeringNetworkPolicyType{networkingv1beta1.PeeringNetworkPolicyTypeIngress}
+	if len(policy.Spec.Egress) > 0 {
+		types = append(types, networkingv1beta1.PeeringNetworkPolicyTypeEgress)
+	}
+	return types
+}
+
+// getSelectedPodIPs returns the sorted addresses of the given family of the local pods selected by the policy.
+// Offloaded pods and pods sharing the host network are ignored, as their traffic is not handled by the local gateway.
+func getSelectedPodIPs(ctx context.Context, cl client.Client, policy *networkingv1beta1.PeeringNetworkPolicy,
+	family corev1.IPFamily) ([]string, error) {
+	nsSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+	podSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod selector: %w", err)
+	}
+	// Offloaded pods are excluded, as they are executed in the remote clusters.
+	offloaded, err := labels.NewRequirement(consts.LocalPodLabelKey, "!=", []string{consts.LocalPodLabelValue})
+	if err != nil {
+		return nil, err
+	}
+	podSelector = podSelector.Add(*offloaded)
+
+	var namespaces corev1.NamespaceList
+	if err := cl.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: nsSelector}); err != nil {
+		return nil, fmt.Errorf("unable to list namespaces: %w", err)
+	}
+
+	ips := sets.New[string]()
+	for i := range namespaces.Items {
+		var pods corev1.PodList
+		if err := cl.List(ctx, &pods, client.InNamespace(namespaces.Items[i].Name),
+			client.MatchingLabelsSelector{Selector: podSelector}); err != nil {
+			return nil, fmt.Errorf("unable to list pods in namespace %q: %w", namespaces.Items[i].Name, err)
+		}
+		for j := range pods.Items {
+			if pods.Items[j].Spec.HostNetwork || isPodTerminated(&pods.Items[j]) {
+				continue
+			}
+			ips.Insert(getPodIPsByFamily(&pods.Items[j], family)...)
+		}
+	}
+	return sets.List(ips), nil
+}
+
+// getPeerAddresses returns the sorted addresses (or CIDRs) of the given family identifying the peer in the gateway,
+// where the traffic is filtered before (after) being remapped towards (from) the remote cluster.
+func getPeerAddresses(ctx context.Context, cl client.Client, cfg *networkingv1beta1.Configuration,
+	peer *networkingv1beta1.PeeringNetworkPolicyPeer, family corev1.IPFamily) ([]string, error) {
+	if len(peer.Namespaces) == 0 {
+		var cidrs []string
+		for _, cidr := range []*networkingv1beta1.CIDR{
+			cidrutils.GetByFamily(cfg.Spec.Remote.CIDR.Pod, family),
+			cidrutils.GetByFamily(cfg.Spec.Remote.CIDR.External, family),
+		} {
+			if !cidrutils.IsVoid(cidr) {
+				cidrs = append(cidrs, cidr.String())
+			}
+		}
+		return cidrs, nil
+	}
+
+	virtualNodes, err := getVirtualNodeNames(ctx, cl, peer.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The offloaded pods are exposed locally with the remapped addresses, which need to be
+	// translated back to the ones of the remote pod CIDR.
+	var remapped *networkingv1beta1.CIDR
+	if cfg.Status.Remote != nil {
+		remapped = cidrutils.GetByFamily(cfg.Status.Remote.CIDR.Pod, family)
+	}
+	original := cidrutils.GetByFamily(cfg.Spec.Remote.CIDR.Pod, family)
+	if cidrutils.IsVoid(remapped) || cidrutils.IsVoid(original) {
+		return nil, nil
+	}
+
+	ips := sets.New[string]()
+	for _, namespace := range peer.Namespaces {
+		pods, err := getters.ListOffloadedPods(ctx, cl, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list offloaded pods in namespace %q: %w", namespace, err)
+		}
+		for i := range pods.Items {
+			if !virtualNodes.Has(pods.Items[i].Spec.NodeName) || isPodTerminated(&pods.Items[i]) {
+				continue
+			}
+			for _, ip := range getPodIPsByFamily(&pods.Items[i], family) {
+				translated, err := translateIP(ip, remapped.String(), original.String())
+				if err != nil {
+					return nil, err
+				}
+				ips.Insert(translated)
+			}
+		}
+	}
+	return sets.List(ips), nil
+}
+
+// isPodSelected returns whether the given local pod, belonging to the given namespace, is selected by the policy.
+func isPodSelected(policy *networkingv1beta1.PeeringNetworkPolicy, pod *corev1.Pod, namespace *corev1.Namespace) (bool, error) {
+	nsSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+	podSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid pod selector: %w", err)
+	}
+	return nsSelector.Matches(labels.Set(namespace.Labels)) && podSelector.Matches(labels.Set(pod.Labels)), nil
+}
+
+// getPeerClusterIDs returns the IDs of the remote clusters whose peers, in any rule of the given policies,
+// select the pods offloaded from the given namespace.
+func getPeerClusterIDs(policies []networkingv1beta1.PeeringNetworkPolicy, namespace string) sets.Set[liqov1beta1.ClusterID] {
+	clusterIDs := sets.New[liqov1beta1.ClusterID]()
+	for i := range policies {
+		for _, policyRules := range [][]networkingv1beta1.PeeringNetworkPolicyRule{policies[i].Spec.Ingress, policies[i].Spec.Egress} {
+			for j := range policyRules {
+				for k := range policyRules[j].Clusters {
+					if slices.Contains(policyRules[j].Clusters[k].Namespaces, namespace) {
+						clusterIDs.Insert(policyRules[j].Clusters[k].ClusterID)
+					}
+				}
+			}
+		}
+	}
+	return clusterIDs
+}
+
+// getVirtualNodeNames returns the names of the virtual nodes targeting the given remote cluster.
+func getVirtualNodeNames(ctx context.Context, cl client.Client, clusterID liqov1beta1.ClusterID) (sets.Set[string], error) {
+	nodes, err := getters.ListNodesByClusterID(ctx, cl, clusterID)
+	switch {
+	case apierrors.IsNotFound(err):
+		return sets.New[string](), nil
+	case err != nil:
+		return nil, fmt.Errorf("unable to list virtual nodes of cluster %q: %w", clusterID, err)
+	}
+	names := sets.New[string]()
+	for i := range nodes.Items {
+		names.Insert(nodes.Items[i].Name)
+	}
+	return names, nil
+}
+
+func isPodTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+func getPodIPsByFamily(pod *corev1.Pod, family corev1.IPFamily) []string {
+	var ips []string
+	for _, ip := range pod.Status.PodIPs {
+		if cidrutils.GetFamily(cidrutils.HostCIDR(ip.IP)) == family {
+			ips = append(ips, ip.IP)
+		}
+	}
+	return ips
+}
+
+// translateIP maps the given address from the source CIDR to the destination one, preserving its host part.
+// Addresses not belonging to the source CIDR are returned unchanged.
+func translateIP(ip, from, to string) (string, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return "", fmt.Errorf("invalid IP %q", ip)
+	}
+	_, fromNet, err := net.ParseCIDR(from)
+	if err != nil {
+		return "", err
+	}
+	_, toNet, err := net.ParseCIDR(to)
+	if err != nil {
+		return "", err
+	}
+	if !fromNet.Contains(addr) || fromNet.String() == toNet.String() {
+		return ip, nil
+	}
+	return mapping.RemapMask(addr, *toNet).String(), nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
@@ -44,18 +45,27 @@ const (
 )
 
 // CreateOrUpdateNatMappingCIDR creates or updates the NAT mapping for a CIDR type.
+// A separate FirewallConfiguration is created for each IP family of the CIDR (dual-stack clusters).
 func CreateOrUpdateNatMappingCIDR(ctx context.Context, cl client.Client, opts *Options,
 	cfg *networkingv1beta1.Configuration, scheme *runtime.Scheme, cidrtype CIDRType) error {
-	var tableCIDRName string
-	switch cidrtype {
-	case PodCIDR:
-		tableCIDRName = TablePodCIDRName
-	case ExternalCIDR:
-		tableCIDRName = TableExternalCIDRName
+	remoteCIDRs, remoteRemapCIDRs := getRemoteCIDRs(cfg, cidrtype)
+	for _, family := range cidrutils.GetFamilies(remoteCIDRs) {
+		if cidrutils.IsVoid(cidrutils.GetByFamily(remoteRemapCIDRs, family)) {
+			klog.V(4).Infof("Skipping %s NAT mapping for %q: %s CIDR not yet remapped", family, cfg.Name, cidrtype)
+			continue
+		}
+		if err := createOrUpdateNatMappingCIDRFamily(ctx, cl, opts, cfg, scheme, cidrtype, family); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+func createOrUpdateNatMappingCIDRFamily(ctx context.Context, cl client.Client, opts *Options,
+	cfg *networkingv1beta1.Configuration, scheme *runtime.Scheme, cidrtype CIDRType, family corev1.IPFamily) error {
 	fwcfg := &networkingv1beta1.FirewallConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-%s", cfg.Name, tableCIDRName),
+			Name:      fmt.Sprintf("%s-%s", cfg.Name, getTableCIDRName(cidrtype, family)),
 			Namespace: cfg.Namespace,
 		},
 	}
@@ -64,7 +74,7 @@ func CreateOrUpdateNatMappingCIDR(ctx context.Context, cl client.Client, opts *O
 
 	if _, err := resource.CreateOrUpdate(
 		ctx, cl, fwcfg,
-		mutateCIDRFirewallConfiguration(fwcfg, cfg, opts, scheme, cidrtype),
+		mutateCIDRFirewallConfiguration(fwcfg, cfg, opts, scheme, cidrtype, family),
 	); err != nil {
 		return err
 	}
@@ -74,42 +84,93 @@ func CreateOrUpdateNatMappingCIDR(ctx context.Context, cl client.Client, opts *O
 	return nil
 }
 
+// getTableCIDRName returns the name of the table managing the NAT mapping for the given CIDR type and IP family.
+// The IPv4 table keeps the plain name, while the IPv6 one is suffixed, as they coexist in dual-stack clusters.
+func getTableCIDRName(cidrtype CIDRType, family corev1.IPFamily) string {
+	var tableCIDRName string
+	switch cidrtype {
+	case PodCIDR:
+		tableCIDRName = TablePodCIDRName
+	case ExternalCIDR:
+		tableCIDRName = TableExternalCIDRName
+	}
+	if family == corev1.IPv6Protocol {
+		tableCIDRName += TableIPv6Suffix
+	}
+	return tableCIDRName
+}
+
+// getRemoteCIDRs returns the original and the remapped remote CIDRs of the given type.
+func getRemoteCIDRs(cfg *networkingv1beta1.Configuration, cidrtype CIDRType) (remoteCIDRs, remoteRemapCIDRs []networkingv1beta1.CIDR) {
+	switch cidrtype {
+	case PodCIDR:
+		remoteCIDRs = cfg.Spec.Remote.CIDR.Pod
+		if cfg.Status.Remote != nil {
+			remoteRemapCIDRs = cfg.Status.Remote.CIDR.Pod
+		}
+	case ExternalCIDR:
+		remoteCIDRs = cfg.Spec.Remote.CIDR.External
+		if cfg.Status.Remote != nil {
+			remoteRemapCIDRs = cfg.Status.Remote.CIDR.External
+		}
+	}
+	return remoteCIDRs, remoteRemapCIDRs
+}
+
+// getRemoteCIDRsByFamily returns the original and the remapped remote CIDRs of the given type and IP family.
+func getRemoteCIDRsByFamily(cfg *networkingv1beta1.Configuration, cidrtype CIDRType, family corev1.IPFamily) (remoteCIDR, remoteRemapCIDR string) {
+	remoteCIDRs, remoteRemapCIDRs := getRemoteCIDRs(cfg, cidrtype)
+	if cidr := cidrutils.GetByFamily(remoteCIDRs, family); cidr != nil {
+		remoteCIDR = cidr.String()
+	}
+	if cidr := cidrutils.GetByFamily(remoteRemapCIDRs, family); cidr != nil {
+		remoteRemapCIDR = cidr.String()
+	}
+	return remoteCIDR, remoteRemapCIDR
+}
+
 func mutateCIDRFirewallConfiguration(fwcfg *networkingv1beta1.FirewallConfiguration, cfg *networkingv1beta1.Configuration,
-	opts *Options, scheme *runtime.Scheme, cidrtype CIDRType) func() error {
+	opts *Options, scheme *runtime.Scheme, cidrtype CIDRType, family corev1.IPFamily) func() error {
 	return func() error {
 		if cfg.Labels == nil {
 			return fmt.Errorf("configuration %q has no labels", cfg.Name)
 		}
 		remoteClusterID := cfg.Labels[string(consts.RemoteClusterID)]
 		fwcfg.SetLabels(ForgeFirewallTargetLabels(remoteClusterID))
-		fwcfg.Spec = forgeCIDRFirewallConfigurationSpec(cfg, opts, cidrtype)
+		fwcfg.Spec = forgeCIDRFirewallConfigurationSpec(cfg, opts, cidrtype, family)
 		return controllerutil.SetOwnerReference(cfg, fwcfg, scheme)
 	}
 }
 
 func forgeCIDRFirewallConfigurationSpec(cfg *networkingv1beta1.Configuration, opts *Options,
-	cidrtype CIDRType) networkingv1beta1.FirewallConfigurationSpec {
-	var tableCIDRName string
-	switch cidrtype {
-	case PodCIDR:
-		tableCIDRName = TablePodCIDRName
-	case ExternalCIDR:
-		tableCIDRName = TableExternalCIDRName
+	cidrtype CIDRType, family corev1.IPFamily) networkingv1beta1.FirewallConfigurationSpec {
+	remoteCIDR, remoteRemapCIDR := getRemoteCIDRsByFamily(cfg, cidrtype, family)
+	return forgeNatMappingFirewallConfigurationSpec(getTableCIDRName(cidrtype, family), family,
+		[]firewall.NatRule{forgeCIDRFirewallConfigurationDNATRule(opts, remoteCIDR, remoteRemapCIDR)},
+		[]firewall.NatRule{forgeCIDRFirewallConfigurationSNATRule(opts, remoteCIDR, remoteRemapCIDR)})
+}
+
+// forgeNatMappingFirewallConfigurationSpec forges the spec of a FirewallConfiguration mapping remote CIDRs to the remapped ones.
+func forgeNatMappingFirewallConfigurationSpec(tableName string, family corev1.IPFamily,
+	dnatRules, snatRules []firewall.NatRule) networkingv1beta1.FirewallConfigurationSpec {
+	tableFamily := firewall.TableFamilyIPv4
+	if family == corev1.IPv6Protocol {
+		tableFamily = firewall.TableFamilyIPv6
 	}
 
 	return networkingv1beta1.FirewallConfigurationSpec{
 		Table: firewall.Table{
-			Name:   &tableCIDRName,
-			Family: ptr.To(firewall.TableFamilyIPv4),
+			Name:   ptr.To(tableName),
+			Family: ptr.To(tableFamily),
 			Chains: []firewall.Chain{
-				forgeCIDRFirewallConfigurationDNATChain(cfg, opts, cidrtype),
-				forgeCIDRFirewallConfigurationSNATChain(cfg, opts, cidrtype),
+				forgeCIDRFirewallConfigurationDNATChain(dnatRules),
+				forgeCIDRFirewallConfigurationSNATChain(snatRules),
 			},
 		},
 	}
 }
 
-func forgeCIDRFirewallConfigurationDNATChain(cfg *networkingv1beta1.Configuration, opts *Options, cidrtype CIDRType) firewall.Chain {
+func forgeCIDRFirewallConfigurationDNATChain(rules []firewall.NatRule) firewall.Chain {
 	return firewall.Chain{
 		Name:     &DNATChainName,
 		Policy:   ptr.To(firewall.ChainPolicyAccept),
@@ -117,13 +178,12 @@ func forgeCIDRFirewallConfigurationDNATChain(cfg *networkingv1beta1.Configuratio
 		Hook:     &firewall.ChainHookPrerouting,
 		Priority: &firewall.ChainPriorityNATDest,
 		Rules: firewall.RulesSet{
-			NatRules: forgeCIDRFirewallConfigurationDNATRules(cfg, opts, cidrtype),
+			NatRules: rules,
 		},
 	}
 }
 
-func forgeCIDRFirewallConfigurationSNATChain(cfg *networkingv1beta1.Configuration,
-	opts *Options, cidrtype CIDRType) firewall.Chain {
+func forgeCIDRFirewallConfigurationSNATChain(rules []firewall.NatRule) firewall.Chain {
 	return firewall.Chain{
 		Name:     &SNATChainName,
 		Policy:   ptr.To(firewall.ChainPolicyAccept),
@@ -131,89 +191,67 @@ func forgeCIDRFirewallConfigurationSNATChain(cfg *networkingv1beta1.Configuratio
 		Hook:     &firewall.ChainHookPostrouting,
 		Priority: &firewall.ChainPriorityNATSource,
 		Rules: firewall.RulesSet{
-			NatRules: forgeCIDRFirewallConfigurationSNATRules(cfg, opts, cidrtype),
+			NatRules: rules,
 		},
 	}
 }
 
-func forgeCIDRFirewallConfigurationDNATRules(cfg *networkingv1beta1.Configuration, opts *Options, cidrtype CIDRType) []firewall.NatRule {
-	var remoteCIDR, remoteRemapCIDR string
-	switch cidrtype {
-	case PodCIDR:
-		remoteCIDR = cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.Pod).String()
-		remoteRemapCIDR = cidrutils.GetPrimary(cfg.Status.Remote.CIDR.Pod).String()
-	case ExternalCIDR:
-		remoteCIDR = cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.External).String()
-		remoteRemapCIDR = cidrutils.GetPrimary(cfg.Status.Remote.CIDR.External).String()
-	}
-	return []firewall.NatRule{
-		{
-			NatType: firewall.NatTypeDestination,
-			Match: []firewall.Match{
-				{
-					Op: firewall.MatchOperationEq,
-					IP: &firewall.MatchIP{
-						Value:    remoteRemapCIDR,
-						Position: firewall.MatchPositionDst,
-					},
+// forgeCIDRFirewallConfigurationDNATRule translates the traffic directed to the remapped CIDR into the original remote one.
+func forgeCIDRFirewallConfigurationDNATRule(opts *Options, remoteCIDR, remoteRemapCIDR string) firewall.NatRule {
+	return firewall.NatRule{
+		NatType: firewall.NatTypeDestination,
+		Match: []firewall.Match{
+			{
+				Op: firewall.MatchOperationEq,
+				IP: &firewall.MatchIP{
+					Value:    remoteRemapCIDR,
+					Position: firewall.MatchPositionDst,
 				},
-				{
-					Op: firewall.MatchOperationNeq,
-					Dev: &firewall.MatchDev{
-						Value:    opts.DefaultInterfaceName,
-						Position: firewall.MatchDevPositionIn,
-					},
+			},
+			{
+				Op: firewall.MatchOperationNeq,
+				Dev: &firewall.MatchDev{
+					Value:    opts.DefaultInterfaceName,
+					Position: firewall.MatchDevPositionIn,
 				},
-				{
-					Op: firewall.MatchOperationNeq,
-					Dev: &firewall.MatchDev{
-						Value:    tunnel.TunnelInterfaceName,
-						Position: firewall.MatchDevPositionIn,
-					},
+			},
+			{
+				Op: firewall.MatchOperationNeq,
+				Dev: &firewall.MatchDev{
+					Value:    tunnel.TunnelInterfaceName,
+					Position: firewall.MatchDevPositionIn,
 				},
 			},
-			To: ptr.To(remoteCIDR),
 		},
+		To: ptr.To(remoteCIDR),
 	}
 }
 
-func forgeCIDRFirewallConfigurationSNATRules(cfg *networkingv1beta1.Configuration,
-	opts *Options, cidrtype CIDRType) []firewall.NatRule {
-	var remoteCIDR, remoteRemapCIDR string
-	switch cidrtype {
-	case PodCIDR:
-		remoteCIDR = cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.Pod).String()
-		remoteRemapCIDR = cidrutils.GetPrimary(cfg.Status.Remote.CIDR.Pod).String()
-	case ExternalCIDR:
-		remoteCIDR = cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.External).String()
-		remoteRemapCIDR = cidrutils.GetPrimary(cfg.Status.Remote.CIDR.External).String()
-	}
-
-	return []firewall.NatRule{
-		{
-			NatType: firewall.NatTypeSource,
-			To:      ptr.To(remoteRemapCIDR),
-			Match: []firewall.Match{
-				{
-					Op: firewall.MatchOperationNeq,
-					Dev: &firewall.MatchDev{
-						Value:    opts.DefaultInterfaceName,
-						Position: firewall.MatchDevPositionOut,
-					},
+// forgeCIDRFirewallConfigurationSNATRule translates the traffic coming from the original remote CIDR into the remapped one.
+func forgeCIDRFirewallConfigurationSNATRule(opts *Options, remoteCIDR, remoteRemapCIDR string) firewall.NatRule {
+	return firewall.NatRule{
+		NatType: firewall.NatTypeSource,
+		To:      ptr.To(remoteRemapCIDR),
+		Match: []firewall.Match{
+			{
+				Op: firewall.MatchOperationNeq,
+				Dev: &firewall.MatchDev{
+					Value:    opts.DefaultInterfaceName,
+					Position: firewall.MatchDevPositionOut,
 				},
-				{
-					Op: firewall.MatchOperationEq,
-					IP: &firewall.MatchIP{
-						Value:    remoteCIDR,
-						Position: firewall.MatchPositionSrc,
-					},
+			},
+			{
+				Op: firewall.MatchOperationEq,
+				IP: &firewall.MatchIP{
+					Value:    remoteCIDR,
+					Position: firewall.MatchPositionSrc,
 				},
-				{
-					Op: firewall.MatchOperationEq,
-					Dev: &firewall.MatchDev{
-						Value:    tunnel.TunnelInterfaceName,
-						Position: firewall.MatchDevPositionIn,
-					},
+			},
+			{
+				Op: firewall.MatchOperationEq,
+				Dev: &firewall.MatchDev{
+					Value:    tunnel.TunnelInterfaceName,
+					Position: firewall.MatchDevPositionIn,
 				},
 			},
 		},
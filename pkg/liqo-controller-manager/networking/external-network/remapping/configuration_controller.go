@@ -90,6 +90,10 @@ func (r *RemappingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	if err := EnforceNatMappingTransit(ctx, r.Client, r.Options, conf, r.Scheme); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
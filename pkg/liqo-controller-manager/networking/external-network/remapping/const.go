@@ -19,6 +19,10 @@ var (
 	TablePodCIDRName = "remap-podcidr"
 	// TableExternalCIDRName is the name of the table for the external CIDR.
 	TableExternalCIDRName = "remap-externalcidr"
+	// TableTransitPrefix is the prefix of the tables for the CIDRs of the clusters reachable in transit.
+	TableTransitPrefix = "remap-transit"
+	// TableIPv6Suffix is the suffix appended to the name of the tables managing IPv6 traffic.
+	TableIPv6Suffix = "-v6"
 	// TableIPMappingGwName is the name of the table for the IP mapping.
 	TableIPMappingGwName = "remap-ipmapping-gw"
 	// TableIPMappingFabricName is the name of the table for the IP mapping.
@@ -67,6 +67,18 @@ func (r *IPReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 	deleting := !ip.DeletionTimestamp.IsZero()
 	containsFinalizer := controllerutil.ContainsFinalizer(ip, ipMappingControllerFinalizer)
 
+	// The IPs of the offloaded pods are translated through the address maps enforced by the ipmapping controller,
+	// hence the per-IP NAT mapping possibly created by a previous version is removed.
+	if _, ok := ip.Labels[consts.OffloadedPodNameLabelKey]; ok {
+		if containsFinalizer {
+			if err := DeleteNatMappingIP(ctx, r.Client, ip); err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to delete the NAT mapping for the IP %q: %w", req.NamespacedName, err)
+			}
+			return ctrl.Result{}, r.ensureIPMappingFinalizerAbsence(ctx, ip)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	if deleting {
 		if containsFinalizer {
 			if err := DeleteNatMappingIP(ctx, r.Client, ip); err != nil {
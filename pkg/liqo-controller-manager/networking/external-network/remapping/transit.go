@@ -0,0 +1,133 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remapping
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	"github.com/liqotech/liqo/pkg/consts"
+	configuration "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/configuration"
+	cidrutils "github.com/liqotech/liqo/pkg/utils/cidr"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// EnforceNatMappingTransit creates or updates the NAT mapping for the clusters reachable in transit through the remote one,
+// and deletes the ones of the clusters no longer reachable.
+// A separate FirewallConfiguration is created for each transit cluster and IP family, including both the pod and external CIDRs.
+func EnforceNatMappingTransit(ctx context.Context, cl client.Client, opts *Options,
+	cfg *networkingv1beta1.Configuration, scheme *runtime.Scheme) error {
+	if cfg.Labels == nil {
+		return fmt.Errorf("configuration %q has no labels", cfg.Name)
+	}
+	remoteClusterID := cfg.Labels[consts.RemoteClusterID]
+
+	desired := sets.New[string]()
+	if cfg.Status.Remote != nil {
+		for i := range cfg.Spec.Remote.Transit {
+			transit := &cfg.Spec.Remote.Transit[i]
+			remapped := configuration.GetTransit(cfg.Status.Remote.Transit, transit.ClusterID)
+			if remapped == nil {
+				klog.V(4).Infof("Skipping transit NAT mapping for %q: cluster %s not yet remapped", cfg.Name, transit.ClusterID)
+				continue
+			}
+			for _, family := range cidrutils.GetFamilies(slices.Concat(transit.CIDR.Pod, transit.CIDR.External)) {
+				dnatRules, snatRules := forgeTransitNatRules(opts, transit, remapped, family)
+				if len(dnatRules) == 0 {
+					continue
+				}
+				fwcfg := &networkingv1beta1.FirewallConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("%s-%s", cfg.Name, getTableTransitName(transit, family)),
+						Namespace: cfg.Namespace,
+					},
+				}
+				if _, err := resource.CreateOrUpdate(ctx, cl, fwcfg, func() error {
+					fwcfg.SetLabels(labels.Merge(ForgeFirewallTargetLabels(remoteClusterID), map[string]string{
+						configuration.LabelTransitClusterID: string(transit.ClusterID),
+					}))
+					fwcfg.Spec = forgeNatMappingFirewallConfigurationSpec(getTableTransitName(transit, family), family, dnatRules, snatRules)
+					return controllerutil.SetOwnerReference(cfg, fwcfg, scheme)
+				}); err != nil {
+					return err
+				}
+				desired.Insert(fwcfg.Name)
+			}
+		}
+	}
+
+	return deleteStaleTransitFirewallConfigurations(ctx, cl, cfg, remoteClusterID, desired)
+}
+
+func deleteStaleTransitFirewallConfigurations(ctx context.Context, cl client.Client,
+	cfg *networkingv1beta1.Configuration, remoteClusterID string, desired sets.Set[string]) error {
+	selector := labels.SelectorFromSet(ForgeFirewallTargetLabels(remoteClusterID))
+	fwcfgs, err := getters.ListFirewallConfigurationsInNamespaceByLabel(ctx, cl, cfg.Namespace, selector)
+	if err != nil {
+		return fmt.Errorf("unable to list the firewall configurations of %q: %w", cfg.Name, err)
+	}
+	for i := range fwcfgs.Items {
+		fwcfg := &fwcfgs.Items[i]
+		if _, ok := fwcfg.Labels[configuration.LabelTransitClusterID]; !ok || desired.Has(fwcfg.Name) {
+			continue
+		}
+		if err := client.IgnoreNotFound(cl.Delete(ctx, fwcfg)); err != nil {
+			return fmt.Errorf("unable to delete the firewall configuration %q: %w", client.ObjectKeyFromObject(fwcfg), err)
+		}
+		klog.Infof("Firewall configuration %q deleted, as cluster %s is no longer reachable in transit",
+			client.ObjectKeyFromObject(fwcfg), fwcfg.Labels[configuration.LabelTransitClusterID])
+	}
+	return nil
+}
+
+// forgeTransitNatRules forges the NAT rules mapping the CIDRs of a cluster reachable in transit to the remapped ones.
+// No rules are forged for the CIDRs which did not need to be remapped.
+func forgeTransitNatRules(opts *Options, transit, remapped *networkingv1beta1.TransitConfig,
+	family corev1.IPFamily) (dnatRules, snatRules []firewall.NatRule) {
+	for _, cidrs := range [][2][]networkingv1beta1.CIDR{
+		{transit.CIDR.Pod, remapped.CIDR.Pod},
+		{transit.CIDR.External, remapped.CIDR.External},
+	} {
+		original, remap := cidrutils.GetByFamily(cidrs[0], family), cidrutils.GetByFamily(cidrs[1], family)
+		if original == nil || cidrutils.IsVoid(remap) || *original == *remap {
+			continue
+		}
+		dnatRules = append(dnatRules, forgeCIDRFirewallConfigurationDNATRule(opts, original.String(), remap.String()))
+		snatRules = append(snatRules, forgeCIDRFirewallConfigurationSNATRule(opts, original.String(), remap.String()))
+	}
+	return dnatRules, snatRules
+}
+
+// getTableTransitName returns the name of the table managing the NAT mapping of a cluster reachable in transit.
+func getTableTransitName(transit *networkingv1beta1.TransitConfig, family corev1.IPFamily) string {
+	tableName := fmt.Sprintf("%s-%s", TableTransitPrefix, transit.ClusterID)
+	if family == corev1.IPv6Protocol {
+		tableName += TableIPv6Suffix
+	}
+	return tableName
+}
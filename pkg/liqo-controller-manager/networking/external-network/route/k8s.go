@@ -17,7 +17,9 @@ package route
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -69,9 +71,12 @@ func enforceRouteConfigurationPresence(ctx context.Context, cl client.Client, sc
 		return nil
 	}
 
-	remoteInterfaceIP, err := tunnel.GetRemoteInterfaceIP(mode)
-	if err != nil {
-		return err
+	// The remote interface has an IP address for each family, to route the traffic towards the CIDRs of the same family.
+	remoteInterfaceIPs := map[corev1.IPFamily]string{}
+	for _, family := range []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol} {
+		if remoteInterfaceIPs[family], err = tunnel.GetRemoteInterfaceIPByFamily(mode, family); err != nil {
+			return err
+		}
 	}
 
 	routecfg := &networkingv1beta1.RouteConfiguration{
@@ -87,7 +92,7 @@ func enforceRouteConfigurationPresence(ctx context.Context, cl client.Client, sc
 	}
 
 	_, err = resource.CreateOrUpdate(ctx, cl, routecfg,
-		forgeMutateRouteConfiguration(cfg, routecfg, scheme, remoteClusterID, remoteInterfaceIP, internalNodes))
+		forgeMutateRouteConfiguration(cfg, routecfg, scheme, remoteClusterID, remoteInterfaceIPs, internalNodes))
 	return err
 }
 
@@ -95,7 +100,7 @@ func enforceRouteConfigurationPresence(ctx context.Context, cl client.Client, sc
 func forgeMutateRouteConfiguration(cfg *networkingv1beta1.Configuration,
 	routecfg *networkingv1beta1.RouteConfiguration, scheme *runtime.Scheme,
 	remoteClusterID liqov1beta1.ClusterID,
-	remoteInterfaceIP string, internalNodes *networkingv1beta1.InternalNodeList) func() error {
+	remoteInterfaceIPs map[corev1.IPFamily]string, internalNodes *networkingv1beta1.InternalNodeList) func() error {
 	return func() error {
 		var err error
 
@@ -111,30 +116,25 @@ func forgeMutateRouteConfiguration(cfg *networkingv1beta1.Configuration,
 			},
 		}
 
+		// A rule is configured for each remote CIDR, including the ones of both IP families in dual-stack clusters
+		// and the ones of the clusters reachable in transit through the remote one.
+		remoteCIDRs := slices.Concat(cfg.Spec.Remote.CIDR.Pod, cfg.Spec.Remote.CIDR.External)
+		for i := range cfg.Spec.Remote.Transit {
+			remoteCIDRs = slices.Concat(remoteCIDRs, cfg.Spec.Remote.Transit[i].CIDR.Pod, cfg.Spec.Remote.Transit[i].CIDR.External)
+		}
 		for i := range internalNodes.Items {
-			routecfg.Spec.Table.Rules = append(routecfg.Spec.Table.Rules,
-				[]networkingv1beta1.Rule{
-					{
-						Iif: &internalNodes.Items[i].Spec.Interface.Gateway.Name,
-						Dst: cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.Pod),
-						Routes: []networkingv1beta1.Route{
-							{
-								Dst: cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.Pod),
-								Gw:  ptr.To(networkingv1beta1.IP(remoteInterfaceIP)),
-							},
-						},
-					},
-					{
-						Iif: &internalNodes.Items[i].Spec.Interface.Gateway.Name,
-						Dst: cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.External),
-						Routes: []networkingv1beta1.Route{
-							{
-								Dst: cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.External),
-								Gw:  ptr.To(networkingv1beta1.IP(remoteInterfaceIP)),
-							},
+			for j := range remoteCIDRs {
+				routecfg.Spec.Table.Rules = append(routecfg.Spec.Table.Rules, networkingv1beta1.Rule{
+					Iif: &internalNodes.Items[i].Spec.Interface.Gateway.Name,
+					Dst: &remoteCIDRs[j],
+					Routes: []networkingv1beta1.Route{
+						{
+							Dst: &remoteCIDRs[j],
+							Gw:  ptr.To(networkingv1beta1.IP(remoteInterfaceIPs[cidrutils.GetFamily(remoteCIDRs[j])])),
 						},
 					},
-				}...)
+				})
+			}
 		}
 		return nil
 	}
@@ -31,6 +31,7 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
@@ -79,10 +80,15 @@ func NewServerReconciler(cl client.Client, dynClient dynamic.Interface,
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayservers,verbs=get;list;watch;delete;create;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayservers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=connections,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=wggatewayservers,verbs=get;list;watch;delete;create;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=wggatewayservers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=wggatewayservers/finalizers,verbs=update
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=wggatewayservertemplates,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayservers,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=ipsecgatewayservertemplates,verbs=get;list;watch;delete;create;update;patch
 
 // Reconcile manage GatewayServer lifecycle.
 func (r *ServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -247,6 +253,10 @@ func (r *ServerReconciler) EnsureGatewayServer(ctx context.Context, gwServer *ne
 		return fmt.Errorf("unable to update the server: %w", err)
 	}
 
+	if err := enutils.EnsureConnectionQualityThresholds(ctx, r.Client, gwServer, gwServer.Spec.QualityThresholds); err != nil {
+		return err
+	}
+
 	gwServer.Status.ServerRef = &corev1.ObjectReference{
 		APIVersion: unstructuredObject.GetAPIVersion(),
 		Kind:       unstructuredObject.GetKind(),
@@ -272,6 +282,12 @@ func (r *ServerReconciler) EnsureGatewayServer(ctx context.Context, gwServer *ne
 	if ok && internalEndpoint != nil {
 		gwServer.Status.InternalEndpoint = enutils.ParseInternalEndpoint(*internalEndpoint)
 	}
+	internalEndpoints, ok := enutils.GetIfExists[[]interface{}](status, "internalEndpoints")
+	if ok && internalEndpoints != nil {
+		gwServer.Status.InternalEndpoints = enutils.ParseInternalEndpoints(*internalEndpoints)
+	} else {
+		gwServer.Status.InternalEndpoints = nil
+	}
 
 	return nil
 }
@@ -292,5 +308,7 @@ func (r *ServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlGatewayServerExternal).
 		WatchesRawSource(factorySource.Source(ownerEnqueuer)).
 		For(&networkingv1beta1.GatewayServer{}).
+		Watches(&networkingv1beta1.Connection{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &networkingv1beta1.GatewayServer{})).
 		Complete(r)
 }
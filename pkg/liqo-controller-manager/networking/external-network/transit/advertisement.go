@@ -0,0 +1,190 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils"
+	foreignclusterutils "github.com/liqotech/liqo/pkg/utils/foreigncluster"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// advertisementName is the name of the TransitAdvertisement created in the tenant namespace of each remote cluster.
+const advertisementName = "transit"
+
+// enforceTransitAdvertisement ensures the TransitAdvertisement in the tenant namespace of the given remote cluster contains
+// the given CIDRs, so that they are replicated to the remote cluster, even if empty to withdraw the previous ones.
+func (r *TransitReconciler) enforceTransitAdvertisement(ctx context.Context, remoteClusterID liqov1beta1.ClusterID,
+	transits []networkingv1beta1.TransitConfig) error {
+	fc, err := foreignclusterutils.GetForeignClusterByID(ctx, r.Client, remoteClusterID)
+	switch {
+	case apierrors.IsNotFound(err):
+		klog.V(4).Infof("ForeignCluster %s not found, transit advertisement postponed", remoteClusterID)
+		return nil
+	case err != nil:
+		return fmt.Errorf("unable to get the ForeignCluster %s: %w", remoteClusterID, err)
+	}
+	if fc.Status.TenantNamespace.Local == "" {
+		klog.V(4).Infof("Tenant namespace of cluster %s not yet created, transit advertisement postponed", remoteClusterID)
+		return nil
+	}
+
+	adv := &networkingv1beta1.TransitAdvertisement{ObjectMeta: metav1.ObjectMeta{
+		Name:      advertisementName,
+		Namespace: fc.Status.TenantNamespace.Local,
+	}}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, adv, func() error {
+		adv.Labels = labels.Merge(adv.Labels, map[string]string{
+			consts.ReplicationRequestedLabel:   consts.ReplicationRequestedLabelValue,
+			consts.ReplicationDestinationLabel: string(remoteClusterID),
+			consts.RemoteClusterID:             string(remoteClusterID),
+		})
+		adv.Spec.Transit = transits
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to enforce the transit advertisement %q: %w", client.ObjectKeyFromObject(adv), err)
+	}
+	klog.V(utils.FromResult(result)).Infof("TransitAdvertisement %q successfully enforced (with %v operation)", klog.KObj(adv), result)
+	return nil
+}
+
+// ensureStaleAdvertisementsAbsence deletes the TransitAdvertisements towards the remote clusters whose configuration
+// no longer exists, so that the advertised CIDRs are withdrawn also from the remote clusters.
+func (r *TransitReconciler) ensureStaleAdvertisementsAbsence(ctx context.Context) error {
+	var advs networkingv1beta1.TransitAdvertisementList
+	if err := r.Client.List(ctx, &advs, client.MatchingLabels{
+		consts.ReplicationRequestedLabel: consts.ReplicationRequestedLabelValue,
+	}); err != nil {
+		return fmt.Errorf("unable to list the transit advertisements: %w", err)
+	}
+
+	for i := range advs.Items {
+		adv := &advs.Items[i]
+		remoteClusterID := liqov1beta1.ClusterID(adv.Labels[consts.RemoteClusterID])
+		_, err := getters.GetConfigurationByClusterID(ctx, r.Client, remoteClusterID, metav1.NamespaceAll)
+		switch {
+		case err == nil:
+			continue
+		case !apierrors.IsNotFound(err):
+			return fmt.Errorf("unable to get the configuration of cluster %s: %w", remoteClusterID, err)
+		}
+		if err := client.IgnoreNotFound(r.Client.Delete(ctx, adv)); err != nil {
+			return fmt.Errorf("unable to delete the transit advertisement %q: %w", client.ObjectKeyFromObject(adv), err)
+		}
+		klog.Infof("TransitAdvertisement %q towards cluster %s deleted", klog.KObj(adv), remoteClusterID)
+	}
+	return nil
+}
+
+// getAdvertisedTransits returns the CIDRs the given remote cluster advertises as reachable in transit through it,
+// as replicated in the local cluster. No CIDR is returned if the remote cluster does not advertise any, or if it is not
+// among the clusters allowed to forward the local traffic in transit. The reasons of the entries which have been
+// discarded by the validation are also returned.
+func (r *TransitReconciler) getAdvertisedTransits(ctx context.Context,
+	remoteClusterID liqov1beta1.ClusterID) (transits []networkingv1beta1.TransitConfig, rejected []string, err error) {
+	var advs networkingv1beta1.TransitAdvertisementList
+	if err := r.Client.List(ctx, &advs, client.MatchingLabels{
+		consts.ReplicationStatusLabel: strconv.FormatBool(true),
+		consts.ReplicationOriginLabel: string(remoteClusterID),
+	}); err != nil {
+		return nil, nil, fmt.Errorf("unable to list the transit advertisements of cluster %s: %w", remoteClusterID, err)
+	}
+
+	switch len(advs.Items) {
+	case 0:
+		return nil, nil, nil
+	case 1:
+	default:
+		return nil, nil, fmt.Errorf("multiple transit advertisements found for cluster %s", remoteClusterID)
+	}
+
+	if !slices.Contains(r.AcceptedClusters, remoteClusterID) {
+		klog.V(4).Infof("Ignoring the transit advertisement of cluster %s, as it is not accepted", remoteClusterID)
+		return nil, nil, nil
+	}
+	transits, rejected = validateTransits(advs.Items[0].Spec.Transit, r.LocalClusterID, remoteClusterID, r.MaxAdvertisedClusters)
+	return transits, rejected, nil
+}
+
+// validateTransits returns the entries of an advertisement which can be applied to the configuration of its origin
+// cluster, capped to the given maximum, together with the reasons of the discarded ones. An entry is discarded
+// if it refers to the local or to the origin cluster, if it is duplicated, or if any of its CIDRs is not valid.
+func validateTransits(transits []networkingv1beta1.TransitConfig, localClusterID, originClusterID liqov1beta1.ClusterID,
+	maxTransits int) (valid []networkingv1beta1.TransitConfig, rejected []string) {
+	for i := range transits {
+		transit := &transits[i]
+		var reason string
+		switch {
+		case transit.ClusterID == "":
+			reason = "missing cluster ID"
+		case transit.ClusterID == localClusterID || transit.ClusterID == originClusterID:
+			reason = "it refers to a cluster of the peering"
+		case slices.ContainsFunc(valid, func(t networkingv1beta1.TransitConfig) bool { return t.ClusterID == transit.ClusterID }):
+			reason = "duplicated cluster ID"
+		case len(transit.CIDR.Pod) == 0 && len(transit.CIDR.External) == 0:
+			reason = "no CIDR"
+		case len(valid) >= maxTransits:
+			reason = fmt.Sprintf("more than %d clusters advertised", maxTransits)
+		default:
+			for _, cidr := range slices.Concat(transit.CIDR.Pod, transit.CIDR.External) {
+				if err := validateTransitCIDR(cidr); err != nil {
+					reason = err.Error()
+					break
+				}
+			}
+		}
+
+		if reason != "" {
+			rejected = append(rejected, fmt.Sprintf("cluster %q: %s", transit.ClusterID, reason))
+			continue
+		}
+		valid = append(valid, *transit.DeepCopy())
+	}
+	return valid, rejected
+}
+
+// validateTransitCIDR checks that the given CIDR is a valid unicast network, which is not the default route.
+func validateTransitCIDR(cidr networkingv1beta1.CIDR) error {
+	ip, network, err := net.ParseCIDR(cidr.String())
+	if err != nil {
+		return fmt.Errorf("invalid CIDR %q", cidr)
+	}
+	if ones, _ := network.Mask.Size(); ones == 0 {
+		return fmt.Errorf("CIDR %q matches any address", cidr)
+	}
+	if !ip.Equal(network.IP) {
+		return fmt.Errorf("CIDR %q has host bits set", cidr)
+	}
+	if ip.IsLoopback() || ip.IsMulticast() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+		return fmt.Errorf("CIDR %q is not a unicast network", cidr)
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	configuration "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/configuration"
+	networkingutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/utils"
+	"github.com/liqotech/liqo/pkg/utils/events"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// cluster-role
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=transitpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=configurations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=transitadvertisements,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core.liqo.io,resources=foreignclusters,verbs=get;list;watch
+
+// TransitReconciler advertises to each remote cluster the CIDRs of the peers it is allowed to reach in transit
+// through the local cluster, according to the TransitPolicies. The CIDRs, as remapped by the local cluster,
+// are set in the spec.local.transit field of the Configuration of the remote cluster, and in the TransitAdvertisement
+// replicated to it. Conversely, the CIDRs advertised by the remote cluster through the replicated TransitAdvertisement
+// are set in the spec.remote.transit field, and removed once withdrawn. The latter are accepted only from the
+// clusters allowed to forward the local traffic in transit, after validating each entry.
+type TransitReconciler struct {
+	Client         client.Client
+	Scheme         *runtime.Scheme
+	EventsRecorder record.EventRecorder
+
+	LocalClusterID        liqov1beta1.ClusterID
+	AcceptedClusters      []liqov1beta1.ClusterID
+	MaxAdvertisedClusters int
+}
+
+// NewTransitReconciler returns a new TransitReconciler.
+func NewTransitReconciler(cl client.Client, s *runtime.Scheme, er record.EventRecorder, localClusterID liqov1beta1.ClusterID,
+	acceptedClusters []liqov1beta1.ClusterID, maxAdvertisedClusters int) *TransitReconciler {
+	return &TransitReconciler{
+		Client:         cl,
+		Scheme:         s,
+		EventsRecorder: er,
+
+		LocalClusterID:        localClusterID,
+		AcceptedClusters:      acceptedClusters,
+		MaxAdvertisedClusters: maxAdvertisedClusters,
+	}
+}
+
+// Reconcile manages the Configuration resources, advertising the CIDRs of the peers reachable in transit.
+func (r *TransitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cfg := &networkingv1beta1.Configuration{}
+	if err := r.Client.Get(ctx, req.NamespacedName, cfg); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(6).Infof("There is no configuration %s", req.String())
+			return ctrl.Result{}, r.ensureStaleAdvertisementsAbsence(ctx)
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to get the configuration %q: %w", req.NamespacedName, err)
+	}
+
+	// The local configuration is defaulted by the configuration controller, which triggers a new reconciliation.
+	if cfg.Spec.Local == nil {
+		klog.V(4).Infof("Configuration %q has no local configuration yet", req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	remoteClusterID, ok := cfg.Labels[consts.RemoteClusterID]
+	if !ok {
+		return ctrl.Result{}, fmt.Errorf("configuration %q has no remote cluster ID label", req.NamespacedName)
+	}
+
+	var policies networkingv1beta1.TransitPolicyList
+	if err := r.Client.List(ctx, &policies); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to list the transit policies: %w", err)
+	}
+
+	transits, err := r.forgeTransits(ctx, getTransitPeers(policies.Items, liqov1beta1.ClusterID(remoteClusterID)))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.enforceTransitAdvertisement(ctx, liqov1beta1.ClusterID(remoteClusterID), transits); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	remoteTransits, rejected, err := r.getAdvertisedTransits(ctx, liqov1beta1.ClusterID(remoteClusterID))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(rejected) > 0 {
+		msg := fmt.Sprintf("Discarded %d cluster(s) advertised in transit by the remote cluster: %s", len(rejected), strings.Join(rejected, "; "))
+		events.EventWithOptions(r.EventsRecorder, cfg, msg, &events.Option{EventType: events.Warning, Reason: "TransitRejected"})
+		klog.Warningf("Configuration %q: %s", req.NamespacedName, msg)
+	}
+
+	if equality.Semantic.DeepEqual(cfg.Spec.Local.Transit, transits) && equality.Semantic.DeepEqual(cfg.Spec.Remote.Transit, remoteTransits) {
+		return ctrl.Result{}, nil
+	}
+
+	cfg.Spec.Local.Transit = transits
+	cfg.Spec.Remote.Transit = remoteTransits
+	if err := r.Client.Update(ctx, cfg); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to update the transit CIDRs of configuration %q: %w", req.NamespacedName, err)
+	}
+	events.Event(r.EventsRecorder, cfg, fmt.Sprintf("Advertising %d cluster(s) reachable in transit, %d reachable in transit through the remote cluster",
+		len(transits), len(remoteTransits)))
+	klog.Infof("Configuration %q advertising %d cluster(s) reachable in transit, %d reachable in transit through the remote cluster",
+		req.NamespacedName, len(transits), len(remoteTransits))
+
+	return ctrl.Result{}, nil
+}
+
+// forgeTransits returns the CIDRs of the given peers, as remapped by the local cluster.
+// The peers whose configuration is not yet remapped are skipped, as their CIDRs will be advertised once available.
+func (r *TransitReconciler) forgeTransits(ctx context.Context, peers []liqov1beta1.ClusterID) ([]networkingv1beta1.TransitConfig, error) {
+	var transits []networkingv1beta1.TransitConfig
+	for _, peer := range peers {
+		peerCfg, err := getters.GetConfigurationByClusterID(ctx, r.Client, peer, corev1.NamespaceAll)
+		switch {
+		case apierrors.IsNotFound(err):
+			klog.V(4).Infof("Configuration of transit peer %s not found", peer)
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("unable to get the configuration of transit peer %s: %w", peer, err)
+		}
+		if !networkingutils.IsConfigurationStatusSet(peerCfg.Status) {
+			klog.V(4).Infof("Configuration of transit peer %s not yet remapped", peer)
+			continue
+		}
+		transits = append(transits, networkingv1beta1.TransitConfig{
+			ClusterID: peer,
+			CIDR: networkingv1beta1.ClusterConfigCIDR{
+				Pod:      peerCfg.Status.Remote.CIDR.Pod,
+				External: peerCfg.Status.Remote.CIDR.External,
+			},
+		})
+	}
+	return transits, nil
+}
+
+// getTransitPeers returns the sorted list of the clusters the given one is allowed to reach in transit.
+func getTransitPeers(policies []networkingv1beta1.TransitPolicy, clusterID liqov1beta1.ClusterID) []liqov1beta1.ClusterID {
+	var peers []liqov1beta1.ClusterID
+	for i := range policies {
+		if !slices.Contains(policies[i].Spec.Peers, clusterID) {
+			continue
+		}
+		for _, peer := range policies[i].Spec.Peers {
+			if peer != clusterID && !slices.Contains(peers, peer) {
+				peers = append(peers, peer)
+			}
+		}
+	}
+	slices.SortFunc(peers, func(a, b liqov1beta1.ClusterID) int {
+		return strings.Compare(string(a), string(b))
+	})
+	return peers
+}
+
+// SetupWithManager register the TransitReconciler to the manager.
+func (r *TransitReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	filterByLabelsPredicate, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			configuration.Configured: configuration.ConfiguredValue,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	enqueuer := handler.EnqueueRequestsFromMapFunc(r.configurationsEnqueuer)
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlConfigurationTransit).
+		For(&networkingv1beta1.Configuration{}, builder.WithPredicates(filterByLabelsPredicate)).
+		Watches(&networkingv1beta1.TransitPolicy{}, enqueuer).
+		// The CIDRs advertised to a cluster depend on the status of the configurations of its transit peers.
+		Watches(&networkingv1beta1.Configuration{}, enqueuer, builder.WithPredicates(filterByLabelsPredicate)).
+		// The local TransitAdvertisements are enforced, while the replicated ones are applied to the configuration of their origin.
+		Watches(&networkingv1beta1.TransitAdvertisement{}, handler.EnqueueRequestsFromMapFunc(r.configurationEnqueuerByClusterID)).
+		// The TransitAdvertisements are created once the tenant namespace of the remote cluster is known.
+		Watches(&liqov1beta1.ForeignCluster{}, handler.EnqueueRequestsFromMapFunc(r.configurationEnqueuerByClusterID),
+			builder.WithPredicates(tenantNamespaceChangedPredicate())).
+		Complete(r)
+}
+
+// configurationsEnqueuer enqueues all the configurations, as any change to the policies,
+// or to the remapped CIDRs of a peer, may affect the CIDRs advertised to every remote cluster.
+func (r *TransitReconciler) configurationsEnqueuer(ctx context.Context, _ client.Object) []reconcile.Request {
+	cfgs, err := getters.ListConfigurationsByLabel(ctx, r.Client, labels.SelectorFromSet(map[string]string{
+		configuration.Configured: configuration.ConfiguredValue,
+	}))
+	if err != nil {
+		klog.Errorf("Unable to list configurations: %v", err)
+		return nil
+	}
+	requests := make([]reconcile.Request, len(cfgs.Items))
+	for i := range cfgs.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfgs.Items[i])}
+	}
+	return requests
+}
+
+// configurationEnqueuerByClusterID enqueues the configuration of the remote cluster the given object refers to.
+func (r *TransitReconciler) configurationEnqueuerByClusterID(ctx context.Context, obj client.Object) []reconcile.Request {
+	remoteClusterID, ok := obj.GetLabels()[consts.RemoteClusterID]
+	if !ok {
+		return nil
+	}
+	cfg, err := getters.GetConfigurationByClusterID(ctx, r.Client, liqov1beta1.ClusterID(remoteClusterID), corev1.NamespaceAll)
+	switch {
+	case apierrors.IsNotFound(err):
+		// A stale advertisement is deleted by the reconciliation of any removed configuration.
+		return nil
+	case err != nil:
+		klog.Errorf("Unable to get the configuration of cluster %s: %v", remoteClusterID, err)
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(cfg)}}
+}
+
+// tenantNamespaceChangedPredicate filters the ForeignCluster updates not changing the local tenant namespace.
+func tenantNamespaceChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldFc, okOld := e.ObjectOld.(*liqov1beta1.ForeignCluster)
+			newFc, okNew := e.ObjectNew.(*liqov1beta1.ForeignCluster)
+			return okOld && okNew && oldFc.Status.TenantNamespace.Local != newFc.Status.TenantNamespace.Local
+		},
+	}
+}
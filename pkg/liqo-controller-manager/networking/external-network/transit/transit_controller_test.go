@@ -0,0 +1,303 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transit
+
+import (
+	"context"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+var _ = Describe("Transit controller", func() {
+	var (
+		ctx    context.Context
+		cl     client.Client
+		r      *TransitReconciler
+		cfgA   *networkingv1beta1.Configuration
+		cfgC   *networkingv1beta1.Configuration
+		policy *networkingv1beta1.TransitPolicy
+		// accepted contains the clusters allowed to forward the local traffic in transit.
+		accepted []liqov1beta1.ClusterID
+
+		newConfiguration = func(clusterID, podCIDR, externalCIDR string) *networkingv1beta1.Configuration {
+			return &networkingv1beta1.Configuration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterID,
+					Namespace: "liqo-tenant-" + clusterID,
+					Labels:    map[string]string{consts.RemoteClusterID: clusterID},
+				},
+				Spec: networkingv1beta1.ConfigurationSpec{
+					Local: &networkingv1beta1.ClusterConfig{CIDR: networkingv1beta1.ClusterConfigCIDR{
+						Pod:      []networkingv1beta1.CIDR{"10.100.0.0/16"},
+						External: []networkingv1beta1.CIDR{"10.101.0.0/16"},
+					}},
+					Remote: networkingv1beta1.ClusterConfig{CIDR: networkingv1beta1.ClusterConfigCIDR{
+						Pod:      []networkingv1beta1.CIDR{"10.100.0.0/16"},
+						External: []networkingv1beta1.CIDR{"10.101.0.0/16"},
+					}},
+				},
+				Status: networkingv1beta1.ConfigurationStatus{
+					Remote: &networkingv1beta1.ClusterConfig{CIDR: networkingv1beta1.ClusterConfigCIDR{
+						Pod:      []networkingv1beta1.CIDR{networkingv1beta1.CIDR(podCIDR)},
+						External: []networkingv1beta1.CIDR{networkingv1beta1.CIDR(externalCIDR)},
+					}},
+				},
+			}
+		}
+
+		newForeignCluster = func(clusterID string) *liqov1beta1.ForeignCluster {
+			return &liqov1beta1.ForeignCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   clusterID,
+					Labels: map[string]string{consts.RemoteClusterID: clusterID},
+				},
+				Spec:   liqov1beta1.ForeignClusterSpec{ClusterID: liqov1beta1.ClusterID(clusterID)},
+				Status: liqov1beta1.ForeignClusterStatus{TenantNamespace: liqov1beta1.TenantNamespaceType{Local: "liqo-tenant-" + clusterID}},
+			}
+		}
+
+		getAdvertisement = func(clusterID string) *networkingv1beta1.TransitAdvertisement {
+			adv := &networkingv1beta1.TransitAdvertisement{}
+			Expect(cl.Get(ctx, client.ObjectKey{Name: advertisementName, Namespace: "liqo-tenant-" + clusterID}, adv)).To(Succeed())
+			return adv
+		}
+
+		reconcile = func(cfg *networkingv1beta1.Configuration) *networkingv1beta1.Configuration {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cfg)})
+			Expect(err).ToNot(HaveOccurred())
+			updated := &networkingv1beta1.Configuration{}
+			Expect(cl.Get(ctx, client.ObjectKeyFromObject(cfg), updated)).To(Succeed())
+			return updated
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		accepted = []liqov1beta1.ClusterID{"cluster-a"}
+		cfgA = newConfiguration("cluster-a", "10.70.0.0/16", "10.71.0.0/16")
+		cfgC = newConfiguration("cluster-c", "10.80.0.0/16", "10.81.0.0/16")
+		policy = &networkingv1beta1.TransitPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-to-c"},
+			Spec:       networkingv1beta1.TransitPolicySpec{Peers: []liqov1beta1.ClusterID{"cluster-a", "cluster-c"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		r = NewTransitReconciler(cl, scheme, record.NewFakeRecorder(10), "cluster-local", accepted, 2)
+	})
+
+	When("a transit policy between two peers exists", func() {
+		BeforeEach(func() {
+			cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cfgA, cfgC, policy).Build()
+		})
+
+		It("should advertise to each peer the remapped CIDRs of the other one", func() {
+			Expect(reconcile(cfgA).Spec.Local.Transit).To(ConsistOf(networkingv1beta1.TransitConfig{
+				ClusterID: "cluster-c",
+				CIDR: networkingv1beta1.ClusterConfigCIDR{
+					Pod:      []networkingv1beta1.CIDR{"10.80.0.0/16"},
+					External: []networkingv1beta1.CIDR{"10.81.0.0/16"},
+				},
+			}))
+			Expect(reconcile(cfgC).Spec.Local.Transit).To(ConsistOf(networkingv1beta1.TransitConfig{
+				ClusterID: "cluster-a",
+				CIDR: networkingv1beta1.ClusterConfigCIDR{
+					Pod:      []networkingv1beta1.CIDR{"10.70.0.0/16"},
+					External: []networkingv1beta1.CIDR{"10.71.0.0/16"},
+				},
+			}))
+		})
+
+		It("should withdraw the advertised CIDRs once the policy is deleted", func() {
+			Expect(reconcile(cfgA).Spec.Local.Transit).To(HaveLen(1))
+			Expect(cl.Delete(ctx, policy)).To(Succeed())
+			Expect(reconcile(cfgA).Spec.Local.Transit).To(BeEmpty())
+		})
+	})
+
+	When("the configuration of the other peer is not yet remapped", func() {
+		BeforeEach(func() {
+			cfgC.Status.Remote = nil
+			cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cfgA, cfgC, policy).Build()
+		})
+
+		It("should not advertise any CIDR", func() {
+			Expect(reconcile(cfgA).Spec.Local.Transit).To(BeEmpty())
+		})
+	})
+
+	When("the cluster is not a peer of any transit policy", func() {
+		BeforeEach(func() {
+			policy.Spec.Peers = []liqov1beta1.ClusterID{"cluster-b", "cluster-c"}
+			cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cfgA, cfgC, policy).Build()
+		})
+
+		It("should not advertise any CIDR", func() {
+			Expect(reconcile(cfgA).Spec.Local.Transit).To(BeEmpty())
+		})
+	})
+
+	When("the remote clusters are peered", func() {
+		BeforeEach(func() {
+			cl = fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(cfgA, cfgC, policy, newForeignCluster("cluster-a"), newForeignCluster("cluster-c")).Build()
+		})
+
+		It("should replicate the advertised CIDRs to each peer", func() {
+			reconcile(cfgA)
+			adv := getAdvertisement("cluster-a")
+			Expect(adv.Labels).To(HaveKeyWithValue(consts.ReplicationRequestedLabel, consts.ReplicationRequestedLabelValue))
+			Expect(adv.Labels).To(HaveKeyWithValue(consts.ReplicationDestinationLabel, "cluster-a"))
+			Expect(adv.Labels).To(HaveKeyWithValue(consts.RemoteClusterID, "cluster-a"))
+			Expect(adv.Spec.Transit).To(ConsistOf(networkingv1beta1.TransitConfig{
+				ClusterID: "cluster-c",
+				CIDR: networkingv1beta1.ClusterConfigCIDR{
+					Pod:      []networkingv1beta1.CIDR{"10.80.0.0/16"},
+					External: []networkingv1beta1.CIDR{"10.81.0.0/16"},
+				},
+			}))
+		})
+
+		It("should withdraw the replicated CIDRs once the policy is deleted", func() {
+			reconcile(cfgA)
+			Expect(getAdvertisement("cluster-a").Spec.Transit).To(HaveLen(1))
+			Expect(cl.Delete(ctx, policy)).To(Succeed())
+			reconcile(cfgA)
+			Expect(getAdvertisement("cluster-a").Spec.Transit).To(BeEmpty())
+		})
+
+		It("should delete the advertisement once the configuration is deleted", func() {
+			reconcile(cfgA)
+			Expect(cl.Delete(ctx, cfgA)).To(Succeed())
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cfgA)})
+			Expect(err).ToNot(HaveOccurred())
+			err = cl.Get(ctx, client.ObjectKey{Name: advertisementName, Namespace: "liqo-tenant-cluster-a"}, &networkingv1beta1.TransitAdvertisement{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("the remote cluster advertises CIDRs reachable in transit", func() {
+		var adv *networkingv1beta1.TransitAdvertisement
+
+		BeforeEach(func() {
+			adv = &networkingv1beta1.TransitAdvertisement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      advertisementName,
+					Namespace: "liqo-tenant-cluster-a",
+					Labels: map[string]string{
+						consts.ReplicationStatusLabel: strconv.FormatBool(true),
+						consts.ReplicationOriginLabel: "cluster-a",
+						consts.RemoteClusterID:        "cluster-a",
+					},
+				},
+				Spec: networkingv1beta1.TransitAdvertisementSpec{Transit: []networkingv1beta1.TransitConfig{{
+					ClusterID: "cluster-b",
+					CIDR:      networkingv1beta1.ClusterConfigCIDR{Pod: []networkingv1beta1.CIDR{"10.90.0.0/16"}},
+				}}},
+			}
+			cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cfgA, adv).Build()
+		})
+
+		It("should apply them to the remote configuration", func() {
+			Expect(reconcile(cfgA).Spec.Remote.Transit).To(ConsistOf(adv.Spec.Transit))
+		})
+
+		It("should remove them once the advertisement is withdrawn", func() {
+			Expect(reconcile(cfgA).Spec.Remote.Transit).To(HaveLen(1))
+			Expect(cl.Delete(ctx, adv)).To(Succeed())
+			Expect(reconcile(cfgA).Spec.Remote.Transit).To(BeEmpty())
+		})
+
+		When("the remote cluster is not allowed to forward the traffic in transit", func() {
+			BeforeEach(func() {
+				accepted = []liqov1beta1.ClusterID{"cluster-c"}
+			})
+
+			It("should ignore the advertisement", func() {
+				Expect(reconcile(cfgA).Spec.Remote.Transit).To(BeEmpty())
+			})
+		})
+
+		When("the advertisement contains invalid entries", func() {
+			BeforeEach(func() {
+				adv.Spec.Transit = append(adv.Spec.Transit,
+					networkingv1beta1.TransitConfig{
+						ClusterID: "cluster-local",
+						CIDR:      networkingv1beta1.ClusterConfigCIDR{Pod: []networkingv1beta1.CIDR{"10.91.0.0/16"}},
+					},
+					networkingv1beta1.TransitConfig{
+						ClusterID: "cluster-d",
+						CIDR:      networkingv1beta1.ClusterConfigCIDR{Pod: []networkingv1beta1.CIDR{"0.0.0.0/0"}},
+					},
+					networkingv1beta1.TransitConfig{
+						ClusterID: "cluster-e",
+						CIDR:      networkingv1beta1.ClusterConfigCIDR{Pod: []networkingv1beta1.CIDR{"10.92.0.0/16"}},
+					},
+					networkingv1beta1.TransitConfig{
+						ClusterID: "cluster-f",
+						CIDR:      networkingv1beta1.ClusterConfigCIDR{Pod: []networkingv1beta1.CIDR{"10.93.0.0/16"}},
+					},
+				)
+				Expect(cl.Update(ctx, adv)).To(Succeed())
+			})
+
+			It("should apply only the valid ones, up to the maximum", func() {
+				transits := reconcile(cfgA).Spec.Remote.Transit
+				Expect(transits).To(HaveLen(2))
+				Expect(transits[0].ClusterID).To(Equal(liqov1beta1.ClusterID("cluster-b")))
+				Expect(transits[1].ClusterID).To(Equal(liqov1beta1.ClusterID("cluster-e")))
+			})
+		})
+	})
+})
+
+var _ = DescribeTable("validateTransits function",
+	func(cidr networkingv1beta1.CIDR, clusterID liqov1beta1.ClusterID, valid bool) {
+		transits := []networkingv1beta1.TransitConfig{{
+			ClusterID: clusterID,
+			CIDR:      networkingv1beta1.ClusterConfigCIDR{External: []networkingv1beta1.CIDR{cidr}},
+		}}
+		accepted, rejected := validateTransits(transits, "cluster-local", "cluster-hub", 16)
+		if valid {
+			Expect(accepted).To(Equal(transits))
+			Expect(rejected).To(BeEmpty())
+		} else {
+			Expect(accepted).To(BeEmpty())
+			Expect(rejected).To(HaveLen(1))
+		}
+	},
+	Entry("valid IPv4 CIDR", networkingv1beta1.CIDR("10.90.0.0/16"), liqov1beta1.ClusterID("cluster-b"), true),
+	Entry("valid IPv6 CIDR", networkingv1beta1.CIDR("fd00:90::/64"), liqov1beta1.ClusterID("cluster-b"), true),
+	Entry("malformed CIDR", networkingv1beta1.CIDR("10.90.0.0"), liqov1beta1.ClusterID("cluster-b"), false),
+	Entry("CIDR with host bits", networkingv1beta1.CIDR("10.90.0.1/16"), liqov1beta1.ClusterID("cluster-b"), false),
+	Entry("default route", networkingv1beta1.CIDR("0.0.0.0/0"), liqov1beta1.ClusterID("cluster-b"), false),
+	Entry("loopback CIDR", networkingv1beta1.CIDR("127.0.0.0/8"), liqov1beta1.ClusterID("cluster-b"), false),
+	Entry("multicast CIDR", networkingv1beta1.CIDR("224.0.0.0/4"), liqov1beta1.ClusterID("cluster-b"), false),
+	Entry("missing cluster ID", networkingv1beta1.CIDR("10.90.0.0/16"), liqov1beta1.ClusterID(""), false),
+	Entry("local cluster", networkingv1beta1.CIDR("10.90.0.0/16"), liqov1beta1.ClusterID("cluster-local"), false),
+	Entry("origin cluster", networkingv1beta1.CIDR("10.90.0.0/16"), liqov1beta1.ClusterID("cluster-hub"), false),
+)
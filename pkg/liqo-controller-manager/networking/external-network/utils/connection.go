@@ -0,0 +1,61 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+)
+
+// EnsureConnectionQualityThresholds propagates the given quality thresholds to the Connections owned by the given gateway.
+// The Connections are created by the gateway itself, hence they are looked up through their owner references.
+func EnsureConnectionQualityThresholds(ctx context.Context, cl client.Client, gwObj client.Object,
+	thresholds *networkingv1beta1.ConnectionQualityThresholds) error {
+	var connections networkingv1beta1.ConnectionList
+	if err := cl.List(ctx, &connections, client.InNamespace(gwObj.GetNamespace())); err != nil {
+		return fmt.Errorf("unable to list the connections in namespace %q: %w", gwObj.GetNamespace(), err)
+	}
+
+	for i := range connections.Items {
+		conn := &connections.Items[i]
+		if !isOwnedBy(conn, gwObj) || equality.Semantic.DeepEqual(conn.Spec.QualityThresholds, thresholds) {
+			continue
+		}
+
+		original := conn.DeepCopy()
+		conn.Spec.QualityThresholds = thresholds.DeepCopy()
+		if err := cl.Patch(ctx, conn, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("unable to update the quality thresholds of connection %q: %w", client.ObjectKeyFromObject(conn), err)
+		}
+		klog.Infof("Updated the quality thresholds of connection %q", client.ObjectKeyFromObject(conn))
+	}
+
+	return nil
+}
+
+func isOwnedBy(obj, owner client.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/utils"
+)
+
+var _ = Describe("Connection quality thresholds", func() {
+	var (
+		ctx        context.Context
+		cl         client.Client
+		gwServer   *networkingv1beta1.GatewayServer
+		thresholds *networkingv1beta1.ConnectionQualityThresholds
+	)
+
+	forgeConnection := func(name string, ownerUID types.UID) *networkingv1beta1.Connection {
+		return &networkingv1beta1.Connection{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "tenant",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: networkingv1beta1.GroupVersion.String(), Kind: networkingv1beta1.GatewayServerKind,
+				Name: "gateway", UID: ownerUID,
+			}},
+		}}
+	}
+
+	getThresholds := func(name string) *networkingv1beta1.ConnectionQualityThresholds {
+		var conn networkingv1beta1.Connection
+		Expect(cl.Get(ctx, types.NamespacedName{Namespace: "tenant", Name: name}, &conn)).To(Succeed())
+		return conn.Spec.QualityThresholds
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		gwServer = &networkingv1beta1.GatewayServer{ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "tenant", UID: "owner"}}
+		thresholds = &networkingv1beta1.ConnectionQualityThresholds{
+			MaxLatency:              &metav1.Duration{Duration: 50 * time.Millisecond},
+			MaxPacketLossPercentage: ptr.To[int32](2),
+		}
+		cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			forgeConnection("owned", "owner"), forgeConnection("other", "another-owner")).Build()
+	})
+
+	It("should propagate the thresholds to the owned connections only", func() {
+		Expect(utils.EnsureConnectionQualityThresholds(ctx, cl, gwServer, thresholds)).To(Succeed())
+		Expect(getThresholds("owned")).To(Equal(thresholds))
+		Expect(getThresholds("other")).To(BeNil())
+	})
+
+	It("should remove the thresholds when they are unset in the gateway", func() {
+		Expect(utils.EnsureConnectionQualityThresholds(ctx, cl, gwServer, thresholds)).To(Succeed())
+		Expect(utils.EnsureConnectionQualityThresholds(ctx, cl, gwServer, nil)).To(Succeed())
+		Expect(getThresholds("owned")).To(BeNil())
+	})
+})
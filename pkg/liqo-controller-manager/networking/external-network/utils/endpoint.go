@@ -0,0 +1,245 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/gateway/concurrent"
+	"github.com/liqotech/liqo/pkg/utils"
+)
+
+// ErrUnsupportedServiceType is returned when the gateway server is exposed through a service of an unsupported type.
+var ErrUnsupportedServiceType = errors.New("service type not supported")
+
+// ForgeEndpointStatus forges the endpoint status of a gateway server, depending on the type of the service exposing it.
+func ForgeEndpointStatus(ctx context.Context, cl client.Client, service *corev1.Service,
+	dep *appsv1.Deployment) (*networkingv1beta1.EndpointStatus, error) {
+	switch service.Spec.Type {
+	case corev1.ServiceTypeClusterIP:
+		return ForgeEndpointStatusClusterIP(service)
+	case corev1.ServiceTypeNodePort:
+		endpointStatus, _, err := ForgeEndpointStatusNodePort(ctx, cl, service, dep)
+		return endpointStatus, err
+	case corev1.ServiceTypeLoadBalancer:
+		return ForgeEndpointStatusLoadBalancer(service)
+	default:
+		err := fmt.Errorf("%w: %q for gateway server Service %s/%s", ErrUnsupportedServiceType, service.Spec.Type, service.Namespace, service.Name)
+		klog.Error(err)
+		return nil, err
+	}
+}
+
+// ForgeEndpointStatusClusterIP forges the endpoint status of a gateway server exposed through a ClusterIP service.
+func ForgeEndpointStatusClusterIP(service *corev1.Service) (*networkingv1beta1.EndpointStatus, error) {
+	if len(service.Spec.Ports) == 0 {
+		err := fmt.Errorf("service %s/%s has no ports", service.Namespace, service.Name)
+		klog.Error(err)
+		return nil, err
+	}
+
+	port := service.Spec.Ports[0].Port
+	protocol := &service.Spec.Ports[0].Protocol
+	addresses := service.Spec.ClusterIPs
+
+	return &networkingv1beta1.EndpointStatus{
+		Protocol:  protocol,
+		Port:      port,
+		Addresses: addresses,
+	}, nil
+}
+
+// ForgeEndpointStatusNodePort forges the endpoint status of a gateway server exposed through a NodePort service,
+// together with the internal endpoint of the active gateway pod.
+func ForgeEndpointStatusNodePort(ctx context.Context, cl client.Client, service *corev1.Service,
+	dep *appsv1.Deployment) (*networkingv1beta1.EndpointStatus, *networkingv1beta1.InternalGatewayEndpoint, error) {
+	if len(service.Spec.Ports) == 0 {
+		err := fmt.Errorf("service %s/%s has no ports", service.Namespace, service.Name)
+		klog.Error(err)
+		return nil, nil, err
+	}
+
+	pod, err := GetActiveGatewayPod(ctx, cl, dep)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpointStatus, err := forgeEndpointStatusNodePortForPod(ctx, cl, service, pod)
+	if err != nil {
+		return nil, nil, err
+	}
+	return endpointStatus, ForgeInternalEndpoint(pod), nil
+}
+
+// forgeEndpointStatusNodePortForPod forges the endpoint status of a gateway server exposed through a NodePort service,
+// reached through the node hosting the given gateway pod.
+func forgeEndpointStatusNodePortForPod(ctx context.Context, cl client.Client, service *corev1.Service,
+	pod *corev1.Pod) (*networkingv1beta1.EndpointStatus, error) {
+	port := service.Spec.Ports[0].NodePort
+	protocol := &service.Spec.Ports[0].Protocol
+
+	node := &corev1.Node{}
+	err := cl.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node)
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Unable to get node %q: %v", pod.Spec.NodeName, err)
+		return nil, err
+	}
+
+	addresses := make([]string, 1)
+	if utils.IsNodeReady(node) {
+		if addresses[0], err = utils.GetAddress(node); err != nil {
+			klog.Errorf("Unable to get address of node %q: %v", pod.Spec.NodeName, err)
+			return nil, err
+		}
+	}
+
+	return &networkingv1beta1.EndpointStatus{
+		Protocol:  protocol,
+		Port:      port,
+		Addresses: addresses,
+	}, nil
+}
+
+// ForgeEndpointStatusLoadBalancer forges the endpoint status of a gateway server exposed through a LoadBalancer service.
+func ForgeEndpointStatusLoadBalancer(service *corev1.Service) (*networkingv1beta1.EndpointStatus, error) {
+	if len(service.Spec.Ports) == 0 {
+		err := fmt.Errorf("service %s/%s has no ports", service.Namespace, service.Name)
+		klog.Error(err)
+		return nil, err
+	}
+
+	port := service.Spec.Ports[0].Port
+	protocol := &service.Spec.Ports[0].Protocol
+
+	var addresses []string
+	for i := range service.Status.LoadBalancer.Ingress {
+		if hostName := service.Status.LoadBalancer.Ingress[i].Hostname; hostName != "" {
+			addresses = append(addresses, hostName)
+		}
+		if ip := service.Status.LoadBalancer.Ingress[i].IP; ip != "" {
+			addresses = append(addresses, ip)
+		}
+	}
+
+	return &networkingv1beta1.EndpointStatus{
+		Protocol:  protocol,
+		Port:      port,
+		Addresses: addresses,
+	}, nil
+}
+
+// GetActiveGatewayPod returns the active pod of a gateway deployment, and its IP is already assigned.
+// In active-active mode, it returns the connected replica with the lowest index, or the first replica if none is connected.
+func GetActiveGatewayPod(ctx context.Context, cl client.Client, dep *appsv1.Deployment) (*corev1.Pod, error) {
+	if concurrent.IsActiveActive(dep) {
+		pods, err := GetActiveGatewayPods(ctx, cl, dep)
+		if err != nil {
+			return nil, err
+		}
+		if len(pods) == 0 {
+			err := fmt.Errorf("no active pods with an IP for deployment %s/%s", dep.Namespace, dep.Name)
+			klog.Error(err)
+			return nil, err
+		}
+		for i := range pods {
+			if concurrent.IsConnectedGateway(&pods[i]) {
+				return &pods[i], nil
+			}
+		}
+		return &pods[0], nil
+	}
+
+	podsSelector := client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(gateway.ForgeActiveGatewayPodLabels())}
+	var podList corev1.PodList
+	if err := cl.List(ctx, &podList, client.InNamespace(dep.Namespace), podsSelector); err != nil {
+		klog.Errorf("Unable to list pods of deployment %s/%s: %v", dep.Namespace, dep.Name, err)
+		return nil, err
+	}
+
+	if len(podList.Items) != 1 {
+		err := fmt.Errorf("wrong number of pods for deployment %s/%s: %d (must be 1)", dep.Namespace, dep.Name, len(podList.Items))
+		klog.Error(err)
+		return nil, err
+	}
+
+	pod := &podList.Items[0]
+	if pod.Status.PodIP == "" {
+		err := fmt.Errorf("pod %s/%s has no IP", pod.Namespace, pod.Name)
+		klog.Error(err)
+		return nil, err
+	}
+	return pod, nil
+}
+
+// ForgeInternalEndpoint forges the internal endpoint of a gateway, given its active pod.
+func ForgeInternalEndpoint(pod *corev1.Pod) *networkingv1beta1.InternalGatewayEndpoint {
+	return &networkingv1beta1.InternalGatewayEndpoint{
+		IP:   ptr.To(networkingv1beta1.IP(pod.Status.PodIP)),
+		Node: &pod.Spec.NodeName,
+	}
+}
+
+// GetActiveGatewayPods returns the active pods of a gateway deployment with an IP already assigned, sorted by replica index.
+func GetActiveGatewayPods(ctx context.Context, cl client.Client, dep *appsv1.Deployment) ([]corev1.Pod, error) {
+	podsSelector := labels.Merge(gateway.ForgeActiveGatewayPodLabels(), dep.Spec.Template.GetLabels())
+	var podList corev1.PodList
+	if err := cl.List(ctx, &podList, client.InNamespace(dep.Namespace), client.MatchingLabels(podsSelector)); err != nil {
+		klog.Errorf("Unable to list pods of deployment %s/%s: %v", dep.Namespace, dep.Name, err)
+		return nil, err
+	}
+
+	pods := make([]corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		if podList.Items[i].Status.PodIP != "" && podList.Items[i].DeletionTimestamp.IsZero() {
+			pods = append(pods, podList.Items[i])
+		}
+	}
+	concurrent.SortReplicasByIndex(pods)
+	return pods, nil
+}
+
+// ForgeInternalEndpoints forges the internal endpoints of the connected replicas of a gateway running in active-active mode.
+// It returns nil if the gateway does not run in active-active mode.
+func ForgeInternalEndpoints(ctx context.Context, cl client.Client, dep *appsv1.Deployment) ([]networkingv1beta1.InternalGatewayEndpoint, error) {
+	if !concurrent.IsActiveActive(dep) {
+		return nil, nil
+	}
+
+	pods, err := GetActiveGatewayPods(ctx, cl, dep)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []networkingv1beta1.InternalGatewayEndpoint
+	for i := range pods {
+		if concurrent.IsConnectedGateway(&pods[i]) {
+			endpoints = append(endpoints, *ForgeInternalEndpoint(&pods[i]))
+		}
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+// ClusterRoleBindingEnquerer enqueues the gateway associated with a ClusterRoleBinding.
+func ClusterRoleBindingEnquerer(_ context.Context, obj client.Object) []ctrl.Request {
+	crb, ok := obj.(*rbacv1.ClusterRoleBinding)
+	if !ok {
+		return nil
+	}
+
+	return gatewayRequestFromLabels(crb.Labels)
+}
+
+// PodEnquerer enqueues the gateway associated with a Pod.
+func PodEnquerer(_ context.Context, obj client.Object) []ctrl.Request {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+
+	return gatewayRequestFromLabels(pod.Labels)
+}
+
+func gatewayRequestFromLabels(labels map[string]string) []ctrl.Request {
+	if labels == nil {
+		return nil
+	}
+	gwName, ok := labels[consts.GatewayNameLabel]
+	if !ok {
+		return nil
+	}
+	gwNs, ok := labels[consts.GatewayNamespaceLabel]
+	if !ok {
+		return nil
+	}
+
+	return []ctrl.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: gwNs,
+				Name:      gwName,
+			},
+		},
+	}
+}
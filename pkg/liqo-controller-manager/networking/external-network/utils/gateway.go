@@ -0,0 +1,186 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/gateway"
+	mapsutil "github.com/liqotech/liqo/pkg/utils/maps"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// EnforceKeysSecret ensures the keys secret of a gateway: it is generated if no secret has been provided in the gateway
+// specification, while a user-provided secret is checked and labeled. The outcome is recorded as an event on the gateway.
+func EnforceKeysSecret(ctx context.Context, cl client.Client, recorder record.EventRecorder, gwObj client.Object,
+	secretRef corev1.LocalObjectReference, mode gateway.Mode, generate KeysGenerator) error {
+	if secretRef.Name == "" {
+		if err := EnsureKeysSecret(ctx, cl, gwObj, mode, generate); err != nil {
+			recorder.Event(gwObj, corev1.EventTypeWarning, "KeysSecretEnforcedFailed", "Failed to enforce keys secret")
+			return err
+		}
+		recorder.Event(gwObj, corev1.EventTypeNormal, "KeysSecretEnforced", "Enforced keys secret")
+		return nil
+	}
+
+	// Check that the secret exists and ensure is correctly labeled
+	if err := CheckExistingKeysSecret(ctx, cl, secretRef.Name, gwObj.GetNamespace(), gwObj); err != nil {
+		recorder.Event(gwObj, corev1.EventTypeWarning, "KeysSecretCheckFailed", fmt.Sprintf("Failed to check keys secret: %s", err))
+		return err
+	}
+	recorder.Event(gwObj, corev1.EventTypeNormal, "KeysSecretChecked", "Checked keys secret")
+	return nil
+}
+
+// ForgeSecretRefStatus returns the reference to the keys secret of a gateway, or nil if it does not exist yet.
+func ForgeSecretRefStatus(ctx context.Context, cl client.Client, gwObj metav1.Object) (*corev1.ObjectReference, error) {
+	secret, err := GetKeysSecret(ctx, cl, gwObj)
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		return &corev1.ObjectReference{
+			Name:      secret.Name,
+			Namespace: secret.Namespace,
+		}, nil
+	}
+}
+
+// EnsureGatewayDeployment creates or updates the deployment of a gateway from its template.
+// The keys secret referenced in the gateway status is mounted in the volume with the given name.
+func EnsureGatewayDeployment(ctx context.Context, cl client.Client, s *runtime.Scheme, recorder record.EventRecorder,
+	gwObj client.Object, template *networkingv1beta1.DeploymentTemplate, secretRef *corev1.ObjectReference,
+	keysVolumeName string, depNsName types.NamespacedName) (*appsv1.Deployment, error) {
+	dep := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:      depNsName.Name,
+		Namespace: depNsName.Namespace,
+	}}
+
+	op, err := resource.CreateOrUpdate(ctx, cl, &dep, func() error {
+		// Forge metadata
+		mapsutil.SmartMergeLabels(&dep, template.Metadata.GetLabels())
+		mapsutil.SmartMergeAnnotations(&dep, template.Metadata.GetAnnotations())
+
+		// Forge spec
+		dep.Spec = template.Spec
+
+		if secretRef != nil {
+			// When no secret reference is provided, we need to replace the secret name in the deployment manifest with the auto-generated one.
+			for i := range dep.Spec.Template.Spec.Volumes {
+				if dep.Spec.Template.Spec.Volumes[i].Name == keysVolumeName {
+					dep.Spec.Template.Spec.Volumes[i].Secret = &corev1.SecretVolumeSource{
+						SecretName: secretRef.Name,
+					}
+					break
+				}
+			}
+		} else {
+			recorder.Event(gwObj, corev1.EventTypeWarning, "MissingSecretRef", "Keys secret not found")
+		}
+
+		// Set the gateway as owner of the deployment
+		return controllerutil.SetControllerReference(gwObj, &dep, s)
+	})
+	if err != nil {
+		klog.Errorf("error while creating/updating deployment %q (operation: %s): %v", depNsName, op, err)
+		return nil, err
+	}
+
+	klog.Infof("Deployment %q correctly enforced (operation: %s)", depNsName, op)
+	return &dep, nil
+}
+
+// EnsureGatewayService creates or updates the service exposing a gateway server from its template.
+func EnsureGatewayService(ctx context.Context, cl client.Client, s *runtime.Scheme,
+	gwObj client.Object, template *networkingv1beta1.ServiceTemplate, svcNsName types.NamespacedName) (*corev1.Service, error) {
+	svc := corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      svcNsName.Name,
+		Namespace: svcNsName.Namespace,
+	}}
+
+	op, err := resource.CreateOrUpdate(ctx, cl, &svc, func() error {
+		// Forge metadata
+		mapsutil.SmartMergeLabels(&svc, template.Metadata.GetLabels())
+		mapsutil.SmartMergeAnnotations(&svc, template.Metadata.GetAnnotations())
+
+		// Forge spec
+		serviceClassName := svc.Spec.LoadBalancerClass
+		svc.Spec = template.Spec
+		if template.Spec.LoadBalancerClass == nil {
+			svc.Spec.LoadBalancerClass = serviceClassName
+		}
+
+		// Set the gateway as owner of the service
+		return controllerutil.SetControllerReference(gwObj, &svc, s)
+	})
+	if err != nil {
+		klog.Errorf("error while creating/updating service %q (operation: %s): %v", svcNsName, op, err)
+		return nil, err
+	}
+
+	klog.Infof("Service %q correctly enforced (operation: %s)", svcNsName, op)
+	return &svc, nil
+}
+
+// ForgeServerEndpointStatus returns the endpoint exposing a gateway server, or nil if the deployment does not exist.
+// In case the service type is not supported, a nil endpoint is returned together with ErrUnsupportedServiceType,
+// to avoid misaligned spec and status.
+func ForgeServerEndpointStatus(ctx context.Context, cl client.Client, svcNsName types.NamespacedName,
+	dep *appsv1.Deployment) (*networkingv1beta1.EndpointStatus, error) {
+	if dep == nil {
+		return nil, nil
+	}
+
+	var service corev1.Service
+	if err := cl.Get(ctx, svcNsName, &service); err != nil {
+		klog.Error(err) // raise an error also if service NotFound
+		return nil, err
+	}
+
+	return ForgeEndpointStatus(ctx, cl, &service, dep)
+}
+
+// ForgeServerInternalEndpointStatus returns the endpoint of a gateway server for the internal network,
+// or nil if the deployment does not exist.
+func ForgeServerInternalEndpointStatus(ctx context.Context, cl client.Client, svcNsName types.NamespacedName,
+	dep *appsv1.Deployment) (*networkingv1beta1.InternalGatewayEndpoint, error) {
+	if dep == nil {
+		return nil, nil
+	}
+
+	var service corev1.Service
+	if err := cl.Get(ctx, svcNsName, &service); err != nil {
+		klog.Error(err) // raise an error also if service NotFound
+		return nil, err
+	}
+
+	_, ige, err := ForgeEndpointStatusNodePort(ctx, cl, &service, dep)
+	return ige, err
+}
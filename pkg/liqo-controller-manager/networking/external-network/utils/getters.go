@@ -41,6 +41,26 @@ func ParseEndpoint(endpoint map[string]interface{}) *networkingv1beta1.EndpointS
 		tmp := corev1.Protocol(value.(string))
 		res.Protocol = &tmp
 	}
+	if value, ok := endpoint["replicas"]; ok {
+		for _, replica := range value.([]interface{}) {
+			res.Replicas = append(res.Replicas, ParseReplicaEndpoint(replica.(map[string]interface{})))
+		}
+	}
+	return res
+}
+
+// ParseReplicaEndpoint parses the endpoint of a gateway replica from a map.
+func ParseReplicaEndpoint(endpoint map[string]interface{}) networkingv1beta1.ReplicaEndpointStatus {
+	res := networkingv1beta1.ReplicaEndpointStatus{}
+	if value, ok := endpoint["index"]; ok {
+		res.Index = int32(value.(int64))
+	}
+	if value, ok := endpoint["addresses"]; ok {
+		res.Addresses = interfaceListToList[string](value.([]interface{}))
+	}
+	if value, ok := endpoint["port"]; ok {
+		res.Port = int32(value.(int64))
+	}
 	return res
 }
 
@@ -56,6 +76,15 @@ func ParseInternalEndpoint(internalEndpoint map[string]interface{}) *networkingv
 	return res
 }
 
+// ParseInternalEndpoints parses a list of internal endpoints from a slice.
+func ParseInternalEndpoints(internalEndpoints []interface{}) []networkingv1beta1.InternalGatewayEndpoint {
+	res := make([]networkingv1beta1.InternalGatewayEndpoint, 0, len(internalEndpoints))
+	for _, internalEndpoint := range internalEndpoints {
+		res = append(res, *ParseInternalEndpoint(internalEndpoint.(map[string]interface{})))
+	}
+	return res
+}
+
 // ParseRef parses an ObjectReference from a map.
 func ParseRef(ref map[string]interface{}) *corev1.ObjectReference {
 	res := &corev1.ObjectReference{}
@@ -0,0 +1,69 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/utils"
+)
+
+var _ = Describe("Parsing tests", func() {
+	Context("ParseEndpoint", func() {
+		It("should parse an endpoint without replicas", func() {
+			endpoint := utils.ParseEndpoint(map[string]interface{}{
+				"addresses": []interface{}{"10.0.0.1"},
+				"port":      int64(51840),
+				"protocol":  "UDP",
+			})
+			Expect(endpoint).To(Equal(&networkingv1beta1.EndpointStatus{
+				Addresses: []string{"10.0.0.1"},
+				Port:      51840,
+				Protocol:  ptr.To(corev1.ProtocolUDP),
+			}))
+		})
+
+		It("should parse the endpoints of the replicas", func() {
+			endpoint := utils.ParseEndpoint(map[string]interface{}{
+				"addresses": []interface{}{"10.0.0.1"},
+				"port":      int64(51840),
+				"replicas": []interface{}{
+					map[string]interface{}{"index": int64(0), "addresses": []interface{}{"10.0.0.2"}, "port": int64(30000)},
+					map[string]interface{}{"index": int64(1), "addresses": []interface{}{"10.0.0.3"}, "port": int64(30001)},
+				},
+			})
+			Expect(endpoint.Replicas).To(Equal([]networkingv1beta1.ReplicaEndpointStatus{
+				{Index: 0, Addresses: []string{"10.0.0.2"}, Port: 30000},
+				{Index: 1, Addresses: []string{"10.0.0.3"}, Port: 30001},
+			}))
+		})
+	})
+
+	Context("ParseInternalEndpoints", func() {
+		It("should parse the internal endpoints of the replicas", func() {
+			Expect(utils.ParseInternalEndpoints([]interface{}{
+				map[string]interface{}{"ip": "10.80.0.2", "node": "node-1"},
+				map[string]interface{}{"ip": "10.80.0.3"},
+			})).To(Equal([]networkingv1beta1.InternalGatewayEndpoint{
+				{IP: ptr.To(networkingv1beta1.IP("10.80.0.2")), Node: ptr.To("node-1")},
+				{IP: ptr.To(networkingv1beta1.IP("10.80.0.3"))},
+			}))
+		})
+	})
+})
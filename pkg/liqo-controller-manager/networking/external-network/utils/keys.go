@@ -0,0 +1,252 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/gateway"
+	"github.com/liqotech/liqo/pkg/gateway/forge"
+	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// FilterKeysSecretsPredicate returns the predicate matching the secrets containing the keys of the gateways.
+func FilterKeysSecretsPredicate() predicate.Predicate {
+	filterGatewayResources, err := predicate.LabelSelectorPredicate(liqolabels.GatewayResourceLabelSelector)
+	utilruntime.Must(err)
+
+	filterResourcesForRemote, err := predicate.LabelSelectorPredicate(liqolabels.ResourceForRemoteClusterLabelSelector)
+	utilruntime.Must(err)
+
+	return predicate.And(filterGatewayResources, filterResourcesForRemote)
+}
+
+// KeysSecretEnquerer enqueues the gateway associated with a keys secret.
+func KeysSecretEnquerer(_ context.Context, obj client.Object) []ctrl.Request {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return nil
+	}
+
+	return []ctrl.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: secret.Namespace,
+				Name:      forge.GatewayResourceName(secret.Name),
+			},
+		},
+	}
+}
+
+// KeysGenerator generates the private and public keys of a gateway.
+type KeysGenerator func() (privateKey, publicKey []byte, err error)
+
+// GenerateWireGuardKeys generates a Curve25519 key pair, used by the WireGuard tunnel implementation.
+func GenerateWireGuardKeys() (privateKey, publicKey []byte, err error) {
+	pri, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	pub := pri.PublicKey()
+	return pri[:], pub[:], nil
+}
+
+// GenerateIPsecKeys generates an ECDSA P-256 key pair, from which the keys of the IPsec security associations are derived.
+// The private key is encoded in PKCS #8, and the public key in PKIX, ASN.1 DER form.
+func GenerateIPsecKeys() (privateKey, publicKey []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if privateKey, err = x509.MarshalPKCS8PrivateKey(key); err != nil {
+		return nil, nil, err
+	}
+	if publicKey, err = x509.MarshalPKIXPublicKey(&key.PublicKey); err != nil {
+		return nil, nil, err
+	}
+	return privateKey, publicKey, nil
+}
+
+// EnsureKeysSecret ensures the presence of the private and public keys of a gateway, and saves them inside a Secret resource.
+// The keys are generated with the given generator, depending on the tunnel implementation.
+func EnsureKeysSecret(ctx context.Context, cl client.Client, gwObj metav1.Object, mode gateway.Mode, generate KeysGenerator) error {
+	var controllerRef metav1.OwnerReference
+	for _, ref := range gwObj.GetOwnerReferences() {
+		if ref.Controller != nil && *ref.Controller {
+			switch ref.Kind {
+			case networkingv1beta1.GatewayClientKind:
+				controllerRef = ref
+			case networkingv1beta1.GatewayServerKind:
+				controllerRef = ref
+			}
+			break
+		}
+	}
+
+	opts := &gateway.Options{
+		Name:            controllerRef.Name,
+		Namespace:       gwObj.GetNamespace(),
+		RemoteClusterID: gwObj.GetLabels()[consts.RemoteClusterID],
+		GatewayUID:      string(controllerRef.UID),
+		Mode:            mode,
+	}
+
+	_, err := GetKeysSecret(ctx, cl, gwObj)
+	switch {
+	case kerrors.IsNotFound(err):
+		pri, pub, err := generate()
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		if err := createKeysSecret(ctx, cl, opts, pri, pub); err != nil {
+			klog.Error(err)
+			return err
+		}
+		klog.Infof("Keys secret for gateway %q correctly enforced", gwObj.GetName())
+		return nil
+	case err != nil:
+		klog.Error(err)
+		return err
+	default:
+		return nil
+	}
+}
+
+// CheckExistingKeysSecret checks that a user-provided keys secret contains the mandatory fields, and enforces the gateway labels on it.
+func CheckExistingKeysSecret(ctx context.Context, cl client.Client, secretName, namespace string, gwObj metav1.Object) error {
+	var s corev1.Secret
+	if err := cl.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, &s); err != nil {
+		return err
+	}
+
+	// Check needed data fields are present
+	if s.Data == nil {
+		return fmt.Errorf("mandatory data %q and %q are missing in secret %q", consts.PrivateKeyField, consts.PublicKeyField, secretName)
+	}
+	if _, ok := s.Data[consts.PrivateKeyField]; !ok {
+		return fmt.Errorf("missing %q data in secret %q", consts.PrivateKeyField, secretName)
+	}
+	if _, ok := s.Data[consts.PublicKeyField]; !ok {
+		return fmt.Errorf("missing %q data in secret %q", consts.PublicKeyField, secretName)
+	}
+
+	// Check remote cluster ID label match the parent gateway object
+	remoteClusterID, exists := gwObj.GetLabels()[consts.RemoteClusterID]
+	if !exists || remoteClusterID == "" {
+		return fmt.Errorf("missing %q label in gateway %q", consts.RemoteClusterID, gwObj.GetName())
+	}
+	if s.Labels != nil {
+		if v, ok := s.Labels[consts.RemoteClusterID]; ok && v != remoteClusterID {
+			return fmt.Errorf("label %q in secret %q does not match the one in gateway %q", consts.RemoteClusterID, secretName, gwObj.GetName())
+		}
+	}
+
+	// Enforce correct labels on the secret if not present
+	if s.Labels == nil || s.Labels[consts.RemoteClusterID] == "" || s.Labels[consts.GatewayResourceLabel] != consts.GatewayResourceLabelValue {
+		s.SetLabels(labels.Merge(s.GetLabels(), map[string]string{
+			consts.RemoteClusterID:      remoteClusterID,
+			consts.GatewayResourceLabel: consts.GatewayResourceLabelValue,
+		}))
+		if err := cl.Update(ctx, &s); err != nil {
+			return fmt.Errorf("unable to update labels in secret %q: %w", secretName, err)
+		}
+		klog.Infof("Enforced correct gateway labels in secret %q", secretName)
+	}
+
+	return nil
+}
+
+// GetKeysSecret returns the secret containing the keys of the given gateway.
+func GetKeysSecret(ctx context.Context, cl client.Client, gwObj metav1.Object) (*corev1.Secret, error) {
+	gwObjNsName := types.NamespacedName{Name: gwObj.GetName(), Namespace: gwObj.GetNamespace()}
+
+	remoteClusterID, exists := gwObj.GetLabels()[consts.RemoteClusterID]
+	if !exists {
+		err := fmt.Errorf("missing %q label in gateway %q", consts.RemoteClusterID, gwObjNsName)
+		klog.Error(err)
+		return nil, err
+	}
+	secretSelector := client.MatchingLabels{
+		consts.GatewayResourceLabel: consts.GatewayResourceLabelValue,
+		consts.RemoteClusterID:      remoteClusterID,
+	}
+
+	var secrets corev1.SecretList
+	err := cl.List(ctx, &secrets, client.InNamespace(gwObj.GetNamespace()), secretSelector)
+	if err != nil {
+		klog.Errorf("Unable to list secrets associated to gateway %q: %v", gwObjNsName, err)
+		return nil, err
+	}
+
+	switch len(secrets.Items) {
+	case 0:
+		err = kerrors.NewNotFound(corev1.Resource("Secret"), gwObjNsName.Name)
+		return nil, err
+	case 1:
+		return &secrets.Items[0], nil
+	default:
+		return nil, fmt.Errorf("found multiple secrets associated to gateway %q", gwObjNsName)
+	}
+}
+
+// createKeysSecret saves the private and public keys of a gateway inside a Secret resource.
+func createKeysSecret(ctx context.Context, cl client.Client, opts *gateway.Options, pri, pub []byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      forge.GatewayResourceName(opts.Name),
+			Namespace: opts.Namespace,
+		},
+	}
+
+	if _, err := resource.CreateOrUpdate(ctx, cl, secret, func() error {
+		secret.SetLabels(map[string]string{
+			string(consts.RemoteClusterID):      opts.RemoteClusterID,
+			string(consts.GatewayResourceLabel): string(consts.GatewayResourceLabelValue),
+		})
+		if err := gateway.SetOwnerReferenceWithMode(opts, secret, cl.Scheme()); err != nil {
+			return err
+		}
+		secret.Data = map[string][]byte{
+			consts.PrivateKeyField: pri,
+			consts.PublicKeyField:  pub,
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
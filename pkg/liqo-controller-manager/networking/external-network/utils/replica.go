@@ -0,0 +1,173 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/gateway/concurrent"
+	mapsutil "github.com/liqotech/liqo/pkg/utils/maps"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// ForgeReplicaServiceName returns the name of the Service exposing a single replica of a gateway server.
+func ForgeReplicaServiceName(svcName string, index int) string {
+	return fmt.Sprintf("%s-%d", svcName, index)
+}
+
+// EnsureReplicaServices ensures that, when the gateway server runs in active-active mode, each replica is exposed
+// through a dedicated Service, so that each client replica can connect to the server replica with the same index.
+// The Services no longer matching a replica are deleted.
+func EnsureReplicaServices(ctx context.Context, cl client.Client, s *runtime.Scheme, owner client.Object,
+	svcTemplate *networkingv1beta1.ServiceTemplate, svcName string, dep *appsv1.Deployment) error {
+	replicas := 0
+	if dep != nil && concurrent.IsActiveActive(dep) {
+		replicas = int(ptr.Deref(dep.Spec.Replicas, 1))
+	}
+
+	for index := 0; index < replicas; index++ {
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:      ForgeReplicaServiceName(svcName, index),
+			Namespace: owner.GetNamespace(),
+		}}
+		op, err := resource.CreateOrUpdate(ctx, cl, svc, func() error {
+			return mutateReplicaService(svc, svcTemplate, index, owner, s)
+		})
+		if err != nil {
+			klog.Errorf("error while creating/updating service %q (operation: %s): %v", client.ObjectKeyFromObject(svc), op, err)
+			return err
+		}
+	}
+
+	var services corev1.ServiceList
+	if err := cl.List(ctx, &services, client.InNamespace(owner.GetNamespace()), client.HasLabels{concurrent.ReplicaIndexKey}); err != nil {
+		return fmt.Errorf("unable to list the replica services: %w", err)
+	}
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if !metav1.IsControlledBy(svc, owner) {
+			continue
+		}
+		index, err := strconv.Atoi(svc.Labels[concurrent.ReplicaIndexKey])
+		if err == nil && index < replicas {
+			continue
+		}
+		if err := client.IgnoreNotFound(cl.Delete(ctx, svc)); err != nil {
+			return fmt.Errorf("unable to delete the replica service %q: %w", client.ObjectKeyFromObject(svc), err)
+		}
+		klog.Infof("Replica service %q deleted", client.ObjectKeyFromObject(svc))
+	}
+
+	return nil
+}
+
+func mutateReplicaService(svc *corev1.Service, svcTemplate *networkingv1beta1.ServiceTemplate, index int,
+	owner client.Object, s *runtime.Scheme) error {
+	replicaLabels := map[string]string{concurrent.ReplicaIndexKey: strconv.Itoa(index)}
+	mapsutil.SmartMergeLabels(svc, labels.Merge(svcTemplate.Metadata.GetLabels(), replicaLabels))
+	mapsutil.SmartMergeAnnotations(svc, svcTemplate.Metadata.GetAnnotations())
+
+	loadBalancerClass := svc.Spec.LoadBalancerClass
+	svc.Spec = *svcTemplate.Spec.DeepCopy()
+	if svc.Spec.LoadBalancerClass == nil {
+		svc.Spec.LoadBalancerClass = loadBalancerClass
+	}
+
+	svc.Spec.Selector = labels.Merge(svc.Spec.Selector, replicaLabels)
+	// The static addresses and ports cannot be shared among multiple services, and are left to the main one.
+	svc.Spec.LoadBalancerIP = ""
+	for i := range svc.Spec.Ports {
+		svc.Spec.Ports[i].NodePort = 0
+	}
+	// The endpoints of the replicas must be reachable before the tunnel is established.
+	svc.Spec.PublishNotReadyAddresses = true
+
+	return controllerutil.SetControllerReference(owner, svc, s)
+}
+
+// ForgeReplicaEndpointsStatus forges the endpoints of the single replicas of a gateway server running in active-active mode.
+// It returns nil if the gateway does not run in active-active mode.
+func ForgeReplicaEndpointsStatus(ctx context.Context, cl client.Client, svcName string,
+	dep *appsv1.Deployment) ([]networkingv1beta1.ReplicaEndpointStatus, error) {
+	if !concurrent.IsActiveActive(dep) {
+		return nil, nil
+	}
+
+	pods, err := GetActiveGatewayPods(ctx, cl, dep)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []networkingv1beta1.ReplicaEndpointStatus
+	for index := 0; index < int(ptr.Deref(dep.Spec.Replicas, 1)); index++ {
+		var svc corev1.Service
+		svcKey := client.ObjectKey{Namespace: dep.Namespace, Name: ForgeReplicaServiceName(svcName, index)}
+		if err := cl.Get(ctx, svcKey, &svc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to get the replica service %q: %w", svcKey, err)
+		}
+
+		var endpoint *networkingv1beta1.EndpointStatus
+		switch svc.Spec.Type {
+		case corev1.ServiceTypeNodePort:
+			pod := getReplicaPod(pods, index)
+			if pod == nil {
+				continue
+			}
+			endpoint, err = forgeEndpointStatusNodePortForPod(ctx, cl, &svc, pod)
+		default:
+			endpoint, err = ForgeEndpointStatus(ctx, cl, &svc, dep)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(endpoint.Addresses) == 0 || endpoint.Addresses[0] == "" {
+			// The endpoint is not available yet (e.g., the load balancer has not been provisioned).
+			continue
+		}
+
+		endpoints = append(endpoints, networkingv1beta1.ReplicaEndpointStatus{
+			Index:     int32(index),
+			Addresses: endpoint.Addresses,
+			Port:      endpoint.Port,
+		})
+	}
+	return endpoints, nil
+}
+
+func getReplicaPod(pods []corev1.Pod, index int) *corev1.Pod {
+	for i := range pods {
+		if podIndex, ok := concurrent.GetReplicaIndex(&pods[i]); ok && podIndex == index {
+			return &pods[i]
+		}
+	}
+	return nil
+}
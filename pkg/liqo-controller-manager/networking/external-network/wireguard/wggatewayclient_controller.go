@@ -23,13 +23,10 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
-	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,8 +38,6 @@ import (
 	"github.com/liqotech/liqo/pkg/gateway"
 	"github.com/liqotech/liqo/pkg/gateway/forge"
 	enutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/utils"
-	mapsutil "github.com/liqotech/liqo/pkg/utils/maps"
-	"github.com/liqotech/liqo/pkg/utils/resource"
 )
 
 // WgGatewayClientReconciler manage WgGatewayClient lifecycle.
@@ -162,21 +157,10 @@ func (r *WgGatewayClientReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
-	// If a secret has not been provided in the gateway specification, the controller is in charge of generating a secret with the Wireguard keys.
-	if wgClient.Spec.SecretRef.Name == "" {
-		// Ensure WireGuard keys secret (create or update)
-		if err = ensureKeysSecret(ctx, r.Client, wgClient, gateway.ModeClient); err != nil {
-			r.eventRecorder.Event(wgClient, corev1.EventTypeWarning, "KeysSecretEnforcedFailed", "Failed to enforce keys secret")
-			return ctrl.Result{}, err
-		}
-		r.eventRecorder.Event(wgClient, corev1.EventTypeNormal, "KeysSecretEnforced", "Enforced keys secret")
-	} else {
-		// Check that the secret exists and ensure is correctly labeled
-		if err = checkExistingKeysSecret(ctx, r.Client, wgClient.Spec.SecretRef.Name, wgClient.Namespace, wgClient.GetObjectMeta()); err != nil {
-			r.eventRecorder.Event(wgClient, corev1.EventTypeWarning, "KeysSecretCheckFailed", fmt.Sprintf("Failed to check keys secret: %s", err))
-			return ctrl.Result{}, err
-		}
-		r.eventRecorder.Event(wgClient, corev1.EventTypeNormal, "KeysSecretChecked", "Checked keys secret")
+	// If a secret has not been provided in the gateway specification, the controller is in charge of generating a secret with the WireGuard keys.
+	if err = enutils.EnforceKeysSecret(ctx, r.Client, r.eventRecorder, wgClient, wgClient.Spec.SecretRef,
+		gateway.ModeClient, enutils.GenerateWireGuardKeys); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	if err := r.handleSecretRefStatus(ctx, wgClient); err != nil {
@@ -187,7 +171,8 @@ func (r *WgGatewayClientReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// Ensure deployment (create or update)
-	_, err = r.ensureDeployment(ctx, wgClient, deployNsName)
+	_, err = enutils.EnsureGatewayDeployment(ctx, r.Client, r.Scheme, r.eventRecorder, wgClient, &wgClient.Spec.Deployment,
+		wgClient.Status.SecretRef, wireguardVolumeName, deployNsName)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -211,106 +196,43 @@ func (r *WgGatewayClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&networkingv1beta1.WgGatewayClient{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ServiceAccount{}).
-		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(podEnquerer)).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(enutils.PodEnquerer)).
 		Watches(&rbacv1.ClusterRoleBinding{},
-			handler.EnqueueRequestsFromMapFunc(clusterRoleBindingEnquerer)).
+			handler.EnqueueRequestsFromMapFunc(enutils.ClusterRoleBindingEnquerer)).
 		Watches(&corev1.Secret{},
-			handler.EnqueueRequestsFromMapFunc(wireGuardSecretEnquerer),
-			builder.WithPredicates(filterWireGuardSecretsPredicate())).
+			handler.EnqueueRequestsFromMapFunc(enutils.KeysSecretEnquerer),
+			builder.WithPredicates(enutils.FilterKeysSecretsPredicate())).
 		Complete(r)
 }
 
-func (r *WgGatewayClientReconciler) ensureDeployment(ctx context.Context, wgClient *networkingv1beta1.WgGatewayClient,
-	depNsName types.NamespacedName) (*appsv1.Deployment, error) {
-	dep := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
-		Name:      depNsName.Name,
-		Namespace: depNsName.Namespace,
-	}}
-
-	op, err := resource.CreateOrUpdate(ctx, r.Client, &dep, func() error {
-		return r.mutateFnWgClientDeployment(&dep, wgClient)
-	})
-	if err != nil {
-		klog.Errorf("error while creating/updating deployment %q (operation: %s): %v", depNsName, op, err)
-		return nil, err
-	}
-
-	klog.Infof("Deployment %q correctly enforced (operation: %s)", depNsName, op)
-	return &dep, nil
-}
-
-func (r *WgGatewayClientReconciler) mutateFnWgClientDeployment(deployment *appsv1.Deployment, wgClient *networkingv1beta1.WgGatewayClient) error {
-	// Forge metadata
-	mapsutil.SmartMergeLabels(deployment, wgClient.Spec.Deployment.Metadata.GetLabels())
-	mapsutil.SmartMergeAnnotations(deployment, wgClient.Spec.Deployment.Metadata.GetAnnotations())
-
-	// Forge spec
-	deployment.Spec = wgClient.Spec.Deployment.Spec
-
-	if wgClient.Status.SecretRef != nil {
-		// When no secret reference is provided, we will need to replace the secret name in the deployment manifest with the auto-generated one.
-		for i := range deployment.Spec.Template.Spec.Volumes {
-			if deployment.Spec.Template.Spec.Volumes[i].Name == wireguardVolumeName {
-				deployment.Spec.Template.Spec.Volumes[i].Secret = &corev1.SecretVolumeSource{
-					SecretName: wgClient.Status.SecretRef.Name,
-				}
-				break
-			}
-		}
-	} else {
-		r.eventRecorder.Event(wgClient, corev1.EventTypeWarning, "MissingSecretRef", "WireGuard keys secret not found")
-	}
-
-	// Set WireGuard client as owner of the deployment
-	return controllerutil.SetControllerReference(wgClient, deployment, r.Scheme)
-}
-
 func (r *WgGatewayClientReconciler) handleSecretRefStatus(ctx context.Context, wgClient *networkingv1beta1.WgGatewayClient) error {
-	secret, err := getWireGuardSecret(ctx, r.Client, wgClient)
-	switch {
-	case apierrors.IsNotFound(err):
-		wgClient.Status.SecretRef = nil
-		return nil
-	case err != nil:
+	secretRef, err := enutils.ForgeSecretRefStatus(ctx, r.Client, wgClient)
+	if err != nil {
 		return err
-	default:
-		wgClient.Status.SecretRef = &corev1.ObjectReference{
-			Name:      secret.Name,
-			Namespace: secret.Namespace,
-		}
-		return nil
 	}
+	wgClient.Status.SecretRef = secretRef
+	return nil
 }
 
 func (r *WgGatewayClientReconciler) handleInternalEndpointStatus(ctx context.Context,
 	wgClient *networkingv1beta1.WgGatewayClient, dep *appsv1.Deployment) error {
 	if dep == nil {
 		wgClient.Status.InternalEndpoint = nil
+		wgClient.Status.InternalEndpoints = nil
 		return nil
 	}
 
-	podsSelector := client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(gateway.ForgeActiveGatewayPodLabels())}
-	var podList corev1.PodList
-	if err := r.List(ctx, &podList, client.InNamespace(dep.Namespace), podsSelector); err != nil {
-		klog.Errorf("Unable to list pods of deployment %s/%s: %v", dep.Namespace, dep.Name, err)
-		return err
-	}
-
-	if len(podList.Items) != 1 {
-		err := fmt.Errorf("wrong number of pods for deployment %s/%s: %d (must be 1)", dep.Namespace, dep.Name, len(podList.Items))
-		klog.Error(err)
+	pod, err := enutils.GetActiveGatewayPod(ctx, r.Client, dep)
+	if err != nil {
 		return err
 	}
 
-	if podList.Items[0].Status.PodIP == "" {
-		err := fmt.Errorf("pod %s/%s has no IP", podList.Items[0].Namespace, podList.Items[0].Name)
-		klog.Error(err)
+	ies, err := enutils.ForgeInternalEndpoints(ctx, r.Client, dep)
+	if err != nil {
 		return err
 	}
 
-	wgClient.Status.InternalEndpoint = &networkingv1beta1.InternalGatewayEndpoint{
-		IP:   ptr.To(networkingv1beta1.IP(podList.Items[0].Status.PodIP)),
-		Node: &podList.Items[0].Spec.NodeName,
-	}
+	wgClient.Status.InternalEndpoint = enutils.ForgeInternalEndpoint(pod)
+	wgClient.Status.InternalEndpoints = ies
 	return nil
 }
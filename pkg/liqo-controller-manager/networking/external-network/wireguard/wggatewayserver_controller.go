@@ -16,6 +16,7 @@ package wireguard
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -23,13 +24,10 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
-	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,9 +39,6 @@ import (
 	"github.com/liqotech/liqo/pkg/gateway"
 	"github.com/liqotech/liqo/pkg/gateway/forge"
 	enutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/external-network/utils"
-	"github.com/liqotech/liqo/pkg/utils"
-	mapsutil "github.com/liqotech/liqo/pkg/utils/maps"
-	"github.com/liqotech/liqo/pkg/utils/resource"
 )
 
 // WgGatewayServerReconciler manage WgGatewayServer lifecycle.
@@ -171,21 +166,10 @@ func (r *WgGatewayServerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
-	// If a secret has not been provided in the gateway specification, the controller is in charge of generating a secret with the Wireguard keys.
-	if wgServer.Spec.SecretRef.Name == "" {
-		// Ensure WireGuard keys secret (create or update)
-		if err = ensureKeysSecret(ctx, r.Client, wgServer, gateway.ModeServer); err != nil {
-			r.eventRecorder.Event(wgServer, corev1.EventTypeWarning, "KeysSecretEnforcedFailed", "Failed to enforce keys secret")
-			return ctrl.Result{}, err
-		}
-		r.eventRecorder.Event(wgServer, corev1.EventTypeNormal, "KeysSecretEnforced", "Enforced keys secret")
-	} else {
-		// Check that the secret exists and ensure is correctly labeled
-		if err = checkExistingKeysSecret(ctx, r.Client, wgServer.Spec.SecretRef.Name, wgServer.Namespace, wgServer.GetObjectMeta()); err != nil {
-			r.eventRecorder.Event(wgServer, corev1.EventTypeWarning, "KeysSecretCheckFailed", fmt.Sprintf("Failed to check keys secret: %s", err))
-			return ctrl.Result{}, err
-		}
-		r.eventRecorder.Event(wgServer, corev1.EventTypeNormal, "KeysSecretChecked", "Checked keys secret")
+	// If a secret has not been provided in the gateway specification, the controller is in charge of generating a secret with the WireGuard keys.
+	if err = enutils.EnforceKeysSecret(ctx, r.Client, r.eventRecorder, wgServer, wgServer.Spec.SecretRef,
+		gateway.ModeServer, enutils.GenerateWireGuardKeys); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	if err := r.handleSecretRefStatus(ctx, wgServer); err != nil {
@@ -196,19 +180,25 @@ func (r *WgGatewayServerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// Ensure deployment (create or update)
-	_, err = r.ensureDeployment(ctx, wgServer, deployNsName)
+	deploy, err = enutils.EnsureGatewayDeployment(ctx, r.Client, r.Scheme, r.eventRecorder, wgServer, &wgServer.Spec.Deployment,
+		wgServer.Status.SecretRef, wireguardVolumeName, deployNsName)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	r.eventRecorder.Event(wgServer, corev1.EventTypeNormal, "DeploymentEnforced", "Enforced deployment")
 
 	// Ensure service (create or update)
-	_, err = r.ensureService(ctx, wgServer, svcNsName)
+	_, err = enutils.EnsureGatewayService(ctx, r.Client, r.Scheme, wgServer, &wgServer.Spec.Service, svcNsName)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	r.eventRecorder.Event(wgServer, corev1.EventTypeNormal, "ServiceEnforced", "Enforced service")
 
+	// Ensure the services exposing the single replicas, in active-active mode (create, update or delete)
+	if err = enutils.EnsureReplicaServices(ctx, r.Client, r.Scheme, wgServer, &wgServer.Spec.Service, svcNsName.Name, deploy); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Ensure Metrics (if set)
 	err = enutils.EnsureMetrics(ctx,
 		r.Client, r.Scheme,
@@ -228,272 +218,60 @@ func (r *WgGatewayServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ServiceAccount{}).
-		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(podEnquerer)).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(enutils.PodEnquerer)).
 		Watches(&rbacv1.ClusterRoleBinding{},
-			handler.EnqueueRequestsFromMapFunc(clusterRoleBindingEnquerer)).
+			handler.EnqueueRequestsFromMapFunc(enutils.ClusterRoleBindingEnquerer)).
 		Watches(&corev1.Secret{},
-			handler.EnqueueRequestsFromMapFunc(wireGuardSecretEnquerer),
-			builder.WithPredicates(filterWireGuardSecretsPredicate())).
+			handler.EnqueueRequestsFromMapFunc(enutils.KeysSecretEnquerer),
+			builder.WithPredicates(enutils.FilterKeysSecretsPredicate())).
 		Complete(r)
 }
 
-func (r *WgGatewayServerReconciler) ensureDeployment(ctx context.Context, wgServer *networkingv1beta1.WgGatewayServer,
-	depNsName types.NamespacedName) (*appsv1.Deployment, error) {
-	dep := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
-		Name:      depNsName.Name,
-		Namespace: depNsName.Namespace,
-	}}
-
-	op, err := resource.CreateOrUpdate(ctx, r.Client, &dep, func() error {
-		return r.mutateFnWgServerDeployment(&dep, wgServer)
-	})
-	if err != nil {
-		klog.Errorf("error while creating/updating deployment %q (operation: %s): %v", depNsName, op, err)
-		return nil, err
-	}
-
-	klog.Infof("Deployment %q correctly enforced (operation: %s)", depNsName, op)
-	return &dep, nil
-}
-
-func (r *WgGatewayServerReconciler) ensureService(ctx context.Context, wgServer *networkingv1beta1.WgGatewayServer,
-	svcNsName types.NamespacedName) (*corev1.Service, error) {
-	svc := corev1.Service{ObjectMeta: metav1.ObjectMeta{
-		Name:      svcNsName.Name,
-		Namespace: svcNsName.Namespace,
-	}}
-
-	op, err := resource.CreateOrUpdate(ctx, r.Client, &svc, func() error {
-		return r.mutateFnWgServerService(&svc, wgServer)
-	})
-	if err != nil {
-		klog.Errorf("error while creating/updating service %q (operation: %s): %v", svcNsName, op, err)
-		return nil, err
-	}
-
-	klog.Infof("Service %q correctly enforced (operation: %s)", svcNsName, op)
-	return &svc, nil
-}
-
-func (r *WgGatewayServerReconciler) mutateFnWgServerDeployment(deployment *appsv1.Deployment, wgServer *networkingv1beta1.WgGatewayServer) error {
-	// Forge metadata
-	mapsutil.SmartMergeLabels(deployment, wgServer.Spec.Deployment.Metadata.GetLabels())
-	mapsutil.SmartMergeAnnotations(deployment, wgServer.Spec.Deployment.Metadata.GetAnnotations())
-
-	// Forge spec
-	deployment.Spec = wgServer.Spec.Deployment.Spec
-
-	if wgServer.Status.SecretRef != nil {
-		for i := range deployment.Spec.Template.Spec.Volumes {
-			if deployment.Spec.Template.Spec.Volumes[i].Name == wireguardVolumeName {
-				deployment.Spec.Template.Spec.Volumes[i].Secret = &corev1.SecretVolumeSource{
-					SecretName: wgServer.Status.SecretRef.Name,
-				}
-				break
-			}
-		}
-	} else {
-		r.eventRecorder.Event(wgServer, corev1.EventTypeWarning, "MissingSecretRef", "WireGuard keys secret not found")
-	}
-
-	// Set WireGuard server as owner of the deployment
-	return controllerutil.SetControllerReference(wgServer, deployment, r.Scheme)
-}
-
-func (r *WgGatewayServerReconciler) mutateFnWgServerService(service *corev1.Service, wgServer *networkingv1beta1.WgGatewayServer) error {
-	// Forge metadata
-	mapsutil.SmartMergeLabels(service, wgServer.Spec.Service.Metadata.GetLabels())
-	mapsutil.SmartMergeAnnotations(service, wgServer.Spec.Service.Metadata.GetAnnotations())
-
-	// Forge spec
-	serviceClassName := service.Spec.LoadBalancerClass
-	service.Spec = wgServer.Spec.Service.Spec
-	if wgServer.Spec.Service.Spec.LoadBalancerClass == nil {
-		service.Spec.LoadBalancerClass = serviceClassName
-	}
-
-	// Set WireGuard server as owner of the service
-	return controllerutil.SetControllerReference(wgServer, service, r.Scheme)
-}
-
 func (r *WgGatewayServerReconciler) handleEndpointStatus(ctx context.Context, wgServer *networkingv1beta1.WgGatewayServer,
 	svcNsName types.NamespacedName, dep *appsv1.Deployment) error {
-	if dep == nil {
-		wgServer.Status.Endpoint = nil
-		return nil
-	}
-
-	// Handle WireGuard server Service
-	var service corev1.Service
-	err := r.Get(ctx, svcNsName, &service)
-	if err != nil {
-		klog.Error(err) // raise an error also if service NotFound
-		return err
-	}
-
 	// Put service endpoint in WireGuard server status
-	var endpointStatus *networkingv1beta1.EndpointStatus
-	switch service.Spec.Type {
-	case corev1.ServiceTypeClusterIP:
-		endpointStatus, err = r.forgeEndpointStatusClusterIP(&service)
-	case corev1.ServiceTypeNodePort:
-		endpointStatus, _, err = r.forgeEndpointStatusNodePort(ctx, &service, dep)
-	case corev1.ServiceTypeLoadBalancer:
-		endpointStatus, err = r.forgeEndpointStatusLoadBalancer(&service)
-	default:
-		err = fmt.Errorf("service type %q not supported for WireGuard server Service %q", service.Spec.Type, svcNsName)
-		klog.Error(err)
-		wgServer.Status.Endpoint = nil // we empty the endpoint status to avoid misaligned spec and status
-	}
-
+	endpointStatus, err := enutils.ForgeServerEndpointStatus(ctx, r.Client, svcNsName, dep)
 	if err != nil {
-		return err
-	}
-
-	wgServer.Status.Endpoint = endpointStatus
-
-	return nil
-}
-
-func (r *WgGatewayServerReconciler) forgeEndpointStatusClusterIP(service *corev1.Service) (*networkingv1beta1.EndpointStatus, error) {
-	if len(service.Spec.Ports) == 0 {
-		err := fmt.Errorf("service %s/%s has no ports", service.Namespace, service.Name)
-		klog.Error(err)
-		return nil, err
-	}
-
-	port := service.Spec.Ports[0].Port
-	protocol := &service.Spec.Ports[0].Protocol
-	addresses := service.Spec.ClusterIPs
-
-	return &networkingv1beta1.EndpointStatus{
-		Protocol:  protocol,
-		Port:      port,
-		Addresses: addresses,
-	}, nil
-}
-
-func (r *WgGatewayServerReconciler) forgeEndpointStatusNodePort(ctx context.Context, service *corev1.Service,
-	dep *appsv1.Deployment) (*networkingv1beta1.EndpointStatus, *networkingv1beta1.InternalGatewayEndpoint, error) {
-	if len(service.Spec.Ports) == 0 {
-		err := fmt.Errorf("service %s/%s has no ports", service.Namespace, service.Name)
-		klog.Error(err)
-		return nil, nil, err
-	}
-
-	port := service.Spec.Ports[0].NodePort
-	protocol := &service.Spec.Ports[0].Protocol
-
-	podsSelector := client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(gateway.ForgeActiveGatewayPodLabels())}
-	var podList corev1.PodList
-	if err := r.List(ctx, &podList, client.InNamespace(dep.Namespace), podsSelector); err != nil {
-		klog.Errorf("Unable to list pods of deployment %s/%s: %v", dep.Namespace, dep.Name, err)
-		return nil, nil, err
-	}
-
-	if len(podList.Items) != 1 {
-		err := fmt.Errorf("wrong number of pods for deployment %s/%s: %d (must be 1)", dep.Namespace, dep.Name, len(podList.Items))
-		klog.Error(err)
-		return nil, nil, err
-	}
-
-	pod := &podList.Items[0]
-
-	node := &corev1.Node{}
-	err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node)
-	if err != nil && !apierrors.IsNotFound(err) {
-		klog.Errorf("Unable to get node %q: %v", pod.Spec.NodeName, err)
-		return nil, nil, err
-	}
-
-	addresses := make([]string, 1)
-	if utils.IsNodeReady(node) {
-		if addresses[0], err = utils.GetAddress(node); err != nil {
-			klog.Errorf("Unable to get address of node %q: %v", pod.Spec.NodeName, err)
-			return nil, nil, err
+		if errors.Is(err, enutils.ErrUnsupportedServiceType) {
+			wgServer.Status.Endpoint = nil // we empty the endpoint status to avoid misaligned spec and status
 		}
+		return err
 	}
 
-	internalAddress := pod.Status.PodIP
-	if internalAddress == "" {
-		err := fmt.Errorf("pod %s/%s has no IP", pod.Namespace, pod.Name)
-		klog.Error(err)
-		return nil, nil, err
-	}
-
-	return &networkingv1beta1.EndpointStatus{
-			Protocol:  protocol,
-			Port:      port,
-			Addresses: addresses,
-		}, &networkingv1beta1.InternalGatewayEndpoint{
-			IP:   ptr.To(networkingv1beta1.IP(internalAddress)),
-			Node: &pod.Spec.NodeName,
-		}, nil
-}
-
-func (r *WgGatewayServerReconciler) forgeEndpointStatusLoadBalancer(service *corev1.Service) (*networkingv1beta1.EndpointStatus, error) {
-	if len(service.Spec.Ports) == 0 {
-		err := fmt.Errorf("service %s/%s has no ports", service.Namespace, service.Name)
-		klog.Error(err)
-		return nil, err
-	}
-
-	port := service.Spec.Ports[0].Port
-	protocol := &service.Spec.Ports[0].Protocol
-
-	var addresses []string
-	for i := range service.Status.LoadBalancer.Ingress {
-		if hostName := service.Status.LoadBalancer.Ingress[i].Hostname; hostName != "" {
-			addresses = append(addresses, hostName)
-		}
-		if ip := service.Status.LoadBalancer.Ingress[i].IP; ip != "" {
-			addresses = append(addresses, ip)
+	if endpointStatus != nil {
+		if endpointStatus.Replicas, err = enutils.ForgeReplicaEndpointsStatus(ctx, r.Client, svcNsName.Name, dep); err != nil {
+			return err
 		}
 	}
 
-	return &networkingv1beta1.EndpointStatus{
-		Protocol:  protocol,
-		Port:      port,
-		Addresses: addresses,
-	}, nil
+	wgServer.Status.Endpoint = endpointStatus
+	return nil
 }
 
 func (r *WgGatewayServerReconciler) handleSecretRefStatus(ctx context.Context, wgServer *networkingv1beta1.WgGatewayServer) error {
-	secret, err := getWireGuardSecret(ctx, r.Client, wgServer)
-	switch {
-	case apierrors.IsNotFound(err):
-		wgServer.Status.SecretRef = nil
-		return nil
-	case err != nil:
+	secretRef, err := enutils.ForgeSecretRefStatus(ctx, r.Client, wgServer)
+	if err != nil {
 		return err
-	default:
-		wgServer.Status.SecretRef = &corev1.ObjectReference{
-			Name:      secret.Name,
-			Namespace: secret.Namespace,
-		}
-		return nil
 	}
+	wgServer.Status.SecretRef = secretRef
+	return nil
 }
 
 func (r *WgGatewayServerReconciler) handleInternalEndpointStatus(ctx context.Context, wgServer *networkingv1beta1.WgGatewayServer,
 	svcNsName types.NamespacedName, dep *appsv1.Deployment) error {
-	if dep == nil {
-		wgServer.Status.InternalEndpoint = nil
-		return nil
-	}
-
-	var service corev1.Service
-	err := r.Get(ctx, svcNsName, &service)
+	ige, err := enutils.ForgeServerInternalEndpointStatus(ctx, r.Client, svcNsName, dep)
 	if err != nil {
-		klog.Error(err) // raise an error also if service NotFound
 		return err
 	}
 
-	_, ige, err := r.forgeEndpointStatusNodePort(ctx, &service, dep)
-	if err != nil {
-		return err
+	var ies []networkingv1beta1.InternalGatewayEndpoint
+	if dep != nil {
+		if ies, err = enutils.ForgeInternalEndpoints(ctx, r.Client, dep); err != nil {
+			return err
+		}
 	}
 
 	wgServer.Status.InternalEndpoint = ige
+	wgServer.Status.InternalEndpoints = ies
 	return nil
 }
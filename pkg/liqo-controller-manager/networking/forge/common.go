@@ -14,8 +14,62 @@
 
 package forge
 
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+)
+
 // Common default values for the networking module.
 const (
 	DefaultMTU      = 1340
 	DefaultProtocol = "UDP"
 )
+
+// GatewayType identifies the tunnel technology implemented by the gateways.
+type GatewayType string
+
+const (
+	// GatewayTypeWireGuard identifies the gateways based on WireGuard.
+	GatewayTypeWireGuard GatewayType = "wireguard"
+	// GatewayTypeIPsec identifies the gateways based on IPsec (XFRM).
+	GatewayTypeIPsec GatewayType = "ipsec"
+	// DefaultGatewayType is the default tunnel technology.
+	DefaultGatewayType = GatewayTypeWireGuard
+)
+
+// GatewayTemplates returns the default server and client template types and names for the given gateway type.
+func GatewayTemplates(gwType GatewayType) (serverType, serverTemplateName, clientType, clientTemplateName string, err error) {
+	switch gwType {
+	case GatewayTypeWireGuard:
+		return DefaultGwServerType, DefaultGwServerTemplateName, DefaultGwClientType, DefaultGwClientTemplateName, nil
+	case GatewayTypeIPsec:
+		return IPsecGwServerType, IPsecGwServerTemplateName, IPsecGwClientType, IPsecGwClientTemplateName, nil
+	default:
+		return "", "", "", "", fmt.Errorf("unknown gateway type %q", gwType)
+	}
+}
+
+// ConnectionQualityThresholds forges the thresholds on the quality of a connection.
+// Zero values leave the corresponding threshold unset, and nil is returned if no threshold is set.
+func ConnectionQualityThresholds(maxLatency, maxJitter time.Duration, maxPacketLossPercentage int32) *networkingv1beta1.ConnectionQualityThresholds {
+	if maxLatency == 0 && maxJitter == 0 && maxPacketLossPercentage == 0 {
+		return nil
+	}
+
+	thresholds := &networkingv1beta1.ConnectionQualityThresholds{}
+	if maxLatency != 0 {
+		thresholds.MaxLatency = &metav1.Duration{Duration: maxLatency}
+	}
+	if maxJitter != 0 {
+		thresholds.MaxJitter = &metav1.Duration{Duration: maxJitter}
+	}
+	if maxPacketLossPercentage != 0 {
+		thresholds.MaxPacketLossPercentage = ptr.To(maxPacketLossPercentage)
+	}
+	return thresholds
+}
@@ -76,12 +76,12 @@ func ConfigurationForRemoteCluster(ctx context.Context, cl client.Client,
 		return nil, fmt.Errorf("unable to get cluster identity: %w", err)
 	}
 
-	podCIDR, err := ipamutils.GetPodCIDR(ctx, cl, liqoNamespace)
+	podCIDRs, err := ipamutils.GetPodCIDRs(ctx, cl, liqoNamespace)
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve pod CIDR: %w", err)
 	}
 
-	externalCIDR, err := ipamutils.GetExternalCIDR(ctx, cl, liqoNamespace)
+	externalCIDRs, err := ipamutils.GetExternalCIDRs(ctx, cl, liqoNamespace)
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve external CIDR: %w", err)
 	}
@@ -100,8 +100,8 @@ func ConfigurationForRemoteCluster(ctx context.Context, cl client.Client,
 		Spec: networkingv1beta1.ConfigurationSpec{
 			Remote: networkingv1beta1.ClusterConfig{
 				CIDR: networkingv1beta1.ClusterConfigCIDR{
-					Pod:      cidrutils.SetPrimary(networkingv1beta1.CIDR(podCIDR)),
-					External: cidrutils.SetPrimary(networkingv1beta1.CIDR(externalCIDR)),
+					Pod:      cidrutils.FromStrings(podCIDRs),
+					External: cidrutils.FromStrings(externalCIDRs),
 				},
 			},
 		},
@@ -32,6 +32,12 @@ const (
 	DefaultGwClientTemplateName = "wireguard-client"
 )
 
+// Values for the IPsec GatewayClient.
+const (
+	IPsecGwClientType         = "networking.liqo.io/v1beta1/ipsecgatewayclienttemplates"
+	IPsecGwClientTemplateName = "ipsec-client"
+)
+
 // defaultGatewayClientName returns the default name for a GatewayClient.
 func defaultGatewayClientName(remoteClusterID liqov1beta1.ClusterID) string {
 	return string(remoteClusterID)
@@ -45,9 +51,11 @@ type GwClientOptions struct {
 	TemplateName      string
 	TemplateNamespace string
 	MTU               int
+	QualityThresholds *networkingv1beta1.ConnectionQualityThresholds
 	Addresses         []string
 	Port              int32
 	Protocol          string
+	Replicas          []networkingv1beta1.ReplicaEndpointStatus
 }
 
 // GatewayClient forges a GatewayClient.
@@ -86,11 +94,15 @@ func MutateGatewayClient(gwClient *networkingv1beta1.GatewayClient, o *GwClientO
 	// MTU
 	gwClient.Spec.MTU = o.MTU
 
+	// Connection quality thresholds
+	gwClient.Spec.QualityThresholds = o.QualityThresholds
+
 	// Server Endpoint
 	gwClient.Spec.Endpoint = networkingv1beta1.EndpointStatus{
 		Addresses: o.Addresses,
 		Port:      o.Port,
 		Protocol:  ptr.To(corev1.Protocol(o.Protocol)),
+		Replicas:  o.Replicas,
 	}
 
 	// Client Template Reference
@@ -36,6 +36,13 @@ const (
 	DefaultKeysDir              = "/etc/wireguard/keys"
 )
 
+// Values for the IPsec GatewayServer.
+const (
+	IPsecGwServerType         = "networking.liqo.io/v1beta1/ipsecgatewayservertemplates"
+	IPsecGwServerTemplateName = "ipsec-server"
+	DefaultIPsecKeysDir       = "/etc/ipsec/keys"
+)
+
 // defaultGatewayServerName returns the default name for a GatewayServer.
 func defaultGatewayServerName(remoteClusterID liqov1beta1.ClusterID) string {
 	return string(remoteClusterID)
@@ -50,6 +57,7 @@ type GwServerOptions struct {
 	TemplateNamespace string
 	ServiceType       corev1.ServiceType
 	MTU               int
+	QualityThresholds *networkingv1beta1.ConnectionQualityThresholds
 	Port              int32
 	NodePort          *int32
 	LoadBalancerIP    *string
@@ -91,6 +99,9 @@ func MutateGatewayServer(gwServer *networkingv1beta1.GatewayServer, o *GwServerO
 	// MTU
 	gwServer.Spec.MTU = o.MTU
 
+	// Connection quality thresholds
+	gwServer.Spec.QualityThresholds = o.QualityThresholds
+
 	// Server Endpoint
 	gwServer.Spec.Endpoint = networkingv1beta1.Endpoint{
 		Port:        o.Port,
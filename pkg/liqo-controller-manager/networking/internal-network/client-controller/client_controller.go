@@ -26,6 +26,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
@@ -34,7 +35,6 @@ import (
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/internal-network/fabricipam"
 	netutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/utils"
 	"github.com/liqotech/liqo/pkg/utils"
-	cidrutils "github.com/liqotech/liqo/pkg/utils/cidr"
 	"github.com/liqotech/liqo/pkg/utils/getters"
 	"github.com/liqotech/liqo/pkg/utils/resource"
 )
@@ -43,13 +43,16 @@ import (
 type ClientReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Encryption is the encryption mode configured on the InternalFabrics which do not specify one.
+	Encryption networkingv1beta1.InternalFabricEncryptionMode
 }
 
 // NewClientReconciler returns a new ClientReconciler.
-func NewClientReconciler(cl client.Client, s *runtime.Scheme) *ClientReconciler {
+func NewClientReconciler(cl client.Client, s *runtime.Scheme, encryption networkingv1beta1.InternalFabricEncryptionMode) *ClientReconciler {
 	return &ClientReconciler{
-		Client: cl,
-		Scheme: s,
+		Client:     cl,
+		Scheme:     s,
+		Encryption: encryption,
 	}
 }
 
@@ -58,6 +61,7 @@ func NewClientReconciler(cl client.Client, s *runtime.Scheme) *ClientReconciler
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayclients/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=configurations,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=internalfabrics,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=connections,verbs=get;list;watch
 
 // Reconcile manage GatewayClient lifecycle.
 func (r *ClientReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -125,7 +129,14 @@ func (r *ClientReconciler) ensureInternalFabric(ctx context.Context, gwClient *n
 		}
 		internalFabric.Labels[consts.RemoteClusterID] = string(remoteClusterID)
 
-		internalFabric.Spec.MTU = gwClient.Spec.MTU
+		if internalFabric.Spec.Encryption == "" {
+			internalFabric.Spec.Encryption = r.Encryption
+		}
+
+		if internalFabric.Spec.MTU, err = internalnetwork.ForgeInternalFabricMTU(ctx, r.Client, gwClient.Namespace,
+			remoteClusterID, gwClient.Spec.MTU, internalFabric.Spec.Encryption); err != nil {
+			return err
+		}
 
 		internalFabric.Spec.GatewayIP = *gwClient.Status.InternalEndpoint.IP
 
@@ -142,11 +153,16 @@ func (r *ClientReconciler) ensureInternalFabric(ctx context.Context, gwClient *n
 		}
 		internalFabric.Spec.Interface.Gateway.IP = networkingv1beta1.IP(ip.String())
 
-		internalFabric.Spec.RemoteCIDRs = []networkingv1beta1.CIDR{
-			*cidrutils.GetPrimary(configuration.Status.Remote.CIDR.Pod),
-			*cidrutils.GetPrimary(configuration.Status.Remote.CIDR.External),
+		// In active-active mode, the traffic is load balanced across all the connected gateway replicas.
+		if internalFabric.Spec.Replicas, err = internalnetwork.ForgeInternalFabricReplicas(
+			ctx, r.Client, internalFabric, gwClient.Status.InternalEndpoints); err != nil {
+			return err
 		}
 
+		// The remote CIDRs include the ones of both IP families in dual-stack clusters,
+		// and the ones of the clusters reachable in transit through the remote one.
+		internalFabric.Spec.RemoteCIDRs = netutils.GetRemoteRemappedCIDRs(configuration.Status)
+
 		return controllerutil.SetControllerReference(gwClient, internalFabric, r.Scheme)
 	}); err != nil {
 		return err
@@ -160,5 +176,8 @@ func (r *ClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlGatewayClientInternal).
 		Owns(&networkingv1beta1.InternalFabric{}).
 		For(&networkingv1beta1.GatewayClient{}).
+		// The MTU discovered through the tunnel is reported in the status of the Connection.
+		Watches(&networkingv1beta1.Connection{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &networkingv1beta1.GatewayClient{})).
 		Complete(r)
 }
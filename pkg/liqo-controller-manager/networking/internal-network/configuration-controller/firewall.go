@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,23 +33,31 @@ import (
 	"github.com/liqotech/liqo/pkg/utils/resource"
 )
 
+// ensureFirewallConfiguration ensures a FirewallConfiguration for each IP family configured both locally and remotely.
 func (r *ConfigurationReconciler) ensureFirewallConfiguration(ctx context.Context,
 	cfg *networkingv1beta1.Configuration, opts *Options) error {
-	firewall := &networkingv1beta1.FirewallConfiguration{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      generateFirewallConfigurationName(cfg),
-			Namespace: cfg.GetNamespace(),
-		},
+	if cfg.Spec.Local == nil || cfg.Status.Remote == nil {
+		return fmt.Errorf("configuration %q is not yet ready", cfg.Name)
 	}
-	_, err := resource.CreateOrUpdate(ctx, r.Client, firewall, forgeMutateFirewallConfiguration(firewall, cfg, r.Scheme, opts))
-	if err != nil {
-		return err
+	families := cidrutils.GetCommonFamilies(cfg.Spec.Local.CIDR.Pod, cfg.Spec.Local.CIDR.External,
+		cfg.Status.Remote.CIDR.Pod, cfg.Status.Remote.CIDR.External)
+	for _, family := range families {
+		firewall := &networkingv1beta1.FirewallConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateFirewallConfigurationName(cfg, family),
+				Namespace: cfg.GetNamespace(),
+			},
+		}
+		_, err := resource.CreateOrUpdate(ctx, r.Client, firewall, forgeMutateFirewallConfiguration(firewall, cfg, r.Scheme, opts, family))
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func forgeMutateFirewallConfiguration(fwcfg *networkingv1beta1.FirewallConfiguration,
-	cfg *networkingv1beta1.Configuration, scheme *runtime.Scheme, opts *Options) func() error {
+	cfg *networkingv1beta1.Configuration, scheme *runtime.Scheme, opts *Options, family corev1.IPFamily) func() error {
 	return func() error {
 		var err error
 
@@ -61,11 +70,14 @@ func forgeMutateFirewallConfiguration(fwcfg *networkingv1beta1.FirewallConfigura
 			return err
 		}
 
-		fwcfg.Spec.Table.Name = ptr.To(generateFirewallConfigurationName(cfg))
+		fwcfg.Spec.Table.Name = ptr.To(generateFirewallConfigurationName(cfg, family))
 		fwcfg.Spec.Table.Family = ptr.To(firewallapi.TableFamilyIPv4)
+		if family == corev1.IPv6Protocol {
+			fwcfg.Spec.Table.Family = ptr.To(firewallapi.TableFamilyIPv6)
+		}
 		fwcfg.Spec.Table.Chains = []firewallapi.Chain{*forgeFirewallChain()}
 
-		fwcfg.Spec.Table.Chains[0].Rules.NatRules, err = forgeFirewallNatRule(cfg, opts)
+		fwcfg.Spec.Table.Chains[0].Rules.NatRules, err = forgeFirewallNatRule(cfg, opts, family)
 		if err != nil {
 			return err
 		}
@@ -87,113 +99,145 @@ func forgeFirewallChain() *firewallapi.Chain {
 	}
 }
 
-func forgeFirewallNatRule(cfg *networkingv1beta1.Configuration, opts *Options) (natrules []firewallapi.NatRule, err error) {
-	unknownSourceIP, err := ipamutils.GetUnknownSourceIP(cidrutils.GetPrimary(cfg.Spec.Local.CIDR.External).String())
+func forgeFirewallNatRule(cfg *networkingv1beta1.Configuration, opts *Options, family corev1.IPFamily) (natrules []firewallapi.NatRule, err error) {
+	localPodCIDR := cidrutils.GetByFamily(cfg.Spec.Local.CIDR.Pod, family).String()
+	remotePodCIDR := cidrutils.GetByFamily(cfg.Status.Remote.CIDR.Pod, family).String()
+	remoteExternalCIDR := cidrutils.GetByFamily(cfg.Status.Remote.CIDR.External, family).String()
+
+	unknownSourceIP, err := ipamutils.GetUnknownSourceIP(cidrutils.GetByFamily(cfg.Spec.Local.CIDR.External, family).String())
 	if err != nil {
 		return nil, fmt.Errorf("unable to get first IP from CIDR: %w", err)
 	}
 
+	// Traffic forwarded in transit from the other peers (the local cluster acts as transit gateway).
+	// It must come first, as it would be otherwise masqueraded by the following rules.
+	natrules = append(natrules, forgeFirewallTransitBypassNatRules(cfg, family, remotePodCIDR, remoteExternalCIDR)...)
+
 	// Pod CIDR
-	if !opts.FullMasqueradeEnabled {
-		natrules = append(natrules, firewallapi.NatRule{
-			Name: ptr.To(generatePodNatRuleName(cfg)),
-			Match: []firewallapi.Match{
-				{
-					Op: firewallapi.MatchOperationEq,
-					IP: &firewallapi.MatchIP{
-						Position: firewallapi.MatchPositionDst,
-						Value:    cidrutils.GetPrimary(cfg.Status.Remote.CIDR.Pod).String(),
-					},
-				},
-				{
-					Op: firewallapi.MatchOperationEq,
-					IP: &firewallapi.MatchIP{
-						Position: firewallapi.MatchPositionSrc,
-						Value:    cidrutils.GetPrimary(cfg.Spec.Local.CIDR.Pod).String(),
-					},
-				},
-			},
-			NatType: firewallapi.NatTypeSource,
-			To:      ptr.To(cidrutils.GetPrimary(cfg.Spec.Local.CIDR.Pod).String()),
-		})
-	}
+	natrules = append(natrules, forgeFirewallRemoteNatRules(generatePodNatRuleName(cfg), generateNodePortSvcNatRuleName(cfg),
+		remotePodCIDR, localPodCIDR, unknownSourceIP, opts)...)
 
-	natrules = append(natrules, firewallapi.NatRule{
-		Name: ptr.To(generateNodePortSvcNatRuleName(cfg)),
-		Match: []firewallapi.Match{
-			{
-				Op: firewallapi.MatchOperationEq,
-				IP: &firewallapi.MatchIP{
-					Position: firewallapi.MatchPositionDst,
-					Value:    cidrutils.GetPrimary(cfg.Status.Remote.CIDR.Pod).String(),
-				},
-			},
-		},
-		NatType: firewallapi.NatTypeSource,
-		To:      ptr.To(unknownSourceIP),
-	})
-	if !opts.FullMasqueradeEnabled {
-		natrules[1].Match = append(natrules[1].Match, firewallapi.Match{
-			Op: firewallapi.MatchOperationNeq,
-			IP: &firewallapi.MatchIP{
-				Position: firewallapi.MatchPositionSrc,
-				Value:    cidrutils.GetPrimary(cfg.Spec.Local.CIDR.Pod).String(),
-			},
-		})
+	// External CIDR
+	natrules = append(natrules, forgeFirewallRemoteNatRules(generatePodNatRuleNameExt(cfg), generateNodePortSvcNatRuleNameExt(cfg),
+		remoteExternalCIDR, localPodCIDR, unknownSourceIP, opts)...)
+
+	// CIDRs of the clusters reachable in transit through the remote one.
+	for i := range cfg.Status.Remote.Transit {
+		transit := &cfg.Status.Remote.Transit[i]
+		if cidr := cidrutils.GetByFamily(transit.CIDR.Pod, family); !cidrutils.IsVoid(cidr) {
+			natrules = append(natrules, forgeFirewallRemoteNatRules(generatePodNatRuleNameTransit(cfg, transit),
+				generateNodePortSvcNatRuleNameTransit(cfg, transit), cidr.String(), localPodCIDR, unknownSourceIP, opts)...)
+		}
+		if cidr := cidrutils.GetByFamily(transit.CIDR.External, family); !cidrutils.IsVoid(cidr) {
+			natrules = append(natrules, forgeFirewallRemoteNatRules(generatePodNatRuleNameTransitExt(cfg, transit),
+				generateNodePortSvcNatRuleNameTransitExt(cfg, transit), cidr.String(), localPodCIDR, unknownSourceIP, opts)...)
+		}
 	}
+	return natrules, nil
+}
 
-	// External CIDR
+// forgeFirewallRemoteNatRules forges the rules preserving the source address of the traffic sent by the local pods
+// towards the given remote CIDR, while the traffic coming from any other source is masqueraded with the unknown source IP.
+func forgeFirewallRemoteNatRules(podRuleName, nodePortSvcRuleName, remoteCIDR, localPodCIDR, unknownSourceIP string,
+	opts *Options) (natrules []firewallapi.NatRule) {
 	if !opts.FullMasqueradeEnabled {
 		natrules = append(natrules, firewallapi.NatRule{
-			Name: ptr.To(generatePodNatRuleNameExt(cfg)),
+			Name: ptr.To(podRuleName),
 			Match: []firewallapi.Match{
 				{
 					Op: firewallapi.MatchOperationEq,
 					IP: &firewallapi.MatchIP{
 						Position: firewallapi.MatchPositionDst,
-						Value:    cidrutils.GetPrimary(cfg.Status.Remote.CIDR.External).String(),
+						Value:    remoteCIDR,
 					},
 				},
 				{
 					Op: firewallapi.MatchOperationEq,
 					IP: &firewallapi.MatchIP{
 						Position: firewallapi.MatchPositionSrc,
-						Value:    cidrutils.GetPrimary(cfg.Spec.Local.CIDR.Pod).String(),
+						Value:    localPodCIDR,
 					},
 				},
 			},
 			NatType: firewallapi.NatTypeSource,
-			To:      ptr.To(cidrutils.GetPrimary(cfg.Spec.Local.CIDR.Pod).String()),
+			To:      ptr.To(localPodCIDR),
 		})
 	}
 
-	natrules = append(natrules, firewallapi.NatRule{
-		Name: ptr.To(generateNodePortSvcNatRuleNameExt(cfg)),
+	nodePortSvcRule := firewallapi.NatRule{
+		Name: ptr.To(nodePortSvcRuleName),
 		Match: []firewallapi.Match{
 			{
 				Op: firewallapi.MatchOperationEq,
 				IP: &firewallapi.MatchIP{
 					Position: firewallapi.MatchPositionDst,
-					Value:    cidrutils.GetPrimary(cfg.Status.Remote.CIDR.External).String(),
+					Value:    remoteCIDR,
 				},
 			},
 		},
 		NatType: firewallapi.NatTypeSource,
 		To:      ptr.To(unknownSourceIP),
-	})
+	}
 	if !opts.FullMasqueradeEnabled {
-		natrules[3].Match = append(natrules[3].Match, firewallapi.Match{
+		nodePortSvcRule.Match = append(nodePortSvcRule.Match, firewallapi.Match{
 			Op: firewallapi.MatchOperationNeq,
 			IP: &firewallapi.MatchIP{
 				Position: firewallapi.MatchPositionSrc,
-				Value:    cidrutils.GetPrimary(cfg.Spec.Local.CIDR.Pod).String(),
+				Value:    localPodCIDR,
 			},
 		})
 	}
-	return natrules, nil
+	return append(natrules, nodePortSvcRule)
 }
 
-func generateFirewallConfigurationName(cfg *networkingv1beta1.Configuration) string {
+// forgeFirewallTransitBypassNatRules forges the rules preserving the source address of the traffic forwarded
+// in transit from the other peers (whose CIDRs are advertised to the remote cluster) towards the remote CIDRs.
+func forgeFirewallTransitBypassNatRules(cfg *networkingv1beta1.Configuration, family corev1.IPFamily,
+	remotePodCIDR, remoteExternalCIDR string) (natrules []firewallapi.NatRule) {
+	dsts := map[string]string{"pod": remotePodCIDR, "ext": remoteExternalCIDR}
+	for i := range cfg.Spec.Local.Transit {
+		transit := &cfg.Spec.Local.Transit[i]
+		srcs := map[string]*networkingv1beta1.CIDR{
+			"pod": cidrutils.GetByFamily(transit.CIDR.Pod, family),
+			"ext": cidrutils.GetByFamily(transit.CIDR.External, family),
+		}
+		for _, srcKind := range []string{"pod", "ext"} {
+			if cidrutils.IsVoid(srcs[srcKind]) {
+				continue
+			}
+			src := srcs[srcKind].String()
+			for _, dstKind := range []string{"pod", "ext"} {
+				natrules = append(natrules, firewallapi.NatRule{
+					Name: ptr.To(generateTransitBypassNatRuleName(cfg, transit, srcKind, dstKind)),
+					Match: []firewallapi.Match{
+						{
+							Op: firewallapi.MatchOperationEq,
+							IP: &firewallapi.MatchIP{
+								Position: firewallapi.MatchPositionDst,
+								Value:    dsts[dstKind],
+							},
+						},
+						{
+							Op: firewallapi.MatchOperationEq,
+							IP: &firewallapi.MatchIP{
+								Position: firewallapi.MatchPositionSrc,
+								Value:    src,
+							},
+						},
+					},
+					NatType: firewallapi.NatTypeSource,
+					To:      ptr.To(src),
+				})
+			}
+		}
+	}
+	return natrules
+}
+
+func generateFirewallConfigurationName(cfg *networkingv1beta1.Configuration, family corev1.IPFamily) string {
+	if family == corev1.IPv6Protocol {
+		return fmt.Sprintf("%s-masquerade-bypass-v6", cfg.Name)
+	}
 	return fmt.Sprintf("%s-masquerade-bypass", cfg.Name)
 }
 
@@ -212,3 +256,24 @@ func generatePodNatRuleNameExt(cfg *networkingv1beta1.Configuration) string {
 func generateNodePortSvcNatRuleNameExt(cfg *networkingv1beta1.Configuration) string {
 	return fmt.Sprintf("service-nodeport-%s-ext", cfg.Name)
 }
+
+func generatePodNatRuleNameTransit(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig) string {
+	return fmt.Sprintf("podcidr-%s-transit-%s", cfg.Name, transit.ClusterID)
+}
+
+func generateNodePortSvcNatRuleNameTransit(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig) string {
+	return fmt.Sprintf("service-nodeport-%s-transit-%s", cfg.Name, transit.ClusterID)
+}
+
+func generatePodNatRuleNameTransitExt(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig) string {
+	return fmt.Sprintf("podcidr-%s-transit-%s-ext", cfg.Name, transit.ClusterID)
+}
+
+func generateNodePortSvcNatRuleNameTransitExt(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig) string {
+	return fmt.Sprintf("service-nodeport-%s-transit-%s-ext", cfg.Name, transit.ClusterID)
+}
+
+func generateTransitBypassNatRuleName(cfg *networkingv1beta1.Configuration, transit *networkingv1beta1.TransitConfig,
+	srcKind, dstKind string) string {
+	return fmt.Sprintf("transit-%s-%s-to-%s-%s", transit.ClusterID, srcKind, cfg.Name, dstKind)
+}
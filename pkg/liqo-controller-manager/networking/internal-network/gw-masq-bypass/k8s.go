@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
+	netutils "k8s.io/utils/net"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -114,7 +115,11 @@ func forgeFirewallPodUpdateFunction(internalnode *networkingv1beta1.InternalNode
 		fwcfg.Labels[GatewayMasqueradeBypassLabel] = GatewayMasqueradeBypassLabelValue
 
 		fwcfg.Spec.Table.Name = ptr.To(generateFirewallConfigurationName(internalnode.Name))
+		// The geneve tunnels are established through the primary IP of the gateway pods, whose family is the same for the whole cluster.
 		fwcfg.Spec.Table.Family = ptr.To(firewall.TableFamilyIPv4)
+		if netutils.IsIPv6String(pod.Status.PodIP) {
+			fwcfg.Spec.Table.Family = ptr.To(firewall.TableFamilyIPv6)
+		}
 
 		if fwcfg.Spec.Table.Chains == nil || len(fwcfg.Spec.Table.Chains) == 0 {
 			fwcfg.Spec.Table.Chains = []firewall.Chain{{
@@ -17,6 +17,8 @@ package internalnetwork
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/rand"
@@ -55,24 +57,78 @@ func FindFreeInterfaceName(ctx context.Context, cl client.Client, i interface{})
 	}
 }
 
-func findFreeInterfaceNameForInternalFabric(ctx context.Context, cl client.Client) (string, error) {
+// ForgeInternalFabricReplicas returns the additional gateway replicas of an InternalFabric, given the internal endpoints
+// of the connected replicas of a gateway running in active-active mode. The primary replica, whose IP is the GatewayIP
+// of the InternalFabric, is skipped. The node interface names are preserved across updates, and reused when possible.
+func ForgeInternalFabricReplicas(ctx context.Context, cl client.Client, internalFabric *networkingv1beta1.InternalFabric,
+	endpoints []networkingv1beta1.InternalGatewayEndpoint) ([]networkingv1beta1.InternalFabricSpecReplica, error) {
+	currentNames := make(map[networkingv1beta1.IP]string, len(internalFabric.Spec.Replicas))
+	for i := range internalFabric.Spec.Replicas {
+		currentNames[internalFabric.Spec.Replicas[i].GatewayIP] = internalFabric.Spec.Replicas[i].NodeInterfaceName
+	}
+
+	var replicas []networkingv1beta1.InternalFabricSpecReplica
+	var pending []networkingv1beta1.IP
+	for i := range endpoints {
+		if endpoints[i].IP == nil || *endpoints[i].IP == internalFabric.Spec.GatewayIP {
+			continue
+		}
+		ip := *endpoints[i].IP
+		if name, ok := currentNames[ip]; ok {
+			replicas = append(replicas, networkingv1beta1.InternalFabricSpecReplica{GatewayIP: ip, NodeInterfaceName: name})
+			delete(currentNames, ip)
+			continue
+		}
+		pending = append(pending, ip)
+	}
+
+	// The names of the replicas no longer present are reused first, to limit the creation of new interfaces.
+	var freeNames []string
+	for _, name := range currentNames {
+		freeNames = append(freeNames, name)
+	}
+	sort.Strings(freeNames)
+
+	taken := []string{internalFabric.Spec.Interface.Node.Name}
+	for i := range internalFabric.Spec.Replicas {
+		taken = append(taken, internalFabric.Spec.Replicas[i].NodeInterfaceName)
+	}
+	for _, ip := range pending {
+		var name string
+		if len(freeNames) > 0 {
+			name, freeNames = freeNames[0], freeNames[1:]
+		} else {
+			var err error
+			if name, err = findFreeInterfaceNameForInternalFabric(ctx, cl, taken...); err != nil {
+				return nil, err
+			}
+			taken = append(taken, name)
+		}
+		replicas = append(replicas, networkingv1beta1.InternalFabricSpecReplica{GatewayIP: ip, NodeInterfaceName: name})
+	}
+
+	return replicas, nil
+}
+
+func findFreeInterfaceNameForInternalFabric(ctx context.Context, cl client.Client, taken ...string) (string, error) {
 	list, err := getters.ListInternalFabricsByLabels(ctx, cl, labels.Everything())
 	if err != nil {
 		return "", fmt.Errorf("cannot list internal nodes: %w", err)
 	}
 
+	for i := range list.Items {
+		taken = append(taken, list.Items[i].Spec.Interface.Node.Name)
+		for j := range list.Items[i].Spec.Replicas {
+			taken = append(taken, list.Items[i].Spec.Replicas[j].NodeInterfaceName)
+		}
+	}
+
 	ok := false
 	retry := 0
 	var name string
 	for !ok && retry < maxretries {
 		name = forgeInterfaceName()
-		ok = true
-		for i := range list.Items {
-			if list.Items[i].Spec.Interface.Node.Name == name {
-				ok = false
-				break
-			}
-		}
+		ok = !slices.Contains(taken, name)
 		retry++
 	}
 	if !ok {
@@ -28,6 +28,7 @@ import (
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	"github.com/liqotech/liqo/pkg/fabric"
+	cidrutils "github.com/liqotech/liqo/pkg/utils/cidr"
 	"github.com/liqotech/liqo/pkg/utils/resource"
 )
 
@@ -52,15 +53,19 @@ func (r *InternalFabricReconciler) ensureRouteConfiguration(ctx context.Context,
 		// Add route rule for every remote CIDR
 		var rules []networkingv1beta1.Rule
 
+		gwRoute := networkingv1beta1.Route{
+			Dst:   ptr.To(cidrutils.HostCIDR(internalFabric.Spec.Interface.Gateway.IP.String())),
+			Dev:   ptr.To(internalFabric.Spec.Interface.Node.Name),
+			Scope: ptr.To(networkingv1beta1.LinkScope),
+		}
+		// In active-active mode, the gateway IP is reachable through the interface of every replica.
+		if len(internalFabric.Spec.Replicas) > 0 {
+			gwRoute.Dev = nil
+			gwRoute.NextHops = forgeNextHops(internalFabric, nil)
+		}
 		rules = append(rules, networkingv1beta1.Rule{
-			Dst: ptr.To(networkingv1beta1.CIDR(fmt.Sprintf("%s/32", internalFabric.Spec.Interface.Gateway.IP))),
-			Routes: []networkingv1beta1.Route{
-				{
-					Dst:   ptr.To(networkingv1beta1.CIDR(fmt.Sprintf("%s/32", internalFabric.Spec.Interface.Gateway.IP))),
-					Dev:   ptr.To(internalFabric.Spec.Interface.Node.Name),
-					Scope: ptr.To(networkingv1beta1.LinkScope),
-				},
-			},
+			Dst:    ptr.To(cidrutils.HostCIDR(internalFabric.Spec.Interface.Gateway.IP.String())),
+			Routes: []networkingv1beta1.Route{gwRoute},
 		})
 
 		remoteCIDRs := internalFabric.Spec.RemoteCIDRs
@@ -69,14 +74,19 @@ func (r *InternalFabricReconciler) ensureRouteConfiguration(ctx context.Context,
 			return remoteCIDRs[i] < remoteCIDRs[j]
 		})
 		for _, remoteCIDR := range remoteCIDRs {
-			rule := networkingv1beta1.Rule{
-				Routes: []networkingv1beta1.Route{
-					{
-						Dst: ptr.To(remoteCIDR),
-						Gw:  ptr.To(internalFabric.Spec.Interface.Gateway.IP),
-					},
-				},
+			remoteRoute := networkingv1beta1.Route{
 				Dst: ptr.To(remoteCIDR),
+				Gw:  ptr.To(internalFabric.Spec.Interface.Gateway.IP),
+			}
+			// In active-active mode, the traffic is load balanced across the gateway replicas, all sharing the same
+			// gateway IP on their own interface.
+			if len(internalFabric.Spec.Replicas) > 0 {
+				remoteRoute.Gw = nil
+				remoteRoute.NextHops = forgeNextHops(internalFabric, ptr.To(internalFabric.Spec.Interface.Gateway.IP))
+			}
+			rule := networkingv1beta1.Rule{
+				Routes: []networkingv1beta1.Route{remoteRoute},
+				Dst:    ptr.To(remoteCIDR),
 			}
 			rules = append(rules, rule)
 		}
@@ -102,3 +112,22 @@ func (r *InternalFabricReconciler) ensureRouteConfiguration(ctx context.Context,
 func GenerateRouteConfigurationName(internalFabric *networkingv1beta1.InternalFabric) string {
 	return fmt.Sprintf("%s-node-gw", internalFabric.Name)
 }
+
+// forgeNextHops returns a next hop for the primary gateway and for every additional replica of the InternalFabric.
+// If gw is set, the next hops are onlink routes through the given gateway IP.
+func forgeNextHops(internalFabric *networkingv1beta1.InternalFabric, gw *networkingv1beta1.IP) []networkingv1beta1.NextHop {
+	devs := []string{internalFabric.Spec.Interface.Node.Name}
+	for i := range internalFabric.Spec.Replicas {
+		devs = append(devs, internalFabric.Spec.Replicas[i].NodeInterfaceName)
+	}
+
+	nextHops := make([]networkingv1beta1.NextHop, len(devs))
+	for i := range devs {
+		nextHops[i] = networkingv1beta1.NextHop{Dev: ptr.To(devs[i])}
+		if gw != nil {
+			nextHops[i].Gw = ptr.To(*gw)
+			nextHops[i].Onlink = ptr.To(true)
+		}
+	}
+	return nextHops
+}
@@ -0,0 +1,49 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internalnetwork
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// ForgeInternalFabricMTU returns the MTU of the InternalFabric associated with the gateway in the given namespace:
+// the MTU configured on the gateway, lowered to the one discovered through the tunnel (if the discovery is enabled).
+// When the fabric is encrypted, the overhead of the WireGuard peerings carrying the geneve tunnels is subtracted as well.
+func ForgeInternalFabricMTU(ctx context.Context, cl client.Client, namespace string, remoteClusterID liqov1beta1.ClusterID,
+	mtu int, encryption networkingv1beta1.InternalFabricEncryptionMode) (int, error) {
+	connection, err := getters.GetConnectionByClusterIDInNamespace(ctx, cl, string(remoteClusterID), namespace)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return 0, err
+	default:
+		if pathMTU := connection.Status.PathMTU; pathMTU != nil && pathMTU.Value > 0 && pathMTU.Value < mtu {
+			mtu = pathMTU.Value
+		}
+	}
+
+	if encryption == networkingv1beta1.InternalFabricEncryptionModeWireGuard {
+		mtu -= consts.FabricEncryptionOverhead
+	}
+	return mtu, nil
+}
@@ -234,10 +234,10 @@ func forgeRouteConfigurationRules(internalnode *networkingv1beta1.InternalNode,
 	return []networkingv1beta1.Rule{
 		{
 			FwMark: &mark,
-			Dst:    ptr.To(networkingv1beta1.CIDR(fmt.Sprintf("%s/32", nodePortSrcIP))),
+			Dst:    ptr.To(cidrutils.HostCIDR(nodePortSrcIP)),
 			Routes: []networkingv1beta1.Route{
 				{
-					Dst: ptr.To(networkingv1beta1.CIDR(fmt.Sprintf("%s/32", nodePortSrcIP))),
+					Dst: ptr.To(cidrutils.HostCIDR(nodePortSrcIP)),
 					Dev: ptr.To(internalnode.Spec.Interface.Gateway.Name),
 					Gw:  ptr.To(internalnode.Spec.Interface.Node.IP),
 				},
@@ -297,11 +297,21 @@ func forgeRouteConfigurationExtCIDRRules(internalnode *networkingv1beta1.Interna
 	configurations []networkingv1beta1.Configuration, ips []ipamv1alpha1.IP) []networkingv1beta1.Rule {
 	rules := []networkingv1beta1.Rule{}
 	for i := range configurations {
-		rules = append(rules, networkingv1beta1.Rule{
-			Dst:    cidrutils.GetPrimary(configurations[i].Status.Remote.CIDR.Pod),
-			Iif:    ptr.To(tunnel.TunnelInterfaceName),
-			Routes: forgeRouteConfigurationExtCIDRRoutes(internalnode, cidrutils.GetPrimary(configurations[i].Status.Remote.CIDR.Pod)),
-		})
+		// A rule is configured for each remote pod CIDR, one per IP family in dual-stack clusters.
+		dsts := configurations[i].Status.Remote.CIDR.Pod
+		// When the local cluster acts as transit gateway for the remote cluster (i.e., it advertises to it the CIDRs
+		// of other peers), the traffic coming from the other peers is directed to its external CIDRs as well.
+		if configurations[i].Spec.Local != nil && len(configurations[i].Spec.Local.Transit) > 0 {
+			dsts = slices.Concat(dsts, configurations[i].Status.Remote.CIDR.External)
+		}
+		for j := range dsts {
+			dst := &dsts[j]
+			rules = append(rules, networkingv1beta1.Rule{
+				Dst:    dst,
+				Iif:    ptr.To(tunnel.TunnelInterfaceName),
+				Routes: forgeRouteConfigurationExtCIDRRoutes(internalnode, dst),
+			})
+		}
 	}
 	rules = append(rules, networkingv1beta1.Rule{
 		Iif:    ptr.To(tunnel.TunnelInterfaceName),
@@ -323,7 +333,7 @@ func forgeRouteConfigurationExtCIDRRoutesIP(internalnode *networkingv1beta1.Inte
 	routes := []networkingv1beta1.Route{}
 	for i := range ips {
 		routes = append(routes, networkingv1beta1.Route{
-			Dst: ptr.To(networkingv1beta1.CIDR(fmt.Sprintf("%s/32", ips[i].Spec.IP.String()))),
+			Dst: ptr.To(cidrutils.HostCIDR(ips[i].Spec.IP.String())),
 			Dev: ptr.To(internalnode.Spec.Interface.Gateway.Name),
 			Gw:  ptr.To(internalnode.Spec.Interface.Node.IP),
 		})
@@ -31,6 +31,7 @@ import (
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	"github.com/liqotech/liqo/pkg/gateway"
 	"github.com/liqotech/liqo/pkg/gateway/tunnel"
+	cidrutils "github.com/liqotech/liqo/pkg/utils/cidr"
 	"github.com/liqotech/liqo/pkg/utils/resource"
 )
 
@@ -106,9 +107,7 @@ func forgeRoutePodUpdateFunction(internalnode *networkingv1beta1.InternalNode, r
 
 		if routecfg.Spec.Table.Rules == nil || len(routecfg.Spec.Table.Rules) < 1 {
 			routecfg.Spec.Table.Rules = make([]networkingv1beta1.Rule, 1)
-			routecfg.Spec.Table.Rules[0].Dst = ptr.To(networkingv1beta1.CIDR(
-				fmt.Sprintf("%s/32", internalnode.Spec.Interface.Node.IP),
-			))
+			routecfg.Spec.Table.Rules[0].Dst = ptr.To(cidrutils.HostCIDR(internalnode.Spec.Interface.Node.IP.String()))
 		}
 
 		if exists := routeContainsNode(internalnode, &routecfg.Spec.Table.Rules[0]); !exists {
@@ -126,11 +125,15 @@ func forgeRoutePodUpdateFunction(internalnode *networkingv1beta1.InternalNode, r
 			routecfg.Spec.Table.Rules[1].Iif = ptr.To(tunnel.TunnelInterfaceName)
 		}
 
-		if existingroute, exists := routeContainsPod(pod, &routecfg.Spec.Table.Rules[1]); exists {
-			updatePodToRoute(pod, internalnode, existingroute)
-		} else {
-			addPodToRoute(pod, internalnode, &routecfg.Spec.Table.Rules[1])
+		// A route is configured for each IP of the pod, one per IP family in dual-stack clusters.
+		for _, podIP := range getPodIPs(pod) {
+			if existingroute, exists := routeContainsPodIP(podIP, &routecfg.Spec.Table.Rules[1]); exists {
+				updatePodToRoute(podIP, internalnode, existingroute)
+			} else {
+				addPodToRoute(pod, podIP, internalnode, &routecfg.Spec.Table.Rules[1])
+			}
 		}
+		removeStalePodRoutes(pod, &routecfg.Spec.Table.Rules[1])
 
 		return nil
 	}
@@ -145,43 +148,74 @@ func forgeRoutePodDeleteFunction(pod *corev1.Pod, routecfg *networkingv1beta1.Ro
 
 		// We allocate this array statically with length 2.
 		// The rule we are managing is the second one.
-		if existingroute, exists := routeContainsPod(pod, &routecfg.Spec.Table.Rules[1]); exists {
-			routecfg.Spec.Table.Rules[1].Routes = slices.DeleteFunc(routecfg.Spec.Table.Rules[1].Routes, func(r networkingv1beta1.Route) bool {
-				return r.Dst == existingroute.Dst
-			})
-		}
+		routecfg.Spec.Table.Rules[1].Routes = slices.DeleteFunc(routecfg.Spec.Table.Rules[1].Routes, func(r networkingv1beta1.Route) bool {
+			return routeMatchPod(pod, &r)
+		})
 
 		return nil
 	}
 }
 
-func routeContainsPod(pod *corev1.Pod, rule *networkingv1beta1.Rule) (*networkingv1beta1.Route, bool) {
-	for i := range rule.Routes {
-		if string(*rule.Routes[i].Dst) == fmt.Sprintf("%s/32", pod.Status.PodIP) {
-			return &rule.Routes[i], true
+// getPodIPs returns the IPs of the given pod, including the one of the secondary IP family in dual-stack clusters.
+func getPodIPs(pod *corev1.Pod) []string {
+	if len(pod.Status.PodIPs) == 0 {
+		if pod.Status.PodIP == "" {
+			return nil
 		}
-		// This is necessary to detect pods that are not present anymore in etcd but still have a route.
-		if rule.Routes[i].TargetRef != nil &&
-			rule.Routes[i].TargetRef.Name == pod.GetName() &&
-			rule.Routes[i].TargetRef.Namespace == pod.GetNamespace() {
+		return []string{pod.Status.PodIP}
+	}
+	ips := make([]string, len(pod.Status.PodIPs))
+	for i := range pod.Status.PodIPs {
+		ips[i] = pod.Status.PodIPs[i].IP
+	}
+	return ips
+}
+
+// routeMatchPodIPs returns whether the given route targets one of the given pod IPs.
+func routeMatchPodIPs(podIPs []string, route *networkingv1beta1.Route) bool {
+	return route.Dst != nil && slices.ContainsFunc(podIPs, func(podIP string) bool { return *route.Dst == cidrutils.HostCIDR(podIP) })
+}
+
+// routeMatchPod returns whether the given route targets the given pod, either through one of its IPs or its reference.
+// The latter is necessary to detect pods that are not present anymore in etcd but still have a route.
+func routeMatchPod(pod *corev1.Pod, route *networkingv1beta1.Route) bool {
+	if routeMatchPodIPs(getPodIPs(pod), route) {
+		return true
+	}
+	return route.TargetRef != nil &&
+		route.TargetRef.Name == pod.GetName() &&
+		route.TargetRef.Namespace == pod.GetNamespace()
+}
+
+func routeContainsPodIP(podIP string, rule *networkingv1beta1.Rule) (*networkingv1beta1.Route, bool) {
+	for i := range rule.Routes {
+		if routeMatchPodIPs([]string{podIP}, &rule.Routes[i]) {
 			return &rule.Routes[i], true
 		}
 	}
 	return nil, false
 }
 
+// removeStalePodRoutes removes the routes referencing the given pod through an IP it does not own anymore.
+func removeStalePodRoutes(pod *corev1.Pod, rule *networkingv1beta1.Rule) {
+	podIPs := getPodIPs(pod)
+	rule.Routes = slices.DeleteFunc(rule.Routes, func(r networkingv1beta1.Route) bool {
+		return routeMatchPod(pod, &r) && !routeMatchPodIPs(podIPs, &r)
+	})
+}
+
 func routeContainsNode(internalnode *networkingv1beta1.InternalNode, rule *networkingv1beta1.Rule) bool {
 	for i := range rule.Routes {
-		if rule.Routes[i].Dst.String() == fmt.Sprintf("%s/32", internalnode.Spec.Interface.Node.IP) {
+		if rule.Routes[i].Dst.String() == cidrutils.HostCIDR(internalnode.Spec.Interface.Node.IP.String()).String() {
 			return true
 		}
 	}
 	return false
 }
 
-func addPodToRoute(pod *corev1.Pod, internalnode *networkingv1beta1.InternalNode, rule *networkingv1beta1.Rule) {
+func addPodToRoute(pod *corev1.Pod, podIP string, internalnode *networkingv1beta1.InternalNode, rule *networkingv1beta1.Rule) {
 	rule.Routes = append(rule.Routes, networkingv1beta1.Route{
-		Dst: ptr.To(networkingv1beta1.CIDR(fmt.Sprintf("%s/32", pod.Status.PodIP))),
+		Dst: ptr.To(cidrutils.HostCIDR(podIP)),
 		Gw:  ptr.To(internalnode.Spec.Interface.Node.IP),
 		TargetRef: &corev1.ObjectReference{
 			Kind:      pod.GetObjectKind().GroupVersionKind().Kind,
@@ -195,14 +229,14 @@ func addPodToRoute(pod *corev1.Pod, internalnode *networkingv1beta1.InternalNode
 func addNodeToRoute(internalnode *networkingv1beta1.InternalNode, rule *networkingv1beta1.Rule) {
 	rule.Routes = []networkingv1beta1.Route{
 		{
-			Dst:   ptr.To(networkingv1beta1.CIDR(fmt.Sprintf("%s/32", internalnode.Spec.Interface.Node.IP))),
+			Dst:   ptr.To(cidrutils.HostCIDR(internalnode.Spec.Interface.Node.IP.String())),
 			Dev:   &internalnode.Spec.Interface.Gateway.Name,
 			Scope: ptr.To(networkingv1beta1.LinkScope),
 		},
 	}
 }
 
-func updatePodToRoute(pod *corev1.Pod, internalnode *networkingv1beta1.InternalNode, route *networkingv1beta1.Route) {
-	route.Dst = ptr.To(networkingv1beta1.CIDR(fmt.Sprintf("%s/32", pod.Status.PodIP)))
+func updatePodToRoute(podIP string, internalnode *networkingv1beta1.InternalNode, route *networkingv1beta1.Route) {
+	route.Dst = ptr.To(cidrutils.HostCIDR(podIP))
 	route.Gw = ptr.To(internalnode.Spec.Interface.Node.IP)
 }
@@ -26,6 +26,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
@@ -34,7 +35,6 @@ import (
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/internal-network/fabricipam"
 	netutils "github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/utils"
 	"github.com/liqotech/liqo/pkg/utils"
-	cidrutils "github.com/liqotech/liqo/pkg/utils/cidr"
 	"github.com/liqotech/liqo/pkg/utils/getters"
 	"github.com/liqotech/liqo/pkg/utils/resource"
 )
@@ -43,13 +43,16 @@ import (
 type ServerReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Encryption is the encryption mode configured on the InternalFabrics which do not specify one.
+	Encryption networkingv1beta1.InternalFabricEncryptionMode
 }
 
 // NewServerReconciler returns a new ServerReconciler.
-func NewServerReconciler(cl client.Client, s *runtime.Scheme) *ServerReconciler {
+func NewServerReconciler(cl client.Client, s *runtime.Scheme, encryption networkingv1beta1.InternalFabricEncryptionMode) *ServerReconciler {
 	return &ServerReconciler{
-		Client: cl,
-		Scheme: s,
+		Client:     cl,
+		Scheme:     s,
+		Encryption: encryption,
 	}
 }
 
@@ -58,6 +61,7 @@ func NewServerReconciler(cl client.Client, s *runtime.Scheme) *ServerReconciler
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=gatewayservers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=configurations,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.liqo.io,resources=internalfabrics,verbs=get;list;watch;delete;create;update;patch
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=connections,verbs=get;list;watch
 
 // Reconcile manage GatewayServer lifecycle.
 func (r *ServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
@@ -125,7 +129,14 @@ func (r *ServerReconciler) ensureInternalFabric(ctx context.Context, gwServer *n
 		}
 		internalFabric.Labels[consts.RemoteClusterID] = string(remoteClusterID)
 
-		internalFabric.Spec.MTU = gwServer.Spec.MTU
+		if internalFabric.Spec.Encryption == "" {
+			internalFabric.Spec.Encryption = r.Encryption
+		}
+
+		if internalFabric.Spec.MTU, err = internalnetwork.ForgeInternalFabricMTU(ctx, r.Client, gwServer.Namespace,
+			remoteClusterID, gwServer.Spec.MTU, internalFabric.Spec.Encryption); err != nil {
+			return err
+		}
 
 		internalFabric.Spec.GatewayIP = *gwServer.Status.InternalEndpoint.IP
 
@@ -139,11 +150,16 @@ func (r *ServerReconciler) ensureInternalFabric(ctx context.Context, gwServer *n
 		}
 		internalFabric.Spec.Interface.Gateway.IP = networkingv1beta1.IP(ip.String())
 
-		internalFabric.Spec.RemoteCIDRs = []networkingv1beta1.CIDR{
-			*cidrutils.GetPrimary(configuration.Status.Remote.CIDR.Pod),
-			*cidrutils.GetPrimary(configuration.Status.Remote.CIDR.External),
+		// In active-active mode, the traffic is load balanced across all the connected gateway replicas.
+		if internalFabric.Spec.Replicas, err = internalnetwork.ForgeInternalFabricReplicas(
+			ctx, r.Client, internalFabric, gwServer.Status.InternalEndpoints); err != nil {
+			return err
 		}
 
+		// The remote CIDRs include the ones of both IP families in dual-stack clusters,
+		// and the ones of the clusters reachable in transit through the remote one.
+		internalFabric.Spec.RemoteCIDRs = netutils.GetRemoteRemappedCIDRs(configuration.Status)
+
 		return controllerutil.SetControllerReference(gwServer, internalFabric, r.Scheme)
 	}); err != nil {
 		return err
@@ -157,5 +173,8 @@ func (r *ServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlGatewayServerInternal).
 		Owns(&networkingv1beta1.InternalFabric{}).
 		For(&networkingv1beta1.GatewayServer{}).
+		// The MTU discovered through the tunnel is reported in the status of the Connection.
+		Watches(&networkingv1beta1.Connection{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &networkingv1beta1.GatewayServer{})).
 		Complete(r)
 }
@@ -15,6 +15,8 @@
 package utils
 
 import (
+	"slices"
+
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 	cidrutils "github.com/liqotech/liqo/pkg/utils/cidr"
 )
@@ -25,3 +27,16 @@ func IsConfigurationStatusSet(confStatus networkingv1beta1.ConfigurationStatus)
 		!cidrutils.IsVoid(cidrutils.GetPrimary(confStatus.Remote.CIDR.Pod)) &&
 		!cidrutils.IsVoid(cidrutils.GetPrimary(confStatus.Remote.CIDR.External))
 }
+
+// GetRemoteRemappedCIDRs returns the remapped pod and external CIDRs of the remote cluster,
+// followed by the ones of the clusters reachable in transit through it.
+func GetRemoteRemappedCIDRs(confStatus networkingv1beta1.ConfigurationStatus) []networkingv1beta1.CIDR {
+	if confStatus.Remote == nil {
+		return nil
+	}
+	cidrs := slices.Concat(confStatus.Remote.CIDR.Pod, confStatus.Remote.CIDR.External)
+	for i := range confStatus.Remote.Transit {
+		cidrs = slices.Concat(cidrs, confStatus.Remote.Transit[i].CIDR.Pod, confStatus.Remote.Transit[i].CIDR.External)
+	}
+	return cidrs
+}
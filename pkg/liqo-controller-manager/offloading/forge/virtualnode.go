@@ -40,6 +40,7 @@ type VirtualNodeOptions struct {
 	LoadBalancerClasses []liqov1beta1.LoadBalancerType `json:"loadBalancerClasses,omitempty"`
 	NodeLabels          map[string]string              `json:"nodeLabels,omitempty"`
 	NodeSelector        map[string]string              `json:"nodeSelector,omitempty"`
+	DisabledReflectors  []string                       `json:"disabledReflectors,omitempty"`
 }
 
 // VirtualNode forges a VirtualNode resource.
@@ -86,8 +87,9 @@ func MutateVirtualNode(ctx context.Context, cl client.Client, virtualNode *offlo
 		Hard: opts.ResourceList,
 	}
 	virtualNode.Spec.StorageClasses = opts.StorageClasses
-	virtualNode.Spec.IngressClasses = opts.IngressClasses
-	virtualNode.Spec.LoadBalancerClasses = opts.LoadBalancerClasses
+	virtualNode.Spec.IngressClasses = mergeIngressClasses(virtualNode.Spec.IngressClasses, opts.IngressClasses)
+	virtualNode.Spec.LoadBalancerClasses = mergeLoadBalancerClasses(virtualNode.Spec.LoadBalancerClasses, opts.LoadBalancerClasses)
+	virtualNode.Spec.DisabledReflectors = opts.DisabledReflectors
 
 	if runtimeClassName != nil && *runtimeClassName != "" {
 		if virtualNode.Spec.OffloadingPatch == nil {
@@ -121,6 +123,52 @@ func MutateVirtualNode(ctx context.Context, cl client.Client, virtualNode *offlo
 	return nil
 }
 
+// mergeIngressClasses returns the desired ingress classes. The status reflection setting of the desired
+// entries is authoritative when explicitly set, while the one configured by the user on the existing
+// entries with the same name is preserved otherwise.
+func mergeIngressClasses(existing, desired []liqov1beta1.IngressType) []liqov1beta1.IngressType {
+	if desired == nil {
+		return nil
+	}
+
+	reflectStatus := make(map[string]*bool, len(existing))
+	for i := range existing {
+		reflectStatus[existing[i].IngressClassName] = existing[i].ReflectStatus
+	}
+
+	merged := make([]liqov1beta1.IngressType, len(desired))
+	for i := range desired {
+		desired[i].DeepCopyInto(&merged[i])
+		if merged[i].ReflectStatus == nil {
+			merged[i].ReflectStatus = reflectStatus[desired[i].IngressClassName]
+		}
+	}
+	return merged
+}
+
+// mergeLoadBalancerClasses returns the desired load balancer classes. The status reflection setting of the desired
+// entries is authoritative when explicitly set, while the one configured by the user on the existing
+// entries with the same name is preserved otherwise.
+func mergeLoadBalancerClasses(existing, desired []liqov1beta1.LoadBalancerType) []liqov1beta1.LoadBalancerType {
+	if desired == nil {
+		return nil
+	}
+
+	reflectStatus := make(map[string]*bool, len(existing))
+	for i := range existing {
+		reflectStatus[existing[i].LoadBalancerClassName] = existing[i].ReflectStatus
+	}
+
+	merged := make([]liqov1beta1.LoadBalancerType, len(desired))
+	for i := range desired {
+		desired[i].DeepCopyInto(&merged[i])
+		if merged[i].ReflectStatus == nil {
+			merged[i].ReflectStatus = reflectStatus[desired[i].LoadBalancerClassName]
+		}
+	}
+	return merged
+}
+
 // VirtualNodeOptionsFromResourceSlice extracts the VirtualNodeOptions from a ResourceSlice.
 func VirtualNodeOptionsFromResourceSlice(resourceSlice *authv1beta1.ResourceSlice,
 	kubeconfigSecretName string, vkOptionsTemplateRef *corev1.ObjectReference) *VirtualNodeOptions {
@@ -134,5 +182,6 @@ func VirtualNodeOptionsFromResourceSlice(resourceSlice *authv1beta1.ResourceSlic
 		LoadBalancerClasses: resourceSlice.Status.LoadBalancerClasses,
 		NodeLabels:          resourceSlice.Status.NodeLabels,
 		NodeSelector:        resourceSlice.Status.NodeSelector,
+		DisabledReflectors:  resourceSlice.Status.DisabledReflectors,
 	}
 }
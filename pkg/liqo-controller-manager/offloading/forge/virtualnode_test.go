@@ -0,0 +1,93 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/forge"
+)
+
+var _ = Describe("VirtualNode forging", func() {
+	var (
+		virtualNode *offloadingv1beta1.VirtualNode
+		opts        *forge.VirtualNodeOptions
+	)
+
+	mutate := func() {
+		cl := fake.NewClientBuilder().Build()
+		Expect(forge.MutateVirtualNode(context.Background(), cl, virtualNode, "remote-cluster-id", opts, nil, nil, nil)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		virtualNode = forge.VirtualNode("name", "namespace")
+		opts = &forge.VirtualNodeOptions{
+			IngressClasses:      []liqov1beta1.IngressType{{IngressClassName: "nginx", Default: true}},
+			LoadBalancerClasses: []liqov1beta1.LoadBalancerType{{LoadBalancerClassName: "metallb", Default: true}},
+		}
+	})
+
+	When("the status reflection is enabled on the existing virtual node", func() {
+		BeforeEach(func() {
+			mutate()
+			virtualNode.Spec.IngressClasses[0].ReflectStatus = ptr.To(true)
+			virtualNode.Spec.LoadBalancerClasses[0].ReflectStatus = ptr.To(true)
+		})
+
+		It("should preserve it when the desired classes do not set it", func() {
+			mutate()
+			Expect(virtualNode.Spec.IngressClasses).To(ConsistOf(liqov1beta1.IngressType{
+				IngressClassName: "nginx", Default: true, ReflectStatus: ptr.To(true)}))
+			Expect(virtualNode.Spec.LoadBalancerClasses).To(ConsistOf(liqov1beta1.LoadBalancerType{
+				LoadBalancerClassName: "metallb", Default: true, ReflectStatus: ptr.To(true)}))
+		})
+
+		It("should turn it off when the desired classes explicitly disable it", func() {
+			opts.IngressClasses[0].ReflectStatus = ptr.To(false)
+			opts.LoadBalancerClasses[0].ReflectStatus = ptr.To(false)
+			mutate()
+			Expect(virtualNode.Spec.IngressClasses[0].ReflectStatus).To(Equal(ptr.To(false)))
+			Expect(virtualNode.Spec.LoadBalancerClasses[0].ReflectStatus).To(Equal(ptr.To(false)))
+		})
+
+		It("should turn it off when the user disables it", func() {
+			virtualNode.Spec.IngressClasses[0].ReflectStatus = ptr.To(false)
+			virtualNode.Spec.LoadBalancerClasses[0].ReflectStatus = nil
+			mutate()
+			Expect(virtualNode.Spec.IngressClasses[0].ReflectStatus).To(Equal(ptr.To(false)))
+			Expect(virtualNode.Spec.LoadBalancerClasses[0].ReflectStatus).To(BeNil())
+		})
+
+		It("should drop it once the class is no longer offered", func() {
+			opts.IngressClasses = []liqov1beta1.IngressType{{IngressClassName: "traefik", Default: true}}
+			mutate()
+			Expect(virtualNode.Spec.IngressClasses).To(ConsistOf(liqov1beta1.IngressType{IngressClassName: "traefik", Default: true}))
+		})
+	})
+
+	It("should enable it when the desired classes explicitly enable it", func() {
+		opts.IngressClasses[0].ReflectStatus = ptr.To(true)
+		mutate()
+		Expect(virtualNode.Spec.IngressClasses[0].ReflectStatus).To(Equal(ptr.To(true)))
+		Expect(virtualNode.Spec.LoadBalancerClasses[0].ReflectStatus).To(BeNil())
+	})
+})
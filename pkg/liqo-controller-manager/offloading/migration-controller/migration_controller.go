@@ -0,0 +1,580 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrationctrl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	k8shelper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils"
+)
+
+const (
+	// migrationControllerFinalizer is the finalizer added to Migrations, to release the gated pods upon deletion.
+	migrationControllerFinalizer = "migration.offloading.liqo.io/finalizer"
+
+	// selectedNodeAnnotationKey is the annotation set on PVCs to track the node their volume is provisioned on.
+	selectedNodeAnnotationKey = "volume.kubernetes.io/selected-node"
+
+	// requeuePeriod is the period after which the migration is reconciled again while waiting for pods to terminate.
+	requeuePeriod = 5 * time.Second
+)
+
+// MigrationReconciler reconciles a Migration object.
+type MigrationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Namespace is the namespace where the volume mover jobs (and the corresponding secrets) are created.
+	Namespace                 string
+	VolumeMoverImage          string
+	VolumeMoverServiceAccount string
+}
+
+// NewMigrationReconciler returns a new MigrationReconciler, moving volumes through the given volume mover image.
+func NewMigrationReconciler(cl client.Client, s *runtime.Scheme, recorder record.EventRecorder,
+	namespace, volumeMoverImage, volumeMoverServiceAccount string) *MigrationReconciler {
+	return &MigrationReconciler{
+		Client:                    cl,
+		Scheme:                    s,
+		Recorder:                  recorder,
+		Namespace:                 namespace,
+		VolumeMoverImage:          volumeMoverImage,
+		VolumeMoverServiceAccount: volumeMoverServiceAccount,
+	}
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=migrations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=migrations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=migrations/finalizers,verbs=update
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespaceoffloadings,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update;delete
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// Reconcile moves the pods selected by a Migration from the source to the target node, one phase at a time.
+func (r *MigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var migration offloadingv1beta1.Migration
+	if err := r.Get(ctx, req.NamespacedName, &migration); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(4).Infof("Migration %q not found", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		klog.Errorf("Failed to retrieve Migration %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	if !migration.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, &migration)
+	}
+
+	if !controllerutil.ContainsFinalizer(&migration, migrationControllerFinalizer) {
+		controllerutil.AddFinalizer(&migration, migrationControllerFinalizer)
+		if err := r.Update(ctx, &migration); err != nil {
+			klog.Errorf("Failed to add finalizer to Migration %q: %v", req.NamespacedName, err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	switch migration.Status.Phase {
+	case "", offloadingv1beta1.PendingMigrationPhaseType:
+		return ctrl.Result{}, r.handlePending(ctx, &migration)
+	case offloadingv1beta1.EvictingMigrationPhaseType:
+		return r.handleEvicting(ctx, &migration)
+	case offloadingv1beta1.MovingVolumesMigrationPhaseType:
+		return r.handleMovingVolumes(ctx, &migration)
+	case offloadingv1beta1.RollingBackMigrationPhaseType:
+		return r.handleRollingBack(ctx, &migration)
+	default:
+		// The migration already completed, hence there is nothing else to do.
+		return ctrl.Result{}, nil
+	}
+}
+
+// handlePending validates the migration, and starts evicting the selected pods from the source node.
+func (r *MigrationReconciler) handlePending(ctx context.Context, migration *offloadingv1beta1.Migration) error {
+	targetNode, err := r.validate(ctx, migration)
+	if err != nil {
+		klog.Warningf("Migration %q is not valid: %v", klog.KObj(migration), err)
+		r.Recorder.Eventf(migration, corev1.EventTypeWarning, "Invalid", "Invalid migration: %v", err)
+		migration.Status.Phase = offloadingv1beta1.FailedMigrationPhaseType
+		migration.Status.Message = err.Error()
+		migration.Status.CompletionTime = ptrNow()
+		return r.Status().Update(ctx, migration)
+	}
+
+	klog.Infof("Migrating pods of Migration %q from node %q to node %q", klog.KObj(migration), migration.Spec.SourceNode, targetNode)
+	r.Recorder.Eventf(migration, corev1.EventTypeNormal, "Started", "Migrating pods from node %q to node %q", migration.Spec.SourceNode, targetNode)
+	migration.Status.Phase = offloadingv1beta1.EvictingMigrationPhaseType
+	migration.Status.TargetNode = targetNode
+	migration.Status.Message = "Evicting the selected pods from the source node"
+	migration.Status.StartTime = ptrNow()
+	return r.Status().Update(ctx, migration)
+}
+
+// handleEvicting evicts the selected pods still hosted by the source node, so that they are recreated by their controllers.
+// The source node is cordoned for the selected pods by the pod webhook since the creation of the migration, with their
+// replacements pinned to the target node. Hence, the selected pods created beforehand and not yet scheduled are evicted
+// as well, as they might otherwise land on the source node. Evictions honor the PodDisruptionBudgets, hence the pods whose
+// eviction is not currently allowed are retried later. It moves to the next phase once all of them have terminated.
+func (r *MigrationReconciler) handleEvicting(ctx context.Context, migration *offloadingv1beta1.Migration) (ctrl.Result, error) {
+	pods, err := r.listSourcePods(ctx, migration)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	uncordoned, err := r.listUncordonedPods(ctx, migration)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if len(pods)+len(uncordoned) > 0 {
+		for _, pod := range slices.Concat(pods, uncordoned) {
+			if pod.Spec.NodeName != "" && !slices.Contains(migration.Status.Pods, pod.Name) {
+				migration.Status.Pods = append(migration.Status.Pods, pod.Name)
+			}
+			if !pod.DeletionTimestamp.IsZero() {
+				continue
+			}
+			switch err := evictPod(ctx, r.Client, pod); {
+			case apierrors.IsTooManyRequests(err):
+				// The eviction would violate a PodDisruptionBudget: retry later, once the other pods have been recreated.
+				klog.Infof("Eviction of pod %q from node %q not currently allowed (migration: %q): %v",
+					klog.KObj(pod), migration.Spec.SourceNode, klog.KObj(migration), err)
+				continue
+			case client.IgnoreNotFound(err) != nil:
+				klog.Errorf("Failed to evict pod %q: %v", klog.KObj(pod), err)
+				return ctrl.Result{}, err
+			}
+			klog.Infof("Pod %q evicted (node: %q, migration: %q)", klog.KObj(pod), pod.Spec.NodeName, klog.KObj(migration))
+		}
+
+		// Wait for the pods to terminate, before moving their volumes.
+		return ctrl.Result{RequeueAfter: requeuePeriod}, r.Status().Update(ctx, migration)
+	}
+
+	migration.Status.Phase = offloadingv1beta1.MovingVolumesMigrationPhaseType
+	migration.Status.Message = "Moving the volumes of the selected pods to the target node"
+	return ctrl.Result{}, r.Status().Update(ctx, migration)
+}
+
+// handleMovingVolumes moves the volumes mounted by the migrated pods from the source to the target node, one at a time,
+// through the volume mover job. Once completed, the migrated pods are released, and scheduled on the target node.
+func (r *MigrationReconciler) handleMovingVolumes(ctx context.Context, migration *offloadingv1beta1.Migration) (ctrl.Result, error) {
+	pods, err := r.listGatedPods(ctx, migration)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if migration.Status.CurrentVolume == "" {
+		pvcs, err := r.listSourceVolumes(ctx, migration, pods)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if len(pvcs) > 0 {
+			if mounted, err := r.isMounted(ctx, pvcs[0]); err != nil || mounted {
+				// Wait for the volume to be released, before moving it.
+				return ctrl.Result{RequeueAfter: requeuePeriod}, err
+			}
+
+			// Record the volume before starting the move, to track its progress across reconciliations.
+			migration.Status.CurrentVolume = pvcs[0].Name
+			return ctrl.Result{}, r.Status().Update(ctx, migration)
+		}
+
+		// All volumes have been moved: let the migrated pods be scheduled on the target node.
+		if err := r.ungatePods(ctx, pods); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.cleanupVolumeMover(ctx, migration); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		klog.Infof("Migration %q completed successfully", klog.KObj(migration))
+		r.Recorder.Eventf(migration, corev1.EventTypeNormal, "Succeeded", "Pods moved to node %q", migration.Status.TargetNode)
+		migration.Status.Phase = offloadingv1beta1.SucceededMigrationPhaseType
+		migration.Status.Message = "The selected pods have been moved to the target node"
+		migration.Status.CompletionTime = ptrNow()
+		return ctrl.Result{}, r.Status().Update(ctx, migration)
+	}
+
+	pvcName := migration.Status.CurrentVolume
+	result, err := r.reconcileVolumeMove(ctx, migration, pvcName, migration.Status.TargetNode)
+	switch {
+	case err != nil:
+		return ctrl.Result{}, err
+	case result == volumeMoveInProgress:
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	case result == volumeMoveFailed:
+		// The volume mover restores the data into the source node in case of failures.
+		klog.Errorf("Failed to move PVC %s/%s to node %q", migration.Namespace, pvcName, migration.Status.TargetNode)
+		r.Recorder.Eventf(migration, corev1.EventTypeWarning, "Failed", "Failed to move volume %q", pvcName)
+		migration.Status.Phase = offloadingv1beta1.RollingBackMigrationPhaseType
+		migration.Status.Message = fmt.Sprintf("Failed to move volume %q (see the logs of the %s job)", pvcName, volumeMoverName(migration))
+	default:
+		klog.Infof("PVC %s/%s moved to node %q (migration: %q)", migration.Namespace, pvcName, migration.Status.TargetNode, klog.KObj(migration))
+		migration.Status.Volumes = append(migration.Status.Volumes, pvcName)
+	}
+
+	// Record the outcome before deleting the job, to avoid moving the same volume again in case of failures.
+	migration.Status.CurrentVolume = ""
+	if err := r.Status().Update(ctx, migration); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.deleteVolumeMoverJob(ctx, migration)
+}
+
+// handleRollingBack moves the volumes already moved to the target node back to the source node, one at a time.
+// Then, it releases the migrated pods, whose replacements are pinned to the source node by the pod webhook.
+func (r *MigrationReconciler) handleRollingBack(ctx context.Context, migration *offloadingv1beta1.Migration) (ctrl.Result, error) {
+	if len(migration.Status.Volumes) > 0 {
+		// Restore the volumes in reverse order, starting from the last moved one.
+		pvcName := migration.Status.Volumes[len(migration.Status.Volumes)-1]
+		if migration.Status.CurrentVolume != pvcName {
+			migration.Status.CurrentVolume = pvcName
+			return ctrl.Result{}, r.Status().Update(ctx, migration)
+		}
+
+		result, err := r.reconcileVolumeMove(ctx, migration, pvcName, migration.Spec.SourceNode)
+		switch {
+		case err != nil:
+			return ctrl.Result{}, err
+		case result == volumeMoveInProgress:
+			return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		case result == volumeMoveFailed:
+			// The volume mover restores the data into the target node in case of failures, hence preventing data loss.
+			klog.Errorf("Failed to restore PVC %s/%s into node %q", migration.Namespace, pvcName, migration.Spec.SourceNode)
+			r.Recorder.Eventf(migration, corev1.EventTypeWarning, "RollbackFailed",
+				"Failed to restore volume %q into node %q, it is left on node %q", pvcName, migration.Spec.SourceNode, migration.Status.TargetNode)
+			migration.Status.Message = fmt.Sprintf("%s; failed to restore volume %q into node %q",
+				migration.Status.Message, pvcName, migration.Spec.SourceNode)
+		default:
+			klog.Infof("PVC %s/%s restored into node %q (migration: %q)", migration.Namespace, pvcName, migration.Spec.SourceNode, klog.KObj(migration))
+		}
+
+		migration.Status.Volumes = migration.Status.Volumes[:len(migration.Status.Volumes)-1]
+		migration.Status.CurrentVolume = ""
+		if err := r.Status().Update(ctx, migration); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.deleteVolumeMoverJob(ctx, migration)
+	}
+
+	released, err := r.releasePods(ctx, migration)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if released > 0 {
+		// Wait before completing the rollback, so that the replacements of the released pods (promptly recreated by
+		// their controllers) are still pinned to the source node, rather than being scheduled on any node.
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+	if err := r.cleanupVolumeMover(ctx, migration); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	klog.Infof("Migration %q rolled back", klog.KObj(migration))
+	migration.Status.Phase = offloadingv1beta1.FailedMigrationPhaseType
+	migration.Status.CompletionTime = ptrNow()
+	return ctrl.Result{}, r.Status().Update(ctx, migration)
+}
+
+// handleDeletion waits for the volume mover job of the migration to complete (as interrupting it might lead to data loss),
+// releases the pods still gated by the migration, and removes the finalizer.
+func (r *MigrationReconciler) handleDeletion(ctx context.Context, migration *offloadingv1beta1.Migration) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(migration, migrationControllerFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	job, err := r.getVolumeMoverJob(ctx, migration)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if job != nil {
+		if finished, _ := isJobFinished(job); !finished {
+			klog.Infof("Migration %q is being deleted, waiting for the volume mover job to complete", klog.KObj(migration))
+			return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		}
+	}
+
+	if err := r.cleanupVolumeMover(ctx, migration); err != nil {
+		return ctrl.Result{}, err
+	}
+	if _, err := r.releasePods(ctx, migration); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(migration, migrationControllerFinalizer)
+	return ctrl.Result{}, r.Update(ctx, migration)
+}
+
+// validate checks whether the migration can be performed, and returns the name of the target node.
+func (r *MigrationReconciler) validate(ctx context.Context, migration *offloadingv1beta1.Migration) (string, error) {
+	var nsoff offloadingv1beta1.NamespaceOffloading
+	if err := r.Get(ctx, client.ObjectKey{Namespace: migration.Namespace, Name: consts.DefaultNamespaceOffloadingName}, &nsoff); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("namespace %q is not offloaded", migration.Namespace)
+		}
+		return "", err
+	}
+	if nsoff.Spec.PodOffloadingStrategy == offloadingv1beta1.LocalPodOffloadingStrategyType {
+		return "", fmt.Errorf("pod offloading is disabled for namespace %q", migration.Namespace)
+	}
+
+	var source corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: migration.Spec.SourceNode}, &source); err != nil {
+		return "", fmt.Errorf("failed to retrieve source node %q: %w", migration.Spec.SourceNode, err)
+	}
+	if !utils.IsVirtualNode(&source) {
+		return "", fmt.Errorf("source node %q is not a virtual node", source.Name)
+	}
+
+	pods, err := r.listSourcePods(ctx, migration)
+	if err != nil {
+		return "", err
+	}
+	uncordoned, err := r.listUncordonedPods(ctx, migration)
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range slices.Concat(pods, uncordoned) {
+		if metav1.GetControllerOf(pod) == nil {
+			return "", fmt.Errorf("pod %q is not managed by a controller, hence it cannot be recreated", pod.Name)
+		}
+	}
+
+	if migration.Spec.TargetNode != "" {
+		var target corev1.Node
+		if err := r.Get(ctx, client.ObjectKey{Name: migration.Spec.TargetNode}, &target); err != nil {
+			return "", fmt.Errorf("failed to retrieve target node %q: %w", migration.Spec.TargetNode, err)
+		}
+		if err := checkTargetNode(&target, &source, &nsoff.Spec.ClusterSelector); err != nil {
+			return "", err
+		}
+		return target.Name, nil
+	}
+
+	// No target node specified: select the first suitable virtual node.
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes, client.MatchingLabels{consts.TypeLabel: consts.TypeNode}); err != nil {
+		return "", fmt.Errorf("failed to list virtual nodes: %w", err)
+	}
+	slices.SortFunc(nodes.Items, func(a, b corev1.Node) int { return strings.Compare(a.Name, b.Name) })
+	for i := range nodes.Items {
+		if checkTargetNode(&nodes.Items[i], &source, &nsoff.Spec.ClusterSelector) == nil {
+			return nodes.Items[i].Name, nil
+		}
+	}
+	return "", fmt.Errorf("no virtual node matching the ClusterSelector of namespace %q is available", migration.Namespace)
+}
+
+// checkTargetNode checks whether the given node is a suitable target for the migration.
+func checkTargetNode(target, source *corev1.Node, selector *corev1.NodeSelector) error {
+	switch {
+	case target.Name == source.Name:
+		return fmt.Errorf("target node %q is the same as the source one", target.Name)
+	case !utils.IsVirtualNode(target):
+		return fmt.Errorf("target node %q is not a virtual node", target.Name)
+	case !utils.IsNodeReady(target):
+		return fmt.Errorf("target node %q is not ready", target.Name)
+	}
+
+	// A cluster selector with no NodeSelectorTerms matches all clusters.
+	if len(selector.NodeSelectorTerms) == 0 {
+		return nil
+	}
+	match, err := k8shelper.MatchNodeSelectorTerms(target, selector)
+	if err != nil {
+		return fmt.Errorf("invalid ClusterSelector: %w", err)
+	}
+	if !match {
+		return fmt.Errorf("target node %q does not match the ClusterSelector", target.Name)
+	}
+	return nil
+}
+
+// listSourcePods returns the pods selected by the migration which are hosted by the source node.
+func (r *MigrationReconciler) listSourcePods(ctx context.Context, migration *offloadingv1beta1.Migration) ([]*corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&migration.Spec.PodSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod selector: %w", err)
+	}
+
+	return r.listPods(ctx, migration.Namespace, selector, func(pod *corev1.Pod) bool {
+		return pod.Spec.NodeName == migration.Spec.SourceNode
+	})
+}
+
+// listUncordonedPods returns the pods selected by the migration which are not yet scheduled, and have been created before
+// the source node was cordoned for them (i.e., they are not constrained by any migration).
+func (r *MigrationReconciler) listUncordonedPods(ctx context.Context, migration *offloadingv1beta1.Migration) ([]*corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&migration.Spec.PodSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod selector: %w", err)
+	}
+
+	return r.listPods(ctx, migration.Namespace, selector, func(pod *corev1.Pod) bool {
+		_, constrained := pod.Annotations[consts.MigrationAnnotationKey]
+		return pod.Spec.NodeName == "" && !constrained && len(pod.Spec.SchedulingGates) == 0
+	})
+}
+
+// listGatedPods returns the pods created while the migration is in progress, and still waiting to be scheduled.
+func (r *MigrationReconciler) listGatedPods(ctx context.Context, migration *offloadingv1beta1.Migration) ([]*corev1.Pod, error) {
+	return r.listPods(ctx, migration.Namespace, labels.Everything(), func(pod *corev1.Pod) bool {
+		return pod.Annotations[consts.MigrationAnnotationKey] == migration.Name && isGated(pod)
+	})
+}
+
+func (r *MigrationReconciler) listPods(ctx context.Context, namespace string, selector labels.Selector,
+	filter func(*corev1.Pod) bool) ([]*corev1.Pod, error) {
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %q: %w", namespace, err)
+	}
+
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		if filter(&podList.Items[i]) {
+			pods = append(pods, &podList.Items[i])
+		}
+	}
+	return pods, nil
+}
+
+// listSourceVolumes returns the PVCs mounted by the given pods, whose volume is stored in the source node
+// and has not been moved yet.
+func (r *MigrationReconciler) listSourceVolumes(ctx context.Context, migration *offloadingv1beta1.Migration,
+	pods []*corev1.Pod) ([]*corev1.PersistentVolumeClaim, error) {
+	var pvcs []*corev1.PersistentVolumeClaim
+	for _, pod := range pods {
+		for i := range pod.Spec.Volumes {
+			volume := &pod.Spec.Volumes[i]
+			if volume.PersistentVolumeClaim == nil || slices.Contains(migration.Status.Volumes, volume.PersistentVolumeClaim.ClaimName) ||
+				slices.ContainsFunc(pvcs, func(pvc *corev1.PersistentVolumeClaim) bool {
+					return pvc.Name == volume.PersistentVolumeClaim.ClaimName
+				}) {
+				continue
+			}
+
+			var pvc corev1.PersistentVolumeClaim
+			if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}, &pvc); err != nil {
+				return nil, fmt.Errorf("failed to retrieve PVC %q: %w", volume.PersistentVolumeClaim.ClaimName, err)
+			}
+			if pvc.Annotations[selectedNodeAnnotationKey] == migration.Spec.SourceNode {
+				pvcs = append(pvcs, &pvc)
+			}
+		}
+	}
+	return pvcs, nil
+}
+
+// isMounted returns whether the given PVC is used by any pod already scheduled on a node.
+func (r *MigrationReconciler) isMounted(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	pods, err := r.listPods(ctx, pvc.Namespace, labels.Everything(), func(pod *corev1.Pod) bool {
+		return pod.Spec.NodeName != "" && slices.ContainsFunc(pod.Spec.Volumes, func(volume corev1.Volume) bool {
+			return volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvc.Name
+		})
+	})
+	return len(pods) > 0, err
+}
+
+// ungatePods removes the migration scheduling gate from the given pods.
+func (r *MigrationReconciler) ungatePods(ctx context.Context, pods []*corev1.Pod) error {
+	for _, pod := range pods {
+		pod.Spec.SchedulingGates = slices.DeleteFunc(pod.Spec.SchedulingGates, func(gate corev1.PodSchedulingGate) bool {
+			return gate.Name == consts.MigrationSchedulingGate
+		})
+		if err := r.Update(ctx, pod); client.IgnoreNotFound(err) != nil {
+			klog.Errorf("Failed to remove the scheduling gate from pod %q: %v", klog.KObj(pod), err)
+			return err
+		}
+	}
+	return nil
+}
+
+// releasePods evicts the pods still gated by the migration, so that they are recreated by their controllers, and returns
+// their number. Being pending, gated pods can always be evicted, regardless of the PodDisruptionBudgets.
+func (r *MigrationReconciler) releasePods(ctx context.Context, migration *offloadingv1beta1.Migration) (int, error) {
+	pods, err := r.listGatedPods(ctx, migration)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, pod := range pods {
+		if err := evictPod(ctx, r.Client, pod); client.IgnoreNotFound(err) != nil {
+			klog.Errorf("Failed to release pod %q: %v", klog.KObj(pod), err)
+			return 0, err
+		}
+	}
+	return len(pods), nil
+}
+
+// evictPod evicts the given pod through the eviction subresource, which honors the PodDisruptionBudgets.
+func evictPod(ctx context.Context, cl client.Client, pod *corev1.Pod) error {
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+	return cl.SubResource("eviction").Create(ctx, pod, eviction)
+}
+
+func isGated(pod *corev1.Pod) bool {
+	return slices.ContainsFunc(pod.Spec.SchedulingGates, func(gate corev1.PodSchedulingGate) bool {
+		return gate.Name == consts.MigrationSchedulingGate
+	})
+}
+
+func ptrNow() *metav1.Time {
+	now := metav1.Now()
+	return &now
+}
+
+// SetupWithManager registers a new controller for Migration resources.
+func (r *MigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	isVolumeMoverJob := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		_, found := obj.GetAnnotations()[volumeMoverMigrationAnnotationKey]
+		return obj.GetNamespace() == r.Namespace && found
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlMigration).
+		For(&offloadingv1beta1.Migration{}).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(volumeMoverJobToMigration), builder.WithPredicates(isVolumeMoverJob)).
+		Complete(r)
+}
@@ -0,0 +1,389 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrationctrl
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/volumemover"
+)
+
+var _ = Describe("MigrationController", func() {
+	const (
+		ns         = "default"
+		name       = "migration"
+		sourceNode = "liqo-source"
+		targetNode = "liqo-target"
+		liqoNs     = "liqo"
+	)
+
+	var (
+		ctx        context.Context
+		fakeClient client.WithWatch
+		funcs      interceptor.Funcs
+		reconciler *MigrationReconciler
+		migration  *offloadingv1beta1.Migration
+
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+
+		newVirtualNode = func(name string) *corev1.Node {
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{consts.TypeLabel: consts.TypeNode}},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				},
+			}
+		}
+
+		newPod = func(name, node string, controlled bool) *corev1.Pod {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: map[string]string{"app": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+					NodeName:   node,
+				},
+			}
+			if controlled {
+				pod.OwnerReferences = []metav1.OwnerReference{{
+					APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "test", UID: "uid", Controller: ptr.To(true),
+				}}
+			}
+			return pod
+		}
+
+		newGatedPod = func(name, pvc string) *corev1.Pod {
+			pod := newPod(name, "", true)
+			pod.Annotations = map[string]string{consts.MigrationAnnotationKey: migration.Name}
+			pod.Spec.SchedulingGates = []corev1.PodSchedulingGate{{Name: consts.MigrationSchedulingGate}}
+			pod.Spec.Volumes = []corev1.Volume{{
+				Name:         "data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc}},
+			}}
+			return pod
+		}
+
+		newPVC = func(name, node string) *corev1.PersistentVolumeClaim {
+			return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: ns, Annotations: map[string]string{selectedNodeAnnotationKey: node},
+			}}
+		}
+
+		setup = func(objs ...client.Object) {
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).
+				WithObjects(append(objs, migration)...).
+				WithStatusSubresource(&offloadingv1beta1.Migration{}).
+				WithInterceptorFuncs(funcs).Build()
+			reconciler = NewMigrationReconciler(fakeClient, scheme.Scheme, record.NewFakeRecorder(100),
+				liqoNs, "volume-mover", "liqo-volume-mover")
+		}
+
+		newJob = func(owner *offloadingv1beta1.Migration, pvc, node string) *batchv1.Job {
+			return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name: volumeMoverName(owner), Namespace: liqoNs,
+				Annotations: map[string]string{
+					volumeMoverMigrationAnnotationKey:  migrationKey(owner),
+					volumeMoverVolumeAnnotationKey:     pvc,
+					volumeMoverTargetNodeAnnotationKey: node,
+				},
+			}}
+		}
+
+		getJob = func() (*batchv1.Job, error) {
+			var job batchv1.Job
+			err := fakeClient.Get(ctx, client.ObjectKey{Namespace: liqoNs, Name: volumeMoverName(migration)}, &job)
+			return &job, err
+		}
+
+		completeJob = func(condition batchv1.JobConditionType) {
+			job, err := getJob()
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}
+			ExpectWithOffset(1, fakeClient.Status().Update(ctx, job)).To(Succeed())
+		}
+
+		isNotFound = func(err error) bool { return apierrors.IsNotFound(err) }
+
+		reconcile = func() ctrl.Result {
+			res, err := reconciler.Reconcile(ctx, req)
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			ExpectWithOffset(1, fakeClient.Get(ctx, req.NamespacedName, migration)).To(Succeed())
+			return res
+		}
+
+		nsoff = func(strategy offloadingv1beta1.PodOffloadingStrategyType) *offloadingv1beta1.NamespaceOffloading {
+			return &offloadingv1beta1.NamespaceOffloading{
+				ObjectMeta: metav1.ObjectMeta{Name: consts.DefaultNamespaceOffloadingName, Namespace: ns},
+				Spec:       offloadingv1beta1.NamespaceOffloadingSpec{PodOffloadingStrategy: strategy},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		funcs = interceptor.Funcs{}
+		migration = &offloadingv1beta1.Migration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, UID: "migration-uid"},
+			Spec: offloadingv1beta1.MigrationSpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				SourceNode:  sourceNode,
+			},
+		}
+	})
+
+	Context("a pending migration", func() {
+		It("should fail if the namespace is not offloaded", func() {
+			setup(newVirtualNode(sourceNode), newVirtualNode(targetNode))
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.FailedMigrationPhaseType))
+			Expect(migration.Finalizers).To(ContainElement(migrationControllerFinalizer))
+		})
+
+		It("should fail if the namespace does not allow remote pods", func() {
+			setup(nsoff(offloadingv1beta1.LocalPodOffloadingStrategyType), newVirtualNode(sourceNode), newVirtualNode(targetNode))
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.FailedMigrationPhaseType))
+		})
+
+		It("should fail if a selected pod is not managed by a controller", func() {
+			setup(nsoff(offloadingv1beta1.LocalAndRemotePodOffloadingStrategyType), newVirtualNode(sourceNode), newVirtualNode(targetNode),
+				newPod("bare", sourceNode, false))
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.FailedMigrationPhaseType))
+			Expect(migration.Status.Message).To(ContainSubstring("bare"))
+		})
+
+		It("should fail if the target node is the source one", func() {
+			migration.Spec.TargetNode = sourceNode
+			setup(nsoff(offloadingv1beta1.LocalAndRemotePodOffloadingStrategyType), newVirtualNode(sourceNode))
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.FailedMigrationPhaseType))
+		})
+
+		It("should select a virtual node and start evicting the pods", func() {
+			setup(nsoff(offloadingv1beta1.LocalAndRemotePodOffloadingStrategyType), newVirtualNode(sourceNode), newVirtualNode(targetNode),
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "a-physical-node"}})
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.EvictingMigrationPhaseType))
+			Expect(migration.Status.TargetNode).To(Equal(targetNode))
+			Expect(migration.Status.StartTime).ToNot(BeNil())
+		})
+	})
+
+	Context("an evicting migration", func() {
+		BeforeEach(func() {
+			migration.Finalizers = []string{migrationControllerFinalizer}
+			migration.Status.Phase = offloadingv1beta1.EvictingMigrationPhaseType
+			migration.Status.TargetNode = targetNode
+		})
+
+		It("should evict the selected pods hosted by the source node", func() {
+			setup(newPod("selected", sourceNode, true), newPod("other", targetNode, true))
+			Expect(reconcile().RequeueAfter).To(Equal(requeuePeriod))
+			Expect(migration.Status.Pods).To(ConsistOf("selected"))
+
+			var pods corev1.PodList
+			Expect(fakeClient.List(ctx, &pods)).To(Succeed())
+			Expect(pods.Items).To(HaveLen(1))
+			Expect(pods.Items[0].Name).To(Equal("other"))
+
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.MovingVolumesMigrationPhaseType))
+		})
+
+		It("should evict the selected pods not yet scheduled, and created before the source node was cordoned", func() {
+			cordoned := newPod("cordoned", "", true)
+			cordoned.Annotations = map[string]string{consts.MigrationAnnotationKey: migration.Name}
+			setup(newPod("uncordoned", "", true), cordoned)
+			Expect(reconcile().RequeueAfter).To(Equal(requeuePeriod))
+			Expect(migration.Status.Pods).To(BeEmpty())
+
+			var pods corev1.PodList
+			Expect(fakeClient.List(ctx, &pods)).To(Succeed())
+			Expect(pods.Items).To(HaveLen(1))
+			Expect(pods.Items[0].Name).To(Equal("cordoned"))
+		})
+
+		It("should retry the evictions not allowed by a PodDisruptionBudget", func() {
+			funcs.SubResourceCreate = func(ctx context.Context, cl client.Client, subResource string, obj, sub client.Object,
+				opts ...client.SubResourceCreateOption) error {
+				if subResource == "eviction" && obj.GetName() == "protected" {
+					return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+				}
+				return cl.SubResource(subResource).Create(ctx, obj, sub, opts...)
+			}
+			setup(newPod("selected", sourceNode, true), newPod("protected", sourceNode, true))
+			Expect(reconcile().RequeueAfter).To(Equal(requeuePeriod))
+			Expect(migration.Status.Pods).To(ConsistOf("selected", "protected"))
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.EvictingMigrationPhaseType))
+
+			var pods corev1.PodList
+			Expect(fakeClient.List(ctx, &pods)).To(Succeed())
+			Expect(pods.Items).To(HaveLen(1))
+			Expect(pods.Items[0].Name).To(Equal("protected"))
+		})
+	})
+
+	Context("a migration moving volumes", func() {
+		BeforeEach(func() {
+			migration.Finalizers = []string{migrationControllerFinalizer}
+			migration.Status.Phase = offloadingv1beta1.MovingVolumesMigrationPhaseType
+			migration.Status.TargetNode = targetNode
+		})
+
+		It("should move the volumes through the volume mover job and release the pods", func() {
+			setup(newGatedPod("gated", "source-pvc"), newPVC("source-pvc", sourceNode))
+			reconcile()
+			Expect(migration.Status.CurrentVolume).To(Equal("source-pvc"))
+
+			Expect(reconcile().RequeueAfter).To(Equal(requeuePeriod))
+			job, err := getJob()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(job.Annotations).To(HaveKeyWithValue(volumeMoverMigrationAnnotationKey, ns+"/"+name))
+			Expect(job.Spec.BackoffLimit).To(HaveValue(BeEquivalentTo(0)))
+			Expect(job.Spec.Template.Spec.ServiceAccountName).To(Equal("liqo-volume-mover"))
+			container := job.Spec.Template.Spec.Containers[0]
+			Expect(container.Image).To(Equal("volume-mover"))
+			Expect(container.Args).To(ConsistOf("--namespace="+ns, "--pvc-name=source-pvc", "--target-node="+targetNode, "--id=migration-uid"))
+			Expect(container.Env).To(ConsistOf(HaveField("Name", volumemover.ResticPasswordEnv)))
+
+			secretName := container.Env[0].ValueFrom.SecretKeyRef.Name
+			var secret corev1.Secret
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: liqoNs, Name: secretName}, &secret)).To(Succeed())
+			Expect(secret.StringData).To(HaveKeyWithValue(volumeMoverPasswordKey, Not(BeEmpty())))
+
+			Expect(reconcile().RequeueAfter).To(Equal(requeuePeriod))
+			Expect(migration.Status.Volumes).To(BeEmpty())
+
+			completeJob(batchv1.JobComplete)
+			reconcile()
+			Expect(migration.Status.Volumes).To(ConsistOf("source-pvc"))
+			Expect(migration.Status.CurrentVolume).To(BeEmpty())
+			_, err = getJob()
+			Expect(err).To(Satisfy(isNotFound))
+
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.SucceededMigrationPhaseType))
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: liqoNs, Name: secretName}, &secret)).To(Satisfy(isNotFound))
+
+			var pod corev1.Pod
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: "gated"}, &pod)).To(Succeed())
+			Expect(pod.Spec.SchedulingGates).To(BeEmpty())
+		})
+
+		It("should not move the volumes stored in other nodes", func() {
+			setup(newGatedPod("gated", "other-pvc"), newPVC("other-pvc", targetNode))
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.SucceededMigrationPhaseType))
+			_, err := getJob()
+			Expect(err).To(Satisfy(isNotFound))
+		})
+
+		It("should move the volumes concurrently with the other migrations", func() {
+			migration.Status.CurrentVolume = "source-pvc"
+			other := newJob(&offloadingv1beta1.Migration{ObjectMeta: metav1.ObjectMeta{Name: "migration", Namespace: "other", UID: "other-uid"}},
+				"other-pvc", targetNode)
+			setup(newGatedPod("gated", "source-pvc"), newPVC("source-pvc", sourceNode), other)
+			Expect(reconcile().RequeueAfter).To(Equal(requeuePeriod))
+
+			job, err := getJob()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(job.Annotations).To(HaveKeyWithValue(volumeMoverMigrationAnnotationKey, ns+"/"+name))
+			Expect(job.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--pvc-name=source-pvc"))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(other), &batchv1.Job{})).To(Succeed())
+		})
+
+		It("should roll back the moved volumes if a volume cannot be moved", func() {
+			migration.Status.Volumes = []string{"moved-pvc"}
+			migration.Status.CurrentVolume = "source-pvc"
+			setup(newGatedPod("gated", "source-pvc"), newPVC("source-pvc", sourceNode), newPVC("moved-pvc", targetNode),
+				newJob(migration, "source-pvc", targetNode))
+			completeJob(batchv1.JobFailed)
+
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.RollingBackMigrationPhaseType))
+			Expect(migration.Status.CurrentVolume).To(BeEmpty())
+			_, err := getJob()
+			Expect(err).To(Satisfy(isNotFound))
+
+			// The volumes already moved to the target node are restored into the source one.
+			reconcile()
+			Expect(migration.Status.CurrentVolume).To(Equal("moved-pvc"))
+			Expect(reconcile().RequeueAfter).To(Equal(requeuePeriod))
+			job, err := getJob()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(job.Spec.Template.Spec.Containers[0].Args).To(ContainElements("--pvc-name=moved-pvc", "--target-node="+sourceNode))
+
+			completeJob(batchv1.JobComplete)
+			reconcile()
+			Expect(migration.Status.Volumes).To(BeEmpty())
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.RollingBackMigrationPhaseType))
+
+			// The gated pods are released, while keeping the migration rolling back to pin their replacements to the source node.
+			Expect(reconcile().RequeueAfter).To(Equal(requeuePeriod))
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.RollingBackMigrationPhaseType))
+			var pods corev1.PodList
+			Expect(fakeClient.List(ctx, &pods)).To(Succeed())
+			Expect(pods.Items).To(BeEmpty())
+
+			reconcile()
+			Expect(migration.Status.Phase).To(Equal(offloadingv1beta1.FailedMigrationPhaseType))
+		})
+	})
+
+	Context("a migration being deleted", func() {
+		BeforeEach(func() {
+			migration.Finalizers = []string{migrationControllerFinalizer}
+			migration.DeletionTimestamp = ptr.To(metav1.Now())
+			migration.Status.Phase = offloadingv1beta1.MovingVolumesMigrationPhaseType
+			migration.Status.TargetNode = targetNode
+			migration.Status.CurrentVolume = "source-pvc"
+		})
+
+		It("should wait for the volume mover job to complete before releasing the pods", func() {
+			setup(newGatedPod("gated", "source-pvc"), newJob(migration, "source-pvc", targetNode))
+			Expect(reconcile().RequeueAfter).To(Equal(requeuePeriod))
+			Expect(migration.Finalizers).To(ContainElement(migrationControllerFinalizer))
+
+			completeJob(batchv1.JobComplete)
+			res, err := reconciler.Reconcile(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeZero())
+			Expect(fakeClient.Get(ctx, req.NamespacedName, migration)).To(Satisfy(isNotFound))
+			_, err = getJob()
+			Expect(err).To(Satisfy(isNotFound))
+
+			var pods corev1.PodList
+			Expect(fakeClient.List(ctx, &pods)).To(Succeed())
+			Expect(pods.Items).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,281 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrationctrl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/volumemover"
+)
+
+const (
+	// volumeMoverNamePrefix is the prefix of the name of the job moving the volumes of a migration (and of the corresponding
+	// secret). A single job exists at any time for each migration, while the moves of different migrations run concurrently.
+	volumeMoverNamePrefix = "liqo-volume-mover"
+
+	// volumeMoverMigrationAnnotationKey is the annotation set on the volume mover job to track the Migration it belongs to.
+	volumeMoverMigrationAnnotationKey = "liqo.io/volume-mover-migration"
+	// volumeMoverVolumeAnnotationKey is the annotation set on the volume mover job to track the PVC being moved.
+	volumeMoverVolumeAnnotationKey = "liqo.io/volume-mover-volume"
+	// volumeMoverTargetNodeAnnotationKey is the annotation set on the volume mover job to track the node the PVC is moved to.
+	volumeMoverTargetNodeAnnotationKey = "liqo.io/volume-mover-target-node"
+
+	// volumeMoverPasswordKey is the key of the secret containing the password of the restic repository.
+	volumeMoverPasswordKey = "password"
+)
+
+// volumeMoveResult represents the outcome of the move of a volume.
+type volumeMoveResult string
+
+const (
+	// volumeMoveInProgress -> the volume mover job has not completed yet.
+	volumeMoveInProgress volumeMoveResult = "InProgress"
+	// volumeMoveSucceeded -> the volume has been moved to the target node.
+	volumeMoveSucceeded volumeMoveResult = "Succeeded"
+	// volumeMoveFailed -> the volume could not be moved to the target node, and it has been restored into the origin one.
+	volumeMoveFailed volumeMoveResult = "Failed"
+)
+
+// reconcileVolumeMove ensures that the volume mover job moving the given PVC to the target node exists, and returns the
+// outcome of the move. The job is not deleted once completed, hence allowing the caller to record the outcome beforehand.
+func (r *MigrationReconciler) reconcileVolumeMove(ctx context.Context, migration *offloadingv1beta1.Migration,
+	pvcName, targetNode string) (volumeMoveResult, error) {
+	job, err := r.getVolumeMoverJob(ctx, migration)
+	if err != nil {
+		return "", err
+	}
+
+	if job == nil {
+		return volumeMoveInProgress, r.createVolumeMoverJob(ctx, migration, pvcName, targetNode)
+	}
+
+	finished, failed := isJobFinished(job)
+	if job.Annotations[volumeMoverVolumeAnnotationKey] != pvcName || job.Annotations[volumeMoverTargetNodeAnnotationKey] != targetNode {
+		// The job refers to a previous move of the same migration, whose outcome has already been recorded.
+		if finished {
+			return volumeMoveInProgress, r.deleteVolumeMoverJob(ctx, migration)
+		}
+		return volumeMoveInProgress, nil
+	}
+
+	switch {
+	case !finished:
+		return volumeMoveInProgress, nil
+	case failed:
+		return volumeMoveFailed, nil
+	default:
+		return volumeMoveSucceeded, nil
+	}
+}
+
+// getVolumeMoverJob returns the volume mover job of the given migration, or nil if it does not exist (or it is being deleted).
+func (r *MigrationReconciler) getVolumeMoverJob(ctx context.Context, migration *offloadingv1beta1.Migration) (*batchv1.Job, error) {
+	var job batchv1.Job
+	if err := r.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: volumeMoverName(migration)}, &job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to retrieve the volume mover job: %w", err)
+	}
+
+	if !job.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// createVolumeMoverJob creates the job moving the given PVC to the target node.
+func (r *MigrationReconciler) createVolumeMoverJob(ctx context.Context, migration *offloadingv1beta1.Migration,
+	pvcName, targetNode string) error {
+	secretName, err := r.ensureVolumeMoverSecret(ctx, migration)
+	if err != nil {
+		return err
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volumeMoverName(migration),
+			Namespace: r.Namespace,
+			Annotations: map[string]string{
+				volumeMoverMigrationAnnotationKey:  migrationKey(migration),
+				volumeMoverVolumeAnnotationKey:     pvcName,
+				volumeMoverTargetNodeAnnotationKey: targetNode,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			// The move is not idempotent, hence it shall not be retried in case of failures.
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: r.VolumeMoverServiceAccount,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:            "volume-mover",
+						Image:           r.VolumeMoverImage,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Args: []string{
+							"--namespace=" + migration.Namespace,
+							"--pvc-name=" + pvcName,
+							"--target-node=" + targetNode,
+							"--id=" + string(migration.UID),
+						},
+						Env: []corev1.EnvVar{{
+							Name: volumemover.ResticPasswordEnv,
+							ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+								Key:                  volumeMoverPasswordKey,
+							}},
+						}},
+					}},
+				},
+			},
+		},
+	}
+
+	if err := r.Create(ctx, job); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// The previous job of the migration is still being deleted: wait for it to disappear.
+			return nil
+		}
+		klog.Errorf("Failed to create the job moving PVC %s/%s to node %q: %v", migration.Namespace, pvcName, targetNode, err)
+		return err
+	}
+
+	klog.Infof("Moving PVC %s/%s to node %q (migration: %q)", migration.Namespace, pvcName, targetNode, klog.KObj(migration))
+	return nil
+}
+
+// deleteVolumeMoverJob deletes the volume mover job of the given migration. An error is returned in case
+// the job is still running, as interrupting a move might lead to data loss.
+func (r *MigrationReconciler) deleteVolumeMoverJob(ctx context.Context, migration *offloadingv1beta1.Migration) error {
+	job, err := r.getVolumeMoverJob(ctx, migration)
+	if err != nil || job == nil {
+		return err
+	}
+
+	if finished, _ := isJobFinished(job); !finished {
+		return fmt.Errorf("the volume mover job of migration %q is still running", klog.KObj(migration))
+	}
+
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		klog.Errorf("Failed to delete the volume mover job: %v", err)
+		return err
+	}
+	return nil
+}
+
+// ensureVolumeMoverSecret ensures the existence of the secret containing the password of the restic repository used by
+// the volume mover jobs of the given migration, and returns its name. The password is generated once per migration,
+// hence remaining stable across controller restarts and rollbacks.
+func (r *MigrationReconciler) ensureVolumeMoverSecret(ctx context.Context, migration *offloadingv1beta1.Migration) (string, error) {
+	name := volumeMoverName(migration)
+
+	var secret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: name}, &secret)
+	switch {
+	case err == nil:
+		return name, nil
+	case !apierrors.IsNotFound(err):
+		return "", fmt.Errorf("failed to retrieve the volume mover secret: %w", err)
+	}
+
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return "", fmt.Errorf("failed to generate the restic password: %w", err)
+	}
+
+	secret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   r.Namespace,
+			Annotations: map[string]string{volumeMoverMigrationAnnotationKey: migrationKey(migration)},
+		},
+		StringData: map[string]string{volumeMoverPasswordKey: base64.RawURLEncoding.EncodeToString(password)},
+	}
+	if err := r.Create(ctx, &secret); client.IgnoreAlreadyExists(err) != nil {
+		klog.Errorf("Failed to create the volume mover secret for migration %q: %v", klog.KObj(migration), err)
+		return "", err
+	}
+	return name, nil
+}
+
+// cleanupVolumeMover deletes the volume mover job (if completed) and the secret of the given migration.
+func (r *MigrationReconciler) cleanupVolumeMover(ctx context.Context, migration *offloadingv1beta1.Migration) error {
+	if err := r.deleteVolumeMoverJob(ctx, migration); err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: volumeMoverName(migration), Namespace: r.Namespace}}
+	if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		klog.Errorf("Failed to delete the volume mover secret for migration %q: %v", klog.KObj(migration), err)
+		return err
+	}
+	return nil
+}
+
+// volumeMoverJobToMigration maps the volume mover job to the Migration it belongs to.
+func volumeMoverJobToMigration(_ context.Context, obj client.Object) []reconcile.Request {
+	namespace, name, found := splitMigrationKey(obj.GetAnnotations()[volumeMoverMigrationAnnotationKey])
+	if !found {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
+
+// isJobFinished returns whether the given job completed, and whether it failed.
+func isJobFinished(job *batchv1.Job) (finished, failed bool) {
+	for i := range job.Status.Conditions {
+		condition := &job.Status.Conditions[i]
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, false
+		case batchv1.JobFailed:
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// volumeMoverName returns the name of the volume mover job of the given migration, as well as of the corresponding secret.
+func volumeMoverName(migration *offloadingv1beta1.Migration) string {
+	return fmt.Sprintf("%s-%s", volumeMoverNamePrefix, migration.UID)
+}
+
+func migrationKey(migration *offloadingv1beta1.Migration) string {
+	return types.NamespacedName{Namespace: migration.Namespace, Name: migration.Name}.String()
+}
+
+func splitMigrationKey(key string) (namespace, name string, found bool) {
+	namespace, name, found = strings.Cut(key, string(types.Separator))
+	return namespace, name, found && namespace != "" && name != ""
+}
@@ -29,10 +29,15 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/auth"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
+	peeringroles "github.com/liqotech/liqo/pkg/peering-roles"
 	"github.com/liqotech/liqo/pkg/utils"
 	liqoerrors "github.com/liqotech/liqo/pkg/utils/errors"
+	"github.com/liqotech/liqo/pkg/utils/getters"
 	"github.com/liqotech/liqo/pkg/utils/resource"
 )
 
@@ -82,7 +87,25 @@ func (r *NamespaceMapReconciler) createNamespace(ctx context.Context, name, orig
 	// Make sure the appropriate role binding is present in the namespace for virtual kubelet operations.
 	// The rolebinding is named after the tenant namespace name, since that is guaranteed to be unique.
 	// This will simplify the support for remote namespaces associated with multiple origins.
+	roleRef, subject, err := r.remoteNamespaceBinding(ctx, nm, origin)
+	if err != nil {
+		return true, err
+	}
+
 	binding := rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: name, Name: nm.GetNamespace()}}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(&binding), &binding); client.IgnoreNotFound(err) != nil {
+		return true, fmt.Errorf("failed to retrieve role binding %q: %w", klog.KObj(&binding), err)
+	}
+
+	// The role reference of a role binding is immutable, hence it is recreated if the PeeringRoleProfile of the tenant changed.
+	if !binding.CreationTimestamp.IsZero() && binding.RoleRef != roleRef {
+		if err := client.IgnoreNotFound(r.Delete(ctx, &binding)); err != nil {
+			return true, fmt.Errorf("failed to delete outdated role binding %q: %w", klog.KObj(&binding), err)
+		}
+		klog.Infof("RoleBinding %q deleted, as referring to the outdated ClusterRole %q", klog.KObj(&binding), binding.RoleRef.Name)
+		binding = rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: name, Name: nm.GetNamespace()}}
+	}
+
 	result, err := resource.CreateOrUpdate(ctx, r.Client, &binding, func() error {
 		binding.Annotations = labels.Merge(binding.GetAnnotations(), map[string]string{
 			consts.RemoteNamespaceManagedByAnnotationKey: nmID,
@@ -92,9 +115,10 @@ func (r *NamespaceMapReconciler) createNamespace(ctx context.Context, name, orig
 			consts.RemoteClusterID:    origin,
 		})
 
+		// The subject is always enforced, as rotated when the credentials of the tenant are revoked.
+		binding.Subjects = []rbacv1.Subject{subject}
 		if binding.CreationTimestamp.IsZero() {
-			binding.Subjects = []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: origin}}
-			binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: consts.RemoteNamespaceClusterRoleName}
+			binding.RoleRef = roleRef
 		}
 
 		return nil
@@ -107,6 +131,27 @@ func (r *NamespaceMapReconciler) createNamespace(ctx context.Context, name, orig
 	return true, nil
 }
 
+// remoteNamespaceBinding returns the reference to the ClusterRole granting permissions to the virtual kubelet in the remote
+// namespaces, according to the PeeringRoleProfile referenced by the Tenant of the origin cluster (if any), and the group
+// of the virtual kubelet it is bound to, according to the current generation of the credentials of the Tenant.
+func (r *NamespaceMapReconciler) remoteNamespaceBinding(ctx context.Context,
+	nm *offloadingv1beta1.NamespaceMap, origin string) (rbacv1.RoleRef, rbacv1.Subject, error) {
+	roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: consts.RemoteNamespaceClusterRoleName}
+	subject := rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: auth.GroupName(origin)}
+
+	tenant, err := getters.GetTenantByClusterID(ctx, r.Client, liqov1beta1.ClusterID(origin), nm.GetNamespace())
+	switch {
+	case apierrors.IsNotFound(err):
+		return roleRef, subject, nil
+	case err != nil:
+		return roleRef, subject, fmt.Errorf("failed to retrieve the tenant of cluster %q: %w", origin, err)
+	}
+
+	roleRef.Name = peeringroles.RemoteNamespaceClusterRoleName(tenant.Spec.PeeringRoleProfileRef)
+	subject.Name = auth.GroupName(authentication.ResourceSliceGroup(tenant.Spec.ClusterID, authentication.TenantCredentialsGeneration(tenant)))
+	return roleRef, subject, nil
+}
+
 // For every entry of DesiredMapping create remote Namespace if it has not already being created.
 // ensureNamespacesExistence tries to create all the remote namespaces requested in DesiredMapping (NamespaceMap->Spec->DesiredMapping).
 func (r *NamespaceMapReconciler) ensureNamespacesExistence(ctx context.Context, nm *offloadingv1beta1.NamespaceMap) error {
@@ -27,13 +27,16 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/internal/crdReplicator/reflection"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 )
 
 // NamespaceMapReconciler creates remote namespaces and updates NamespaceMaps Status.
@@ -47,6 +50,7 @@ type NamespaceMapReconciler struct {
 // +kubebuilder:rbac:groups=core.liqo.io,resources=foreignclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespacemaps,verbs=get;watch;list;update;patch;create;delete
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespacemaps/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=tenants,verbs=get;list;watch
 
 // needed to approve the certificates
 // +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests,verbs=get;list;watch
@@ -116,5 +120,42 @@ func (r *NamespaceMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// https://kubernetes.io/docs/concepts/overview/working-with-objects/owners-dependents/.
 		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(enqueuer)).
 		Watches(&rbacv1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(enqueuer)).
+		// The Tenants are watched to update the role bindings when the referenced PeeringRoleProfile changes,
+		// or the credentials are revoked.
+		Watches(&authv1beta1.Tenant{}, handler.EnqueueRequestsFromMapFunc(r.tenantEnqueuer),
+			builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, credentialsGenerationChangedPredicate()))).
 		Complete(r)
 }
+
+// credentialsGenerationChangedPredicate filters the updates of the Tenants changing the generation of their credentials.
+func credentialsGenerationChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldTenant, okOld := e.ObjectOld.(*authv1beta1.Tenant)
+			newTenant, okNew := e.ObjectNew.(*authv1beta1.Tenant)
+			return okOld && okNew &&
+				authentication.TenantCredentialsGeneration(oldTenant) != authentication.TenantCredentialsGeneration(newTenant)
+		},
+	}
+}
+
+// tenantEnqueuer enqueues the NamespaceMaps replicated from the cluster associated with the given Tenant.
+func (r *NamespaceMapReconciler) tenantEnqueuer(ctx context.Context, obj client.Object) []reconcile.Request {
+	tenant, ok := obj.(*authv1beta1.Tenant)
+	if !ok || tenant.Spec.ClusterID == "" {
+		return nil
+	}
+
+	var nms offloadingv1beta1.NamespaceMapList
+	if err := r.List(ctx, &nms, client.InNamespace(tenant.GetNamespace()),
+		client.MatchingLabels{consts.ReplicationOriginLabel: string(tenant.Spec.ClusterID)}); err != nil {
+		klog.Errorf("Failed to retrieve the NamespaceMaps associated with Tenant %q: %v", klog.KObj(tenant), err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(nms.Items))
+	for i := range nms.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&nms.Items[i])}
+	}
+	return requests
+}
@@ -29,7 +29,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	ctrlutils "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/auth"
 	liqoconst "github.com/liqotech/liqo/pkg/consts"
 	namespacemapctrl "github.com/liqotech/liqo/pkg/liqo-controller-manager/offloading/namespacemap-controller"
 	. "github.com/liqotech/liqo/pkg/utils/testutil"
@@ -120,6 +122,92 @@ var _ = Describe("Enforcement logic", func() {
 				Describe("perform checks", func() { SuccessWhenBody() })
 			})
 
+			When("the remote clusters are authenticated with prefixed groups", func() {
+				BeforeEach(func() {
+					auth.SetIdentityPrefixes("liqo:", "liqo:")
+					DeferCleanup(auth.SetIdentityPrefixes, "", "")
+				})
+
+				It("should bind the role to the prefixed group", func() {
+					Expect(err).ToNot(HaveOccurred())
+					var binding rbacv1.RoleBinding
+					Expect(reconciler.Get(ctx, types.NamespacedName{Namespace: "namespace-remote", Name: "tenant-namespace"}, &binding)).To(Succeed())
+					Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "liqo:origin"}))
+				})
+			})
+
+			When("the tenant references a PeeringRoleProfile", func() {
+				BeforeEach(func() {
+					tenant := authv1beta1.Tenant{
+						ObjectMeta: metav1.ObjectMeta{Name: "origin", Namespace: "tenant-namespace",
+							Labels: map[string]string{liqoconst.RemoteClusterID: "origin"}},
+						Spec: authv1beta1.TenantSpec{ClusterID: "origin",
+							PeeringRoleProfileRef: &corev1.LocalObjectReference{Name: "restricted"}},
+					}
+					clientBuilder.WithObjects(&tenant)
+				})
+
+				ItShouldReferTheProfileClusterRole := func() {
+					It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+					It("should bind the ClusterRole derived from the profile", func() {
+						var binding rbacv1.RoleBinding
+						Expect(reconciler.Get(ctx, types.NamespacedName{Namespace: "namespace-remote", Name: "tenant-namespace"}, &binding)).To(Succeed())
+						Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "origin"}))
+						Expect(binding.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole",
+							Name: liqoconst.RemoteNamespaceClusterRoleName + "-restricted"}))
+					})
+				}
+
+				When("the rolebinding does not yet exist", func() {
+					Describe("perform checks", func() { ItShouldReferTheProfileClusterRole() })
+				})
+
+				When("the rolebinding refers to the default ClusterRole", func() {
+					BeforeEach(func() {
+						namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "namespace-remote",
+							Annotations: map[string]string{liqoconst.RemoteNamespaceManagedByAnnotationKey: "tenant-namespace/name"},
+						}}
+						binding := rbacv1.RoleBinding{
+							ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-remote", Name: "tenant-namespace"},
+							Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "origin"}},
+							RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole",
+								Name: liqoconst.RemoteNamespaceClusterRoleName},
+						}
+						clientBuilder.WithObjects(&namespace, &binding)
+					})
+
+					Describe("perform checks", func() { ItShouldReferTheProfileClusterRole() })
+				})
+			})
+
+			When("the credentials of the tenant have been revoked", func() {
+				BeforeEach(func() {
+					tenant := authv1beta1.Tenant{
+						ObjectMeta: metav1.ObjectMeta{Name: "origin", Namespace: "tenant-namespace",
+							Labels: map[string]string{liqoconst.RemoteClusterID: "origin"}},
+						Spec:   authv1beta1.TenantSpec{ClusterID: "origin"},
+						Status: authv1beta1.TenantStatus{Credentials: &authv1beta1.CredentialsStatus{Generation: 2}},
+					}
+					namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "namespace-remote",
+						Annotations: map[string]string{liqoconst.RemoteNamespaceManagedByAnnotationKey: "tenant-namespace/name"},
+					}}
+					binding := rbacv1.RoleBinding{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-remote", Name: "tenant-namespace"},
+						Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "origin"}},
+						RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole",
+							Name: liqoconst.RemoteNamespaceClusterRoleName},
+					}
+					clientBuilder.WithObjects(&tenant, &namespace, &binding)
+				})
+
+				It("should bind the role to the rotated group", func() {
+					Expect(err).ToNot(HaveOccurred())
+					var binding rbacv1.RoleBinding
+					Expect(reconciler.Get(ctx, types.NamespacedName{Namespace: "namespace-remote", Name: "tenant-namespace"}, &binding)).To(Succeed())
+					Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "origin-gen2"}))
+				})
+			})
+
 			When("the namespace already exists but it is not managed by the NamespaceMap", func() {
 				BeforeEach(func() {
 					namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "namespace-remote"}}
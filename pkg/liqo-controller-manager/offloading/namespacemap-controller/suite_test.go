@@ -21,6 +21,7 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/client-go/kubernetes/scheme"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/pkg/utils/testutil"
 )
@@ -31,6 +32,7 @@ func TestNamespacemapController(t *testing.T) {
 }
 
 var _ = BeforeSuite(func() {
+	Expect(authv1beta1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(offloadingv1beta1.AddToScheme(scheme.Scheme)).To(Succeed())
 
 	testutil.LogsToGinkgoWriter()
@@ -26,10 +26,12 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/internal/crdReplicator/reflection"
 	liqoconst "github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/utils/getters"
+	"github.com/liqotech/liqo/pkg/utils/resources"
 	virtualnodeutils "github.com/liqotech/liqo/pkg/utils/virtualnode"
 )
 
@@ -48,24 +50,73 @@ func (r *NamespaceOffloadingReconciler) enforceClusterSelector(ctx context.Conte
 			len(clusterIDs), len(clusterIDMap))
 	}
 
-	var returnErr error
+	// Aggregate the information about the virtual nodes matching the ClusterSelector by remote cluster.
+	candidates := map[liqov1beta1.ClusterID]*offloadingv1beta1.RankedCluster{}
+	hard, used := map[liqov1beta1.ClusterID]corev1.ResourceList{}, map[liqov1beta1.ClusterID]corev1.ResourceList{}
 	for i := range virtualNodes.Items {
-		match, err := MatchVirtualNodeSelectorTerms(ctx, r.Client, &virtualNodes.Items[i], &nsoff.Spec.ClusterSelector)
+		vn := &virtualNodes.Items[i]
+		match, err := MatchVirtualNodeSelectorTerms(ctx, r.Client, vn, &nsoff.Spec.ClusterSelector)
 		if err != nil {
 			r.Recorder.Eventf(nsoff, corev1.EventTypeWarning, "Invalid", "Invalid ClusterSelector: %v", err)
 			// We end the processing here, as this error will be triggered for all the virtual nodes.
 			return fmt.Errorf("invalid ClusterSelector: %w", err)
 		}
+		if !match {
+			continue
+		}
+
+		score, err := ScoreVirtualNode(ctx, r.Client, vn, nsoff.Spec.ClusterPreferences)
+		if err != nil {
+			r.Recorder.Eventf(nsoff, corev1.EventTypeWarning, "Invalid", "Invalid ClusterPreferences: %v", err)
+			return fmt.Errorf("invalid ClusterPreferences: %w", err)
+		}
+
+		candidate, found := candidates[vn.Spec.ClusterID]
+		if !found {
+			candidate = &offloadingv1beta1.RankedCluster{ClusterID: vn.Spec.ClusterID, Score: score}
+			candidates[vn.Spec.ClusterID] = candidate
+			hard[vn.Spec.ClusterID], used[vn.Spec.ClusterID] = corev1.ResourceList{}, corev1.ResourceList{}
+		}
+		candidate.Score = max(candidate.Score, score)
+
+		// The capacity is evaluated only when needed, to avoid listing the offloaded pods otherwise.
+		if nsoff.Spec.SpillOverStrategy == offloadingv1beta1.OnLowCapacitySpillOverStrategyType {
+			vnhard, vnused, err := r.virtualNodeCapacity(ctx, vn)
+			if err != nil {
+				return err
+			}
+			resources.AddResources(hard[vn.Spec.ClusterID], vnhard)
+			resources.AddResources(used[vn.Spec.ClusterID], vnused)
+		}
+	}
 
-		if match {
-			if err = addDesiredMapping(ctx, r.Client, nsoff.Namespace, r.remoteNamespaceName(nsoff),
-				clusterIDMap[string(virtualNodes.Items[i].Spec.ClusterID)]); err != nil {
+	ranking := make([]offloadingv1beta1.RankedCluster, 0, len(candidates))
+	current := map[liqov1beta1.ClusterID]bool{}
+	for clusterID, candidate := range candidates {
+		if nsoff.Spec.SpillOverStrategy == offloadingv1beta1.OnLowCapacitySpillOverStrategyType {
+			candidate.LowCapacity = lowCapacity(hard[clusterID], used[clusterID], nsoff.Spec.SpillOverThreshold)
+		}
+		if nm, ok := clusterIDMap[string(clusterID)]; ok {
+			_, current[clusterID] = nm.Spec.DesiredMapping[nsoff.Namespace]
+		}
+		ranking = append(ranking, *candidate)
+	}
+	nsoff.Status.ClusterRanking = rankClusters(&nsoff.Spec, ranking, current)
+
+	selected := map[string]bool{}
+	for i := range nsoff.Status.ClusterRanking {
+		selected[string(nsoff.Status.ClusterRanking[i].ClusterID)] = nsoff.Status.ClusterRanking[i].Selected
+	}
+
+	var returnErr error
+	for _, clusterID := range clusterIDs {
+		if selected[clusterID] {
+			if err = addDesiredMapping(ctx, r.Client, nsoff.Namespace, r.remoteNamespaceName(nsoff), clusterIDMap[clusterID]); err != nil {
 				returnErr = fmt.Errorf("failed to configure all desired mappings")
 			}
 		} else {
-			// Ensure old mappings are removed in case the cluster selector is updated.
-			if err = removeDesiredMapping(ctx, r.Client, nsoff.Namespace,
-				clusterIDMap[string(virtualNodes.Items[i].Spec.ClusterID)]); err != nil {
+			// Ensure old mappings are removed in case the cluster selector is updated, or the cluster is no longer ranked high enough.
+			if err = removeDesiredMapping(ctx, r.Client, nsoff.Namespace, clusterIDMap[clusterID]); err != nil {
 				returnErr = fmt.Errorf("failed to configure all desired mappings")
 			}
 		}
@@ -16,6 +16,7 @@ package nsoffctrl
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -49,6 +50,10 @@ type NamespaceOffloadingReconciler struct {
 
 const (
 	namespaceOffloadingControllerFinalizer = "namespaceoffloading-controller.liqo.io/finalizer"
+
+	// spillOverResyncPeriod is the period after which the free capacity of the remote clusters is re-evaluated,
+	// in case the OnLowCapacity SpillOverStrategy is selected.
+	spillOverResyncPeriod = 30 * time.Second
 )
 
 // cluster-role
@@ -59,6 +64,7 @@ const (
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=virtualnode, verbs=get;list;watch;patch;update
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile implements the NamespaceOffloading reconciliation logic.
@@ -113,13 +119,18 @@ func (r *NamespaceOffloadingReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
+	// Periodically re-evaluate the free capacity of the remote clusters, as it is not tracked by any watch.
+	if nsoff.Spec.SpillOverStrategy == offloadingv1beta1.OnLowCapacitySpillOverStrategyType {
+		result.RequeueAfter = spillOverResyncPeriod
+	}
+
 	switch nsoff.Spec.PodOffloadingStrategy {
 	case offloadingv1beta1.LocalAndRemotePodOffloadingStrategyType, offloadingv1beta1.RemotePodOffloadingStrategyType:
 		// If the offloading policy includes remote clusters, then ensure the corresponding namespace has the liqo scheduling label.
-		return ctrl.Result{}, r.enforceSchedulingLabelPresence(ctx, nsoff.Namespace)
+		return result, r.enforceSchedulingLabelPresence(ctx, nsoff.Namespace)
 	default:
 		// Otherwise, ensure the label is not present.
-		return ctrl.Result{}, r.enforceSchedulingLabelAbsence(ctx, nsoff.Namespace)
+		return result, r.enforceSchedulingLabelAbsence(ctx, nsoff.Namespace)
 	}
 }
 
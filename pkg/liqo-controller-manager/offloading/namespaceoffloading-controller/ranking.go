@@ -0,0 +1,126 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsoffctrl
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/indexer"
+	"github.com/liqotech/liqo/pkg/utils/resources"
+	virtualnodeutils "github.com/liqotech/liqo/pkg/utils/virtualnode"
+)
+
+// ScoreVirtualNode returns the sum of the weights of the preference terms matched by the virtual node.
+func ScoreVirtualNode(ctx context.Context, cl client.Client, virtualNode *offloadingv1beta1.VirtualNode,
+	preferences []corev1.PreferredSchedulingTerm) (int64, error) {
+	// Shortcircuit the scoring logic, to avoid retrieving the node labels in case no preference is specified.
+	if len(preferences) == 0 {
+		return 0, nil
+	}
+	terms, err := nodeaffinity.NewPreferredSchedulingTerms(preferences)
+	if err != nil {
+		return 0, err
+	}
+	n, err := virtualnodeutils.ForgeFakeNodeFromVirtualNode(ctx, cl, virtualNode)
+	if err != nil {
+		return 0, fmt.Errorf("failed to forge fake node from VirtualNode: %w", err)
+	}
+	return terms.Score(n), nil
+}
+
+// virtualNodeCapacity returns the resources assigned to the virtual node, along with the ones requested by the pods scheduled on it.
+func (r *NamespaceOffloadingReconciler) virtualNodeCapacity(ctx context.Context,
+	virtualNode *offloadingv1beta1.VirtualNode) (hard, used corev1.ResourceList, err error) {
+	var pods corev1.PodList
+	// The node is named after the VirtualNode it is created from.
+	if err := r.List(ctx, &pods, client.MatchingFields{indexer.FieldNodeNameFromPod: virtualNode.Name}); err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve the pods scheduled on virtual node %q: %w", virtualNode.Name, err)
+	}
+
+	used = corev1.ResourceList{}
+	for i := range pods.Items {
+		if phase := pods.Items[i].Status.Phase; phase == corev1.PodSucceeded || phase == corev1.PodFailed {
+			continue
+		}
+		resources.AddResources(used, resources.PodQuotaUsage(&pods.Items[i]))
+	}
+	return virtualNode.Spec.ResourceQuota.Hard.DeepCopy(), used, nil
+}
+
+// lowCapacity returns whether the free quantity of any of the given resources is below the threshold percentage.
+// Resources with no assigned quantity are not taken into account.
+func lowCapacity(hard, used corev1.ResourceList, threshold int32) bool {
+	free := resources.SubResources(hard, used)
+	for name, quantity := range hard {
+		if quantity.IsZero() {
+			continue
+		}
+		f := free[name]
+		if f.AsApproximateFloat64()*100 < quantity.AsApproximateFloat64()*float64(threshold) {
+			return true
+		}
+	}
+	return false
+}
+
+// rankClusters sorts the given clusters by decreasing score (ties are broken by cluster ID, to ensure a deterministic
+// outcome), and marks those the namespace shall be offloaded to, according to the MaxClusters and SpillOver settings.
+// When spilling over, the clusters the namespace is currently offloaded to remain selected, to prevent the offloaded
+// workloads from being torn down as soon as the higher-ranked clusters free up some resources.
+func rankClusters(spec *offloadingv1beta1.NamespaceOffloadingSpec, clusters []offloadingv1beta1.RankedCluster,
+	current map[liqov1beta1.ClusterID]bool) []offloadingv1beta1.RankedCluster {
+	slices.SortFunc(clusters, func(a, b offloadingv1beta1.RankedCluster) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+		return cmp.Compare(a.ClusterID, b.ClusterID)
+	})
+
+	limit := len(clusters)
+	if spec.MaxClusters > 0 && int(spec.MaxClusters) < limit {
+		limit = int(spec.MaxClusters)
+	}
+
+	spill := true
+	for i := range clusters {
+		clusters[i].Selected = false
+		if limit == 0 {
+			continue
+		}
+
+		switch {
+		case spec.SpillOverStrategy != offloadingv1beta1.OnLowCapacitySpillOverStrategyType:
+			clusters[i].Selected = true
+		case spill || current[clusters[i].ClusterID]:
+			clusters[i].Selected = true
+			// Lower-ranked clusters are selected only as long as all the preceding ones are low on resources.
+			spill = spill && clusters[i].LowCapacity
+		}
+
+		if clusters[i].Selected {
+			limit--
+		}
+	}
+	return clusters
+}
@@ -0,0 +1,108 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsoffctrl
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+)
+
+var _ = Describe("Cluster ranking", func() {
+	var (
+		spec     offloadingv1beta1.NamespaceOffloadingSpec
+		clusters []offloadingv1beta1.RankedCluster
+		current  map[liqov1beta1.ClusterID]bool
+	)
+
+	selected := func(ranking []offloadingv1beta1.RankedCluster) []liqov1beta1.ClusterID {
+		var ids []liqov1beta1.ClusterID
+		for i := range ranking {
+			if ranking[i].Selected {
+				ids = append(ids, ranking[i].ClusterID)
+			}
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		spec = offloadingv1beta1.NamespaceOffloadingSpec{SpillOverStrategy: offloadingv1beta1.DisabledSpillOverStrategyType}
+		clusters = []offloadingv1beta1.RankedCluster{
+			{ClusterID: remoteCluster3, Score: 10},
+			{ClusterID: remoteCluster2, Score: 50},
+			{ClusterID: remoteCluster1, Score: 10},
+		}
+		current = map[liqov1beta1.ClusterID]bool{}
+	})
+
+	It("should sort the clusters by decreasing score and cluster ID", func() {
+		ranking := rankClusters(&spec, clusters, current)
+		Expect(ranking).To(HaveLen(3))
+		Expect(ranking[0].ClusterID).To(Equal(remoteCluster2))
+		Expect(ranking[1].ClusterID).To(Equal(remoteCluster1))
+		Expect(ranking[2].ClusterID).To(Equal(remoteCluster3))
+		Expect(selected(ranking)).To(ConsistOf(remoteCluster1, remoteCluster2, remoteCluster3))
+	})
+
+	It("should select at most MaxClusters clusters", func() {
+		spec.MaxClusters = 2
+		Expect(selected(rankClusters(&spec, clusters, current))).To(ConsistOf(remoteCluster2, remoteCluster1))
+	})
+
+	When("spilling over on low capacity", func() {
+		BeforeEach(func() { spec.SpillOverStrategy = offloadingv1beta1.OnLowCapacitySpillOverStrategyType })
+
+		It("should select the highest-ranked cluster only, if not low on resources", func() {
+			Expect(selected(rankClusters(&spec, clusters, current))).To(ConsistOf(remoteCluster2))
+		})
+
+		It("should select the lower-ranked clusters if the preceding ones are low on resources", func() {
+			clusters[1].LowCapacity = true
+			Expect(selected(rankClusters(&spec, clusters, current))).To(ConsistOf(remoteCluster2, remoteCluster1))
+		})
+
+		It("should keep the clusters the namespace is currently offloaded to", func() {
+			current[remoteCluster3] = true
+			Expect(selected(rankClusters(&spec, clusters, current))).To(ConsistOf(remoteCluster2, remoteCluster3))
+		})
+
+		It("should honor MaxClusters", func() {
+			spec.MaxClusters = 1
+			clusters[1].LowCapacity = true
+			current[remoteCluster3] = true
+			Expect(selected(rankClusters(&spec, clusters, current))).To(ConsistOf(remoteCluster2))
+		})
+	})
+
+	DescribeTable("Evaluating whether a cluster is low on resources",
+		func(hard, used corev1.ResourceList, expected bool) {
+			Expect(lowCapacity(hard, used, 20)).To(Equal(expected))
+		},
+		Entry("no resources assigned", corev1.ResourceList{}, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}, false),
+		Entry("enough free resources",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10"), corev1.ResourceMemory: resource.MustParse("10Gi")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("7"), corev1.ResourceMemory: resource.MustParse("8Gi")}, false),
+		Entry("a resource below the threshold",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10"), corev1.ResourceMemory: resource.MustParse("10Gi")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("9"), corev1.ResourceMemory: resource.MustParse("1Gi")}, true),
+		Entry("a resource fully used",
+			corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+			corev1.ResourceList{corev1.ResourcePods: resource.MustParse("12")}, true),
+	)
+})
@@ -0,0 +1,225 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package onqctrl
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils"
+	"github.com/liqotech/liqo/pkg/utils/resources"
+)
+
+// OffloadedNamespaceQuotaReconciler aggregates the resources consumed by an offloaded namespace in the local cluster
+// and in all the remote clusters it is offloaded to, reporting them in the status of the OffloadedNamespaceQuotas.
+// The resulting usage is then propagated to the remote clusters, to enforce the quotas upon the admission of ShadowPods.
+type OffloadedNamespaceQuotaReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+
+	LocalCluster liqov1beta1.ClusterID
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=offloadednamespacequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=offloadednamespacequotas/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespaceoffloadings,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+// Reconcile aggregates the resources consumed by the namespace the OffloadedNamespaceQuota refers to.
+func (r *OffloadedNamespaceQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var quota offloadingv1beta1.OffloadedNamespaceQuota
+	if err := r.Get(ctx, req.NamespacedName, &quota); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(4).Infof("OffloadedNamespaceQuota %q not found", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		klog.Errorf("Failed to retrieve OffloadedNamespaceQuota %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	clusters, err := r.usagePerCluster(ctx, quota.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	status := offloadingv1beta1.OffloadedNamespaceQuotaStatus{Used: corev1.ResourceList{}}
+	names := quotav1.ResourceNames(quota.Spec.Hard)
+	for _, cluster := range clusters {
+		used := quotav1.Mask(cluster.Used, names)
+		status.Clusters = append(status.Clusters, offloadingv1beta1.ClusterQuotaUsage{ClusterID: cluster.ClusterID, Used: used})
+		resources.AddResources(status.Used, used)
+	}
+	withinLimits, _ := quotav1.LessThanOrEqual(status.Used, quota.Spec.Hard)
+	status.Exceeded = !withinLimits
+
+	if equality.Semantic.DeepEqual(quota.Status.Used, status.Used) && equality.Semantic.DeepEqual(quota.Status.Clusters, status.Clusters) &&
+		quota.Status.Exceeded == status.Exceeded {
+		klog.V(4).Infof("OffloadedNamespaceQuota %q already up-to-date", req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	if status.Exceeded && !quota.Status.Exceeded {
+		klog.Warningf("Resources consumed by namespace %q exceed the OffloadedNamespaceQuota %q", quota.Namespace, quota.Name)
+		r.Recorder.Event(&quota, corev1.EventTypeWarning, "QuotaExceeded", "The resources consumed by the namespace exceed the hard limits")
+	}
+
+	status.LastUpdateTime = ptrNow()
+	quota.Status = status
+	if err := r.Status().Update(ctx, &quota); err != nil {
+		klog.Errorf("Failed to update the status of OffloadedNamespaceQuota %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	klog.V(4).Infof("Status of OffloadedNamespaceQuota %q correctly updated", req.NamespacedName)
+	return ctrl.Result{}, nil
+}
+
+// usagePerCluster returns the resources consumed by the given namespace in each cluster. Pods scheduled on virtual nodes
+// are accounted to the corresponding remote cluster, as the local view of the ones hosted by the remote namespace.
+// The returned list always includes the local cluster and all the remote clusters the namespace is offloaded to.
+func (r *OffloadedNamespaceQuotaReconciler) usagePerCluster(ctx context.Context, namespace string) ([]offloadingv1beta1.ClusterQuotaUsage, error) {
+	usage := map[liqov1beta1.ClusterID]corev1.ResourceList{r.LocalCluster: {}}
+
+	var nsoff offloadingv1beta1.NamespaceOffloading
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: consts.DefaultNamespaceOffloadingName}, &nsoff)
+	switch {
+	case err == nil:
+		for clusterID := range nsoff.Status.RemoteNamespacesConditions {
+			usage[liqov1beta1.ClusterID(clusterID)] = corev1.ResourceList{}
+		}
+	case !apierrors.IsNotFound(err):
+		klog.Errorf("Failed to retrieve the NamespaceOffloading of namespace %q: %v", namespace, err)
+		return nil, err
+	}
+
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes, client.MatchingLabels{consts.TypeLabel: consts.TypeNode}); err != nil {
+		klog.Errorf("Failed to list virtual nodes: %v", err)
+		return nil, err
+	}
+	virtualNodes := make(map[string]liqov1beta1.ClusterID, len(nodes.Items))
+	for i := range nodes.Items {
+		if utils.IsVirtualNode(&nodes.Items[i]) {
+			virtualNodes[nodes.Items[i].Name] = liqov1beta1.ClusterID(nodes.Items[i].Labels[consts.RemoteClusterID])
+		}
+	}
+
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(namespace)); err != nil {
+		klog.Errorf("Failed to list pods in namespace %q: %v", namespace, err)
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		clusterID := r.LocalCluster
+		if remote, found := virtualNodes[pod.Spec.NodeName]; found {
+			clusterID = remote
+		}
+		if _, found := usage[clusterID]; !found {
+			usage[clusterID] = corev1.ResourceList{}
+		}
+		resources.AddResources(usage[clusterID], resources.PodQuotaUsage(pod))
+	}
+
+	clusters := make([]offloadingv1beta1.ClusterQuotaUsage, 0, len(usage))
+	for clusterID, used := range usage {
+		clusters = append(clusters, offloadingv1beta1.ClusterQuotaUsage{ClusterID: clusterID, Used: used})
+	}
+	slices.SortFunc(clusters, func(a, b offloadingv1beta1.ClusterQuotaUsage) int {
+		return strings.Compare(string(a.ClusterID), string(b.ClusterID))
+	})
+	return clusters, nil
+}
+
+func ptrNow() *metav1.Time {
+	now := metav1.Now()
+	return &now
+}
+
+// SetupWithManager registers a new controller for OffloadedNamespaceQuota resources.
+func (r *OffloadedNamespaceQuotaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlNamespaceQuota).
+		For(&offloadingv1beta1.OffloadedNamespaceQuota{}).
+		Watches(&corev1.Pod{}, r.enqueueNamespaceQuotas(), builder.WithPredicates(r.podPredicate())).
+		Watches(&offloadingv1beta1.NamespaceOffloading{}, r.enqueueNamespaceQuotas()).
+		Complete(r)
+}
+
+// podPredicate filters the pod events relevant for the computation of the namespace usage, i.e., those concerning
+// offloaded namespaces and, in case of updates, changing the node the pod is bound to, its phase or its requests.
+func (r *OffloadedNamespaceQuotaReconciler) podPredicate() predicate.Predicate {
+	offloaded := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		var nsoff offloadingv1beta1.NamespaceOffloading
+		key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: consts.DefaultNamespaceOffloadingName}
+		return r.Get(context.Background(), key, &nsoff) == nil
+	})
+
+	changed := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+			newPod, okNew := e.ObjectNew.(*corev1.Pod)
+			if !okOld || !okNew {
+				return true
+			}
+			return oldPod.Spec.NodeName != newPod.Spec.NodeName || oldPod.Status.Phase != newPod.Status.Phase ||
+				!quotav1.Equals(resources.PodQuotaUsage(oldPod), resources.PodQuotaUsage(newPod))
+		},
+	}
+
+	return predicate.And(offloaded, changed)
+}
+
+// enqueueNamespaceQuotas enqueues all the OffloadedNamespaceQuotas in the namespace of the given object.
+func (r *OffloadedNamespaceQuotaReconciler) enqueueNamespaceQuotas() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var quotas offloadingv1beta1.OffloadedNamespaceQuotaList
+		if err := r.List(ctx, &quotas, client.InNamespace(obj.GetNamespace())); err != nil {
+			klog.Errorf("Failed to list OffloadedNamespaceQuotas in namespace %q: %v", obj.GetNamespace(), err)
+			return nil
+		}
+
+		reqs := make([]reconcile.Request, len(quotas.Items))
+		for i := range quotas.Items {
+			reqs[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&quotas.Items[i])}
+		}
+		return reqs
+	})
+}
@@ -0,0 +1,205 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package onqctrl
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubectl/pkg/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+)
+
+var _ = Describe("OffloadedNamespaceQuotaController", func() {
+	const (
+		ns           = "default"
+		localCluster = liqov1beta1.ClusterID("local")
+	)
+
+	var (
+		ctx        context.Context
+		fakeClient client.WithWatch
+		reconciler *OffloadedNamespaceQuotaReconciler
+		quota      *offloadingv1beta1.OffloadedNamespaceQuota
+
+		newVirtualNode = func(name string, clusterID liqov1beta1.ClusterID) *corev1.Node {
+			return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{
+				consts.TypeLabel: consts.TypeNode, consts.RemoteClusterID: string(clusterID)}}}
+		}
+
+		newPod = func(name, node, cpu string, phase corev1.PodPhase) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+				Spec: corev1.PodSpec{
+					NodeName: node,
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx", Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+					}}},
+				},
+				Status: corev1.PodStatus{Phase: phase},
+			}
+		}
+
+		nsoff = &offloadingv1beta1.NamespaceOffloading{
+			ObjectMeta: metav1.ObjectMeta{Name: consts.DefaultNamespaceOffloadingName, Namespace: ns},
+			Status: offloadingv1beta1.NamespaceOffloadingStatus{
+				RemoteNamespacesConditions: map[string]offloadingv1beta1.RemoteNamespaceConditions{
+					"remote-1": {}, "remote-2": {},
+				},
+			},
+		}
+
+		reconcile = func() {
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(quota)})
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			ExpectWithOffset(1, fakeClient.Get(ctx, client.ObjectKeyFromObject(quota), quota)).To(Succeed())
+		}
+
+		cpuOf = func(clusterID liqov1beta1.ClusterID) string {
+			usage := quota.Status.ClusterUsage(clusterID)
+			return usage.Cpu().String()
+		}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		quota = &offloadingv1beta1.OffloadedNamespaceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: ns},
+			Spec: offloadingv1beta1.OffloadedNamespaceQuotaSpec{Hard: corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("4"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		reconciler = &OffloadedNamespaceQuotaReconciler{
+			Client:       fakeClient,
+			Recorder:     record.NewFakeRecorder(10),
+			LocalCluster: localCluster,
+		}
+	})
+
+	When("the namespace is offloaded to multiple clusters", func() {
+		BeforeEach(func() {
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).
+				WithObjects(quota, nsoff.DeepCopy(), newVirtualNode("liqo-remote-1", "remote-1"), newVirtualNode("liqo-remote-2", "remote-2"),
+					newPod("local", "worker", "1", corev1.PodRunning),
+					newPod("remote-1-a", "liqo-remote-1", "500m", corev1.PodRunning),
+					newPod("remote-1-b", "liqo-remote-1", "500m", corev1.PodPending),
+					newPod("completed", "liqo-remote-1", "2", corev1.PodSucceeded),
+					newPod("unscheduled", "", "2", corev1.PodPending)).
+				WithStatusSubresource(&offloadingv1beta1.OffloadedNamespaceQuota{}).Build()
+		})
+
+		It("should aggregate the usage of each cluster", func() {
+			reconcile()
+			Expect(quota.Status.Clusters).To(HaveLen(3))
+			Expect(cpuOf(localCluster)).To(Equal("1"))
+			Expect(cpuOf("remote-1")).To(Equal("1"))
+			Expect(cpuOf("remote-2")).To(Equal("0"))
+			Expect(quota.Status.Used.Cpu().String()).To(Equal("2"))
+			Expect(quota.Status.Used.Pods().String()).To(Equal("3"))
+			Expect(quota.Status.Exceeded).To(BeFalse())
+			Expect(quota.Status.LastUpdateTime).ToNot(BeNil())
+		})
+
+		It("should only account for the resources limited by the quota", func() {
+			reconcile()
+			Expect(quota.Status.Used).ToNot(HaveKey(corev1.ResourceMemory))
+		})
+
+		It("should flag the quota as exceeded when the usage is above the hard limits", func() {
+			Expect(fakeClient.Create(ctx, newPod("large", "liqo-remote-2", "3", corev1.PodRunning))).To(Succeed())
+			reconcile()
+			Expect(cpuOf("remote-2")).To(Equal("3"))
+			Expect(quota.Status.Exceeded).To(BeTrue())
+		})
+	})
+
+	When("the namespace is not offloaded", func() {
+		BeforeEach(func() {
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).
+				WithObjects(quota, newPod("local", "worker", "1", corev1.PodRunning)).
+				WithStatusSubresource(&offloadingv1beta1.OffloadedNamespaceQuota{}).Build()
+		})
+
+		It("should account for the local usage only", func() {
+			reconcile()
+			Expect(quota.Status.Clusters).To(HaveLen(1))
+			Expect(cpuOf(localCluster)).To(Equal("1"))
+		})
+	})
+
+	Describe("the pod predicate", func() {
+		var pod *corev1.Pod
+
+		BeforeEach(func() {
+			pod = newPod("pod", "liqo-remote-1", "1", corev1.PodPending)
+		})
+
+		When("the namespace is offloaded", func() {
+			BeforeEach(func() {
+				fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(nsoff.DeepCopy()).Build()
+			})
+
+			It("should accept the creation and deletion of pods", func() {
+				Expect(reconciler.podPredicate().Create(event.CreateEvent{Object: pod})).To(BeTrue())
+				Expect(reconciler.podPredicate().Delete(event.DeleteEvent{Object: pod})).To(BeTrue())
+			})
+
+			It("should accept the updates changing the phase of the pod", func() {
+				updated := pod.DeepCopy()
+				updated.Status.Phase = corev1.PodSucceeded
+				Expect(reconciler.podPredicate().Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: updated})).To(BeTrue())
+			})
+
+			It("should accept the updates changing the requests of the pod", func() {
+				updated := pod.DeepCopy()
+				updated.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU] = resource.MustParse("2")
+				Expect(reconciler.podPredicate().Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: updated})).To(BeTrue())
+			})
+
+			It("should discard the updates not affecting the usage", func() {
+				updated := pod.DeepCopy()
+				updated.Labels = map[string]string{"foo": "bar"}
+				Expect(reconciler.podPredicate().Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: updated})).To(BeFalse())
+			})
+		})
+
+		When("the namespace is not offloaded", func() {
+			BeforeEach(func() {
+				fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+			})
+
+			It("should discard the events", func() {
+				Expect(reconciler.podPredicate().Create(event.CreateEvent{Object: pod})).To(BeFalse())
+				Expect(reconciler.podPredicate().Delete(event.DeleteEvent{Object: pod})).To(BeFalse())
+			})
+		})
+	})
+})
@@ -17,7 +17,10 @@ package liqocontrollermanager
 import (
 	"time"
 
+	"github.com/liqotech/liqo/pkg/audit"
 	identitymanager "github.com/liqotech/liqo/pkg/identityManager"
+	"github.com/liqotech/liqo/pkg/identityManager/signer"
+	"github.com/liqotech/liqo/pkg/resourceoffer"
 	"github.com/liqotech/liqo/pkg/utils/args"
 )
 
@@ -44,16 +47,21 @@ type Options struct {
 	DefaultLimitsEnforcement   string
 
 	// Networking module
-	IPAMServer                     string
-	GatewayServerResources         args.StringList
-	GatewayClientResources         args.StringList
-	WgGatewayServerClusterRoleName string
-	WgGatewayClientClusterRoleName string
-	FabricFullMasqueradeEnabled    bool
-	GwmasqbypassEnabled            bool
-	NetworkWorkers                 int
-	IPWorkers                      int
-	GenevePort                     uint16
+	IPAMServer                        string
+	GatewayServerResources            args.StringList
+	GatewayClientResources            args.StringList
+	WgGatewayServerClusterRoleName    string
+	WgGatewayClientClusterRoleName    string
+	IPsecGatewayServerClusterRoleName string
+	IPsecGatewayClientClusterRoleName string
+	FabricFullMasqueradeEnabled       bool
+	GwmasqbypassEnabled               bool
+	FabricEncryption                  args.StringEnum
+	NetworkWorkers                    int
+	IPWorkers                         int
+	GenevePort                        uint16
+	TransitAcceptedClusters           args.StringList
+	TransitMaxAdvertisedClusters      int
 
 	// Authentication module
 	APIServerAddressOverride string
@@ -61,13 +69,23 @@ type Options struct {
 	TrustedCA                bool
 	TLSCompatibilityMode     bool
 	AWSConfig                *identitymanager.LocalAwsConfig
+	OIDCConfig               *identitymanager.LocalOIDCConfig
+	CertificateSigner        *args.StringEnum
+	SignerConfig             *signer.Config
 	ClusterLabels            args.StringMap
 	IngressClasses           args.ClassNameList
 	LoadBalancerClasses      args.ClassNameList
 	DefaultNodeResources     args.ResourceMap
+	CapacityResyncPeriod     time.Duration
+	ResourceSlicePlugins     args.StringMap
+	ResourceSlicePluginsTLS  *resourceoffer.TLSConfig
 	GlobalLabels             args.StringMap
 	GlobalAnnotations        args.StringMap
 
+	// Authentication & Offloading modules
+	AutoscalingSyncPeriod     time.Duration
+	AutoscalingScaleDownDelay time.Duration
+
 	// Offloading module
 	EnableStorage               bool
 	VirtualStorageClassName     string
@@ -76,14 +94,21 @@ type Options struct {
 	EnableNodeFailureController bool
 	ShadowPodWorkers            int
 	ShadowEndpointSliceWorkers  int
+	VolumeMoverImage            string
+	VolumeMoverServiceAccount   string
 
 	// Cross module
 	EnableAPIServerIPRemapping bool
+	AuditConfig                *audit.Config
 }
 
 // NewOptions creates a new Options struct with default values.
 func NewOptions() *Options {
 	return &Options{
-		AWSConfig: &identitymanager.LocalAwsConfig{},
+		AWSConfig:               &identitymanager.LocalAwsConfig{},
+		OIDCConfig:              &identitymanager.LocalOIDCConfig{},
+		SignerConfig:            &signer.Config{},
+		ResourceSlicePluginsTLS: &resourceoffer.TLSConfig{},
+		AuditConfig:             &audit.Config{},
 	}
 }
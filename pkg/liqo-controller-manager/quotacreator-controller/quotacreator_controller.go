@@ -33,6 +33,7 @@ import (
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/internal/crdReplicator/reflection"
+	"github.com/liqotech/liqo/pkg/auth"
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	"github.com/liqotech/liqo/pkg/utils/resource"
@@ -94,7 +95,7 @@ func (r *QuotaCreatorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		},
 	}
 	_, err := resource.CreateOrUpdate(ctx, r.Client, &quota, func() error {
-		quota.Spec.User = userName
+		quota.Spec.User = auth.UserName(userName)
 		quota.Spec.LimitsEnforcement = r.DefaultLimitsEnforcement
 		quota.Spec.Resources = resourceSlice.Status.Resources.DeepCopy()
 
@@ -0,0 +1,101 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcesliceautoscalercontroller
+
+import (
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+)
+
+// tolerance is the relative difference between the current and the desired resources
+// below which the ResourceSlice is not resized, to prevent flapping.
+const tolerance = 0.1
+
+// desiredResources returns the resources to be requested by an autoscaled ResourceSlice, given the currently
+// requested ones and the demand of the offloaded pods, along with whether they differ from the current ones.
+// Only the resources bounded by the autoscaling policy are resized, while the other ones are left untouched.
+// If allowScaleDown is false, the resources can only grow.
+func desiredResources(current, demand corev1.ResourceList, autoscaling *authv1beta1.ResourceSliceAutoscaling,
+	allowScaleDown bool) (desired corev1.ResourceList, changed bool) {
+	desired = current.DeepCopy()
+	if desired == nil {
+		desired = corev1.ResourceList{}
+	}
+
+	for name, upper := range autoscaling.MaxResources {
+		target := scaleToTarget(name, demand[name], autoscaling.TargetUtilizationPercentage)
+
+		lower, hasLower := autoscaling.MinResources[name]
+		switch {
+		case target.Cmp(upper) > 0:
+			target = upper.DeepCopy()
+		case hasLower && target.Cmp(lower) < 0:
+			target = lower.DeepCopy()
+		}
+
+		cur, ok := current[name]
+		if ok && withinBounds(cur, lower, hasLower, upper) {
+			if withinTolerance(cur, target) || (!allowScaleDown && target.Cmp(cur) < 0) {
+				continue
+			}
+		}
+
+		if !ok || !target.Equal(cur) {
+			desired[name] = target
+			changed = true
+		}
+	}
+
+	return desired, changed
+}
+
+// scaleToTarget returns the quantity such that the given demand corresponds to the target utilization percentage.
+func scaleToTarget(name corev1.ResourceName, demand resource.Quantity, targetPercentage int32) resource.Quantity {
+	if targetPercentage <= 0 || targetPercentage > 100 {
+		targetPercentage = 100
+	}
+
+	// CPU is handled at the millicore granularity, while the other resources are expressed as integer values.
+	if name == corev1.ResourceCPU {
+		milli := int64(math.Ceil(float64(demand.MilliValue()) * 100 / float64(targetPercentage)))
+		return *resource.NewMilliQuantity(milli, resource.DecimalSI)
+	}
+
+	format := demand.Format
+	if format == "" {
+		format = resource.DecimalSI
+	}
+	value := int64(math.Ceil(float64(demand.Value()) * 100 / float64(targetPercentage)))
+	return *resource.NewQuantity(value, format)
+}
+
+// withinBounds returns whether the given quantity is within the given bounds.
+func withinBounds(q, lower resource.Quantity, hasLower bool, upper resource.Quantity) bool {
+	return q.Cmp(upper) <= 0 && (!hasLower || q.Cmp(lower) >= 0)
+}
+
+// withinTolerance returns whether the relative difference between the current and the target quantities
+// is below the tolerance.
+func withinTolerance(current, target resource.Quantity) bool {
+	cur := current.AsApproximateFloat64()
+	if cur == 0 {
+		return target.IsZero()
+	}
+	return math.Abs(target.AsApproximateFloat64()-cur)/cur <= tolerance
+}
@@ -0,0 +1,112 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcesliceautoscalercontroller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+)
+
+var _ = Describe("Autoscaling computation", func() {
+	var autoscaling *authv1beta1.ResourceSliceAutoscaling
+
+	BeforeEach(func() {
+		autoscaling = &authv1beta1.ResourceSliceAutoscaling{
+			MinResources: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			MaxResources: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			TargetUtilizationPercentage: 50,
+		}
+	})
+
+	When("the ResourceSlice does not request any resource yet", func() {
+		It("should request the lower bounds", func() {
+			desired, changed := desiredResources(nil, corev1.ResourceList{}, autoscaling, true)
+			Expect(changed).To(BeTrue())
+			Expect(desired.Cpu().Equal(resource.MustParse("1"))).To(BeTrue())
+			Expect(desired.Memory().Equal(resource.MustParse("1Gi"))).To(BeTrue())
+		})
+	})
+
+	When("the demand grows", func() {
+		It("should scale up according to the target utilization, up to the upper bounds", func() {
+			current := corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			}
+			demand := corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1500m"),
+				corev1.ResourceMemory: resource.MustParse("10Gi"),
+			}
+			desired, changed := desiredResources(current, demand, autoscaling, false)
+			Expect(changed).To(BeTrue())
+			Expect(desired.Cpu().Equal(resource.MustParse("3"))).To(BeTrue())
+			Expect(desired.Memory().Equal(resource.MustParse("16Gi"))).To(BeTrue())
+			Expect(desired.Pods().Equal(resource.MustParse("110"))).To(BeTrue())
+		})
+	})
+
+	When("the demand decreases", func() {
+		var current, demand corev1.ResourceList
+
+		BeforeEach(func() {
+			current = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}
+			demand = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}
+		})
+
+		It("should scale down if allowed", func() {
+			desired, changed := desiredResources(current, demand, autoscaling, true)
+			Expect(changed).To(BeTrue())
+			Expect(desired.Cpu().Equal(resource.MustParse("2"))).To(BeTrue())
+			Expect(desired.Memory().Equal(resource.MustParse("8Gi"))).To(BeTrue())
+		})
+
+		It("should not scale down if not allowed", func() {
+			_, changed := desiredResources(current, demand, autoscaling, false)
+			Expect(changed).To(BeFalse())
+		})
+	})
+
+	When("the difference is within the tolerance", func() {
+		It("should not resize the ResourceSlice", func() {
+			current := corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			}
+			demand := corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1050m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}
+			_, changed := desiredResources(current, demand, autoscaling, true)
+			Expect(changed).To(BeFalse())
+		})
+	})
+})
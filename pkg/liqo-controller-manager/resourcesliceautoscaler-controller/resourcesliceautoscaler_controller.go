@@ -0,0 +1,346 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcesliceautoscalercontroller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	k8shelper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/klog/v2"
+	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/internal/crdReplicator/reflection"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/indexer"
+	"github.com/liqotech/liqo/pkg/utils/resources"
+)
+
+// ResourceSliceAutoscalerReconciler resizes the resources requested by the local ResourceSlices with autoscaling enabled,
+// according to the resources demanded by the pods scheduled on the associated virtual nodes.
+type ResourceSliceAutoscalerReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	EventRecorder record.EventRecorder
+
+	metricsClient  metricsv1beta1.PodMetricsesGetter
+	syncPeriod     time.Duration
+	scaleDownDelay time.Duration
+}
+
+// NewResourceSliceAutoscalerReconciler returns a new ResourceSliceAutoscalerReconciler.
+// The metrics client is optional: if nil, only the resources requested by the offloaded pods are considered.
+func NewResourceSliceAutoscalerReconciler(cl client.Client, s *runtime.Scheme, recorder record.EventRecorder,
+	metricsClient metricsv1beta1.PodMetricsesGetter, syncPeriod, scaleDownDelay time.Duration) *ResourceSliceAutoscalerReconciler {
+	return &ResourceSliceAutoscalerReconciler{
+		Client: cl,
+		Scheme: s,
+
+		EventRecorder: recorder,
+
+		metricsClient:  metricsClient,
+		syncPeriod:     syncPeriod,
+		scaleDownDelay: scaleDownDelay,
+	}
+}
+
+// cluster-role
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=resourceslices,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=virtualnodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+// Reconcile resizes the resources requested by the autoscaled resourceslices.
+func (r *ResourceSliceAutoscalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var resourceSlice authv1beta1.ResourceSlice
+	if err := r.Get(ctx, req.NamespacedName, &resourceSlice); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(4).Infof("resourceSlice %q not found", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		klog.Errorf("unable to get ResourceSlice %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	if resourceSlice.DeletionTimestamp != nil || resourceSlice.Spec.Autoscaling == nil {
+		return ctrl.Result{}, nil
+	}
+
+	demand, err := r.getDemand(ctx, &resourceSlice)
+	if err != nil {
+		klog.Errorf("unable to compute the resources demanded to ResourceSlice %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	desired, changed := desiredResources(resourceSlice.Spec.Resources, demand,
+		resourceSlice.Spec.Autoscaling, r.scaleDownAllowed(&resourceSlice))
+	if !changed {
+		klog.V(4).Infof("ResourceSlice %q does not need to be resized", req.NamespacedName)
+		return ctrl.Result{RequeueAfter: r.syncPeriod}, nil
+	}
+
+	resourceSlice.Spec.Resources = desired
+	if resourceSlice.Annotations == nil {
+		resourceSlice.Annotations = map[string]string{}
+	}
+	resourceSlice.Annotations[consts.LastScaleTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+
+	if err := r.Update(ctx, &resourceSlice); err != nil {
+		klog.Errorf("unable to update ResourceSlice %q: %v", req.NamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	msg := fmt.Sprintf("ResourceSlice resized to cpu: %s, memory: %s, pods: %s",
+		resources.CPU(desired), resources.Memory(desired), resources.Pods(desired))
+	klog.Infof("%s (resourceslice %q)", msg, req.NamespacedName)
+	r.EventRecorder.Event(&resourceSlice, corev1.EventTypeNormal, "ResourceSliceResized", msg)
+
+	return ctrl.Result{RequeueAfter: r.syncPeriod}, nil
+}
+
+// getDemand returns the resources demanded by the pods scheduled on the virtual node associated with the given
+// ResourceSlice, including the pending ones attributed to it among the ones not bound to any node yet (see
+// getPendingPods). For each resource, the maximum between the requested and the measured usage is considered,
+// while the pods resource accounts for the number of pods.
+func (r *ResourceSliceAutoscalerReconciler) getDemand(ctx context.Context,
+	resourceSlice *authv1beta1.ResourceSlice) (corev1.ResourceList, error) {
+	nodeName, err := r.getVirtualNodeName(ctx, resourceSlice)
+	if err != nil {
+		return nil, err
+	}
+	if nodeName == "" {
+		// The virtual node has not been created yet, hence no pod can be scheduled on it.
+		return corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(0, resource.DecimalSI)}, nil
+	}
+
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.MatchingFields{indexer.FieldNodeNameFromPod: nodeName}); err != nil {
+		return nil, fmt.Errorf("unable to list the pods scheduled on virtual node %q: %w", nodeName, err)
+	}
+
+	pods := make([]corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		if phase := podList.Items[i].Status.Phase; phase == corev1.PodSucceeded || phase == corev1.PodFailed {
+			continue
+		}
+		pods = append(pods, podList.Items[i])
+	}
+
+	pending, err := r.getPendingPods(ctx, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The pending pods are not bound to any node yet, hence they are not accounted by SumPodsRequests.
+	demand := resources.SumPodsRequests(pods)
+	for i := range pending {
+		resources.AddResources(demand, resources.PodQuotaUsage(&pending[i]))
+	}
+	pods = append(pods, pending...)
+
+	resources.MaxResources(demand, r.getUsage(ctx, pods))
+	demand[corev1.ResourcePods] = *resource.NewQuantity(int64(len(pods)), resource.DecimalSI)
+	return demand, nil
+}
+
+// getPendingPods returns the pending pods not bound to any node yet which are attributed to the given virtual node.
+// Each pod is attributed to a single virtual node, i.e., the first one (by name) among those associated with autoscaled
+// ResourceSlices which it could be scheduled on, so that a pending pod does not make all the ResourceSlices grow at once.
+func (r *ResourceSliceAutoscalerReconciler) getPendingPods(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	nodes, err := r.getAutoscaledVirtualNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(nodes, func(node corev1.Node) bool { return node.Name == nodeName }) {
+		// The virtual node has not been created yet, hence no pod can be scheduled on it.
+		return nil, nil
+	}
+
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.MatchingFields{indexer.FieldNodeNameFromPod: ""}); err != nil {
+		return nil, fmt.Errorf("unable to list the pods not bound to any node: %w", err)
+	}
+
+	pods := make([]corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		if podList.Items[i].Status.Phase != corev1.PodPending {
+			continue
+		}
+		idx := slices.IndexFunc(nodes, func(node corev1.Node) bool { return schedulableOn(&podList.Items[i], &node) })
+		if idx >= 0 && nodes[idx].Name == nodeName {
+			pods = append(pods, podList.Items[i])
+		}
+	}
+	return pods, nil
+}
+
+// getAutoscaledVirtualNodes returns the virtual nodes associated with the local ResourceSlices with autoscaling enabled,
+// sorted by name. The virtual nodes not created yet are ignored.
+func (r *ResourceSliceAutoscalerReconciler) getAutoscaledVirtualNodes(ctx context.Context) ([]corev1.Node, error) {
+	selector, err := metav1.LabelSelectorAsSelector(ptr.To(reflection.LocalResourcesLabelSelector()))
+	if err != nil {
+		return nil, err
+	}
+	var resourceSlices authv1beta1.ResourceSliceList
+	if err := r.List(ctx, &resourceSlices, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("unable to list the local ResourceSlices: %w", err)
+	}
+
+	nodes := make([]corev1.Node, 0, len(resourceSlices.Items))
+	for i := range resourceSlices.Items {
+		if resourceSlices.Items[i].Spec.Autoscaling == nil {
+			continue
+		}
+		nodeName, err := r.getVirtualNodeName(ctx, &resourceSlices.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		if nodeName == "" {
+			continue
+		}
+		var node corev1.Node
+		if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to get virtual node %q: %w", nodeName, err)
+		}
+		nodes = append(nodes, node)
+	}
+	slices.SortFunc(nodes, func(a, b corev1.Node) int { return strings.Compare(a.Name, b.Name) })
+	return nodes, nil
+}
+
+// getVirtualNodeName returns the name of the virtual node created from the given ResourceSlice, which is shared by the
+// VirtualNode resource and the corresponding node. An empty string is returned if the virtual node has not been created yet.
+func (r *ResourceSliceAutoscalerReconciler) getVirtualNodeName(ctx context.Context, resourceSlice *authv1beta1.ResourceSlice) (string, error) {
+	var virtualNodes offloadingv1beta1.VirtualNodeList
+	if err := r.List(ctx, &virtualNodes, client.InNamespace(resourceSlice.Namespace),
+		client.MatchingLabels{consts.ResourceSliceNameLabelKey: resourceSlice.Name}); err != nil {
+		return "", fmt.Errorf("unable to list the VirtualNodes of ResourceSlice %q: %w", client.ObjectKeyFromObject(resourceSlice), err)
+	}
+	if len(virtualNodes.Items) == 0 {
+		return "", nil
+	}
+	return virtualNodes.Items[0].Name, nil
+}
+
+// schedulableOn returns whether the given pod tolerates the taints of the given node, and matches its required node affinity.
+func schedulableOn(pod *corev1.Pod, node *corev1.Node) bool {
+	if _, untolerated := k8shelper.FindMatchingUntoleratedTaint(klog.Background(), node.Spec.Taints, pod.Spec.Tolerations,
+		func(t *corev1.Taint) bool {
+			return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
+		}, false); untolerated {
+		return false
+	}
+	match, err := nodeaffinity.GetRequiredNodeAffinity(pod).Match(node)
+	return err == nil && match
+}
+
+// getUsage returns the resources actually used by the given pods, as reported by the metrics API.
+// The metrics are listed once per namespace, while the pods whose metrics are not available are ignored.
+func (r *ResourceSliceAutoscalerReconciler) getUsage(ctx context.Context, pods []corev1.Pod) corev1.ResourceList {
+	usage := corev1.ResourceList{}
+	if r.metricsClient == nil {
+		return usage
+	}
+
+	running := map[string]sets.Set[string]{}
+	for i := range pods {
+		if pods[i].Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if _, ok := running[pods[i].Namespace]; !ok {
+			running[pods[i].Namespace] = sets.New[string]()
+		}
+		running[pods[i].Namespace].Insert(pods[i].Name)
+	}
+
+	for namespace, names := range running {
+		metrics, err := r.metricsClient.PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			klog.V(4).Infof("unable to list the pod metrics in namespace %q: %v", namespace, err)
+			continue
+		}
+		for i := range metrics.Items {
+			if !names.Has(metrics.Items[i].Name) {
+				continue
+			}
+			for j := range metrics.Items[i].Containers {
+				resources.AddResources(usage, metrics.Items[i].Containers[j].Usage)
+			}
+		}
+	}
+	return usage
+}
+
+// scaleDownAllowed returns whether the resources of the given ResourceSlice can be decreased,
+// i.e., whether the scale down delay elapsed since the last time they were changed.
+func (r *ResourceSliceAutoscalerReconciler) scaleDownAllowed(resourceSlice *authv1beta1.ResourceSlice) bool {
+	last, ok := resourceSlice.Annotations[consts.LastScaleTimeAnnotation]
+	if !ok {
+		return true
+	}
+	lastScaleTime, err := time.Parse(time.RFC3339, last)
+	if err != nil {
+		klog.Warningf("invalid %s annotation on ResourceSlice %q: %v",
+			consts.LastScaleTimeAnnotation, client.ObjectKeyFromObject(resourceSlice), err)
+		return true
+	}
+	return time.Since(lastScaleTime) >= r.scaleDownDelay
+}
+
+// SetupWithManager register the ResourceSliceAutoscalerReconciler with the manager.
+func (r *ResourceSliceAutoscalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// generate the predicate to filter just the ResourceSlices created by the local cluster checking crdReplicator labels
+	localResSliceFilter, err := predicate.LabelSelectorPredicate(reflection.LocalResourcesLabelSelector())
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).Named(consts.CtrlResourceSliceAutoscaler).
+		For(&authv1beta1.ResourceSlice{}, builder.WithPredicates(predicate.And(localResSliceFilter, withAutoscaling()))).
+		Complete(r)
+}
+
+func withAutoscaling() predicate.Funcs {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		rs, ok := obj.(*authv1beta1.ResourceSlice)
+		if !ok {
+			return false
+		}
+		return rs.Spec.Autoscaling != nil
+	})
+}
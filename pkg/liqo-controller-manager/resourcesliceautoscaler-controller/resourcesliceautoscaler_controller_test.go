@@ -0,0 +1,211 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcesliceautoscalercontroller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/indexer"
+)
+
+var _ = Describe("Demand computation", func() {
+	const (
+		resourceSliceName      = "remote-cluster"
+		otherResourceSliceName = "remote-cluster-other"
+		// The virtual nodes are not named after the ResourceSlices, to check they are resolved through the label.
+		virtualNodeName      = "liqo-remote-cluster"
+		otherVirtualNodeName = "liqo-remote-cluster-other"
+	)
+
+	var (
+		ctx           context.Context
+		objects       []client.Object
+		metrics       *metricsfake.Clientset
+		metricsLists  []string
+		resourceSlice *authv1beta1.ResourceSlice
+	)
+
+	newPod := func(name, namespace, nodeName string, phase corev1.PodPhase, cpu string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{Name: "container", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				}}},
+				Tolerations: []corev1.Toleration{{Key: "virtual-node", Operator: corev1.TolerationOpExists}},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	newVirtualNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"type": "virtual-node", "name": name}},
+			Spec:       corev1.NodeSpec{Taints: []corev1.Taint{{Key: "virtual-node", Effect: corev1.TaintEffectNoExecute}}},
+		}
+	}
+
+	newVirtualNodeResource := func(name, resourceSliceName string) *offloadingv1beta1.VirtualNode {
+		return &offloadingv1beta1.VirtualNode{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant",
+			Labels: map[string]string{consts.ResourceSliceNameLabelKey: resourceSliceName}}}
+	}
+
+	newResourceSlice := func(name string) *authv1beta1.ResourceSlice {
+		return &authv1beta1.ResourceSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant", Labels: map[string]string{
+				consts.ReplicationRequestedLabel:   consts.ReplicationRequestedLabelValue,
+				consts.ReplicationDestinationLabel: name,
+			}},
+			Spec: authv1beta1.ResourceSliceSpec{Autoscaling: &authv1beta1.ResourceSliceAutoscaling{}},
+		}
+	}
+
+	newPodMetrics := func(name, namespace, cpu string) metricsv1beta1.PodMetrics {
+		return metricsv1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Containers: []metricsv1beta1.ContainerMetrics{{Name: "container", Usage: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse(cpu),
+			}}},
+		}
+	}
+
+	getDemandOf := func(rs *authv1beta1.ResourceSlice) corev1.ResourceList {
+		cl := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(objects...).
+			WithIndex(&corev1.Pod{}, indexer.FieldNodeNameFromPod, indexer.ExtractNodeName).Build()
+		r := NewResourceSliceAutoscalerReconciler(cl, testScheme, record.NewFakeRecorder(10), metrics.MetricsV1beta1(), 0, 0)
+		demand, err := r.getDemand(ctx, rs)
+		Expect(err).ToNot(HaveOccurred())
+		return demand
+	}
+
+	getDemand := func() corev1.ResourceList {
+		return getDemandOf(resourceSlice)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		resourceSlice = newResourceSlice(resourceSliceName)
+		objects = []client.Object{
+			resourceSlice,
+			newVirtualNodeResource(virtualNodeName, resourceSliceName),
+			newVirtualNode(virtualNodeName),
+			newPod("scheduled", "foo", virtualNodeName, corev1.PodRunning, "1"),
+			newPod("other", "bar", virtualNodeName, corev1.PodRunning, "1"),
+			newPod("completed", "foo", virtualNodeName, corev1.PodSucceeded, "1"),
+			newPod("elsewhere", "foo", "physical-node", corev1.PodRunning, "1"),
+		}
+
+		metricsLists = nil
+		metrics = metricsfake.NewSimpleClientset()
+		metrics.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			metricsLists = append(metricsLists, action.GetNamespace())
+			return true, &metricsv1beta1.PodMetricsList{Items: []metricsv1beta1.PodMetrics{
+				newPodMetrics("scheduled", action.GetNamespace(), "3"),
+				newPodMetrics("elsewhere", action.GetNamespace(), "5"),
+			}}, nil
+		})
+	})
+
+	It("should consider the usage of the pods scheduled on the virtual node, listing the metrics once per namespace", func() {
+		demand := getDemand()
+		// The requests sum up to 2 CPUs, while the usage of the pod on the physical node is ignored.
+		Expect(demand.Cpu().String()).To(Equal("3"))
+		Expect(demand.Pods().Value()).To(BeEquivalentTo(2))
+		Expect(metricsLists).To(ConsistOf("foo", "bar"))
+	})
+
+	When("the virtual node has not been created yet", func() {
+		BeforeEach(func() {
+			objects = []client.Object{resourceSlice, newPod("pending", "foo", "", corev1.PodPending, "2"),
+				newPod("named-after-slice", "foo", resourceSliceName, corev1.PodRunning, "1")}
+		})
+
+		It("should not demand any resource", func() {
+			demand := getDemand()
+			Expect(demand.Cpu().IsZero()).To(BeTrue())
+			Expect(demand.Pods().Value()).To(BeZero())
+		})
+	})
+
+	When("some pods are pending", func() {
+		BeforeEach(func() {
+			unschedulable := newPod("not-tolerating", "foo", "", corev1.PodPending, "1")
+			unschedulable.Spec.Tolerations = nil
+			affinity := newPod("not-matching", "foo", "", corev1.PodPending, "1")
+			affinity.Spec.NodeSelector = map[string]string{"type": "physical-node"}
+			objects = append(objects,
+				newPod("pending", "foo", "", corev1.PodPending, "2"),
+				newPod("bound", "foo", virtualNodeName, corev1.PodPending, "2"),
+				unschedulable, affinity)
+		})
+
+		It("should include the ones which could be scheduled on the virtual node", func() {
+			demand := getDemand()
+			Expect(demand.Cpu().String()).To(Equal("6"))
+			Expect(demand.Pods().Value()).To(BeEquivalentTo(4))
+		})
+
+		When("multiple ResourceSlices are autoscaled", func() {
+			var otherResourceSlice *authv1beta1.ResourceSlice
+
+			BeforeEach(func() {
+				otherResourceSlice = newResourceSlice(otherResourceSliceName)
+				targeted := newPod("targeted", "foo", "", corev1.PodPending, "4")
+				targeted.Spec.NodeSelector = map[string]string{"name": otherVirtualNodeName}
+				objects = append(objects, otherResourceSlice, newVirtualNodeResource(otherVirtualNodeName, otherResourceSliceName),
+					newVirtualNode(otherVirtualNodeName), targeted)
+			})
+
+			It("should attribute each pending pod to a single virtual node", func() {
+				demand := getDemand()
+				Expect(demand.Cpu().String()).To(Equal("6"))
+				Expect(demand.Pods().Value()).To(BeEquivalentTo(4))
+
+				// The pending pod which could be scheduled on both virtual nodes is not accounted twice.
+				demand = getDemandOf(otherResourceSlice)
+				Expect(demand.Cpu().String()).To(Equal("4"))
+				Expect(demand.Pods().Value()).To(BeEquivalentTo(1))
+			})
+		})
+
+		When("other virtual nodes are not associated with autoscaled ResourceSlices", func() {
+			BeforeEach(func() {
+				objects = append(objects, newVirtualNode("a-not-autoscaled"))
+			})
+
+			It("should still include the pending pods", func() {
+				demand := getDemand()
+				Expect(demand.Cpu().String()).To(Equal("6"))
+			})
+		})
+	})
+})
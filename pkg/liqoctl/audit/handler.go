@@ -0,0 +1,321 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	liqoaudit "github.com/liqotech/liqo/pkg/audit"
+	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	podutils "github.com/liqotech/liqo/pkg/liqoctl/utils/pod"
+	argsutils "github.com/liqotech/liqo/pkg/utils/args"
+	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
+)
+
+// OutputFormat represents the format of the output of the command.
+type OutputFormat string
+
+const (
+	// JSON indicates that the events are printed as JSON lines, as in the original stream.
+	JSON OutputFormat = "json"
+
+	// maxLineSize is the maximum size of a line of the audit stream.
+	maxLineSize = 1024 * 1024
+
+	// auditVolumeName is the name of the volume the durable audit files are written to (see the Helm chart).
+	auditVolumeName = "audit-log"
+)
+
+// Options encapsulates the arguments of the audit command.
+type Options struct {
+	*factory.Factory
+
+	ClusterID argsutils.ClusterIDFlags
+	Actions   []string
+	Since     time.Duration
+	File      string
+	Logs      bool
+	Format    OutputFormat
+}
+
+// NewOptions returns a new Options struct.
+func NewOptions(f *factory.Factory) *Options {
+	return &Options{
+		Factory: f,
+	}
+}
+
+// Run implements the audit command.
+func (o *Options) Run(ctx context.Context) error {
+	var events []*liqoaudit.Event
+	var err error
+
+	switch {
+	case o.File != "":
+		events, err = o.readFile()
+	case o.Logs:
+		events, err = o.readPodLogs(ctx)
+	default:
+		var found bool
+		if events, found, err = o.readVolume(ctx); err == nil && !found {
+			o.Printer.Warning.Println("The audit volume is not enabled: reading the events from the logs of the Liqo components, " +
+				"which cover only the current and the previous instance of each component")
+			events, err = o.readPodLogs(ctx)
+		}
+	}
+	if err != nil {
+		o.Printer.CheckErr(fmt.Errorf("unable to retrieve the audit events: %v", output.PrettyErr(err)))
+		return err
+	}
+
+	var since time.Time
+	if o.Since > 0 {
+		since = time.Now().Add(-o.Since)
+	}
+	events = filterEvents(events, o.ClusterID.GetClusterID(), o.Actions, since)
+
+	if len(events) == 0 && o.Format == "" {
+		o.Printer.Info.Println("No audit events found")
+		return nil
+	}
+
+	return printEvents(os.Stdout, events, o.Format)
+}
+
+// readFile reads the audit events from the file specified by the user ("-" for the standard input).
+func (o *Options) readFile() ([]*liqoaudit.Event, error) {
+	if o.File == liqoaudit.StdoutPath {
+		return readEvents(os.Stdin)
+	}
+
+	file, err := os.Open(o.File)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readEvents(file)
+}
+
+// readVolume reads the audit events from the files written to the audit volume (one per pod), through a pod mounting it.
+// It returns false in case no running pod mounts the audit volume (i.e., the persistence of the audit stream is disabled).
+func (o *Options) readVolume(ctx context.Context) (events []*liqoaudit.Event, found bool, err error) {
+	for _, selector := range []labels.Selector{liqolabels.ControllerManagerLabelSelector(), liqolabels.WebhookLabelSelector()} {
+		pods, err := o.KubeClient.CoreV1().Pods(o.LiqoNamespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list the pods matching %q: %w", selector, err)
+		}
+
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.Phase != corev1.PodRunning {
+				continue
+			}
+
+			container, mount, ok := auditVolumeMount(pod)
+			if !ok {
+				continue
+			}
+
+			events, err = o.readVolumeFrom(ctx, pod, container, mount.MountPath)
+			return events, true, err
+		}
+	}
+
+	return nil, false, nil
+}
+
+// auditVolumeMount returns the container of the given pod mounting the audit volume, and the corresponding mount.
+func auditVolumeMount(pod *corev1.Pod) (container string, mount *corev1.VolumeMount, found bool) {
+	for i := range pod.Spec.Containers {
+		for j := range pod.Spec.Containers[i].VolumeMounts {
+			if pod.Spec.Containers[i].VolumeMounts[j].Name == auditVolumeName {
+				return pod.Spec.Containers[i].Name, &pod.Spec.Containers[i].VolumeMounts[j], true
+			}
+		}
+	}
+	return "", nil, false
+}
+
+// readVolumeFrom reads the audit events from the files in the given directory of the given container, which are streamed through exec.
+func (o *Options) readVolumeFrom(ctx context.Context, pod *corev1.Pod, container, path string) ([]*liqoaudit.Event, error) {
+	reader, writer := io.Pipe()
+	var stderr strings.Builder
+
+	go func() {
+		cmd := []string{"find", path, "-type", "f", "-name", "*.jsonl", "-exec", "cat", "{}", ";"}
+		err := podutils.StreamExecInPod(ctx, o.KubeClient, o.RESTConfig, pod, container, cmd, writer, &stderr)
+		if err != nil && stderr.Len() > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		writer.CloseWithError(err)
+	}()
+
+	events, err := readEvents(reader)
+	// Make sure the command terminates in case of errors while reading the events.
+	reader.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the audit volume through pod %q: %w", pod.Name, err)
+	}
+	return events, nil
+}
+
+// readPodLogs reads the audit events from the logs of the liqo components emitting them,
+// which are written to the standard output when the audit log path is set to "-".
+// The logs of the previous instance of restarted containers are read as well, while older ones are lost:
+// the durable audit sinks (i.e., the persistent file and the webhook) should be used to retain the complete history.
+func (o *Options) readPodLogs(ctx context.Context) ([]*liqoaudit.Event, error) {
+	logOptions := &corev1.PodLogOptions{}
+	if o.Since > 0 {
+		logOptions.SinceSeconds = ptr.To(int64(o.Since.Seconds()))
+	}
+
+	var events []*liqoaudit.Event
+	for _, selector := range []labels.Selector{liqolabels.ControllerManagerLabelSelector(), liqolabels.WebhookLabelSelector()} {
+		pods, err := o.KubeClient.CoreV1().Pods(o.LiqoNamespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the pods matching %q: %w", selector, err)
+		}
+
+		for i := range pods.Items {
+			podEvents, err := o.readPodEvents(ctx, &pods.Items[i], logOptions)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, podEvents...)
+		}
+	}
+
+	return events, nil
+}
+
+func (o *Options) readPodEvents(ctx context.Context, pod *corev1.Pod, logOptions *corev1.PodLogOptions) ([]*liqoaudit.Event, error) {
+	var events []*liqoaudit.Event
+	if restarted(pod) {
+		previousOptions := logOptions.DeepCopy()
+		previousOptions.Previous = true
+		previous, err := o.readLogs(ctx, pod, previousOptions)
+		if err != nil {
+			o.Printer.Warning.Printfln("Failed to retrieve the logs of the previous instance of pod %q: %v", pod.Name, err)
+		}
+		events = append(events, previous...)
+	}
+
+	current, err := o.readLogs(ctx, pod, logOptions)
+	if err != nil {
+		return nil, err
+	}
+	return append(events, current...), nil
+}
+
+// restarted returns whether any of the containers of the given pod has been restarted.
+func restarted(pod *corev1.Pod) bool {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].RestartCount > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Options) readLogs(ctx context.Context, pod *corev1.Pod, logOptions *corev1.PodLogOptions) ([]*liqoaudit.Event, error) {
+	stream, err := o.KubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the logs of pod %q: %w", pod.Name, err)
+	}
+	defer stream.Close()
+
+	return readEvents(stream)
+}
+
+// readEvents reads the audit events from the given stream, skipping the lines not containing an audit event.
+func readEvents(reader io.Reader) ([]*liqoaudit.Event, error) {
+	var events []*liqoaudit.Event
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	for scanner.Scan() {
+		if event, ok := liqoaudit.ParseEvent(scanner.Bytes()); ok {
+			events = append(events, event)
+		}
+	}
+
+	return events, scanner.Err()
+}
+
+// filterEvents returns the events referring to the given cluster and actions (if not empty), and not older than since,
+// sorted by time.
+func filterEvents(events []*liqoaudit.Event, clusterID liqov1beta1.ClusterID, actions []string, since time.Time) []*liqoaudit.Event {
+	var filtered []*liqoaudit.Event
+	for _, event := range events {
+		switch {
+		case clusterID != "" && event.ClusterID != clusterID:
+		case len(actions) > 0 && !slices.ContainsFunc(actions, func(action string) bool {
+			return strings.EqualFold(action, string(event.Action))
+		}):
+		case event.Time.Before(since):
+		default:
+			filtered = append(filtered, event)
+		}
+	}
+
+	slices.SortStableFunc(filtered, func(a, b *liqoaudit.Event) int { return a.Time.Compare(b.Time) })
+	return filtered
+}
+
+// printEvents prints the given events to the writer, according to the output format.
+func printEvents(writer io.Writer, events []*liqoaudit.Event, format OutputFormat) error {
+	if format == JSON {
+		encoder := json.NewEncoder(writer)
+		for _, event := range events {
+			if err := encoder.Encode(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data := pterm.TableData{{"Time", "Cluster ID", "Component", "Action", "Outcome", "Object", "Message"}}
+	for _, event := range events {
+		object := event.Resource
+		switch {
+		case event.Namespace != "":
+			object = fmt.Sprintf("%s %s/%s", event.Resource, event.Namespace, event.Name)
+		case event.Name != "":
+			object = fmt.Sprintf("%s %s", event.Resource, event.Name)
+		}
+
+		data = append(data, []string{event.Time.Local().Format(time.DateTime), string(event.ClusterID), event.Component,
+			string(event.Action), string(event.Outcome), object, event.Message})
+	}
+
+	return pterm.DefaultTable.WithHasHeader().WithWriter(writer).WithData(data).Render()
+}
@@ -0,0 +1,147 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	liqoaudit "github.com/liqotech/liqo/pkg/audit"
+)
+
+var _ = Describe("Audit stream handling", func() {
+	var (
+		now    time.Time
+		events []*liqoaudit.Event
+	)
+
+	forgeEvent := func(clusterID liqov1beta1.ClusterID, action liqoaudit.Action, age time.Duration) *liqoaudit.Event {
+		return &liqoaudit.Event{
+			Kind:      liqoaudit.EventKind,
+			Time:      now.Add(-age),
+			Component: "liqo-controller-manager",
+			ClusterID: clusterID,
+			Action:    action,
+			Outcome:   liqoaudit.OutcomeSucceeded,
+			Resource:  "tenants",
+			Namespace: "liqo-tenant-" + string(clusterID),
+			Name:      string(clusterID),
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		events = []*liqoaudit.Event{
+			forgeEvent("cluster-a", liqoaudit.ActionTenantCreate, 72*time.Hour),
+			forgeEvent("cluster-b", liqoaudit.ActionShadowPodCreate, time.Hour),
+			forgeEvent("cluster-a", liqoaudit.ActionShadowPodDelete, 2*time.Hour),
+			forgeEvent("cluster-a", liqoaudit.ActionShadowPodCreate, 3*time.Hour),
+		}
+	})
+
+	Describe("the readEvents function", func() {
+		It("should return the audit events, skipping the other lines", func() {
+			var stream strings.Builder
+			stream.WriteString("I1018 10:00:00.000000       1 main.go:42] Starting\n")
+			Expect(printEvents(&stream, events[:2], JSON)).To(Succeed())
+			stream.WriteString("{\"kind\":\"Event\"}\n\n")
+
+			read, err := readEvents(strings.NewReader(stream.String()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(read).To(HaveLen(2))
+			Expect(read[0].ClusterID).To(BeEquivalentTo("cluster-a"))
+			Expect(read[0].Action).To(Equal(liqoaudit.ActionTenantCreate))
+			Expect(read[0].Time.Equal(events[0].Time)).To(BeTrue())
+			Expect(read[1].ClusterID).To(BeEquivalentTo("cluster-b"))
+		})
+	})
+
+	DescribeTable("the filterEvents function",
+		func(clusterID liqov1beta1.ClusterID, actions []string, since time.Duration, expected []int) {
+			var sinceTime time.Time
+			if since > 0 {
+				sinceTime = now.Add(-since)
+			}
+
+			var expectedEvents []*liqoaudit.Event
+			for _, i := range expected {
+				expectedEvents = append(expectedEvents, events[i])
+			}
+			Expect(filterEvents(events, clusterID, actions, sinceTime)).To(Equal(expectedEvents))
+		},
+		Entry("no filters, sorting by time", liqov1beta1.ClusterID(""), nil, time.Duration(0), []int{0, 3, 2, 1}),
+		Entry("by cluster ID", liqov1beta1.ClusterID("cluster-a"), nil, time.Duration(0), []int{0, 3, 2}),
+		Entry("by cluster ID and time", liqov1beta1.ClusterID("cluster-a"), nil, 24*time.Hour, []int{3, 2}),
+		Entry("by action, case insensitive", liqov1beta1.ClusterID(""), []string{"shadowpodcreate"}, time.Duration(0), []int{3, 1}),
+		Entry("by multiple actions", liqov1beta1.ClusterID("cluster-a"),
+			[]string{string(liqoaudit.ActionTenantCreate), string(liqoaudit.ActionShadowPodDelete)}, time.Duration(0), []int{0, 2}),
+		Entry("not matching any event", liqov1beta1.ClusterID("cluster-c"), nil, time.Duration(0), []int{}),
+	)
+
+	Describe("the printEvents function", func() {
+		var buffer *bytes.Buffer
+
+		BeforeEach(func() { buffer = &bytes.Buffer{} })
+
+		It("should print the events as a table", func() {
+			Expect(printEvents(buffer, events[1:2], "")).To(Succeed())
+			Expect(buffer.String()).To(ContainSubstring("Cluster ID"))
+			Expect(buffer.String()).To(ContainSubstring("cluster-b"))
+			Expect(buffer.String()).To(ContainSubstring(string(liqoaudit.ActionShadowPodCreate)))
+			Expect(buffer.String()).To(ContainSubstring("tenants liqo-tenant-cluster-b/cluster-b"))
+		})
+
+		It("should print the events as JSON lines", func() {
+			Expect(printEvents(buffer, events, JSON)).To(Succeed())
+			lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+			Expect(lines).To(HaveLen(len(events)))
+			for _, line := range lines {
+				_, ok := liqoaudit.ParseEvent([]byte(line))
+				Expect(ok).To(BeTrue())
+			}
+		})
+	})
+
+	Describe("the auditVolumeMount function", func() {
+		var pod *corev1.Pod
+
+		BeforeEach(func() {
+			pod = &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "sidecar", VolumeMounts: []corev1.VolumeMount{{Name: "other", MountPath: "/other"}}},
+				{Name: "controller-manager"},
+			}}}
+		})
+
+		It("should return the container mounting the audit volume", func() {
+			pod.Spec.Containers[1].VolumeMounts = []corev1.VolumeMount{{Name: auditVolumeName, MountPath: "/var/log/liqo/audit"}}
+
+			container, mount, found := auditVolumeMount(pod)
+			Expect(found).To(BeTrue())
+			Expect(container).To(Equal("controller-manager"))
+			Expect(mount.MountPath).To(Equal("/var/log/liqo/audit"))
+		})
+
+		It("should return false if the audit volume is not mounted", func() {
+			_, _, found := auditVolumeMount(pod)
+			Expect(found).To(BeFalse())
+		})
+	})
+})
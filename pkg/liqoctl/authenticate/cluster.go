@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
@@ -151,9 +152,26 @@ func (c *Cluster) EnsureSignedNonce(ctx context.Context, nonce []byte) ([]byte,
 	return signedNonceValue, nil
 }
 
+// GetCredentialsGeneration returns the generation of the credentials issued to the remote cluster, as reported by the
+// corresponding Tenant (if any). It is increased each time the credentials are revoked.
+func (c *Cluster) GetCredentialsGeneration(ctx context.Context) (int64, error) {
+	tenant, err := getters.GetTenantByClusterID(ctx, c.local.CRClient, c.RemoteClusterID, c.TenantNamespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		return 0, nil
+	case err != nil:
+		c.local.Printer.CheckErr(fmt.Errorf("unable to retrieve the tenant: %w", err))
+		return 0, err
+	case tenant.Status.Credentials == nil:
+		return 0, nil
+	default:
+		return tenant.Status.Credentials.Generation, nil
+	}
+}
+
 // GenerateTenant generate the tenant resource to be applied on the provider cluster.
-func (c *Cluster) GenerateTenant(
-	ctx context.Context, signedNonce []byte, remoteTenantNamespace string, proxyURL *string) (*authv1beta1.Tenant, error) {
+func (c *Cluster) GenerateTenant(ctx context.Context, signedNonce []byte, remoteTenantNamespace string,
+	proxyURL *string, generation int64) (*authv1beta1.Tenant, error) {
 	s := c.local.Printer.StartSpinner("Generating tenant")
 	tenant, err := authutils.GenerateTenant(
 		ctx, c.local.CRClient,
@@ -162,6 +180,7 @@ func (c *Cluster) GenerateTenant(
 		remoteTenantNamespace,
 		signedNonce,
 		proxyURL,
+		generation,
 	)
 	if err != nil {
 		s.Fail(fmt.Sprintf("Unable to generate tenant: %v", output.PrettyErr(err)))
@@ -179,7 +198,10 @@ func (c *Cluster) EnsureTenant(ctx context.Context, tenant *authv1beta1.Tenant)
 	if _, err := resource.CreateOrUpdate(ctx, c.local.CRClient, tenant, func() error {
 		tenant.Labels = newTenant.Labels
 		tenant.Annotations = newTenant.Annotations
+		// The credential policy is set by the administrator of the provider cluster, hence it is preserved.
+		credentialPolicy := tenant.Spec.CredentialPolicy
 		tenant.Spec = newTenant.Spec
+		tenant.Spec.CredentialPolicy = credentialPolicy
 		return nil
 	}); err != nil {
 		s.Fail(fmt.Sprintf("Unable to apply tenant on provider cluster: %v", output.PrettyErr(err)))
@@ -92,8 +92,14 @@ func (o *Options) RunAuthenticate(ctx context.Context) error {
 		o.ProxyURL = "http://" + remappedIP + ":8118"
 	}
 
+	// In the provider cluster, retrieve the generation of the credentials, increased at each revocation.
+	generation, err := provider.GetCredentialsGeneration(ctx)
+	if err != nil {
+		return err
+	}
+
 	// In the consumer cluster, forge a tenant resource to be applied on the provider cluster
-	tenant, err := consumer.GenerateTenant(ctx, signedNonce, provider.TenantNamespace, &o.ProxyURL)
+	tenant, err := consumer.GenerateTenant(ctx, signedNonce, provider.TenantNamespace, &o.ProxyURL, generation)
 	if err != nil {
 		return err
 	}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,9 +50,9 @@ type NetworkChecker struct {
 // Collect data about the network of the local installation of Liqo.
 func (l *NetworkChecker) Collect(ctx context.Context, options info.Options) {
 	fields := map[string]func(ctx context.Context, cl client.Client, namespace string) (string, error){
-		"PodCIDR":      ipam.GetPodCIDR,
-		"ServiceCIDR":  ipam.GetServiceCIDR,
-		"ExternalCIDR": ipam.GetExternalCIDR,
+		"PodCIDR":      joinCIDRs(ipam.GetPodCIDRs),
+		"ServiceCIDR":  joinCIDRs(ipam.GetServiceCIDRs),
+		"ExternalCIDR": joinCIDRs(ipam.GetExternalCIDRs),
 		"InternalCIDR": ipam.GetInternalCIDR,
 	}
 
@@ -64,6 +65,16 @@ func (l *NetworkChecker) Collect(ctx context.Context, options info.Options) {
 	}
 }
 
+// joinCIDRs wraps a function returning a list of CIDRs (e.g., in case of dual-stack clusters),
+// so that they are returned as a single comma-separated string.
+func joinCIDRs(fn func(ctx context.Context, cl client.Client, namespace string) ([]string, error),
+) func(ctx context.Context, cl client.Client, namespace string) (string, error) {
+	return func(ctx context.Context, cl client.Client, namespace string) (string, error) {
+		cidrs, err := fn(ctx, cl, namespace)
+		return strings.Join(cidrs, ","), err
+	}
+}
+
 // Format returns the collected data using a user friendly output.
 func (l *NetworkChecker) Format(options info.Options) string {
 	main := output.NewRootSection()
@@ -18,7 +18,9 @@ package peer
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,6 +28,7 @@ import (
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/liqo-controller-manager/authentication"
 	"github.com/liqotech/liqo/pkg/liqoctl/info"
 	"github.com/liqotech/liqo/pkg/liqoctl/info/common"
 	"github.com/liqotech/liqo/pkg/liqoctl/output"
@@ -51,12 +54,26 @@ type ResourceSliceStatus struct {
 	Resources corev1.ResourceList `json:"resources"`
 }
 
+// CredentialsInfo contains info about the rotation status of the control plane credentials.
+type CredentialsInfo struct {
+	Generation int64      `json:"generation"`
+	Subject    string     `json:"subject,omitempty"`
+	IssuedAt   *time.Time `json:"issuedAt,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	RenewAt    *time.Time `json:"renewAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
 // Auth contains some info about the current status of the authentication module.
 type Auth struct {
 	Status         common.ModuleStatus `json:"status"`
 	Alerts         []string            `json:"alerts,omitempty"`
 	APIServerAddr  string
 	ResourceSlices []ResourceSliceStatus `json:"resourceSlices"`
+	// Credentials are the credentials used by the local cluster to access the provider cluster.
+	Credentials *CredentialsInfo `json:"credentials,omitempty"`
+	// TenantCredentials are the credentials issued by the local cluster to the consumer cluster.
+	TenantCredentials *CredentialsInfo `json:"tenantCredentials,omitempty"`
 }
 
 // AuthChecker collects some info about the current status of the authentication module.
@@ -75,11 +92,17 @@ func (ac *AuthChecker) Collect(ctx context.Context, options info.Options) {
 		ac.collectStatusInfo(clusterID, options.ClustersInfo, &authStatus)
 
 		if authStatus.Status != common.ModuleDisabled {
-			if options.ClustersInfo[clusterID].Status.Role == liqov1beta1.ProviderRole {
+			role := options.ClustersInfo[clusterID].Status.Role
+			if role == liqov1beta1.ProviderRole {
 				if err := ac.collectAPIAddress(ctx, options.CRClient, clusterID, &authStatus); err != nil {
 					ac.AddCollectionError(fmt.Errorf("unable to get API server address of cluster %q: %w", clusterID, err))
 				}
 			}
+			if role == liqov1beta1.ConsumerRole || role == liqov1beta1.ConsumerAndProviderRole {
+				if err := ac.collectTenantCredentials(ctx, options.CRClient, clusterID, &authStatus); err != nil {
+					ac.AddCollectionError(fmt.Errorf("unable to get the credentials issued to cluster %q: %w", clusterID, err))
+				}
+			}
 
 			// Get the ResourceSlices related to the given remote clusterID
 			resSlices, err := getters.ListResourceSlicesByClusterID(ctx, options.CRClient, clusterID)
@@ -110,6 +133,13 @@ func (ac *AuthChecker) FormatForClusterID(clusterID liqov1beta1.ClusterID, optio
 				main.AddEntry("API server", data.APIServerAddr)
 			}
 
+			if data.Credentials != nil {
+				formatCredentials(main.AddSection("Credentials"), data.Credentials)
+			}
+			if data.TenantCredentials != nil {
+				formatCredentials(main.AddSection("Tenant credentials"), data.TenantCredentials)
+			}
+
 			// Show resource slices
 			slicesSection := main.AddSection("Resource slices")
 			for i := range data.ResourceSlices {
@@ -214,5 +244,69 @@ func (ac *AuthChecker) collectAPIAddress(ctx context.Context, cl client.Client,
 	} else {
 		authStatus.APIServerAddr = identity.Spec.AuthParams.APIServer
 	}
+
+	// Collect the rotation status of the credentials, if they carry an expiration.
+	if notBefore, notAfter, err := authentication.CredentialValidity(&identity.Spec.AuthParams); err == nil {
+		renewAt := authentication.RenewalTime(notBefore, notAfter, identity.Spec.AuthParams.Rotation)
+		authStatus.Credentials = &CredentialsInfo{
+			Generation: authentication.CredentialsGeneration(&identity.Spec.AuthParams),
+			IssuedAt:   &notBefore,
+			ExpiresAt:  &notAfter,
+			RenewAt:    &renewAt,
+		}
+	}
+	return nil
+}
+
+// collectTenantCredentials collects the rotation status of the credentials issued to the consumer cluster.
+func (ac *AuthChecker) collectTenantCredentials(ctx context.Context, cl client.Client,
+	clusterID liqov1beta1.ClusterID, authStatus *Auth) error {
+	tenant, err := getters.GetTenantByClusterID(ctx, cl, clusterID, corev1.NamespaceAll)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	credentials := tenant.Status.Credentials
+	if credentials == nil {
+		return nil
+	}
+
+	authStatus.TenantCredentials = &CredentialsInfo{
+		Generation: credentials.Generation,
+		Subject:    credentials.Subject,
+	}
+	if credentials.IssuedAt != nil {
+		authStatus.TenantCredentials.IssuedAt = &credentials.IssuedAt.Time
+	}
+	if credentials.ExpiresAt != nil {
+		authStatus.TenantCredentials.ExpiresAt = &credentials.ExpiresAt.Time
+	}
+	if credentials.RevokedAt != nil {
+		authStatus.TenantCredentials.RevokedAt = &credentials.RevokedAt.Time
+	}
 	return nil
 }
+
+// formatCredentials adds the info about the rotation status of the given credentials to the given section.
+func formatCredentials(section output.Section, credentials *CredentialsInfo) {
+	section.AddEntry("Generation", strconv.FormatInt(credentials.Generation, 10))
+	if credentials.Subject != "" {
+		section.AddEntry("Subject", credentials.Subject)
+	}
+	if credentials.IssuedAt != nil {
+		section.AddEntry("Issued at", credentials.IssuedAt.Format(time.RFC3339))
+	}
+	if credentials.ExpiresAt != nil {
+		if time.Now().After(*credentials.ExpiresAt) {
+			section.AddEntryWarning("Expired at", credentials.ExpiresAt.Format(time.RFC3339))
+		} else {
+			section.AddEntry("Expires at", credentials.ExpiresAt.Format(time.RFC3339))
+		}
+	}
+	if credentials.RenewAt != nil {
+		section.AddEntry("Renewal at", credentials.RenewAt.Format(time.RFC3339))
+	}
+	if credentials.RevokedAt != nil {
+		section.AddEntryWarning("Revoked at", credentials.RevokedAt.Format(time.RFC3339))
+	}
+}
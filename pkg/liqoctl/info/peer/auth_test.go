@@ -20,14 +20,17 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pterm/pterm"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -117,6 +120,51 @@ var _ = Describe("AuthChecker tests", func() {
 				}
 			})
 
+			It("should collect the status of the credentials issued to a consumer", func() {
+				issuedAt := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+				expiresAt := metav1.NewTime(issuedAt.Add(24 * time.Hour))
+				tenant := &authv1beta1.Tenant{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      remoteClusterID,
+						Namespace: "liqo-tenant-" + remoteClusterID,
+						Labels:    map[string]string{consts.RemoteClusterID: remoteClusterID},
+					},
+					Status: authv1beta1.TenantStatus{
+						Credentials: &authv1beta1.CredentialsStatus{
+							Generation: 2,
+							Subject:    remoteClusterID + "-gen2",
+							IssuedAt:   &issuedAt,
+							ExpiresAt:  &expiresAt,
+						},
+					},
+				}
+				foreignclusterRes := testutil.FakeForeignCluster(liqov1beta1.ClusterID(remoteClusterID), &liqov1beta1.Modules{
+					Authentication: liqov1beta1.Module{Enabled: true},
+				})
+				foreignclusterRes.Status.Role = liqov1beta1.ConsumerRole
+
+				options.CRClient = clientBuilder.WithObjects(tenant).Build()
+				options.ClustersInfo = map[liqov1beta1.ClusterID]*liqov1beta1.ForeignCluster{
+					liqov1beta1.ClusterID(remoteClusterID): foreignclusterRes,
+				}
+
+				ac = &AuthChecker{}
+				ac.Collect(ctx, options)
+				Expect(ac.GetCollectionErrors()).To(BeEmpty(), "Unexpected collection errors detected")
+
+				rawData, err := ac.GetDataByClusterID(liqov1beta1.ClusterID(remoteClusterID))
+				Expect(err).NotTo(HaveOccurred(), "An error occurred while getting the data")
+
+				data := rawData.(Auth)
+				Expect(data.Credentials).To(BeNil(), "Unexpected credentials for a consumer cluster")
+				Expect(data.TenantCredentials).NotTo(BeNil(), "Tenant credentials not collected")
+				Expect(data.TenantCredentials.Generation).To(BeEquivalentTo(2))
+				Expect(data.TenantCredentials.Subject).To(Equal(remoteClusterID + "-gen2"))
+				Expect(data.TenantCredentials.IssuedAt.Equal(issuedAt.Time)).To(BeTrue(), "Unexpected issue time")
+				Expect(data.TenantCredentials.ExpiresAt.Equal(expiresAt.Time)).To(BeTrue(), "Unexpected expiration time")
+				Expect(data.TenantCredentials.RevokedAt).To(BeNil())
+			})
+
 			It("tests ResourceSlice collection of data", func() {
 				ac = &AuthChecker{}
 
@@ -180,6 +228,15 @@ var _ = Describe("AuthChecker tests", func() {
 					Expect(text).To(ContainSubstring(pterm.Sprintf("API server: %s", testCase.APIServerAddr)), "Unexpected API server")
 				}
 
+				if testCase.TenantCredentials != nil {
+					Expect(text).To(ContainSubstring("Tenant credentials"), "Tenant credentials section not shown")
+					Expect(text).To(ContainSubstring(fmt.Sprintf("Generation: %d", testCase.TenantCredentials.Generation)))
+					if testCase.TenantCredentials.RevokedAt != nil {
+						Expect(text).To(ContainSubstring(
+							fmt.Sprintf("Revoked at: %s", testCase.TenantCredentials.RevokedAt.Format(time.RFC3339))))
+					}
+				}
+
 				// This test expects that all the ResourceSlice names starts with "rs-"
 				if len(testCase.ResourceSlices) > 0 {
 					outSections := strings.Split(text, "Resource slices")
@@ -235,6 +292,14 @@ var _ = Describe("AuthChecker tests", func() {
 					},
 				},
 			}),
+			Entry("Healthy module with revoked tenant credentials", Auth{
+				Status: common.ModuleHealthy,
+				TenantCredentials: &CredentialsInfo{
+					Generation: 3,
+					Subject:    "fake-remote-gen3",
+					RevokedAt:  ptr.To(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			}),
 			Entry("Unhealthy module", Auth{
 				Status: common.ModuleUnhealthy,
 				Alerts: []string{"This is an error"},
@@ -21,10 +21,15 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/firewall"
 	"github.com/liqotech/liqo/pkg/liqoctl/info"
 	"github.com/liqotech/liqo/pkg/liqoctl/info/common"
 	"github.com/liqotech/liqo/pkg/liqoctl/output"
@@ -54,12 +59,31 @@ type GatewayInfo struct {
 	Role    GatewayType `json:"role"`
 }
 
+// ConnectionInfo contains info about the status and the quality of the connection with the peer.
+type ConnectionInfo struct {
+	Status     networkingv1beta1.ConnectionStatusValue `json:"status"`
+	Latency    string                                  `json:"latency,omitempty"`
+	PacketLoss string                                  `json:"packetLoss,omitempty"`
+	Jitter     string                                  `json:"jitter,omitempty"`
+	Degraded   string                                  `json:"degraded,omitempty"`
+}
+
+// FirewallInfo contains info about the FirewallConfigurations applied to the traffic with the peer.
+type FirewallInfo struct {
+	// OutOfSync contains the mismatches between the FirewallConfigurations and the rules applied by the gateways.
+	OutOfSync []string `json:"outOfSync,omitempty"`
+	// Dropped contains the filter rules of the FirewallConfigurations which dropped or rejected the traffic.
+	Dropped []string `json:"dropped,omitempty"`
+}
+
 // Network contains some info and the status of the network between the local cluster and a peer.
 type Network struct {
-	Status  common.ModuleStatus `json:"status"`
-	Alerts  []string            `json:"alerts,omitempty"`
-	CIDRs   CIDRInfo
-	Gateway GatewayInfo `json:"gateway"`
+	Status     common.ModuleStatus `json:"status"`
+	Alerts     []string            `json:"alerts,omitempty"`
+	CIDRs      CIDRInfo
+	Gateway    GatewayInfo     `json:"gateway"`
+	Connection *ConnectionInfo `json:"connection,omitempty"`
+	Firewall   *FirewallInfo   `json:"firewall,omitempty"`
 }
 
 // NetworkChecker collects some info about the status of the network between the local cluster and the active peers.
@@ -96,6 +120,16 @@ func (nc *NetworkChecker) Collect(ctx context.Context, options info.Options) {
 			if err := nc.collectGatewayInfo(ctx, options.CRClient, clusterID, &peerNetwork); err != nil {
 				nc.AddCollectionError(fmt.Errorf("unable to get network gateway info for cluster %q: %w", clusterID, err))
 			}
+
+			// Collect info about the connection
+			if err := nc.collectConnectionInfo(ctx, options.CRClient, clusterID, &peerNetwork); err != nil {
+				nc.AddCollectionError(fmt.Errorf("unable to get network connection info for cluster %q: %w", clusterID, err))
+			}
+
+			// Collect info about the firewall
+			if err := nc.collectFirewallInfo(ctx, options.CRClient, clusterID, &peerNetwork); err != nil {
+				nc.AddCollectionError(fmt.Errorf("unable to get firewall info for cluster %q: %w", clusterID, err))
+			}
 		}
 
 		nc.data[clusterID] = peerNetwork
@@ -132,6 +166,35 @@ func (nc *NetworkChecker) FormatForClusterID(clusterID liqov1beta1.ClusterID, op
 			gatewaySection.AddEntry("Role", string(data.Gateway.Role))
 			gatewaySection.AddEntry("Address", data.Gateway.Address...)
 			gatewaySection.AddEntry("Port", fmt.Sprint(data.Gateway.Port))
+
+			// Print info about Connection
+			if data.Connection != nil {
+				connectionSection := main.AddSection("Connection")
+				connectionSection.AddEntry("Status", string(data.Connection.Status))
+				if data.Connection.Latency != "" {
+					connectionSection.AddEntry("Latency", data.Connection.Latency)
+				}
+				if data.Connection.PacketLoss != "" {
+					connectionSection.AddEntry("Packet loss", data.Connection.PacketLoss)
+				}
+				if data.Connection.Jitter != "" {
+					connectionSection.AddEntry("Jitter", data.Connection.Jitter)
+				}
+				if data.Connection.Degraded != "" {
+					connectionSection.AddEntryWarning("Degraded", data.Connection.Degraded)
+				}
+			}
+
+			// Print info about Firewall
+			if data.Firewall != nil {
+				firewallSection := main.AddSection("Firewall")
+				if len(data.Firewall.OutOfSync) > 0 {
+					firewallSection.AddEntryWarning("Out of sync", data.Firewall.OutOfSync...)
+				}
+				if len(data.Firewall.Dropped) > 0 {
+					firewallSection.AddEntry("Dropped", data.Firewall.Dropped...)
+				}
+			}
 		}
 
 		return main.SprintForBox(options.Printer)
@@ -193,6 +256,63 @@ func (nc *NetworkChecker) collectGatewayInfo(ctx context.Context, cl client.Clie
 	return nil
 }
 
+// collectConnectionInfo collects the info about the status and the quality of the connection with the peer cluster.
+func (nc *NetworkChecker) collectConnectionInfo(ctx context.Context, cl client.Client, clusterID liqov1beta1.ClusterID,
+	peerNetwork *Network) error {
+	connection, err := getters.GetConnectionByClusterID(ctx, cl, string(clusterID))
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	peerNetwork.Connection = &ConnectionInfo{
+		Status:     connection.Status.Value,
+		Latency:    connection.Status.Latency.Value,
+		PacketLoss: connection.Status.Quality.PacketLoss,
+		Jitter:     connection.Status.Quality.Jitter,
+	}
+	degraded := meta.FindStatusCondition(connection.Status.Conditions, networkingv1beta1.ConnectionDegradedCondition)
+	if degraded != nil && degraded.Status == metav1.ConditionTrue {
+		peerNetwork.Connection.Degraded = degraded.Message
+	}
+	return nil
+}
+
+// collectFirewallInfo collects the info about the FirewallConfigurations applied by the gateway towards the peer cluster,
+// reporting the ones not in sync with nftables and the rules which dropped the traffic.
+func (nc *NetworkChecker) collectFirewallInfo(ctx context.Context, cl client.Client, clusterID liqov1beta1.ClusterID,
+	peerNetwork *Network) error {
+	var fwcfgs networkingv1beta1.FirewallConfigurationList
+	if err := cl.List(ctx, &fwcfgs, client.MatchingLabelsSelector{
+		Selector: labels.SelectorFromSet(labels.Set{firewall.FirewallUniqueTargetKey: string(clusterID)}),
+	}); err != nil {
+		return err
+	}
+
+	firewallInfo := FirewallInfo{}
+	for i := range fwcfgs.Items {
+		fwcfg := &fwcfgs.Items[i]
+		for j := range fwcfg.Status.Conditions {
+			condition := &fwcfg.Status.Conditions[j]
+			switch {
+			case condition.Type == networkingv1beta1.FirewallConfigurationStatusConditionTypeSynced && condition.Status == metav1.ConditionFalse:
+				firewallInfo.OutOfSync = append(firewallInfo.OutOfSync,
+					fmt.Sprintf("%s on %s: %s", fwcfg.Name, condition.Host, condition.Message))
+			case condition.Type == networkingv1beta1.FirewallConfigurationStatusConditionTypeDropping && condition.Status == metav1.ConditionTrue:
+				firewallInfo.Dropped = append(firewallInfo.Dropped,
+					fmt.Sprintf("%s on %s: %s", fwcfg.Name, condition.Host, condition.Message))
+			}
+		}
+	}
+
+	if len(firewallInfo.OutOfSync) > 0 || len(firewallInfo.Dropped) > 0 {
+		peerNetwork.Firewall = &firewallInfo
+	}
+	return nil
+}
+
 func joinCidrs(cidrs []networkingv1beta1.CIDR) string {
 	cidrsString := make([]string, len(cidrs))
 	for i := range cidrs {
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +30,7 @@ import (
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/firewall"
 	"github.com/liqotech/liqo/pkg/liqoctl/factory"
 	"github.com/liqotech/liqo/pkg/liqoctl/info"
 	"github.com/liqotech/liqo/pkg/liqoctl/info/common"
@@ -169,6 +171,46 @@ var _ = Describe("NetworkChecker tests", func() {
 			Entry("Both gateways present", GatewayType("")),
 		)
 
+		It("collectFirewallInfo function test", func() {
+			fwcfg := &networkingv1beta1.FirewallConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-policy",
+					Namespace: "liqo-tenant-fake",
+					Labels:    map[string]string{firewall.FirewallUniqueTargetKey: remoteClusterID},
+				},
+				Status: networkingv1beta1.FirewallConfigurationStatus{
+					Conditions: []networkingv1beta1.FirewallConfigurationStatusCondition{{
+						Type:    networkingv1beta1.FirewallConfigurationStatusConditionTypeSynced,
+						Status:  metav1.ConditionFalse,
+						Host:    "gw-0",
+						Message: "rule allow-dns in chain forward is missing",
+					}, {
+						Type:   networkingv1beta1.FirewallConfigurationStatusConditionTypeSynced,
+						Status: metav1.ConditionTrue,
+						Host:   "gw-1",
+					}, {
+						Type:    networkingv1beta1.FirewallConfigurationStatusConditionTypeDropping,
+						Status:  metav1.ConditionTrue,
+						Host:    "gw-0",
+						Message: "forward/isolate-egress, forward/isolate-ingress",
+					}, {
+						Type:   networkingv1beta1.FirewallConfigurationStatusConditionTypeDropping,
+						Status: metav1.ConditionFalse,
+						Host:   "gw-1",
+					}},
+				},
+			}
+			clientBuilder.WithObjects(fwcfg)
+			options.CRClient = clientBuilder.Build()
+			peerNetwork := Network{}
+
+			nc = &NetworkChecker{}
+			Expect(nc.collectFirewallInfo(ctx, options.CRClient, liqov1beta1.ClusterID(remoteClusterID), &peerNetwork)).To(Succeed())
+			Expect(peerNetwork.Firewall).NotTo(BeNil())
+			Expect(peerNetwork.Firewall.OutOfSync).To(ConsistOf("fake-policy on gw-0: rule allow-dns in chain forward is missing"))
+			Expect(peerNetwork.Firewall.Dropped).To(ConsistOf("fake-policy on gw-0: forward/isolate-egress, forward/isolate-ingress"))
+		})
+
 		DescribeTable("FormatForClusterID function test", func(testCase Network) {
 			nc = &NetworkChecker{}
 			nc.data = map[liqov1beta1.ClusterID]Network{
@@ -221,6 +263,24 @@ var _ = Describe("NetworkChecker tests", func() {
 					Expect(text).To(ContainSubstring(address), "Unexpected gateway address")
 				}
 				Expect(text).To(ContainSubstring(pterm.Sprintf("Port: %d", testCase.Gateway.Port)), "Unexpected gateway port")
+
+				// Check Connection visualization
+				if testCase.Connection != nil {
+					Expect(text).To(ContainSubstring(pterm.Sprintf("Latency: %s", testCase.Connection.Latency)), "Unexpected latency")
+					Expect(text).To(ContainSubstring(pterm.Sprintf("Packet loss: %s", testCase.Connection.PacketLoss)), "Unexpected packet loss")
+					Expect(text).To(ContainSubstring(pterm.Sprintf("Jitter: %s", testCase.Connection.Jitter)), "Unexpected jitter")
+					Expect(text).To(ContainSubstring(pterm.Sprintf("Degraded: %s", testCase.Connection.Degraded)), "Unexpected degradation")
+				}
+
+				// Check Firewall visualization
+				if testCase.Firewall != nil {
+					for _, outOfSync := range testCase.Firewall.OutOfSync {
+						Expect(text).To(ContainSubstring(outOfSync), "Unexpected firewall drift")
+					}
+					for _, dropped := range testCase.Firewall.Dropped {
+						Expect(text).To(ContainSubstring(dropped), "Unexpected dropped traffic")
+					}
+				}
 			}
 		},
 			Entry("Disabled module", Network{Status: common.ModuleDisabled}),
@@ -256,6 +316,26 @@ var _ = Describe("NetworkChecker tests", func() {
 					Role:    GatewayClientType,
 				},
 			}),
+			Entry("Degraded connection", Network{
+				Status: common.ModuleUnhealthy,
+				Alerts: expectedAlerts,
+				Gateway: GatewayInfo{
+					Address: []string{"10.0.0.0/24"},
+					Port:    4320,
+					Role:    GatewayClientType,
+				},
+				Connection: &ConnectionInfo{
+					Status:     networkingv1beta1.Connected,
+					Latency:    "12ms",
+					PacketLoss: "5.0%",
+					Jitter:     "3ms",
+					Degraded:   "packet loss exceeds 2%",
+				},
+				Firewall: &FirewallInfo{
+					OutOfSync: []string{"fake-policy on gw-0: rule allow-dns in chain forward is missing"},
+					Dropped:   []string{"fake-policy on gw-0: forward/isolate-ingress"},
+				},
+			}),
 			Entry("Healthy module CIDR", Network{
 				Status: common.ModuleUnhealthy,
 				Alerts: expectedAlerts,
@@ -0,0 +1,68 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package move
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/liqoctl/factory"
+	"github.com/liqotech/liqo/pkg/liqoctl/output"
+	"github.com/liqotech/liqo/pkg/liqoctl/wait"
+)
+
+// WorkloadOptions encapsulates the arguments of the move workload command.
+type WorkloadOptions struct {
+	*factory.Factory
+
+	Selector   *metav1.LabelSelector
+	SourceNode string
+	TargetNode string
+
+	Wait    bool
+	Timeout time.Duration
+}
+
+// Run implements the move workload command.
+func (o *WorkloadOptions) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	s := o.Printer.StartSpinner("Creating the migration")
+
+	migration := &offloadingv1beta1.Migration{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: fmt.Sprintf("%s-", o.SourceNode), Namespace: o.Namespace},
+		Spec: offloadingv1beta1.MigrationSpec{
+			PodSelector: *o.Selector,
+			SourceNode:  o.SourceNode,
+			TargetNode:  o.TargetNode,
+		},
+	}
+	if err := o.CRClient.Create(ctx, migration); err != nil {
+		s.Fail(fmt.Sprintf("Failed to create the migration: %v", output.PrettyErr(err)))
+		return err
+	}
+	s.Success(fmt.Sprintf("Migration %q correctly created", migration.Name))
+
+	if !o.Wait {
+		return nil
+	}
+
+	return wait.NewWaiterFromFactory(o.Factory).ForMigration(ctx, migration.Namespace, migration.Name)
+}
@@ -207,7 +207,10 @@ func (c *Cluster) SetupConfiguration(ctx context.Context, conf *networkingv1beta
 				conf.Labels[consts.RemoteClusterID] = cID
 			}
 		}
+		// The CIDRs reachable in transit are advertised by the remote cluster through the peering, hence preserved.
+		transit := conf.Spec.Remote.Transit
 		conf.Spec.Remote = confCopy.Spec.Remote
+		conf.Spec.Remote.Transit = transit
 		return nil
 	})
 	if err != nil {
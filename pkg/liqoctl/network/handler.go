@@ -59,6 +59,12 @@ type Options struct {
 
 	MTU                int
 	DisableSharingKeys bool
+
+	// MaxLatency, MaxJitter and MaxPacketLoss define the thresholds on the quality of the connection,
+	// beyond which it is considered degraded. Zero values leave the corresponding threshold unset.
+	MaxLatency    time.Duration
+	MaxJitter     time.Duration
+	MaxPacketLoss int32
 }
 
 // NewOptions returns a new Options struct.
@@ -353,6 +359,7 @@ func (o *Options) newGatewayServerForgeOptions(kubeClient kubernetes.Interface,
 		TemplateNamespace: o.ServerTemplateNamespace,
 		ServiceType:       corev1.ServiceType(o.ServerServiceType.Value),
 		MTU:               o.MTU,
+		QualityThresholds: forge.ConnectionQualityThresholds(o.MaxLatency, o.MaxJitter, o.MaxPacketLoss),
 		Port:              o.ServerServicePort,
 		NodePort:          ptr.To(o.ServerServiceNodePort),
 		LoadBalancerIP:    ptr.To(o.ServerServiceLoadBalancerIP),
@@ -368,8 +375,10 @@ func (o *Options) newGatewayClientForgeOptions(kubeClient kubernetes.Interface,
 		TemplateName:      o.ClientTemplateName,
 		TemplateNamespace: o.ClientTemplateNamespace,
 		MTU:               o.MTU,
+		QualityThresholds: forge.ConnectionQualityThresholds(o.MaxLatency, o.MaxJitter, o.MaxPacketLoss),
 		Addresses:         serverEndpoint.Addresses,
 		Port:              serverEndpoint.Port,
 		Protocol:          string(*serverEndpoint.Protocol),
+		Replicas:          serverEndpoint.Replicas,
 	}
 }
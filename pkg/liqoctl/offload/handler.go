@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -46,6 +48,10 @@ type Options struct {
 	NamespaceMappingStrategy offloadingv1beta1.NamespaceMappingStrategyType
 	RemoteNamespaceName      string
 	ClusterSelector          [][]metav1.LabelSelectorRequirement
+	ClusterPreferences       []corev1.PreferredSchedulingTerm
+	MaxClusters              int32
+	SpillOverStrategy        offloadingv1beta1.SpillOverStrategyType
+	SpillOverThreshold       int32
 
 	OutputFormat string
 
@@ -55,23 +61,58 @@ type Options struct {
 // ParseClusterSelectors parses the cluster selector.
 func (o *Options) ParseClusterSelectors(selectors []string) error {
 	for _, selector := range selectors {
-		s, err := metav1.ParseToLabelSelector(selector)
+		requirements, err := parseSelector(selector)
 		if err != nil {
 			return err
 		}
 
-		// Convert MatchLabels into MatchExpressions
-		for key, value := range s.MatchLabels {
-			req := metav1.LabelSelectorRequirement{Key: key, Operator: metav1.LabelSelectorOpIn, Values: []string{value}}
-			s.MatchExpressions = append(s.MatchExpressions, req)
+		o.ClusterSelector = append(o.ClusterSelector, requirements)
+	}
+
+	return nil
+}
+
+// ParseClusterPreferences parses the cluster preferences, each one in the form <weight>:<selector>.
+func (o *Options) ParseClusterPreferences(preferences []string) error {
+	for _, preference := range preferences {
+		weight, selector, found := strings.Cut(preference, ":")
+		if !found {
+			return fmt.Errorf("invalid cluster preference %q, expected format <weight>:<selector>", preference)
+		}
+
+		w, err := strconv.ParseInt(weight, 10, 32)
+		if err != nil || w < 1 || w > 100 {
+			return fmt.Errorf("invalid weight %q in cluster preference %q, expected an integer in the range 1-100", weight, preference)
 		}
 
-		o.ClusterSelector = append(o.ClusterSelector, s.MatchExpressions)
+		requirements, err := parseSelector(selector)
+		if err != nil {
+			return err
+		}
+
+		o.ClusterPreferences = append(o.ClusterPreferences, corev1.PreferredSchedulingTerm{
+			Weight: int32(w), Preference: toNodeSelectorTerm(requirements)})
 	}
 
 	return nil
 }
 
+// parseSelector parses a label selector, converting it into the corresponding list of requirements.
+func parseSelector(selector string) ([]metav1.LabelSelectorRequirement, error) {
+	s, err := metav1.ParseToLabelSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert MatchLabels into MatchExpressions
+	for key, value := range s.MatchLabels {
+		req := metav1.LabelSelectorRequirement{Key: key, Operator: metav1.LabelSelectorOpIn, Values: []string{value}}
+		s.MatchExpressions = append(s.MatchExpressions, req)
+	}
+
+	return s.MatchExpressions, nil
+}
+
 // Run implements the offload namespace command.
 func (o *Options) Run(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
@@ -143,6 +184,10 @@ func (o *Options) runOffload(ctx context.Context, namespace string) error {
 		nsoff.Spec.NamespaceMappingStrategy = o.NamespaceMappingStrategy
 		nsoff.Spec.RemoteNamespaceName = o.RemoteNamespaceName
 		nsoff.Spec.ClusterSelector = toNodeSelector(o.ClusterSelector)
+		nsoff.Spec.ClusterPreferences = o.ClusterPreferences
+		nsoff.Spec.MaxClusters = o.MaxClusters
+		nsoff.Spec.SpillOverStrategy = o.SpillOverStrategy
+		nsoff.Spec.SpillOverThreshold = o.SpillOverThreshold
 		return nil
 	})
 	if err != nil {
@@ -179,6 +224,10 @@ func (o *Options) output(namespace string) error {
 			NamespaceMappingStrategy: o.NamespaceMappingStrategy,
 			RemoteNamespaceName:      o.RemoteNamespaceName,
 			ClusterSelector:          toNodeSelector(o.ClusterSelector),
+			ClusterPreferences:       o.ClusterPreferences,
+			MaxClusters:              o.MaxClusters,
+			SpillOverStrategy:        o.SpillOverStrategy,
+			SpillOverThreshold:       o.SpillOverThreshold,
 		},
 	}
 
@@ -206,18 +255,22 @@ func toNodeSelector(selectors [][]metav1.LabelSelectorRequirement) corev1.NodeSe
 	terms := []corev1.NodeSelectorTerm{}
 
 	for _, selector := range selectors {
-		var requirements []corev1.NodeSelectorRequirement
-
-		for _, r := range selector {
-			requirements = append(requirements, corev1.NodeSelectorRequirement{
-				Key:      r.Key,
-				Operator: corev1.NodeSelectorOperator(r.Operator),
-				Values:   r.Values,
-			})
-		}
-
-		terms = append(terms, corev1.NodeSelectorTerm{MatchExpressions: requirements})
+		terms = append(terms, toNodeSelectorTerm(selector))
 	}
 
 	return corev1.NodeSelector{NodeSelectorTerms: terms}
 }
+
+func toNodeSelectorTerm(selector []metav1.LabelSelectorRequirement) corev1.NodeSelectorTerm {
+	var requirements []corev1.NodeSelectorRequirement
+
+	for _, r := range selector {
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      r.Key,
+			Operator: corev1.NodeSelectorOperator(r.Operator),
+			Values:   r.Values,
+		})
+	}
+
+	return corev1.NodeSelectorTerm{MatchExpressions: requirements}
+}
@@ -18,6 +18,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/liqotech/liqo/pkg/liqoctl/offload"
@@ -58,4 +59,36 @@ var _ = Describe("Handler tests", func() {
 			ErrMatcher: Not(HaveOccurred()),
 		}),
 	)
+
+	type ClusterPreferenceCase struct {
+		Preferences []string
+		Expected    []corev1.PreferredSchedulingTerm
+		ErrMatcher  types.GomegaMatcher
+	}
+
+	DescribeTable("cluster preferences parsing",
+		func(c ClusterPreferenceCase) {
+			var opts offload.Options
+			Expect(opts.ParseClusterPreferences(c.Preferences)).To(c.ErrMatcher)
+			Expect(opts.ClusterPreferences).To(ConsistOf(c.Expected))
+		},
+		Entry("weighted preferences", ClusterPreferenceCase{
+			Preferences: []string{"50:key=value", "10:!staging"},
+			Expected: []corev1.PreferredSchedulingTerm{
+				{Weight: 50, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "key", Operator: corev1.NodeSelectorOpIn, Values: []string{"value"}}}}},
+				{Weight: 10, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "staging", Operator: corev1.NodeSelectorOpDoesNotExist, Values: []string{}}}}},
+			},
+			ErrMatcher: Not(HaveOccurred()),
+		}),
+		Entry("missing weight", ClusterPreferenceCase{
+			Preferences: []string{"key=value"},
+			ErrMatcher:  HaveOccurred(),
+		}),
+		Entry("weight out of range", ClusterPreferenceCase{
+			Preferences: []string{"101:key=value"},
+			ErrMatcher:  HaveOccurred(),
+		}),
+	)
 })
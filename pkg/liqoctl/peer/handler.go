@@ -41,6 +41,7 @@ type Options struct {
 
 	// Networking options
 	NetworkingDisabled          bool
+	GatewayType                 *argsutils.StringEnum
 	ServerServiceLocation       *argsutils.StringEnum
 	ServerServiceType           *argsutils.StringEnum
 	ServerServicePort           int32
@@ -49,6 +50,9 @@ type Options struct {
 	ClientConnectAddress        string
 	ClientConnectPort           int32
 	MTU                         int
+	MaxLatency                  time.Duration
+	MaxJitter                   time.Duration
+	MaxPacketLoss               int32
 
 	// Authentication options
 	CreateResourceSlice bool
@@ -68,6 +72,9 @@ type Options struct {
 func NewOptions(localFactory *factory.Factory) *Options {
 	return &Options{
 		LocalFactory: localFactory,
+		GatewayType: argsutils.NewEnum(
+			[]string{string(nwforge.GatewayTypeWireGuard), string(nwforge.GatewayTypeIPsec)},
+			string(nwforge.DefaultGatewayType)),
 		ServerServiceLocation: argsutils.NewEnum(
 			[]string{string(liqov1beta1.ConsumerRole), string(liqov1beta1.ProviderRole)},
 			string(nwforge.DefaultGwServerLocation)),
@@ -124,6 +131,11 @@ func ensureNetworking(ctx context.Context, o *Options) error {
 		remoteFactory = o.LocalFactory
 	}
 
+	serverType, serverTemplateName, clientType, clientTemplateName, err := nwforge.GatewayTemplates(nwforge.GatewayType(o.GatewayType.Value))
+	if err != nil {
+		return err
+	}
+
 	networkOptions := network.Options{
 		LocalFactory:  localFactory,
 		RemoteFactory: remoteFactory,
@@ -132,22 +144,26 @@ func ensureNetworking(ctx context.Context, o *Options) error {
 		Wait:           true,
 		SkipValidation: o.SkipValidation,
 
-		ServerGatewayType:           nwforge.DefaultGwServerType,
-		ServerTemplateName:          nwforge.DefaultGwServerTemplateName,
+		ServerGatewayType:           serverType,
+		ServerTemplateName:          serverTemplateName,
 		ServerTemplateNamespace:     remoteFactory.LiqoNamespace,
 		ServerServiceType:           o.ServerServiceType,
 		ServerServicePort:           o.ServerServicePort,
 		ServerServiceNodePort:       o.ServerServiceNodePort,
 		ServerServiceLoadBalancerIP: o.ServerServiceLoadBalancerIP,
 
-		ClientGatewayType:       nwforge.DefaultGwClientType,
-		ClientTemplateName:      nwforge.DefaultGwClientTemplateName,
+		ClientGatewayType:       clientType,
+		ClientTemplateName:      clientTemplateName,
 		ClientTemplateNamespace: localFactory.LiqoNamespace,
 		ClientConnectAddress:    o.ClientConnectAddress,
 		ClientConnectPort:       o.ClientConnectPort,
 
 		MTU:                o.MTU,
 		DisableSharingKeys: false,
+
+		MaxLatency:    o.MaxLatency,
+		MaxJitter:     o.MaxJitter,
+		MaxPacketLoss: o.MaxPacketLoss,
 	}
 
 	if err := networkOptions.RunConnect(ctx); err != nil {
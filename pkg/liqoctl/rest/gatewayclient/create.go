@@ -76,6 +76,12 @@ func (o *Options) Create(ctx context.Context, options *rest.CreateOptions) *cobr
 	cmd.Flags().StringVar(&o.TemplateName, "template-name", forge.DefaultGwClientTemplateName, "Name of the Gateway Client template")
 	cmd.Flags().StringVar(&o.TemplateNamespace, "template-namespace", "", "Namespace of the Gateway Client template")
 	cmd.Flags().IntVar(&o.MTU, "mtu", forge.DefaultMTU, "MTU of Gateway Client")
+	cmd.Flags().DurationVar(&o.MaxLatency, "max-latency", 0,
+		"Maximum round-trip latency of the connection, beyond which it is considered degraded. Leave empty to disable the threshold")
+	cmd.Flags().DurationVar(&o.MaxJitter, "max-jitter", 0,
+		"Maximum jitter of the connection, beyond which it is considered degraded. Leave empty to disable the threshold")
+	cmd.Flags().Int32Var(&o.MaxPacketLoss, "max-packet-loss", 0,
+		"Maximum percentage of lost packets, beyond which the connection is considered degraded. Leave empty to disable the threshold")
 	cmd.Flags().StringSliceVar(&o.Addresses, "addresses", []string{}, "Addresses of Gateway Server")
 	cmd.Flags().Int32Var(&o.Port, "port", 0, "Port of Gateway Server")
 	cmd.Flags().StringVar(&o.Protocol, "protocol", forge.DefaultProtocol, "Gateway Protocol")
@@ -15,6 +15,8 @@
 package gatewayclient
 
 import (
+	"time"
+
 	"github.com/liqotech/liqo/pkg/liqo-controller-manager/networking/forge"
 	"github.com/liqotech/liqo/pkg/liqoctl/rest"
 	"github.com/liqotech/liqo/pkg/utils/args"
@@ -30,6 +32,9 @@ type Options struct {
 	TemplateName      string
 	TemplateNamespace string
 	MTU               int
+	MaxLatency        time.Duration
+	MaxJitter         time.Duration
+	MaxPacketLoss     int32
 	Addresses         []string
 	Port              int32
 	Protocol          string
@@ -63,6 +68,7 @@ func (o *Options) getForgeOptions() *forge.GwClientOptions {
 		TemplateName:      o.TemplateName,
 		TemplateNamespace: o.TemplateNamespace,
 		MTU:               o.MTU,
+		QualityThresholds: forge.ConnectionQualityThresholds(o.MaxLatency, o.MaxJitter, o.MaxPacketLoss),
 		Addresses:         o.Addresses,
 		Port:              o.Port,
 		Protocol:          o.Protocol,
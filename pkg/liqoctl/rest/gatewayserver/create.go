@@ -78,6 +78,12 @@ func (o *Options) Create(ctx context.Context, options *rest.CreateOptions) *cobr
 	cmd.Flags().StringVar(&o.TemplateNamespace, "template-namespace", "", "Namespace of the Gateway Server template")
 	cmd.Flags().Var(o.ServiceType, "service-type", fmt.Sprintf("Service type of Gateway Server. Default: %s", forge.DefaultGwServerServiceType))
 	cmd.Flags().IntVar(&o.MTU, "mtu", forge.DefaultMTU, "MTU of Gateway Server")
+	cmd.Flags().DurationVar(&o.MaxLatency, "max-latency", 0,
+		"Maximum round-trip latency of the connection, beyond which it is considered degraded. Leave empty to disable the threshold")
+	cmd.Flags().DurationVar(&o.MaxJitter, "max-jitter", 0,
+		"Maximum jitter of the connection, beyond which it is considered degraded. Leave empty to disable the threshold")
+	cmd.Flags().Int32Var(&o.MaxPacketLoss, "max-packet-loss", 0,
+		"Maximum percentage of lost packets, beyond which the connection is considered degraded. Leave empty to disable the threshold")
 	cmd.Flags().Int32Var(&o.Port, "port", forge.DefaultGwServerPort, "Port of Gateway Server")
 	cmd.Flags().Int32Var(&o.NodePort, "node-port", 0,
 		"Force the NodePort of the Gateway Server. Leave empty to let Kubernetes allocate a random NodePort")
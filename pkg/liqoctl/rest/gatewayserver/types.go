@@ -15,6 +15,8 @@
 package gatewayserver
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
@@ -34,6 +36,9 @@ type Options struct {
 	TemplateNamespace string
 	ServiceType       *argsutils.StringEnum
 	MTU               int
+	MaxLatency        time.Duration
+	MaxJitter         time.Duration
+	MaxPacketLoss     int32
 	Port              int32
 	NodePort          int32
 	LoadBalancerIP    string
@@ -72,6 +77,7 @@ func (o *Options) getForgeOptions() *forge.GwServerOptions {
 		TemplateNamespace: o.TemplateNamespace,
 		ServiceType:       corev1.ServiceType(o.ServiceType.Value),
 		MTU:               o.MTU,
+		QualityThresholds: forge.ConnectionQualityThresholds(o.MaxLatency, o.MaxJitter, o.MaxPacketLoss),
 		Port:              o.Port,
 		NodePort:          ptr.To(o.NodePort),
 		LoadBalancerIP:    ptr.To(o.LoadBalancerIP),
@@ -46,7 +46,9 @@ Examples:
   $ {{ .Executable }} create resourceslice my-slice --remote-cluster-id remote-cluster-id \
   --cpu 4 --memory 8Gi --pods 30
   $ {{ .Executable }} create resourceslice my-slice --remote-cluster-id remote-cluster-id \
-  --cpu 4 --memory 8Gi --pods 30 --resource nvidia.com/gpu=2`
+  --cpu 4 --memory 8Gi --pods 30 --resource nvidia.com/gpu=2
+  $ {{ .Executable }} create resourceslice my-slice --remote-cluster-id remote-cluster-id \
+  --cpu 2 --memory 4Gi --max-cpu 16 --max-memory 32Gi --target-utilization 70`
 
 // Create implements the create command.
 func (o *Options) Create(ctx context.Context, options *rest.CreateOptions) *cobra.Command {
@@ -84,6 +86,14 @@ func (o *Options) Create(ctx context.Context, options *rest.CreateOptions) *cobr
 	cmd.Flags().StringVar(&o.Pods, "pods", "", "The amount of pods requested in the resource slice")
 	cmd.Flags().StringToStringVar(
 		&o.OtherResources, "resource", nil, "Other resources requested in the resource slice (e.g., 'resource=nvidia.com/gpu=2')")
+	cmd.Flags().StringVar(&o.MaxCPU, "max-cpu", "",
+		"The maximum amount of CPU the resource slice can be automatically scaled up to (enables autoscaling)")
+	cmd.Flags().StringVar(&o.MaxMemory, "max-memory", "",
+		"The maximum amount of memory the resource slice can be automatically scaled up to (enables autoscaling)")
+	cmd.Flags().StringVar(&o.MaxPods, "max-pods", "",
+		"The maximum amount of pods the resource slice can be automatically scaled up to (enables autoscaling)")
+	cmd.Flags().Int32Var(&o.TargetUtilization, "target-utilization", 70,
+		"The target utilization percentage of the autoscaled resource slice")
 	cmd.Flags().BoolVar(&o.DisableVirtualNodeCreation, "no-virtual-node", false,
 		"Prevent the automatic creation of a VirtualNode for the ResourceSlice. Default: false")
 
@@ -115,8 +125,9 @@ func (o *Options) HandleCreate(ctx context.Context) error {
 	resourceSlice := forge.ResourceSlice(opts.Name, namespace)
 	_, err = resource.CreateOrUpdate(ctx, opts.CRClient, resourceSlice, func() error {
 		return forge.MutateResourceSlice(resourceSlice, o.RemoteClusterID.GetClusterID(), &forge.ResourceSliceOptions{
-			Class:     authv1beta1.ResourceSliceClass(o.Class),
-			Resources: o.buildResourceMap(),
+			Class:       authv1beta1.ResourceSliceClass(o.Class),
+			Resources:   o.buildResourceMap(),
+			Autoscaling: o.buildAutoscalingOptions(),
 		}, !o.DisableVirtualNodeCreation)
 	})
 	if err != nil {
@@ -172,6 +183,21 @@ func (o *Options) buildResourceMap() map[corev1.ResourceName]string {
 	return resources
 }
 
+func (o *Options) buildAutoscalingOptions() *forge.ResourceSliceAutoscalingOptions {
+	if o.MaxCPU == "" && o.MaxMemory == "" && o.MaxPods == "" {
+		return nil
+	}
+
+	return &forge.ResourceSliceAutoscalingOptions{
+		MaxResources: map[corev1.ResourceName]string{
+			corev1.ResourceCPU:    o.MaxCPU,
+			corev1.ResourceMemory: o.MaxMemory,
+			corev1.ResourcePods:   o.MaxPods,
+		},
+		TargetUtilizationPercentage: o.TargetUtilization,
+	}
+}
+
 // output implements the logic to output the generated ResourceSlice resource.
 func (o *Options) output(ctx context.Context) error {
 	opts := o.CreateOptions
@@ -192,8 +218,9 @@ func (o *Options) output(ctx context.Context) error {
 
 	resourceSlice := forge.ResourceSlice(opts.Name, namespace)
 	err = forge.MutateResourceSlice(resourceSlice, o.RemoteClusterID.GetClusterID(), &forge.ResourceSliceOptions{
-		Class:     authv1beta1.ResourceSliceClass(o.Class),
-		Resources: o.buildResourceMap(),
+		Class:       authv1beta1.ResourceSliceClass(o.Class),
+		Resources:   o.buildResourceMap(),
+		Autoscaling: o.buildAutoscalingOptions(),
 	}, !o.DisableVirtualNodeCreation)
 	if err != nil {
 		return err
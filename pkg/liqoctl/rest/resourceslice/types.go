@@ -33,6 +33,11 @@ type Options struct {
 	Memory         string
 	Pods           string
 	OtherResources map[string]string
+
+	MaxCPU            string
+	MaxMemory         string
+	MaxPods           string
+	TargetUtilization int32
 }
 
 var _ rest.API = &Options{}
@@ -74,6 +74,8 @@ func (o *Options) Generate(ctx context.Context, options *rest.GenerateOptions) *
 			"and define it when the manifest is applied")
 	cmd.Flags().StringVar(&o.nonce, "nonce", "", "The nonce to sign for the authentication with the remote cluster")
 	cmd.Flags().StringVar(&o.proxyURL, "proxy-url", "", "The URL of the proxy to use for the communication with the remote cluster")
+	cmd.Flags().Int64Var(&o.generation, "credentials-generation", 0,
+		"The generation of the credentials, as reported by the Tenant on the remote cluster (increased at each revocation)")
 
 	runtime.Must(cmd.MarkFlagRequired("remote-cluster-id"))
 
@@ -121,7 +123,7 @@ func (o *Options) handleGenerate(ctx context.Context) error {
 		return err
 	}
 
-	tenant, err := authutils.GenerateTenant(ctx, opts.CRClient, localClusterID, opts.LiqoNamespace, o.remoteTenantNs, signedNonce, &o.proxyURL)
+	tenant, err := authutils.GenerateTenant(ctx, opts.CRClient, localClusterID, opts.LiqoNamespace, o.remoteTenantNs, signedNonce, &o.proxyURL, o.generation)
 	if err != nil {
 		opts.Printer.CheckErr(fmt.Errorf("unable to generate tenant: %w", err))
 		return err
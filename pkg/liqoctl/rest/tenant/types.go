@@ -29,6 +29,7 @@ type Options struct {
 	remoteTenantNs  string
 	nonce           string
 	proxyURL        string
+	generation      int64
 }
 
 var _ rest.API = &Options{}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
@@ -25,6 +26,7 @@ import (
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
@@ -102,6 +104,10 @@ func (o *Options) Create(ctx context.Context, options *rest.CreateOptions) *cobr
 		[]string{}, "The ingress classes offered by the remote cluster. The first one will be used as default")
 	cmd.Flags().StringSliceVar(&o.loadBalancerClasses, "load-balancer-classes",
 		[]string{}, "The load balancer classes offered by the remote cluster. The first one will be used as default")
+	cmd.Flags().StringSliceVar(&o.reflectStatusIngressClasses, "reflect-status-ingress-classes",
+		[]string{}, "The ingress classes whose status (i.e., assigned hostnames and IPs) is reflected back to the local ingresses")
+	cmd.Flags().StringSliceVar(&o.reflectStatusLoadBalancerClasses, "reflect-status-load-balancer-classes",
+		[]string{}, "The load balancer classes whose status (i.e., assigned external IPs) is reflected back to the local services")
 	cmd.Flags().StringToStringVar(&o.labels, "labels", map[string]string{}, "The labels to be added to the virtual node")
 	cmd.Flags().StringToStringVar(&o.nodeSelector, "node-selector", map[string]string{}, "The node selector to be applied to offloaded pods")
 	cmd.Flags().StringVar(&o.runtimeClassName, "runtime-class-name", "", "The runtimeClass the pods should have on the target remote cluster")
@@ -158,6 +164,7 @@ func (o *Options) handleCreate(ctx context.Context) error {
 		opts.Printer.CheckErr(err)
 		return err
 	}
+	o.enableStatusReflection(vnOpts)
 
 	if opts.OutputFormat != "" {
 		opts.Printer.CheckErr(o.output(ctx, opts.Name, tenantNamespace, vnOpts))
@@ -286,6 +293,20 @@ func (o *Options) forgeVirtualNodeOptions(vkOptionsTemplateRef *corev1.ObjectRef
 	}, nil
 }
 
+// enableStatusReflection configures the status reflection for the ingress and load balancer classes, enabling it only
+// for the ones selected by the user. The setting is explicit, hence it overrides the one of an existing virtual node.
+func (o *Options) enableStatusReflection(vnOpts *forge.VirtualNodeOptions) {
+	for i := range vnOpts.IngressClasses {
+		vnOpts.IngressClasses[i].ReflectStatus = ptr.To(
+			slices.Contains(o.reflectStatusIngressClasses, vnOpts.IngressClasses[i].IngressClassName))
+	}
+
+	for i := range vnOpts.LoadBalancerClasses {
+		vnOpts.LoadBalancerClasses[i].ReflectStatus = ptr.To(
+			slices.Contains(o.reflectStatusLoadBalancerClasses, vnOpts.LoadBalancerClasses[i].LoadBalancerClassName))
+	}
+}
+
 func (o *Options) getTenantNamespace(ctx context.Context) (string, error) {
 	ns, err := o.namespaceManager.GetNamespace(ctx, o.remoteClusterID.GetClusterID())
 	switch {
@@ -38,12 +38,14 @@ type Options struct {
 	pods           string
 	otherResources map[string]string
 
-	storageClasses      []string
-	ingressClasses      []string
-	loadBalancerClasses []string
-	labels              map[string]string
-	nodeSelector        map[string]string
-	runtimeClassName    string
+	storageClasses                   []string
+	ingressClasses                   []string
+	loadBalancerClasses              []string
+	reflectStatusIngressClasses      []string
+	reflectStatusLoadBalancerClasses []string
+	labels                           map[string]string
+	nodeSelector                     map[string]string
+	runtimeClassName                 string
 }
 
 var _ rest.API = &Options{}
@@ -49,72 +49,76 @@ func ForgePodTargets(ctx context.Context, cl *client.Client, totalReplicas int32
 
 // ForgePodTargetForProvider creates a target for a specific cluster.
 func ForgePodTargetForProvider(ctx context.Context, cl *client.Client, name string, totalReplicas int32, target Targets) error {
-	eps := discoveryv1.EndpointSliceList{}
-
-	timeout, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-	if err := wait.PollUntilContextCancel(timeout, 5*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		if err := cl.Providers[name].List(ctx, &eps,
-			ctrlclient.InNamespace(setup.NamespaceName),
-			ctrlclient.MatchingLabels{
-				discoveryv1.LabelServiceName: setup.DeploymentName,
-			},
-		); err != nil {
-			return false, err
-		}
-		return len(eps.Items) == 2, nil
-	}); err != nil {
-		if len(eps.Items) != 2 {
-			return fmt.Errorf("%q expected 2 endpoint slice, got %d", name, len(eps.Items))
-		}
-		return fmt.Errorf("error waiting for provider %q endpoint slice: %w", name, err)
-	}
-
-	if len(eps.Items[0].Endpoints)+len(eps.Items[1].Endpoints) != int(totalReplicas) {
-		return fmt.Errorf("%q expected %d endpoints, got %d", name, totalReplicas, len(eps.Items[0].Endpoints)+len(eps.Items[1].Endpoints))
-	}
-
-	target[name] = make([]string, len(eps.Items[0].Endpoints)+len(eps.Items[1].Endpoints))
-	for i := range eps.Items[0].Endpoints {
-		target[name][i] = eps.Items[0].Endpoints[i].Addresses[0]
-	}
-
-	for i := range eps.Items[1].Endpoints {
-		target[name][i+len(eps.Items[0].Endpoints)] = eps.Items[1].Endpoints[i].Addresses[0]
+	endpoints, err := waitForPodEndpoints(ctx, cl.Providers[name], 5*time.Second, totalReplicas)
+	if err != nil {
+		return fmt.Errorf("error waiting for provider %q endpoint slices: %w", name, err)
 	}
+	target[name] = endpoints
 	return nil
 }
 
 // ForgePodTargetForConsumer creates a target for the consumer cluster.
 func ForgePodTargetForConsumer(ctx context.Context, cl *client.Client, totalReplicas int32, target Targets) error {
-	eps := discoveryv1.EndpointSliceList{}
+	endpoints, err := waitForPodEndpoints(ctx, cl.Consumer, 60*time.Second, totalReplicas)
+	if err != nil {
+		return fmt.Errorf("error waiting for consumer endpoint slices: %w", err)
+	}
+	target[cl.ConsumerName] = endpoints
+	return nil
+}
+
+// waitForPodEndpoints waits until the endpoint slices of the test service contain an address of each pod for every IP family,
+// and returns all of them. In dual-stack clusters, this ensures that the pods are checked through both the IPv4 and IPv6 addresses.
+func waitForPodEndpoints(ctx context.Context, cl ctrlclient.Client, timeout time.Duration, totalReplicas int32) ([]string, error) {
+	var endpoints []string
+	var perr error
 
-	timeout, cancel := context.WithTimeout(ctx, 60*time.Second)
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
-	if err := wait.PollUntilContextCancel(timeout, 5*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		if err := cl.Consumer.List(ctx, &eps,
-			ctrlclient.InNamespace(setup.NamespaceName),
-			ctrlclient.MatchingLabels{
-				discoveryv1.LabelServiceName: setup.DeploymentName,
-			},
-		); err != nil {
+	if err := wait.PollUntilContextCancel(timeoutCtx, 5*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		eps := discoveryv1.EndpointSliceList{}
+		if err := cl.List(ctx, &eps, ctrlclient.InNamespace(setup.NamespaceName),
+			ctrlclient.MatchingLabels{discoveryv1.LabelServiceName: setup.DeploymentName}); err != nil {
 			return false, err
 		}
-		return len(eps.Items) == 1, nil
+
+		endpoints, perr = podEndpoints(eps.Items, totalReplicas)
+		return perr == nil, nil
 	}); err != nil {
-		if len(eps.Items) != 1 {
-			return fmt.Errorf("consumer expected 1 endpoint slice, got %d", len(eps.Items))
+		if perr != nil {
+			return nil, perr
+		}
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// podEndpoints returns the addresses of the given endpoint slices, checking that each IP family
+// includes exactly an address for each of the expected replicas.
+func podEndpoints(eps []discoveryv1.EndpointSlice, totalReplicas int32) ([]string, error) {
+	families := map[discoveryv1.AddressType][]string{}
+	for i := range eps {
+		for j := range eps[i].Endpoints {
+			if len(eps[i].Endpoints[j].Addresses) > 0 {
+				families[eps[i].AddressType] = append(families[eps[i].AddressType], eps[i].Endpoints[j].Addresses[0])
+			}
 		}
-		return fmt.Errorf("error waiting for consumer endpoint slice: %w", err)
 	}
 
-	if len(eps.Items[0].Endpoints) != int(totalReplicas) {
-		return fmt.Errorf("consumer expected %d endpoints, got %d", totalReplicas, len(eps.Items[0].Endpoints))
+	if len(families) == 0 {
+		return nil, fmt.Errorf("expected %d endpoints, got 0", totalReplicas)
 	}
 
-	target[cl.ConsumerName] = make([]string, len(eps.Items[0].Endpoints))
-	for i := range eps.Items[0].Endpoints {
-		target[cl.ConsumerName][i] = eps.Items[0].Endpoints[i].Addresses[0]
+	var endpoints []string
+	for _, family := range []discoveryv1.AddressType{discoveryv1.AddressTypeIPv4, discoveryv1.AddressTypeIPv6, discoveryv1.AddressTypeFQDN} {
+		addresses, found := families[family]
+		if !found {
+			continue
+		}
+		if len(addresses) != int(totalReplicas) {
+			return nil, fmt.Errorf("expected %d %s endpoints, got %d", totalReplicas, family, len(addresses))
+		}
+		endpoints = append(endpoints, addresses...)
 	}
-	return nil
+	return endpoints, nil
 }
@@ -118,7 +118,12 @@ func forgeIPTarget(ctx context.Context, cl clientctrl.Client, localIPRemapped ma
 			return nil, fmt.Errorf("failed to parse IP: %s", ip)
 		}
 
-		_, cidrtarget, err := net.ParseCIDR(cidrutils.GetPrimary(cfgs.Items[i].Status.Remote.CIDR.External).String())
+		extCIDR := cidrutils.GetByFamily(cfgs.Items[i].Status.Remote.CIDR.External, cidrutils.GetFamily(cidrutils.HostCIDR(ip)))
+		if cidrutils.IsVoid(extCIDR) {
+			return nil, fmt.Errorf("no external CIDR matching the family of IP %s for remote cluster %q", ip, id)
+		}
+
+		_, cidrtarget, err := net.ParseCIDR(extCIDR.String())
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse CIDR: %w", err)
 		}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -116,7 +117,7 @@ func RunCheckExternalToNodePortServiceWithClient(ctx context.Context, cl ctrlcli
 				continue
 			}
 			nodeip := GetNodeAddress(&nodes.Items[i])
-			ok, err := httpclient.Curl(ctx, fmt.Sprintf("http://%s:%d", nodeip, nodeport), !opts.Topts.Verbose, opts.Topts.LocalFactory.Printer.Logger)
+			ok, err := httpclient.Curl(ctx, "http://"+net.JoinHostPort(nodeip, strconv.Itoa(int(nodeport))), !opts.Topts.Verbose, opts.Topts.LocalFactory.Printer.Logger)
 			successCount, errorCount, err = utils.ManageResults(opts.Topts.FailFast, err, ok, successCount, errorCount)
 			if err != nil {
 				return successCount, errorCount, err
@@ -184,7 +185,7 @@ func RunsCheckExternalToLoadBalancerServiceWithClient(ctx context.Context, cl ct
 	}
 
 	for i := 0; i < int(totreplicas*2); i++ {
-		ok, err := httpclient.Curl(ctx, fmt.Sprintf("http://%s", lbip), !opts.Topts.Verbose, opts.Topts.LocalFactory.Printer.Logger)
+		ok, err := httpclient.Curl(ctx, "http://"+formatHost(lbip), !opts.Topts.Verbose, opts.Topts.LocalFactory.Printer.Logger)
 		successCount, errorCount, err = utils.ManageResults(opts.Topts.FailFast, err, ok, successCount, errorCount)
 		if err != nil {
 			return successCount, errorCount, err
@@ -272,7 +273,7 @@ func RunsCheckPodToNodePortServiceWithClient(ctx context.Context, cl ctrlclient.
 			}
 			nodeip := GetNodeAddress(&nodes.Items[i])
 			successCount, errorCount, err = RunCheckToTargets(ctx, cl, cfg[name],
-				opts, name, []string{fmt.Sprintf("http://%s:%d", nodeip, nodeport)}, false, ExecCurl)
+				opts, name, []string{"http://" + net.JoinHostPort(nodeip, strconv.Itoa(int(nodeport)))}, false, ExecCurl)
 			successCountTot += successCount
 			errorCountTot += errorCount
 			if err != nil {
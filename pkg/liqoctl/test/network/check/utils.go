@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	netutils "k8s.io/utils/net"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/liqotech/liqo/pkg/liqoctl/test/network/setup"
@@ -39,3 +40,11 @@ func listPods(ctx context.Context, cl ctrlclient.Client, owner string, hostnetwo
 	}
 	return &pods, nil
 }
+
+// formatHost wraps IPv6 addresses in square brackets, so that they can be used as host in URLs.
+func formatHost(host string) string {
+	if netutils.IsIPv6String(host) {
+		return "[" + host + "]"
+	}
+	return host
+}
@@ -15,6 +15,9 @@
 package flags
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/pflag"
 )
 
@@ -40,6 +43,8 @@ const (
 	FlagNamesPodNodeport FlagNames = "pod-np"
 	// FlagNamesIP is the flag that enables IP remapping for the tests.
 	FlagNamesIP FlagNames = "ip"
+	// FlagNamesIPFamily is the flag that selects the IP family of the test services.
+	FlagNamesIPFamily FlagNames = "ip-family"
 )
 
 // AddFlags adds the flags used by the network tests to the given flag set.
@@ -54,4 +59,6 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&o.Basic, string(FlagNamesBasic), false, "Run only pod-to-pod checks")
 	fs.BoolVar(&o.PodToNodePort, string(FlagNamesPodNodeport), false, "Enable curl from pod to nodeport service")
 	fs.BoolVar(&o.IPRemapping, string(FlagNamesIP), false, "Enable IP remapping for the tests")
+	fs.Var(o.IPFamily, string(FlagNamesIPFamily),
+		fmt.Sprintf("Select the IP family of the test services (defaults to the cluster one). Possible values: %s", strings.Join(o.IPFamily.Allowed, ",")))
 }
@@ -18,7 +18,10 @@ import (
 	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/liqotech/liqo/pkg/liqoctl/test"
+	argsutils "github.com/liqotech/liqo/pkg/utils/args"
 )
 
 // NodePortNodes represents the type of nodes to target in NodePort tests.
@@ -68,6 +71,7 @@ func NewOptions(topts *test.Options) *Options {
 	return &Options{
 		Topts:         topts,
 		NodePortNodes: NodePortNodesAll,
+		IPFamily:      argsutils.NewEnumWithVoidDefault([]string{string(corev1.IPv4Protocol), string(corev1.IPv6Protocol)}),
 	}
 }
 
@@ -94,4 +98,6 @@ type Options struct {
 	PodToNodePort bool
 	// IpRemapping
 	IPRemapping bool
+	// IPFamily is the IP family of the test services (if empty, the cluster default is used).
+	IPFamily *argsutils.StringEnum
 }
@@ -73,8 +73,8 @@ func PrintConfigurations(ctx context.Context, cl ctrlclient.Client, table *pterm
 func AppendLocalConfigurationTableData(cfg *networkingv1beta1.Configuration, td pterm.TableData) pterm.TableData {
 	return append(td, []string{
 		"local",
-		cidrutils.GetPrimary(cfg.Spec.Local.CIDR.Pod).String(), "N/R",
-		cidrutils.GetPrimary(cfg.Spec.Local.CIDR.External).String(), "N/R",
+		cidrutils.Join(cfg.Spec.Local.CIDR.Pod), "N/R",
+		cidrutils.Join(cfg.Spec.Local.CIDR.External), "N/R",
 	})
 }
 
@@ -82,7 +82,7 @@ func AppendLocalConfigurationTableData(cfg *networkingv1beta1.Configuration, td
 func AppendRemoteConfigurationTableData(cfg *networkingv1beta1.Configuration, td pterm.TableData) pterm.TableData {
 	return append(td, []string{
 		cfg.Name,
-		cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.Pod).String(), cidrutils.GetPrimary(cfg.Status.Remote.CIDR.Pod).String(),
-		cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.External).String(), cidrutils.GetPrimary(cfg.Status.Remote.CIDR.External).String(),
+		cidrutils.Join(cfg.Spec.Remote.CIDR.Pod), cidrutils.Join(cfg.Status.Remote.CIDR.Pod),
+		cidrutils.Join(cfg.Spec.Remote.CIDR.External), cidrutils.Join(cfg.Status.Remote.CIDR.External),
 	})
 }
@@ -38,6 +38,12 @@ func CreateService(ctx context.Context, cl *client.Client, opts *flags.Options)
 			Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.IntOrString{IntVal: 80}}},
 		},
 	}
+	setServiceIPFamily(svc, opts)
+	if svc.Spec.IPFamilyPolicy == nil {
+		// Request both the IP families when available, to check the pod-to-pod connectivity through each of them.
+		policy := corev1.IPFamilyPolicyPreferDualStack
+		svc.Spec.IPFamilyPolicy = &policy
+	}
 	if err := cl.Consumer.Create(ctx, svc); err != nil && ctrlclient.IgnoreAlreadyExists(err) != nil {
 		return err
 	}
@@ -53,6 +59,7 @@ func CreateService(ctx context.Context, cl *client.Client, opts *flags.Options)
 			Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.IntOrString{IntVal: 80}}},
 		},
 	}
+	setServiceIPFamily(svcnp, opts)
 	if err := cl.Consumer.Create(ctx, svcnp); err != nil && ctrlclient.IgnoreAlreadyExists(err) != nil {
 		return err
 	}
@@ -69,9 +76,20 @@ func CreateService(ctx context.Context, cl *client.Client, opts *flags.Options)
 				Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.IntOrString{IntVal: 80}}},
 			},
 		}
+		setServiceIPFamily(svclb, opts)
 		if err := cl.Consumer.Create(ctx, svclb); err != nil && ctrlclient.IgnoreAlreadyExists(err) != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// setServiceIPFamily forces the IP family of the service, if requested by the user.
+func setServiceIPFamily(svc *corev1.Service, opts *flags.Options) {
+	if opts.IPFamily == nil || opts.IPFamily.Value == "" {
+		return
+	}
+	policy := corev1.IPFamilyPolicySingleStack
+	svc.Spec.IPFamilyPolicy = &policy
+	svc.Spec.IPFamilies = []corev1.IPFamily{corev1.IPFamily(opts.IPFamily.Value)}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,18 @@ import (
 // ExecInPod executes a command in a pod.
 func ExecInPod(ctx context.Context, clset *kubernetes.Clientset, cfg *rest.Config,
 	pod *corev1.Pod, cmd string) (stdout, stderr string, err error) {
+	// Capture the output and error streams
+	var stdoutBuff, stderrBuff bytes.Buffer
+	if err = StreamExecInPod(ctx, clset, cfg, pod, pod.Spec.Containers[0].Name, strings.Split(cmd, " "), &stdoutBuff, &stderrBuff); err != nil {
+		return "", "", err
+	}
+
+	return stdoutBuff.String(), stderrBuff.String(), nil
+}
+
+// StreamExecInPod executes a command in the given container of a pod, streaming its output and error to the given writers.
+func StreamExecInPod(ctx context.Context, clset kubernetes.Interface, cfg *rest.Config,
+	pod *corev1.Pod, container string, cmd []string, stdout, stderr io.Writer) error {
 	// Prepare the API URL used to execute the command
 	url := clset.CoreV1().RESTClient().Post().
 		Resource("pods").
@@ -37,8 +50,8 @@ func ExecInPod(ctx context.Context, clset *kubernetes.Clientset, cfg *rest.Confi
 		Namespace(pod.Namespace).
 		SubResource("exec").
 		VersionedParams(&corev1.PodExecOptions{
-			Command:   strings.Split(cmd, " "),
-			Container: pod.Spec.Containers[0].Name,
+			Command:   cmd,
+			Container: container,
 			Stdin:     false,
 			Stdout:    true,
 			Stderr:    true,
@@ -48,21 +61,19 @@ func ExecInPod(ctx context.Context, clset *kubernetes.Clientset, cfg *rest.Confi
 	// Execute the command
 	exec, err := remotecommand.NewSPDYExecutor(cfg, "POST", url)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to initialize command executor: %w", err)
+		return fmt.Errorf("failed to initialize command executor: %w", err)
 	}
 
-	// Capture the output and error streams
-	var stdoutBuff, stderrBuff bytes.Buffer
 	if err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
 		Stdin:  nil,
-		Stdout: &stdoutBuff,
-		Stderr: &stderrBuff,
+		Stdout: stdout,
+		Stderr: stderr,
 		Tty:    false,
 	}); err != nil {
-		return "", "", fmt.Errorf("failed to execute command: %w", err)
+		return fmt.Errorf("failed to execute command: %w", err)
 	}
 
-	return stdoutBuff.String(), stderrBuff.String(), nil
+	return nil
 }
 
 // TryFor tries to execute the function f for a maximum of maxRetries times.
@@ -183,6 +183,35 @@ func (w *Waiter) ForUnoffloading(ctx context.Context, namespace string) error {
 	return nil
 }
 
+// ForMigration waits until the status on the Migration resource states that the migration either succeeded or failed.
+func (w *Waiter) ForMigration(ctx context.Context, namespace, name string) error {
+	s := w.Printer.StartSpinner(fmt.Sprintf("Waiting for migration %q to complete", name))
+	var migration offloadingv1beta1.Migration
+	err := wait.PollUntilContextCancel(ctx, 1*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		if err := w.CRClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &migration); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		switch migration.Status.Phase {
+		case offloadingv1beta1.FailedMigrationPhaseType:
+			return true, fmt.Errorf("the migration failed: %s", migration.Status.Message)
+		case offloadingv1beta1.SucceededMigrationPhaseType:
+			return true, nil
+		case "":
+			return false, nil
+		default:
+			s.UpdateText(fmt.Sprintf("Waiting for migration %q to complete (phase: %s)", name, migration.Status.Phase))
+			return false, nil
+		}
+	})
+	if err != nil {
+		s.Fail(fmt.Sprintf("Failed waiting for migration to complete: %s", output.PrettyErr(err)))
+		return err
+	}
+	s.Success(fmt.Sprintf("Pods moved to node %q", migration.Status.TargetNode))
+	return nil
+}
+
 // ForConfiguration waits until the status on the Configuration resource states that the configuration has been
 // successfully applied. If tenantNamespace is empty this function searches in all the namespaces in the cluster.
 func (w *Waiter) ForConfiguration(ctx context.Context, remoteClusterID liqov1beta1.ClusterID, tenantNamespace string) error {
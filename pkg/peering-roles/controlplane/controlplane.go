@@ -24,3 +24,5 @@ package controlplane
 
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespacemaps,verbs=get;update;patch;list;watch;delete;create;deletecollection
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespacemaps/status,verbs=get;update;patch;list;watch;delete;create;deletecollection
+
+// +kubebuilder:rbac:groups=networking.liqo.io,resources=transitadvertisements,verbs=get;update;patch;list;watch;delete;create;deletecollection
@@ -0,0 +1,223 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringroles
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/resources"
+)
+
+// profileResource associates a resource restricted by a PeeringRoleProfile with the corresponding
+// RBAC resource and with the reflectors operating on it.
+type profileResource struct {
+	group    string
+	resource string
+	// writers are the reflectors requiring write access to the resource.
+	writers []resources.ResourceReflected
+	// readers are the reflectors requiring read access only to the resource.
+	readers []resources.ResourceReflected
+}
+
+var profileResources = map[authv1beta1.PeeringRoleResource]profileResource{
+	authv1beta1.PeeringRoleResourceServices: {
+		group: corev1.GroupName, resource: "services", writers: []resources.ResourceReflected{resources.Service}},
+	authv1beta1.PeeringRoleResourceEndpointSlices: {
+		group: offloadingv1beta1.SchemeGroupVersion.Group, resource: "shadowendpointslices",
+		writers: []resources.ResourceReflected{resources.EndpointSlice}},
+	authv1beta1.PeeringRoleResourceIngresses: {
+		group: networkingv1.GroupName, resource: "ingresses", writers: []resources.ResourceReflected{resources.Ingress}},
+	authv1beta1.PeeringRoleResourceConfigMaps: {
+		group: corev1.GroupName, resource: "configmaps", writers: []resources.ResourceReflected{resources.ConfigMap}},
+	// The service account reflector stores the tokens in remote secrets.
+	authv1beta1.PeeringRoleResourceSecrets: {
+		group: corev1.GroupName, resource: "secrets", writers: []resources.ResourceReflected{resources.Secret, resources.ServiceAccount}},
+	authv1beta1.PeeringRoleResourcePersistentVolumeClaims: {
+		group: corev1.GroupName, resource: "persistentvolumeclaims", writers: []resources.ResourceReflected{resources.PersistentVolumeClaim}},
+	// The event reflector only reads the remote events, to reflect them in the local cluster.
+	authv1beta1.PeeringRoleResourceEvents: {
+		group: corev1.GroupName, resource: "events", readers: []resources.ResourceReflected{resources.Event}},
+}
+
+// readOnlyVerbs are the verbs granted on the read-only resources.
+var readOnlyVerbs = sets.New("get", "list", "watch")
+
+// ProfileClusterRoleName returns the name of the ClusterRole derived from the given one, restricted according to the given PeeringRoleProfile.
+func ProfileClusterRoleName(clusterRole, profile string) string {
+	return clusterRole + "-" + profile
+}
+
+// ClusterRoleNameForProfile returns the name of the ClusterRole to be bound in place of the given one,
+// given the PeeringRoleProfile referenced by the tenant (if any).
+func ClusterRoleNameForProfile(clusterRole string, profileRef *corev1.LocalObjectReference) string {
+	if profileRef == nil || profileRef.Name == "" {
+		return clusterRole
+	}
+	return ProfileClusterRoleName(clusterRole, profileRef.Name)
+}
+
+// RemoteNamespaceClusterRoleName returns the name of the ClusterRole granting permissions to the virtual kubelet
+// in the offloaded namespaces, given the PeeringRoleProfile referenced by the tenant (if any).
+func RemoteNamespaceClusterRoleName(profileRef *corev1.LocalObjectReference) string {
+	return ClusterRoleNameForProfile(consts.RemoteNamespaceClusterRoleName, profileRef)
+}
+
+// IsProfileClusterRoleBase returns whether the given ClusterRole is restricted by the PeeringRoleProfiles,
+// hence whether the corresponding ClusterRoles shall be derived for each of them.
+func IsProfileClusterRoleBase(clusterRole client.Object) bool {
+	return clusterRole.GetName() == consts.RemoteNamespaceClusterRoleName ||
+		slices.Contains(consts.TenantClusterRolesAppNames, clusterRole.GetLabels()[consts.K8sAppNameKey]) ||
+		slices.Contains(consts.TenantClusterRolesClusterWideAppNames, clusterRole.GetLabels()[consts.K8sAppNameKey])
+}
+
+// ForgeProfileRules returns the policy rules obtained restricting the given ones according to the given PeeringRoleProfile.
+// The rules concerning the disabled resources are removed, while the ones concerning the read-only resources are
+// restricted to the read verbs. The other rules are preserved as they are.
+func ForgeProfileRules(rules []rbacv1.PolicyRule, spec *authv1beta1.PeeringRoleProfileSpec) []rbacv1.PolicyRule {
+	disabled := profileResourceKeys(spec.DisabledResources)
+	readOnly := profileResourceKeys(spec.ReadOnlyResources)
+
+	forged := make([]rbacv1.PolicyRule, 0, len(rules))
+	for i := range rules {
+		rule := &rules[i]
+		if len(rule.Resources) == 0 {
+			forged = append(forged, *rule.DeepCopy())
+			continue
+		}
+
+		var unrestricted, restricted []string
+		for _, resource := range rule.Resources {
+			switch {
+			case matchesRule(disabled, rule.APIGroups, resource):
+				// The resource is disabled, hence no permissions are granted.
+			case matchesRule(readOnly, rule.APIGroups, resource):
+				restricted = append(restricted, resource)
+			default:
+				unrestricted = append(unrestricted, resource)
+			}
+		}
+
+		if len(unrestricted) > 0 {
+			forgedRule := rule.DeepCopy()
+			forgedRule.Resources = unrestricted
+			forged = append(forged, *forgedRule)
+		}
+
+		if verbs := filterReadOnlyVerbs(rule.Verbs); len(restricted) > 0 && len(verbs) > 0 {
+			forgedRule := rule.DeepCopy()
+			forgedRule.Resources, forgedRule.Verbs = restricted, verbs
+			forged = append(forged, *forgedRule)
+		}
+	}
+	return forged
+}
+
+// DisabledReflectors returns the (sorted) list of reflectors not permitted by the given PeeringRoleProfile,
+// which shall be disabled in the virtual nodes targeting the local cluster.
+func DisabledReflectors(spec *authv1beta1.PeeringRoleProfileSpec) []string {
+	disabled := sets.New[string]()
+	for _, resource := range spec.DisabledResources {
+		for _, reflector := range profileResources[resource].writers {
+			disabled.Insert(string(reflector))
+		}
+		for _, reflector := range profileResources[resource].readers {
+			disabled.Insert(string(reflector))
+		}
+	}
+	for _, resource := range spec.ReadOnlyResources {
+		for _, reflector := range profileResources[resource].writers {
+			disabled.Insert(string(reflector))
+		}
+	}
+
+	if disabled.Len() == 0 {
+		return nil
+	}
+	return sets.List(disabled)
+}
+
+// MissingProfileDisabledReflectors returns the (sorted) list of reflectors to be disabled when the PeeringRoleProfile
+// referenced by the tenant does not exist. Consistently with the RBAC permissions, which are not granted at all
+// in that case, all the reflectors operating on the resources restricted by the profiles are disabled.
+func MissingProfileDisabledReflectors() []string {
+	spec := authv1beta1.PeeringRoleProfileSpec{}
+	for resource := range profileResources {
+		spec.DisabledResources = append(spec.DisabledResources, resource)
+	}
+	return DisabledReflectors(&spec)
+}
+
+// GetTenantProfile returns the PeeringRoleProfile referenced by the given tenant, or nil if none is referenced.
+// A NotFound error is returned if the referenced profile does not exist.
+func GetTenantProfile(ctx context.Context, cl client.Client, tenant *authv1beta1.Tenant) (*authv1beta1.PeeringRoleProfile, error) {
+	if tenant.Spec.PeeringRoleProfileRef == nil || tenant.Spec.PeeringRoleProfileRef.Name == "" {
+		return nil, nil
+	}
+
+	var profile authv1beta1.PeeringRoleProfile
+	if err := cl.Get(ctx, types.NamespacedName{Name: tenant.Spec.PeeringRoleProfileRef.Name}, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// profileResourceKeys returns the set of "group/resource" keys corresponding to the given resources.
+func profileResourceKeys(list []authv1beta1.PeeringRoleResource) sets.Set[string] {
+	keys := sets.New[string]()
+	for _, resource := range list {
+		if pr, ok := profileResources[resource]; ok {
+			keys.Insert(pr.group + "/" + pr.resource)
+		}
+	}
+	return keys
+}
+
+// matchesRule returns whether the given resource (possibly a subresource) of a rule with the given API groups
+// is part of the given set of keys.
+func matchesRule(keys sets.Set[string], groups []string, resource string) bool {
+	resource, _, _ = strings.Cut(resource, "/")
+	for _, group := range groups {
+		if keys.Has(group + "/" + resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterReadOnlyVerbs returns the read verbs among the given ones.
+func filterReadOnlyVerbs(verbs []string) []string {
+	var filtered []string
+	for _, verb := range verbs {
+		if verb == rbacv1.VerbAll {
+			return sets.List(readOnlyVerbs)
+		}
+		if readOnlyVerbs.Has(verb) {
+			filtered = append(filtered, verb)
+		}
+	}
+	return filtered
+}
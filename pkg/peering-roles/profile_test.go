@@ -0,0 +1,133 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peeringroles_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	peeringroles "github.com/liqotech/liqo/pkg/peering-roles"
+)
+
+var _ = Describe("PeeringRoleProfile", func() {
+	var rules []rbacv1.PolicyRule
+
+	BeforeEach(func() {
+		rules = []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"configmaps", "events", "secrets", "services"},
+				Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+			{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+			{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: []string{"*"}},
+			{APIGroups: []string{"offloading.liqo.io"}, Resources: []string{"shadowendpointslices", "shadowpods"},
+				Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+			{NonResourceURLs: []string{"/healthz"}, Verbs: []string{"get"}},
+		}
+	})
+
+	Describe("The ForgeProfileRules function", func() {
+		It("should preserve the rules if no resource is restricted", func() {
+			Expect(peeringroles.ForgeProfileRules(rules, &authv1beta1.PeeringRoleProfileSpec{})).To(Equal(rules))
+		})
+
+		It("should remove the disabled resources and restrict the read-only ones", func() {
+			forged := peeringroles.ForgeProfileRules(rules, &authv1beta1.PeeringRoleProfileSpec{
+				DisabledResources: []authv1beta1.PeeringRoleResource{
+					authv1beta1.PeeringRoleResourceSecrets, authv1beta1.PeeringRoleResourceEndpointSlices},
+				ReadOnlyResources: []authv1beta1.PeeringRoleResource{
+					authv1beta1.PeeringRoleResourceEvents, authv1beta1.PeeringRoleResourceIngresses},
+			})
+
+			Expect(forged).To(ConsistOf(
+				rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps", "services"},
+					Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+				rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: []string{"get", "list", "watch"}},
+				rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+				rbacv1.PolicyRule{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"},
+					Verbs: []string{"get", "list", "watch"}},
+				rbacv1.PolicyRule{APIGroups: []string{"offloading.liqo.io"}, Resources: []string{"shadowpods"},
+					Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+				rbacv1.PolicyRule{NonResourceURLs: []string{"/healthz"}, Verbs: []string{"get"}},
+			))
+		})
+
+		It("should not modify the original rules", func() {
+			_ = peeringroles.ForgeProfileRules(rules, &authv1beta1.PeeringRoleProfileSpec{
+				DisabledResources: []authv1beta1.PeeringRoleResource{authv1beta1.PeeringRoleResourceSecrets},
+			})
+			Expect(rules[0].Resources).To(ConsistOf("configmaps", "events", "secrets", "services"))
+		})
+	})
+
+	DescribeTable("The DisabledReflectors function",
+		func(spec authv1beta1.PeeringRoleProfileSpec, expected []string) {
+			Expect(peeringroles.DisabledReflectors(&spec)).To(Equal(expected))
+		},
+		Entry("no restrictions", authv1beta1.PeeringRoleProfileSpec{}, nil),
+		Entry("disabled secrets", authv1beta1.PeeringRoleProfileSpec{
+			DisabledResources: []authv1beta1.PeeringRoleResource{authv1beta1.PeeringRoleResourceSecrets},
+		}, []string{"secret", "serviceaccount"}),
+		Entry("read-only events", authv1beta1.PeeringRoleProfileSpec{
+			ReadOnlyResources: []authv1beta1.PeeringRoleResource{authv1beta1.PeeringRoleResourceEvents},
+		}, nil),
+		Entry("disabled events and read-only ingresses", authv1beta1.PeeringRoleProfileSpec{
+			DisabledResources: []authv1beta1.PeeringRoleResource{authv1beta1.PeeringRoleResourceEvents},
+			ReadOnlyResources: []authv1beta1.PeeringRoleResource{authv1beta1.PeeringRoleResourceIngresses},
+		}, []string{"event", "ingress"}),
+	)
+
+	It("should disable all the restricted reflectors when the profile is missing", func() {
+		Expect(peeringroles.MissingProfileDisabledReflectors()).To(Equal([]string{
+			"configmap", "endpointslice", "event", "ingress", "persistentvolumeclaim", "secret", "service", "serviceaccount"}))
+	})
+
+	DescribeTable("The ClusterRoleNameForProfile function",
+		func(ref *corev1.LocalObjectReference, expected string) {
+			Expect(peeringroles.ClusterRoleNameForProfile("liqo-remote-controlplane", ref)).To(Equal(expected))
+		},
+		Entry("no profile", nil, "liqo-remote-controlplane"),
+		Entry("empty profile", &corev1.LocalObjectReference{}, "liqo-remote-controlplane"),
+		Entry("with profile", &corev1.LocalObjectReference{Name: "restricted"}, "liqo-remote-controlplane-restricted"),
+	)
+
+	DescribeTable("The IsProfileClusterRoleBase function",
+		func(name string, labels map[string]string, expected bool) {
+			clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+			Expect(peeringroles.IsProfileClusterRoleBase(clusterRole)).To(Equal(expected))
+		},
+		Entry("remote namespace role", "liqo-virtual-kubelet-remote", nil, true),
+		Entry("control plane role", "liqo-remote-controlplane",
+			map[string]string{"app.kubernetes.io/name": "remote-controlplane"}, true),
+		Entry("cluster-wide role", "liqo-virtual-kubelet-remote-clusterwide",
+			map[string]string{"app.kubernetes.io/name": "virtual-kubelet-remote-clusterwide"}, true),
+		Entry("derived role", "liqo-remote-controlplane-restricted",
+			map[string]string{"liqo.io/peering-role-profile": "restricted"}, false),
+	)
+
+	DescribeTable("The RemoteNamespaceClusterRoleName function",
+		func(profile string, expected string) {
+			var ref *corev1.LocalObjectReference
+			if profile != "" {
+				ref = &corev1.LocalObjectReference{Name: profile}
+			}
+			Expect(peeringroles.RemoteNamespaceClusterRoleName(ref)).To(Equal(expected))
+		},
+		Entry("no profile", "", "liqo-virtual-kubelet-remote"),
+		Entry("with profile", "restricted", "liqo-virtual-kubelet-remote-restricted"),
+	)
+})
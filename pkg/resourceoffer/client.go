@@ -0,0 +1,134 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourceoffer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/klog/v2"
+)
+
+// TLSConfig contains the TLS configuration used to secure the connections with the ResourceOffer plugins.
+type TLSConfig struct {
+	// CAFile is the path of the CA bundle used to verify the certificate of the peer.
+	// On the client side, if empty, the system roots are used.
+	// On the server side, if set, clients are required to present a certificate signed by this CA.
+	CAFile string
+	// CertFile and KeyFile are the paths of the certificate and key presented to the peer.
+	CertFile string
+	KeyFile  string
+	// Insecure disables TLS, hence the connections are not encrypted. It must be explicitly set,
+	// and it is mutually exclusive with the other fields.
+	Insecure bool
+}
+
+func (c *TLSConfig) validate() error {
+	if c.Insecure && (c.CAFile != "" || c.CertFile != "" || c.KeyFile != "") {
+		return fmt.Errorf("the insecure mode cannot be enabled together with the TLS configuration")
+	}
+	return nil
+}
+
+// NewClientConn returns a connection towards the ResourceOffer plugin listening at the given address.
+// The connection is secured with TLS, unless the insecure mode is explicitly enabled.
+func NewClientConn(address string, cfg *TLSConfig) (*grpc.ClientConn, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Insecure {
+		klog.Warningf("TLS is disabled, the connection with the ResourceOffer plugin at %q is not encrypted", address)
+		return grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if err := loadKeyPair(tlsConfig, cfg); err != nil {
+		return nil, err
+	}
+
+	return grpc.NewClient(address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+}
+
+// NewServerCredentials returns the credentials used by a ResourceOffer plugin to serve its gRPC endpoint.
+// The returned credentials are insecure only if the insecure mode is explicitly enabled, and require the
+// clients to present a valid certificate if a CA is specified.
+func NewServerCredentials(cfg *TLSConfig) (credentials.TransportCredentials, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Insecure {
+		klog.Warning("TLS is disabled, the connections with the clients are not encrypted")
+		return insecure.NewCredentials(), nil
+	}
+
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, fmt.Errorf("both the certificate and the key are required to serve over TLS")
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if err := loadKeyPair(tlsConfig, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CA bundle %q: %w", caFile, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no valid certificate found in the CA bundle %q", caFile)
+	}
+	return pool, nil
+}
+
+func loadKeyPair(tlsConfig *tls.Config, cfg *TLSConfig) error {
+	if cfg.CertFile == "" && cfg.KeyFile == "" {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the TLS certificate: %w", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sample
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/liqotech/liqo/pkg/resourceoffer"
+	"github.com/liqotech/liqo/pkg/utils/resources"
+)
+
+// Server is a sample ResourceOffer plugin, which grants the requested resources up to a given share
+// of the resources still available in the provider cluster.
+type Server struct {
+	resourceoffer.UnimplementedResourceOfferServer
+
+	// MaxShare is the maximum fraction (between 0 and 1) of the available resources granted to a single ResourceSlice.
+	MaxShare float64
+}
+
+// NewServer returns a new sample ResourceOffer plugin.
+func NewServer(maxShare float64) (*Server, error) {
+	if maxShare <= 0 || maxShare > 1 {
+		return nil, fmt.Errorf("invalid max share %v: it must be in the (0, 1] interval", maxShare)
+	}
+	return &Server{MaxShare: maxShare}, nil
+}
+
+// Offer computes the resources granted to the ResourceSlice contained in the request.
+func (s *Server) Offer(_ context.Context, req *resourceoffer.OfferRequest) (*resourceoffer.OfferResponse, error) {
+	resourceSlice, tenant, err := resourceoffer.ParseOfferRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	available, err := availableResources(req.GetInventory())
+	if err != nil {
+		return nil, err
+	}
+
+	granted := corev1.ResourceList{}
+	for name, requested := range resourceSlice.Spec.Resources {
+		share := available[name]
+		share.SetMilli(int64(float64(share.MilliValue()) * s.MaxShare))
+		if requested.Cmp(share) > 0 {
+			granted[name] = share
+		} else {
+			granted[name] = requested.DeepCopy()
+		}
+	}
+
+	klog.Infof("Granting %v to ResourceSlice %s/%s of cluster %q", granted,
+		resourceSlice.Namespace, resourceSlice.Name, tenant.Spec.ClusterID)
+
+	if len(granted) == 0 || granted.Cpu().IsZero() || granted.Memory().IsZero() {
+		return &resourceoffer.OfferResponse{
+			Accepted: false,
+			Reason:   "InsufficientResources",
+			Message:  "Not enough CPU or memory available in the provider cluster",
+		}, nil
+	}
+
+	return &resourceoffer.OfferResponse{
+		Accepted:  true,
+		Reason:    "ResourcesGranted",
+		Message:   fmt.Sprintf("Granted up to %.0f%% of the available resources", s.MaxShare*100),
+		Resources: resourceoffer.ResourceListToMap(granted),
+	}, nil
+}
+
+// availableResources returns the resources still available in the ready and schedulable nodes of the inventory,
+// minus the ones already granted to the other ResourceSlices.
+func availableResources(inventory *resourceoffer.Inventory) (corev1.ResourceList, error) {
+	available := corev1.ResourceList{}
+	for _, node := range inventory.GetNodes() {
+		if !node.GetReady() || !node.GetSchedulable() {
+			continue
+		}
+
+		allocatable, err := resourceoffer.MapToResourceList(node.GetAllocatable())
+		if err != nil {
+			return nil, err
+		}
+		requested, err := resourceoffer.MapToResourceList(node.GetRequested())
+		if err != nil {
+			return nil, err
+		}
+		resources.AddResources(available, resources.SubResources(allocatable, requested))
+	}
+
+	granted, err := resourceoffer.MapToResourceList(inventory.GetGranted())
+	if err != nil {
+		return nil, err
+	}
+
+	return resources.SubResources(available, granted), nil
+}
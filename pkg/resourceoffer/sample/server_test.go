@@ -0,0 +1,121 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sample_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/resourceoffer"
+	"github.com/liqotech/liqo/pkg/resourceoffer/sample"
+)
+
+var _ = Describe("Sample ResourceOffer plugin", func() {
+	var (
+		ctx       context.Context
+		server    *sample.Server
+		inventory *resourceoffer.Inventory
+		requested corev1.ResourceList
+		resp      *resourceoffer.OfferResponse
+		err       error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server, err = sample.NewServer(0.5)
+		Expect(err).ToNot(HaveOccurred())
+
+		inventory = &resourceoffer.Inventory{
+			Nodes: []*resourceoffer.Node{
+				{
+					Name: "ready", Ready: true, Schedulable: true,
+					Allocatable: map[string]string{"cpu": "8", "memory": "16Gi"},
+					Requested:   map[string]string{"cpu": "2", "memory": "4Gi"},
+				},
+				{
+					Name: "not-ready", Ready: false, Schedulable: true,
+					Allocatable: map[string]string{"cpu": "8", "memory": "16Gi"},
+				},
+			},
+			Granted: map[string]string{"cpu": "2", "memory": "4Gi"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		req, err := resourceoffer.ForgeOfferRequest(&authv1beta1.ResourceSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: "slice", Namespace: "tenant"},
+			Spec:       authv1beta1.ResourceSliceSpec{Resources: requested},
+		}, &authv1beta1.Tenant{Spec: authv1beta1.TenantSpec{ClusterID: "consumer"}}, inventory)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err = server.Offer(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	When("the requested resources fit in the allowed share", func() {
+		BeforeEach(func() {
+			requested = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			}
+		})
+
+		It("should grant the requested resources", func() {
+			Expect(resp.GetAccepted()).To(BeTrue())
+			granted, err := resourceoffer.MapToResourceList(resp.GetResources())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(granted.Cpu().Equal(resource.MustParse("1"))).To(BeTrue())
+			Expect(granted.Memory().Equal(resource.MustParse("2Gi"))).To(BeTrue())
+		})
+	})
+
+	When("the requested resources exceed the allowed share", func() {
+		BeforeEach(func() {
+			requested = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10"),
+				corev1.ResourceMemory: resource.MustParse("20Gi"),
+			}
+		})
+
+		It("should grant only the allowed share of the available resources", func() {
+			Expect(resp.GetAccepted()).To(BeTrue())
+			granted, err := resourceoffer.MapToResourceList(resp.GetResources())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(granted.Cpu().Equal(resource.MustParse("2"))).To(BeTrue())
+			Expect(granted.Memory().Equal(resource.MustParse("4Gi"))).To(BeTrue())
+		})
+	})
+
+	When("no resources are available", func() {
+		BeforeEach(func() {
+			inventory.Granted = map[string]string{"cpu": "6", "memory": "12Gi"}
+			requested = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			}
+		})
+
+		It("should deny the ResourceSlice", func() {
+			Expect(resp.GetAccepted()).To(BeFalse())
+			Expect(resp.GetReason()).To(Equal("InsufficientResources"))
+		})
+	})
+})
@@ -0,0 +1,145 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourceoffer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils"
+)
+
+// ForgeOfferRequest forges the request sent to a ResourceOffer plugin.
+func ForgeOfferRequest(resourceSlice *authv1beta1.ResourceSlice, tenant *authv1beta1.Tenant, inventory *Inventory) (*OfferRequest, error) {
+	rs, err := json.Marshal(resourceSlice)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the ResourceSlice: %w", err)
+	}
+
+	t, err := json.Marshal(tenant)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the Tenant: %w", err)
+	}
+
+	return &OfferRequest{ResourceSlice: rs, Tenant: t, Inventory: inventory}, nil
+}
+
+// ParseOfferRequest decodes the ResourceSlice and the Tenant contained in a request sent to a ResourceOffer plugin.
+func ParseOfferRequest(req *OfferRequest) (*authv1beta1.ResourceSlice, *authv1beta1.Tenant, error) {
+	var resourceSlice authv1beta1.ResourceSlice
+	if err := json.Unmarshal(req.GetResourceSlice(), &resourceSlice); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode the ResourceSlice: %w", err)
+	}
+
+	var tenant authv1beta1.Tenant
+	if err := json.Unmarshal(req.GetTenant(), &tenant); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode the Tenant: %w", err)
+	}
+
+	return &resourceSlice, &tenant, nil
+}
+
+// ForgeNode forges the inventory entry describing the given node.
+func ForgeNode(node *corev1.Node, requested corev1.ResourceList) *Node {
+	return &Node{
+		Name:        node.Name,
+		Labels:      node.Labels,
+		Allocatable: ResourceListToMap(node.Status.Allocatable),
+		Requested:   ResourceListToMap(requested),
+		Ready:       utils.IsNodeReady(node),
+		Schedulable: !node.Spec.Unschedulable,
+	}
+}
+
+// ResourceListToMap converts a ResourceList into a map of stringified quantities.
+func ResourceListToMap(rl corev1.ResourceList) map[string]string {
+	res := make(map[string]string, len(rl))
+	for k, v := range rl {
+		res[k.String()] = v.String()
+	}
+	return res
+}
+
+// MapToResourceList converts a map of stringified quantities into a ResourceList.
+func MapToResourceList(m map[string]string) (corev1.ResourceList, error) {
+	res := make(corev1.ResourceList, len(m))
+	for k, v := range m {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q for resource %q: %w", v, k, err)
+		}
+		res[corev1.ResourceName(k)] = q
+	}
+	return res, nil
+}
+
+// StorageClassesToProto converts the given storage classes into their protobuf representation.
+func StorageClassesToProto(classes []liqov1beta1.StorageType) []*Class {
+	res := make([]*Class, len(classes))
+	for i := range classes {
+		res[i] = &Class{Name: classes[i].StorageClassName, Default: classes[i].Default}
+	}
+	return res
+}
+
+// StorageClassesFromProto converts the given protobuf classes into storage classes.
+func StorageClassesFromProto(classes []*Class) []liqov1beta1.StorageType {
+	res := make([]liqov1beta1.StorageType, len(classes))
+	for i := range classes {
+		res[i] = liqov1beta1.StorageType{StorageClassName: classes[i].GetName(), Default: classes[i].GetDefault()}
+	}
+	return res
+}
+
+// IngressClassesToProto converts the given ingress classes into their protobuf representation.
+func IngressClassesToProto(classes []liqov1beta1.IngressType) []*Class {
+	res := make([]*Class, len(classes))
+	for i := range classes {
+		res[i] = &Class{Name: classes[i].IngressClassName, Default: classes[i].Default}
+	}
+	return res
+}
+
+// IngressClassesFromProto converts the given protobuf classes into ingress classes.
+func IngressClassesFromProto(classes []*Class) []liqov1beta1.IngressType {
+	res := make([]liqov1beta1.IngressType, len(classes))
+	for i := range classes {
+		res[i] = liqov1beta1.IngressType{IngressClassName: classes[i].GetName(), Default: classes[i].GetDefault()}
+	}
+	return res
+}
+
+// LoadBalancerClassesToProto converts the given load balancer classes into their protobuf representation.
+func LoadBalancerClassesToProto(classes []liqov1beta1.LoadBalancerType) []*Class {
+	res := make([]*Class, len(classes))
+	for i := range classes {
+		res[i] = &Class{Name: classes[i].LoadBalancerClassName, Default: classes[i].Default}
+	}
+	return res
+}
+
+// LoadBalancerClassesFromProto converts the given protobuf classes into load balancer classes.
+func LoadBalancerClassesFromProto(classes []*Class) []liqov1beta1.LoadBalancerType {
+	res := make([]liqov1beta1.LoadBalancerType, len(classes))
+	for i := range classes {
+		res[i] = liqov1beta1.LoadBalancerType{LoadBalancerClassName: classes[i].GetName(), Default: classes[i].GetDefault()}
+	}
+	return res
+}
@@ -113,15 +113,51 @@ func IsEqualRoute(route1, route2 *netlink.Route) bool {
 	if route1.Gw != nil && route2.Gw != nil && route1.Gw.String() != route2.Gw.String() {
 		return false
 	}
+	if route1.Via != nil && route2.Via != nil && !route1.Via.Equal(route2.Via) {
+		return false
+	}
 	if route1.LinkIndex != 0 && route2.LinkIndex != 0 && route1.LinkIndex != route2.LinkIndex {
 		return false
 	}
 	if route1.Flags != route2.Flags {
 		return false
 	}
+	return isEqualMultiPath(route1.MultiPath, route2.MultiPath)
+}
+
+// isEqualMultiPath checks if the two lists of next hops are equal, regardless of their order.
+func isEqualMultiPath(nhs1, nhs2 []*netlink.NexthopInfo) bool {
+	if len(nhs1) != len(nhs2) {
+		return false
+	}
+	for _, nh1 := range nhs1 {
+		found := false
+		for _, nh2 := range nhs2 {
+			if isEqualNextHop(nh1, nh2) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
 	return true
 }
 
+func isEqualNextHop(nh1, nh2 *netlink.NexthopInfo) bool {
+	if nh1.LinkIndex != nh2.LinkIndex || nh1.Hops != nh2.Hops {
+		return false
+	}
+	if !nh1.Gw.Equal(nh2.Gw) {
+		return false
+	}
+	if (nh1.Via == nil) != (nh2.Via == nil) || (nh1.Via != nil && !nh1.Via.Equal(nh2.Via)) {
+		return false
+	}
+	return nh1.Flags&int(netlink.FLAG_ONLINK) == nh2.Flags&int(netlink.FLAG_ONLINK)
+}
+
 // CleanRoutes cleans the routes that are not contained in the given route list.
 func CleanRoutes(routes []networkingv1beta1.Route, tableID uint32) error {
 	existingrules, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: int(tableID)}, netlink.RT_FILTER_TABLE)
@@ -203,7 +239,7 @@ func forgeNetlinkRoute(route *networkingv1beta1.Route, tableID uint32) (*netlink
 		}
 	}
 
-	return &netlink.Route{
+	nlroute := &netlink.Route{
 		Dst:       dst,
 		Gw:        gw,
 		Src:       src,
@@ -211,5 +247,53 @@ func forgeNetlinkRoute(route *networkingv1beta1.Route, tableID uint32) (*netlink
 		Table:     int(tableID),
 		Flags:     flags,
 		Scope:     scope,
-	}, nil
+	}
+
+	// IPv6 destinations might be reached through an IPv4 gateway (e.g., the internal network interfaces in dual-stack clusters),
+	// which requires the gateway to be specified as a "via" attribute, including its address family.
+	if dst != nil && gw != nil && dst.IP.To4() == nil && gw.To4() != nil {
+		nlroute.Gw = nil
+		nlroute.Via = &netlink.Via{AddrFamily: netlink.FAMILY_V4, Addr: gw}
+	}
+
+	if len(route.NextHops) > 0 {
+		nlroute.Gw, nlroute.Via, nlroute.LinkIndex, nlroute.Flags = nil, nil, 0, 0
+		if nlroute.MultiPath, err = forgeNetlinkNextHops(route.NextHops, dst); err != nil {
+			return nil, err
+		}
+	}
+
+	return nlroute, nil
+}
+
+// forgeNetlinkNextHops forges the next hops of a multipath route.
+func forgeNetlinkNextHops(nextHops []networkingv1beta1.NextHop, dst *net.IPNet) ([]*netlink.NexthopInfo, error) {
+	nhs := make([]*netlink.NexthopInfo, len(nextHops))
+	for i := range nextHops {
+		nh := &netlink.NexthopInfo{}
+		if nextHops[i].Gw != nil {
+			gw := net.ParseIP(nextHops[i].Gw.String())
+			if dst != nil && dst.IP.To4() == nil && gw.To4() != nil {
+				nh.Via = &netlink.Via{AddrFamily: netlink.FAMILY_V4, Addr: gw}
+			} else {
+				nh.Gw = gw
+			}
+		}
+		if nextHops[i].Dev != nil {
+			link, err := netlink.LinkByName(*nextHops[i].Dev)
+			if err != nil {
+				return nil, err
+			}
+			nh.LinkIndex = link.Attrs().Index
+		}
+		if nextHops[i].Onlink != nil && *nextHops[i].Onlink {
+			nh.Flags |= int(netlink.FLAG_ONLINK)
+		}
+		// The kernel expects the weight of the next hop minus one.
+		if nextHops[i].Weight != nil && *nextHops[i].Weight > 1 {
+			nh.Hops = *nextHops[i].Weight - 1
+		}
+		nhs[i] = nh
+	}
+	return nhs, nil
 }
@@ -15,31 +15,41 @@
 package route
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"net"
 
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+	netutils "k8s.io/utils/net"
 
 	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 )
 
 // EnsureRulePresence ensures the presence of the given rule.
+// Rules not matching on source nor destination are ensured for both IP families.
 func EnsureRulePresence(rule *networkingv1beta1.Rule, tableID uint32) error {
 	rules, err := GetRulesByTableID(tableID)
 	if err != nil {
 		return err
 	}
 
-	_, exists, err := ExistsRule(rule, rules)
-	if err != nil {
-		return err
-	}
-	if exists {
-		return nil
-	}
+	for _, family := range getRuleFamilies(rule) {
+		_, exists, err := ExistsRule(rule, filterRulesByFamily(rules, family))
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
 
-	return AddRule(rule, tableID)
+		if err := addRule(rule, tableID, family); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // EnsureRuleAbsence ensures the absence of the given rule.
@@ -49,20 +59,36 @@ func EnsureRuleAbsence(rule *networkingv1beta1.Rule, tableID uint32) error {
 		return err
 	}
 
-	existingrule, exists, err := ExistsRule(rule, rules)
-	if err != nil {
-		return err
-	}
-	if !exists {
-		return nil
+	for _, family := range getRuleFamilies(rule) {
+		existingrule, exists, err := ExistsRule(rule, filterRulesByFamily(rules, family))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if err := netlink.RuleDel(existingrule); err != nil {
+			return err
+		}
 	}
-	return netlink.RuleDel(existingrule)
+	return nil
 }
 
 // AddRule adds the given rule to the rules list.
+// Rules not matching on source nor destination are added for both IP families.
 func AddRule(rule *networkingv1beta1.Rule, tableID uint32) error {
+	for _, family := range getRuleFamilies(rule) {
+		if err := addRule(rule, tableID, family); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addRule(rule *networkingv1beta1.Rule, tableID uint32, family int) error {
 	newrule := netlink.NewRule()
 	newrule.Table = int(tableID)
+	newrule.Family = family
 
 	if rule.Src != nil {
 		_, srcnet, err := net.ParseCIDR(rule.Src.String())
@@ -96,12 +122,41 @@ func AddRule(rule *networkingv1beta1.Rule, tableID uint32) error {
 	}
 
 	err := netlink.RuleAdd(newrule)
+	// IPv6 might be disabled in IPv4-only clusters: in that case, family-agnostic rules are configured for IPv4 only.
+	if err != nil && family == netlink.FAMILY_V6 && rule.Src == nil && rule.Dst == nil && errors.Is(err, unix.EAFNOSUPPORT) {
+		klog.V(4).Infof("Skipping IPv6 rule %v: IPv6 is not supported", rule)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("unable to add rule %v: %w", rule, err)
 	}
 	return nil
 }
 
+// getRuleFamilies returns the IP families the given rule is configured for.
+func getRuleFamilies(rule *networkingv1beta1.Rule) []int {
+	for _, cidr := range []*networkingv1beta1.CIDR{rule.Src, rule.Dst} {
+		if cidr == nil {
+			continue
+		}
+		if netutils.IsIPv6CIDRString(cidr.String()) {
+			return []int{netlink.FAMILY_V6}
+		}
+		return []int{netlink.FAMILY_V4}
+	}
+	return []int{netlink.FAMILY_V4, netlink.FAMILY_V6}
+}
+
+func filterRulesByFamily(rules []netlink.Rule, family int) []netlink.Rule {
+	var filtered []netlink.Rule
+	for i := range rules {
+		if rules[i].Family == family {
+			filtered = append(filtered, rules[i])
+		}
+	}
+	return filtered
+}
+
 // GetRulesByTableID returns all the rules associated with the given table ID.
 func GetRulesByTableID(tableID uint32) ([]netlink.Rule, error) {
 	rulelist, err := netlink.RuleListFiltered(netlink.FAMILY_ALL, &netlink.Rule{
@@ -20,6 +20,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -27,7 +28,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/auth"
 	"github.com/liqotech/liqo/pkg/consts"
+	peeringroles "github.com/liqotech/liqo/pkg/peering-roles"
 	"github.com/liqotech/liqo/pkg/utils/resource"
 )
 
@@ -35,6 +38,15 @@ import (
 // This method creates RoleBindings in the Tenant Namespace for a remote identity.
 func (nm *tenantNamespaceManager) BindClusterRoles(ctx context.Context, cluster liqov1beta1.ClusterID,
 	owner metav1.Object, clusterRoles ...*rbacv1.ClusterRole) ([]*rbacv1.RoleBinding, error) {
+	return nm.BindClusterRolesToUser(ctx, cluster, string(cluster), nil, owner, clusterRoles...)
+}
+
+// BindClusterRolesToUser creates RoleBindings in the Tenant Namespace of the remote cluster for the given ClusterRoles,
+// binding them to the given user. Existing RoleBindings bound to a different user are updated, hence revoking
+// the permissions of the previous one. If a PeeringRoleProfile is referenced, the ClusterRoles derived from the
+// given ones according to the profile are bound in their place.
+func (nm *tenantNamespaceManager) BindClusterRolesToUser(ctx context.Context, cluster liqov1beta1.ClusterID, user string,
+	profileRef *v1.LocalObjectReference, owner metav1.Object, clusterRoles ...*rbacv1.ClusterRole) ([]*rbacv1.RoleBinding, error) {
 	namespace, err := nm.GetNamespace(ctx, cluster)
 	if err != nil {
 		klog.Error(err)
@@ -43,7 +55,7 @@ func (nm *tenantNamespaceManager) BindClusterRoles(ctx context.Context, cluster
 
 	bindings := make([]*rbacv1.RoleBinding, len(clusterRoles))
 	for i, clusterRole := range clusterRoles {
-		bindings[i], err = nm.bindClusterRole(ctx, cluster, owner, namespace, clusterRole)
+		bindings[i], err = nm.bindClusterRole(ctx, cluster, user, profileRef, owner, namespace, clusterRole)
 		if err != nil {
 			klog.Error(err)
 			return nil, err
@@ -70,9 +82,9 @@ func (nm *tenantNamespaceManager) UnbindClusterRoles(ctx context.Context, cluste
 	return nil
 }
 
-// create a RoleBinding for the given clusterid in the given Namespace.
-func (nm *tenantNamespaceManager) bindClusterRole(ctx context.Context, cluster liqov1beta1.ClusterID,
-	owner metav1.Object, namespace *v1.Namespace, clusterRole *rbacv1.ClusterRole) (*rbacv1.RoleBinding, error) {
+// create a RoleBinding for the given clusterid and user in the given Namespace.
+func (nm *tenantNamespaceManager) bindClusterRole(ctx context.Context, cluster liqov1beta1.ClusterID, user string,
+	profileRef *v1.LocalObjectReference, owner metav1.Object, namespace *v1.Namespace, clusterRole *rbacv1.ClusterRole) (*rbacv1.RoleBinding, error) {
 	name := getRoleBindingName(clusterRole.Name)
 	labels := map[string]string{
 		consts.K8sAppManagedByKey: consts.LiqoAppLabelValue,
@@ -93,13 +105,13 @@ func (nm *tenantNamespaceManager) bindClusterRole(ctx context.Context, cluster l
 			{
 				Kind:     rbacv1.UserKind,
 				APIGroup: rbacv1.GroupName,
-				Name:     string(cluster),
+				Name:     auth.UserName(user),
 			},
 		},
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: rbacv1.GroupName,
 			Kind:     "ClusterRole",
-			Name:     clusterRole.Name,
+			Name:     peeringroles.ClusterRoleNameForProfile(clusterRole.Name, profileRef),
 		},
 	}
 
@@ -112,11 +124,31 @@ func (nm *tenantNamespaceManager) bindClusterRole(ctx context.Context, cluster l
 	resource.AddGlobalLabels(rb)
 	resource.AddGlobalAnnotations(rb)
 
-	rb, err := nm.client.RbacV1().RoleBindings(namespace.Name).Create(ctx, rb, metav1.CreateOptions{})
-	if apierrors.IsAlreadyExists(err) {
-		return nm.client.RbacV1().RoleBindings(namespace.Name).Get(ctx, name, metav1.GetOptions{})
+	created, err := nm.client.RbacV1().RoleBindings(namespace.Name).Create(ctx, rb, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return created, err
 	}
-	return rb, err
+
+	existing, err := nm.client.RbacV1().RoleBindings(namespace.Name).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.RoleRef != rb.RoleRef {
+		// The role reference is immutable, hence the RoleBinding is recreated (e.g., the PeeringRoleProfile has changed).
+		if err := nm.client.RbacV1().RoleBindings(namespace.Name).Delete(ctx, name, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		return nm.client.RbacV1().RoleBindings(namespace.Name).Create(ctx, rb, metav1.CreateOptions{})
+	}
+
+	if equality.Semantic.DeepEqual(existing.Subjects, rb.Subjects) {
+		return existing, nil
+	}
+
+	// The RoleBinding is bound to a different user (e.g., the credentials have been revoked): rotate the subject.
+	existing.Subjects = rb.Subjects
+	return nm.client.RbacV1().RoleBindings(namespace.Name).Update(ctx, existing, metav1.UpdateOptions{})
 }
 
 // delete a RoleBinding in the given Namespace.
@@ -136,10 +168,18 @@ func getClusterRoleBindingName(clusterRoleName string, cluster liqov1beta1.Clust
 // BindClusterRolesClusterWide creates ClusterRoleBindings for the given ClusterRoles.
 func (nm *tenantNamespaceManager) BindClusterRolesClusterWide(ctx context.Context, cluster liqov1beta1.ClusterID,
 	owner metav1.Object, clusterRoles ...*rbacv1.ClusterRole) ([]*rbacv1.ClusterRoleBinding, error) {
+	return nm.BindClusterRolesClusterWideToGroup(ctx, cluster, string(cluster), nil, owner, clusterRoles...)
+}
+
+// BindClusterRolesClusterWideToGroup creates ClusterRoleBindings for the given ClusterRoles, binding them to the given group.
+// Existing ClusterRoleBindings bound to a different group are updated, hence revoking the permissions of the previous one.
+// If a PeeringRoleProfile is referenced, the ClusterRoles derived from the given ones according to the profile are bound in their place.
+func (nm *tenantNamespaceManager) BindClusterRolesClusterWideToGroup(ctx context.Context, cluster liqov1beta1.ClusterID, group string,
+	profileRef *v1.LocalObjectReference, owner metav1.Object, clusterRoles ...*rbacv1.ClusterRole) ([]*rbacv1.ClusterRoleBinding, error) {
 	var err error
 	bindings := make([]*rbacv1.ClusterRoleBinding, len(clusterRoles))
 	for i, clusterRole := range clusterRoles {
-		bindings[i], err = nm.bindClusterRoleClusterWide(ctx, cluster, owner, clusterRole)
+		bindings[i], err = nm.bindClusterRoleClusterWide(ctx, cluster, group, profileRef, owner, clusterRole)
 		if err != nil {
 			klog.Error(err)
 			return nil, err
@@ -148,8 +188,8 @@ func (nm *tenantNamespaceManager) BindClusterRolesClusterWide(ctx context.Contex
 	return bindings, nil
 }
 
-func (nm *tenantNamespaceManager) bindClusterRoleClusterWide(ctx context.Context, cluster liqov1beta1.ClusterID,
-	owner metav1.Object, clusterRole *rbacv1.ClusterRole) (*rbacv1.ClusterRoleBinding, error) {
+func (nm *tenantNamespaceManager) bindClusterRoleClusterWide(ctx context.Context, cluster liqov1beta1.ClusterID, group string,
+	profileRef *v1.LocalObjectReference, owner metav1.Object, clusterRole *rbacv1.ClusterRole) (*rbacv1.ClusterRoleBinding, error) {
 	name := getClusterRoleBindingName(clusterRole.Name, cluster)
 	labels := map[string]string{
 		consts.K8sAppManagedByKey: consts.LiqoAppLabelValue,
@@ -168,13 +208,13 @@ func (nm *tenantNamespaceManager) bindClusterRoleClusterWide(ctx context.Context
 			{
 				Kind:     rbacv1.GroupKind,
 				APIGroup: rbacv1.GroupName,
-				Name:     string(cluster),
+				Name:     auth.GroupName(group),
 			},
 		},
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: rbacv1.GroupName,
 			Kind:     "ClusterRole",
-			Name:     clusterRole.Name,
+			Name:     peeringroles.ClusterRoleNameForProfile(clusterRole.Name, profileRef),
 		},
 	}
 
@@ -187,11 +227,31 @@ func (nm *tenantNamespaceManager) bindClusterRoleClusterWide(ctx context.Context
 	resource.AddGlobalLabels(crb)
 	resource.AddGlobalAnnotations(crb)
 
-	crb, err := nm.client.RbacV1().ClusterRoleBindings().Create(ctx, crb, metav1.CreateOptions{})
-	if apierrors.IsAlreadyExists(err) {
-		return nm.client.RbacV1().ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
+	created, err := nm.client.RbacV1().ClusterRoleBindings().Create(ctx, crb, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return created, err
 	}
-	return crb, err
+
+	existing, err := nm.client.RbacV1().ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.RoleRef != crb.RoleRef {
+		// The role reference is immutable, hence the ClusterRoleBinding is recreated (e.g., the PeeringRoleProfile has changed).
+		if err := nm.client.RbacV1().ClusterRoleBindings().Delete(ctx, name, metav1.DeleteOptions{}); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		return nm.client.RbacV1().ClusterRoleBindings().Create(ctx, crb, metav1.CreateOptions{})
+	}
+
+	if equality.Semantic.DeepEqual(existing.Subjects, crb.Subjects) {
+		return existing, nil
+	}
+
+	// The ClusterRoleBinding is bound to a different group (e.g., the credentials have been revoked): rotate the subject.
+	existing.Subjects = crb.Subjects
+	return nm.client.RbacV1().ClusterRoleBindings().Update(ctx, existing, metav1.UpdateOptions{})
 }
 
 // UnbindClusterRolesClusterWide deletes ClusterRoleBindings for the given ClusterRoles.
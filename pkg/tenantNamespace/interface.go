@@ -32,8 +32,12 @@ type Manager interface {
 	GetNamespace(ctx context.Context, cluster liqov1beta1.ClusterID) (*corev1.Namespace, error)
 	BindClusterRoles(ctx context.Context, cluster liqov1beta1.ClusterID,
 		owner metav1.Object, clusterRoles ...*rbacv1.ClusterRole) ([]*rbacv1.RoleBinding, error)
+	BindClusterRolesToUser(ctx context.Context, cluster liqov1beta1.ClusterID, user string,
+		profileRef *corev1.LocalObjectReference, owner metav1.Object, clusterRoles ...*rbacv1.ClusterRole) ([]*rbacv1.RoleBinding, error)
 	UnbindClusterRoles(ctx context.Context, cluster liqov1beta1.ClusterID, clusterRoles ...*rbacv1.ClusterRole) error
 	BindClusterRolesClusterWide(ctx context.Context, cluster liqov1beta1.ClusterID,
 		owner metav1.Object, clusterRoles ...*rbacv1.ClusterRole) ([]*rbacv1.ClusterRoleBinding, error)
+	BindClusterRolesClusterWideToGroup(ctx context.Context, cluster liqov1beta1.ClusterID, group string,
+		profileRef *corev1.LocalObjectReference, owner metav1.Object, clusterRoles ...*rbacv1.ClusterRole) ([]*rbacv1.ClusterRoleBinding, error)
 	UnbindClusterRolesClusterWide(ctx context.Context, cluster liqov1beta1.ClusterID, clusterRoles ...*rbacv1.ClusterRole) error
 }
@@ -200,6 +200,78 @@ var _ = Describe("TenantNamespace", func() {
 				Expect(kerrors.IsNotFound(err)).To(BeTrue())
 			})
 
+			It("Rotate the bound user", func() {
+				var err error
+				rb, err = namespaceManager.BindClusterRoles(ctx, homeCluster, nil, clusterRoles[0])
+				Expect(err).To(BeNil())
+				checkRoleBinding(rb[0], namespace.Name, homeCluster, clusterRoles[0].Name)
+
+				// bind the same cluster role to a different user
+				rotatedUser := string(homeCluster) + "-gen1"
+				rb, err = namespaceManager.BindClusterRolesToUser(ctx, homeCluster, rotatedUser, nil, nil, clusterRoles[0])
+				Expect(err).To(BeNil())
+				Expect(len(rb)).To(BeNumerically("==", 1))
+				Expect(rb[0].Subjects).To(HaveLen(1))
+				Expect(rb[0].Subjects[0].Name).To(Equal(rotatedUser))
+
+				stored, err := client.RbacV1().RoleBindings(namespace.Name).Get(ctx, rb[0].Name, metav1.GetOptions{})
+				Expect(err).To(BeNil())
+				Expect(stored.Subjects).To(HaveLen(1))
+				Expect(stored.Subjects[0].Name).To(Equal(rotatedUser))
+
+				err = namespaceManager.UnbindClusterRoles(ctx, homeCluster, clusterRoles[0])
+				Expect(err).To(BeNil())
+			})
+
+			It("Bind the ClusterRoles derived from the PeeringRoleProfile", func() {
+				var err error
+				rb, err = namespaceManager.BindClusterRoles(ctx, homeCluster, nil, clusterRoles[0])
+				Expect(err).To(BeNil())
+				checkRoleBinding(rb[0], namespace.Name, homeCluster, clusterRoles[0].Name)
+
+				// the role reference is immutable, hence the binding is recreated
+				profileRef := &v1.LocalObjectReference{Name: "restricted"}
+				rb, err = namespaceManager.BindClusterRolesToUser(ctx, homeCluster, string(homeCluster), profileRef, nil, clusterRoles[0])
+				Expect(err).To(BeNil())
+				Expect(rb).To(HaveLen(1))
+				checkRoleBinding(rb[0], namespace.Name, homeCluster, clusterRoles[0].Name+"-restricted")
+
+				stored, err := client.RbacV1().RoleBindings(namespace.Name).Get(ctx, rb[0].Name, metav1.GetOptions{})
+				Expect(err).To(BeNil())
+				Expect(stored.RoleRef.Name).To(Equal(clusterRoles[0].Name + "-restricted"))
+
+				crb, err := namespaceManager.BindClusterRolesClusterWideToGroup(ctx, homeCluster, string(homeCluster), profileRef, nil, clusterRoles[0])
+				Expect(err).To(BeNil())
+				Expect(crb).To(HaveLen(1))
+				Expect(crb[0].RoleRef.Name).To(Equal(clusterRoles[0].Name + "-restricted"))
+
+				err = namespaceManager.UnbindClusterRoles(ctx, homeCluster, clusterRoles[0])
+				Expect(err).To(BeNil())
+				err = namespaceManager.UnbindClusterRolesClusterWide(ctx, homeCluster, clusterRoles[0])
+				Expect(err).To(BeNil())
+			})
+
+			It("Rotate the bound group cluster wide", func() {
+				crb, err := namespaceManager.BindClusterRolesClusterWide(ctx, homeCluster, nil, clusterRoles[0])
+				Expect(err).To(BeNil())
+				Expect(crb[0].Subjects).To(ConsistOf(HaveField("Name", string(homeCluster))))
+
+				// bind the same cluster role to a different group
+				rotatedGroup := string(homeCluster) + "-gen1"
+				crb, err = namespaceManager.BindClusterRolesClusterWideToGroup(ctx, homeCluster, rotatedGroup, nil, nil, clusterRoles[0])
+				Expect(err).To(BeNil())
+				Expect(crb).To(HaveLen(1))
+
+				stored, err := client.RbacV1().ClusterRoleBindings().Get(ctx, crb[0].Name, metav1.GetOptions{})
+				Expect(err).To(BeNil())
+				Expect(stored.Subjects).To(HaveLen(1))
+				Expect(stored.Subjects[0].Kind).To(Equal(rbacv1.GroupKind))
+				Expect(stored.Subjects[0].Name).To(Equal(rotatedGroup))
+
+				err = namespaceManager.UnbindClusterRolesClusterWide(ctx, homeCluster, clusterRoles[0])
+				Expect(err).To(BeNil())
+			})
+
 		})
 
 	})
@@ -14,7 +14,16 @@
 
 package cidr
 
-import networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+import (
+	"net"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	netutils "k8s.io/utils/net"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+)
 
 // GetPrimary returns the primary CIDR from a list of CIDRs.
 func GetPrimary(cidrs []networkingv1beta1.CIDR) *networkingv1beta1.CIDR {
@@ -29,6 +38,24 @@ func SetPrimary(cidr networkingv1beta1.CIDR) []networkingv1beta1.CIDR {
 	return []networkingv1beta1.CIDR{cidr}
 }
 
+// FromStrings converts a list of strings into a list of CIDRs, preserving their order (i.e., the primary CIDR comes first).
+func FromStrings(cidrs []string) []networkingv1beta1.CIDR {
+	res := make([]networkingv1beta1.CIDR, len(cidrs))
+	for i := range cidrs {
+		res[i] = networkingv1beta1.CIDR(cidrs[i])
+	}
+	return res
+}
+
+// Join returns the comma-separated representation of a list of CIDRs.
+func Join(cidrs []networkingv1beta1.CIDR) string {
+	res := make([]string, len(cidrs))
+	for i := range cidrs {
+		res[i] = cidrs[i].String()
+	}
+	return strings.Join(res, ",")
+}
+
 // IsVoid checks if a CIDR is void.
 func IsVoid(cidr *networkingv1beta1.CIDR) bool {
 	if cidr == nil {
@@ -36,3 +63,100 @@ func IsVoid(cidr *networkingv1beta1.CIDR) bool {
 	}
 	return cidr.String() == ""
 }
+
+// GetFamily returns the IP family of a CIDR, or an empty string if the CIDR is not valid.
+func GetFamily(cidr networkingv1beta1.CIDR) corev1.IPFamily {
+	switch netutils.IPFamilyOfCIDRString(cidr.String()) {
+	case netutils.IPv4:
+		return corev1.IPv4Protocol
+	case netutils.IPv6:
+		return corev1.IPv6Protocol
+	default:
+		return corev1.IPFamilyUnknown
+	}
+}
+
+// GetByFamily returns the first CIDR of the given IP family from a list of CIDRs, or nil if none is found.
+func GetByFamily(cidrs []networkingv1beta1.CIDR, family corev1.IPFamily) *networkingv1beta1.CIDR {
+	for i := range cidrs {
+		if GetFamily(cidrs[i]) == family {
+			return &cidrs[i]
+		}
+	}
+	return nil
+}
+
+// SetByFamily sets a CIDR in a list of CIDRs, replacing the one of the same IP family if present, or appending it otherwise.
+func SetByFamily(cidrs []networkingv1beta1.CIDR, cidr networkingv1beta1.CIDR) []networkingv1beta1.CIDR {
+	family := GetFamily(cidr)
+	for i := range cidrs {
+		if GetFamily(cidrs[i]) == family {
+			cidrs[i] = cidr
+			return cidrs
+		}
+	}
+	return append(cidrs, cidr)
+}
+
+// GetFamilies returns the IP families of a list of CIDRs, preserving their order.
+func GetFamilies(cidrs []networkingv1beta1.CIDR) []corev1.IPFamily {
+	var families []corev1.IPFamily
+	for i := range cidrs {
+		if family := GetFamily(cidrs[i]); family != corev1.IPFamilyUnknown && !slices.Contains(families, family) {
+			families = append(families, family)
+		}
+	}
+	return families
+}
+
+// SortByFamilies sorts a list of CIDRs according to the given order of IP families (e.g., to match the one of a reference list).
+func SortByFamilies(cidrs []networkingv1beta1.CIDR, families []corev1.IPFamily) []networkingv1beta1.CIDR {
+	slices.SortStableFunc(cidrs, func(a, b networkingv1beta1.CIDR) int {
+		return familyIndex(families, GetFamily(a)) - familyIndex(families, GetFamily(b))
+	})
+	return cidrs
+}
+
+// HasSameFamilies returns whether the list of CIDRs contains a CIDR for each IP family of the reference list.
+func HasSameFamilies(reference, cidrs []networkingv1beta1.CIDR) bool {
+	families := GetFamilies(reference)
+	if len(families) == 0 {
+		return false
+	}
+	for _, family := range families {
+		if IsVoid(GetByFamily(cidrs, family)) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetCommonFamilies returns the IP families of the first list of CIDRs which are present in all the other lists as well.
+func GetCommonFamilies(cidrs []networkingv1beta1.CIDR, others ...[]networkingv1beta1.CIDR) []corev1.IPFamily {
+	var families []corev1.IPFamily
+	for _, family := range GetFamilies(cidrs) {
+		if !slices.ContainsFunc(others, func(other []networkingv1beta1.CIDR) bool { return IsVoid(GetByFamily(other, family)) }) {
+			families = append(families, family)
+		}
+	}
+	return families
+}
+
+func familyIndex(families []corev1.IPFamily, family corev1.IPFamily) int {
+	if idx := slices.Index(families, family); idx >= 0 {
+		return idx
+	}
+	return len(families)
+}
+
+// HostCIDR returns the CIDR matching only the given IP address (i.e., /32 for IPv4 and /128 for IPv6 addresses).
+// IPv4-mapped IPv6 addresses are normalized to their IPv4 representation, consistently with GetFamily.
+func HostCIDR(ip string) networkingv1beta1.CIDR {
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() != nil {
+		return networkingv1beta1.CIDR(parsed.To4().String() + "/32")
+	}
+	if netutils.IsIPv6String(ip) {
+		return networkingv1beta1.CIDR(ip + "/128")
+	}
+	return networkingv1beta1.CIDR(ip + "/32")
+}
@@ -0,0 +1,122 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cidr
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+)
+
+var _ = Describe("CIDR utilities", func() {
+	const (
+		v4 = networkingv1beta1.CIDR("10.0.0.0/16")
+		v6 = networkingv1beta1.CIDR("fd00::/64")
+	)
+
+	var (
+		dual    = []networkingv1beta1.CIDR{v4, v6}
+		dualRev = []networkingv1beta1.CIDR{v6, v4}
+		onlyV4  = []networkingv1beta1.CIDR{v4}
+		onlyV6  = []networkingv1beta1.CIDR{v6}
+
+		ptr = func(cidr networkingv1beta1.CIDR) *networkingv1beta1.CIDR { return &cidr }
+	)
+
+	DescribeTable("GetFamily",
+		func(cidr networkingv1beta1.CIDR, expected corev1.IPFamily) {
+			Expect(GetFamily(cidr)).To(Equal(expected))
+		},
+		Entry("IPv4 CIDR", v4, corev1.IPv4Protocol),
+		Entry("IPv6 CIDR", v6, corev1.IPv6Protocol),
+		Entry("IPv4-mapped IPv6 CIDR", networkingv1beta1.CIDR("::ffff:10.0.0.0/120"), corev1.IPv4Protocol),
+		Entry("IP address without prefix", networkingv1beta1.CIDR("10.0.0.1"), corev1.IPFamilyUnknown),
+		Entry("malformed CIDR", networkingv1beta1.CIDR("foo"), corev1.IPFamilyUnknown),
+		Entry("void CIDR", networkingv1beta1.CIDR(""), corev1.IPFamilyUnknown),
+	)
+
+	DescribeTable("GetByFamily",
+		func(cidrs []networkingv1beta1.CIDR, family corev1.IPFamily, expected *networkingv1beta1.CIDR) {
+			Expect(GetByFamily(cidrs, family)).To(Equal(expected))
+		},
+		Entry("IPv4 from a dual-stack list", dual, corev1.IPv4Protocol, ptr(v4)),
+		Entry("IPv6 from a dual-stack list", dual, corev1.IPv6Protocol, ptr(v6)),
+		Entry("IPv4 from a reversed dual-stack list", dualRev, corev1.IPv4Protocol, ptr(v4)),
+		Entry("IPv6 from an IPv4-only list", onlyV4, corev1.IPv6Protocol, nil),
+		Entry("first match with multiple CIDRs of the same family",
+			[]networkingv1beta1.CIDR{v4, "10.1.0.0/16"}, corev1.IPv4Protocol, ptr(v4)),
+		Entry("empty list", nil, corev1.IPv4Protocol, nil),
+	)
+
+	DescribeTable("SetByFamily",
+		func(cidrs []networkingv1beta1.CIDR, cidr networkingv1beta1.CIDR, expected []networkingv1beta1.CIDR) {
+			Expect(SetByFamily(append([]networkingv1beta1.CIDR(nil), cidrs...), cidr)).To(Equal(expected))
+		},
+		Entry("replacing the IPv4 CIDR", dual, networkingv1beta1.CIDR("10.1.0.0/16"),
+			[]networkingv1beta1.CIDR{"10.1.0.0/16", v6}),
+		Entry("replacing the IPv6 CIDR", dual, networkingv1beta1.CIDR("fd01::/64"),
+			[]networkingv1beta1.CIDR{v4, "fd01::/64"}),
+		Entry("appending the IPv6 CIDR to an IPv4-only list", onlyV4, v6, dual),
+		Entry("appending the IPv4 CIDR to an IPv6-only list", onlyV6, v4, dualRev),
+		Entry("appending to an empty list", nil, v4, onlyV4),
+	)
+
+	DescribeTable("SortByFamilies",
+		func(cidrs []networkingv1beta1.CIDR, families []corev1.IPFamily, expected []networkingv1beta1.CIDR) {
+			Expect(SortByFamilies(append([]networkingv1beta1.CIDR(nil), cidrs...), families)).To(Equal(expected))
+		},
+		Entry("already sorted", dual, []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}, dual),
+		Entry("reversed", dual, []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}, dualRev),
+		Entry("missing families go last", dual, []corev1.IPFamily{corev1.IPv6Protocol}, dualRev),
+		Entry("stable for the same family", []networkingv1beta1.CIDR{"10.1.0.0/16", v6, v4},
+			[]corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}, []networkingv1beta1.CIDR{"10.1.0.0/16", v4, v6}),
+		Entry("no reference families", dualRev, nil, dualRev),
+	)
+
+	DescribeTable("HasSameFamilies",
+		func(reference, cidrs []networkingv1beta1.CIDR, expected bool) {
+			Expect(HasSameFamilies(reference, cidrs)).To(Equal(expected))
+		},
+		Entry("same dual-stack families", dual, dualRev, true),
+		Entry("IPv4-only reference and dual-stack list", onlyV4, dual, true),
+		Entry("dual-stack reference and IPv4-only list", dual, onlyV4, false),
+		Entry("different single families", onlyV4, onlyV6, false),
+		Entry("empty reference", nil, dual, false),
+		Entry("empty list", dual, nil, false),
+	)
+
+	DescribeTable("GetCommonFamilies",
+		func(cidrs []networkingv1beta1.CIDR, others [][]networkingv1beta1.CIDR, expected []corev1.IPFamily) {
+			Expect(GetCommonFamilies(cidrs, others...)).To(Equal(expected))
+		},
+		Entry("no other lists", dual, nil, []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}),
+		Entry("all dual-stack, preserving the order of the first list", dualRev, [][]networkingv1beta1.CIDR{dual, dual},
+			[]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}),
+		Entry("one IPv4-only list", dual, [][]networkingv1beta1.CIDR{dual, onlyV4}, []corev1.IPFamily{corev1.IPv4Protocol}),
+		Entry("disjoint families", onlyV4, [][]networkingv1beta1.CIDR{onlyV6}, nil),
+		Entry("empty list", nil, [][]networkingv1beta1.CIDR{dual}, nil),
+	)
+
+	DescribeTable("HostCIDR",
+		func(ip string, expected networkingv1beta1.CIDR) {
+			Expect(HostCIDR(ip)).To(Equal(expected))
+		},
+		Entry("IPv4 address", "10.0.0.1", networkingv1beta1.CIDR("10.0.0.1/32")),
+		Entry("IPv6 address", "fd00::1", networkingv1beta1.CIDR("fd00::1/128")),
+		Entry("IPv4-mapped IPv6 address", "::ffff:10.0.0.1", networkingv1beta1.CIDR("10.0.0.1/32")),
+	)
+})
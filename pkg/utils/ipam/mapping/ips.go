@@ -132,43 +132,43 @@ func MapAddress(ctx context.Context, cl client.Client,
 }
 
 // MapAddressWithConfiguration maps the address with the network configuration of the cluster.
+// The address is remapped according to the CIDRs of its own IP family (dual-stack clusters).
 func MapAddressWithConfiguration(cfg *networkingv1beta1.Configuration, address string) (string, error) {
-	var (
-		podnet, podnetMapped, extnet, extnetMapped *net.IPNet
-		err                                        error
-	)
-
-	podNeedsRemap := cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.Pod).String() != cidrutils.GetPrimary(cfg.Status.Remote.CIDR.Pod).String()
-	extNeedsRemap := cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.External).String() != cidrutils.GetPrimary(cfg.Status.Remote.CIDR.External).String()
+	paddr := net.ParseIP(address)
+	if paddr == nil {
+		return address, nil
+	}
+	family := corev1.IPv4Protocol
+	if paddr.To4() == nil {
+		family = corev1.IPv6Protocol
+	}
 
-	_, podnet, err = net.ParseCIDR(cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.Pod).String())
-	if err != nil {
-		return "", err
+	var remotePodCIDRs, remoteExtCIDRs []networkingv1beta1.CIDR
+	if cfg.Status.Remote != nil {
+		remotePodCIDRs, remoteExtCIDRs = cfg.Status.Remote.CIDR.Pod, cfg.Status.Remote.CIDR.External
 	}
-	if podNeedsRemap {
-		_, podnetMapped, err = net.ParseCIDR(cidrutils.GetPrimary(cfg.Status.Remote.CIDR.Pod).String())
+
+	for _, cidrs := range [][2][]networkingv1beta1.CIDR{
+		{cfg.Spec.Remote.CIDR.Pod, remotePodCIDRs},
+		{cfg.Spec.Remote.CIDR.External, remoteExtCIDRs},
+	} {
+		original, remapped := cidrutils.GetByFamily(cidrs[0], family), cidrutils.GetByFamily(cidrs[1], family)
+		if original == nil || remapped == nil || original.String() == remapped.String() {
+			continue
+		}
+
+		_, originalNet, err := net.ParseCIDR(original.String())
 		if err != nil {
 			return "", err
 		}
-	}
-
-	_, extnet, err = net.ParseCIDR(cidrutils.GetPrimary(cfg.Spec.Remote.CIDR.External).String())
-	if err != nil {
-		return "", err
-	}
-	if extNeedsRemap {
-		_, extnetMapped, err = net.ParseCIDR(cidrutils.GetPrimary(cfg.Status.Remote.CIDR.External).String())
+		_, remappedNet, err := net.ParseCIDR(remapped.String())
 		if err != nil {
 			return "", err
 		}
-	}
 
-	paddr := net.ParseIP(address)
-	if podNeedsRemap && podnet.Contains(paddr) {
-		return RemapMask(paddr, *podnetMapped).String(), nil
-	}
-	if extNeedsRemap && extnet.Contains(paddr) {
-		return RemapMask(paddr, *extnetMapped).String(), nil
+		if originalNet.Contains(paddr) {
+			return RemapMask(paddr, *remappedNet).String(), nil
+		}
 	}
 
 	return address, nil
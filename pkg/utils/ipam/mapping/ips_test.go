@@ -20,6 +20,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
 )
 
 var _ = Describe("RemapMask", func() {
@@ -135,3 +137,45 @@ var _ = Describe("RemapMask", func() {
 		Entry("IPv6 remapping", "2001:db8:abcd:1234::1", "2001:db8::/61", "2001:db8:0:4::1"),
 	)
 })
+
+var _ = Describe("MapAddressWithConfiguration", func() {
+	var cfg *networkingv1beta1.Configuration
+
+	BeforeEach(func() {
+		cfg = &networkingv1beta1.Configuration{
+			Spec: networkingv1beta1.ConfigurationSpec{
+				Remote: networkingv1beta1.ClusterConfig{
+					CIDR: networkingv1beta1.ClusterConfigCIDR{
+						Pod:      []networkingv1beta1.CIDR{"10.0.0.0/16", "fd00:10::/64"},
+						External: []networkingv1beta1.CIDR{"10.70.0.0/16", "fd00:70::/64"},
+					},
+				},
+			},
+			Status: networkingv1beta1.ConfigurationStatus{
+				Remote: &networkingv1beta1.ClusterConfig{
+					CIDR: networkingv1beta1.ClusterConfigCIDR{
+						Pod:      []networkingv1beta1.CIDR{"10.1.0.0/16", "fd00:11::/64"},
+						External: []networkingv1beta1.CIDR{"10.70.0.0/16", "fd00:71::/64"},
+					},
+				},
+			},
+		}
+	})
+
+	DescribeTable("dual-stack remapping",
+		func(address, expected string) {
+			Expect(MapAddressWithConfiguration(cfg, address)).To(Equal(expected))
+		},
+		Entry("IPv4 pod address", "10.0.1.2", "10.1.1.2"),
+		Entry("IPv4 external address (not remapped)", "10.70.1.2", "10.70.1.2"),
+		Entry("IPv6 pod address", "fd00:10::1:2", "fd00:11::1:2"),
+		Entry("IPv6 external address", "fd00:70::1:2", "fd00:71::1:2"),
+		Entry("address outside the remote CIDRs", "192.168.1.1", "192.168.1.1"),
+		Entry("not an IP address", "example.com", "example.com"),
+	)
+
+	It("should not remap addresses of a family missing in the status", func() {
+		cfg.Status.Remote.CIDR.Pod = cfg.Status.Remote.CIDR.Pod[:1]
+		Expect(MapAddressWithConfiguration(cfg, "fd00:10::1:2")).To(Equal("fd00:10::1:2"))
+	})
+})
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -127,6 +128,63 @@ func GetInternalCIDR(ctx context.Context, cl client.Client, liqoNamespace string
 	return nw.Status.CIDR.String(), nil
 }
 
+// GetPodCIDRs retrieves the podCIDRs of the local cluster.
+// The primary podCIDR comes first, followed by the one of the secondary IP family, if any (dual-stack clusters).
+func GetPodCIDRs(ctx context.Context, cl client.Client, liqoNamespace string) ([]string, error) {
+	podCIDR, err := GetPodCIDR(ctx, cl, liqoNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return appendSecondaryCIDR(ctx, cl, liqoNamespace, []string{podCIDR}, consts.NetworkTypePodCIDRSecondary)
+}
+
+// GetServiceCIDRs retrieves the serviceCIDRs of the local cluster.
+// The primary serviceCIDR comes first, followed by the one of the secondary IP family, if any (dual-stack clusters).
+func GetServiceCIDRs(ctx context.Context, cl client.Client, liqoNamespace string) ([]string, error) {
+	serviceCIDR, err := GetServiceCIDR(ctx, cl, liqoNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return appendSecondaryCIDR(ctx, cl, liqoNamespace, []string{serviceCIDR}, consts.NetworkTypeServiceCIDRSecondary)
+}
+
+// GetExternalCIDRs retrieves the externalCIDRs of the local cluster.
+// The primary externalCIDR comes first, followed by the one of the secondary IP family, if any (dual-stack clusters).
+func GetExternalCIDRs(ctx context.Context, cl client.Client, liqoNamespace string) ([]string, error) {
+	externalCIDR, err := GetExternalCIDR(ctx, cl, liqoNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return appendSecondaryCIDR(ctx, cl, liqoNamespace, []string{externalCIDR}, consts.NetworkTypeExternalCIDRSecondary)
+}
+
+// appendSecondaryCIDR appends to the given list the CIDR of the Network of the given secondary type, if it exists.
+func appendSecondaryCIDR(ctx context.Context, cl client.Client, liqoNamespace string,
+	cidrs []string, networkType consts.NetworkType) ([]string, error) {
+	nw, err := liqogetters.GetUniqueNetworkByLabel(ctx, cl, labels.SelectorFromSet(map[string]string{
+		consts.NetworkTypeLabelKey: string(networkType),
+	}), liqoNamespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		return cidrs, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if nw.Status.CIDR == "" {
+		return nil, fmt.Errorf("the %s network is not yet configured: missing status on the Network resource", networkType)
+	}
+
+	return append(cidrs, nw.Status.CIDR.String()), nil
+}
+
+// GetNetworksByType retrieves the Network resources of the given type.
+func GetNetworksByType(ctx context.Context, cl client.Client, networkType consts.NetworkType, namespace string) ([]ipamv1alpha1.Network, error) {
+	return liqogetters.GetNetworksByLabel(ctx, cl, labels.SelectorFromSet(map[string]string{
+		consts.NetworkTypeLabelKey: string(networkType),
+	}), namespace)
+}
+
 // GetReservedSubnetNetworks retrieves the Network resources of type Reserved.
 func GetReservedSubnetNetworks(ctx context.Context, cl client.Client) ([]ipamv1alpha1.Network, error) {
 	return liqogetters.GetNetworksByLabel(
@@ -0,0 +1,45 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kernel
+
+import (
+	"errors"
+	"os"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	ipv4MultipathHashPolicyPath = "/proc/sys/net/ipv4/fib_multipath_hash_policy"
+	ipv6MultipathHashPolicyPath = "/proc/sys/net/ipv6/fib_multipath_hash_policy"
+)
+
+// EnableMultipathL4Hashing configures the host to balance the multipath routes based on the layer 4 hash of the packets.
+// This allows the connections to be spread across the next hops, while all the packets of a connection follow the same path.
+func EnableMultipathL4Hashing() error {
+	if err := os.WriteFile(ipv4MultipathHashPolicyPath, []byte("1"), os.ModePerm); err != nil {
+		return err
+	}
+
+	// IPv6 might be disabled in IPv4-only clusters.
+	if _, err := os.Stat(ipv6MultipathHashPolicyPath); errors.Is(err, os.ErrNotExist) {
+		klog.Warningf("Unable to configure the IPv6 multipath hash policy (is IPv6 disabled?): %v", err)
+		return nil
+	}
+	if err := os.WriteFile(ipv6MultipathHashPolicyPath, []byte("1"), os.ModePerm); err != nil {
+		return err
+	}
+	return nil
+}
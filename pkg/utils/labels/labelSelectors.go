@@ -130,3 +130,8 @@ func ComponentLabelSelector(name, component string) labels.Selector {
 func ControllerManagerLabelSelector() labels.Selector {
 	return ComponentLabelSelector("controller-manager", "controller-manager")
 }
+
+// WebhookLabelSelector returns the label selector associated with the webhook components.
+func WebhookLabelSelector() labels.Selector {
+	return ComponentLabelSelector("webhook", "webhook")
+}
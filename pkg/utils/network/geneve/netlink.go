@@ -17,6 +17,7 @@ package geneve
 import (
 	"fmt"
 	"net"
+	"slices"
 
 	"github.com/vishvananda/netlink"
 	"k8s.io/klog/v2"
@@ -77,7 +78,8 @@ func CreateGeneveInterface(name string, local, remote net.IP, id uint32, disable
 		}
 	} else {
 		geneveLink = link.(*netlink.Geneve)
-		if !geneveLink.Remote.Equal(remote) || geneveLink.MTU != mtu || geneveLink.Dport != port {
+		switch {
+		case !geneveLink.Remote.Equal(remote) || geneveLink.Dport != port:
 			klog.Warningf("geneve link already exists with different remote IP (%s -> %s), modifyng it",
 				geneveLink.Remote.String(), remote.String())
 			if err := netlink.LinkDel(geneveLink); err != nil {
@@ -87,6 +89,12 @@ func CreateGeneveInterface(name string, local, remote net.IP, id uint32, disable
 			if err := netlink.LinkAdd(geneveLink); err != nil {
 				return fmt.Errorf("cannot modify geneve link: %w", err)
 			}
+		case geneveLink.MTU != mtu:
+			// The MTU is changed in place (e.g., when the MTU of the tunnel is discovered), preserving the routes on the interface.
+			klog.Infof("geneve link already exists with different MTU (%d -> %d), modifying it", geneveLink.MTU, mtu)
+			if err := netlink.LinkSetMTU(geneveLink, mtu); err != nil {
+				return fmt.Errorf("cannot set geneve link MTU: %w", err)
+			}
 		}
 	}
 
@@ -101,10 +109,15 @@ func CreateGeneveInterface(name string, local, remote net.IP, id uint32, disable
 	}
 
 	if ExistGeneveInterfaceAddr(geneveLink, local) == nil {
+		// The local address is configured as a host address (i.e., /32 for IPv4 and /128 for IPv6).
+		bits := net.IPv6len * 8
+		if local.To4() != nil {
+			bits = net.IPv4len * 8
+		}
 		if err := netlink.AddrAdd(geneveLink, &netlink.Addr{
 			IPNet: &net.IPNet{
 				IP:   local,
-				Mask: net.IPMask{0xff, 0xff, 0xff, 0xff},
+				Mask: net.CIDRMask(bits, bits),
 			},
 		}); err != nil {
 			return fmt.Errorf("cannot add address to geneve link: %w", err)
@@ -153,3 +166,23 @@ func ListGeneveInterfaces() ([]netlink.Link, error) {
 	}
 	return geneveLinks, nil
 }
+
+// EnsureStaleGeneveInterfacesAbsence removes the geneve interfaces with the given ID whose name is not in the desired list
+// (e.g., the ones associated with gateway replicas no longer present).
+func EnsureStaleGeneveInterfacesAbsence(id uint32, desired []string) error {
+	links, err := ListGeneveInterfaces()
+	if err != nil {
+		return err
+	}
+	for i := range links {
+		geneveLink, ok := links[i].(*netlink.Geneve)
+		if !ok || geneveLink.ID != id || slices.Contains(desired, geneveLink.Name) {
+			continue
+		}
+		klog.Infof("Removing stale geneve interface %s (id %d)", geneveLink.Name, id)
+		if err := netlink.LinkDel(geneveLink); err != nil {
+			return fmt.Errorf("cannot delete geneve link %s: %w", geneveLink.Name, err)
+		}
+	}
+	return nil
+}
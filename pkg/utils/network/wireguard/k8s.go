@@ -0,0 +1,108 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkingv1beta1 "github.com/liqotech/liqo/apis/networking/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/resource"
+)
+
+// ForgePublicKeyLabels returns the labels of the PublicKey published by a gateway or a node for the given internal fabric.
+func ForgePublicKeyLabels(internalFabricName, role string) map[string]string {
+	return map[string]string{
+		consts.InternalFabricName:       internalFabricName,
+		consts.FabricPublicKeyRoleLabel: role,
+	}
+}
+
+// EnsurePublicKeyPresence creates or updates the PublicKey with the given name, publishing the given key for the internal fabric.
+// The PublicKey is owned by the internal fabric, so that it is garbage collected when the internal fabric is deleted.
+func EnsurePublicKeyPresence(ctx context.Context, cl client.Client, s *runtime.Scheme, internalFabric *networkingv1beta1.InternalFabric,
+	name string, lbls, annotations map[string]string, key wgtypes.Key) error {
+	publicKey := &networkingv1beta1.PublicKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: internalFabric.Namespace,
+		},
+	}
+	if _, err := resource.CreateOrUpdate(ctx, cl, publicKey, func() error {
+		if publicKey.Labels == nil {
+			publicKey.Labels = make(map[string]string)
+		}
+		maps.Copy(publicKey.Labels, lbls)
+		if len(annotations) > 0 {
+			if publicKey.Annotations == nil {
+				publicKey.Annotations = make(map[string]string)
+			}
+			maps.Copy(publicKey.Annotations, annotations)
+		}
+		publicKey.Spec.PublicKey = key[:]
+		return controllerutil.SetOwnerReference(internalFabric, publicKey, s)
+	}); err != nil {
+		return fmt.Errorf("unable to enforce the fabric publickey %s/%s: %w", internalFabric.Namespace, name, err)
+	}
+	return nil
+}
+
+// EnsurePublicKeyAbsence deletes the PublicKey with the given name, if present.
+func EnsurePublicKeyAbsence(ctx context.Context, cl client.Client, name, namespace string) error {
+	publicKey := &networkingv1beta1.PublicKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	if err := client.IgnoreNotFound(cl.Delete(ctx, publicKey)); err != nil {
+		return fmt.Errorf("unable to delete the fabric publickey %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// ListPublicKeys returns the PublicKeys published for the given internal fabric by the gateways or by the nodes.
+func ListPublicKeys(ctx context.Context, cl client.Client, internalFabric *networkingv1beta1.InternalFabric,
+	role string) ([]networkingv1beta1.PublicKey, error) {
+	var publicKeys networkingv1beta1.PublicKeyList
+	if err := cl.List(ctx, &publicKeys, client.InNamespace(internalFabric.Namespace),
+		client.MatchingLabels(ForgePublicKeyLabels(internalFabric.Name, role))); err != nil {
+		return nil, fmt.Errorf("unable to list the fabric publickeys in namespace %s: %w", internalFabric.Namespace, err)
+	}
+	return publicKeys.Items, nil
+}
+
+// ParsePublicKey returns the WireGuard key contained in the given PublicKey.
+func ParsePublicKey(publicKey *networkingv1beta1.PublicKey) (wgtypes.Key, error) {
+	key, err := wgtypes.NewKey(publicKey.Spec.PublicKey)
+	if err != nil {
+		return wgtypes.Key{}, fmt.Errorf("invalid key in publickey %s/%s: %w", publicKey.Namespace, publicKey.Name, err)
+	}
+	return key, nil
+}
+
+// IsEncrypted returns whether the traffic of the given internal fabric must be encrypted through WireGuard.
+func IsEncrypted(internalFabric *networkingv1beta1.InternalFabric) bool {
+	return internalFabric.Spec.Encryption == networkingv1beta1.InternalFabricEncryptionModeWireGuard &&
+		internalFabric.DeletionTimestamp.IsZero()
+}
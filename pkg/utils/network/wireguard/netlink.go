@@ -0,0 +1,261 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"k8s.io/klog/v2"
+)
+
+// rulePriority is the priority of the rules steering the geneve traffic towards the encrypted peers.
+// It precedes the default rules, so that the geneve traffic is encrypted regardless of the other routes.
+const rulePriority = 100
+
+// Peer contains the information required to establish a WireGuard peering of the internal fabric.
+type Peer struct {
+	// PublicKey is the public key of the peer.
+	PublicKey wgtypes.Key
+	// Endpoint is the IP used to reach the peer.
+	Endpoint net.IP
+	// AllowedIPs are the source IPs accepted from the peer.
+	AllowedIPs []net.IP
+}
+
+// EnsureWireGuardInterfacePresence ensures that the WireGuard interface with the given name exists,
+// and that it is configured with the given private key, listen port and peers.
+func EnsureWireGuardInterfacePresence(name string, privateKey wgtypes.Key, port int, peers []Peer) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil && !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return fmt.Errorf("cannot get wireguard link %s: %w", name, err)
+	}
+
+	if link == nil {
+		link = &netlink.Wireguard{LinkAttrs: netlink.LinkAttrs{Name: name}}
+		if err := netlink.LinkAdd(link); err != nil {
+			return fmt.Errorf("cannot create wireguard link %s: %w", name, err)
+		}
+		klog.Infof("Created wireguard interface %s", name)
+	}
+
+	wgcl, err := wgctrl.New()
+	if err != nil {
+		return fmt.Errorf("cannot create wireguard client: %w", err)
+	}
+	defer wgcl.Close()
+
+	peersConfig := make([]wgtypes.PeerConfig, len(peers))
+	for i := range peers {
+		peersConfig[i] = wgtypes.PeerConfig{
+			PublicKey:         peers[i].PublicKey,
+			Endpoint:          &net.UDPAddr{IP: peers[i].Endpoint, Port: port},
+			AllowedIPs:        forgeHostNetworks(peers[i].AllowedIPs),
+			ReplaceAllowedIPs: true,
+		}
+	}
+
+	if err := wgcl.ConfigureDevice(name, wgtypes.Config{
+		PrivateKey:   &privateKey,
+		ListenPort:   &port,
+		Peers:        peersConfig,
+		ReplacePeers: true,
+	}); err != nil {
+		return fmt.Errorf("cannot configure wireguard link %s: %w", name, err)
+	}
+
+	if err := netlink.LinkSetUp(link); err != nil {
+		return fmt.Errorf("cannot set wireguard link %s up: %w", name, err)
+	}
+	return nil
+}
+
+// EnsureWireGuardInterfaceAbsence ensures that the WireGuard interface with the given name does not exist.
+func EnsureWireGuardInterfaceAbsence(name string) error {
+	link, err := netlink.LinkByName(name)
+	switch {
+	case errors.As(err, &netlink.LinkNotFoundError{}):
+		return nil
+	case err != nil:
+		return fmt.Errorf("cannot get wireguard link %s: %w", name, err)
+	}
+	klog.Infof("Removing wireguard interface %s", name)
+	return netlink.LinkDel(link)
+}
+
+// EnsureRoutesPresence ensures that the geneve traffic towards the given endpoints is routed through the WireGuard interface.
+// The traffic is steered by a rule matching the geneve port, hence the WireGuard packets themselves, as well as any other
+// traffic towards the same endpoints, keep following the default routes.
+// The source of each route is the one selected by the default routes, so that the peer accepts the encrypted traffic
+// and the source IPs detected for the geneve tunnels do not change.
+func EnsureRoutesPresence(name string, tableID uint32, genevePort uint16, endpoints []net.IP) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("cannot get wireguard link %s: %w", name, err)
+	}
+
+	if err := ensureRulePresence(netlink.FAMILY_V4, tableID, genevePort); err != nil {
+		return err
+	}
+	if err := ensureRulePresence(netlink.FAMILY_V6, tableID, genevePort); err != nil {
+		// IPv6 might be disabled in IPv4-only clusters: in that case, only the IPv4 rule is configured.
+		klog.Warningf("Unable to configure the IPv6 rule for the encrypted fabric (is IPv6 disabled?): %v", err)
+	}
+
+	routes, err := listRoutes(tableID)
+	if err != nil {
+		return err
+	}
+
+	desired := forgeHostNetworks(endpoints)
+	for i := range desired {
+		src, err := getDefaultSrc(desired[i].IP)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(routes, func(r netlink.Route) bool {
+			return r.Dst != nil && r.Dst.String() == desired[i].String() && r.Src.Equal(src) && r.LinkIndex == link.Attrs().Index
+		}) {
+			continue
+		}
+		if err := netlink.RouteReplace(&netlink.Route{
+			LinkIndex: link.Attrs().Index,
+			Dst:       &desired[i],
+			Src:       src,
+			Table:     int(tableID),
+			Scope:     netlink.SCOPE_LINK,
+		}); err != nil {
+			return fmt.Errorf("cannot add route towards %s: %w", desired[i].String(), err)
+		}
+	}
+
+	for i := range routes {
+		if routes[i].Dst != nil && slices.ContainsFunc(desired, func(d net.IPNet) bool { return d.String() == routes[i].Dst.String() }) {
+			continue
+		}
+		if err := netlink.RouteDel(&routes[i]); err != nil {
+			return fmt.Errorf("cannot delete stale route %s: %w", routes[i].String(), err)
+		}
+	}
+	return nil
+}
+
+// EnsureRoutesAbsence ensures that the rules and the routes steering the geneve traffic through the WireGuard interface do not exist.
+func EnsureRoutesAbsence(tableID uint32) error {
+	routes, err := listRoutes(tableID)
+	if err != nil {
+		return err
+	}
+	for i := range routes {
+		if err := netlink.RouteDel(&routes[i]); err != nil {
+			return fmt.Errorf("cannot delete route %s: %w", routes[i].String(), err)
+		}
+	}
+
+	rules, err := netlink.RuleListFiltered(netlink.FAMILY_ALL, &netlink.Rule{Table: int(tableID)}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return fmt.Errorf("cannot list rules: %w", err)
+	}
+	for i := range rules {
+		if err := netlink.RuleDel(&rules[i]); err != nil {
+			return fmt.Errorf("cannot delete rule %s: %w", rules[i].String(), err)
+		}
+	}
+	return nil
+}
+
+func ensureRulePresence(family int, tableID uint32, genevePort uint16) error {
+	rules, err := netlink.RuleListFiltered(family, &netlink.Rule{Table: int(tableID)}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return fmt.Errorf("cannot list rules: %w", err)
+	}
+	if slices.ContainsFunc(rules, func(r netlink.Rule) bool {
+		return r.IPProto == unix.IPPROTO_UDP && r.Dport != nil && r.Dport.Start == genevePort && r.Dport.End == genevePort
+	}) {
+		return nil
+	}
+
+	// Remove the rules possibly referring to a different geneve port.
+	for i := range rules {
+		if err := netlink.RuleDel(&rules[i]); err != nil {
+			return fmt.Errorf("cannot delete rule %s: %w", rules[i].String(), err)
+		}
+	}
+
+	rule := netlink.NewRule()
+	rule.Family = family
+	rule.Table = int(tableID)
+	rule.Priority = rulePriority
+	rule.IPProto = unix.IPPROTO_UDP
+	rule.Dport = netlink.NewRulePortRange(genevePort, genevePort)
+	if err := netlink.RuleAdd(rule); err != nil {
+		return fmt.Errorf("cannot add rule %s: %w", rule.String(), err)
+	}
+	return nil
+}
+
+// getDefaultSrc returns the source IP selected by the default routes to reach the given destination.
+func getDefaultSrc(dst net.IP) (net.IP, error) {
+	routes, err := netlink.RouteGet(dst)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the route towards %s: %w", dst.String(), err)
+	}
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("no route towards %s", dst.String())
+	}
+	return routes[0].Src, nil
+}
+
+func listRoutes(tableID uint32) ([]netlink.Route, error) {
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: int(tableID)}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list routes: %w", err)
+	}
+	return routes, nil
+}
+
+// forgeHostNetworks returns the host networks (i.e., /32 for IPv4 and /128 for IPv6) of the given IPs.
+func forgeHostNetworks(ips []net.IP) []net.IPNet {
+	networks := make([]net.IPNet, 0, len(ips))
+	for _, ip := range ips {
+		bits := net.IPv6len * 8
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), net.IPv4len*8
+		}
+		networks = append(networks, net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return networks
+}
+
+// FindLocalIP returns the first of the given IPs which is assigned to a local interface, or nil if none is.
+func FindLocalIP(ips []string) (net.IP, error) {
+	addrs, err := netlink.AddrList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the local addresses: %w", err)
+	}
+	for _, ip := range ips {
+		parsed := net.ParseIP(ip)
+		if slices.ContainsFunc(addrs, func(a netlink.Addr) bool { return a.IP.Equal(parsed) }) {
+			return parsed, nil
+		}
+	}
+	return nil, nil
+}
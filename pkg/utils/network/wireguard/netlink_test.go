@@ -0,0 +1,181 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package wireguard
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+)
+
+const (
+	linkName   = "liqo-test"
+	tableID    = 1234
+	genevePort = 6091
+)
+
+// setupLink creates a veth pair, with the given addresses assigned to the interface named linkName.
+func setupLink(cidrs ...string) {
+	link := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: linkName}, PeerName: linkName + "-peer"}
+	Expect(netlink.LinkAdd(link)).To(Succeed())
+	for _, cidr := range cidrs {
+		addr, err := netlink.ParseAddr(cidr)
+		Expect(err).NotTo(HaveOccurred())
+		// Duplicate address detection is skipped, as it would delay the usage of the IPv6 addresses.
+		addr.Flags = unix.IFA_F_NODAD
+		Expect(netlink.AddrAdd(link, addr)).To(Succeed())
+	}
+	peer, err := netlink.LinkByName(linkName + "-peer")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(netlink.LinkSetUp(peer)).To(Succeed())
+	Expect(netlink.LinkSetUp(link)).To(Succeed())
+}
+
+// getRouteDsts returns the destinations of the routes of the given table.
+func getRouteDsts() []string {
+	routes, err := listRoutes(tableID)
+	Expect(err).NotTo(HaveOccurred())
+	dsts := make([]string, len(routes))
+	for i := range routes {
+		dsts[i] = routes[i].Dst.String()
+	}
+	return dsts
+}
+
+// getRules returns the rules pointing to the given table.
+func getRules() []netlink.Rule {
+	rules, err := netlink.RuleListFiltered(netlink.FAMILY_ALL, &netlink.Rule{Table: tableID}, netlink.RT_FILTER_TABLE)
+	Expect(err).NotTo(HaveOccurred())
+	return rules
+}
+
+var _ = Describe("WireGuard netlink utils", func() {
+	DescribeTable("forgeHostNetworks function",
+		func(ips []string, expected []string) {
+			parsed := make([]net.IP, len(ips))
+			for i := range ips {
+				parsed[i] = net.ParseIP(ips[i])
+			}
+			networks := forgeHostNetworks(parsed)
+			Expect(networks).To(HaveLen(len(expected)))
+			for i := range networks {
+				Expect(networks[i].String()).To(Equal(expected[i]))
+			}
+		},
+		Entry("No IPs", nil, nil),
+		Entry("IPv4 addresses", []string{"10.0.0.1", "192.168.1.10"}, []string{"10.0.0.1/32", "192.168.1.10/32"}),
+		Entry("IPv6 addresses", []string{"fd00::1", "2001:db8::10"}, []string{"fd00::1/128", "2001:db8::10/128"}),
+		Entry("IPv4-mapped IPv6 address", []string{"::ffff:10.0.0.1"}, []string{"10.0.0.1/32"}),
+		Entry("Dual-stack addresses", []string{"10.0.0.1", "fd00::1"}, []string{"10.0.0.1/32", "fd00::1/128"}),
+	)
+
+	Context("with a dedicated network namespace", func() {
+		var ns netns.NsHandle
+
+		BeforeEach(func() {
+			ns = testutil.NewNetworkNamespace()
+			testutil.InNetworkNamespace(ns, func() {
+				setupLink("10.0.0.1/24", "fd00::1/64")
+			})
+		})
+
+		DescribeTable("FindLocalIP function",
+			func(ips []string, expected string) {
+				testutil.InNetworkNamespace(ns, func() {
+					ip, err := FindLocalIP(ips)
+					Expect(err).NotTo(HaveOccurred())
+					if expected == "" {
+						Expect(ip).To(BeNil())
+					} else {
+						Expect(ip.String()).To(Equal(expected))
+					}
+				})
+			},
+			Entry("No IPs", nil, ""),
+			Entry("Remote IP", []string{"10.0.0.2"}, ""),
+			Entry("Local IPv4", []string{"10.0.0.1"}, "10.0.0.1"),
+			Entry("Local IPv6", []string{"fd00::1"}, "fd00::1"),
+			Entry("First local IP", []string{"10.0.0.2", "fd00::1", "10.0.0.1"}, "fd00::1"),
+			Entry("Invalid IP", []string{"invalid"}, ""),
+		)
+
+		It("should reconcile the rules and the routes towards the endpoints", func() {
+			testutil.InNetworkNamespace(ns, func() {
+				By("Enforcing the routes towards the endpoints")
+				endpoints := []net.IP{net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.3"), net.ParseIP("fd00::2")}
+				Expect(EnsureRoutesPresence(linkName, tableID, genevePort, endpoints)).To(Succeed())
+				Expect(getRouteDsts()).To(ConsistOf("10.0.0.2/32", "10.0.0.3/32", "fd00::2/128"))
+
+				routes, err := listRoutes(tableID)
+				Expect(err).NotTo(HaveOccurred())
+				for i := range routes {
+					// The source is the one selected by the default routes.
+					if routes[i].Dst.IP.To4() != nil {
+						Expect(routes[i].Src.String()).To(Equal("10.0.0.1"))
+					} else {
+						Expect(routes[i].Src.String()).To(Equal("fd00::1"))
+					}
+				}
+
+				rules := getRules()
+				Expect(rules).To(HaveLen(2))
+				for i := range rules {
+					Expect(rules[i].IPProto).To(Equal(unix.IPPROTO_UDP))
+					Expect(rules[i].Dport).To(Equal(netlink.NewRulePortRange(genevePort, genevePort)))
+					Expect(rules[i].Priority).To(Equal(rulePriority))
+				}
+
+				By("Enforcing the routes again, to check that they are not duplicated")
+				Expect(EnsureRoutesPresence(linkName, tableID, genevePort, endpoints)).To(Succeed())
+				Expect(getRouteDsts()).To(HaveLen(3))
+				Expect(getRules()).To(HaveLen(2))
+
+				By("Removing an endpoint and changing the geneve port")
+				Expect(EnsureRoutesPresence(linkName, tableID, genevePort+1, endpoints[1:])).To(Succeed())
+				Expect(getRouteDsts()).To(ConsistOf("10.0.0.3/32", "fd00::2/128"))
+				rules = getRules()
+				Expect(rules).To(HaveLen(2))
+				for i := range rules {
+					Expect(rules[i].Dport).To(Equal(netlink.NewRulePortRange(genevePort+1, genevePort+1)))
+				}
+
+				By("Removing the routes and the rules")
+				Expect(EnsureRoutesAbsence(tableID)).To(Succeed())
+				Expect(getRouteDsts()).To(BeEmpty())
+				Expect(getRules()).To(BeEmpty())
+			})
+		})
+
+		It("should fail to enforce the routes if the interface does not exist", func() {
+			testutil.InNetworkNamespace(ns, func() {
+				Expect(EnsureRoutesPresence("missing", tableID, genevePort, []net.IP{net.ParseIP("10.0.0.2")})).To(HaveOccurred())
+			})
+		})
+
+		It("should succeed to remove an interface which does not exist", func() {
+			testutil.InNetworkNamespace(ns, func() {
+				Expect(EnsureWireGuardInterfaceAbsence("missing")).To(Succeed())
+			})
+		})
+	})
+})
@@ -24,9 +24,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	resourcehelper "k8s.io/component-helpers/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils"
 	"github.com/liqotech/liqo/pkg/utils/getters"
 	liqolabels "github.com/liqotech/liqo/pkg/utils/labels"
 )
@@ -111,3 +113,81 @@ func SumResourceSlices(resourceslices []authv1beta1.ResourceSlice) corev1.Resour
 	}
 	return tot
 }
+
+// SumNodesAllocatable sums the allocatable resources of the given nodes.
+// Virtual nodes, as well as nodes which are either unschedulable or not ready, are not taken into account.
+func SumNodesAllocatable(nodes []corev1.Node) corev1.ResourceList {
+	tot := corev1.ResourceList{}
+	for i := range nodes {
+		if !IsAllocatableNode(&nodes[i]) {
+			continue
+		}
+		AddResources(tot, nodes[i].Status.Allocatable)
+	}
+	return tot
+}
+
+// IsAllocatableNode returns whether the allocatable resources of the given node are taken into account by SumNodesAllocatable.
+func IsAllocatableNode(node *corev1.Node) bool {
+	return !utils.IsVirtualNode(node) && !node.Spec.Unschedulable && utils.IsNodeReady(node)
+}
+
+// SumPodsRequests sums the resources requested by the given pods, in addition to the pods themselves.
+// Pods not bound to any node, as well as pods which already terminated, are not taken into account.
+func SumPodsRequests(pods []corev1.Pod) corev1.ResourceList {
+	tot := corev1.ResourceList{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		AddResources(tot, PodQuotaUsage(pod))
+	}
+	return tot
+}
+
+// PodQuotaUsage returns the resources accounted to the given pod for quota purposes,
+// i.e., the resources it requests, in addition to the pod itself.
+func PodQuotaUsage(pod *corev1.Pod) corev1.ResourceList {
+	usage := resourcehelper.PodRequests(pod, resourcehelper.PodResourcesOptions{})
+	usage[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	return usage
+}
+
+// AddResources adds the quantities of the second resource list to the first one.
+func AddResources(dst, src corev1.ResourceList) {
+	for k, v := range src {
+		if r, ok := dst[k]; !ok {
+			dst[k] = v.DeepCopy()
+		} else {
+			r.Add(v)
+			dst[k] = r
+		}
+	}
+}
+
+// MaxResources sets each quantity of the first resource list to the maximum between it and the one of the second.
+func MaxResources(dst, src corev1.ResourceList) {
+	for k, v := range src {
+		if r, ok := dst[k]; !ok || v.Cmp(r) > 0 {
+			dst[k] = v.DeepCopy()
+		}
+	}
+}
+
+// SubResources returns a new resource list containing the quantities of the first resource list minus the
+// ones of the second. Only the resources of the first list are returned, and negative results are set to zero.
+func SubResources(a, b corev1.ResourceList) corev1.ResourceList {
+	res := corev1.ResourceList{}
+	for k, v := range a {
+		r := v.DeepCopy()
+		if s, ok := b[k]; ok {
+			r.Sub(s)
+		}
+		if r.Sign() < 0 {
+			r.Set(0)
+		}
+		res[k] = r
+	}
+	return res
+}
@@ -135,4 +135,117 @@ var _ = Describe("Shared Resources utility functions", func() {
 			Expect(resources.Others(res)).To(Equal(resources.Others(resExpected)))
 		})
 	})
+
+	When("Nodes allocatable resources are summed", func() {
+		var nodes []corev1.Node
+
+		BeforeEach(func() {
+			forgeNode := func(name string, ready, unschedulable, virtual bool) corev1.Node {
+				node := corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+					Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+					Status: corev1.NodeStatus{
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+						Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+					},
+				}
+				if ready {
+					node.Status.Conditions[0].Status = corev1.ConditionTrue
+				}
+				if virtual {
+					node.Labels[consts.TypeLabel] = consts.TypeNode
+				}
+				return node
+			}
+
+			nodes = []corev1.Node{
+				forgeNode("ready-1", true, false, false),
+				forgeNode("ready-2", true, false, false),
+				forgeNode("not-ready", false, false, false),
+				forgeNode("unschedulable", true, true, false),
+				forgeNode("virtual", true, false, true),
+			}
+		})
+
+		It("Should only consider the ready, schedulable and physical nodes", func() {
+			res := resources.SumNodesAllocatable(nodes)
+			Expect(res.Cpu().Equal(resource.MustParse("4"))).To(BeTrue())
+			Expect(res.Memory().Equal(resource.MustParse("8Gi"))).To(BeTrue())
+		})
+	})
+
+	When("Pods requests are summed", func() {
+		var pods []corev1.Pod
+
+		BeforeEach(func() {
+			forgePod := func(nodeName string, phase corev1.PodPhase) corev1.Pod {
+				return corev1.Pod{
+					Spec: corev1.PodSpec{
+						NodeName: nodeName,
+						Containers: []corev1.Container{{
+							Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							}},
+						}},
+					},
+					Status: corev1.PodStatus{Phase: phase},
+				}
+			}
+
+			pods = []corev1.Pod{
+				forgePod("node", corev1.PodRunning),
+				forgePod("node", corev1.PodPending),
+				forgePod("", corev1.PodPending),
+				forgePod("node", corev1.PodSucceeded),
+				forgePod("node", corev1.PodFailed),
+			}
+		})
+
+		It("Should only consider the bound and not terminated pods", func() {
+			res := resources.SumPodsRequests(pods)
+			Expect(res.Cpu().Equal(resource.MustParse("1"))).To(BeTrue())
+			Expect(res.Memory().Equal(resource.MustParse("2Gi"))).To(BeTrue())
+			Expect(res.Pods().Equal(resource.MustParse("2"))).To(BeTrue())
+		})
+	})
+
+	When("Resources are subtracted", func() {
+		It("Should return the difference, floored to zero", func() {
+			res := resources.SubResources(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+				corev1.ResourcePods:   resource.MustParse("10"),
+			}, corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+				"other":               resource.MustParse("1"),
+			})
+			Expect(res).To(HaveLen(3))
+			Expect(res.Cpu().Equal(resource.MustParse("1500m"))).To(BeTrue())
+			Expect(res.Memory().IsZero()).To(BeTrue())
+			Expect(res.Pods().Equal(resource.MustParse("10"))).To(BeTrue())
+		})
+	})
+
+	When("The maximum of two resource lists is computed", func() {
+		It("Should keep the greater quantity of each resource", func() {
+			res := corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}
+			resources.MaxResources(res, corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+				corev1.ResourcePods:   resource.MustParse("3"),
+			})
+			Expect(res).To(HaveLen(3))
+			Expect(res.Cpu().Equal(resource.MustParse("2"))).To(BeTrue())
+			Expect(res.Memory().Equal(resource.MustParse("2Gi"))).To(BeTrue())
+			Expect(res.Pods().Equal(resource.MustParse("3"))).To(BeTrue())
+		})
+	})
 })
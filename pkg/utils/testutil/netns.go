@@ -0,0 +1,64 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package testutil
+
+import (
+	"runtime"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/vishvananda/netns"
+)
+
+// NewNetworkNamespace creates a new network namespace, which is closed at the end of the test,
+// without moving the calling goroutine into it. The test is skipped if the namespace cannot be created,
+// since it requires the NET_ADMIN capability.
+func NewNetworkNamespace() netns.NsHandle {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	defer origin.Close()
+
+	ns, err := netns.New()
+	if err != nil {
+		ginkgo.Skip("unable to create a network namespace (is the NET_ADMIN capability missing?): " + err.Error())
+	}
+	ginkgo.DeferCleanup(ns.Close)
+
+	gomega.Expect(netns.Set(origin)).To(gomega.Succeed())
+	return ns
+}
+
+// InNetworkNamespace runs the given function in the given network namespace, restoring the original one afterwards.
+func InNetworkNamespace(ns netns.NsHandle, f func()) {
+	runtime.LockOSThread()
+
+	origin, err := netns.Get()
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	defer origin.Close()
+
+	gomega.Expect(netns.Set(ns)).To(gomega.Succeed())
+	defer func() {
+		// The thread is left locked if the original namespace cannot be restored, so that it is discarded.
+		gomega.Expect(netns.Set(origin)).To(gomega.Succeed())
+		runtime.UnlockOSThread()
+	}()
+
+	f()
+}
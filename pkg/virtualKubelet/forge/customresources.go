@@ -0,0 +1,73 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	customResourceMetadataField = "metadata"
+	customResourceStatusField   = "status"
+)
+
+// RemoteCustomResource forges the reflected custom resource, given the local one.
+// The local metadata (except for labels and annotations) and status are not reflected,
+// as well as the fields (expressed as dot-separated paths) listed in prunedFields.
+func RemoteCustomResource(local *unstructured.Unstructured, targetNamespace string,
+	prunedFields []string, forgingOpts *ForgingOpts) *unstructured.Unstructured {
+	remote := &unstructured.Unstructured{Object: make(map[string]interface{}, len(local.Object))}
+	for key, value := range local.Object {
+		if key == customResourceMetadataField || key == customResourceStatusField {
+			continue
+		}
+		remote.Object[key] = runtime.DeepCopyJSONValue(value)
+	}
+
+	remote.SetName(local.GetName())
+	remote.SetNamespace(targetNamespace)
+	remote.SetLabels(labels.Merge(FilterNotReflected(local.GetLabels(), forgingOpts.LabelsNotReflected), ReflectionLabels()))
+	remote.SetAnnotations(FilterNotReflected(local.GetAnnotations(), forgingOpts.AnnotationsNotReflected))
+
+	for _, field := range prunedFields {
+		unstructured.RemoveNestedField(remote.Object, strings.Split(field, ".")...)
+	}
+
+	return remote
+}
+
+// LocalCustomResourceStatus forges the local custom resource with the status of the remote one.
+// It returns nil in case the local status is already up-to-date.
+func LocalCustomResourceStatus(local, remote *unstructured.Unstructured) *unstructured.Unstructured {
+	remoteStatus, remoteFound := remote.Object[customResourceStatusField]
+	localStatus, localFound := local.Object[customResourceStatusField]
+	if remoteFound == localFound && equality.Semantic.DeepEqual(remoteStatus, localStatus) {
+		return nil
+	}
+
+	updated := local.DeepCopy()
+	if !remoteFound {
+		delete(updated.Object, customResourceStatusField)
+		return updated
+	}
+
+	updated.Object[customResourceStatusField] = runtime.DeepCopyJSONValue(remoteStatus)
+	return updated
+}
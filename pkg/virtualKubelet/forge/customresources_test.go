@@ -0,0 +1,135 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+var _ = Describe("Custom resources forging", func() {
+	var local *unstructured.Unstructured
+
+	BeforeEach(func() {
+		local = &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]interface{}{
+				"name": "name", "namespace": "original", "uid": "uid", "resourceVersion": "10",
+				"labels":      map[string]interface{}{"foo": "bar", testutil.FakeNotReflectedLabelKey: "true"},
+				"annotations": map[string]interface{}{"bar": "baz", testutil.FakeNotReflectedAnnotKey: "true"},
+			},
+			"spec": map[string]interface{}{
+				"secretName": "secret",
+				"dnsNames":   []interface{}{"foo.example.com"},
+				"issuerRef":  map[string]interface{}{"name": "issuer", "kind": "ClusterIssuer"},
+			},
+			"status": map[string]interface{}{"revision": int64(1)},
+		}}
+	})
+
+	Describe("the RemoteCustomResource function", func() {
+		var (
+			prunedFields []string
+			output       *unstructured.Unstructured
+		)
+
+		BeforeEach(func() { prunedFields = []string{"spec.issuerRef.kind", "spec.notExisting"} })
+		JustBeforeEach(func() {
+			output = forge.RemoteCustomResource(local, "reflected", prunedFields, testutil.FakeForgingOpts())
+		})
+
+		It("should correctly set the type information", func() {
+			Expect(output.GetAPIVersion()).To(Equal("cert-manager.io/v1"))
+			Expect(output.GetKind()).To(Equal("Certificate"))
+		})
+
+		It("should correctly set the metadata", func() {
+			Expect(output.GetName()).To(Equal("name"))
+			Expect(output.GetNamespace()).To(Equal("reflected"))
+			Expect(output.GetUID()).To(BeEmpty())
+			Expect(output.GetResourceVersion()).To(BeEmpty())
+		})
+
+		It("should correctly set the labels", func() {
+			Expect(output.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+			Expect(output.GetLabels()).To(HaveKeyWithValue(forge.LiqoOriginClusterIDKey, string(LocalClusterID)))
+			Expect(output.GetLabels()).To(HaveKeyWithValue(forge.LiqoDestinationClusterIDKey, string(RemoteClusterID)))
+			Expect(output.GetLabels()).ToNot(HaveKey(testutil.FakeNotReflectedLabelKey))
+		})
+
+		It("should correctly set the annotations", func() {
+			Expect(output.GetAnnotations()).To(HaveKeyWithValue("bar", "baz"))
+			Expect(output.GetAnnotations()).ToNot(HaveKey(testutil.FakeNotReflectedAnnotKey))
+		})
+
+		It("should reflect the spec, except for the pruned fields", func() {
+			Expect(output.Object).To(HaveKeyWithValue("spec", map[string]interface{}{
+				"secretName": "secret",
+				"dnsNames":   []interface{}{"foo.example.com"},
+				"issuerRef":  map[string]interface{}{"name": "issuer"},
+			}))
+		})
+
+		It("should not reflect the status", func() {
+			Expect(output.Object).ToNot(HaveKey("status"))
+		})
+
+		It("should not mutate the local object", func() {
+			Expect(local.Object).To(HaveKeyWithValue("spec", HaveKeyWithValue("issuerRef", HaveKey("kind"))))
+		})
+	})
+
+	Describe("the LocalCustomResourceStatus function", func() {
+		var (
+			remote *unstructured.Unstructured
+			output *unstructured.Unstructured
+		)
+
+		BeforeEach(func() { remote = local.DeepCopy() })
+		JustBeforeEach(func() { output = forge.LocalCustomResourceStatus(local, remote) })
+
+		When("the status is already up-to-date", func() {
+			It("should return nil", func() { Expect(output).To(BeNil()) })
+		})
+
+		When("the status differs", func() {
+			BeforeEach(func() { remote.Object["status"] = map[string]interface{}{"revision": int64(2)} })
+
+			It("should return the local object with the remote status", func() {
+				Expect(output).ToNot(BeNil())
+				Expect(output.GetName()).To(Equal("name"))
+				Expect(output.GetNamespace()).To(Equal("original"))
+				Expect(output.Object).To(HaveKeyWithValue("status", map[string]interface{}{"revision": int64(2)}))
+			})
+			It("should not mutate the local object", func() {
+				Expect(local.Object).To(HaveKeyWithValue("status", map[string]interface{}{"revision": int64(1)}))
+			})
+		})
+
+		When("the remote status is not present", func() {
+			BeforeEach(func() { delete(remote.Object, "status") })
+
+			It("should return the local object without status", func() {
+				Expect(output).ToNot(BeNil())
+				Expect(output.Object).ToNot(HaveKey("status"))
+			})
+		})
+	})
+})
@@ -17,6 +17,7 @@ package forge
 import (
 	"fmt"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
 )
@@ -39,6 +40,9 @@ const (
 
 	// EventFailedSATokensReflection -> the reason for the event when the reflection of service account tokens fails.
 	EventFailedSATokensReflection = "FailedSATokensReflection"
+
+	// EventStatusReflectionConflict -> the reason for the event when the status is already reflected from a different cluster.
+	EventStatusReflectionConflict = "StatusReflectionConflict"
 )
 
 // EventSuccessfulReflectionMsg returns the message for the event when the outgoing reflection completes successfully.
@@ -61,6 +65,12 @@ func EventFailedStatusReflectionMsg(err error) string {
 	return fmt.Sprintf("Error reflecting object status back from cluster %q: %v", RemoteCluster, err)
 }
 
+// EventStatusReflectionConflictMsg returns the message for the event when the incoming reflection
+// has been aborted because the status is already reflected from a different cluster.
+func EventStatusReflectionConflictMsg(owner liqov1beta1.ClusterID) string {
+	return fmt.Sprintf("Skipped reflecting object status back from cluster %q: already reflected from cluster %q", RemoteCluster, owner)
+}
+
 // EventFailedReflectionAlreadyExistsMsg returns the message for the event when the reflection
 // has been aborted because the remote object already exists.
 func EventFailedReflectionAlreadyExistsMsg() string {
@@ -99,6 +109,12 @@ func EventObjectReflectionDisabledMsg(reflectionType offloadingv1beta1.Reflectio
 	return fmt.Sprintf("Reflection to cluster %q disabled for the current object (policy: %q)", RemoteCluster, reflectionType)
 }
 
+// EventObjectReflectionPolicyMismatchMsg returns the message for the event when reflection is disabled for a given resource,
+// as not matching the reflection policy.
+func EventObjectReflectionPolicyMismatchMsg() string {
+	return fmt.Sprintf("Reflection to cluster %q disabled for the current object, as not matching the reflection policy", RemoteCluster)
+}
+
 // EventSAReflectionDisabledMsg returns the message for the event when service account reflection is disabled.
 func EventSAReflectionDisabledMsg() string {
 	return fmt.Sprintf("Reflection to cluster %q disabled for secrets holding service account tokens", RemoteCluster)
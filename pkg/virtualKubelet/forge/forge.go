@@ -16,6 +16,7 @@ package forge
 
 import (
 	"os"
+	"slices"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -100,3 +101,12 @@ func NewForgingOpts(offloadingPatch *offloadingv1beta1.OffloadingPatch) ForgingO
 func NewEmptyForgingOpts() ForgingOpts {
 	return ForgingOpts{}
 }
+
+// RemoteClassName returns the name of the class the reflected object is assigned to in the remote cluster,
+// that is the local one if offered by the remote cluster, and the default one otherwise.
+func RemoteClassName(local *string, offered []string, defaultClass string) string {
+	if local != nil && slices.Contains(offered, *local) {
+		return *local
+	}
+	return defaultClass
+}
@@ -16,8 +16,10 @@ package forge
 
 import (
 	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	netv1apply "k8s.io/client-go/applyconfigurations/networking/v1"
 
+	liqoconst "github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/utils/maps"
 )
 
@@ -32,7 +34,19 @@ func RemoteIngress(local *netv1.Ingress, targetNamespace string, enableIngress b
 
 // FilterIngressAnnotations filters the ingress annotations to be reflected, removing the ingress class annotation.
 func FilterIngressAnnotations(local map[string]string) map[string]string {
-	return maps.Filter(local, maps.FilterBlacklist("kubernetes.io/ingress.class"))
+	return maps.Filter(local, maps.FilterBlacklist("kubernetes.io/ingress.class", liqoconst.StatusReflectedFromAnnotationKey))
+}
+
+// LocalIngressStatus forges the local ingress with the load balancer status reflected from the remote one.
+// It returns nil in case the local status is already aligned, and no update is required.
+func LocalIngressStatus(local, remote *netv1.Ingress) *netv1.Ingress {
+	if equality.Semantic.DeepEqual(local.Status.LoadBalancer, remote.Status.LoadBalancer) {
+		return nil
+	}
+
+	mutated := local.DeepCopy()
+	mutated.Status.LoadBalancer = *remote.Status.LoadBalancer.DeepCopy()
+	return mutated
 }
 
 // RemoteIngressSpec forges the apply patch for the specs of the reflected ingress, given the local one.
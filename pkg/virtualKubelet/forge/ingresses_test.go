@@ -23,6 +23,7 @@ import (
 	netv1apply "k8s.io/client-go/applyconfigurations/networking/v1"
 	"k8s.io/utils/pointer"
 
+	liqoconst "github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/utils/testutil"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 )
@@ -85,7 +86,7 @@ var _ = Describe("Ingresses Forging", func() {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "name", Namespace: "original",
 					Labels:      map[string]string{"foo": "bar"},
-					Annotations: map[string]string{"bar": "baz", "kubernetes.io/ingress.class": "nginx"},
+					Annotations: map[string]string{"bar": "baz", "kubernetes.io/ingress.class": "nginx", liqoconst.StatusReflectedFromAnnotationKey: "cl"},
 				},
 			}
 			forgingOpts = testutil.FakeForgingOpts()
@@ -109,6 +110,7 @@ var _ = Describe("Ingresses Forging", func() {
 			Expect(output.Annotations).To(HaveKeyWithValue("bar", "baz"))
 			Expect(output.Annotations).ToNot(HaveKey("kubernetes.io/ingress.class"))
 			Expect(output.Annotations).ToNot(HaveKey(testutil.FakeNotReflectedAnnotKey))
+			Expect(output.Annotations).ToNot(HaveKey(liqoconst.StatusReflectedFromAnnotationKey))
 		})
 		It("should correctly set the spec", func() {
 			Expect(output.Spec.DefaultBackend).To(PointTo(Equal(netv1apply.IngressBackendApplyConfiguration{
@@ -302,4 +304,32 @@ var _ = Describe("Ingresses Forging", func() {
 			Expect(output[0].SecretName).To(PointTo(Equal("example-secret")))
 		})
 	})
+
+	Describe("the LocalIngressStatus function", func() {
+		var local, remote, output *netv1.Ingress
+
+		BeforeEach(func() {
+			local = &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "local"}}
+			remote = &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "remote"}}
+			remote.Status.LoadBalancer.Ingress = []netv1.IngressLoadBalancerIngress{{IP: "1.1.1.1"}, {Hostname: "foo.example.com"}}
+		})
+
+		JustBeforeEach(func() { output = forge.LocalIngressStatus(local, remote) })
+
+		When("the local status is not aligned", func() {
+			It("should return a copy of the local object", func() {
+				Expect(output).ToNot(BeIdenticalTo(local))
+				Expect(output.GetNamespace()).To(Equal("local"))
+			})
+			It("should correctly set the load balancer status", func() {
+				Expect(output.Status.LoadBalancer).To(Equal(remote.Status.LoadBalancer))
+			})
+			It("should not mutate the local object", func() { Expect(local.Status.LoadBalancer.Ingress).To(BeEmpty()) })
+		})
+
+		When("the local status is already aligned", func() {
+			BeforeEach(func() { local.Status.LoadBalancer = *remote.Status.LoadBalancer.DeepCopy() })
+			It("should return nil", func() { Expect(output).To(BeNil()) })
+		})
+	})
 })
@@ -0,0 +1,71 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/resources"
+)
+
+// OffloadedNamespaceQuotaKind is the kind of the OffloadedNamespaceQuota resource.
+const OffloadedNamespaceQuotaKind = "OffloadedNamespaceQuota"
+
+// RemoteOffloadedNamespaceQuota forges the reflected OffloadedNamespaceQuota, given the local one.
+func RemoteOffloadedNamespaceQuota(local *offloadingv1beta1.OffloadedNamespaceQuota, targetNamespace string,
+	forgingOpts *ForgingOpts) *offloadingv1beta1.OffloadedNamespaceQuota {
+	return &offloadingv1beta1.OffloadedNamespaceQuota{
+		TypeMeta: metav1.TypeMeta{APIVersion: offloadingv1beta1.SchemeGroupVersion.String(), Kind: OffloadedNamespaceQuotaKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        local.GetName(),
+			Namespace:   targetNamespace,
+			Labels:      labels.Merge(FilterNotReflected(local.GetLabels(), forgingOpts.LabelsNotReflected), ReflectionLabels()),
+			Annotations: FilterNotReflected(local.GetAnnotations(), forgingOpts.AnnotationsNotReflected),
+		},
+		Spec: *local.Spec.DeepCopy(),
+	}
+}
+
+// RemoteOffloadedNamespaceQuotaStatus forges the status of the reflected OffloadedNamespaceQuota, given the local one.
+// The usage of the remote cluster is left out, as directly observed by the remote cluster upon admission:
+// hence, the resulting Used field accounts only for the resources consumed in all the other clusters.
+func RemoteOffloadedNamespaceQuotaStatus(local *offloadingv1beta1.OffloadedNamespaceQuota,
+	targetNamespace string) *offloadingv1beta1.OffloadedNamespaceQuota {
+	remote := &offloadingv1beta1.OffloadedNamespaceQuota{
+		TypeMeta:   metav1.TypeMeta{APIVersion: offloadingv1beta1.SchemeGroupVersion.String(), Kind: OffloadedNamespaceQuotaKind},
+		ObjectMeta: metav1.ObjectMeta{Name: local.GetName(), Namespace: targetNamespace},
+		Status: offloadingv1beta1.OffloadedNamespaceQuotaStatus{
+			Exceeded:       local.Status.Exceeded,
+			LastUpdateTime: local.Status.LastUpdateTime.DeepCopy(),
+		},
+	}
+
+	for i := range local.Status.Clusters {
+		if local.Status.Clusters[i].ClusterID == RemoteCluster {
+			continue
+		}
+		usage := local.Status.Clusters[i].DeepCopy()
+		remote.Status.Clusters = append(remote.Status.Clusters, *usage)
+		if remote.Status.Used == nil {
+			remote.Status.Used = usage.Used.DeepCopy()
+			continue
+		}
+		resources.AddResources(remote.Status.Used, usage.Used)
+	}
+
+	return remote
+}
@@ -0,0 +1,116 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+var _ = Describe("OffloadedNamespaceQuotas forging", func() {
+	var local *offloadingv1beta1.OffloadedNamespaceQuota
+
+	BeforeEach(func() {
+		local = &offloadingv1beta1.OffloadedNamespaceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "name", Namespace: "original", UID: "uid", ResourceVersion: "10",
+				Labels:      map[string]string{"foo": "bar", testutil.FakeNotReflectedLabelKey: "true"},
+				Annotations: map[string]string{"bar": "baz", testutil.FakeNotReflectedAnnotKey: "true"},
+			},
+			Spec: offloadingv1beta1.OffloadedNamespaceQuotaSpec{
+				Hard: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")},
+			},
+			Status: offloadingv1beta1.OffloadedNamespaceQuotaStatus{
+				Used: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("7")},
+				Clusters: []offloadingv1beta1.ClusterQuotaUsage{
+					{ClusterID: LocalClusterID, Used: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+					{ClusterID: RemoteClusterID, Used: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}},
+					{ClusterID: "other", Used: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}},
+				},
+				Exceeded: true,
+			},
+		}
+	})
+
+	Describe("the RemoteOffloadedNamespaceQuota function", func() {
+		var output *offloadingv1beta1.OffloadedNamespaceQuota
+
+		JustBeforeEach(func() {
+			output = forge.RemoteOffloadedNamespaceQuota(local, "reflected", testutil.FakeForgingOpts())
+		})
+
+		It("should correctly set the type information", func() {
+			Expect(output.APIVersion).To(Equal(offloadingv1beta1.SchemeGroupVersion.String()))
+			Expect(output.Kind).To(Equal(forge.OffloadedNamespaceQuotaKind))
+		})
+
+		It("should correctly set the metadata", func() {
+			Expect(output.Name).To(Equal("name"))
+			Expect(output.Namespace).To(Equal("reflected"))
+			Expect(output.UID).To(BeEmpty())
+			Expect(output.ResourceVersion).To(BeEmpty())
+			Expect(output.Labels).To(HaveKeyWithValue("foo", "bar"))
+			Expect(output.Labels).To(HaveKeyWithValue(forge.LiqoOriginClusterIDKey, string(LocalClusterID)))
+			Expect(output.Labels).To(HaveKeyWithValue(forge.LiqoDestinationClusterIDKey, string(RemoteClusterID)))
+			Expect(output.Labels).ToNot(HaveKey(testutil.FakeNotReflectedLabelKey))
+			Expect(output.Annotations).To(HaveKeyWithValue("bar", "baz"))
+			Expect(output.Annotations).ToNot(HaveKey(testutil.FakeNotReflectedAnnotKey))
+		})
+
+		It("should correctly set the spec", func() {
+			Expect(output.Spec).To(Equal(local.Spec))
+		})
+
+		It("should not set the status", func() {
+			Expect(output.Status).To(Equal(offloadingv1beta1.OffloadedNamespaceQuotaStatus{}))
+		})
+	})
+
+	Describe("the RemoteOffloadedNamespaceQuotaStatus function", func() {
+		var output *offloadingv1beta1.OffloadedNamespaceQuota
+
+		JustBeforeEach(func() {
+			output = forge.RemoteOffloadedNamespaceQuotaStatus(local, "reflected")
+		})
+
+		It("should correctly set the metadata", func() {
+			Expect(output.Name).To(Equal("name"))
+			Expect(output.Namespace).To(Equal("reflected"))
+			Expect(output.Kind).To(Equal(forge.OffloadedNamespaceQuotaKind))
+		})
+
+		It("should leave out the usage of the remote cluster", func() {
+			Expect(output.Status.Clusters).To(HaveLen(2))
+			Expect(output.Status.ClusterUsage(RemoteClusterID)).To(BeNil())
+			Expect(output.Status.Used.Cpu().Cmp(resource.MustParse("5"))).To(BeZero())
+		})
+
+		It("should preserve the exceeded flag", func() {
+			Expect(output.Status.Exceeded).To(BeTrue())
+		})
+
+		It("should not mutate the local object", func() {
+			Expect(local.Status.Used.Cpu().Cmp(resource.MustParse("7"))).To(BeZero())
+			Expect(local.Status.Clusters[0].Used.Cpu().Cmp(resource.MustParse("1"))).To(BeZero())
+		})
+	})
+})
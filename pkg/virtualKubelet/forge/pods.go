@@ -38,6 +38,8 @@ const (
 	PodOffloadingBackOffReason = "OffloadingBackOff"
 	// PodOffloadingAbortedReason -> the reason assigned to pods rejected by the virtual kubelet after offloading has started.
 	PodOffloadingAbortedReason = "OffloadingAborted"
+	// PodOffloadingDeniedReason -> the reason assigned to pods whose offloading has been denied by the remote cluster.
+	PodOffloadingDeniedReason = "OffloadingDenied"
 
 	// ServiceAccountVolumeName is the prefix name that will be added to volumes that mount ServiceAccount secrets.
 	// This constant is taken from kubernetes/kubernetes (plugin/pkg/admission/serviceaccount/admission.go).
@@ -148,6 +150,14 @@ func LocalRejectedPodStatus(local *corev1.PodStatus, phase corev1.PodPhase, reas
 	return *local
 }
 
+// LocalDeniedPod forges the status of a local pod whose offloading has been denied by the remote cluster (e.g., because
+// violating its admission policies). The pod is kept pending, and the denial reason is exposed in the status message.
+func LocalDeniedPod(local *corev1.Pod, message string) *corev1.Pod {
+	pod := LocalRejectedPod(local, corev1.PodPending, PodOffloadingDeniedReason)
+	pod.Status.Message = message
+	return pod
+}
+
 // RemoteShadowPod forges the reflected shadowpod, given the local one.
 func RemoteShadowPod(local *corev1.Pod, remote *offloadingv1beta1.ShadowPod,
 	targetNamespace string, forgingOpts *ForgingOpts, mutators ...RemotePodSpecMutator) *offloadingv1beta1.ShadowPod {
@@ -139,6 +139,37 @@ var _ = Describe("Pod forging", func() {
 		It("should preserve the other status fields", func() { Expect(output.Status.PodIP).To(Equal(local.Status.PodIP)) })
 	})
 
+	Describe("the LocalDeniedPod function", func() {
+		var local, original, output *corev1.Pod
+
+		BeforeEach(func() {
+			local = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "local-name", Namespace: "local-namespace"},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			original = local.DeepCopy()
+			output = forge.LocalDeniedPod(local, "denied by TenantPolicy \"foo\"")
+		})
+
+		It("should correctly propagate the local object meta", func() { Expect(output.ObjectMeta).To(Equal(local.ObjectMeta)) })
+		It("should not mutate the input object", func() { Expect(local).To(Equal(original)) })
+		It("should correctly set the pending phase, reason and message", func() {
+			Expect(output.Status.Phase).To(Equal(corev1.PodPending))
+			Expect(output.Status.Reason).To(Equal(forge.PodOffloadingDeniedReason))
+			Expect(output.Status.Message).To(Equal("denied by TenantPolicy \"foo\""))
+		})
+		It("should correctly mutate the pod conditions", func() {
+			Expect(output.Status.Conditions).To(HaveLen(1))
+			Expect(output.Status.Conditions[0].Status).To(Equal(corev1.ConditionFalse))
+			Expect(output.Status.Conditions[0].Reason).To(Equal(forge.PodOffloadingDeniedReason))
+		})
+	})
+
 	Describe("the RemoteShadowPod function", func() {
 		var (
 			local          *corev1.Pod
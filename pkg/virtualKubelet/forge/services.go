@@ -16,10 +16,12 @@ package forge
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
 	"k8s.io/utils/pointer"
 
 	liqoconst "github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/maps"
 )
 
 // nodePortUnset -> the value representing an unset NodePort.
@@ -30,10 +32,27 @@ func RemoteService(local *corev1.Service, targetNamespace string, enableLoadBala
 	forgingOpts *ForgingOpts) *corev1apply.ServiceApplyConfiguration {
 	return corev1apply.Service(local.GetName(), targetNamespace).
 		WithLabels(FilterNotReflected(local.GetLabels(), forgingOpts.LabelsNotReflected)).WithLabels(ReflectionLabels()).
-		WithAnnotations(FilterNotReflected(local.GetAnnotations(), forgingOpts.AnnotationsNotReflected)).
+		WithAnnotations(FilterNotReflected(FilterStatusReflectionAnnotations(local.GetAnnotations()), forgingOpts.AnnotationsNotReflected)).
 		WithSpec(RemoteServiceSpec(local.Spec.DeepCopy(), getForceRemoteNodePort(local), enableLoadBalancer, remoteRealLoadBalancerClassName))
 }
 
+// FilterStatusReflectionAnnotations filters the annotations to be reflected, removing the one tracking the status reflection origin.
+func FilterStatusReflectionAnnotations(local map[string]string) map[string]string {
+	return maps.Filter(local, maps.FilterBlacklist(liqoconst.StatusReflectedFromAnnotationKey))
+}
+
+// LocalServiceStatus forges the local service with the load balancer status reflected from the remote one.
+// It returns nil in case the local status is already aligned, and no update is required.
+func LocalServiceStatus(local, remote *corev1.Service) *corev1.Service {
+	if equality.Semantic.DeepEqual(local.Status.LoadBalancer, remote.Status.LoadBalancer) {
+		return nil
+	}
+
+	mutated := local.DeepCopy()
+	mutated.Status.LoadBalancer = *remote.Status.LoadBalancer.DeepCopy()
+	return mutated
+}
+
 // RemoteServiceSpec forges the apply patch for the specs of the reflected service, given the local ones.
 // It expects the local object to be a deepcopy, as it is mutated.
 func RemoteServiceSpec(local *corev1.ServiceSpec, forceRemoteNodePort,
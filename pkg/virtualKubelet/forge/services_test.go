@@ -24,6 +24,7 @@ import (
 	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
 	"k8s.io/utils/pointer"
 
+	liqoconst "github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/utils/testutil"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 )
@@ -41,7 +42,7 @@ var _ = Describe("Services Forging", func() {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "name", Namespace: "original",
 					Labels:      map[string]string{"foo": "bar", testutil.FakeNotReflectedLabelKey: "true"},
-					Annotations: map[string]string{"bar": "baz", testutil.FakeNotReflectedAnnotKey: "true"},
+					Annotations: map[string]string{"bar": "baz", testutil.FakeNotReflectedAnnotKey: "true", liqoconst.StatusReflectedFromAnnotationKey: "cl"},
 				},
 				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort},
 			}
@@ -64,6 +65,7 @@ var _ = Describe("Services Forging", func() {
 			It("should correctly set the annotations", func() {
 				Expect(output.Annotations).To(HaveKeyWithValue("bar", "baz"))
 				Expect(output.Annotations).ToNot(HaveKey(testutil.FakeNotReflectedAnnotKey))
+				Expect(output.Annotations).ToNot(HaveKey(liqoconst.StatusReflectedFromAnnotationKey))
 			})
 			It("should correctly set the spec", func() {
 				Expect(output.Spec.Type).To(PointTo(Equal(corev1.ServiceTypeNodePort)))
@@ -196,4 +198,53 @@ var _ = Describe("Services Forging", func() {
 			It("should be replicated", func() { Expect(output[0].NodePort).To(PointTo(BeNumerically("==", 33333))) })
 		})
 	})
+
+	Describe("the LocalServiceStatus function", func() {
+		var local, remote, output *corev1.Service
+
+		BeforeEach(func() {
+			local = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "local"}}
+			remote = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "remote"}}
+			remote.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "1.1.1.1"}, {Hostname: "foo.example.com"}}
+		})
+
+		JustBeforeEach(func() { output = forge.LocalServiceStatus(local, remote) })
+
+		When("the local status is not aligned", func() {
+			It("should return a copy of the local object", func() {
+				Expect(output).ToNot(BeIdenticalTo(local))
+				Expect(output.GetNamespace()).To(Equal("local"))
+			})
+			It("should correctly set the load balancer status", func() {
+				Expect(output.Status.LoadBalancer).To(Equal(remote.Status.LoadBalancer))
+			})
+			It("should not mutate the local object", func() { Expect(local.Status.LoadBalancer.Ingress).To(BeEmpty()) })
+		})
+
+		When("the local status is already aligned", func() {
+			BeforeEach(func() { local.Status.LoadBalancer = *remote.Status.LoadBalancer.DeepCopy() })
+			It("should return nil", func() { Expect(output).To(BeNil()) })
+		})
+
+		When("the remote status is empty", func() {
+			BeforeEach(func() {
+				local.Status.LoadBalancer = *remote.Status.LoadBalancer.DeepCopy()
+				remote.Status.LoadBalancer = corev1.LoadBalancerStatus{}
+			})
+			It("should clear the local status", func() { Expect(output.Status.LoadBalancer.Ingress).To(BeEmpty()) })
+		})
+	})
+
+	Describe("the RemoteClassName function", func() {
+		offered := []string{"default", "other"}
+
+		DescribeTable("RemoteClassName table",
+			func(local *string, expected string) {
+				Expect(forge.RemoteClassName(local, offered, "default")).To(Equal(expected))
+			},
+			Entry("when the local class is not set", nil, "default"),
+			Entry("when the local class is offered", pointer.String("other"), "other"),
+			Entry("when the local class is not offered", pointer.String("missing"), "default"),
+		)
+	})
 })
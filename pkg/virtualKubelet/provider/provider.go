@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -37,10 +38,12 @@ import (
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/configuration"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/customresource"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/event"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/exposition"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/namespacemap"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/quota"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/resources"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/storage"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/workload"
@@ -65,17 +68,22 @@ type InitConfig struct {
 	LocalPodCIDR         string
 	InformerResyncPeriod time.Duration
 
-	ReflectorsConfigs map[resources.ResourceReflected]offloadingv1beta1.ReflectorConfig
-
-	EnableAPIServerSupport          bool
-	EnableStorage                   bool
-	VirtualStorageClassName         string
-	RemoteRealStorageClassName      string
-	EnableIngress                   bool
-	RemoteRealIngressClassName      string
-	EnableLoadBalancer              bool
-	RemoteRealLoadBalancerClassName string
-	EnableMetrics                   bool
+	ReflectorsConfigs               map[resources.ResourceReflected]offloadingv1beta1.ReflectorConfig
+	CustomResourceReflectorsConfigs []offloadingv1beta1.CustomResourceReflectorConfig
+
+	EnableAPIServerSupport           bool
+	EnableStorage                    bool
+	VirtualStorageClassName          string
+	RemoteRealStorageClassName       string
+	EnableIngress                    bool
+	RemoteRealIngressClassName       string
+	IngressClasses                   []string
+	ReflectIngressStatusClasses      []string
+	EnableLoadBalancer               bool
+	RemoteRealLoadBalancerClassName  string
+	LoadBalancerClasses              []string
+	ReflectLoadBalancerStatusClasses []string
+	EnableMetrics                    bool
 
 	HomeAPIServerHost string
 	HomeAPIServerPort string
@@ -96,9 +104,11 @@ func NewLiqoProvider(ctx context.Context, cfg *InitConfig, eb record.EventBroadc
 	forge.Init(cfg.LocalCluster, cfg.RemoteCluster, cfg.NodeName, cfg.NodeIP)
 	localClient := kubernetes.NewForConfigOrDie(cfg.LocalConfig)
 	localLiqoClient := liqoclient.NewForConfigOrDie(cfg.LocalConfig)
+	localDynClient := dynamic.NewForConfigOrDie(cfg.LocalConfig)
 
 	remoteClient := kubernetes.NewForConfigOrDie(cfg.RemoteConfig)
 	remoteLiqoClient := liqoclient.NewForConfigOrDie(cfg.RemoteConfig)
+	remoteDynClient := dynamic.NewForConfigOrDie(cfg.RemoteConfig)
 	remoteMetricsClient := metrics.NewForConfigOrDie(cfg.RemoteConfig).MetricsV1beta1().PodMetricses
 
 	apiServerSupport := forge.APIServerSupportDisabled
@@ -137,23 +147,64 @@ func NewLiqoProvider(ctx context.Context, cfg *InitConfig, eb record.EventBroadc
 
 	podreflector := workload.NewPodReflector(cfg.RemoteConfig, remoteMetricsClient, &podReflectorConfig, ptr.To(cfg.ReflectorsConfigs[resources.Pod]))
 
+	// The reflectors supporting the reflection policies, which might be invalid.
+	serviceReflector, err := exposition.NewServiceReflector(ptr.To(cfg.ReflectorsConfigs[resources.Service]),
+		cfg.EnableLoadBalancer, cfg.RemoteRealLoadBalancerClassName, cfg.LoadBalancerClasses, cfg.ReflectLoadBalancerStatusClasses)
+	if err != nil {
+		return nil, err
+	}
+	configMapReflector, err := configuration.NewConfigMapReflector(ptr.To(cfg.ReflectorsConfigs[resources.ConfigMap]))
+	if err != nil {
+		return nil, err
+	}
+	secretReflector, err := configuration.NewSecretReflector(apiServerSupport == forge.APIServerSupportLegacy,
+		ptr.To(cfg.ReflectorsConfigs[resources.Secret]))
+	if err != nil {
+		return nil, err
+	}
+
 	forgingOpts := forge.NewForgingOpts(cfg.OffloadingPatch)
 
-	reflectionManager := manager.New(localClient, remoteClient, localLiqoClient, remoteLiqoClient, cfg.InformerResyncPeriod, eb, &forgingOpts).
+	reflectionManager := manager.New(localClient, remoteClient, localLiqoClient, remoteLiqoClient, localDynClient, remoteDynClient,
+		cfg.InformerResyncPeriod, eb, &forgingOpts).
 		With(podreflector).
-		With(exposition.NewServiceReflector(ptr.To(cfg.ReflectorsConfigs[resources.Service]), cfg.EnableLoadBalancer, cfg.RemoteRealLoadBalancerClassName)).
-		With(exposition.NewIngressReflector(ptr.To(cfg.ReflectorsConfigs[resources.Ingress]), cfg.EnableIngress, cfg.RemoteRealIngressClassName)).
-		With(configuration.NewConfigMapReflector(ptr.To(cfg.ReflectorsConfigs[resources.ConfigMap]))).
-		With(configuration.NewSecretReflector(apiServerSupport == forge.APIServerSupportLegacy, ptr.To(cfg.ReflectorsConfigs[resources.Secret]))).
+		With(serviceReflector).
+		With(exposition.NewIngressReflector(ptr.To(cfg.ReflectorsConfigs[resources.Ingress]),
+			cfg.EnableIngress, cfg.RemoteRealIngressClassName, cfg.IngressClasses, cfg.ReflectIngressStatusClasses)).
+		With(configMapReflector).
+		With(secretReflector).
 		With(configuration.NewServiceAccountReflector(apiServerSupport == forge.APIServerSupportTokenAPI,
 			ptr.To(cfg.ReflectorsConfigs[resources.ServiceAccount]))).
 		With(storage.NewPersistentVolumeClaimReflector(cfg.VirtualStorageClassName, cfg.RemoteRealStorageClassName,
 			cfg.EnableStorage, ptr.To(cfg.ReflectorsConfigs[resources.PersistentVolumeClaim]))).
 		With(event.NewEventReflector(ptr.To(cfg.ReflectorsConfigs[resources.Event]))).
+		With(quota.NewOffloadedNamespaceQuotaReflector(ptr.To(cfg.ReflectorsConfigs[resources.NamespaceQuota]))).
 		WithNamespaceHandler(namespacemap.NewHandler(localLiqoClient, cfg.Namespace, cfg.InformerResyncPeriod))
 
 	if !cfg.DisableIPReflection {
-		reflectionManager.With(exposition.NewEndpointSliceReflector(cfg.LocalPodCIDR, ptr.To(cfg.ReflectorsConfigs[resources.EndpointSlice])))
+		endpointSliceReflector, err := exposition.NewEndpointSliceReflector(cfg.LocalPodCIDR, ptr.To(cfg.ReflectorsConfigs[resources.EndpointSlice]))
+		if err != nil {
+			return nil, err
+		}
+		reflectionManager.With(endpointSliceReflector)
+	}
+
+	for i := range cfg.CustomResourceReflectorsConfigs {
+		reflectorConfig := &cfg.CustomResourceReflectorsConfigs[i]
+		if reflectorConfig.Direction == offloadingv1beta1.RemoteToLocalStatus {
+			// The status can be reflected back only if the local custom resource exposes the status subresource.
+			gvr := customresource.GroupVersionResource(reflectorConfig)
+			hasStatus, err := customresource.HasStatusSubresource(localClient.Discovery(), gvr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to check whether resource %s exposes the status subresource", gvr.GroupResource())
+			}
+			if !hasStatus {
+				klog.Warningf("Resource %s does not expose the status subresource in the local cluster: "+
+					"its status is not reflected back from the remote cluster", gvr.GroupResource())
+				reflectorConfig.Direction = offloadingv1beta1.LocalToRemote
+			}
+		}
+		reflectionManager.With(customresource.NewCustomResourceReflector(reflectorConfig))
 	}
 
 	reflectionManager.Start(ctx)
@@ -51,9 +51,9 @@ type NamespacedConfigMapReflector struct {
 }
 
 // NewConfigMapReflector builds a ConfigMapReflector.
-func NewConfigMapReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
-	return generic.NewReflector(ConfigMapReflectorName, NewNamespacedConfigMapReflector,
-		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
+func NewConfigMapReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig) (manager.Reflector, error) {
+	return generic.NewReflectorWithPolicy(ConfigMapReflectorName, NewNamespacedConfigMapReflector,
+		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, reflectorConfig.Policy, generic.ConcurrencyModeLeader)
 }
 
 // RemoteConfigMapNamespacedKeyer returns a keyer associated with the given namespace,
@@ -73,6 +73,7 @@ func NewNamespacedConfigMapReflector(opts *options.NamespacedOpts) manager.Names
 	// no matter the cluster, hence it will be processed by the handle function in the same way.
 	local.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
 	remote.Informer().AddEventHandler(opts.HandlerFactory(RemoteConfigMapNamespacedKeyer(opts.LocalNamespace)))
+	generic.EnqueueOnNamespaceLabelsChange(opts, local.Informer())
 
 	return &NamespacedConfigMapReflector{
 		NamespacedReflector:    generic.NewNamespacedReflector(opts, ConfigMapReflectorName),
@@ -132,6 +133,25 @@ func (ncr *NamespacedConfigMapReflector) Handle(ctx context.Context, name string
 		}
 	}
 
+	// Abort the reflection if the local object does not match the reflection policy.
+	if !kerrors.IsNotFound(lerr) {
+		matches, err := ncr.MatchesReflectionPolicy(local)
+		if err != nil {
+			klog.Errorf("Failed to check whether local ConfigMap %q matches the reflection policy: %v", ncr.LocalRef(name), err)
+			return err
+		}
+		if !matches {
+			klog.Infof("Skipping reflection of local ConfigMap %q as not matching the reflection policy", ncr.LocalRef(name))
+			ncr.Event(local, corev1.EventTypeNormal, forge.EventReflectionDisabled, forge.EventObjectReflectionPolicyMismatchMsg())
+			if kerrors.IsNotFound(rerr) { // The remote object does not already exist, hence no further action is required.
+				return nil
+			}
+
+			// Otherwise, let pretend the local object does not exist, so that the remote one gets deleted.
+			lerr = kerrors.NewNotFound(corev1.Resource("configmap"), local.GetName())
+		}
+	}
+
 	tracer.Step("Performed the sanity checks")
 
 	if kerrors.IsNotFound(lerr) {
@@ -146,7 +166,7 @@ func (ncr *NamespacedConfigMapReflector) Handle(ctx context.Context, name string
 	}
 
 	// Forge the mutation to be applied to the remote cluster.
-	mutation := forge.RemoteConfigMap(local, ncr.RemoteNamespace(), ncr.ForgingOpts)
+	mutation := forge.RemoteConfigMap(ncr.ReflectionPolicy().FilterConfigMap(local), ncr.RemoteNamespace(), ncr.ForgingOpts)
 	tracer.Step("Remote mutation created")
 
 	defer tracer.Step("Enforced the correctness of the remote object")
@@ -51,9 +51,9 @@ type NamespacedSecretReflector struct {
 }
 
 // NewSecretReflector builds a SecretReflector.
-func NewSecretReflector(enableSAReflection bool, reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
-	return generic.NewReflector(SecretReflectorName, NewNamespacedSecretReflector(enableSAReflection),
-		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
+func NewSecretReflector(enableSAReflection bool, reflectorConfig *offloadingv1beta1.ReflectorConfig) (manager.Reflector, error) {
+	return generic.NewReflectorWithPolicy(SecretReflectorName, NewNamespacedSecretReflector(enableSAReflection),
+		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, reflectorConfig.Policy, generic.ConcurrencyModeLeader)
 }
 
 // NewNamespacedSecretReflector returns a function generating NamespacedSecretReflector instances.
@@ -66,6 +66,7 @@ func NewNamespacedSecretReflector(enableSAReflection bool) func(*options.Namespa
 		// no matter the cluster, hence it will be processed by the handle function in the same way.
 		local.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
 		remote.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+		generic.EnqueueOnNamespaceLabelsChange(opts, local.Informer())
 
 		return &NamespacedSecretReflector{
 			NamespacedReflector: generic.NewNamespacedReflector(opts, SecretReflectorName),
@@ -135,6 +136,26 @@ func (nsr *NamespacedSecretReflector) Handle(ctx context.Context, name string) e
 		}
 	}
 
+	// Abort the reflection if the local object does not match the reflection policy.
+	if !kerrors.IsNotFound(lerr) {
+		matches, err := nsr.MatchesReflectionPolicy(local)
+		if err != nil {
+			klog.Errorf("Failed to check whether local Secret %q matches the reflection policy: %v", nsr.LocalRef(name), err)
+			return err
+		}
+		// Secrets stripped of the keys required by their type are not reflected, as they would be invalid.
+		if !matches || !nsr.ReflectionPolicy().HasReflectableKeys(local) {
+			klog.Infof("Skipping reflection of local Secret %q as not matching the reflection policy", nsr.LocalRef(name))
+			nsr.Event(local, corev1.EventTypeNormal, forge.EventReflectionDisabled, forge.EventObjectReflectionPolicyMismatchMsg())
+			if kerrors.IsNotFound(rerr) { // The remote object does not already exist, hence no further action is required.
+				return nil
+			}
+
+			// Otherwise, let pretend the local object does not exist, so that the remote one gets deleted.
+			lerr = kerrors.NewNotFound(corev1.Resource("secret"), local.GetName())
+		}
+	}
+
 	tracer.Step("Performed the sanity checks")
 
 	if kerrors.IsNotFound(lerr) {
@@ -149,7 +170,7 @@ func (nsr *NamespacedSecretReflector) Handle(ctx context.Context, name string) e
 	}
 
 	// Forge the mutation to be applied to the remote cluster.
-	mutation := forge.RemoteSecret(local, nsr.RemoteNamespace(), nsr.ForgingOpts)
+	mutation := forge.RemoteSecret(nsr.ReflectionPolicy().FilterSecret(local), nsr.RemoteNamespace(), nsr.ForgingOpts)
 	tracer.Step("Remote mutation created")
 
 	defer tracer.Step("Enforced the correctness of the remote object")
@@ -35,6 +35,7 @@ import (
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/configuration"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/policy"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/resources"
 )
 
@@ -55,6 +56,7 @@ var _ = Describe("Secret Reflection", func() {
 		var (
 			reflector          manager.NamespacedReflector
 			reflectionType     offloadingv1beta1.ReflectionType
+			reflectionPolicy   *policy.Policy
 			enableSAReflection bool
 
 			name          string
@@ -97,6 +99,7 @@ var _ = Describe("Secret Reflection", func() {
 			local = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: LocalNamespace}}
 			remote = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: RemoteNamespace}}
 			reflectionType = root.DefaultReflectorsTypes[resources.Secret]
+			reflectionPolicy = nil
 		})
 
 		AfterEach(func() {
@@ -114,6 +117,7 @@ var _ = Describe("Secret Reflection", func() {
 				WithHandlerFactory(FakeEventHandler).
 				WithEventBroadcaster(record.NewBroadcaster()).
 				WithReflectionType(reflectionType).
+				WithReflectionPolicy(reflectionPolicy).
 				WithForgingOpts(FakeForgingOpts()))
 
 			factory.Start(ctx.Done())
@@ -155,6 +159,35 @@ var _ = Describe("Secret Reflection", func() {
 				})
 			})
 
+			When("the reflection policy excludes some data keys", func() {
+				BeforeEach(func() {
+					local.Data["excluded-key"] = []byte("some excluded data")
+					Expect(client.CoreV1().Secrets(LocalNamespace).Update(ctx, &local, metav1.UpdateOptions{})).Error().ToNot(HaveOccurred())
+
+					var errPolicy error
+					reflectionPolicy, errPolicy = policy.New(&offloadingv1beta1.ReflectionPolicy{ExcludedKeys: []string{"excluded-key"}})
+					Expect(errPolicy).ToNot(HaveOccurred())
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("the excluded keys should not have been replicated to the remote object", func() {
+					remoteAfter := GetSecret(RemoteNamespace)
+					Expect(remoteAfter.Data).To(HaveKeyWithValue("data-key", []byte("some secret data")))
+					Expect(remoteAfter.Data).ToNot(HaveKey("excluded-key"))
+				})
+			})
+
+			When("the local object does not match the reflection policy", func() {
+				BeforeEach(func() {
+					var errPolicy error
+					reflectionPolicy, errPolicy = policy.New(&offloadingv1beta1.ReflectionPolicy{NameRegex: "^other-"})
+					Expect(errPolicy).ToNot(HaveOccurred())
+				})
+
+				When("the remote object does not exist", WhenBodyRemoteShouldNotExist(false))
+				When("the remote object does exist", WhenBodyRemoteShouldNotExist(true))
+			})
+
 			When("the remote object already exists", func() {
 				BeforeEach(func() {
 					remote.SetLabels(labels.Merge(forge.ReflectionLabels(), map[string]string{FakeNotReflectedLabelKey: "true"}))
@@ -0,0 +1,295 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/generic"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+)
+
+// NamespacedCustomResourceReflector manages the reflection of a custom resource.
+type NamespacedCustomResourceReflector struct {
+	generic.NamespacedReflector
+
+	gvr          schema.GroupVersionResource
+	direction    offloadingv1beta1.CustomResourceReflectionDirection
+	prunedFields []string
+
+	localObjects        cache.GenericNamespaceLister
+	remoteObjects       cache.GenericNamespaceLister
+	localObjectsClient  dynamic.ResourceInterface
+	remoteObjectsClient dynamic.ResourceInterface
+
+	localSynced  cache.InformerSynced
+	remoteSynced cache.InformerSynced
+}
+
+// GroupVersionResource returns the GroupVersionResource of the custom resource described by the given configuration.
+func GroupVersionResource(reflectorConfig *offloadingv1beta1.CustomResourceReflectorConfig) schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: reflectorConfig.Group, Version: reflectorConfig.Version, Resource: reflectorConfig.Resource}
+}
+
+// Validate checks whether the given configuration of a custom resource reflector is valid.
+func Validate(reflectorConfig *offloadingv1beta1.CustomResourceReflectorConfig) error {
+	gvr := GroupVersionResource(reflectorConfig)
+	if gvr.Group == "" || gvr.Version == "" || gvr.Resource == "" {
+		return fmt.Errorf("group, version and resource are required (got %q)", gvr.String())
+	}
+
+	switch reflectorConfig.Direction {
+	case offloadingv1beta1.LocalToRemote, offloadingv1beta1.RemoteToLocalStatus:
+	default:
+		return fmt.Errorf("direction %q is not valid for resource %s. Ammitted values: %q, %q", reflectorConfig.Direction,
+			gvr.GroupResource(), offloadingv1beta1.LocalToRemote, offloadingv1beta1.RemoteToLocalStatus)
+	}
+
+	switch reflectorConfig.Type {
+	case offloadingv1beta1.DenyList, offloadingv1beta1.AllowList:
+	default:
+		return fmt.Errorf("reflection type %q is not valid for resource %s. Ammitted values: %q, %q", reflectorConfig.Type,
+			gvr.GroupResource(), offloadingv1beta1.DenyList, offloadingv1beta1.AllowList)
+	}
+
+	for _, field := range reflectorConfig.PrunedFields {
+		switch root, _, _ := strings.Cut(field, "."); root {
+		case "", "apiVersion", "kind", "metadata", "status":
+			return fmt.Errorf("pruned field %q is not valid for resource %s", field, gvr.GroupResource())
+		}
+	}
+
+	return nil
+}
+
+// HasStatusSubresource returns whether the given custom resource exposes the status subresource.
+// It returns false in case the resource does not exist.
+func HasStatusSubresource(dc discovery.DiscoveryInterface, gvr schema.GroupVersionResource) (bool, error) {
+	resources, err := dc.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	for i := range resources.APIResources {
+		if resources.APIResources[i].Name == gvr.Resource+"/status" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NewCustomResourceReflector returns a new reflector for the custom resource described by the given configuration.
+func NewCustomResourceReflector(reflectorConfig *offloadingv1beta1.CustomResourceReflectorConfig) manager.Reflector {
+	return generic.NewReflector(GroupVersionResource(reflectorConfig).GroupResource().String(),
+		NewNamespacedCustomResourceReflector(reflectorConfig), generic.WithoutFallback(),
+		reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
+}
+
+// NewNamespacedCustomResourceReflector returns a function generating NamespacedCustomResourceReflector instances.
+func NewNamespacedCustomResourceReflector(reflectorConfig *offloadingv1beta1.CustomResourceReflectorConfig) generic.NamespacedReflectorFactoryFunc {
+	gvr := GroupVersionResource(reflectorConfig)
+
+	return func(opts *options.NamespacedOpts) manager.NamespacedReflector {
+		local := opts.LocalDynFactory.ForResource(gvr)
+		remote := opts.RemoteDynFactory.ForResource(gvr)
+
+		// Using opts.LocalNamespace for both event handlers so that the object will be put in the same workqueue
+		// no matter the cluster, hence it will be processed by the handle function in the same way.
+		local.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+		remote.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+
+		return &NamespacedCustomResourceReflector{
+			NamespacedReflector: generic.NewNamespacedReflector(opts, gvr.GroupResource().String()),
+			gvr:                 gvr,
+			direction:           reflectorConfig.Direction,
+			prunedFields:        reflectorConfig.PrunedFields,
+			localObjects:        local.Lister().ByNamespace(opts.LocalNamespace),
+			remoteObjects:       remote.Lister().ByNamespace(opts.RemoteNamespace),
+			localObjectsClient:  opts.LocalDynClient.Resource(gvr).Namespace(opts.LocalNamespace),
+			remoteObjectsClient: opts.RemoteDynClient.Resource(gvr).Namespace(opts.RemoteNamespace),
+			localSynced:         local.Informer().HasSynced,
+			remoteSynced:        remote.Informer().HasSynced,
+		}
+	}
+}
+
+// Ready returns whether the NamespacedReflector is completely initialized. Differently from the built-in resources,
+// the reflection manager does not wait indefinitely for the custom resource informers to sync (since the CRD might be
+// missing in one of the clusters), hence their synchronization is checked here.
+func (ncr *NamespacedCustomResourceReflector) Ready() bool {
+	return ncr.NamespacedReflector.Ready() && ncr.localSynced() && ncr.remoteSynced()
+}
+
+// Handle is responsible for reconciling the given object and ensuring it is correctly reflected.
+func (ncr *NamespacedCustomResourceReflector) Handle(ctx context.Context, name string) error {
+	tracer := trace.FromContext(ctx)
+	resource := ncr.gvr.GroupResource().String()
+
+	// Retrieve the local and remote objects (only not found errors can occur).
+	klog.V(4).Infof("Handling reflection of local %v %q (remote: %q)", resource, ncr.LocalRef(name), ncr.RemoteRef(name))
+
+	local, lerr := ncr.get(ncr.localObjects, name)
+	utilruntime.Must(client.IgnoreNotFound(lerr))
+	remote, rerr := ncr.get(ncr.remoteObjects, name)
+	utilruntime.Must(client.IgnoreNotFound(rerr))
+	tracer.Step("Retrieved the local and remote objects")
+
+	// Abort the reflection if the remote object is not managed by us, as we do not want to mutate others' objects.
+	if rerr == nil && !forge.IsReflected(remote) {
+		if lerr == nil { // Do not output the warning event in case the event was triggered by the remote object (i.e., the local one does not exists).
+			klog.Infof("Skipping reflection of local %v %q as remote already exists and is not managed by us", resource, ncr.LocalRef(name))
+			ncr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionAlreadyExistsMsg())
+		}
+		return nil
+	}
+
+	// Abort the reflection if the local object has the "skip-reflection" annotation.
+	if !kerrors.IsNotFound(lerr) {
+		skipReflection, err := ncr.ShouldSkipReflection(local)
+		if err != nil {
+			klog.Errorf("Failed to check whether local %v %q should be reflected: %v", resource, ncr.LocalRef(name), err)
+			return err
+		}
+		if skipReflection {
+			if ncr.GetReflectionType() == offloadingv1beta1.DenyList {
+				klog.Infof("Skipping reflection of local %v %q as marked with the skip annotation", resource, ncr.LocalRef(name))
+			} else { // AllowList
+				klog.Infof("Skipping reflection of local %v %q as not marked with the allow annotation", resource, ncr.LocalRef(name))
+			}
+			ncr.Event(local, corev1.EventTypeNormal, forge.EventReflectionDisabled, forge.EventObjectReflectionDisabledMsg(ncr.GetReflectionType()))
+			if kerrors.IsNotFound(rerr) { // The remote object does not already exist, hence no further action is required.
+				return nil
+			}
+
+			// Otherwise, let pretend the local object does not exist, so that the remote one gets deleted.
+			lerr = kerrors.NewNotFound(ncr.gvr.GroupResource(), local.GetName())
+		}
+	}
+
+	tracer.Step("Performed the sanity checks")
+
+	if kerrors.IsNotFound(lerr) {
+		defer tracer.Step("Ensured the absence of the remote object")
+		if !kerrors.IsNotFound(rerr) {
+			klog.V(4).Infof("Deleting remote %v %q, since local %q does no longer exist", resource, ncr.RemoteRef(name), ncr.LocalRef(name))
+			return ncr.DeleteRemote(ctx, deleter{ncr.remoteObjectsClient}, resource, remote.GetName(), remote.GetUID())
+		}
+
+		klog.V(4).Infof("Local %v %q and remote %v %q both vanished", resource, ncr.LocalRef(name), resource, ncr.RemoteRef(name))
+		return nil
+	}
+
+	// Forge the mutation to be applied to the remote cluster.
+	mutation := forge.RemoteCustomResource(local, ncr.RemoteNamespace(), ncr.prunedFields, ncr.ForgingOpts)
+	tracer.Step("Remote mutation created")
+
+	remote, err := ncr.remoteObjectsClient.Apply(ctx, name, mutation, forge.ApplyOptions())
+	if err != nil {
+		klog.Errorf("Failed to enforce remote %v %q (local: %q): %v", resource, ncr.RemoteRef(name), ncr.LocalRef(name), err)
+		ncr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
+		return err
+	}
+	tracer.Step("Enforced the correctness of the remote object")
+
+	klog.Infof("Remote %v %q successfully enforced (local: %q)", resource, ncr.RemoteRef(name), ncr.LocalRef(name))
+	ncr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
+
+	if ncr.direction != offloadingv1beta1.RemoteToLocalStatus {
+		return nil
+	}
+
+	defer tracer.Step("Enforced the correctness of the local status")
+	return ncr.handleStatus(ctx, local, remote)
+}
+
+// handleStatus reflects the status of the remote object back to the local one, if necessary.
+func (ncr *NamespacedCustomResourceReflector) handleStatus(ctx context.Context, local, remote *unstructured.Unstructured) error {
+	resource := ncr.gvr.GroupResource().String()
+
+	updated := forge.LocalCustomResourceStatus(local, remote)
+	if updated == nil {
+		klog.V(4).Infof("Status of local %v %q already in sync with remote %q", resource, ncr.LocalRef(local.GetName()), ncr.RemoteRef(remote.GetName()))
+		return nil
+	}
+
+	if _, err := ncr.localObjectsClient.UpdateStatus(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to update the status of local %v %q (remote: %q): %v", resource, ncr.LocalRef(local.GetName()),
+			ncr.RemoteRef(remote.GetName()), err)
+		ncr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedStatusReflectionMsg(err))
+		return err
+	}
+
+	klog.Infof("Status of local %v %q successfully enforced (remote: %q)", resource, ncr.LocalRef(local.GetName()), ncr.RemoteRef(remote.GetName()))
+	ncr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulStatusReflectionMsg())
+	return nil
+}
+
+// deleter adapts a dynamic.ResourceInterface to the generic.ResourceDeleter interface.
+type deleter struct{ dynamic.ResourceInterface }
+
+// Delete deletes the object with the given name.
+func (d deleter) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return d.ResourceInterface.Delete(ctx, name, opts)
+}
+
+// get retrieves the object with the given name from the given lister.
+func (ncr *NamespacedCustomResourceReflector) get(lister cache.GenericNamespaceLister, name string) (*unstructured.Unstructured, error) {
+	obj, err := lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*unstructured.Unstructured), nil
+}
+
+// List returns the list of objects.
+func (ncr *NamespacedCustomResourceReflector) List() ([]interface{}, error) {
+	var keys []interface{}
+	for _, lister := range []cache.GenericNamespaceLister{ncr.localObjects, ncr.remoteObjects} {
+		objs, err := lister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range objs {
+			metadata, err := apimeta.Accessor(objs[i])
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, types.NamespacedName{Namespace: ncr.LocalNamespace(), Name: metadata.GetName()})
+		}
+	}
+	return keys, nil
+}
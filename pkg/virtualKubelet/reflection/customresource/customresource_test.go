@@ -0,0 +1,308 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customresource_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/trace"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils/testutil"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/customresource"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+)
+
+var _ = Describe("Custom resource Reflection", func() {
+	var reflectorConfig offloadingv1beta1.CustomResourceReflectorConfig
+
+	BeforeEach(func() {
+		reflectorConfig = offloadingv1beta1.CustomResourceReflectorConfig{
+			Group: "cert-manager.io", Version: "v1", Resource: "certificates",
+			Direction:    offloadingv1beta1.LocalToRemote,
+			NumWorkers:   1,
+			Type:         offloadingv1beta1.DenyList,
+			PrunedFields: []string{"spec.issuerRef"},
+		}
+	})
+
+	Describe("NewCustomResourceReflector", func() {
+		It("should create a non-nil reflector", func() {
+			reflector := customresource.NewCustomResourceReflector(&reflectorConfig)
+			Expect(reflector).NotTo(BeNil())
+			Expect(reflector.String()).To(Equal("certificates.cert-manager.io"))
+		})
+	})
+
+	DescribeTable("Validate",
+		func(mutate func(*offloadingv1beta1.CustomResourceReflectorConfig), expectError bool) {
+			mutate(&reflectorConfig)
+			if expectError {
+				Expect(customresource.Validate(&reflectorConfig)).To(HaveOccurred())
+			} else {
+				Expect(customresource.Validate(&reflectorConfig)).To(Succeed())
+			}
+		},
+		Entry("valid configuration", func(*offloadingv1beta1.CustomResourceReflectorConfig) {}, false),
+		Entry("missing group", func(c *offloadingv1beta1.CustomResourceReflectorConfig) { c.Group = "" }, true),
+		Entry("missing resource", func(c *offloadingv1beta1.CustomResourceReflectorConfig) { c.Resource = "" }, true),
+		Entry("invalid direction", func(c *offloadingv1beta1.CustomResourceReflectorConfig) { c.Direction = "Invalid" }, true),
+		Entry("invalid type", func(c *offloadingv1beta1.CustomResourceReflectorConfig) { c.Type = offloadingv1beta1.CustomLiqo }, true),
+		Entry("pruned metadata", func(c *offloadingv1beta1.CustomResourceReflectorConfig) {
+			c.PrunedFields = []string{"metadata.labels"}
+		}, true),
+		Entry("pruned status", func(c *offloadingv1beta1.CustomResourceReflectorConfig) { c.PrunedFields = []string{"status"} }, true),
+	)
+
+	DescribeTable("HasStatusSubresource",
+		func(resources []*metav1.APIResourceList, expected bool) {
+			discovery := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: resources}}
+			hasStatus, err := customresource.HasStatusSubresource(discovery, customresource.GroupVersionResource(&reflectorConfig))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hasStatus).To(Equal(expected))
+		},
+		Entry("resource with the status subresource", []*metav1.APIResourceList{{GroupVersion: "cert-manager.io/v1",
+			APIResources: []metav1.APIResource{{Name: "certificates"}, {Name: "certificates/status"}}}}, true),
+		Entry("resource without the status subresource", []*metav1.APIResourceList{{GroupVersion: "cert-manager.io/v1",
+			APIResources: []metav1.APIResource{{Name: "certificates"}, {Name: "issuers/status"}}}}, false),
+		Entry("group version not found", nil, false),
+	)
+
+	Describe("Ready", func() {
+		var (
+			factory   dynamicinformer.DynamicSharedInformerFactory
+			reflector manager.NamespacedReflector
+		)
+
+		BeforeEach(func() {
+			client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{customresource.GroupVersionResource(&reflectorConfig): "CertificateList"})
+			factory = dynamicinformer.NewDynamicSharedInformerFactory(client, 10*time.Hour)
+			reflector = customresource.NewNamespacedCustomResourceReflector(&reflectorConfig)(options.NewNamespaced().
+				WithLocal(LocalNamespace, nil, nil).
+				WithRemote(RemoteNamespace, nil, nil).
+				WithDynLocal(client, factory).
+				WithDynRemote(client, factory).
+				WithHandlerFactory(FakeEventHandler).
+				WithEventBroadcaster(record.NewBroadcaster()).
+				WithReadinessFunc(func() bool { return true }).
+				WithForgingOpts(testutil.FakeForgingOpts()))
+		})
+
+		It("should not be ready until the informers have synced", func() {
+			Expect(reflector.Ready()).To(BeFalse())
+			factory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+			Expect(reflector.Ready()).To(BeTrue())
+		})
+	})
+
+	Describe("Handle", func() {
+		const name = "name"
+
+		var (
+			gvr       schema.GroupVersionResource
+			client    *fake.FakeDynamicClient
+			reflector manager.NamespacedReflector
+
+			local, remote *unstructured.Unstructured
+			err           error
+		)
+
+		ForgeCertificate := func(namespace string) *unstructured.Unstructured {
+			return &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1",
+				"kind":       "Certificate",
+				"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+				"spec": map[string]interface{}{
+					"secretName": "secret",
+					"issuerRef":  map[string]interface{}{"name": "issuer"},
+				},
+			}}
+		}
+
+		GetCertificate := func(namespace string) (*unstructured.Unstructured, error) {
+			return client.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		}
+
+		WhenBodyRemoteShouldNotExist := func(createRemote bool) func() {
+			return func() {
+				BeforeEach(func() {
+					if createRemote {
+						remote.SetLabels(forge.ReflectionLabels())
+						Expect(client.Tracker().Add(remote)).To(Succeed())
+					}
+				})
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("the remote object should not be present", func() {
+					_, err = GetCertificate(RemoteNamespace)
+					Expect(err).To(testutil.BeNotFound())
+				})
+			}
+		}
+
+		BeforeEach(func() {
+			gvr = customresource.GroupVersionResource(&reflectorConfig)
+			client = fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{gvr: "CertificateList"})
+
+			// The fake dynamic client does not properly support server side apply for unstructured objects,
+			// hence it is emulated by replacing the whole object, except for the status.
+			client.PrependReactor("patch", gvr.Resource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+				patch := action.(k8stesting.PatchAction)
+				if patch.GetPatchType() != types.ApplyPatchType {
+					return false, nil, nil
+				}
+
+				obj := &unstructured.Unstructured{}
+				if err := obj.UnmarshalJSON(patch.GetPatch()); err != nil {
+					return true, nil, err
+				}
+
+				existing, err := client.Tracker().Get(gvr, patch.GetNamespace(), patch.GetName())
+				switch {
+				case kerrors.IsNotFound(err):
+					return true, obj, client.Tracker().Create(gvr, obj, patch.GetNamespace())
+				case err != nil:
+					return true, nil, err
+				}
+
+				if status, found := existing.(*unstructured.Unstructured).Object["status"]; found {
+					obj.Object["status"] = status
+				}
+				return true, obj, client.Tracker().Update(gvr, obj, patch.GetNamespace())
+			})
+
+			local = ForgeCertificate(LocalNamespace)
+			remote = ForgeCertificate(RemoteNamespace)
+		})
+
+		JustBeforeEach(func() {
+			factory := dynamicinformer.NewDynamicSharedInformerFactory(client, 10*time.Hour)
+			reflector = customresource.NewNamespacedCustomResourceReflector(&reflectorConfig)(options.NewNamespaced().
+				WithLocal(LocalNamespace, nil, nil).
+				WithRemote(RemoteNamespace, nil, nil).
+				WithDynLocal(client, factory).
+				WithDynRemote(client, factory).
+				WithHandlerFactory(FakeEventHandler).
+				WithEventBroadcaster(record.NewBroadcaster()).
+				WithReflectionType(reflectorConfig.Type).
+				WithForgingOpts(testutil.FakeForgingOpts()))
+
+			factory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+
+			err = reflector.Handle(trace.ContextWithTrace(ctx, trace.New("CustomResource")), name)
+		})
+
+		When("the local object does not exist", func() {
+			When("the remote object does not exist", WhenBodyRemoteShouldNotExist(false))
+			When("the remote object does exist", WhenBodyRemoteShouldNotExist(true))
+		})
+
+		When("the local object does exist", func() {
+			BeforeEach(func() {
+				local.SetLabels(map[string]string{"foo": "bar", testutil.FakeNotReflectedLabelKey: "true"})
+				local.SetAnnotations(map[string]string{"bar": "baz", testutil.FakeNotReflectedAnnotKey: "true"})
+				Expect(client.Tracker().Add(local)).To(Succeed())
+			})
+
+			When("the remote object does not exist", func() {
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("the remote object should be present, with the correct metadata", func() {
+					reflected, err := GetCertificate(RemoteNamespace)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(reflected.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+					Expect(reflected.GetLabels()).To(HaveKeyWithValue(forge.LiqoOriginClusterIDKey, LocalClusterID))
+					Expect(reflected.GetLabels()).To(HaveKeyWithValue(forge.LiqoDestinationClusterIDKey, RemoteClusterID))
+					Expect(reflected.GetLabels()).ToNot(HaveKey(testutil.FakeNotReflectedLabelKey))
+					Expect(reflected.GetAnnotations()).To(HaveKeyWithValue("bar", "baz"))
+					Expect(reflected.GetAnnotations()).ToNot(HaveKey(testutil.FakeNotReflectedAnnotKey))
+				})
+				It("the remote object should be present, without the pruned fields", func() {
+					reflected, err := GetCertificate(RemoteNamespace)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(reflected.Object).To(HaveKeyWithValue("spec", map[string]interface{}{"secretName": "secret"}))
+				})
+			})
+
+			When("the remote object does exist, but is not managed by us", func() {
+				BeforeEach(func() { Expect(client.Tracker().Add(remote)).To(Succeed()) })
+
+				It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+				It("the remote object should not be mutated", func() {
+					reflected, err := GetCertificate(RemoteNamespace)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(reflected.GetLabels()).ToNot(HaveKey("foo"))
+				})
+			})
+
+			When("the local object is marked with the skip annotation", func() {
+				BeforeEach(func() {
+					local.SetAnnotations(map[string]string{consts.SkipReflectionAnnotationKey: "true"})
+					Expect(client.Tracker().Update(gvr, local, LocalNamespace)).To(Succeed())
+				})
+
+				When("the remote object does not exist", WhenBodyRemoteShouldNotExist(false))
+				When("the remote object does exist", WhenBodyRemoteShouldNotExist(true))
+			})
+
+			When("the remote object has a status", func() {
+				BeforeEach(func() {
+					remote.SetLabels(forge.ReflectionLabels())
+					remote.Object["status"] = map[string]interface{}{"revision": int64(1)}
+					Expect(client.Tracker().Add(remote)).To(Succeed())
+				})
+
+				When("the direction is LocalToRemote", func() {
+					It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+					It("the local object should not have the status", func() {
+						original, err := GetCertificate(LocalNamespace)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(original.Object).ToNot(HaveKey("status"))
+					})
+				})
+
+				When("the direction is RemoteToLocalStatus", func() {
+					BeforeEach(func() { reflectorConfig.Direction = offloadingv1beta1.RemoteToLocalStatus })
+
+					It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+					It("the local object should have the remote status", func() {
+						original, err := GetCertificate(LocalNamespace)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(original.Object).To(HaveKeyWithValue("status", map[string]interface{}{"revision": int64(1)}))
+					})
+				})
+			})
+		})
+	})
+})
@@ -71,9 +71,9 @@ type NamespacedEndpointSliceReflector struct {
 }
 
 // NewEndpointSliceReflector returns a new EndpointSliceReflector instance.
-func NewEndpointSliceReflector(localPodCIDR string, reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
-	return generic.NewReflector(EndpointSliceReflectorName, NewNamespacedEndpointSliceReflector(localPodCIDR),
-		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
+func NewEndpointSliceReflector(localPodCIDR string, reflectorConfig *offloadingv1beta1.ReflectorConfig) (manager.Reflector, error) {
+	return generic.NewReflectorWithPolicy(EndpointSliceReflectorName, NewNamespacedEndpointSliceReflector(localPodCIDR),
+		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, reflectorConfig.Policy, generic.ConcurrencyModeLeader)
 }
 
 // NewNamespacedEndpointSliceReflector returns a function generating NamespacedEndpointSliceReflector instances.
@@ -107,6 +107,7 @@ func NewNamespacedEndpointSliceReflector(localPodCIDR string) func(*options.Name
 		// Enqueue all existing remote EndpointSlices in case the local Service has the "skip-reflection" annotation, to ensure they are also deleted.
 		_, err = localServices.Informer().AddEventHandler(opts.HandlerFactory(ner.ServiceToEndpointSlicesKeyer))
 		utilruntime.Must(err)
+		generic.EnqueueOnNamespaceLabelsChange(opts, localEndpointSlices.Informer())
 
 		return ner
 	}
@@ -169,6 +170,27 @@ func (ner *NamespacedEndpointSliceReflector) Handle(ctx context.Context, name st
 		}
 	}
 
+	// Abort the reflection if the local object does not match the reflection policy.
+	var svc *corev1.Service
+	if localExists {
+		svc = ner.localService(local)
+		matches, err := ner.matchesReflectionPolicy(local, svc)
+		if err != nil {
+			klog.Errorf("Failed to check whether local EndpointSlice %q matches the reflection policy: %v", ner.LocalRef(name), err)
+			return err
+		}
+		if !matches {
+			klog.Infof("Skipping reflection of local EndpointSlice %q as not matching the reflection policy", ner.LocalRef(name))
+			ner.Event(local, corev1.EventTypeNormal, forge.EventReflectionDisabled, forge.EventObjectReflectionPolicyMismatchMsg())
+			if !remoteExists { // The shadow object does not already exist, hence no further action is required.
+				return nil
+			}
+
+			// Otherwise, let pretend the local object does not exist, so that the remote one gets deleted.
+			localExists = false
+		}
+	}
+
 	tracer.Step("Performed the sanity checks")
 
 	// The local endpointslice does no longer exist. Ensure it is also absent from the remote cluster.
@@ -183,6 +205,13 @@ func (ner *NamespacedEndpointSliceReflector) Handle(ctx context.Context, name st
 		return nil
 	}
 
+	filtered, ok := ner.ReflectionPolicy().FilterEndpointSlice(local, svc)
+	if !ok {
+		// The informer will trigger a re-enqueue once the service is cached.
+		klog.Infof("Postponing reflection of local EndpointSlice %q until the corresponding Service is known", ner.LocalRef(name))
+		return nil
+	}
+
 	// Wrap the address translation logic, so that we do not have to handle errors in the forge logic.
 	var terr error
 	translator := func(originals []string) []string {
@@ -196,7 +225,7 @@ func (ner *NamespacedEndpointSliceReflector) Handle(ctx context.Context, name st
 		return translations
 	}
 
-	target := forge.RemoteShadowEndpointSlice(local, remote, ner.localNodeClient, ner.RemoteNamespace(), translator, ner.ForgingOpts)
+	target := forge.RemoteShadowEndpointSlice(filtered, remote, ner.localNodeClient, ner.RemoteNamespace(), translator, ner.ForgingOpts)
 	if terr != nil {
 		klog.Errorf("Reflection of local EndpointSlice %q to %q failed: %v", ner.LocalRef(name), ner.RemoteRef(name), terr)
 		ner.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(terr))
@@ -345,6 +374,36 @@ func (ner *NamespacedEndpointSliceReflector) ShouldSkipReflection(obj metav1.Obj
 	return ner.NamespacedReflector.ShouldSkipReflection(svc)
 }
 
+// localService returns the local service associated with the given object, or nil if not found.
+func (ner *NamespacedEndpointSliceReflector) localService(obj metav1.Object) *corev1.Service {
+	svcname, ok := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if !ok {
+		return nil
+	}
+
+	svc, err := ner.localServices.Get(svcname)
+	if err != nil {
+		return nil
+	}
+	return svc
+}
+
+// matchesReflectionPolicy returns whether the given object matches the reflection policy, which is inherited from
+// the service reflector. Hence, it is evaluated against the associated service, if any. The endpointslice itself
+// is evaluated in case the service is not found (likely due to a race condition), as the informer will trigger
+// a re-enqueue once the service is cached.
+func (ner *NamespacedEndpointSliceReflector) matchesReflectionPolicy(obj metav1.Object, svc *corev1.Service) (bool, error) {
+	if svc == nil {
+		return ner.MatchesReflectionPolicy(obj)
+	}
+
+	// Services whose ports are all excluded are not reflected, hence neither are their endpointslices.
+	if !ner.ReflectionPolicy().HasReflectablePorts(svc) {
+		return false, nil
+	}
+	return ner.MatchesReflectionPolicy(svc)
+}
+
 // ServiceToEndpointSlicesKeyer returns the NamespacedName of all local EndpointSlices associated with the given local Service.
 func (ner *NamespacedEndpointSliceReflector) ServiceToEndpointSlicesKeyer(metadata metav1.Object) []types.NamespacedName {
 	req, err := labels.NewRequirement(discoveryv1.LabelServiceName, selection.Equals, []string{metadata.GetName()})
@@ -16,10 +16,12 @@ package exposition
 
 import (
 	"context"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	netv1clients "k8s.io/client-go/kubernetes/typed/networking/v1"
 	netv1listers "k8s.io/client-go/listers/networking/v1"
@@ -48,22 +50,25 @@ type NamespacedIngressReflector struct {
 
 	localIngresses        netv1listers.IngressNamespaceLister
 	remoteIngresses       netv1listers.IngressNamespaceLister
+	localIngressesClient  netv1clients.IngressInterface
 	remoteIngressesClient netv1clients.IngressInterface
 
 	enableIngress              bool
 	remoteRealIngressClassName string
+	ingressClasses             []string
+	reflectStatusClasses       []string
 }
 
 // NewIngressReflector returns a new IngressReflector instance.
-func NewIngressReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig,
-	enableIngress bool, remoteRealIngressClassName string) manager.Reflector {
-	return generic.NewReflector(IngressReflectorName, NewNamespacedIngressReflector(enableIngress, remoteRealIngressClassName),
-		generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
+func NewIngressReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig, enableIngress bool,
+	remoteRealIngressClassName string, ingressClasses, reflectStatusClasses []string) manager.Reflector {
+	return generic.NewReflector(IngressReflectorName,
+		NewNamespacedIngressReflector(enableIngress, remoteRealIngressClassName, ingressClasses, reflectStatusClasses), generic.WithoutFallback(), reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
 }
 
 // NewNamespacedIngressReflector returns a new NamespacedIngressReflector instance.
-func NewNamespacedIngressReflector(enableIngress bool,
-	remoteRealIngressClassName string) func(*options.NamespacedOpts) manager.NamespacedReflector {
+func NewNamespacedIngressReflector(enableIngress bool, remoteRealIngressClassName string,
+	ingressClasses, reflectStatusClasses []string) func(*options.NamespacedOpts) manager.NamespacedReflector {
 	return func(opts *options.NamespacedOpts) manager.NamespacedReflector {
 		local := opts.LocalFactory.Networking().V1().Ingresses()
 		remote := opts.RemoteFactory.Networking().V1().Ingresses()
@@ -77,9 +82,12 @@ func NewNamespacedIngressReflector(enableIngress bool,
 			NamespacedReflector:        generic.NewNamespacedReflector(opts, IngressReflectorName),
 			localIngresses:             local.Lister().Ingresses(opts.LocalNamespace),
 			remoteIngresses:            remote.Lister().Ingresses(opts.RemoteNamespace),
+			localIngressesClient:       opts.LocalClient.NetworkingV1().Ingresses(opts.LocalNamespace),
 			remoteIngressesClient:      opts.RemoteClient.NetworkingV1().Ingresses(opts.RemoteNamespace),
 			enableIngress:              enableIngress,
 			remoteRealIngressClassName: remoteRealIngressClassName,
+			ingressClasses:             ingressClasses,
+			reflectStatusClasses:       reflectStatusClasses,
 		}
 	}
 }
@@ -133,6 +141,13 @@ func (nir *NamespacedIngressReflector) Handle(ctx context.Context, name string)
 	// The local ingress does no longer exist. Ensure it is also absent from the remote cluster.
 	if kerrors.IsNotFound(lerr) {
 		defer tracer.Step("Ensured the absence of the remote object")
+		if local != nil {
+			// The local object still exists, but it shall no longer be reflected. Hence, release the status possibly reflected from the remote one.
+			if err := nir.handleStatus(ctx, local, nil); err != nil {
+				return err
+			}
+		}
+
 		if !kerrors.IsNotFound(rerr) {
 			klog.V(4).Infof("Deleting remote Ingress %q, since local %q does no longer exist", nir.RemoteRef(name), nir.LocalRef(name))
 			return nir.DeleteRemote(ctx, nir.remoteIngressesClient, IngressReflectorName, name, remote.GetUID())
@@ -143,11 +158,12 @@ func (nir *NamespacedIngressReflector) Handle(ctx context.Context, name string)
 	}
 
 	// Forge the mutation to be applied to the remote cluster.
-	mutation := forge.RemoteIngress(local, nir.RemoteNamespace(), nir.enableIngress, nir.remoteRealIngressClassName, nir.ForgingOpts)
+	mutation := forge.RemoteIngress(local, nir.RemoteNamespace(), nir.enableIngress, nir.remoteIngressClassName(local), nir.ForgingOpts)
 	tracer.Step("Remote mutation created")
 
 	defer tracer.Step("Enforced the correctness of the remote object")
-	if _, err := nir.remoteIngressesClient.Apply(ctx, mutation, forge.ApplyOptions()); err != nil {
+	remote, err := nir.remoteIngressesClient.Apply(ctx, mutation, forge.ApplyOptions())
+	if err != nil {
 		klog.Errorf("Failed to enforce remote Ingress %q (local: %q): %v", nir.RemoteRef(name), nir.LocalRef(name), err)
 		nir.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
 		return err
@@ -156,6 +172,78 @@ func (nir *NamespacedIngressReflector) Handle(ctx context.Context, name string)
 	klog.Infof("Remote Ingress %q successfully enforced (local: %q)", nir.RemoteRef(name), nir.LocalRef(name))
 	nir.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
 
+	return nir.handleStatus(ctx, local, remote)
+}
+
+// remoteIngressClassName returns the ingress class the given local ingress is assigned to in the remote cluster.
+func (nir *NamespacedIngressReflector) remoteIngressClassName(local *netv1.Ingress) string {
+	return forge.RemoteClassName(local.Spec.IngressClassName, nir.ingressClasses, nir.remoteRealIngressClassName)
+}
+
+// handleStatus reflects the load balancer status of the remote ingress back to the local one, if enabled for its class.
+// A nil remote ingress means that the local one is no longer reflected, and the status possibly reflected shall be released.
+func (nir *NamespacedIngressReflector) handleStatus(ctx context.Context, local, remote *netv1.Ingress) error {
+	enabled := nir.enableIngress && slices.Contains(nir.reflectStatusClasses, nir.remoteIngressClassName(local))
+	assigned := remote != nil && len(remote.Status.LoadBalancer.Ingress) > 0
+	name := local.GetName()
+
+	switch action, owner := statusReflectionActionFor(local, enabled, assigned); action {
+	case statusReflectionNone:
+		return nil
+
+	case statusReflectionConflict:
+		klog.V(4).Infof("Skipping status reflection of local Ingress %q, as already reflected from cluster %q", nir.LocalRef(name), owner)
+		nir.Event(local, corev1.EventTypeWarning, forge.EventStatusReflectionConflict, forge.EventStatusReflectionConflictMsg(owner))
+		return nil
+
+	case statusReflectionRelease:
+		if mutated := forge.LocalIngressStatus(local, &netv1.Ingress{}); mutated != nil {
+			updated, err := nir.localIngressesClient.UpdateStatus(ctx, mutated, metav1.UpdateOptions{})
+			if err != nil {
+				klog.Errorf("Failed to clear the status of local Ingress %q: %v", nir.LocalRef(name), err)
+				return err
+			}
+			local = updated
+		}
+
+		mutated := local.DeepCopy()
+		releaseStatusReflection(mutated)
+		if _, err := nir.localIngressesClient.Update(ctx, mutated, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Failed to release the status reflection of local Ingress %q: %v", nir.LocalRef(name), err)
+			return err
+		}
+
+		klog.Infof("Status reflection of local Ingress %q successfully released", nir.LocalRef(name))
+		return nil
+
+	case statusReflectionSync:
+		if owner == "" {
+			// Claim the status reflection first, relying on optimistic concurrency to prevent races with other virtual nodes.
+			mutated := local.DeepCopy()
+			claimStatusReflection(mutated)
+			updated, err := nir.localIngressesClient.Update(ctx, mutated, metav1.UpdateOptions{})
+			if err != nil {
+				klog.Errorf("Failed to claim the status reflection of local Ingress %q: %v", nir.LocalRef(name), err)
+				return err
+			}
+			local = updated
+		}
+
+		mutated := forge.LocalIngressStatus(local, remote)
+		if mutated == nil {
+			return nil
+		}
+
+		if _, err := nir.localIngressesClient.UpdateStatus(ctx, mutated, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Failed to reflect the status of remote Ingress %q to local %q: %v", nir.RemoteRef(name), nir.LocalRef(name), err)
+			nir.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedStatusReflectionMsg(err))
+			return err
+		}
+
+		klog.Infof("Status of remote Ingress %q successfully reflected to local %q", nir.RemoteRef(name), nir.LocalRef(name))
+		nir.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulStatusReflectionMsg())
+	}
+
 	return nil
 }
 
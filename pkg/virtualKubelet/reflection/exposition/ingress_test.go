@@ -45,7 +45,7 @@ var _ = Describe("Ingress Reflection Tests", func() {
 				NumWorkers: 1,
 				Type:       root.DefaultReflectorsTypes[resources.Ingress],
 			}
-			Expect(exposition.NewIngressReflector(&reflectorConfig, false, "")).ToNot(BeNil())
+			Expect(exposition.NewIngressReflector(&reflectorConfig, false, "", nil, nil)).ToNot(BeNil())
 		})
 	})
 
@@ -118,7 +118,7 @@ var _ = Describe("Ingress Reflection Tests", func() {
 
 		JustBeforeEach(func() {
 			factory := informers.NewSharedInformerFactory(client, 10*time.Hour)
-			reflector = exposition.NewNamespacedIngressReflector(false, "")(options.NewNamespaced().
+			reflector = exposition.NewNamespacedIngressReflector(false, "", nil, nil)(options.NewNamespaced().
 				WithLocal(LocalNamespace, client, factory).
 				WithRemote(RemoteNamespace, client, factory).
 				WithHandlerFactory(FakeEventHandler).
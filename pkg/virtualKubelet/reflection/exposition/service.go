@@ -16,9 +16,11 @@ package exposition
 
 import (
 	"context"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	corev1clients "k8s.io/client-go/kubernetes/typed/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -50,23 +52,27 @@ type NamespacedServiceReflector struct {
 
 	localServices        corev1listers.ServiceNamespaceLister
 	remoteServices       corev1listers.ServiceNamespaceLister
+	localServicesClient  corev1clients.ServiceInterface
 	remoteServicesClient corev1clients.ServiceInterface
 
 	enableLoadBalancer              bool
 	remoteRealLoadBalancerClassName string
+	loadBalancerClasses             []string
+	reflectStatusClasses            []string
 }
 
 // NewServiceReflector returns a new ServiceReflector instance.
-func NewServiceReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig,
-	enableLoadBalancer bool, remoteRealLoadBalancerClassName string) manager.Reflector {
-	return generic.NewReflector(ServiceReflectorName,
-		NewNamespacedServiceReflector(enableLoadBalancer, remoteRealLoadBalancerClassName), generic.WithoutFallback(),
-		reflectorConfig.NumWorkers, reflectorConfig.Type, generic.ConcurrencyModeLeader)
+func NewServiceReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig, enableLoadBalancer bool,
+	remoteRealLoadBalancerClassName string, loadBalancerClasses, reflectStatusClasses []string) (manager.Reflector, error) {
+	return generic.NewReflectorWithPolicy(ServiceReflectorName,
+		NewNamespacedServiceReflector(enableLoadBalancer, remoteRealLoadBalancerClassName, loadBalancerClasses, reflectStatusClasses),
+		generic.WithoutFallback(),
+		reflectorConfig.NumWorkers, reflectorConfig.Type, reflectorConfig.Policy, generic.ConcurrencyModeLeader)
 }
 
 // NewNamespacedServiceReflector returns a new NamespacedServiceReflector instance.
-func NewNamespacedServiceReflector(
-	enableLoadBalancer bool, remoteRealLoadBalancerClassName string) func(*options.NamespacedOpts) manager.NamespacedReflector {
+func NewNamespacedServiceReflector(enableLoadBalancer bool, remoteRealLoadBalancerClassName string,
+	loadBalancerClasses, reflectStatusClasses []string) func(*options.NamespacedOpts) manager.NamespacedReflector {
 	return func(opts *options.NamespacedOpts) manager.NamespacedReflector {
 		local := opts.LocalFactory.Core().V1().Services()
 		remote := opts.RemoteFactory.Core().V1().Services()
@@ -75,14 +81,18 @@ func NewNamespacedServiceReflector(
 		utilruntime.Must(err)
 		_, err = remote.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
 		utilruntime.Must(err)
+		generic.EnqueueOnNamespaceLabelsChange(opts, local.Informer())
 
 		return &NamespacedServiceReflector{
 			NamespacedReflector:             generic.NewNamespacedReflector(opts, ServiceReflectorName),
 			localServices:                   local.Lister().Services(opts.LocalNamespace),
 			remoteServices:                  remote.Lister().Services(opts.RemoteNamespace),
+			localServicesClient:             opts.LocalClient.CoreV1().Services(opts.LocalNamespace),
 			remoteServicesClient:            opts.RemoteClient.CoreV1().Services(opts.RemoteNamespace),
 			enableLoadBalancer:              enableLoadBalancer,
 			remoteRealLoadBalancerClassName: remoteRealLoadBalancerClassName,
+			loadBalancerClasses:             loadBalancerClasses,
+			reflectStatusClasses:            reflectStatusClasses,
 		}
 	}
 }
@@ -137,11 +147,38 @@ func (nsr *NamespacedServiceReflector) Handle(ctx context.Context, name string)
 		}
 	}
 
+	// Abort the reflection if the local object does not match the reflection policy.
+	if !kerrors.IsNotFound(lerr) {
+		matches, err := nsr.MatchesReflectionPolicy(local)
+		if err != nil {
+			klog.Errorf("Failed to check whether local Service %q matches the reflection policy: %v", nsr.LocalRef(name), err)
+			return err
+		}
+		// Services whose ports are all excluded by the policy are not reflected, as they would be invalid.
+		if !matches || !nsr.ReflectionPolicy().HasReflectablePorts(local) {
+			klog.Infof("Skipping reflection of local Service %q as not matching the reflection policy", nsr.LocalRef(name))
+			nsr.Event(local, corev1.EventTypeNormal, forge.EventReflectionDisabled, forge.EventObjectReflectionPolicyMismatchMsg())
+			if kerrors.IsNotFound(rerr) { // The remote object does not already exist, hence no further action is required.
+				return nil
+			}
+
+			// Otherwise, let pretend the local object does not exist, so that the remote one gets deleted.
+			lerr = kerrors.NewNotFound(corev1.Resource("service"), local.GetName())
+		}
+	}
+
 	tracer.Step("Performed the sanity checks")
 
 	// The local service does no longer exist. Ensure it is also absent from the remote cluster.
 	if kerrors.IsNotFound(lerr) {
 		defer tracer.Step("Ensured the absence of the remote object")
+		if local != nil {
+			// The local object still exists, but it shall no longer be reflected. Hence, release the status possibly reflected from the remote one.
+			if err := nsr.handleStatus(ctx, local, nil); err != nil {
+				return err
+			}
+		}
+
 		if !kerrors.IsNotFound(rerr) {
 			klog.V(4).Infof("Deleting remote Service %q, since local %q does no longer exist", nsr.RemoteRef(name), nsr.LocalRef(name))
 			return nsr.DeleteRemote(ctx, nsr.remoteServicesClient, ServiceReflectorName, name, remote.GetUID())
@@ -152,11 +189,13 @@ func (nsr *NamespacedServiceReflector) Handle(ctx context.Context, name string)
 	}
 
 	// Forge the mutation to be applied to the remote cluster.
-	mutation := forge.RemoteService(local, nsr.RemoteNamespace(), nsr.enableLoadBalancer, nsr.remoteRealLoadBalancerClassName, nsr.ForgingOpts)
+	mutation := forge.RemoteService(nsr.ReflectionPolicy().FilterService(local), nsr.RemoteNamespace(),
+		nsr.enableLoadBalancer, nsr.remoteLoadBalancerClassName(local), nsr.ForgingOpts)
 	tracer.Step("Remote mutation created")
 
 	defer tracer.Step("Enforced the correctness of the remote object")
-	if _, err := nsr.remoteServicesClient.Apply(ctx, mutation, forge.ApplyOptions()); err != nil {
+	remote, err := nsr.remoteServicesClient.Apply(ctx, mutation, forge.ApplyOptions())
+	if err != nil {
 		klog.Errorf("Failed to enforce remote Service %q (local: %q): %v", nsr.RemoteRef(name), nsr.LocalRef(name), err)
 		nsr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
 		return err
@@ -165,6 +204,79 @@ func (nsr *NamespacedServiceReflector) Handle(ctx context.Context, name string)
 	klog.Infof("Remote Service %q successfully enforced (local: %q)", nsr.RemoteRef(name), nsr.LocalRef(name))
 	nsr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
 
+	return nsr.handleStatus(ctx, local, remote)
+}
+
+// remoteLoadBalancerClassName returns the load balancer class the given local service is assigned to in the remote cluster.
+func (nsr *NamespacedServiceReflector) remoteLoadBalancerClassName(local *corev1.Service) string {
+	return forge.RemoteClassName(local.Spec.LoadBalancerClass, nsr.loadBalancerClasses, nsr.remoteRealLoadBalancerClassName)
+}
+
+// handleStatus reflects the load balancer status of the remote service back to the local one, if enabled for its class.
+// A nil remote service means that the local one is no longer reflected, and the status possibly reflected shall be released.
+func (nsr *NamespacedServiceReflector) handleStatus(ctx context.Context, local, remote *corev1.Service) error {
+	enabled := nsr.enableLoadBalancer && local.Spec.Type == corev1.ServiceTypeLoadBalancer &&
+		slices.Contains(nsr.reflectStatusClasses, nsr.remoteLoadBalancerClassName(local))
+	assigned := remote != nil && len(remote.Status.LoadBalancer.Ingress) > 0
+	name := local.GetName()
+
+	switch action, owner := statusReflectionActionFor(local, enabled, assigned); action {
+	case statusReflectionNone:
+		return nil
+
+	case statusReflectionConflict:
+		klog.V(4).Infof("Skipping status reflection of local Service %q, as already reflected from cluster %q", nsr.LocalRef(name), owner)
+		nsr.Event(local, corev1.EventTypeWarning, forge.EventStatusReflectionConflict, forge.EventStatusReflectionConflictMsg(owner))
+		return nil
+
+	case statusReflectionRelease:
+		if mutated := forge.LocalServiceStatus(local, &corev1.Service{}); mutated != nil {
+			updated, err := nsr.localServicesClient.UpdateStatus(ctx, mutated, metav1.UpdateOptions{})
+			if err != nil {
+				klog.Errorf("Failed to clear the status of local Service %q: %v", nsr.LocalRef(name), err)
+				return err
+			}
+			local = updated
+		}
+
+		mutated := local.DeepCopy()
+		releaseStatusReflection(mutated)
+		if _, err := nsr.localServicesClient.Update(ctx, mutated, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Failed to release the status reflection of local Service %q: %v", nsr.LocalRef(name), err)
+			return err
+		}
+
+		klog.Infof("Status reflection of local Service %q successfully released", nsr.LocalRef(name))
+		return nil
+
+	case statusReflectionSync:
+		if owner == "" {
+			// Claim the status reflection first, relying on optimistic concurrency to prevent races with other virtual nodes.
+			mutated := local.DeepCopy()
+			claimStatusReflection(mutated)
+			updated, err := nsr.localServicesClient.Update(ctx, mutated, metav1.UpdateOptions{})
+			if err != nil {
+				klog.Errorf("Failed to claim the status reflection of local Service %q: %v", nsr.LocalRef(name), err)
+				return err
+			}
+			local = updated
+		}
+
+		mutated := forge.LocalServiceStatus(local, remote)
+		if mutated == nil {
+			return nil
+		}
+
+		if _, err := nsr.localServicesClient.UpdateStatus(ctx, mutated, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Failed to reflect the status of remote Service %q to local %q: %v", nsr.RemoteRef(name), nsr.LocalRef(name), err)
+			nsr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedStatusReflectionMsg(err))
+			return err
+		}
+
+		klog.Infof("Status of remote Service %q successfully reflected to local %q", nsr.RemoteRef(name), nsr.LocalRef(name))
+		nsr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulStatusReflectionMsg())
+	}
+
 	return nil
 }
 
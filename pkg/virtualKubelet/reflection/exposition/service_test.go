@@ -46,7 +46,7 @@ var _ = Describe("Service Reflection Tests", func() {
 				NumWorkers: 1,
 				Type:       root.DefaultReflectorsTypes[resources.Service],
 			}
-			Expect(exposition.NewServiceReflector(&reflectorConfig, false, "")).ToNot(BeNil())
+			Expect(exposition.NewServiceReflector(&reflectorConfig, false, "", nil, nil)).ToNot(BeNil())
 		})
 	})
 
@@ -56,6 +56,7 @@ var _ = Describe("Service Reflection Tests", func() {
 		var (
 			reflector      manager.NamespacedReflector
 			reflectionType offloadingv1beta1.ReflectionType
+			reflectStatus  []string
 
 			local, remote corev1.Service
 			err           error
@@ -95,6 +96,7 @@ var _ = Describe("Service Reflection Tests", func() {
 			local = corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: ServiceName, Namespace: LocalNamespace}}
 			remote = corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: ServiceName, Namespace: RemoteNamespace}}
 			reflectionType = root.DefaultReflectorsTypes[resources.Service]
+			reflectStatus = nil
 		})
 
 		AfterEach(func() {
@@ -106,7 +108,7 @@ var _ = Describe("Service Reflection Tests", func() {
 
 		JustBeforeEach(func() {
 			factory := informers.NewSharedInformerFactory(client, 10*time.Hour)
-			reflector = exposition.NewNamespacedServiceReflector(false, "")(options.NewNamespaced().
+			reflector = exposition.NewNamespacedServiceReflector(true, "default", []string{"default", "other"}, reflectStatus)(options.NewNamespaced().
 				WithLocal(LocalNamespace, client, factory).
 				WithRemote(RemoteNamespace, client, factory).
 				WithHandlerFactory(FakeEventHandler).
@@ -180,6 +182,68 @@ var _ = Describe("Service Reflection Tests", func() {
 				})
 			})
 
+			When("the remote object already exists, and has been assigned an external address", func() {
+				BeforeEach(func() {
+					remote.SetLabels(forge.ReflectionLabels())
+					remote.Spec = corev1.ServiceSpec{
+						Type:  corev1.ServiceTypeLoadBalancer,
+						Ports: []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080)}},
+					}
+					created := CreateService(&remote)
+					created.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "1.1.1.1"}}
+					_, err = client.CoreV1().Services(RemoteNamespace).UpdateStatus(ctx, created, metav1.UpdateOptions{})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				When("status reflection is disabled", func() {
+					It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+					It("the local status should not have been modified", func() {
+						localAfter := GetService(LocalNamespace)
+						Expect(localAfter.Status.LoadBalancer.Ingress).To(BeEmpty())
+						Expect(localAfter.Annotations).ToNot(HaveKey(consts.StatusReflectedFromAnnotationKey))
+					})
+				})
+
+				When("status reflection is enabled", func() {
+					BeforeEach(func() { reflectStatus = []string{"default"} })
+
+					It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+					It("the local status should have been reflected from the remote object", func() {
+						localAfter := GetService(LocalNamespace)
+						Expect(localAfter.Status.LoadBalancer.Ingress).To(ConsistOf(corev1.LoadBalancerIngress{IP: "1.1.1.1"}))
+						Expect(localAfter.Annotations).To(HaveKeyWithValue(consts.StatusReflectedFromAnnotationKey, RemoteClusterID))
+					})
+				})
+
+				When("status reflection is enabled only for a different class", func() {
+					BeforeEach(func() { reflectStatus = []string{"other"} })
+
+					It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+					It("the local status should not have been modified", func() {
+						localAfter := GetService(LocalNamespace)
+						Expect(localAfter.Status.LoadBalancer.Ingress).To(BeEmpty())
+						Expect(localAfter.Annotations).ToNot(HaveKey(consts.StatusReflectedFromAnnotationKey))
+					})
+				})
+
+				When("status reflection is enabled, but the status is already reflected from a different cluster", func() {
+					BeforeEach(func() {
+						reflectStatus = []string{"default"}
+						localBefore := GetService(LocalNamespace)
+						localBefore.Annotations[consts.StatusReflectedFromAnnotationKey] = "other-cluster"
+						_, err = client.CoreV1().Services(LocalNamespace).Update(ctx, localBefore, metav1.UpdateOptions{})
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+					It("the local status should not have been modified", func() {
+						localAfter := GetService(LocalNamespace)
+						Expect(localAfter.Status.LoadBalancer.Ingress).To(BeEmpty())
+						Expect(localAfter.Annotations).To(HaveKeyWithValue(consts.StatusReflectedFromAnnotationKey, "other-cluster"))
+					})
+				})
+			})
+
 			When("the remote object already exists, but is not managed by the reflection", func() {
 				var remoteBefore *corev1.Service
 
@@ -0,0 +1,81 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exposition
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	"github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+// statusReflectionAction represents the action to be performed to reflect the status of a remote object back to the local one.
+type statusReflectionAction int
+
+const (
+	// statusReflectionNone -> no action is required.
+	statusReflectionNone statusReflectionAction = iota
+	// statusReflectionConflict -> the status is already reflected from a different remote cluster, hence it shall not be touched.
+	statusReflectionConflict
+	// statusReflectionSync -> the status shall be claimed (if not already) and aligned with the remote one.
+	statusReflectionSync
+	// statusReflectionRelease -> the status previously reflected shall be cleared, and the claim released.
+	statusReflectionRelease
+)
+
+// statusReflectionOwner returns the ID of the remote cluster the status of the given local object is currently reflected from, if any.
+func statusReflectionOwner(local metav1.Object) (liqov1beta1.ClusterID, bool) {
+	owner, found := local.GetAnnotations()[consts.StatusReflectedFromAnnotationKey]
+	return liqov1beta1.ClusterID(owner), found
+}
+
+// statusReflectionActionFor returns the action to be performed concerning the status of the given local object, depending on
+// whether status reflection is enabled and the remote object has been assigned an address. The status of a local object can
+// be reflected from a single remote cluster at a time: the first one claiming it (through the corresponding annotation) wins,
+// while the others back off until it is released (i.e., the address is removed, or status reflection disabled).
+func statusReflectionActionFor(local metav1.Object, enabled, assigned bool) (statusReflectionAction, liqov1beta1.ClusterID) {
+	owner, found := statusReflectionOwner(local)
+	switch {
+	case found && owner != forge.RemoteCluster:
+		if enabled && assigned {
+			return statusReflectionConflict, owner
+		}
+		return statusReflectionNone, owner
+	case enabled && assigned:
+		return statusReflectionSync, owner
+	case found:
+		return statusReflectionRelease, owner
+	default:
+		return statusReflectionNone, owner
+	}
+}
+
+// claimStatusReflection sets the annotation claiming the status reflection from the current remote cluster on the given object.
+func claimStatusReflection(local metav1.Object) {
+	annotations := local.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[consts.StatusReflectedFromAnnotationKey] = string(forge.RemoteCluster)
+	local.SetAnnotations(annotations)
+}
+
+// releaseStatusReflection removes the annotation claiming the status reflection from the given object.
+func releaseStatusReflection(local metav1.Object) {
+	annotations := local.GetAnnotations()
+	delete(annotations, consts.StatusReflectedFromAnnotationKey)
+	local.SetAnnotations(annotations)
+}
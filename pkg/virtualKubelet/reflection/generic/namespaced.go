@@ -22,8 +22,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
@@ -32,6 +36,7 @@ import (
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/policy"
 )
 
 // NamespacedReflector implements the logic common to all namespaced reflectors.
@@ -43,7 +48,9 @@ type NamespacedReflector struct {
 	local  string
 	remote string
 
-	reflectionType offloadingv1beta1.ReflectionType
+	reflectionType   offloadingv1beta1.ReflectionType
+	reflectionPolicy *policy.Policy
+	localNamespaces  corev1listers.NamespaceLister
 
 	ForgingOpts *forge.ForgingOpts
 }
@@ -55,11 +62,50 @@ type ResourceDeleter interface {
 
 // NewNamespacedReflector returns a new NamespacedReflector for the given namespaces.
 func NewNamespacedReflector(opts *options.NamespacedOpts, name string) NamespacedReflector {
-	return NamespacedReflector{
+	gnr := NamespacedReflector{
 		EventRecorder: opts.EventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "liqo-" + strings.ToLower(name) + "-reflection"}),
 		local:         opts.LocalNamespace, remote: opts.RemoteNamespace, ready: opts.Ready,
-		reflectionType: opts.ReflectionType, ForgingOpts: opts.ForgingOpts,
+		reflectionType: opts.ReflectionType, reflectionPolicy: opts.ReflectionPolicy,
+		ForgingOpts: opts.ForgingOpts,
 	}
+
+	if opts.ReflectionPolicy.SelectsNamespaces() {
+		gnr.localNamespaces = opts.LocalFactory.Core().V1().Namespaces().Lister()
+	}
+	return gnr
+}
+
+// EnqueueOnNamespaceLabelsChange configures the re-evaluation of all the local objects cached by the given informer whenever
+// the labels of the local namespace change, in case the reflection policy depends on them. This ensures that the objects are
+// reflected (or their remote counterparts are deleted) as soon as the namespace starts (or stops) matching the policy.
+func EnqueueOnNamespaceLabelsChange(opts *options.NamespacedOpts, local cache.SharedIndexInformer) {
+	if !opts.ReflectionPolicy.SelectsNamespaces() {
+		return
+	}
+
+	// The handlers registered to a given informer are invoked sequentially, hence no synchronization is required.
+	var observed labels.Set
+	keyer := func(metadata metav1.Object) []types.NamespacedName {
+		if metadata.GetName() != opts.LocalNamespace || (observed != nil && labels.Equals(observed, metadata.GetLabels())) {
+			return nil
+		}
+		observed = labels.Merge(labels.Set{}, metadata.GetLabels())
+
+		keys := local.GetStore().ListKeys()
+		names := make([]types.NamespacedName, 0, len(keys))
+		for _, key := range keys {
+			_, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				klog.Errorf("Failed to parse key %q: %v", key, err)
+				continue
+			}
+			names = append(names, types.NamespacedName{Namespace: opts.LocalNamespace, Name: name})
+		}
+		return names
+	}
+
+	_, err := opts.LocalFactory.Core().V1().Namespaces().Informer().AddEventHandler(opts.HandlerFactory(keyer))
+	utilruntime.Must(err)
 }
 
 // Ready returns whether the NamespacedReflector is completely initialized.
@@ -136,6 +182,26 @@ func (gnr *NamespacedReflector) GetReflectionType() offloadingv1beta1.Reflection
 	return gnr.reflectionType
 }
 
+// ReflectionPolicy returns the reflection policy of the reflector (nil if not configured).
+func (gnr *NamespacedReflector) ReflectionPolicy() *policy.Policy {
+	return gnr.reflectionPolicy
+}
+
+// MatchesReflectionPolicy returns whether the given object matches the reflection policy of the reflector,
+// hence it should be reflected as far as the policy is concerned.
+func (gnr *NamespacedReflector) MatchesReflectionPolicy(obj metav1.Object) (bool, error) {
+	var namespaceLabels labels.Set
+	if gnr.reflectionPolicy.SelectsNamespaces() {
+		namespace, err := gnr.localNamespaces.Get(gnr.local)
+		if err != nil {
+			return false, fmt.Errorf("failed to retrieve local namespace %q: %w", gnr.local, err)
+		}
+		namespaceLabels = namespace.GetLabels()
+	}
+
+	return gnr.reflectionPolicy.Matches(obj, namespaceLabels), nil
+}
+
 // ForcedAllowOrSkip checks whether the given object is *explicitly* marked to be allowed or skipped
 // (i.e., it has the allow or the deny annotation), independently from the reflection policy.
 // If so, it returns whether the object should be skipped, or an error if unable to determine it.
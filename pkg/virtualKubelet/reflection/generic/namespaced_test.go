@@ -22,8 +22,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	corev1clients "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
@@ -31,6 +33,7 @@ import (
 	. "github.com/liqotech/liqo/pkg/utils/testutil"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/policy"
 )
 
 var _ = Describe("NamespacedReflector tests", func() {
@@ -126,4 +129,75 @@ var _ = Describe("NamespacedReflector tests", func() {
 			})
 		})
 	})
+
+	Context("the reflection policy selecting the namespaces", func() {
+		var (
+			ctx      context.Context
+			cancel   context.CancelFunc
+			client   *fake.Clientset
+			nsrfl    NamespacedReflector
+			enqueued chan types.NamespacedName
+		)
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+			enqueued = make(chan types.NamespacedName, 10)
+			client = fake.NewSimpleClientset(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: localNamespace, Labels: map[string]string{"team": "foo"}}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: localNamespace}},
+			)
+
+			reflectionPolicy, err := policy.New(&offloadingv1beta1.ReflectionPolicy{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "foo"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			factory := informers.NewSharedInformerFactoryWithOptions(client, 0, informers.WithNamespace(localNamespace))
+			opts := options.NamespacedOpts{
+				LocalNamespace: localNamespace, RemoteNamespace: remoteNamespace, LocalFactory: factory,
+				EventBroadcaster: record.NewBroadcaster(), ReflectionPolicy: reflectionPolicy,
+				HandlerFactory: func(keyer options.Keyer, _ ...options.EventFilter) cache.ResourceEventHandler {
+					handle := func(obj interface{}) {
+						for _, key := range keyer(obj.(metav1.Object)) {
+							enqueued <- key
+						}
+					}
+					return cache.ResourceEventHandlerFuncs{AddFunc: handle, UpdateFunc: func(_, obj interface{}) { handle(obj) }}
+				},
+			}
+
+			EnqueueOnNamespaceLabelsChange(&opts, factory.Core().V1().ConfigMaps().Informer())
+			nsrfl = NewNamespacedReflector(&opts, name)
+
+			factory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+		})
+
+		AfterEach(func() { cancel() })
+
+		It("should evaluate the policy against the cached namespace labels", func() {
+			Expect(nsrfl.MatchesReflectionPolicy(&metav1.ObjectMeta{Name: name})).To(BeTrue())
+		})
+
+		When("the labels of the namespace change", func() {
+			BeforeEach(func() {
+				// Drain the keys possibly enqueued by the initial synchronization.
+				for len(enqueued) > 0 {
+					<-enqueued
+				}
+
+				_, err := client.CoreV1().Namespaces().Update(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name: localNamespace, Labels: map[string]string{"team": "bar"}}}, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should enqueue the objects of the namespace", func() {
+				Eventually(enqueued).Should(Receive(Equal(types.NamespacedName{Namespace: localNamespace, Name: name})))
+			})
+
+			It("should no longer match the policy", func() {
+				Eventually(func() (bool, error) { return nsrfl.MatchesReflectionPolicy(&metav1.ObjectMeta{Name: name}) }).Should(BeFalse())
+			})
+		})
+	})
 })
@@ -39,6 +39,7 @@ import (
 	"github.com/liqotech/liqo/pkg/virtualKubelet/metrics"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/policy"
 )
 
 var _ manager.Reflector = (*reflector)(nil)
@@ -65,8 +66,9 @@ type reflector struct {
 	namespacedFactory NamespacedReflectorFactoryFunc
 	fallbackFactory   FallbackReflectorFactoryFunc
 
-	concurrencyMode ConcurrencyMode
-	reflectionType  offloadingv1beta1.ReflectionType
+	concurrencyMode  ConcurrencyMode
+	reflectionType   offloadingv1beta1.ReflectionType
+	reflectionPolicy *policy.Policy
 }
 
 // String returns the name of the reflector.
@@ -95,6 +97,26 @@ func NewReflector(name string, namespaced NamespacedReflectorFactoryFunc, fallba
 	return newReflector(name, namespaced, fallback, workers, reflectionType, concurrencyMode)
 }
 
+// NewReflectorWithPolicy returns a new reflector, as NewReflector does, which further restricts the reflected objects
+// and their fields according to the given reflection policy. It returns an error if the policy is not valid.
+func NewReflectorWithPolicy(name string, namespaced NamespacedReflectorFactoryFunc, fallback FallbackReflectorFactoryFunc,
+	workers uint, reflectionType offloadingv1beta1.ReflectionType, reflectionPolicy *offloadingv1beta1.ReflectionPolicy,
+	concurrencyMode ConcurrencyMode) (manager.Reflector, error) {
+	compiled, err := policy.New(reflectionPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reflection policy for the %v reflector: %w", name, err)
+	}
+
+	if workers == 0 {
+		// Return a dummy reflector in case no workers are specified, to avoid starting the working queue and registering the infromers.
+		return &dummyreflector{name: name}, nil
+	}
+
+	rfl := newReflector(name, namespaced, fallback, workers, reflectionType, concurrencyMode).(*reflector)
+	rfl.reflectionPolicy = compiled
+	return rfl, nil
+}
+
 // newReflector returns a new reflector to implement the reflection towards a remote clusters.
 func newReflector(name string, namespaced NamespacedReflectorFactoryFunc, fallback FallbackReflectorFactoryFunc,
 	workers uint, reflectionType offloadingv1beta1.ReflectionType, concurrencyMode ConcurrencyMode) manager.Reflector {
@@ -145,7 +167,8 @@ func (gr *reflector) StartNamespace(opts *options.NamespacedOpts) {
 
 	gr.reflectors[opts.LocalNamespace] = gr.namespacedFactory(opts.
 		WithHandlerFactory(gr.handlers).
-		WithReflectionType(gr.reflectionType))
+		WithReflectionType(gr.reflectionType).
+		WithReflectionPolicy(gr.reflectionPolicy))
 
 	// In case a fallback reflector exists, re-enqueue all the elements returned for the given namespace.
 	if gr.fallback != nil {
@@ -22,6 +22,9 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
@@ -38,6 +41,11 @@ import (
 
 var _ Manager = (*manager)(nil)
 
+// dynamicCacheSyncTimeout is the maximum time waited for the synchronization of the dynamic informers before
+// marking the namespace reflection as ready. The dynamic informers refer to arbitrary custom resources, whose caches
+// might never sync (e.g., because the CRD is missing in one of the clusters, or access is forbidden).
+const dynamicCacheSyncTimeout = 30 * time.Second
+
 // manager is an object managing the reflection of objects between the local and the remote cluster.
 type manager struct {
 	sync.Mutex
@@ -46,6 +54,8 @@ type manager struct {
 	remote           kubernetes.Interface
 	localLiqo        liqoclient.Interface
 	remoteLiqo       liqoclient.Interface
+	localDyn         dynamic.Interface
+	remoteDyn        dynamic.Interface
 	resync           time.Duration
 	eventBroadcaster record.EventBroadcaster
 
@@ -61,8 +71,8 @@ type manager struct {
 }
 
 // New returns a new manager to start the reflection towards a remote cluster.
-func New(local, remote kubernetes.Interface, localLiqo, remoteLiqo liqoclient.Interface, resync time.Duration,
-	eb record.EventBroadcaster, forgingOpts *forge.ForgingOpts) Manager {
+func New(local, remote kubernetes.Interface, localLiqo, remoteLiqo liqoclient.Interface, localDyn, remoteDyn dynamic.Interface,
+	resync time.Duration, eb record.EventBroadcaster, forgingOpts *forge.ForgingOpts) Manager {
 	// Configure the field selector to retrieve only the pods scheduled on the current virtual node.
 	localPodTweakListOptions := func(opts *metav1.ListOptions) {
 		opts.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", forge.LiqoNodeName).String()
@@ -73,6 +83,8 @@ func New(local, remote kubernetes.Interface, localLiqo, remoteLiqo liqoclient.In
 		remote:           remote,
 		localLiqo:        localLiqo,
 		remoteLiqo:       remoteLiqo,
+		localDyn:         localDyn,
+		remoteDyn:        remoteDyn,
 		resync:           resync,
 		eventBroadcaster: eb,
 
@@ -166,17 +178,20 @@ func (m *manager) StartNamespace(local, remote string) {
 	// The local informer factories, which select all resources in the given namespace.
 	localFactory := informers.NewSharedInformerFactoryWithOptions(m.local, m.resync, informers.WithNamespace(local))
 	localLiqoFactory := liqoinformers.NewSharedInformerFactoryWithOptions(m.localLiqo, m.resync, liqoinformers.WithNamespace(local))
+	localDynFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(m.localDyn, m.resync, local, nil)
 
 	// The remote informer factories, which select all resources in the given namespace.
 	// We do not filter the resources by label selector, to be able to abort reflection in case the remote object already exists.
 	remoteFactory := informers.NewSharedInformerFactoryWithOptions(m.remote, m.resync, informers.WithNamespace(remote))
 	remoteLiqoFactory := liqoinformers.NewSharedInformerFactoryWithOptions(m.remoteLiqo, m.resync, liqoinformers.WithNamespace(remote))
+	remoteDynFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(m.remoteDyn, m.resync, remote, nil)
 
 	ready := false
 	for _, reflector := range m.reflectors {
 		opts := options.NewNamespaced().
 			WithLocal(local, m.local, localFactory).WithLiqoLocal(m.localLiqo, localLiqoFactory).
 			WithRemote(remote, m.remote, remoteFactory).WithLiqoRemote(m.remoteLiqo, remoteLiqoFactory).
+			WithDynLocal(m.localDyn, localDynFactory).WithDynRemote(m.remoteDyn, remoteDynFactory).
 			WithReadinessFunc(func() bool { return ready }).WithEventBroadcaster(m.eventBroadcaster).
 			WithForgingOpts(&m.forgingOpts)
 		reflector.StartNamespace(opts)
@@ -192,12 +207,21 @@ func (m *manager) StartNamespace(local, remote string) {
 		localLiqoFactory.Start(ctx.Done())
 		remoteFactory.Start(ctx.Done())
 		remoteLiqoFactory.Start(ctx.Done())
+		localDynFactory.Start(ctx.Done())
+		remoteDynFactory.Start(ctx.Done())
 
 		localFactory.WaitForCacheSync(ctx.Done())
 		localLiqoFactory.WaitForCacheSync(ctx.Done())
 		remoteFactory.WaitForCacheSync(ctx.Done())
 		remoteLiqoFactory.WaitForCacheSync(ctx.Done())
 
+		// The dynamic informers are waited for with a timeout, to prevent a single custom resource from stalling the
+		// reflection of all the other resources. The corresponding reflectors wait for their own informers to sync.
+		dynCtx, dynCancel := context.WithTimeout(ctx, dynamicCacheSyncTimeout)
+		localDynSynced := localDynFactory.WaitForCacheSync(dynCtx.Done())
+		remoteDynSynced := remoteDynFactory.WaitForCacheSync(dynCtx.Done())
+		dynCancel()
+
 		// If the context was closed before the cache was ready, let abort the setup
 		select {
 		case <-ctx.Done():
@@ -206,12 +230,25 @@ func (m *manager) StartNamespace(local, remote string) {
 			break
 		}
 
+		warnUnsyncedDynamicInformers("local", local, localDynSynced)
+		warnUnsyncedDynamicInformers("remote", remote, remoteDynSynced)
+
 		// The factories have synced, and we are now ready to start te replication
 		klog.Infof("Reflection between local namespace %q and remote namespace %q correctly started", local, remote)
 		ready = true
 	}()
 }
 
+// warnUnsyncedDynamicInformers outputs a warning for each dynamic informer whose cache did not sync.
+func warnUnsyncedDynamicInformers(cluster, namespace string, synced map[schema.GroupVersionResource]bool) {
+	for gvr, ok := range synced {
+		if !ok {
+			klog.Warningf("Cache of %v in %v namespace %q not yet synced (is the CRD missing, or is access forbidden?): "+
+				"the reflection of this resource is suspended until it syncs", gvr.GroupResource(), cluster, namespace)
+		}
+	}
+}
+
 // StopNamespace stops the reflection for a given namespace.
 func (m *manager) StopNamespace(local, remote string) {
 	m.Lock()
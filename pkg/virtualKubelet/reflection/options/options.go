@@ -18,6 +18,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -28,6 +30,7 @@ import (
 	liqoclient "github.com/liqotech/liqo/pkg/client/clientset/versioned"
 	liqoinformers "github.com/liqotech/liqo/pkg/client/informers/externalversions"
 	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/policy"
 )
 
 // Keyer retrieves a set of NamespacedNames referring to the reconciliation targets from the object metadata.
@@ -79,19 +82,24 @@ type NamespacedOpts struct {
 	RemoteClient     kubernetes.Interface
 	LocalLiqoClient  liqoclient.Interface
 	RemoteLiqoClient liqoclient.Interface
+	LocalDynClient   dynamic.Interface
+	RemoteDynClient  dynamic.Interface
 
 	LocalFactory      informers.SharedInformerFactory
 	RemoteFactory     informers.SharedInformerFactory
 	LocalLiqoFactory  liqoinformers.SharedInformerFactory
 	RemoteLiqoFactory liqoinformers.SharedInformerFactory
+	LocalDynFactory   dynamicinformer.DynamicSharedInformerFactory
+	RemoteDynFactory  dynamicinformer.DynamicSharedInformerFactory
 
 	EventBroadcaster record.EventBroadcaster
 
 	Ready          func() bool
 	HandlerFactory func(Keyer, ...EventFilter) cache.ResourceEventHandler
 
-	ForgingOpts    *forge.ForgingOpts
-	ReflectionType offloadingv1beta1.ReflectionType
+	ForgingOpts      *forge.ForgingOpts
+	ReflectionType   offloadingv1beta1.ReflectionType
+	ReflectionPolicy *policy.Policy
 }
 
 // NewNamespaced returns a new NamespacedOpts object.
@@ -129,6 +137,20 @@ func (ro *NamespacedOpts) WithLiqoRemote(client liqoclient.Interface, factory li
 	return ro
 }
 
+// WithDynLocal configures the local dynamic client and informer factory parameters of the NamespacedOpts.
+func (ro *NamespacedOpts) WithDynLocal(client dynamic.Interface, factory dynamicinformer.DynamicSharedInformerFactory) *NamespacedOpts {
+	ro.LocalDynClient = client
+	ro.LocalDynFactory = factory
+	return ro
+}
+
+// WithDynRemote configures the remote dynamic client and informer factory parameters of the NamespacedOpts.
+func (ro *NamespacedOpts) WithDynRemote(client dynamic.Interface, factory dynamicinformer.DynamicSharedInformerFactory) *NamespacedOpts {
+	ro.RemoteDynClient = client
+	ro.RemoteDynFactory = factory
+	return ro
+}
+
 // WithHandlerFactory configures the handler factory of the NamespacedOpts.
 func (ro *NamespacedOpts) WithHandlerFactory(handler func(Keyer, ...EventFilter) cache.ResourceEventHandler) *NamespacedOpts {
 	ro.HandlerFactory = handler
@@ -159,6 +181,12 @@ func (ro *NamespacedOpts) WithReflectionType(reflectionType offloadingv1beta1.Re
 	return ro
 }
 
+// WithReflectionPolicy configures the reflection policy of the NamespacedOpts.
+func (ro *NamespacedOpts) WithReflectionPolicy(reflectionPolicy *policy.Policy) *NamespacedOpts {
+	ro.ReflectionPolicy = reflectionPolicy
+	return ro
+}
+
 // EventFilterCreate ignores events of type create.
 func EventFilterCreate(et watch.EventType) bool { return et == watch.Added }
 
@@ -19,6 +19,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/informers"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -132,6 +136,8 @@ var _ = Describe("Options", func() {
 			liqoClient     liqoclient.Interface
 			factory        informers.SharedInformerFactory
 			liqoFactory    liqoinformers.SharedInformerFactory
+			dynClient      dynamic.Interface
+			dynFactory     dynamicinformer.DynamicSharedInformerFactory
 			broadcaster    record.EventBroadcaster
 			reflectionType offloadingv1beta1.ReflectionType
 			forgingOpts    *forge.ForgingOpts
@@ -142,6 +148,8 @@ var _ = Describe("Options", func() {
 			liqoClient = liqoclientfake.NewSimpleClientset()
 			factory = informers.NewSharedInformerFactory(client, 10*time.Hour)
 			liqoFactory = liqoinformers.NewSharedInformerFactory(liqoClient, 10*time.Hour)
+			dynClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+			dynFactory = dynamicinformer.NewDynamicSharedInformerFactory(dynClient, 10*time.Hour)
 			broadcaster = record.NewBroadcaster()
 			reflectionType = offloadingv1beta1.CustomLiqo
 			forgingOpts = &forge.ForgingOpts{}
@@ -221,6 +229,54 @@ var _ = Describe("Options", func() {
 			})
 		})
 
+		Describe("The WithDynLocal function", func() {
+			JustBeforeEach(func() { opts = original.WithDynLocal(dynClient, dynFactory) })
+
+			It("should return a non-nil pointer", func() { Expect(opts).ToNot(BeNil()) })
+			It("should return the same pointer of the receiver", func() { Expect(opts).To(BeIdenticalTo(original)) })
+			It("should correctly set the local dynamic client value", func() { Expect(opts.LocalDynClient).To(BeIdenticalTo(dynClient)) })
+			It("should correctly set the local dynamic factory value", func() { Expect(opts.LocalDynFactory).To(BeIdenticalTo(dynFactory)) })
+			It("should leave the other fields unset", func() {
+				Expect(opts.LocalNamespace).To(BeEmpty())
+				Expect(opts.RemoteNamespace).To(BeEmpty())
+				Expect(opts.LocalClient).To(BeNil())
+				Expect(opts.LocalFactory).To(BeNil())
+				Expect(opts.RemoteClient).To(BeNil())
+				Expect(opts.RemoteFactory).To(BeNil())
+				Expect(opts.RemoteDynClient).To(BeNil())
+				Expect(opts.RemoteDynFactory).To(BeNil())
+				Expect(opts.EventBroadcaster).To(BeNil())
+				Expect(opts.HandlerFactory).To(BeNil())
+				Expect(opts.Ready).To(BeNil())
+				Expect(opts.ReflectionType).To(BeEmpty())
+				Expect(opts.ForgingOpts).To(BeNil())
+			})
+		})
+
+		Describe("The WithDynRemote function", func() {
+			JustBeforeEach(func() { opts = original.WithDynRemote(dynClient, dynFactory) })
+
+			It("should return a non-nil pointer", func() { Expect(opts).ToNot(BeNil()) })
+			It("should return the same pointer of the receiver", func() { Expect(opts).To(BeIdenticalTo(original)) })
+			It("should correctly set the remote dynamic client value", func() { Expect(opts.RemoteDynClient).To(BeIdenticalTo(dynClient)) })
+			It("should correctly set the remote dynamic factory value", func() { Expect(opts.RemoteDynFactory).To(BeIdenticalTo(dynFactory)) })
+			It("should leave the other fields unset", func() {
+				Expect(opts.LocalNamespace).To(BeEmpty())
+				Expect(opts.RemoteNamespace).To(BeEmpty())
+				Expect(opts.LocalClient).To(BeNil())
+				Expect(opts.LocalFactory).To(BeNil())
+				Expect(opts.LocalDynClient).To(BeNil())
+				Expect(opts.LocalDynFactory).To(BeNil())
+				Expect(opts.RemoteClient).To(BeNil())
+				Expect(opts.RemoteFactory).To(BeNil())
+				Expect(opts.EventBroadcaster).To(BeNil())
+				Expect(opts.HandlerFactory).To(BeNil())
+				Expect(opts.Ready).To(BeNil())
+				Expect(opts.ReflectionType).To(BeEmpty())
+				Expect(opts.ForgingOpts).To(BeNil())
+			})
+		})
+
 		Describe("The WithLiqoRemote function", func() {
 			JustBeforeEach(func() { opts = original.WithLiqoRemote(liqoClient, liqoFactory) })
 
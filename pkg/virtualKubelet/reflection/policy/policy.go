@@ -0,0 +1,212 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+)
+
+// Policy is the compiled representation of a ReflectionPolicy.
+// A nil Policy matches all objects, and does not filter any field.
+type Policy struct {
+	namespaceSelector labels.Selector
+	objectSelector    labels.Selector
+	nameRegex         *regexp.Regexp
+	excludedKeys      sets.Set[string]
+	excludedPorts     sets.Set[string]
+}
+
+// New compiles the given ReflectionPolicy. It returns a nil Policy if the given one is nil.
+func New(spec *offloadingv1beta1.ReflectionPolicy) (*Policy, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	p := &Policy{
+		excludedKeys:  sets.New(spec.ExcludedKeys...),
+		excludedPorts: sets.New(spec.ExcludedPorts...),
+	}
+
+	var err error
+	if spec.NamespaceSelector != nil {
+		if p.namespaceSelector, err = metav1.LabelSelectorAsSelector(spec.NamespaceSelector); err != nil {
+			return nil, fmt.Errorf("invalid namespace selector: %w", err)
+		}
+	}
+	if spec.ObjectSelector != nil {
+		if p.objectSelector, err = metav1.LabelSelectorAsSelector(spec.ObjectSelector); err != nil {
+			return nil, fmt.Errorf("invalid object selector: %w", err)
+		}
+	}
+	if spec.NameRegex != "" {
+		if p.nameRegex, err = regexp.Compile(spec.NameRegex); err != nil {
+			return nil, fmt.Errorf("invalid name regex: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// SelectsNamespaces returns whether the policy depends on the labels of the namespace of the objects.
+func (p *Policy) SelectsNamespaces() bool {
+	return p != nil && p.namespaceSelector != nil
+}
+
+// Matches returns whether the given object, living in a namespace with the given labels, should be reflected.
+func (p *Policy) Matches(obj metav1.Object, namespaceLabels labels.Set) bool {
+	if p == nil {
+		return true
+	}
+
+	if p.namespaceSelector != nil && !p.namespaceSelector.Matches(namespaceLabels) {
+		return false
+	}
+	if p.objectSelector != nil && !p.objectSelector.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+	if p.nameRegex != nil && !p.nameRegex.MatchString(obj.GetName()) {
+		return false
+	}
+	return true
+}
+
+// FilterConfigMap returns the given ConfigMap, stripped of the excluded data keys.
+// The original object is never modified, and a copy is returned only if some keys are removed.
+func (p *Policy) FilterConfigMap(cm *corev1.ConfigMap) *corev1.ConfigMap {
+	if p == nil || p.excludedKeys.Len() == 0 || (!hasAnyKey(cm.Data, p.excludedKeys) && !hasAnyKey(cm.BinaryData, p.excludedKeys)) {
+		return cm
+	}
+
+	filtered := cm.DeepCopy()
+	for key := range p.excludedKeys {
+		delete(filtered.Data, key)
+		delete(filtered.BinaryData, key)
+	}
+	return filtered
+}
+
+// FilterSecret returns the given Secret, stripped of the excluded data keys.
+// The original object is never modified, and a copy is returned only if some keys are removed.
+func (p *Policy) FilterSecret(secret *corev1.Secret) *corev1.Secret {
+	if p == nil || p.excludedKeys.Len() == 0 || (!hasAnyKey(secret.Data, p.excludedKeys) && !hasAnyKey(secret.StringData, p.excludedKeys)) {
+		return secret
+	}
+
+	filtered := secret.DeepCopy()
+	for key := range p.excludedKeys {
+		delete(filtered.Data, key)
+		delete(filtered.StringData, key)
+	}
+	return filtered
+}
+
+// HasReflectableKeys returns whether the given Secret retains the keys required by its type once stripped of the excluded
+// ones. Secrets missing any of them shall not be reflected, as they would be invalid.
+func (p *Policy) HasReflectableKeys(secret *corev1.Secret) bool {
+	if p == nil || p.excludedKeys.Len() == 0 {
+		return true
+	}
+
+	if secret.Type == corev1.SecretTypeBasicAuth {
+		// Basic authentication secrets require at least one of the two keys.
+		return slices.ContainsFunc([]string{corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey}, func(key string) bool {
+			_, ok := secret.Data[key]
+			return ok && !p.excludedKeys.Has(key)
+		})
+	}
+	return !p.excludedKeys.HasAny(requiredSecretKeys[secret.Type]...)
+}
+
+// requiredSecretKeys are the keys which the Secrets of the given types must contain to pass the API validation.
+var requiredSecretKeys = map[corev1.SecretType][]string{
+	corev1.SecretTypeTLS:              {corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+	corev1.SecretTypeDockerConfigJson: {corev1.DockerConfigJsonKey},
+	corev1.SecretTypeDockercfg:        {corev1.DockerConfigKey},
+	corev1.SecretTypeSSHAuth:          {corev1.SSHAuthPrivateKey},
+}
+
+// FilterService returns the given Service, stripped of the excluded ports.
+// The original object is never modified, and a copy is returned only if some ports are removed.
+func (p *Policy) FilterService(svc *corev1.Service) *corev1.Service {
+	if p == nil || p.excludedPorts.Len() == 0 || !slices.ContainsFunc(svc.Spec.Ports, p.isExcludedPort) {
+		return svc
+	}
+
+	filtered := svc.DeepCopy()
+	filtered.Spec.Ports = slices.DeleteFunc(filtered.Spec.Ports, p.isExcludedPort)
+	return filtered
+}
+
+// HasReflectablePorts returns whether the given Service has at least one port which is not excluded by the policy,
+// or no ports at all. Services whose ports are all excluded shall not be reflected, as they would be invalid.
+func (p *Policy) HasReflectablePorts(svc *corev1.Service) bool {
+	return p == nil || len(svc.Spec.Ports) == 0 || slices.ContainsFunc(svc.Spec.Ports, func(port corev1.ServicePort) bool {
+		return !p.isExcludedPort(port)
+	})
+}
+
+// FilterEndpointSlice returns the given EndpointSlice, stripped of the ports corresponding to the excluded ports of the
+// given Service. EndpointSlice ports are matched by name, as their numbers refer to the target ports of the Service.
+// If some ports are excluded and the Service is nil, false is returned, as the EndpointSlice cannot be filtered until
+// the Service is known. The original object is never modified, and a copy is returned only if some ports are removed.
+func (p *Policy) FilterEndpointSlice(eps *discoveryv1.EndpointSlice, svc *corev1.Service) (*discoveryv1.EndpointSlice, bool) {
+	if p == nil || p.excludedPorts.Len() == 0 {
+		return eps, true
+	}
+	if svc == nil {
+		return nil, false
+	}
+
+	excluded := sets.New[string]()
+	for i := range svc.Spec.Ports {
+		if p.isExcludedPort(svc.Spec.Ports[i]) {
+			excluded.Insert(svc.Spec.Ports[i].Name)
+		}
+	}
+
+	isExcluded := func(port discoveryv1.EndpointPort) bool { return excluded.Has(ptr.Deref(port.Name, "")) }
+	if !slices.ContainsFunc(eps.Ports, isExcluded) {
+		return eps, true
+	}
+
+	filtered := eps.DeepCopy()
+	filtered.Ports = slices.DeleteFunc(filtered.Ports, isExcluded)
+	return filtered, true
+}
+
+func (p *Policy) isExcludedPort(port corev1.ServicePort) bool {
+	return (port.Name != "" && p.excludedPorts.Has(port.Name)) || p.excludedPorts.Has(strconv.Itoa(int(port.Port)))
+}
+
+func hasAnyKey[V any](data map[string]V, keys sets.Set[string]) bool {
+	for key := range data {
+		if keys.Has(key) {
+			return true
+		}
+	}
+	return false
+}
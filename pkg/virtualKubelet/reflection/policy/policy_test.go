@@ -0,0 +1,180 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/policy"
+)
+
+var _ = Describe("Reflection policies", func() {
+	var (
+		spec *offloadingv1beta1.ReflectionPolicy
+		p    *policy.Policy
+		err  error
+	)
+
+	JustBeforeEach(func() { p, err = policy.New(spec) })
+
+	When("the policy is not specified", func() {
+		BeforeEach(func() { spec = nil })
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+		It("should match all objects", func() {
+			Expect(p.Matches(&metav1.ObjectMeta{Name: "foo"}, nil)).To(BeTrue())
+			Expect(p.SelectsNamespaces()).To(BeFalse())
+		})
+		It("should not filter any field", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{"foo": []byte("bar")}}
+			Expect(p.FilterSecret(secret)).To(BeIdenticalTo(secret))
+		})
+	})
+
+	When("the policy is invalid", func() {
+		BeforeEach(func() { spec = &offloadingv1beta1.ReflectionPolicy{NameRegex: "foo("} })
+		It("should return an error", func() { Expect(err).To(HaveOccurred()) })
+	})
+
+	When("the policy selects the objects", func() {
+		BeforeEach(func() {
+			spec = &offloadingv1beta1.ReflectionPolicy{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "foo"}},
+				ObjectSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "bar"}},
+				NameRegex:         "^bar-",
+			}
+		})
+
+		It("should succeed", func() { Expect(err).ToNot(HaveOccurred()) })
+		It("should depend on the namespace labels", func() { Expect(p.SelectsNamespaces()).To(BeTrue()) })
+
+		DescribeTable("the Matches function",
+			func(name string, objLabels, nsLabels map[string]string, expected bool) {
+				obj := &metav1.ObjectMeta{Name: name, Labels: objLabels}
+				Expect(p.Matches(obj, labels.Set(nsLabels))).To(Equal(expected))
+			},
+			Entry("all criteria match", "bar-1", map[string]string{"app": "bar"}, map[string]string{"team": "foo"}, true),
+			Entry("the namespace does not match", "bar-1", map[string]string{"app": "bar"}, map[string]string{"team": "baz"}, false),
+			Entry("the labels do not match", "bar-1", map[string]string{"app": "baz"}, map[string]string{"team": "foo"}, false),
+			Entry("the name does not match", "baz-1", map[string]string{"app": "bar"}, map[string]string{"team": "foo"}, false),
+		)
+	})
+
+	When("the policy filters the fields", func() {
+		BeforeEach(func() {
+			spec = &offloadingv1beta1.ReflectionPolicy{
+				ExcludedKeys:  []string{"password", corev1.TLSPrivateKeyKey},
+				ExcludedPorts: []string{"metrics", "8443"},
+			}
+		})
+
+		It("should remove the excluded keys from secrets, without modifying the original", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{"username": []byte("foo"), "password": []byte("bar")}}
+			filtered := p.FilterSecret(secret)
+			Expect(filtered.Data).To(HaveKey("username"))
+			Expect(filtered.Data).ToNot(HaveKey("password"))
+			Expect(secret.Data).To(HaveKey("password"))
+		})
+
+		DescribeTable("the HasReflectableKeys function",
+			func(secretType corev1.SecretType, keys []string, expected bool) {
+				secret := &corev1.Secret{Type: secretType, Data: map[string][]byte{}}
+				for _, key := range keys {
+					secret.Data[key] = []byte("foo")
+				}
+				Expect(p.HasReflectableKeys(secret)).To(Equal(expected))
+			},
+			Entry("an opaque secret", corev1.SecretTypeOpaque, []string{"password"}, true),
+			Entry("a TLS secret, whose private key is excluded", corev1.SecretTypeTLS,
+				[]string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey}, false),
+			Entry("a docker config secret, whose keys are not excluded", corev1.SecretTypeDockerConfigJson,
+				[]string{corev1.DockerConfigJsonKey}, true),
+			Entry("a basic auth secret, retaining the username", corev1.SecretTypeBasicAuth, []string{"username", "password"}, true),
+			Entry("a basic auth secret, with the password only", corev1.SecretTypeBasicAuth, []string{"password"}, false),
+		)
+
+		It("should remove the excluded keys from configmaps", func() {
+			cm := &corev1.ConfigMap{
+				Data:       map[string]string{"config": "foo"},
+				BinaryData: map[string][]byte{"password": []byte("bar")},
+			}
+			filtered := p.FilterConfigMap(cm)
+			Expect(filtered.Data).To(HaveKey("config"))
+			Expect(filtered.BinaryData).ToNot(HaveKey("password"))
+		})
+
+		It("should not copy the objects if no key is removed", func() {
+			cm := &corev1.ConfigMap{Data: map[string]string{"config": "foo"}}
+			Expect(p.FilterConfigMap(cm)).To(BeIdenticalTo(cm))
+		})
+
+		It("should remove the excluded ports from services, either by name or number", func() {
+			svc := &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80}, {Name: "metrics", Port: 9090}, {Name: "https", Port: 8443},
+			}}}
+			filtered := p.FilterService(svc)
+			Expect(filtered.Spec.Ports).To(ConsistOf(corev1.ServicePort{Name: "http", Port: 80}))
+			Expect(svc.Spec.Ports).To(HaveLen(3))
+		})
+
+		DescribeTable("the HasReflectablePorts function",
+			func(ports []corev1.ServicePort, expected bool) {
+				Expect(p.HasReflectablePorts(&corev1.Service{Spec: corev1.ServiceSpec{Ports: ports}})).To(Equal(expected))
+			},
+			Entry("some ports are not excluded", []corev1.ServicePort{{Name: "http", Port: 80}, {Name: "metrics", Port: 9090}}, true),
+			Entry("all ports are excluded", []corev1.ServicePort{{Name: "metrics", Port: 9090}, {Port: 8443}}, false),
+			Entry("the service has no ports", nil, true),
+		)
+
+		It("should remove the endpointslice ports corresponding to the excluded service ports", func() {
+			svc := &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80}, {Name: "metrics", Port: 9090}, {Name: "https", Port: 8443},
+			}}}
+			eps := &discoveryv1.EndpointSlice{Ports: []discoveryv1.EndpointPort{
+				{Name: ptr.To("http"), Port: ptr.To[int32](8080)},
+				{Name: ptr.To("metrics"), Port: ptr.To[int32](9091)},
+				{Name: ptr.To("https"), Port: ptr.To[int32](443)},
+			}}
+			filtered, ok := p.FilterEndpointSlice(eps, svc)
+			Expect(ok).To(BeTrue())
+			Expect(filtered.Ports).To(ConsistOf(discoveryv1.EndpointPort{Name: ptr.To("http"), Port: ptr.To[int32](8080)}))
+			Expect(eps.Ports).To(HaveLen(3))
+		})
+
+		It("should not filter the endpointslice if the service is not known", func() {
+			eps := &discoveryv1.EndpointSlice{Ports: []discoveryv1.EndpointPort{
+				{Name: ptr.To("http"), Port: ptr.To[int32](8080)},
+				{Name: ptr.To("metrics"), Port: ptr.To[int32](9091)},
+			}}
+			_, ok := p.FilterEndpointSlice(eps, nil)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not copy the endpointslice if no port is removed", func() {
+			svc := &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}}}
+			eps := &discoveryv1.EndpointSlice{Ports: []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To[int32](8080)}}}
+			filtered, ok := p.FilterEndpointSlice(eps, svc)
+			Expect(ok).To(BeTrue())
+			Expect(filtered).To(BeIdenticalTo(eps))
+		})
+	})
+})
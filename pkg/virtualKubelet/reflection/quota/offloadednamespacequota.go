@@ -0,0 +1,170 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	offloadingv1beta1clients "github.com/liqotech/liqo/pkg/client/clientset/versioned/typed/offloading/v1beta1"
+	offloadingv1beta1listers "github.com/liqotech/liqo/pkg/client/listers/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/virtualkubelet"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/generic"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/manager"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/reflection/options"
+)
+
+var _ manager.NamespacedReflector = (*NamespacedOffloadedNamespaceQuotaReflector)(nil)
+
+const (
+	// OffloadedNamespaceQuotaReflectorName -> The name associated with the OffloadedNamespaceQuota reflector.
+	OffloadedNamespaceQuotaReflectorName = "OffloadedNamespaceQuota"
+)
+
+// NamespacedOffloadedNamespaceQuotaReflector manages the OffloadedNamespaceQuota reflection for a given pair of local and remote namespaces.
+// The quotas are reflected along with the usage of the other clusters, to allow the remote cluster to enforce them upon admission.
+type NamespacedOffloadedNamespaceQuotaReflector struct {
+	generic.NamespacedReflector
+
+	localQuotas        offloadingv1beta1listers.OffloadedNamespaceQuotaNamespaceLister
+	remoteQuotas       offloadingv1beta1listers.OffloadedNamespaceQuotaNamespaceLister
+	remoteQuotasClient offloadingv1beta1clients.OffloadedNamespaceQuotaInterface
+}
+
+// NewOffloadedNamespaceQuotaReflector returns a new OffloadedNamespaceQuotaReflector instance.
+func NewOffloadedNamespaceQuotaReflector(reflectorConfig *offloadingv1beta1.ReflectorConfig) manager.Reflector {
+	return generic.NewReflector(OffloadedNamespaceQuotaReflectorName, NewNamespacedOffloadedNamespaceQuotaReflector, generic.WithoutFallback(),
+		reflectorConfig.NumWorkers, offloadingv1beta1.CustomLiqo, generic.ConcurrencyModeLeader)
+}
+
+// NewNamespacedOffloadedNamespaceQuotaReflector returns a new NamespacedOffloadedNamespaceQuotaReflector instance.
+func NewNamespacedOffloadedNamespaceQuotaReflector(opts *options.NamespacedOpts) manager.NamespacedReflector {
+	local := opts.LocalLiqoFactory.Offloading().V1beta1().OffloadedNamespaceQuotas()
+	remote := opts.RemoteLiqoFactory.Offloading().V1beta1().OffloadedNamespaceQuotas()
+
+	_, err := local.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+	_, err = remote.Informer().AddEventHandler(opts.HandlerFactory(generic.NamespacedKeyer(opts.LocalNamespace)))
+	utilruntime.Must(err)
+
+	return &NamespacedOffloadedNamespaceQuotaReflector{
+		NamespacedReflector: generic.NewNamespacedReflector(opts, OffloadedNamespaceQuotaReflectorName),
+		localQuotas:         local.Lister().OffloadedNamespaceQuotas(opts.LocalNamespace),
+		remoteQuotas:        remote.Lister().OffloadedNamespaceQuotas(opts.RemoteNamespace),
+		remoteQuotasClient:  opts.RemoteLiqoClient.OffloadingV1beta1().OffloadedNamespaceQuotas(opts.RemoteNamespace),
+	}
+}
+
+// Handle reconciles OffloadedNamespaceQuota objects.
+func (nqr *NamespacedOffloadedNamespaceQuotaReflector) Handle(ctx context.Context, name string) error {
+	tracer := trace.FromContext(ctx)
+
+	// Retrieve the local and remote objects (only not found errors can occur).
+	klog.V(4).Infof("Handling reflection of local OffloadedNamespaceQuota %q (remote: %q)", nqr.LocalRef(name), nqr.RemoteRef(name))
+	local, lerr := nqr.localQuotas.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(lerr))
+	remote, rerr := nqr.remoteQuotas.Get(name)
+	utilruntime.Must(client.IgnoreNotFound(rerr))
+	tracer.Step("Retrieved the local and remote objects")
+
+	// Abort the reflection if the remote object is not managed by us, as we do not want to mutate others' objects.
+	if rerr == nil && !forge.IsReflected(remote) {
+		if lerr == nil { // Do not output the warning event in case the event was triggered by the remote object (i.e., the local one does not exists).
+			klog.Infof("Skipping reflection of local OffloadedNamespaceQuota %q as remote already exists and is not managed by us", nqr.LocalRef(name))
+			nqr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionAlreadyExistsMsg())
+		}
+		return nil
+	}
+	tracer.Step("Performed the sanity checks")
+
+	if kerrors.IsNotFound(lerr) {
+		defer tracer.Step("Ensured the absence of the remote object")
+		if !kerrors.IsNotFound(rerr) {
+			klog.V(4).Infof("Deleting remote OffloadedNamespaceQuota %q, since local %q does no longer exist", nqr.RemoteRef(name), nqr.LocalRef(name))
+			return nqr.DeleteRemote(ctx, nqr.remoteQuotasClient, OffloadedNamespaceQuotaReflectorName, name, remote.GetUID())
+		}
+
+		klog.V(4).Infof("Local OffloadedNamespaceQuota %q and remote OffloadedNamespaceQuota %q both vanished", nqr.LocalRef(name), nqr.RemoteRef(name))
+		return nil
+	}
+
+	target := forge.RemoteOffloadedNamespaceQuota(local, nqr.RemoteNamespace(), nqr.ForgingOpts)
+	status := forge.RemoteOffloadedNamespaceQuotaStatus(local, nqr.RemoteNamespace())
+	tracer.Step("Forged the remote object")
+
+	var err error
+	switch {
+	case kerrors.IsNotFound(rerr):
+		// The remote object does not exist, hence create it.
+		if remote, err = nqr.remoteQuotasClient.Create(ctx, target, metav1.CreateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+			return nqr.handleError(local, name, "create", err)
+		}
+		tracer.Step("Created the remote object")
+	case !equality.Semantic.DeepEqual(remote.GetLabels(), target.GetLabels()) ||
+		!equality.Semantic.DeepEqual(remote.GetAnnotations(), target.GetAnnotations()) ||
+		!equality.Semantic.DeepEqual(remote.Spec, target.Spec):
+		// The remote object is outdated, hence update it.
+		updated := remote.DeepCopy()
+		updated.SetLabels(target.GetLabels())
+		updated.SetAnnotations(target.GetAnnotations())
+		updated.Spec = target.Spec
+		if remote, err = nqr.remoteQuotasClient.Update(ctx, updated, metav1.UpdateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+			return nqr.handleError(local, name, "update", err)
+		}
+		tracer.Step("Updated the remote object")
+	}
+
+	// Propagate the usage of the other clusters, if changed.
+	if !equality.Semantic.DeepEqual(remote.Status, status.Status) {
+		updated := remote.DeepCopy()
+		updated.Status = status.Status
+		if _, err = nqr.remoteQuotasClient.UpdateStatus(ctx, updated, metav1.UpdateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+			return nqr.handleError(local, name, "update the status of", err)
+		}
+		tracer.Step("Updated the remote status")
+	}
+
+	klog.Infof("Remote OffloadedNamespaceQuota %q successfully enforced (local: %q)", nqr.RemoteRef(name), nqr.LocalRef(name))
+	nqr.Event(local, corev1.EventTypeNormal, forge.EventSuccessfulReflection, forge.EventSuccessfulReflectionMsg())
+	return nil
+}
+
+// handleError logs the given error and outputs the corresponding event, unless caused by a conflict.
+func (nqr *NamespacedOffloadedNamespaceQuotaReflector) handleError(local *offloadingv1beta1.OffloadedNamespaceQuota,
+	name, verb string, err error) error {
+	klog.Errorf("Failed to %s remote OffloadedNamespaceQuota %q (local: %q): %v", verb, nqr.RemoteRef(name), nqr.LocalRef(name), err)
+	if !kerrors.IsConflict(err) {
+		nqr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
+	}
+	return err
+}
+
+// List returns the list of objects.
+func (nqr *NamespacedOffloadedNamespaceQuotaReflector) List() ([]interface{}, error) {
+	return virtualkubelet.List[virtualkubelet.Lister[*offloadingv1beta1.OffloadedNamespaceQuota], *offloadingv1beta1.OffloadedNamespaceQuota](
+		nqr.localQuotas,
+		nqr.remoteQuotas,
+	)
+}
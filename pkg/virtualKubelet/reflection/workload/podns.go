@@ -243,6 +243,10 @@ func (npr *NamespacedPodReflector) Handle(ctx context.Context, name string) erro
 			if !kerrors.IsConflict(err) {
 				npr.Event(local, corev1.EventTypeWarning, forge.EventFailedReflection, forge.EventFailedReflectionMsg(err))
 			}
+			if kerrors.ReasonForError(err) == offloadingv1beta1.TenantPolicyDeniedReason {
+				// The creation has been denied by the TenantPolicy of the remote cluster: reflect the reason to the local pod status.
+				npr.HandleDenied(ctx, local, err)
+			}
 			return err
 		}
 
@@ -464,6 +468,23 @@ func (npr *NamespacedPodReflector) HandleStatus(ctx context.Context, local, remo
 	return nil
 }
 
+// HandleDenied marks the local pod as pending, exposing the reason why its offloading has been denied by the remote cluster.
+func (npr *NamespacedPodReflector) HandleDenied(ctx context.Context, local *corev1.Pod, denial error) {
+	po := forge.LocalDeniedPod(local, denial.Error())
+
+	// Do not attempt to perform an update if not necessary.
+	if reflect.DeepEqual(local.Status, po.Status) {
+		klog.V(4).Infof("Skipping local pod %q status update, as already synced", npr.LocalRef(local.GetName()))
+		return
+	}
+
+	if _, err := npr.localPodsClient.UpdateStatus(ctx, po, metav1.UpdateOptions{FieldManager: forge.ReflectionFieldManager}); err != nil {
+		klog.Errorf("Failed to mark local pod %q as %v (%v): %v", npr.LocalRef(local.GetName()), po.Status.Phase, po.Status.Reason, err)
+		return
+	}
+	klog.Infof("Local pod %q successfully marked as %v (%v)", npr.LocalRef(local.GetName()), po.Status.Phase, po.Status.Reason)
+}
+
 // Exec executes a command in a container of a reflected pod.
 func (npr *NamespacedPodReflector) Exec(ctx context.Context, po, container string, cmd []string, attach api.AttachIO) error {
 	klog.V(4).Infof("Requested to exec command in container %q of local pod %q (remote %q)", container, npr.LocalRef(po), npr.RemoteRef(po))
@@ -27,9 +27,14 @@ package local
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
 
+// Additional permissions necessary to reflect the status of services and ingresses back to the local objects.
+// +kubebuilder:rbac:groups=core,resources=services;services/status,verbs=update
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;ingresses/status,verbs=update
+
 // +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespacemaps;virtualnodes,verbs=get;list;watch;
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=offloadednamespacequotas,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core.liqo.io,resources=foreignclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core.liqo.io,resources=foreignclusters/status,verbs=get;list;watch
 
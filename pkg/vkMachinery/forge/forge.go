@@ -15,11 +15,15 @@
 package forge
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
@@ -70,7 +74,7 @@ func forgeVKContainers(
 	homeCluster, remoteCluster liqov1beta1.ClusterID,
 	nodeName, vkNamespace, localPodCIDR, liqoNamespace string,
 	storageClasses []liqov1beta1.StorageType, ingressClasses []liqov1beta1.IngressType, loadBalancerClasses []liqov1beta1.LoadBalancerType,
-	opts *offloadingv1beta1.VkOptionsTemplate) []v1.Container {
+	disabledReflectors []string, opts *offloadingv1beta1.VkOptionsTemplate) []v1.Container {
 	command := []string{
 		"/usr/bin/virtual-kubelet",
 	}
@@ -92,17 +96,25 @@ func forgeVKContainers(
 	}
 	if len(ingressClasses) > 0 {
 		args = append(args, string(EnableIngress),
-			StringifyArgument(string(RemoteRealIngressClassName),
-				getDefaultIngressClass(ingressClasses).IngressClassName))
+			StringifyArgument(string(RemoteRealIngressClassName), getDefaultIngressClass(ingressClasses).IngressClassName))
+		args = appendArgsClasses(args, IngressClasses, ReflectIngressStatusClasses, ingressClasses,
+			func(class liqov1beta1.IngressType) (string, bool) {
+				return class.IngressClassName, ptr.Deref(class.ReflectStatus, false)
+			})
 	}
 	if len(loadBalancerClasses) > 0 {
 		args = append(args, string(EnableLoadBalancer),
-			StringifyArgument(string(RemoteRealLoadBalancerClassName),
-				getDefaultLoadBalancerClass(loadBalancerClasses).LoadBalancerClassName))
+			StringifyArgument(string(RemoteRealLoadBalancerClassName), getDefaultLoadBalancerClass(loadBalancerClasses).LoadBalancerClassName))
+		args = appendArgsClasses(args, LoadBalancerClasses, ReflectLoadBalancerStatusClasses, loadBalancerClasses,
+			func(class liqov1beta1.LoadBalancerType) (string, bool) {
+				return class.LoadBalancerClassName, ptr.Deref(class.ReflectStatus, false)
+			})
 	}
 
-	args = appendArgsReflectorsWorkers(args, opts.Spec.ReflectorsConfig)
+	args = appendArgsReflectorsWorkers(args, opts.Spec.ReflectorsConfig, disabledReflectors)
 	args = appendArgsReflectorsType(args, opts.Spec.ReflectorsConfig)
+	args = appendArgsReflectorsPolicy(args, opts.Spec.ReflectorsConfig)
+	args = appendArgsCustomResourceReflectors(args, opts.Spec.CustomResourceReflectors)
 
 	if extraAnnotations := opts.Spec.NodeExtraAnnotations; len(extraAnnotations) != 0 {
 		stringifiedMap := argsutils.StringMap{StringMap: extraAnnotations}.String()
@@ -176,24 +188,28 @@ func forgeVKPodSpec(vkNamespace string, homeCluster liqov1beta1.ClusterID, local
 			homeCluster, virtualNode.Spec.ClusterID,
 			virtualNode.Name, vkNamespace, localPodCIDR, liqoNamespace,
 			virtualNode.Spec.StorageClasses, virtualNode.Spec.IngressClasses, virtualNode.Spec.LoadBalancerClasses,
-			opts),
+			virtualNode.Spec.DisabledReflectors, opts),
 		ServiceAccountName: virtualNode.Name,
 		ImagePullSecrets:   opts.Spec.ImagePullSecrets,
 		Tolerations:        opts.Spec.Tolerations,
 	}
 }
 
-func appendArgsReflectorsWorkers(args []string, reflectorsConfig map[string]offloadingv1beta1.ReflectorConfig) []string {
-	if reflectorsConfig == nil {
-		return args
-	}
-
+// appendArgsReflectorsWorkers appends the number of workers of each reflector, setting it to zero
+// for the disabled ones regardless of the configuration of the VkOptionsTemplate.
+func appendArgsReflectorsWorkers(args []string, reflectorsConfig map[string]offloadingv1beta1.ReflectorConfig,
+	disabledReflectors []string) []string {
 	for _, resource := range resources.Reflectors {
+		key := fmt.Sprintf("--%s-reflection-workers", resource)
+		if slices.Contains(disabledReflectors, string(resource)) {
+			args = append(args, StringifyArgument(key, "0"))
+			continue
+		}
+
 		reflector, ok := reflectorsConfig[string(resource)]
 		if !ok {
 			continue
 		}
-		key := fmt.Sprintf("--%s-reflection-workers", resource)
 		args = append(args, StringifyArgument(key, strconv.Itoa(int(reflector.NumWorkers))))
 	}
 
@@ -216,3 +232,58 @@ func appendArgsReflectorsType(args []string, reflectorsConfig map[string]offload
 
 	return args
 }
+
+func appendArgsReflectorsPolicy(args []string, reflectorsConfig map[string]offloadingv1beta1.ReflectorConfig) []string {
+	if reflectorsConfig == nil {
+		return args
+	}
+
+	for _, resource := range resources.ReflectorsCustomizablePolicy {
+		reflector, ok := reflectorsConfig[string(resource)]
+		if !ok || reflector.Policy == nil {
+			continue
+		}
+		encoded, err := json.Marshal(reflector.Policy)
+		if err != nil {
+			klog.Warningf("Failed to encode the %s reflection policy: %v", resource, err)
+			continue
+		}
+		key := fmt.Sprintf("--%s-reflection-policy", resource)
+		args = append(args, StringifyArgument(key, string(encoded)))
+	}
+
+	return args
+}
+
+func appendArgsCustomResourceReflectors(args []string, reflectorsConfig []offloadingv1beta1.CustomResourceReflectorConfig) []string {
+	if len(reflectorsConfig) == 0 {
+		return args
+	}
+
+	encoded, err := json.Marshal(reflectorsConfig)
+	if err != nil {
+		klog.Warningf("Failed to encode the custom resource reflectors configuration: %v", err)
+		return args
+	}
+
+	return append(args, StringifyArgument(string(CustomResourceReflectors), string(encoded)))
+}
+
+// appendArgsClasses appends the names of the offered classes, as well as of the ones whose status is reflected back.
+func appendArgsClasses[T any](args []string, classesFlag, reflectStatusFlag VirtualKubeletOptsFlag,
+	classes []T, describe func(T) (name string, reflectStatus bool)) []string {
+	var names, reflectStatusNames []string
+	for _, class := range classes {
+		name, reflectStatus := describe(class)
+		names = append(names, name)
+		if reflectStatus {
+			reflectStatusNames = append(reflectStatusNames, name)
+		}
+	}
+
+	args = append(args, StringifyArgument(string(classesFlag), argsutils.StringList{StringList: names}.String()))
+	if len(reflectStatusNames) > 0 {
+		args = append(args, StringifyArgument(string(reflectStatusFlag), argsutils.StringList{StringList: reflectStatusNames}.String()))
+	}
+	return args
+}
@@ -43,10 +43,18 @@ const (
 	EnableIngress VirtualKubeletOptsFlag = "--enable-ingress"
 	// RemoteRealIngressClassName is the flag used to specify the remote real ingress class name.
 	RemoteRealIngressClassName VirtualKubeletOptsFlag = "--remote-real-ingress-class-name"
+	// IngressClasses is the flag used to specify the ingress classes offered by the remote cluster.
+	IngressClasses VirtualKubeletOptsFlag = "--ingress-classes"
+	// ReflectIngressStatusClasses is the flag used to specify the ingress classes whose status is reflected.
+	ReflectIngressStatusClasses VirtualKubeletOptsFlag = "--reflect-ingress-status-classes"
 	// EnableLoadBalancer is the flag used to enable the load balancer.
 	EnableLoadBalancer VirtualKubeletOptsFlag = "--enable-load-balancer"
 	// RemoteRealLoadBalancerClassName is the flag used to specify the remote real load balancer class name.
 	RemoteRealLoadBalancerClassName VirtualKubeletOptsFlag = "--remote-real-load-balancer-class-name"
+	// LoadBalancerClasses is the flag used to specify the load balancer classes offered by the remote cluster.
+	LoadBalancerClasses VirtualKubeletOptsFlag = "--load-balancer-classes"
+	// ReflectLoadBalancerStatusClasses is the flag used to specify the load balancer classes whose status is reflected.
+	ReflectLoadBalancerStatusClasses VirtualKubeletOptsFlag = "--reflect-load-balancer-status-classes"
 	// NodeExtraAnnotations is the flag used to specify the node extra annotations.
 	NodeExtraAnnotations VirtualKubeletOptsFlag = "--node-extra-annotations"
 	// NodeExtraLabels is the flag used to specify the node extra labels.
@@ -59,4 +67,6 @@ const (
 	CreateNode VirtualKubeletOptsFlag = "--create-node"
 	// NodeCheckNetwork is the flag used to specify if the network must be checked.
 	NodeCheckNetwork VirtualKubeletOptsFlag = "--node-check-network"
+	// CustomResourceReflectors is the flag used to specify the configuration of the custom resource reflectors.
+	CustomResourceReflectors VirtualKubeletOptsFlag = "--custom-resource-reflectors"
 )
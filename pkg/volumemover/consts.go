@@ -12,15 +12,22 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package move
+package volumemover
 
 const (
 	liqoStorageNamespace = "liqo-storage"
 	resticRegistry       = "restic-registry"
 	resticPort           = 8000
 
+	// namespaceOffloadingUserAnnotationPrefix is the prefix of the annotations recording the remote nodes
+	// each move requires the liqo-storage namespace to be offloaded to.
+	namespaceOffloadingUserAnnotationPrefix = "volumemover.liqo.io/"
+
 	// DefaultResticServerImage is the default image used for the restic server.
 	DefaultResticServerImage = "restic/rest-server:0.11.0"
 	// DefaultResticImage is the default image used for the restic client.
 	DefaultResticImage = "restic/restic:0.14.0"
+
+	// ResticPasswordEnv is the environment variable containing the password of the restic repository.
+	ResticPasswordEnv = "RESTIC_PASSWORD"
 )
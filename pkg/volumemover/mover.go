@@ -0,0 +1,169 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumemover
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/liqotech/liqo/pkg/utils"
+	"github.com/liqotech/liqo/pkg/utils/pod"
+)
+
+// Options encapsulates the arguments to move a volume.
+type Options struct {
+	Client client.Client
+
+	TargetNode string
+
+	// ID identifies the move, to isolate the resources of moves running concurrently.
+	ID string
+
+	ContainersCPURequests, ContainersCPULimits resource.Quantity
+	ContainersRAMRequests, ContainersRAMLimits resource.Quantity
+
+	ResticPassword string
+
+	ResticServerImage string
+	ResticImage       string
+
+	// Progress, if set, is invoked with a description of each step, before executing it.
+	Progress func(step string)
+}
+
+// Move moves the given PVC to the target node, leveraging restic to backup the source data and restore it into a new volume.
+// The PVC is expected not to be used by any scheduled pod. In case the restore into the target node fails, the data is
+// restored back into the origin node, to prevent data loss.
+func (o *Options) Move(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	// we need a context that is not canceled even if the parent one is
+	deferCtx := context.Background()
+
+	var targetNode corev1.Node
+	if err := o.Client.Get(ctx, client.ObjectKey{Name: o.TargetNode}, &targetNode); err != nil {
+		return nil, fmt.Errorf("failed to get target node: %w", err)
+	}
+	targetIsLocal := !utils.IsVirtualNode(&targetNode)
+
+	originIsLocal, originNode, err := isLocalVolume(ctx, o.Client, pvc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if the volume is local: %w", err)
+	}
+
+	o.progress("Offloading the liqo-storage namespace")
+	if err = offloadLiqoStorageNamespace(ctx, o.Client, o.resticRegistryName(), originNode, &targetNode); err != nil {
+		return nil, fmt.Errorf("failed to offload the liqo-storage namespace: %w", err)
+	}
+	defer func() {
+		if err := repatriateLiqoStorageNamespace(deferCtx, o.Client, o.resticRegistryName()); err != nil {
+			klog.Errorf("Failed to repatriate the liqo-storage namespace: %v", err)
+		}
+	}()
+
+	o.progress("Ensuring restic repository")
+	if err = o.ensureResticRepository(ctx, pvc); err != nil {
+		return nil, fmt.Errorf("failed to ensure restic repository: %w", err)
+	}
+	defer func() {
+		if err := deleteResticRepository(deferCtx, o.Client, o.resticRegistryName()); err != nil {
+			klog.Errorf("Failed to remove restic repository: %v", err)
+		}
+	}()
+
+	o.progress("Waiting for restic repository to be up and running")
+	if err = waitForResticRepository(ctx, o.Client, o.resticRegistryName()); err != nil {
+		return nil, fmt.Errorf("failed to wait for restic repository to be up and running: %w", err)
+	}
+
+	o.progress("Taking snapshot")
+	originResticRepositoryURL, err := o.getResticRepositoryURL(ctx, originIsLocal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get origin restic repository URL: %w", err)
+	}
+	if err = o.takeSnapshot(ctx, pvc, originResticRepositoryURL); err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+
+	o.progress("Moving the volume")
+	newPvc, err := recreatePvc(ctx, o.Client, pvc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recreate PVC: %w", err)
+	}
+
+	targetResticRepositoryURL, err := o.getResticRepositoryURL(ctx, targetIsLocal)
+	if err == nil {
+		err = o.restoreSnapshot(ctx, pvc, newPvc, targetResticRepositoryURL)
+	}
+	if err != nil {
+		klog.Warningf("Failed to restore PVC %q into node %q, restoring it into node %q: %v", klog.KObj(pvc), o.TargetNode, originNode.Name, err)
+		o.progress("Restoring the volume into the origin node")
+		if rerr := o.restoreIntoOrigin(ctx, pvc, newPvc, originNode.Name, originResticRepositoryURL); rerr != nil {
+			return nil, fmt.Errorf("failed to restore snapshot: %w (failed to restore it into the origin node: %w)", err, rerr)
+		}
+		return nil, fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	return newPvc, nil
+}
+
+// restoreIntoOrigin restores the snapshot of the given PVC into a new volume in the origin node.
+func (o *Options) restoreIntoOrigin(ctx context.Context, oldPvc, newPvc *corev1.PersistentVolumeClaim,
+	originNode, originResticRepositoryURL string) error {
+	rollbackPvc, err := recreatePvc(ctx, o.Client, newPvc)
+	if err != nil {
+		return err
+	}
+
+	rollbackOpts := *o
+	rollbackOpts.TargetNode = originNode
+	return rollbackOpts.restoreSnapshot(ctx, oldPvc, rollbackPvc, originResticRepositoryURL)
+}
+
+func (o *Options) progress(step string) {
+	if o.Progress != nil {
+		o.Progress(step)
+	}
+}
+
+// resticRegistryName returns the name of the restic registry dedicated to the move.
+func (o *Options) resticRegistryName() string {
+	if o.ID == "" {
+		return resticRegistry
+	}
+	return fmt.Sprintf("%s-%s", resticRegistry, o.ID)
+}
+
+func (o *Options) getResticRepositoryURL(ctx context.Context, isLocal bool) (string, error) {
+	var namespace string
+	if isLocal {
+		namespace = liqoStorageNamespace
+	} else {
+		var err error
+		namespace, err = getRemoteStorageNamespaceName(ctx, o.Client, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("rest:http://%s.%s.svc:%d/", o.resticRegistryName(), namespace, resticPort), nil
+}
+
+func (o *Options) forgeContainerResources() corev1.ResourceRequirements {
+	return pod.ForgeContainerResources(o.ContainersCPURequests, o.ContainersCPULimits, o.ContainersRAMRequests, o.ContainersRAMLimits)
+}
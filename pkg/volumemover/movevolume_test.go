@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package move
+package volumemover
 
 import (
 	"context"
@@ -35,7 +35,6 @@ import (
 
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	liqoconst "github.com/liqotech/liqo/pkg/consts"
-	"github.com/liqotech/liqo/pkg/liqoctl/factory"
 	. "github.com/liqotech/liqo/pkg/utils/testutil"
 )
 
@@ -138,8 +137,8 @@ var _ = Context("Move Volumes", func() {
 			expectedErr OmegaMatcher
 		}
 
-		DescribeTable("checkNoMounter function", func(c mounterTestcase) {
-			err := checkNoMounter(ctx, c.client, c.pvc)
+		DescribeTable("CheckNoMounter function", func(c mounterTestcase) {
+			err := CheckNoMounter(ctx, c.client, c.pvc)
 			Expect(err).To(c.expectedErr)
 		}, Entry("should return nil if no mounter pod is found", mounterTestcase{
 			client:      fake.NewClientBuilder().WithObjects(newPod("pod1", "default", []string{})).Build(),
@@ -231,7 +230,7 @@ var _ = Context("Move Volumes", func() {
 				pvc.Spec.VolumeName = pv.Name
 
 				cl = fake.NewClientBuilder().WithObjects(pv, pvc).Build()
-				o = Options{Factory: &factory.Factory{CRClient: cl}, ResticPassword: resticPassword,
+				o = Options{Client: cl, ResticPassword: resticPassword,
 					ContainersCPURequests: resource.MustParse("100m"), ContainersRAMLimits: resource.MustParse("100M"),
 					ResticImage: DefaultResticImage, ResticServerImage: DefaultResticServerImage}
 			})
@@ -328,7 +327,7 @@ var _ = Context("Move Volumes", func() {
 				nPvc = newPvc("pvc2")
 
 				cl = fake.NewClientBuilder().WithObjects(oPvc, nPvc).Build()
-				o = Options{Factory: &factory.Factory{CRClient: cl}, ResticPassword: resticPassword, TargetNode: "node1",
+				o = Options{Client: cl, ResticPassword: resticPassword, TargetNode: "node1",
 					ContainersCPURequests: resource.MustParse("100m"), ContainersRAMLimits: resource.MustParse("100M"),
 					ResticImage: DefaultResticImage, ResticServerImage: DefaultResticServerImage}
 			})
@@ -432,7 +431,7 @@ var _ = Context("Move Volumes", func() {
 
 			BeforeEach(func() {
 				cl = fake.NewClientBuilder().Build()
-				o = Options{Factory: &factory.Factory{CRClient: cl},
+				o = Options{Client: cl,
 					ResticServerImage: DefaultResticServerImage, ResticPassword: resticPassword}
 
 				targetPvc = newPvc("pvc1")
@@ -462,7 +461,7 @@ var _ = Context("Move Volumes", func() {
 			}
 
 			DescribeTable("delete restic repository", func(c deleteResticRepositoryTestcase) {
-				Expect(deleteResticRepository(ctx, c.client)).To(Succeed())
+				Expect(deleteResticRepository(ctx, c.client, resticRegistry)).To(Succeed())
 
 				var svc corev1.Service
 				Eventually(func() error {
@@ -534,7 +533,7 @@ var _ = Context("Move Volumes", func() {
 			})
 
 			It("creates the NamespaceOffloading resource", func() {
-				Expect(offloadLiqoStorageNamespace(ctx, cl, originNode, targetNode)).To(Succeed())
+				Expect(offloadLiqoStorageNamespace(ctx, cl, resticRegistry, originNode, targetNode)).To(Succeed())
 
 				var nsOffload offloadingv1beta1.NamespaceOffloading
 				Expect(cl.Get(ctx, types.NamespacedName{
@@ -558,7 +557,7 @@ var _ = Context("Move Volumes", func() {
 			})
 
 			It("does not overwrite existing NamespaceOffloading resource", func() {
-				Expect(offloadLiqoStorageNamespace(ctx, cl, originNode, targetNode)).To(Succeed())
+				Expect(offloadLiqoStorageNamespace(ctx, cl, resticRegistry, originNode, targetNode)).To(Succeed())
 
 				var nsOffload offloadingv1beta1.NamespaceOffloading
 				Expect(cl.Get(ctx, types.NamespacedName{
@@ -567,13 +566,38 @@ var _ = Context("Move Volumes", func() {
 				nsOffload.Spec.NamespaceMappingStrategy = offloadingv1beta1.EnforceSameNameMappingStrategyType
 				Expect(cl.Update(ctx, &nsOffload)).To(Succeed())
 
-				Expect(offloadLiqoStorageNamespace(ctx, cl, originNode, targetNode)).To(Succeed())
+				Expect(offloadLiqoStorageNamespace(ctx, cl, resticRegistry, originNode, targetNode)).To(Succeed())
 
 				Expect(cl.Get(ctx, types.NamespacedName{
 					Name: liqoconst.DefaultNamespaceOffloadingName, Namespace: liqoStorageNamespace}, &nsOffload)).To(Succeed())
 				Expect(nsOffload.Spec.NamespaceMappingStrategy).To(Equal(offloadingv1beta1.EnforceSameNameMappingStrategyType))
 			})
 
+			It("offloads the namespace to the remote nodes required by any of the moves", func() {
+				Expect(offloadLiqoStorageNamespace(ctx, cl, resticRegistry, originNode, targetNode)).To(Succeed())
+				Expect(offloadLiqoStorageNamespace(ctx, cl, "other", originNode, otherRemoteNode)).To(Succeed())
+
+				var nsOffload offloadingv1beta1.NamespaceOffloading
+				Expect(cl.Get(ctx, types.NamespacedName{
+					Name: liqoconst.DefaultNamespaceOffloadingName, Namespace: liqoStorageNamespace}, &nsOffload)).To(Succeed())
+				Expect(nsOffload.Spec.ClusterSelector.NodeSelectorTerms).To(HaveLen(1))
+				Expect(nsOffload.Spec.ClusterSelector.NodeSelectorTerms[0].MatchExpressions).To(HaveLen(1))
+				Expect(nsOffload.Spec.ClusterSelector.NodeSelectorTerms[0].MatchExpressions[0].Values).To(
+					ConsistOf(targetNodeName, otherRemoteNodeName))
+
+				By("releasing the namespace for one of the moves")
+				Expect(repatriateLiqoStorageNamespace(ctx, cl, resticRegistry)).To(Succeed())
+				Expect(cl.Get(ctx, types.NamespacedName{
+					Name: liqoconst.DefaultNamespaceOffloadingName, Namespace: liqoStorageNamespace}, &nsOffload)).To(Succeed())
+				Expect(nsOffload.Spec.ClusterSelector.NodeSelectorTerms[0].MatchExpressions[0].Values).To(
+					ConsistOf(otherRemoteNodeName))
+
+				By("releasing the namespace for the other move")
+				Expect(repatriateLiqoStorageNamespace(ctx, cl, "other")).To(Succeed())
+				Expect(cl.Get(ctx, types.NamespacedName{
+					Name: liqoconst.DefaultNamespaceOffloadingName, Namespace: liqoStorageNamespace}, &nsOffload)).To(BeNotFound())
+			})
+
 		})
 
 		Context("teardown", func() {
@@ -590,7 +614,7 @@ var _ = Context("Move Volumes", func() {
 
 			It("deletes the NamespaceOffloading resource", func() {
 				By("deleting it once")
-				Expect(repatriateLiqoStorageNamespace(ctx, cl)).To(Succeed())
+				Expect(repatriateLiqoStorageNamespace(ctx, cl, resticRegistry)).To(Succeed())
 
 				var nsOffload offloadingv1beta1.NamespaceOffloading
 				Eventually(func() error {
@@ -599,7 +623,7 @@ var _ = Context("Move Volumes", func() {
 				}).Should(HaveOccurred())
 
 				By("deleting it twice")
-				Expect(repatriateLiqoStorageNamespace(ctx, cl)).To(Succeed())
+				Expect(repatriateLiqoStorageNamespace(ctx, cl, resticRegistry)).To(Succeed())
 				Eventually(func() error {
 					return cl.Get(ctx, types.NamespacedName{
 						Name: liqoconst.DefaultNamespaceOffloadingName, Namespace: liqoStorageNamespace}, &nsOffload)
@@ -0,0 +1,180 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumemover
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	liqoconst "github.com/liqotech/liqo/pkg/consts"
+	"github.com/liqotech/liqo/pkg/utils"
+)
+
+// offloadLiqoStorageNamespace offloads the liqo-storage namespace to the remote nodes involved in the move.
+// The NamespaceOffloading is shared by the concurrent moves: each of them records the remote nodes it requires
+// in a dedicated annotation, and the namespace is offloaded to the union of them.
+func offloadLiqoStorageNamespace(ctx context.Context, cl client.Client, user string, originNode, targetNode *corev1.Node) error {
+	key := namespaceOffloadingUserAnnotationKey(user)
+	value := strings.Join(getRemoteNodeNames(originNode, targetNode), ",")
+
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() error {
+		var namespaceOffloading offloadingv1beta1.NamespaceOffloading
+		err := cl.Get(ctx, client.ObjectKey{Name: liqoconst.DefaultNamespaceOffloadingName, Namespace: liqoStorageNamespace}, &namespaceOffloading)
+		switch {
+		case apierrors.IsNotFound(err):
+			namespaceOffloading = offloadingv1beta1.NamespaceOffloading{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        liqoconst.DefaultNamespaceOffloadingName,
+					Namespace:   liqoStorageNamespace,
+					Annotations: map[string]string{key: value},
+				},
+				Spec: offloadingv1beta1.NamespaceOffloadingSpec{
+					NamespaceMappingStrategy: offloadingv1beta1.DefaultNameMappingStrategyType,
+					PodOffloadingStrategy:    offloadingv1beta1.LocalPodOffloadingStrategyType,
+				},
+			}
+			namespaceOffloading.Spec.ClusterSelector = forgeLiqoStorageClusterSelector(&namespaceOffloading)
+			return cl.Create(ctx, &namespaceOffloading)
+		case err != nil:
+			return err
+		}
+
+		if namespaceOffloading.Annotations == nil {
+			namespaceOffloading.Annotations = map[string]string{}
+		}
+		namespaceOffloading.Annotations[key] = value
+		namespaceOffloading.Spec.ClusterSelector = forgeLiqoStorageClusterSelector(&namespaceOffloading)
+		return cl.Update(ctx, &namespaceOffloading)
+	})
+}
+
+// repatriateLiqoStorageNamespace releases the liqo-storage namespace offloading held by the given move,
+// deleting the NamespaceOffloading once no other move requires it.
+func repatriateLiqoStorageNamespace(ctx context.Context, cl client.Client, user string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var namespaceOffloading offloadingv1beta1.NamespaceOffloading
+		if err := cl.Get(ctx, client.ObjectKey{Name: liqoconst.DefaultNamespaceOffloadingName, Namespace: liqoStorageNamespace},
+			&namespaceOffloading); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		delete(namespaceOffloading.Annotations, namespaceOffloadingUserAnnotationKey(user))
+		if len(getLiqoStorageUsers(&namespaceOffloading)) == 0 {
+			// The precondition prevents deleting the NamespaceOffloading in case a new move started in the meanwhile.
+			return client.IgnoreNotFound(cl.Delete(ctx, &namespaceOffloading,
+				client.Preconditions{ResourceVersion: &namespaceOffloading.ResourceVersion}))
+		}
+
+		namespaceOffloading.Spec.ClusterSelector = forgeLiqoStorageClusterSelector(&namespaceOffloading)
+		return cl.Update(ctx, &namespaceOffloading)
+	})
+}
+
+func namespaceOffloadingUserAnnotationKey(user string) string {
+	return namespaceOffloadingUserAnnotationPrefix + user
+}
+
+// getLiqoStorageUsers returns the remote nodes required by each move using the liqo-storage namespace.
+func getLiqoStorageUsers(namespaceOffloading *offloadingv1beta1.NamespaceOffloading) map[string][]string {
+	users := map[string][]string{}
+	for key, value := range namespaceOffloading.Annotations {
+		if user, found := strings.CutPrefix(key, namespaceOffloadingUserAnnotationPrefix); found {
+			users[user] = strings.FieldsFunc(value, func(r rune) bool { return r == ',' })
+		}
+	}
+	return users
+}
+
+// forgeLiqoStorageClusterSelector forges the cluster selector targeting the remote nodes required by any of the moves.
+func forgeLiqoStorageClusterSelector(namespaceOffloading *offloadingv1beta1.NamespaceOffloading) corev1.NodeSelector {
+	var remoteNodes []string
+	for _, nodes := range getLiqoStorageUsers(namespaceOffloading) {
+		remoteNodes = append(remoteNodes, nodes...)
+	}
+	slices.Sort(remoteNodes)
+
+	return corev1.NodeSelector{
+		NodeSelectorTerms: []corev1.NodeSelectorTerm{
+			{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{
+						Key:      "kubernetes.io/hostname",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   slices.Compact(remoteNodes),
+					},
+				},
+			},
+		},
+	}
+}
+
+func getRemoteNodeNames(nodes ...*corev1.Node) []string {
+	var remoteNodes []string
+	for _, node := range nodes {
+		if utils.IsVirtualNode(node) {
+			remoteNodes = append(remoteNodes, node.Name)
+		}
+	}
+	return remoteNodes
+}
+
+func getRemoteStorageNamespaceName(ctx context.Context, cl client.Client, backoff *wait.Backoff) (string, error) {
+	var nsOffloading offloadingv1beta1.NamespaceOffloading
+
+	if backoff == nil {
+		backoff = &wait.Backoff{
+			Steps:    20,
+			Duration: 500 * time.Millisecond,
+			Factor:   1.5,
+		}
+	}
+
+	err := retry.OnError(*backoff, func(e error) bool {
+		return true
+	}, func() error {
+		if err := cl.Get(ctx, client.ObjectKey{
+			Name:      liqoconst.DefaultNamespaceOffloadingName,
+			Namespace: liqoStorageNamespace}, &nsOffloading); err != nil {
+			return err
+		}
+
+		if nsOffloading.Status.OffloadingPhase != offloadingv1beta1.ReadyOffloadingPhaseType {
+			return fmt.Errorf("namespace offloading is not ready")
+		}
+		if nsOffloading.Status.RemoteNamespaceName == "" {
+			return fmt.Errorf("remote namespace name is not set")
+		}
+		return nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return nsOffloading.Status.RemoteNamespaceName, nil
+}
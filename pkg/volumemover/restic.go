@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package move
+package volumemover
 
 import (
 	"context"
@@ -31,16 +31,17 @@ import (
 )
 
 func (o *Options) ensureResticRepository(ctx context.Context, targetPvc *corev1.PersistentVolumeClaim) error {
+	name := o.resticRegistryName()
 	svc := corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      resticRegistry,
+			Name:      name,
 			Namespace: liqoStorageNamespace,
 		},
 	}
-	_, err := resource.CreateOrUpdate(ctx, o.CRClient, &svc, func() error {
+	_, err := resource.CreateOrUpdate(ctx, o.Client, &svc, func() error {
 		svc.Spec = corev1.ServiceSpec{
 			Selector: map[string]string{
-				"app": resticRegistry,
+				"app": name,
 			},
 			Ports: []corev1.ServicePort{
 				{
@@ -58,23 +59,23 @@ func (o *Options) ensureResticRepository(ctx context.Context, targetPvc *corev1.
 
 	statefulSet := appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      resticRegistry,
+			Name:      name,
 			Namespace: liqoStorageNamespace,
 		},
 	}
-	_, err = resource.CreateOrUpdate(ctx, o.CRClient, &statefulSet, func() error {
+	_, err = resource.CreateOrUpdate(ctx, o.Client, &statefulSet, func() error {
 		statefulSet.Spec = appsv1.StatefulSetSpec{
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
-					"app": resticRegistry,
+					"app": name,
 				},
 			},
-			ServiceName: resticRegistry,
+			ServiceName: name,
 			Replicas:    pointer.Int32Ptr(1),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"app": resticRegistry,
+						"app": name,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -139,23 +140,23 @@ func (o *Options) ensureResticRepository(ctx context.Context, targetPvc *corev1.
 	return err
 }
 
-func deleteResticRepository(ctx context.Context, cl client.Client) error {
+func deleteResticRepository(ctx context.Context, cl client.Client, name string) error {
 	if err := cl.Delete(ctx, &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      resticRegistry,
+			Name:      name,
 			Namespace: liqoStorageNamespace,
 		},
 	}); client.IgnoreNotFound(err) != nil {
 		return err
 	}
 
-	if err := scaleResticRepository(ctx, cl); err != nil {
+	if err := scaleResticRepository(ctx, cl, name); err != nil {
 		return err
 	}
 
 	if err := cl.Delete(ctx, &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      resticRegistry,
+			Name:      name,
 			Namespace: liqoStorageNamespace,
 		},
 	}); client.IgnoreNotFound(err) != nil {
@@ -164,16 +165,16 @@ func deleteResticRepository(ctx context.Context, cl client.Client) error {
 
 	return client.IgnoreNotFound(cl.Delete(ctx, &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "restic-registry-data-restic-registry-0",
+			Name:      fmt.Sprintf("restic-registry-data-%s-0", name),
 			Namespace: liqoStorageNamespace,
 		},
 	}))
 }
 
-func scaleResticRepository(ctx context.Context, cl client.Client) error {
+func scaleResticRepository(ctx context.Context, cl client.Client, name string) error {
 	statefulSet := appsv1.StatefulSet{}
 	if err := cl.Get(ctx, client.ObjectKey{
-		Name:      resticRegistry,
+		Name:      name,
 		Namespace: liqoStorageNamespace,
 	}, &statefulSet); apierrors.IsNotFound(err) {
 		return nil
@@ -197,7 +198,7 @@ func scaleResticRepository(ctx context.Context, cl client.Client) error {
 			return fmt.Errorf("timeout waiting for restic repository to scale down")
 		case <-ticker.C:
 			if err := cl.Get(timeoutCtx, client.ObjectKey{
-				Name:      resticRegistry,
+				Name:      name,
 				Namespace: liqoStorageNamespace,
 			}, &statefulSet); err != nil {
 				return err
@@ -209,7 +210,7 @@ func scaleResticRepository(ctx context.Context, cl client.Client) error {
 	}
 }
 
-func waitForResticRepository(ctx context.Context, cl client.Client) error {
+func waitForResticRepository(ctx context.Context, cl client.Client, name string) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Minute*5)
 	defer cancel()
 	ticker := time.NewTicker(time.Second * 5)
@@ -222,7 +223,7 @@ func waitForResticRepository(ctx context.Context, cl client.Client) error {
 			return ctx.Err()
 		case <-ticker.C:
 			if err := cl.Get(ctx, client.ObjectKey{
-				Name:      resticRegistry,
+				Name:      name,
 				Namespace: liqoStorageNamespace,
 			}, &statefulSet); err != nil {
 				return err
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package move
+package volumemover
 
 import (
 	"context"
@@ -31,7 +31,7 @@ func (o *Options) restoreSnapshot(ctx context.Context,
 		return err
 	}
 
-	return waitForJob(ctx, o.CRClient, job)
+	return waitForJob(ctx, o.Client, job)
 }
 
 func (o *Options) createRestorerJob(ctx context.Context,
@@ -76,7 +76,7 @@ func (o *Options) createRestorerJob(ctx context.Context,
 							},
 							Env: []corev1.EnvVar{
 								{
-									Name:  "RESTIC_PASSWORD",
+									Name:  ResticPasswordEnv,
 									Value: o.ResticPassword,
 								},
 							},
@@ -105,7 +105,7 @@ func (o *Options) createRestorerJob(ctx context.Context,
 		},
 	}
 
-	if err := o.CRClient.Create(ctx, &job); err != nil {
+	if err := o.Client.Create(ctx, &job); err != nil {
 		return nil, err
 	}
 	return &job, nil
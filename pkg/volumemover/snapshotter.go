@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package move
+package volumemover
 
 import (
 	"context"
@@ -32,13 +32,13 @@ func (o *Options) takeSnapshot(ctx context.Context, pvc *corev1.PersistentVolume
 		return err
 	}
 
-	return waitForJob(ctx, o.CRClient, job)
+	return waitForJob(ctx, o.Client, job)
 }
 
 func (o *Options) createSnapshotterJob(ctx context.Context, pvc *corev1.PersistentVolumeClaim,
 	resticRepositoryURL string) (*batchv1.Job, error) {
 	var pv corev1.PersistentVolume
-	if err := o.CRClient.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, &pv); err != nil {
+	if err := o.Client.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, &pv); err != nil {
 		return nil, err
 	}
 
@@ -63,7 +63,7 @@ func (o *Options) createSnapshotterJob(ctx context.Context, pvc *corev1.Persiste
 							},
 							Env: []corev1.EnvVar{
 								{
-									Name:  "RESTIC_PASSWORD",
+									Name:  ResticPasswordEnv,
 									Value: o.ResticPassword,
 								},
 							},
@@ -83,7 +83,7 @@ func (o *Options) createSnapshotterJob(ctx context.Context, pvc *corev1.Persiste
 							},
 							Env: []corev1.EnvVar{
 								{
-									Name:  "RESTIC_PASSWORD",
+									Name:  ResticPasswordEnv,
 									Value: o.ResticPassword,
 								},
 							},
@@ -113,7 +113,7 @@ func (o *Options) createSnapshotterJob(ctx context.Context, pvc *corev1.Persiste
 		},
 	}
 
-	if err := o.CRClient.Create(ctx, &job); err != nil {
+	if err := o.Client.Create(ctx, &job); err != nil {
 		return nil, err
 	}
 	return &job, nil
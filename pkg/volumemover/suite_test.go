@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package move
+package volumemover
 
 import (
 	"testing"
@@ -21,8 +21,8 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-func TestMove(t *testing.T) {
+func TestVolumeMover(t *testing.T) {
 	defer GinkgoRecover()
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Move Suite")
+	RunSpecs(t, "Volume Mover Suite")
 }
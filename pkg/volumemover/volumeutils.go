@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package move
+package volumemover
 
 import (
 	"context"
@@ -46,7 +46,8 @@ func isLocalVolume(ctx context.Context, cl client.Client, pvc *corev1.Persistent
 	return !utils.IsVirtualNode(&node), &node, nil
 }
 
-func checkNoMounter(ctx context.Context, cl client.Client, pvc *corev1.PersistentVolumeClaim) error {
+// CheckNoMounter returns an error in case the given PVC is mounted by any pod.
+func CheckNoMounter(ctx context.Context, cl client.Client, pvc *corev1.PersistentVolumeClaim) error {
 	var podList corev1.PodList
 	if err := cl.List(ctx, &podList, client.InNamespace(pvc.Namespace)); err != nil {
 		return err
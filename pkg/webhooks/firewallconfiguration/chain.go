@@ -93,7 +93,7 @@ func allowedTableFamilyChainTypeHook(family firewallapi.TableFamily, chainType f
 			case firewallapi.ChainHookIngress:
 				return family == firewallapi.TableFamilyINet
 			case firewallapi.ChainHookForward:
-				return family == firewallapi.TableFamilyIPv4
+				return family == firewallapi.TableFamilyIPv4 || family == firewallapi.TableFamilyIPv6
 			default:
 				return true
 			}
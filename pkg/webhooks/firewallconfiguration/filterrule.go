@@ -77,6 +77,8 @@ func checkFilterRuleIPValue(mIP *firewallapi.MatchIP) error {
 		if err := checkGranularRangeIP(mIP); err != nil {
 			return fmt.Errorf("invalid range %s", mIP.Value)
 		}
+	case firewallapi.IPValueTypeSet:
+		// Set references are checked against the sets of the table.
 	default:
 		return fmt.Errorf("invalid IP value type %s", IPValueType)
 	}
@@ -129,6 +129,10 @@ func (w *webhookValidate) Handle(ctx context.Context, req admission.Request) adm
 		return admission.Denied(err.Error())
 	}
 
+	if err := checkSets(firewallConfiguration.Spec.Table.Sets); err != nil {
+		return admission.Denied(err.Error())
+	}
+
 	for i := range chains {
 		chain := chains[i]
 
@@ -140,6 +144,10 @@ func (w *webhookValidate) Handle(ctx context.Context, req admission.Request) adm
 			return admission.Denied(err.Error())
 		}
 
+		if err := checkSetReferences(&firewallConfiguration.Spec.Table, &chain); err != nil {
+			return admission.Denied(forgeChainError(&chain, err).Error())
+		}
+
 		switch chain.Type {
 		case firewallapi.ChainTypeNAT:
 			if err := checkNatRulesInChain(&chain); err != nil {
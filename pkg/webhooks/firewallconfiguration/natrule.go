@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	fwutils "github.com/liqotech/liqo/pkg/firewall/utils"
 )
 
 func checkNatRulesInChain(chain *firewallapi.Chain) error {
@@ -36,12 +37,18 @@ func checkNatRulesInChain(chain *firewallapi.Chain) error {
 func checkNatRuleTo(natrule *firewallapi.NatRule) error {
 	switch natrule.NatType {
 	case firewallapi.NatTypeDestination, firewallapi.NatTypeSource:
-		if natrule.To == nil {
-			return fmt.Errorf("natrule %s is %s but has no To field", *natrule.Name, natrule.NatType)
+		if natrule.To == nil && natrule.ToMap == nil {
+			return fmt.Errorf("natrule %s is %s but has no To nor ToMap field", *natrule.Name, natrule.NatType)
+		}
+		if natrule.To != nil && natrule.ToMap != nil {
+			return fmt.Errorf("natrule %s has both To and ToMap fields", *natrule.Name)
+		}
+		if natrule.To != nil && fwutils.IsSetReference(*natrule.To) {
+			return fmt.Errorf("natrule %s references a set in the To field, use ToMap instead", *natrule.Name)
 		}
 	case firewallapi.NatTypeMasquerade:
-		if natrule.To != nil {
-			return fmt.Errorf("natrule %s is Masquerade or masquerade but has a To field", *natrule.Name)
+		if natrule.To != nil || natrule.ToMap != nil {
+			return fmt.Errorf("natrule %s is Masquerade or masquerade but has a To or ToMap field", *natrule.Name)
 		}
 	}
 	return nil
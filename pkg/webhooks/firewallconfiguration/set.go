@@ -0,0 +1,160 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firewallconfiguration
+
+import (
+	"fmt"
+
+	"github.com/google/nftables"
+
+	firewallapi "github.com/liqotech/liqo/apis/networking/v1beta1/firewall"
+	fwutils "github.com/liqotech/liqo/pkg/firewall/utils"
+)
+
+func checkSets(sets []firewallapi.Set) error {
+	names := map[string]interface{}{}
+	for i := range sets {
+		if sets[i].Name == "" {
+			return fmt.Errorf("set name is void")
+		}
+		if _, ok := names[sets[i].Name]; ok {
+			return fmt.Errorf("set name %v is duplicated", sets[i].Name)
+		}
+		names[sets[i].Name] = nil
+
+		if _, _, err := fwutils.ForgeSet(&sets[i], &nftables.Table{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSetReferences checks that the sets and maps referenced by the rules of the chain are defined in the table,
+// with types compatible with their usage.
+func checkSetReferences(table *firewallapi.Table, chain *firewallapi.Chain) error {
+	for i := range chain.Rules.FilterRules {
+		rule := &chain.Rules.FilterRules[i]
+		if err := checkMatchSetReferences(table, rule.Match); err != nil {
+			return err
+		}
+		switch {
+		case rule.Action == firewallapi.ActionVerdictMap && rule.VerdictMap == nil:
+			return fmt.Errorf("filterrule %s has vmap action but no verdict map", *rule.Name)
+		case rule.Action != firewallapi.ActionVerdictMap && rule.VerdictMap != nil:
+			return fmt.Errorf("filterrule %s has a verdict map but no vmap action", *rule.Name)
+		case rule.VerdictMap != nil:
+			if err := checkMapLookup(table, rule.VerdictMap, firewallapi.SetDataTypeVerdict); err != nil {
+				return fmt.Errorf("filterrule %s: %w", *rule.Name, err)
+			}
+		}
+	}
+
+	for i := range chain.Rules.NatRules {
+		rule := &chain.Rules.NatRules[i]
+		if err := checkMatchSetReferences(table, rule.Match); err != nil {
+			return err
+		}
+		if rule.ToMap == nil {
+			continue
+		}
+		dataType, err := getTableIPSetDataType(table)
+		if err != nil {
+			return fmt.Errorf("natrule %s: %w", *rule.Name, err)
+		}
+		if err := checkMapLookup(table, rule.ToMap, dataType); err != nil {
+			return fmt.Errorf("natrule %s: %w", *rule.Name, err)
+		}
+	}
+	return nil
+}
+
+func checkMatchSetReferences(table *firewallapi.Table, matches []firewallapi.Match) error {
+	for i := range matches {
+		if matches[i].IP != nil && fwutils.IsSetReference(matches[i].IP.Value) {
+			keyType, err := getTableIPSetDataType(table)
+			if err != nil {
+				return err
+			}
+			if err := checkSetReference(table, fwutils.GetSetReferenceName(matches[i].IP.Value), keyType); err != nil {
+				return err
+			}
+		}
+		if matches[i].Port != nil && fwutils.IsSetReference(matches[i].Port.Value) {
+			if err := checkSetReference(table, fwutils.GetSetReferenceName(matches[i].Port.Value),
+				firewallapi.SetDataTypeInetService); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkSetReference(table *firewallapi.Table, name string, keyType firewallapi.SetDataType) error {
+	set := findSet(table.Sets, name)
+	if set == nil {
+		return fmt.Errorf("set %s is not defined in the table", name)
+	}
+	if set.DataType != nil {
+		return fmt.Errorf("%s is a map, and it cannot be referenced as a set", name)
+	}
+	if set.KeyType != keyType {
+		return fmt.Errorf("set %s has key type %s, but %s is required", name, set.KeyType, keyType)
+	}
+	return nil
+}
+
+func checkMapLookup(table *firewallapi.Table, lookup *firewallapi.MapLookup, dataType firewallapi.SetDataType) error {
+	set := findSet(table.Sets, lookup.Name)
+	if set == nil {
+		return fmt.Errorf("map %s is not defined in the table", lookup.Name)
+	}
+	if set.DataType == nil || *set.DataType != dataType {
+		return fmt.Errorf("%s is not a map with data type %s", lookup.Name, dataType)
+	}
+
+	keyType := firewallapi.SetDataTypeInetService
+	if lookup.Key == firewallapi.MapKeyIP {
+		var err error
+		if keyType, err = getTableIPSetDataType(table); err != nil {
+			return err
+		}
+	}
+	if set.KeyType != keyType {
+		return fmt.Errorf("map %s has key type %s, but %s is required", lookup.Name, set.KeyType, keyType)
+	}
+	return nil
+}
+
+// getTableIPSetDataType returns the type of the IP addresses looked up in sets, which depends on the family of the table.
+func getTableIPSetDataType(table *firewallapi.Table) (firewallapi.SetDataType, error) {
+	switch *table.Family {
+	case firewallapi.TableFamilyIPv4:
+		return firewallapi.SetDataTypeIPv4Addr, nil
+	case firewallapi.TableFamilyIPv6:
+		return firewallapi.SetDataTypeIPv6Addr, nil
+	default:
+		return "", fmt.Errorf("sets of IP addresses can be looked up only in %s and %s tables",
+			firewallapi.TableFamilyIPv4, firewallapi.TableFamilyIPv6)
+	}
+}
+
+func findSet(sets []firewallapi.Set, name string) *firewallapi.Set {
+	for i := range sets {
+		if sets[i].Name == name {
+			return &sets[i]
+		}
+	}
+	return nil
+}
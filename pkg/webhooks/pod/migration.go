@@ -0,0 +1,120 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	liqoconst "github.com/liqotech/liqo/pkg/consts"
+)
+
+// isMigrationInProgress returns whether the given Migration is currently moving its pods, hence requiring them to be gated.
+func isMigrationInProgress(migration *offloadingv1beta1.Migration) bool {
+	return migration.Status.Phase == offloadingv1beta1.EvictingMigrationPhaseType ||
+		migration.Status.Phase == offloadingv1beta1.MovingVolumesMigrationPhaseType
+}
+
+// isMigrationRollingBack returns whether the given Migration is restoring its pods (and volumes) into the source node.
+func isMigrationRollingBack(migration *offloadingv1beta1.Migration) bool {
+	return migration.Status.Phase == offloadingv1beta1.RollingBackMigrationPhaseType
+}
+
+// isMigrationActive returns whether the given Migration constrains the scheduling of the pods it selects.
+// Once the Migration is terminal, the pods are no longer constrained, and they are scheduled according to the NamespaceOffloading.
+func isMigrationActive(migration *offloadingv1beta1.Migration) bool {
+	return migration.DeletionTimestamp.IsZero() && (migration.Status.Phase == "" ||
+		migration.Status.Phase == offloadingv1beta1.PendingMigrationPhaseType ||
+		isMigrationInProgress(migration) || isMigrationRollingBack(migration))
+}
+
+// getMigrationForPod returns the oldest active Migration selecting the given pod, if any.
+func getMigrationForPod(migrations []offloadingv1beta1.Migration, pod *corev1.Pod) (*offloadingv1beta1.Migration, error) {
+	var selected *offloadingv1beta1.Migration
+	for i := range migrations {
+		migration := &migrations[i]
+		if !isMigrationActive(migration) {
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&migration.Spec.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pod selector for migration %q: %w", migration.Name, err)
+		}
+		if !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+
+		if selected == nil || migration.CreationTimestamp.Before(&selected.CreationTimestamp) {
+			selected = migration
+		}
+	}
+	return selected, nil
+}
+
+// mutatePodForMigration constrains the scheduling of the given pod, according to the phase of the Migration it is subject to.
+// While the Migration is in progress, the pod is pinned to the target node, and it is prevented from being scheduled until
+// the volumes have been moved. While rolling back, it is pinned to the source node, and it is prevented from being scheduled
+// until the volumes have been restored. Otherwise (i.e., before the target node is selected), the source node is cordoned.
+func mutatePodForMigration(migration *offloadingv1beta1.Migration, pod *corev1.Pod) {
+	switch {
+	case isMigrationInProgress(migration) && migration.Status.TargetNode != "":
+		klog.V(5).Infof("Pod subject to migration %q towards node %q", migration.Name, migration.Status.TargetNode)
+		fillPodWithTheNewNodeSelector(forgeMigrationNodeSelector(corev1.NodeSelectorOpIn, migration.Status.TargetNode), pod)
+		gatePodForMigration(pod)
+	case isMigrationRollingBack(migration):
+		klog.V(5).Infof("Pod subject to the rollback of migration %q towards node %q", migration.Name, migration.Spec.SourceNode)
+		fillPodWithTheNewNodeSelector(forgeMigrationNodeSelector(corev1.NodeSelectorOpIn, migration.Spec.SourceNode), pod)
+		if len(migration.Status.Volumes) > 0 {
+			gatePodForMigration(pod)
+		}
+	default:
+		klog.V(5).Infof("Pod subject to migration %q away from node %q", migration.Name, migration.Spec.SourceNode)
+		fillPodWithTheNewNodeSelector(forgeMigrationNodeSelector(corev1.NodeSelectorOpNotIn, migration.Spec.SourceNode), pod)
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[liqoconst.MigrationAnnotationKey] = migration.Name
+}
+
+// forgeMigrationNodeSelector forges the node selector either pinning the pod to the given node, or excluding it.
+func forgeMigrationNodeSelector(operator corev1.NodeSelectorOperator, node string) *corev1.NodeSelector {
+	return &corev1.NodeSelector{
+		NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key:      corev1.LabelHostname,
+				Operator: operator,
+				Values:   []string{node},
+			}},
+		}},
+	}
+}
+
+// gatePodForMigration adds the migration scheduling gate to the given pod, if not already present.
+func gatePodForMigration(pod *corev1.Pod) {
+	if !slices.ContainsFunc(pod.Spec.SchedulingGates, func(gate corev1.PodSchedulingGate) bool {
+		return gate.Name == liqoconst.MigrationSchedulingGate
+	}) {
+		pod.Spec.SchedulingGates = append(pod.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: liqoconst.MigrationSchedulingGate})
+	}
+}
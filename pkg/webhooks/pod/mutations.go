@@ -53,7 +53,26 @@ func createTolerationFromNamespaceOffloading(strategy offloadingv1beta1.PodOfflo
 
 // createNodeSelectorFromNamespaceOffloading creates the right NodeSelector according to the PodOffloadingStrategy chosen.
 func createNodeSelectorFromNamespaceOffloading(nsoff *offloadingv1beta1.NamespaceOffloading) (*corev1.NodeSelector, error) {
-	nodeSelector := nsoff.Spec.ClusterSelector
+	nodeSelector := *nsoff.Spec.ClusterSelector.DeepCopy()
+
+	// In case the cluster ranking excludes some of the remote clusters matching the ClusterSelector, it is necessary to
+	// add to every NodeSelectorTerm a new NodeSelectorRequirement, to prevent pods from being scheduled on virtual nodes
+	// targeting clusters the namespace has not been offloaded to.
+	if clusters := selectedClusters(nsoff.Status.ClusterRanking); len(clusters) > 0 {
+		if len(nodeSelector.NodeSelectorTerms) == 0 {
+			nodeSelector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+		}
+
+		for i := range nodeSelector.NodeSelectorTerms {
+			nodeSelector.NodeSelectorTerms[i].MatchExpressions = append(nodeSelector.NodeSelectorTerms[i].MatchExpressions,
+				corev1.NodeSelectorRequirement{
+					Key:      liqoconst.RemoteClusterID,
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   clusters,
+				})
+		}
+	}
+
 	switch nsoff.Spec.PodOffloadingStrategy {
 	case offloadingv1beta1.RemotePodOffloadingStrategyType:
 		// To ensure that the pod is not scheduled on local nodes is necessary to add to every NodeSelectorTerm a
@@ -96,6 +115,22 @@ func createNodeSelectorFromNamespaceOffloading(nsoff *offloadingv1beta1.Namespac
 	return &nodeSelector, nil
 }
 
+// selectedClusters returns the IDs of the remote clusters selected according to the ranking, in case it excludes
+// some of the ones matching the ClusterSelector, and nil otherwise (i.e., no further constraint is necessary).
+func selectedClusters(ranking []offloadingv1beta1.RankedCluster) []string {
+	var selected []string
+	for i := range ranking {
+		if ranking[i].Selected {
+			selected = append(selected, string(ranking[i].ClusterID))
+		}
+	}
+
+	if len(selected) == len(ranking) {
+		return nil
+	}
+	return selected
+}
+
 // fillPodWithTheNewNodeSelector gets the previously computed NodeSelector imposed by the PodOffloadingStrategy and
 // merges it with the Pod NodeSelector if it is already present. It simply adds it to the Pod if previously unset.
 func fillPodWithTheNewNodeSelector(imposedNodeSelector *corev1.NodeSelector, pod *corev1.Pod) {
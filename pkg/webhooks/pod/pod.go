@@ -34,6 +34,7 @@ import (
 
 // cluster-role
 // +kubebuilder:rbac:groups=offloading.liqo.io,resources=namespaceoffloadings,verbs=get;list;watch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=migrations,verbs=get;list;watch
 
 type podwh struct {
 	client  client.Client
@@ -95,5 +96,21 @@ func (w *podwh) Handle(ctx context.Context, req admission.Request) admission.Res
 		return admission.Errored(http.StatusInternalServerError, errors.New("failed constructing pod mutation"))
 	}
 
+	// Constrain the scheduling of the pod in case it is subject to a Migration.
+	var migrations offloadingv1beta1.MigrationList
+	if err = w.client.List(ctx, &migrations, client.InNamespace(req.Namespace)); err != nil {
+		klog.Errorf("Failed retrieving Migrations for namespace %q: %v", req.Namespace, err)
+		return admission.Errored(http.StatusInternalServerError, errors.New("failed retrieving Migrations"))
+	}
+
+	migration, err := getMigrationForPod(migrations.Items, pod)
+	if err != nil {
+		klog.Errorf("Failed determining whether pod is subject to migration: %v", err)
+		return admission.Errored(http.StatusInternalServerError, errors.New("failed constructing pod mutation"))
+	}
+	if migration != nil {
+		mutatePodForMigration(migration, pod)
+	}
+
 	return w.CreatePatchResponse(&req, pod)
 }
@@ -17,6 +17,7 @@ package pod
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -98,6 +99,38 @@ var _ = Describe("Webhook", func() {
 		)
 	})
 
+	Context("Check the NodeSelector imposed by the cluster ranking", func() {
+		var nsoff offloadingv1beta1.NamespaceOffloading
+
+		BeforeEach(func() {
+			nsoff = testutils.GetNamespaceOffloading(offloadingv1beta1.RemotePodOffloadingStrategyType)
+			nsoff.Spec.ClusterSelector = corev1.NodeSelector{}
+			nsoff.Status.ClusterRanking = []offloadingv1beta1.RankedCluster{
+				{ClusterID: "cluster-1", Score: 10, Selected: true},
+				{ClusterID: "cluster-2", Score: 5, Selected: false},
+			}
+		})
+
+		It("should restrict the pods to the selected clusters", func() {
+			nodeSelector, err := createNodeSelectorFromNamespaceOffloading(&nsoff)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodeSelector).To(PointTo(Equal(corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: liqoconst.RemoteClusterID, Operator: corev1.NodeSelectorOpIn, Values: []string{"cluster-1"}},
+					{Key: liqoconst.TypeLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{liqoconst.TypeNode}},
+				},
+			}}})))
+		})
+
+		It("should not add any constraint if all the matching clusters are selected", func() {
+			nsoff.Spec.PodOffloadingStrategy = offloadingv1beta1.LocalAndRemotePodOffloadingStrategyType
+			nsoff.Status.ClusterRanking[1].Selected = true
+			nodeSelector, err := createNodeSelectorFromNamespaceOffloading(&nsoff)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodeSelector).To(BeNil())
+		})
+	})
+
 	Context("Check if the pod NodeSelector is correctly merged with the NamespaceOffloading NodeSelector", func() {
 		It("Check the merged NodeSelector", func() {
 			podNodeSelector := testutils.GetPodNodeSelector()
@@ -342,4 +375,93 @@ var _ = Describe("Webhook", func() {
 			)
 		})
 	})
+
+	Context("Check the mutations imposed by a Migration", func() {
+		var (
+			pod        *corev1.Pod
+			migrations []offloadingv1beta1.Migration
+		)
+
+		newMigration := func(name string, phase offloadingv1beta1.MigrationPhaseType, created time.Time) offloadingv1beta1.Migration {
+			return offloadingv1beta1.Migration{
+				ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+				Spec: offloadingv1beta1.MigrationSpec{
+					PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+					SourceNode:  "source",
+				},
+				Status: offloadingv1beta1.MigrationStatus{Phase: phase, TargetNode: "target-" + name},
+			}
+		}
+
+		BeforeEach(func() {
+			pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Labels: map[string]string{"app": "foo"}}}
+			migrations = []offloadingv1beta1.Migration{
+				newMigration("newer", offloadingv1beta1.MovingVolumesMigrationPhaseType, time.Now()),
+				newMigration("older", offloadingv1beta1.EvictingMigrationPhaseType, time.Now().Add(-time.Hour)),
+				newMigration("failed", offloadingv1beta1.FailedMigrationPhaseType, time.Now().Add(-2*time.Hour)),
+			}
+		})
+
+		It("should select the oldest active migration selecting the pod", func() {
+			migration, err := getMigrationForPod(migrations, pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(migration).To(PointTo(HaveField("Name", "older")))
+		})
+
+		It("should not select any migration if the pod does not match the selector", func() {
+			pod.Labels = map[string]string{"app": "bar"}
+			Expect(getMigrationForPod(migrations, pod)).To(BeNil())
+		})
+
+		It("should pin and gate the pod when the migration is in progress", func() {
+			mutatePodForMigration(&migrations[0], pod)
+			Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpIn, Values: []string{"target-newer"}}}},
+			))
+			Expect(pod.Spec.SchedulingGates).To(ConsistOf(corev1.PodSchedulingGate{Name: liqoconst.MigrationSchedulingGate}))
+			Expect(pod.Annotations).To(HaveKeyWithValue(liqoconst.MigrationAnnotationKey, "newer"))
+		})
+
+		It("should cordon the source node when the migration is pending", func() {
+			migration := newMigration("pending", offloadingv1beta1.PendingMigrationPhaseType, time.Now())
+			migration.Status.TargetNode = ""
+			mutatePodForMigration(&migration, pod)
+			Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpNotIn, Values: []string{"source"}}}},
+			))
+			Expect(pod.Spec.SchedulingGates).To(BeEmpty())
+			Expect(pod.Annotations).To(HaveKeyWithValue(liqoconst.MigrationAnnotationKey, "pending"))
+		})
+
+		It("should pin the pod to the source node, and gate it until the volumes are restored, when rolling back", func() {
+			migration := newMigration("rollingback", offloadingv1beta1.RollingBackMigrationPhaseType, time.Now())
+			migration.Status.Volumes = []string{"pvc"}
+			mutatePodForMigration(&migration, pod)
+			Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpIn, Values: []string{"source"}}}},
+			))
+			Expect(pod.Spec.SchedulingGates).To(ConsistOf(corev1.PodSchedulingGate{Name: liqoconst.MigrationSchedulingGate}))
+		})
+
+		It("should pin the pod to the source node, without gating it, once the volumes are restored", func() {
+			migration := newMigration("rollingback", offloadingv1beta1.RollingBackMigrationPhaseType, time.Now())
+			mutatePodForMigration(&migration, pod)
+			Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpIn, Values: []string{"source"}}}},
+			))
+			Expect(pod.Spec.SchedulingGates).To(BeEmpty())
+		})
+
+		It("should not select terminal migrations", func() {
+			migrations = []offloadingv1beta1.Migration{
+				newMigration("succeeded", offloadingv1beta1.SucceededMigrationPhaseType, time.Now()),
+				newMigration("failed", offloadingv1beta1.FailedMigrationPhaseType, time.Now()),
+			}
+			Expect(getMigrationForPod(migrations, pod)).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,66 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadowpod
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/audit"
+	"github.com/liqotech/liqo/pkg/virtualKubelet/forge"
+)
+
+// recordAuditEvent records the outcome of the admission of the creation or deletion of a ShadowPod in the audit stream,
+// and returns the given response unmodified. Dry-run requests are not recorded, as they do not affect the cluster.
+func (spv *Validator) recordAuditEvent(req *admission.Request, action audit.Action, resp admission.Response) admission.Response {
+	if !audit.Enabled() || (req.DryRun != nil && *req.DryRun) {
+		return resp
+	}
+
+	object := req.Object
+	if action == audit.ActionShadowPodDelete {
+		object = req.OldObject
+	}
+
+	event := &audit.Event{
+		Action:    action,
+		Outcome:   audit.OutcomeAllowed,
+		Resource:  offloadingv1beta1.ShadowPodResource,
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		User:      req.UserInfo.Username,
+		Groups:    req.UserInfo.Groups,
+	}
+
+	// The origin cluster ID is retrieved from the ShadowPod labels, which have already been validated by the handler.
+	if shadowpod, err := decodeShadowPod(spv.decoder, object); err == nil {
+		event.ClusterID = liqov1beta1.ClusterID(shadowpod.Labels[forge.LiqoOriginClusterIDKey])
+		if event.Name == "" {
+			event.Name = shadowpod.Name
+		}
+	}
+
+	if !resp.Allowed {
+		event.Outcome = audit.OutcomeDenied
+	}
+	if resp.Result != nil {
+		event.Reason = string(resp.Result.Reason)
+		event.Message = resp.Result.Message
+	}
+
+	audit.Record(event)
+	return resp
+}
@@ -100,6 +100,9 @@ func (spv *Validator) refreshCache(ctx context.Context) (done bool, err error) {
 		},
 	)
 
+	klog.V(5).Infof("Aligning OffloadedNamespaceQuotas usage")
+	spv.refreshNamespaceQuotaCache(ctx)
+
 	klog.V(5).Infof("Aligning Quotas - PeeringInfo")
 	if err := spv.checkAlignmentQuotaPeeringInfo(ctx); err != nil {
 		klog.Error(err)
@@ -0,0 +1,276 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadowpod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/resources"
+)
+
+// cluster-role
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=offloadednamespacequotas,verbs=get;list;watch
+
+// namespaceQuotaCache is a cache that holds the namespaceQuotaInfo for each namespace subject to OffloadedNamespaceQuotas.
+type namespaceQuotaCache struct {
+	namespaceQuotaInfo sync.Map
+}
+
+// namespaceQuotaInfo is the struct that holds the resources consumed by the ShadowPods of a given namespace.
+// The usage is tracked in memory, so that concurrent creations cannot exceed the limits.
+type namespaceQuotaInfo struct {
+	namespace  string
+	shadowPods map[string]*Description
+	usedQuota  corev1.ResourceList
+	aligned    bool
+	mu         sync.Mutex
+}
+
+// getOrCreateNamespaceQuotaInfo returns the namespaceQuotaInfo for the given namespace. If it doesn't exist, it creates a new one.
+func (nqc *namespaceQuotaCache) getOrCreateNamespaceQuotaInfo(namespace string) *namespaceQuotaInfo {
+	nqi, _ := nqc.namespaceQuotaInfo.LoadOrStore(namespace, &namespaceQuotaInfo{
+		namespace:  namespace,
+		shadowPods: map[string]*Description{},
+		usedQuota:  corev1.ResourceList{},
+	})
+	return nqi.(*namespaceQuotaInfo)
+}
+
+// getNamespaceQuotaInfo returns the namespaceQuotaInfo for the given namespace, if any.
+func (nqc *namespaceQuotaCache) getNamespaceQuotaInfo(namespace string) (*namespaceQuotaInfo, bool) {
+	nqi, found := nqc.namespaceQuotaInfo.Load(namespace)
+	if !found {
+		return nil, found
+	}
+	return nqi.(*namespaceQuotaInfo), found
+}
+
+// checkOffloadedNamespaceQuotas checks whether the creation of the given ShadowPod would exceed any OffloadedNamespaceQuota
+// defined in its namespace, and reserves the corresponding resources unless in dry-run mode. The resources consumed in the
+// local cluster are tracked from the admitted ShadowPods, while the ones consumed in all the other clusters are propagated
+// by the origin cluster through the status of the quota.
+func (spv *Validator) checkOffloadedNamespaceQuotas(ctx context.Context, shadowpod *offloadingv1beta1.ShadowPod, dryRun bool) error {
+	var quotas offloadingv1beta1.OffloadedNamespaceQuotaList
+	if err := spv.client.List(ctx, &quotas, client.InNamespace(shadowpod.Namespace)); err != nil {
+		return fmt.Errorf("failed listing OffloadedNamespaceQuotas: %w", err)
+	}
+	if len(quotas.Items) == 0 {
+		return nil
+	}
+
+	nqi := spv.namespaceQuotaCache.getOrCreateNamespaceQuotaInfo(shadowpod.Namespace)
+	return nqi.testAndUpdateCreation(ctx, spv.client, shadowpod, quotas.Items, dryRun)
+}
+
+// releaseOffloadedNamespaceQuotas releases the resources reserved for the given ShadowPod, whose creation has been denied
+// by a subsequent check.
+func (spv *Validator) releaseOffloadedNamespaceQuotas(shadowpod *offloadingv1beta1.ShadowPod, dryRun bool) {
+	nqi, found := spv.namespaceQuotaCache.getNamespaceQuotaInfo(shadowpod.Namespace)
+	if !found || dryRun {
+		return
+	}
+
+	nqi.mu.Lock()
+	defer nqi.mu.Unlock()
+	if spd, found := nqi.shadowPods[types.NamespacedName{Name: shadowpod.Name, Namespace: shadowpod.Namespace}.String()]; found &&
+		spd.running && spd.uid == shadowpod.GetUID() {
+		nqi.terminateShadowPod(spd)
+		nqi.removeShadowPod(spd)
+	}
+}
+
+// updateOffloadedNamespaceQuotasDeletion releases the resources consumed by the given ShadowPod, unless in dry-run mode.
+func (spv *Validator) updateOffloadedNamespaceQuotasDeletion(shadowpod *offloadingv1beta1.ShadowPod, dryRun bool) {
+	nqi, found := spv.namespaceQuotaCache.getNamespaceQuotaInfo(shadowpod.Namespace)
+	if !found {
+		// The namespace is not subject to any OffloadedNamespaceQuota.
+		return
+	}
+
+	if err := nqi.updateDeletion(shadowpod, dryRun); err != nil {
+		// The next refreshing process will align this issue.
+		klog.Warning(err)
+	}
+}
+
+func (nqi *namespaceQuotaInfo) testAndUpdateCreation(ctx context.Context, c client.Client, sp *offloadingv1beta1.ShadowPod,
+	quotas []offloadingv1beta1.OffloadedNamespaceQuota, dryRun bool) error {
+	nqi.mu.Lock()
+	defer nqi.mu.Unlock()
+
+	// Account for the ShadowPods already existing when the namespace is first subject to a quota.
+	if !nqi.aligned {
+		var shadowpods offloadingv1beta1.ShadowPodList
+		if err := c.List(ctx, &shadowpods, client.InNamespace(nqi.namespace)); err != nil {
+			return fmt.Errorf("failed listing ShadowPods: %w", err)
+		}
+		nqi.alignExistingShadowPods(&shadowpods)
+		nqi.aligned = true
+	}
+
+	nsname := types.NamespacedName{Name: sp.Name, Namespace: sp.Namespace}
+	spd, found := nqi.shadowPods[nsname.String()]
+	if found {
+		if spd.running {
+			return fmt.Errorf("ShadowPod %s is already running", sp.GetName())
+		}
+		if err := checkShadowPodExistence(ctx, c, nsname); err != nil {
+			return err
+		}
+		// Cache refreshing has not deleted it from cache yet.
+		nqi.removeShadowPod(spd)
+	}
+	spd = createShadowPodDescription(sp.GetName(), sp.GetNamespace(), sp.GetUID(), shadowPodQuotaUsage(sp))
+
+	used := quotav1.Add(nqi.usedQuota, spd.quota)
+	for i := range quotas {
+		quota := &quotas[i]
+		names := quotav1.ResourceNames(quota.Spec.Hard)
+		total := quotav1.Mask(quotav1.Add(used, quota.Status.Used), names)
+		if withinLimits, exceeded := quotav1.LessThanOrEqual(total, quota.Spec.Hard); !withinLimits {
+			return fmt.Errorf("exceeded OffloadedNamespaceQuota %q (resources: %s)", quota.Name, strings.Join(resourceNames(exceeded), ", "))
+		}
+	}
+
+	if !dryRun {
+		nqi.addShadowPod(spd)
+		klog.V(5).Infof("Namespace %q updated used quota %s", nqi.namespace, quotaFormatter(nqi.usedQuota))
+	}
+	return nil
+}
+
+func (nqi *namespaceQuotaInfo) updateDeletion(sp *offloadingv1beta1.ShadowPod, dryRun bool) error {
+	nqi.mu.Lock()
+	defer nqi.mu.Unlock()
+
+	nsname := types.NamespacedName{Name: sp.Name, Namespace: sp.Namespace}
+	spd, found := nqi.shadowPods[nsname.String()]
+	switch {
+	case !found:
+		return fmt.Errorf("ShadowPod %s not found in namespace quota cache", nsname.String())
+	case spd.uid != sp.GetUID():
+		return fmt.Errorf("ShadowPod %s: UID mismatch", nsname.String())
+	case !spd.running:
+		return nil
+	}
+
+	if !dryRun {
+		nqi.terminateShadowPod(spd)
+		klog.V(5).Infof("Namespace %q updated used quota %s", nqi.namespace, quotaFormatter(nqi.usedQuota))
+	}
+	return nil
+}
+
+func (nqi *namespaceQuotaInfo) addShadowPod(spd *Description) {
+	nqi.shadowPods[spd.namespacedName.String()] = spd
+	nqi.usedQuota = quotav1.Add(nqi.usedQuota, spd.quota)
+}
+
+func (nqi *namespaceQuotaInfo) terminateShadowPod(spd *Description) {
+	spd.terminate()
+	nqi.usedQuota = quotav1.Subtract(nqi.usedQuota, spd.quota)
+}
+
+func (nqi *namespaceQuotaInfo) removeShadowPod(spd *Description) {
+	delete(nqi.shadowPods, spd.namespacedName.String())
+}
+
+func (nqi *namespaceQuotaInfo) alignExistingShadowPods(shadowPodList *offloadingv1beta1.ShadowPodList) (spMap map[string]struct{}) {
+	spMap = make(map[string]struct{})
+	for i := range shadowPodList.Items {
+		sp := &shadowPodList.Items[i]
+		// Terminating ShadowPods are not accounted, consistently with the deletion requests.
+		if !sp.DeletionTimestamp.IsZero() {
+			continue
+		}
+		nsname := types.NamespacedName{Name: sp.Name, Namespace: sp.Namespace}
+		spMap[nsname.String()] = struct{}{}
+		if _, found := nqi.shadowPods[nsname.String()]; !found {
+			nqi.addShadowPod(createShadowPodDescription(sp.GetName(), sp.GetNamespace(), sp.GetUID(), shadowPodQuotaUsage(sp)))
+		}
+	}
+	return spMap
+}
+
+func (nqi *namespaceQuotaInfo) alignTerminatingOrNotExistingShadowPods(shadowPodList *offloadingv1beta1.ShadowPodList) {
+	nqi.mu.Lock()
+	defer nqi.mu.Unlock()
+
+	spMap := nqi.alignExistingShadowPods(shadowPodList)
+	for _, spd := range nqi.shadowPods {
+		if _, stillPresent := spMap[spd.namespacedName.String()]; stillPresent {
+			continue
+		}
+		// The grace period accounts for the ShadowPods admitted, but not yet observed by the cache.
+		if !spd.running {
+			nqi.removeShadowPod(spd)
+		} else if time.Since(spd.creationTimestamp) > 30*time.Second {
+			nqi.terminateShadowPod(spd)
+			nqi.removeShadowPod(spd)
+		}
+	}
+}
+
+// refreshNamespaceQuotaCache aligns the resources tracked for each namespace with the ShadowPods existing in the cluster,
+// and stops tracking the namespaces no longer subject to any OffloadedNamespaceQuota.
+func (spv *Validator) refreshNamespaceQuotaCache(ctx context.Context) {
+	spv.namespaceQuotaCache.namespaceQuotaInfo.Range(
+		func(key, value interface{}) bool {
+			nqi := value.(*namespaceQuotaInfo)
+			var quotas offloadingv1beta1.OffloadedNamespaceQuotaList
+			if err := spv.client.List(ctx, &quotas, client.InNamespace(nqi.namespace)); err != nil {
+				klog.Warning(err)
+				return true
+			}
+			if len(quotas.Items) == 0 {
+				klog.V(4).Infof("No OffloadedNamespaceQuotas found in namespace %q, removing it from cache", nqi.namespace)
+				spv.namespaceQuotaCache.namespaceQuotaInfo.Delete(key)
+				return true
+			}
+
+			var shadowpods offloadingv1beta1.ShadowPodList
+			if err := spv.client.List(ctx, &shadowpods, client.InNamespace(nqi.namespace)); err != nil {
+				klog.Warning(err)
+				return true
+			}
+			nqi.alignTerminatingOrNotExistingShadowPods(&shadowpods)
+			return true
+		},
+	)
+}
+
+// shadowPodQuotaUsage returns the resources accounted to the given ShadowPod for quota purposes.
+func shadowPodQuotaUsage(shadowpod *offloadingv1beta1.ShadowPod) corev1.ResourceList {
+	return resources.PodQuotaUsage(&corev1.Pod{Spec: shadowpod.Spec.Pod})
+}
+
+func resourceNames(names []corev1.ResourceName) []string {
+	output := make([]string, len(names))
+	for i := range names {
+		output[i] = names[i].String()
+	}
+	return output
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
 	"github.com/liqotech/liqo/pkg/consts"
@@ -91,6 +92,7 @@ var _ = BeforeSuite(func() {
 	testutil.LogsToGinkgoWriter()
 	Expect(corev1.AddToScheme(scheme)).To(Succeed())
 	Expect(liqov1beta1.AddToScheme(scheme)).To(Succeed())
+	Expect(authv1beta1.AddToScheme(scheme)).To(Succeed())
 	Expect(offloadingv1beta1.AddToScheme(scheme)).To(Succeed())
 })
 
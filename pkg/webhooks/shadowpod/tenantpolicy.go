@@ -0,0 +1,264 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadowpod
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/distribution/reference"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/utils/getters"
+)
+
+// baselineCapabilities are the capabilities which can be added to the containers when privileged mode is not allowed,
+// matching the ones allowed by the baseline Pod Security Standard.
+var baselineCapabilities = []corev1.Capability{
+	"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD",
+	"NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+}
+
+// baselineSysctls are the sysctls which can be set by the pods when privileged mode is not allowed,
+// matching the ones allowed by the baseline Pod Security Standard.
+var baselineSysctls = []string{
+	"kernel.shm_rmid_forced", "net.ipv4.ip_local_port_range", "net.ipv4.ip_unprivileged_port_start",
+	"net.ipv4.tcp_syncookies", "net.ipv4.ping_group_range", "net.ipv4.ip_local_reserved_ports",
+	"net.ipv4.tcp_keepalive_time", "net.ipv4.tcp_fin_timeout", "net.ipv4.tcp_keepalive_intvl", "net.ipv4.tcp_keepalive_probes",
+}
+
+// baselineSELinuxTypes are the SELinux types which can be set when privileged mode is not allowed,
+// matching the ones allowed by the baseline Pod Security Standard.
+var baselineSELinuxTypes = []string{"", "container_t", "container_init_t", "container_kvm_t", "container_engine_t"}
+
+// cluster-role
+// +kubebuilder:rbac:groups=authentication.liqo.io,resources=tenants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=offloading.liqo.io,resources=tenantpolicies,verbs=get;list;watch
+
+// getTenantPolicy returns the TenantPolicy referenced by the Tenant associated with the given cluster,
+// or nil in case no policy is configured.
+func getTenantPolicy(ctx context.Context, cl client.Client, clusterID liqov1beta1.ClusterID) (*offloadingv1beta1.TenantPolicy, error) {
+	tenant, err := getters.GetTenantByClusterID(ctx, cl, clusterID, corev1.NamespaceAll)
+	switch {
+	case kerrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed retrieving the Tenant of cluster %q: %w", clusterID, err)
+	case tenant.Spec.TenantPolicyRef == nil || tenant.Spec.TenantPolicyRef.Name == "":
+		return nil, nil
+	}
+
+	var policy offloadingv1beta1.TenantPolicy
+	if err := cl.Get(ctx, client.ObjectKey{Name: tenant.Spec.TenantPolicyRef.Name}, &policy); err != nil {
+		// Fail closed in case the referenced policy cannot be retrieved, as it would be otherwise bypassed.
+		return nil, fmt.Errorf("failed retrieving TenantPolicy %q: %w", tenant.Spec.TenantPolicyRef.Name, err)
+	}
+	return &policy, nil
+}
+
+// checkTenantPolicy returns an error describing all the violations of the given policy by the pod spec, if any.
+func checkTenantPolicy(policy *offloadingv1beta1.TenantPolicy, spec *corev1.PodSpec) error {
+	var violations []string
+
+	if !policy.Spec.AllowHostNamespaces {
+		if spec.HostNetwork {
+			violations = append(violations, "host network is not allowed")
+		}
+		if spec.HostPID {
+			violations = append(violations, "host PID namespace is not allowed")
+		}
+		if spec.HostIPC {
+			violations = append(violations, "host IPC namespace is not allowed")
+		}
+		forEachContainer(spec, func(container *corev1.Container) {
+			for i := range container.Ports {
+				if container.Ports[i].HostPort != 0 {
+					violations = append(violations, fmt.Sprintf("host port %d of container %q is not allowed",
+						container.Ports[i].HostPort, container.Name))
+				}
+			}
+		})
+	}
+
+	if !policy.Spec.AllowPrivileged {
+		violations = append(violations, checkPodPrivileges(spec.SecurityContext)...)
+	}
+
+	if !policy.Spec.AllowHostPathVolumes {
+		for i := range spec.Volumes {
+			if spec.Volumes[i].HostPath != nil {
+				violations = append(violations, fmt.Sprintf("hostPath volume %q is not allowed", spec.Volumes[i].Name))
+			}
+		}
+	}
+
+	if len(policy.Spec.AllowedRuntimeClasses) > 0 {
+		switch runtimeClass := ptr.Deref(spec.RuntimeClassName, ""); {
+		case runtimeClass == "":
+			violations = append(violations, "a runtime class is required")
+		case !slices.Contains(policy.Spec.AllowedRuntimeClasses, runtimeClass):
+			violations = append(violations, fmt.Sprintf("runtime class %q is not allowed", runtimeClass))
+		}
+	}
+
+	forEachContainer(spec, func(container *corev1.Container) {
+		if !policy.Spec.AllowPrivileged {
+			violations = append(violations, checkPrivileges(container)...)
+		}
+		if !imageRegistryAllowed(container.Image, policy.Spec.AllowedImageRegistries) {
+			violations = append(violations, fmt.Sprintf("image %q of container %q is not pulled from an allowed registry", container.Image, container.Name))
+		}
+	})
+
+	if len(violations) > 0 {
+		return fmt.Errorf("denied by TenantPolicy %q: %s", policy.Name, strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// forEachContainer invokes the given function for each init, regular and ephemeral container of the pod spec.
+func forEachContainer(spec *corev1.PodSpec, fn func(container *corev1.Container)) {
+	for i := range spec.InitContainers {
+		fn(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		fn(&spec.Containers[i])
+	}
+	for i := range spec.EphemeralContainers {
+		fn((*corev1.Container)(&spec.EphemeralContainers[i].EphemeralContainerCommon))
+	}
+}
+
+// checkPodPrivileges returns the violations of the pod-level security context in case privileged mode is not allowed,
+// i.e., if it sets sysctls beyond the baseline ones, or it relaxes the sandboxing of the containers.
+func checkPodPrivileges(sc *corev1.PodSecurityContext) []string {
+	if sc == nil {
+		return nil
+	}
+
+	var violations []string
+	for i := range sc.Sysctls {
+		if !slices.Contains(baselineSysctls, sc.Sysctls[i].Name) {
+			violations = append(violations, fmt.Sprintf("sysctl %q is not allowed", sc.Sysctls[i].Name))
+		}
+	}
+	return append(violations, checkSandbox("pod", sc.SELinuxOptions, sc.SeccompProfile, sc.AppArmorProfile, sc.WindowsOptions)...)
+}
+
+// checkPrivileges returns the violations of the given container in case privileged mode is not allowed, i.e.,
+// if it is privileged, it does not disable privilege escalation (allowed by default), it adds capabilities
+// beyond the baseline ones, it unmasks the /proc filesystem or it relaxes its sandboxing.
+func checkPrivileges(container *corev1.Container) []string {
+	sc := container.SecurityContext
+	if sc == nil {
+		sc = &corev1.SecurityContext{}
+	}
+
+	var violations []string
+	if ptr.Deref(sc.Privileged, false) {
+		violations = append(violations, fmt.Sprintf("privileged container %q is not allowed", container.Name))
+	}
+	if ptr.Deref(sc.AllowPrivilegeEscalation, true) {
+		violations = append(violations, fmt.Sprintf("privilege escalation of container %q is not allowed "+
+			"(allowPrivilegeEscalation must be set to false)", container.Name))
+	}
+	if sc.Capabilities != nil {
+		for _, capability := range sc.Capabilities.Add {
+			normalized := corev1.Capability(strings.TrimPrefix(strings.ToUpper(string(capability)), "CAP_"))
+			if !slices.Contains(baselineCapabilities, normalized) {
+				violations = append(violations, fmt.Sprintf("capability %q of container %q is not allowed", capability, container.Name))
+			}
+		}
+	}
+	if ptr.Deref(sc.ProcMount, corev1.DefaultProcMount) != corev1.DefaultProcMount {
+		violations = append(violations, fmt.Sprintf("unmasked proc mount of container %q is not allowed", container.Name))
+	}
+	return append(violations, checkSandbox(fmt.Sprintf("container %q", container.Name),
+		sc.SELinuxOptions, sc.SeccompProfile, sc.AppArmorProfile, sc.WindowsOptions)...)
+}
+
+// checkSandbox returns the violations of the sandboxing options shared by the pod and the container security contexts,
+// i.e., custom SELinux users, roles and types, unconfined seccomp and AppArmor profiles, and Windows host processes.
+func checkSandbox(target string, seLinux *corev1.SELinuxOptions, seccomp *corev1.SeccompProfile,
+	appArmor *corev1.AppArmorProfile, windows *corev1.WindowsSecurityContextOptions) []string {
+	var violations []string
+	if seLinux != nil && (seLinux.User != "" || seLinux.Role != "" || !slices.Contains(baselineSELinuxTypes, seLinux.Type)) {
+		violations = append(violations, fmt.Sprintf("SELinux options of %s are not allowed", target))
+	}
+	if seccomp != nil && seccomp.Type == corev1.SeccompProfileTypeUnconfined {
+		violations = append(violations, fmt.Sprintf("unconfined seccomp profile of %s is not allowed", target))
+	}
+	if appArmor != nil && appArmor.Type == corev1.AppArmorProfileTypeUnconfined {
+		violations = append(violations, fmt.Sprintf("unconfined AppArmor profile of %s is not allowed", target))
+	}
+	if windows != nil && ptr.Deref(windows.HostProcess, false) {
+		violations = append(violations, fmt.Sprintf("Windows host process of %s is not allowed", target))
+	}
+	return violations
+}
+
+// imageRegistryAllowed returns whether the given image is pulled from one of the allowed registries
+// (or repository path prefixes). An empty list of registries allows any image.
+func imageRegistryAllowed(image string, registries []string) bool {
+	if len(registries) == 0 {
+		return true
+	}
+
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return false
+	}
+
+	name := named.Name()
+	for _, registry := range registries {
+		registry = strings.TrimSuffix(registry, "/")
+		if name == registry || strings.HasPrefix(name, registry+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTenantPolicy checks whether the given ShadowPod complies with the TenantPolicy of the origin cluster, if any.
+func (spv *Validator) checkTenantPolicy(ctx context.Context, shadowpod *offloadingv1beta1.ShadowPod, clusterID string) error {
+	policy, err := getTenantPolicy(ctx, spv.client, liqov1beta1.ClusterID(clusterID))
+	if err != nil || policy == nil {
+		return err
+	}
+	return checkTenantPolicy(policy, &shadowpod.Spec.Pod)
+}
+
+// deniedByTenantPolicy returns the admission response denying a ShadowPod violating the TenantPolicy, whose reason
+// allows the consumer cluster to reflect the denial to the status of the local pod.
+func deniedByTenantPolicy(err error) admission.Response {
+	resp := admission.Denied(err.Error())
+	resp.Result.Reason = offloadingv1beta1.TenantPolicyDeniedReason
+	return resp
+}
+
+// applyTenantPolicyDefaults mutates the pod spec according to the defaults configured in the given policy.
+func applyTenantPolicyDefaults(policy *offloadingv1beta1.TenantPolicy, spec *corev1.PodSpec) {
+	if spec.RuntimeClassName == nil && policy.Spec.DefaultRuntimeClass != nil {
+		spec.RuntimeClassName = ptr.To(*policy.Spec.DefaultRuntimeClass)
+	}
+}
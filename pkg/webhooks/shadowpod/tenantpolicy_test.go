@@ -0,0 +1,121 @@
+// Copyright 2019-2026 The Liqo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadowpod
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+)
+
+var _ = Describe("TenantPolicy", func() {
+	DescribeTable("Checking whether an image is pulled from an allowed registry",
+		func(image string, registries []string, expected bool) {
+			Expect(imageRegistryAllowed(image, registries)).To(Equal(expected))
+		},
+		Entry("no registry restriction", "nginx", nil, true),
+		Entry("implicit docker hub registry", "nginx:1.25", []string{"docker.io"}, true),
+		Entry("matching registry", "ghcr.io/liqotech/liqo:v1.0.0", []string{"quay.io", "ghcr.io"}, true),
+		Entry("matching repository prefix", "ghcr.io/liqotech/liqo@sha256:"+
+			"0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", []string{"ghcr.io/liqotech/"}, true),
+		Entry("non-matching repository prefix", "ghcr.io/liqotech-fake/liqo", []string{"ghcr.io/liqotech"}, false),
+		Entry("non-matching registry", "nginx", []string{"ghcr.io"}, false),
+		Entry("invalid image", "INVALID::image", []string{"docker.io"}, false),
+	)
+
+	DescribeTable("Checking the privileges of the containers",
+		func(allowPrivileged bool, sc *corev1.SecurityContext, expected types.GomegaMatcher) {
+			policy := &offloadingv1beta1.TenantPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec:       offloadingv1beta1.TenantPolicySpec{AllowPrivileged: allowPrivileged},
+			}
+			spec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "container", Image: "nginx", SecurityContext: sc}}}
+			Expect(checkTenantPolicy(policy, spec)).To(expected)
+		},
+		Entry("no security context", false, nil,
+			MatchError(ContainSubstring(`privilege escalation of container "container" is not allowed`))),
+		Entry("unprivileged container", false, &corev1.SecurityContext{
+			Privileged: ptr.To(false), AllowPrivilegeEscalation: ptr.To(false),
+		}, Succeed()),
+		Entry("privileged container", false, &corev1.SecurityContext{Privileged: ptr.To(true)},
+			MatchError(ContainSubstring(`privileged container "container" is not allowed`))),
+		Entry("privilege escalation", false, &corev1.SecurityContext{AllowPrivilegeEscalation: ptr.To(true)},
+			MatchError(ContainSubstring(`privilege escalation of container "container" is not allowed`))),
+		Entry("privilege escalation not disabled", false, &corev1.SecurityContext{Privileged: ptr.To(false)},
+			MatchError(ContainSubstring(`privilege escalation of container "container" is not allowed`))),
+		Entry("baseline capabilities", false, &corev1.SecurityContext{AllowPrivilegeEscalation: ptr.To(false), Capabilities: &corev1.Capabilities{
+			Add: []corev1.Capability{"NET_BIND_SERVICE", "CAP_CHOWN"}, Drop: []corev1.Capability{"ALL"},
+		}}, Succeed()),
+		Entry("SYS_ADMIN capability", false, &corev1.SecurityContext{Capabilities: &corev1.Capabilities{
+			Add: []corev1.Capability{"SYS_ADMIN"},
+		}}, MatchError(ContainSubstring(`capability "SYS_ADMIN" of container "container" is not allowed`))),
+		Entry("NET_ADMIN capability", false, &corev1.SecurityContext{Capabilities: &corev1.Capabilities{
+			Add: []corev1.Capability{"CAP_NET_ADMIN"},
+		}}, MatchError(ContainSubstring(`capability "CAP_NET_ADMIN" of container "container" is not allowed`))),
+		Entry("ALL capabilities", false, &corev1.SecurityContext{Capabilities: &corev1.Capabilities{
+			Add: []corev1.Capability{"all"},
+		}}, MatchError(ContainSubstring(`capability "all" of container "container" is not allowed`))),
+		Entry("unmasked proc mount", false, &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false), ProcMount: ptr.To(corev1.UnmaskedProcMount),
+		}, MatchError(ContainSubstring(`unmasked proc mount of container "container" is not allowed`))),
+		Entry("unconfined seccomp profile", false, &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false), SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+		}, MatchError(ContainSubstring(`unconfined seccomp profile of container "container" is not allowed`))),
+		Entry("privileged container allowed by the policy", true, &corev1.SecurityContext{
+			Privileged: ptr.To(true), AllowPrivilegeEscalation: ptr.To(true),
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}},
+		}, Succeed()),
+	)
+
+	DescribeTable("Checking the host access and the pod security context",
+		func(policySpec offloadingv1beta1.TenantPolicySpec, mutate func(spec *corev1.PodSpec), expected types.GomegaMatcher) {
+			policy := &offloadingv1beta1.TenantPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}, Spec: policySpec}
+			spec := &corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "container", Image: "nginx", SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: ptr.To(false)},
+			}}}
+			mutate(spec)
+			Expect(checkTenantPolicy(policy, spec)).To(expected)
+		},
+		Entry("container port", offloadingv1beta1.TenantPolicySpec{}, func(spec *corev1.PodSpec) {
+			spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 8080}}
+		}, Succeed()),
+		Entry("host port", offloadingv1beta1.TenantPolicySpec{}, func(spec *corev1.PodSpec) {
+			spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 8080, HostPort: 80}}
+		}, MatchError(ContainSubstring(`host port 80 of container "container" is not allowed`))),
+		Entry("host port allowed by the policy", offloadingv1beta1.TenantPolicySpec{AllowHostNamespaces: true}, func(spec *corev1.PodSpec) {
+			spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 8080, HostPort: 80}}
+		}, Succeed()),
+		Entry("baseline sysctls", offloadingv1beta1.TenantPolicySpec{}, func(spec *corev1.PodSpec) {
+			spec.SecurityContext = &corev1.PodSecurityContext{Sysctls: []corev1.Sysctl{{Name: "net.ipv4.tcp_syncookies", Value: "1"}}}
+		}, Succeed()),
+		Entry("unsafe sysctls", offloadingv1beta1.TenantPolicySpec{}, func(spec *corev1.PodSpec) {
+			spec.SecurityContext = &corev1.PodSecurityContext{Sysctls: []corev1.Sysctl{{Name: "kernel.msgmax", Value: "65536"}}}
+		}, MatchError(ContainSubstring(`sysctl "kernel.msgmax" is not allowed`))),
+		Entry("custom SELinux type", offloadingv1beta1.TenantPolicySpec{}, func(spec *corev1.PodSpec) {
+			spec.SecurityContext = &corev1.PodSecurityContext{SELinuxOptions: &corev1.SELinuxOptions{Type: "spc_t"}}
+		}, MatchError(ContainSubstring("SELinux options of pod are not allowed"))),
+		Entry("unconfined AppArmor profile", offloadingv1beta1.TenantPolicySpec{}, func(spec *corev1.PodSpec) {
+			spec.SecurityContext = &corev1.PodSecurityContext{AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeUnconfined}}
+		}, MatchError(ContainSubstring("unconfined AppArmor profile of pod is not allowed"))),
+		Entry("pod security context allowed by the policy", offloadingv1beta1.TenantPolicySpec{AllowPrivileged: true}, func(spec *corev1.PodSpec) {
+			spec.SecurityContext = &corev1.PodSecurityContext{Sysctls: []corev1.Sysctl{{Name: "kernel.msgmax", Value: "65536"}}}
+		}, Succeed()),
+	)
+})
@@ -29,7 +29,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/audit"
 	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/utils/getters"
 	pod "github.com/liqotech/liqo/pkg/utils/pod"
@@ -46,6 +48,7 @@ import (
 type Validator struct {
 	client                   client.Client
 	PeeringCache             *peeringCache
+	namespaceQuotaCache      *namespaceQuotaCache
 	decoder                  admission.Decoder
 	enableResourceValidation bool
 }
@@ -55,6 +58,7 @@ func NewValidator(c client.Client, enableResourceValidation bool) *Validator {
 	return &Validator{
 		client:                   c,
 		PeeringCache:             &peeringCache{ready: false},
+		namespaceQuotaCache:      &namespaceQuotaCache{},
 		enableResourceValidation: enableResourceValidation,
 		decoder:                  admission.NewDecoder(runtime.NewScheme()),
 	}
@@ -73,9 +77,9 @@ func (spv *Validator) Handle(ctx context.Context, req admission.Request) admissi
 
 	switch req.Operation {
 	case admissionv1.Create:
-		return spv.HandleCreate(ctx, &req)
+		return spv.recordAuditEvent(&req, audit.ActionShadowPodCreate, spv.HandleCreate(ctx, &req))
 	case admissionv1.Delete:
-		return spv.HandleDelete(ctx, &req)
+		return spv.recordAuditEvent(&req, audit.ActionShadowPodDelete, spv.HandleDelete(ctx, &req))
 	case admissionv1.Update:
 		return spv.HandleUpdate(ctx, &req)
 	default:
@@ -108,22 +112,32 @@ func (spv *Validator) HandleCreate(ctx context.Context, req *admission.Request)
 		return admission.Denied(err.Error())
 	}
 
+	if err := spv.checkTenantPolicy(ctx, shadowpod, clusterID); err != nil {
+		klog.Warningf("ShadowPod %q rejected: %v", klog.KObj(shadowpod), err)
+		return deniedByTenantPolicy(err)
+	}
+
+	if err := spv.checkOffloadedNamespaceQuotas(ctx, shadowpod, *req.DryRun); err != nil {
+		klog.Warningf("ShadowPod %q rejected: %v", klog.KObj(shadowpod), err)
+		return admission.Denied(err.Error())
+	}
+
 	if !spv.enableResourceValidation {
 		return admission.Allowed("")
 	}
 
 	if shadowpod.Labels == nil {
-		return admission.Denied("missing creator label")
+		return spv.deniedCreation(shadowpod, *req.DryRun, "missing creator label")
 	}
 	creatorName, found := shadowpod.Labels[consts.CreatorLabelKey]
 	if !found {
-		return admission.Denied("missing creator label")
+		return spv.deniedCreation(shadowpod, *req.DryRun, "missing creator label")
 	}
 
 	quota, err := getters.GetQuotaByUser(ctx, spv.client, creatorName)
 	if err != nil {
 		klog.Warningf("Failed getting quota for user %s: %v", creatorName, err)
-		return admission.Denied("failed getting quota")
+		return spv.deniedCreation(shadowpod, *req.DryRun, "failed getting quota")
 	}
 
 	klog.V(5).Infof("Quota found for user %q with %s",
@@ -131,7 +145,7 @@ func (spv *Validator) HandleCreate(ctx context.Context, req *admission.Request)
 
 	if quota.Spec.Cordoned != nil && *quota.Spec.Cordoned {
 		klog.Warningf("User %q is cordoned", creatorName)
-		return admission.Denied("user is cordoned")
+		return spv.deniedCreation(shadowpod, *req.DryRun, "user is cordoned")
 	}
 
 	peeringInfo := spv.PeeringCache.getOrCreatePeeringInfo(creatorName, quota.Spec.Resources)
@@ -139,7 +153,7 @@ func (spv *Validator) HandleCreate(ctx context.Context, req *admission.Request)
 	err = peeringInfo.testAndUpdateCreation(ctx, spv.client, shadowpod, quota.Spec.LimitsEnforcement, *req.DryRun)
 	if err != nil {
 		klog.Warning(err)
-		return admission.Denied(err.Error())
+		return spv.deniedCreation(shadowpod, *req.DryRun, err.Error())
 	}
 
 	return admission.Allowed("")
@@ -176,6 +190,12 @@ func (spv *Validator) HandleUpdate(ctx context.Context, req *admission.Request)
 		return admission.Denied("shadopow Cluster ID label is changed")
 	}
 
+	// The images may be mutated, hence the compliance with the TenantPolicy needs to be verified again.
+	if err := spv.checkTenantPolicy(ctx, shadowpod, clusterID); err != nil {
+		klog.Warningf("ShadowPod %q update rejected: %v", klog.KObj(shadowpod), err)
+		return deniedByTenantPolicy(err)
+	}
+
 	if pod.CheckShadowPodUpdate(&shadowpod.Spec.Pod, &oldShadowpod.Spec.Pod) {
 		return admission.Allowed("")
 	}
@@ -196,6 +216,8 @@ func (spv *Validator) HandleDelete(ctx context.Context, req *admission.Request)
 		return admission.Denied("missing creator label")
 	}
 
+	spv.updateOffloadedNamespaceQuotasDeletion(shadowpod, *req.DryRun)
+
 	if !spv.enableResourceValidation {
 		return admission.Allowed("")
 	}
@@ -222,6 +244,12 @@ func (spv *Validator) HandleDelete(ctx context.Context, req *admission.Request)
 	return admission.Allowed("")
 }
 
+// deniedCreation denies the creation of the given ShadowPod, releasing the resources reserved for the OffloadedNamespaceQuotas.
+func (spv *Validator) deniedCreation(shadowpod *offloadingv1beta1.ShadowPod, dryRun bool, reason string) admission.Response {
+	spv.releaseOffloadedNamespaceQuotas(shadowpod, dryRun)
+	return admission.Denied(reason)
+}
+
 func (spv *Validator) validateShadowPodClusterID(ctx context.Context, ns, spClusterID string) (int32, error) {
 	// Get ShadowPod Namespace
 	namespace := &corev1.Namespace{}
@@ -265,12 +293,12 @@ func NewMutator(c client.Client) *webhook.Admission {
 // Handle is the function in charge of handling the webhook mutating request about the creation, update and deletion of shadowpods.
 //
 //nolint:gocritic // the signature of this method is imposed by controller runtime.
-func (spm *Mutator) Handle(_ context.Context, req admission.Request) admission.Response {
+func (spm *Mutator) Handle(ctx context.Context, req admission.Request) admission.Response {
 	klog.V(4).Infof("Operation: %s", req.Operation)
 
 	switch req.Operation {
 	case admissionv1.Create:
-		return spm.HandleCreate(&req)
+		return spm.HandleCreate(ctx, &req)
 	case admissionv1.Delete:
 		return spm.HandleDelete()
 	case admissionv1.Update:
@@ -281,7 +309,7 @@ func (spm *Mutator) Handle(_ context.Context, req admission.Request) admission.R
 }
 
 // HandleCreate is the function in charge of handling Creation requests.
-func (spm *Mutator) HandleCreate(req *admission.Request) admission.Response {
+func (spm *Mutator) HandleCreate(ctx context.Context, req *admission.Request) admission.Response {
 	sp, err := decodeShadowPod(spm.decoder, req.Object)
 	if err != nil {
 		klog.Errorf("Failed decoding shadow pod: %v", err)
@@ -299,6 +327,19 @@ func (spm *Mutator) HandleCreate(req *admission.Request) admission.Response {
 	}
 	sp.Labels[consts.CreatorLabelKey] = creatorName
 
+	// Apply the defaults configured in the TenantPolicy of the origin cluster, if any.
+	// Missing origin cluster labels are not handled here, as the request is rejected by the validating webhook.
+	if clusterID, found := sp.Labels[forge.LiqoOriginClusterIDKey]; found {
+		policy, err := getTenantPolicy(ctx, spm.client, liqov1beta1.ClusterID(clusterID))
+		if err != nil {
+			klog.Errorf("Failed retrieving the TenantPolicy for ShadowPod %q: %v", klog.KObj(sp), err)
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if policy != nil {
+			applyTenantPolicyDefaults(policy, &sp.Spec.Pod)
+		}
+	}
+
 	marshaledShadowPod, err := json.Marshal(sp)
 	if err != nil {
 		klog.Errorf("Failed marshaling ShadowPod object: %v", err)
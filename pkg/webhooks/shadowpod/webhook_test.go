@@ -15,21 +15,32 @@
 package shadowpod
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	authv1beta1 "github.com/liqotech/liqo/apis/authentication/v1beta1"
 	liqov1beta1 "github.com/liqotech/liqo/apis/core/v1beta1"
 	offloadingv1beta1 "github.com/liqotech/liqo/apis/offloading/v1beta1"
+	"github.com/liqotech/liqo/pkg/audit"
+	"github.com/liqotech/liqo/pkg/consts"
 	"github.com/liqotech/liqo/pkg/utils/testutil"
 )
 
@@ -107,6 +118,270 @@ var _ = Describe("Validating webhook", func() {
 		})
 	})
 
+	Describe("Recording the audit events", func() {
+		var (
+			buffer *bytes.Buffer
+			events []*audit.Event
+		)
+
+		BeforeEach(func() {
+			buffer = &bytes.Buffer{}
+			audit.Init("liqo-webhook", audit.NewWriterSink(buffer))
+			DeferCleanup(func() { audit.Init("") })
+		})
+
+		JustBeforeEach(func() {
+			response = spValidator.Handle(ctx, request)
+
+			events = nil
+			for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+				if event, ok := audit.ParseEvent([]byte(line)); ok {
+					events = append(events, event)
+				}
+			}
+		})
+
+		When("the creation of a shadowpod is allowed", func() {
+			BeforeEach(func() {
+				fakeNewShadowPod = forgeShadowPodWithClusterID(clusterID, userName, testNamespace)
+				request = forgeRequest(admissionv1.Create, fakeNewShadowPod, nil)
+				request.UserInfo = authenticationv1.UserInfo{Username: userName, Groups: []string{"liqo-consumers", "system:authenticated"}}
+			})
+			It("should record an allowed event", func() {
+				Expect(response.Allowed).To(BeTrue())
+				Expect(events).To(HaveLen(1))
+				Expect(events[0].Action).To(Equal(audit.ActionShadowPodCreate))
+				Expect(events[0].Outcome).To(Equal(audit.OutcomeAllowed))
+				Expect(events[0].ClusterID).To(BeEquivalentTo(clusterID))
+				Expect(events[0].Resource).To(Equal(offloadingv1beta1.ShadowPodResource))
+				Expect(events[0].Name).To(Equal(fakeNewShadowPod.Name))
+				Expect(events[0].User).To(Equal(userName))
+				Expect(events[0].Groups).To(ConsistOf("liqo-consumers", "system:authenticated"))
+			})
+		})
+
+		When("the creation of a shadowpod is denied", func() {
+			BeforeEach(func() {
+				fakeNewShadowPod = forgeShadowPodWithClusterID(clusterIDInvalid, userNameInvalid, testNamespace)
+				request = forgeRequest(admissionv1.Create, fakeNewShadowPod, nil)
+			})
+			It("should record a denied event, with the cluster ID claimed by the shadowpod", func() {
+				Expect(response.Allowed).To(BeFalse())
+				Expect(events).To(HaveLen(1))
+				Expect(events[0].Action).To(Equal(audit.ActionShadowPodCreate))
+				Expect(events[0].Outcome).To(Equal(audit.OutcomeDenied))
+				Expect(events[0].ClusterID).To(BeEquivalentTo(clusterIDInvalid))
+				Expect(events[0].Message).ToNot(BeEmpty())
+			})
+		})
+
+		When("a shadowpod is deleted", func() {
+			BeforeEach(func() {
+				fakeNewShadowPod = forgeShadowPodWithClusterID(clusterID, userName, testNamespace)
+				request = forgeRequest(admissionv1.Delete, nil, fakeNewShadowPod)
+			})
+			It("should record an allowed event", func() {
+				Expect(response.Allowed).To(BeTrue())
+				Expect(events).To(HaveLen(1))
+				Expect(events[0].Action).To(Equal(audit.ActionShadowPodDelete))
+				Expect(events[0].Outcome).To(Equal(audit.OutcomeAllowed))
+				Expect(events[0].ClusterID).To(BeEquivalentTo(clusterID))
+			})
+		})
+
+		When("the request is a dry-run", func() {
+			BeforeEach(func() {
+				fakeNewShadowPod = forgeShadowPodWithClusterID(clusterID, userName, testNamespace)
+				request = forgeRequest(admissionv1.Create, fakeNewShadowPod, nil)
+				request.DryRun = ptr.To(true)
+			})
+			It("should not record any event", func() {
+				Expect(response.Allowed).To(BeTrue())
+				Expect(events).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Validating ShadowPod against OffloadedNamespaceQuotas", func() {
+		var onq *offloadingv1beta1.OffloadedNamespaceQuota
+
+		BeforeEach(func() {
+			onq = &offloadingv1beta1.OffloadedNamespaceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: testNamespace},
+				Spec: offloadingv1beta1.OffloadedNamespaceQuotaSpec{
+					Hard: corev1.ResourceList{corev1.ResourceCPU: *resource.NewQuantity(int64(resourceCPU), resource.DecimalSI)},
+				},
+			}
+			// An existing ShadowPod, consuming one fourth of the available CPU.
+			Expect(spvClient.Create(ctx, forgeShadowPod(testShadowPodName2, testNamespace, string(testShadowPodUID2), userName))).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			Expect(spvClient.Create(ctx, onq)).To(Succeed())
+			response = spValidator.Handle(ctx, request)
+		})
+
+		When("the resources consumed across clusters are below the hard limits", func() {
+			BeforeEach(func() {
+				onq.Status.Used = corev1.ResourceList{corev1.ResourceCPU: *resource.NewQuantity(int64(resourceCPU/4), resource.DecimalSI)}
+				containers = []containerResource{{cpu: int64(resourceCPU / 4), memory: int64(resourceMemory)}}
+				request = forgeRequest(admissionv1.Create, forgeShadowPodWithResourceRequests(containers, nil), nil)
+			})
+			It("should admit the request", func() {
+				Expect(response.Allowed).To(BeTrue())
+			})
+		})
+
+		When("the resources consumed across clusters would exceed the hard limits", func() {
+			BeforeEach(func() {
+				onq.Status.Used = corev1.ResourceList{corev1.ResourceCPU: *resource.NewQuantity(int64(resourceCPU/2), resource.DecimalSI)}
+				containers = []containerResource{{cpu: int64(resourceCPU / 2), memory: int64(resourceMemory)}}
+				request = forgeRequest(admissionv1.Create, forgeShadowPodWithResourceRequests(containers, nil), nil)
+			})
+			It("should return a forbidden response", func() {
+				Expect(response.Allowed).To(BeFalse())
+				Expect(response.Result.Code).To(BeNumerically("==", http.StatusForbidden))
+				Expect(response.Result.Message).To(ContainSubstring("cpu"))
+			})
+		})
+	})
+
+	Describe("Tracking the OffloadedNamespaceQuota usage", func() {
+		forgeCreation := func(index int, dryRun bool) admission.Request {
+			sp := forgeShadowPodWithResourceRequests([]containerResource{{cpu: int64(resourceCPU / 4), memory: int64(resourceMemory)}}, nil)
+			sp.Name = fmt.Sprintf("concurrent-shadowpod-%d", index)
+			sp.UID = types.UID(fmt.Sprintf("concurrent-shadowpod-uid-%d", index))
+			req := forgeRequest(admissionv1.Create, sp, nil)
+			req.DryRun = ptr.To(dryRun)
+			return req
+		}
+
+		BeforeEach(func() {
+			// An existing ShadowPod, consuming one fourth of the available CPU.
+			Expect(spvClient.Create(ctx, forgeShadowPod(testShadowPodName2, testNamespace, string(testShadowPodUID2), userName))).To(Succeed())
+			Expect(spvClient.Create(ctx, &offloadingv1beta1.OffloadedNamespaceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: testNamespace},
+				Spec: offloadingv1beta1.OffloadedNamespaceQuotaSpec{
+					Hard: corev1.ResourceList{corev1.ResourceCPU: *resource.NewQuantity(int64(resourceCPU), resource.DecimalSI)},
+				},
+			})).To(Succeed())
+		})
+
+		When("multiple ShadowPods are created concurrently", func() {
+			It("should admit only the ones fitting the hard limits", func() {
+				var wg sync.WaitGroup
+				var allowed atomic.Int32
+				for i := range 10 {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						if spValidator.Handle(ctx, forgeCreation(i, false)).Allowed {
+							allowed.Add(1)
+						}
+					}()
+				}
+				wg.Wait()
+				Expect(allowed.Load()).To(BeNumerically("==", 3))
+			})
+		})
+
+		When("the creations are in dry-run mode", func() {
+			It("should not reserve any resource", func() {
+				for i := range 10 {
+					Expect(spValidator.Handle(ctx, forgeCreation(i, true)).Allowed).To(BeTrue())
+				}
+				Expect(spValidator.Handle(ctx, forgeCreation(0, false)).Allowed).To(BeTrue())
+			})
+		})
+
+		When("a ShadowPod is deleted", func() {
+			var creation admission.Request
+
+			BeforeEach(func() {
+				for i := range 3 {
+					Expect(spValidator.Handle(ctx, forgeCreation(i, false)).Allowed).To(BeTrue())
+				}
+				Expect(spValidator.Handle(ctx, forgeCreation(3, false)).Allowed).To(BeFalse())
+				creation = forgeCreation(0, false)
+			})
+
+			It("should release its resources, unless in dry-run mode", func() {
+				sp, err := decodeShadowPod(spValidator.decoder, creation.Object)
+				Expect(err).ToNot(HaveOccurred())
+
+				deletion := forgeRequest(admissionv1.Delete, nil, sp)
+				deletion.DryRun = ptr.To(true)
+				Expect(spValidator.Handle(ctx, deletion).Allowed).To(BeTrue())
+				Expect(spValidator.Handle(ctx, forgeCreation(3, false)).Allowed).To(BeFalse())
+
+				deletion.DryRun = ptr.To(false)
+				Expect(spValidator.Handle(ctx, deletion).Allowed).To(BeTrue())
+				Expect(spValidator.Handle(ctx, forgeCreation(3, false)).Allowed).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Validating ShadowPod against TenantPolicies", func() {
+		BeforeEach(func() {
+			Expect(spvClient.Create(ctx, &authv1beta1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: tenantNamespace,
+					Labels: map[string]string{consts.RemoteClusterID: string(clusterID)}},
+				Spec: authv1beta1.TenantSpec{ClusterID: clusterID, TenantPolicyRef: &corev1.LocalObjectReference{Name: "restricted"}},
+			})).To(Succeed())
+			Expect(spvClient.Create(ctx, &offloadingv1beta1.TenantPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "restricted"},
+				Spec: offloadingv1beta1.TenantPolicySpec{
+					AllowedImageRegistries: []string{"ghcr.io/liqotech"},
+					DefaultRuntimeClass:    ptr.To("gvisor"),
+				},
+			})).To(Succeed())
+
+			fakeNewShadowPod = forgeShadowPodWithClusterID(clusterID, userName, testNamespace)
+			fakeNewShadowPod.Spec.Pod.Containers = []corev1.Container{{
+				Name: "test-container", Image: "ghcr.io/liqotech/test:latest",
+				SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: ptr.To(false)},
+			}}
+		})
+
+		JustBeforeEach(func() {
+			request = forgeRequest(admissionv1.Create, fakeNewShadowPod, nil)
+			response = spValidator.Handle(ctx, request)
+		})
+
+		When("the shadowpod complies with the policy", func() {
+			It("should admit the request", func() {
+				Expect(response.Allowed).To(BeTrue())
+			})
+		})
+
+		When("the shadowpod violates the policy", func() {
+			BeforeEach(func() {
+				fakeNewShadowPod.Spec.Pod.HostNetwork = true
+				fakeNewShadowPod.Spec.Pod.Containers[0].Image = "nginx"
+				fakeNewShadowPod.Spec.Pod.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+			})
+			It("should return a forbidden response, detailing all the violations", func() {
+				Expect(response.Allowed).To(BeFalse())
+				Expect(response.Result.Code).To(BeNumerically("==", http.StatusForbidden))
+				Expect(response.Result.Reason).To(Equal(offloadingv1beta1.TenantPolicyDeniedReason))
+				Expect(response.Result.Message).To(ContainSubstring(`TenantPolicy "restricted"`))
+				Expect(response.Result.Message).To(ContainSubstring("host network"))
+				Expect(response.Result.Message).To(ContainSubstring("privileged container"))
+				Expect(response.Result.Message).To(ContainSubstring(`image "nginx"`))
+			})
+		})
+
+		When("mutating the shadowpod", func() {
+			It("should apply the default runtime class", func() {
+				request.UserInfo.Username = userName
+				response = NewMutator(spvClient).Handle(ctx, request)
+				Expect(response.Allowed).To(BeTrue())
+				Expect(response.Patches).To(ContainElement(HaveField("Path", "/spec/pod/runtimeClassName")))
+			})
+		})
+	})
+
 	Describe("Handle creation ShadowPod with resource validation", func() {
 		JustBeforeEach(func() {
 			response = spValidatorWithResources.Handle(ctx, request)
@@ -30,7 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	liqoconst "github.com/liqotech/liqo/pkg/consts"
-	liqoctlmove "github.com/liqotech/liqo/pkg/liqoctl/move"
+	"github.com/liqotech/liqo/pkg/volumemover"
 	"github.com/liqotech/liqo/test/e2e/testconsts"
 	"github.com/liqotech/liqo/test/e2e/testutils/config"
 	"github.com/liqotech/liqo/test/e2e/testutils/storage"
@@ -159,8 +159,8 @@ var _ = Describe("Liqo E2E", func() {
 				"--containers-cpu-limits", "500m", "--containers-ram-limits", "500Mi"}
 			dockerProxy, ok := os.LookupEnv("DOCKER_PROXY")
 			if ok {
-				args = append(args, "--restic-server-image", dockerProxy+"/"+liqoctlmove.DefaultResticServerImage)
-				args = append(args, "--restic-image", dockerProxy+"/"+liqoctlmove.DefaultResticImage)
+				args = append(args, "--restic-server-image", dockerProxy+"/"+volumemover.DefaultResticServerImage)
+				args = append(args, "--restic-image", dockerProxy+"/"+volumemover.DefaultResticImage)
 			}
 			Expect(util.ExecLiqoctl(testContext.Clusters[0].KubeconfigPath, args, GinkgoWriter)).To(Succeed())
 